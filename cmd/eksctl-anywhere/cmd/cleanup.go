@@ -14,6 +14,9 @@ func cleanup(deps *dependencies.Dependencies, commandErr *error) {
 	if *commandErr == nil {
 		deps.Writer.CleanUpTemp()
 	}
+	if deps.Git != nil {
+		deps.Git.CleanUpWorkspace(*commandErr)
+	}
 }
 
 func close(ctx context.Context, closer types.Closer) {
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Migrate resources",
+	Long:  "Use eksctl anywhere migrate to migrate resources to their current version, such as a GitOpsConfig to a FluxConfig",
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+}
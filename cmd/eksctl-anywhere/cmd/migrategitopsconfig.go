@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type migrateGitOpsConfigOptions struct {
+	clusterOptions
+	wConfig string
+}
+
+var mgc = &migrateGitOpsConfigOptions{}
+
+var migrateGitOpsConfigCmd = &cobra.Command{
+	Use:          "gitops-config",
+	Short:        "Migrate the GitOpsConfig of a cluster to a FluxConfig",
+	Long:         "This command moves the cluster files in the GitOps repository to the paths of the FluxConfig in the cluster config file, replaces the committed GitOpsConfig by the FluxConfig and updates the path flux reconciles",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := mgc.migrateGitOpsConfig(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to migrate gitops config: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	migrateCmd.AddCommand(migrateGitOpsConfigCmd)
+	applyClusterOptionFlags(migrateGitOpsConfigCmd.Flags(), &mgc.clusterOptions)
+	migrateGitOpsConfigCmd.Flags().StringVarP(&mgc.wConfig, "w-config", "w", "", "Kubeconfig file of the cluster flux runs in")
+
+	if err := migrateGitOpsConfigCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (m *migrateGitOpsConfigOptions) migrateGitOpsConfig(ctx context.Context) error {
+	clusterSpec, err := newClusterSpec(m.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	gitOpsRef := clusterSpec.Cluster.Spec.GitOpsRef
+	if gitOpsRef == nil || gitOpsRef.Kind != v1alpha1.FluxConfigKind {
+		return fmt.Errorf("cluster %s must reference the FluxConfig to migrate to", clusterSpec.Cluster.Name)
+	}
+
+	cliConfig := buildCliConfig(clusterSpec)
+	dirs, err := m.directoriesToMount(clusterSpec, cliConfig)
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(dirs...).
+		WithCliConfig(cliConfig).
+		WithGitOpsFlux(clusterSpec.Cluster, clusterSpec.FluxConfig, cliConfig).
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	managementCluster := &types.Cluster{
+		Name:           clusterSpec.Cluster.Name,
+		KubeconfigFile: getKubeconfigPath(clusterSpec.Cluster.Name, m.wConfig),
+	}
+	if clusterSpec.ManagementCluster != nil {
+		managementCluster = clusterSpec.ManagementCluster
+	}
+
+	gitOpsConfig, err := deps.Kubectl.GetEksaGitOpsConfig(ctx, gitOpsRef.Name, managementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace)
+	if err != nil {
+		return fmt.Errorf("getting GitOpsConfig %s: %v", gitOpsRef.Name, err)
+	}
+
+	logger.Info("Migrating GitOpsConfig to FluxConfig", "cluster", clusterSpec.Cluster.Name, "name", gitOpsRef.Name)
+	if err := deps.GitOpsFlux.MigrateGitOpsConfig(ctx, managementCluster, clusterSpec, gitOpsConfig); err != nil {
+		return err
+	}
+	logger.MarkSuccess("GitOpsConfig migrated to FluxConfig")
+
+	return nil
+}
@@ -2,8 +2,10 @@ package cmd
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -141,10 +143,166 @@ func buildCliConfig(clusterSpec *cluster.Spec) *config.CliConfig {
 		cliConfig.GitPrivateKeyFile = os.Getenv(config.EksaGitPrivateKeyTokenEnv)
 		cliConfig.GitKnownHostsFile = os.Getenv(config.EksaGitKnownHostsFileEnv)
 	}
+	if depth, ok := os.LookupEnv(config.EksaGitCloneDepthEnv); ok {
+		d, err := strconv.Atoi(depth)
+		if err != nil || d < 0 {
+			logger.Info("Warning: ignoring invalid git clone depth, the full repository history will be cloned", "env", config.EksaGitCloneDepthEnv, "value", depth)
+		} else {
+			cliConfig.GitCloneDepth = d
+		}
+	}
+	if limit, ok := os.LookupEnv(config.EksaGitProviderRateLimitEnv); ok {
+		l, err := strconv.Atoi(limit)
+		if err != nil || l < 0 {
+			logger.Info("Warning: ignoring invalid git provider rate limit, provider requests won't be limited", "env", config.EksaGitProviderRateLimitEnv, "value", limit)
+		} else {
+			cliConfig.GitProviderRequestsPerHour = l
+		}
+	}
+	if sparse, ok := os.LookupEnv(config.EksaGitSparseCheckoutEnv); ok {
+		enabled, err := strconv.ParseBool(sparse)
+		if err != nil {
+			logger.Info("Warning: ignoring invalid git sparse checkout setting, the full repository will be checked out", "env", config.EksaGitSparseCheckoutEnv, "value", sparse)
+		}
+		cliConfig.GitSparseCheckout = enabled
+	}
+	if scoped, ok := os.LookupEnv(config.EksaGitWorkspaceClusterScopedEnv); ok {
+		enabled, err := strconv.ParseBool(scoped)
+		if err != nil {
+			logger.Info("Warning: ignoring invalid git workspace cluster scoped setting, the workspace won't be scoped by cluster", "env", config.EksaGitWorkspaceClusterScopedEnv, "value", scoped)
+		}
+		cliConfig.GitWorkspaceClusterScoped = enabled
+	}
+	if fallback, ok := os.LookupEnv(config.EksaGitOpsPullRequestFallbackEnv); ok {
+		enabled, err := strconv.ParseBool(fallback)
+		if err != nil {
+			logger.Info("Warning: ignoring invalid gitops pull request fallback setting, protected branches won't be pushed to", "env", config.EksaGitOpsPullRequestFallbackEnv, "value", fallback)
+		}
+		cliConfig.GitOpsPullRequestFallback = enabled
+	}
+	cliConfig.GitOpsPullRequestBranchPrefix = os.Getenv(config.EksaGitOpsPullRequestBranchPrefixEnv)
+	if staging, ok := os.LookupEnv(config.EksaGitOpsStagingBranchEnv); ok {
+		enabled, err := strconv.ParseBool(staging)
+		if err != nil {
+			logger.Info("Warning: ignoring invalid gitops staging branch setting, the cluster config will be pushed to the sync branch", "env", config.EksaGitOpsStagingBranchEnv, "value", staging)
+		}
+		cliConfig.GitOpsStagingBranch = enabled
+	}
+	if changelog, ok := os.LookupEnv(config.EksaGitOpsChangelogEnv); ok {
+		enabled, err := strconv.ParseBool(changelog)
+		if err != nil {
+			logger.Info("Warning: ignoring invalid gitops changelog setting, the operations won't be recorded in the repository", "env", config.EksaGitOpsChangelogEnv, "value", changelog)
+		}
+		cliConfig.GitOpsChangelog = enabled
+	}
+	if tags, ok := os.LookupEnv(config.EksaGitOpsUpgradeTagsEnv); ok {
+		enabled, err := strconv.ParseBool(tags)
+		if err != nil {
+			logger.Info("Warning: ignoring invalid gitops upgrade tags setting, upgrades won't be tagged", "env", config.EksaGitOpsUpgradeTagsEnv, "value", tags)
+		}
+		cliConfig.GitOpsUpgradeTags = enabled
+	}
+	if revert, ok := os.LookupEnv(config.EksaGitOpsRevertOnBootstrapFailureEnv); ok {
+		enabled, err := strconv.ParseBool(revert)
+		if err != nil {
+			logger.Info("Warning: ignoring invalid gitops revert setting, the cluster config won't be reverted", "env", config.EksaGitOpsRevertOnBootstrapFailureEnv, "value", revert)
+		}
+		cliConfig.GitOpsRevertOnBootstrapFailure = enabled
+	}
+	if verify, ok := os.LookupEnv(config.EksaGitOpsVerifyManifestsEnv); ok {
+		enabled, err := strconv.ParseBool(verify)
+		if err != nil {
+			logger.Info("Warning: ignoring invalid gitops verify manifests setting, the manifests won't be verified", "env", config.EksaGitOpsVerifyManifestsEnv, "value", verify)
+		}
+		cliConfig.GitOpsVerifyManifests = enabled
+	}
+	if verify, ok := os.LookupEnv(config.EksaGitOpsVerifyClusterConfigEnv); ok {
+		enabled, err := strconv.ParseBool(verify)
+		if err != nil {
+			logger.Info("Warning: ignoring invalid gitops verify cluster config setting, the committed cluster config won't be verified", "env", config.EksaGitOpsVerifyClusterConfigEnv, "value", verify)
+		}
+		cliConfig.GitOpsVerifyClusterConfig = enabled
+	}
+	if adopt, ok := os.LookupEnv(config.EksaGitOpsAdoptExistingFluxEnv); ok {
+		enabled, err := strconv.ParseBool(adopt)
+		if err != nil {
+			logger.Info("Warning: ignoring invalid gitops adopt existing flux setting, flux will be bootstrapped", "env", config.EksaGitOpsAdoptExistingFluxEnv, "value", adopt)
+		}
+		cliConfig.GitOpsAdoptExistingFlux = enabled
+	}
+	cliConfig.GitOpsCommitMessageTemplate = os.Getenv(config.EksaGitOpsCommitMessageTemplateEnv)
+	if trailers, ok := os.LookupEnv(config.EksaGitOpsCommitTrailersEnv); ok {
+		for _, t := range strings.Split(trailers, ";") {
+			if t = strings.TrimSpace(t); t != "" {
+				cliConfig.GitOpsCommitTrailers = append(cliConfig.GitOpsCommitTrailers, t)
+			}
+		}
+	}
+	if exclude, ok := os.LookupEnv(config.EksaGitOpsCommitExcludeEnv); ok {
+		for _, p := range strings.Split(exclude, ";") {
+			if p = strings.TrimSpace(p); p != "" {
+				cliConfig.GitOpsCommitExcludePatterns = append(cliConfig.GitOpsCommitExcludePatterns, p)
+			}
+		}
+	}
+	if proxy, ok := os.LookupEnv(config.EksaGitProxyEnv); ok {
+		if u, err := url.Parse(proxy); err != nil || u.Host == "" {
+			logger.Info("Warning: ignoring invalid git proxy url", "env", config.EksaGitProxyEnv, "value", proxy)
+		} else {
+			cliConfig.GitProxy = proxy
+		}
+	}
+	cliConfig.GitOpsReconcileTimeout = durationFromEnv(config.EksaGitOpsReconcileTimeoutEnv, "gitops reconcile timeout, flux reconciliation won't be waited for")
+	cliConfig.GitOpsControllersRolloutTimeout = durationFromEnv(config.EksaGitOpsControllersRolloutTimeoutEnv, "gitops controllers rollout timeout, the default is used")
+	cliConfig.GitOpsUpdateSquashWindow = durationFromEnv(config.EksaGitOpsUpdateSquashWindowEnv, "gitops update squash window, updates won't be squashed")
+	cliConfig.GitOpsRetryInitialBackoff = durationFromEnv(config.EksaGitOpsRetryInitialBackoffEnv, "gitops retry initial backoff, the default is used")
+	cliConfig.GitOpsRetryMaxBackoff = durationFromEnv(config.EksaGitOpsRetryMaxBackoffEnv, "gitops retry max backoff, the default is used")
+	cliConfig.GitOpsRetryMaxElapsedTime = durationFromEnv(config.EksaGitOpsRetryMaxElapsedTimeEnv, "gitops retry max elapsed time, the default is used")
+	cliConfig.GitOpsMaxFileSize = sizeLimitFromEnv(config.EksaGitOpsMaxFileSizeEnv, "gitops max file size, the default is used")
+	cliConfig.GitOpsMaxCommitSize = sizeLimitFromEnv(config.EksaGitOpsMaxCommitSizeEnv, "gitops max commit size, the default is used")
+	cliConfig.GitAuthorName = os.Getenv(config.EksaGitAuthorNameEnv)
+	cliConfig.GitAuthorEmail = os.Getenv(config.EksaGitAuthorEmailEnv)
+	cliConfig.GitRepositoryCacheDir = os.Getenv(config.EksaGitRepositoryCacheDirEnv)
+	cliConfig.GitWorkspaceDir = os.Getenv(config.EksaGitWorkspaceDirEnv)
+	cliConfig.GitWorkspaceCleanup = os.Getenv(config.EksaGitWorkspaceCleanupEnv)
+	cliConfig.GitOpsLocalChanges = os.Getenv(config.EksaGitOpsLocalChangesEnv)
+	cliConfig.GitOpsRepoCleanup = os.Getenv(config.EksaGitOpsRepoCleanupEnv)
 
 	return cliConfig
 }
 
+// durationFromEnv parses the duration set in env, returning zero if it's not set or invalid.
+func durationFromEnv(env, setting string) time.Duration {
+	value, ok := os.LookupEnv(env)
+	if !ok {
+		return 0
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		logger.Info("Warning: ignoring invalid "+setting, "env", env, "value", value)
+		return 0
+	}
+	return d
+}
+
+// sizeLimitFromEnv parses the size in bytes set in env, returning zero if it's not set or invalid, and a negative
+// value if it's set to zero to disable the limit.
+func sizeLimitFromEnv(env, setting string) int64 {
+	value, ok := os.LookupEnv(env)
+	if !ok {
+		return 0
+	}
+	s, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || s < 0 {
+		logger.Info("Warning: ignoring invalid "+setting, "env", env, "value", value)
+		return 0
+	}
+	if s == 0 {
+		return -1
+	}
+	return s
+}
+
 func getManagementCluster(clusterSpec *cluster.Spec) *types.Cluster {
 	if clusterSpec.ManagementCluster == nil {
 		return &types.Cluster{
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var repairCmd = &cobra.Command{
+	Use:   "repair",
+	Short: "Repair resources",
+	Long:  "Use eksctl anywhere repair to repair resources, such as the GitOps installation",
+}
+
+func init() {
+	rootCmd.AddCommand(repairCmd)
+}
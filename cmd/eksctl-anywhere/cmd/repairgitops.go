@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type repairGitOpsOptions struct {
+	clusterOptions
+	wConfig string
+}
+
+var rpg = &repairGitOpsOptions{}
+
+var repairGitOpsCmd = &cobra.Command{
+	Use:          "gitops",
+	Short:        "Repair the GitOps installation of a management cluster",
+	Long:         "This command detects a broken flux installation, like a deleted flux namespace, flux-system secret or failed Kustomization, and re-runs the needed parts of the flux bootstrap",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rpg.repairGitOps(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to repair gitops: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	repairCmd.AddCommand(repairGitOpsCmd)
+	applyClusterOptionFlags(repairGitOpsCmd.Flags(), &rpg.clusterOptions)
+	repairGitOpsCmd.Flags().StringVarP(&rpg.wConfig, "w-config", "w", "", "Kubeconfig file of the cluster flux runs in")
+
+	if err := repairGitOpsCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (r *repairGitOpsOptions) repairGitOps(ctx context.Context) error {
+	clusterSpec, err := newClusterSpec(r.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	if clusterSpec.FluxConfig == nil {
+		return fmt.Errorf("cluster %s has no GitOps configuration", clusterSpec.Cluster.Name)
+	}
+
+	cliConfig := buildCliConfig(clusterSpec)
+	dirs, err := r.directoriesToMount(clusterSpec, cliConfig)
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(dirs...).
+		WithCliConfig(cliConfig).
+		WithGitOpsFlux(clusterSpec.Cluster, clusterSpec.FluxConfig, cliConfig).
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	managementCluster := &types.Cluster{
+		Name:           clusterSpec.Cluster.Name,
+		KubeconfigFile: getKubeconfigPath(clusterSpec.Cluster.Name, r.wConfig),
+	}
+
+	logger.Info("Repairing GitOps", "cluster", managementCluster.Name)
+	if err := deps.GitOpsFlux.Repair(ctx, managementCluster, clusterSpec); err != nil {
+		return err
+	}
+	logger.MarkSuccess("GitOps repaired")
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/logger"
 )
 
@@ -19,6 +20,8 @@ var rootCmd = &cobra.Command{
 	Long:             `Use eksctl anywhere to build your own self-managing cluster on your hardware with the best of Amazon EKS`,
 	PersistentPreRun: rootPersistentPreRun,
 	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		logger.CloseEvents()
+
 		outputFilePath := logger.GetOutputFilePath()
 		if outputFilePath == "" {
 			return
@@ -31,10 +34,18 @@ var rootCmd = &cobra.Command{
 }
 
 func init() {
-	rootCmd.PersistentFlags().IntP("verbosity", "v", 0, "Set the log level verbosity")
+	rootCmd.PersistentFlags().StringP("verbosity", "v", "0", "Set the log level verbosity, optionally per component such as 2,git=6,flux=3")
+	rootCmd.PersistentFlags().String("log-format", logger.TextFormat, "Set the console log format, text or json. It can also be set with the EKSA_LOG_FORMAT environment variable")
+	rootCmd.PersistentFlags().String("events-output", "", "Write the lifecycle events of the operation as NDJSON to a file, or to an inherited file descriptor with fd:<number>. It can also be set with the EKSA_EVENTS_OUTPUT environment variable")
 	if err := viper.BindPFlags(rootCmd.PersistentFlags()); err != nil {
 		log.Fatalf("failed to bind flags for root: %v", err)
 	}
+	if err := viper.BindEnv("log-format", "EKSA_LOG_FORMAT"); err != nil {
+		log.Fatalf("failed to bind environment variables for root: %v", err)
+	}
+	if err := viper.BindEnv("events-output", "EKSA_EVENTS_OUTPUT"); err != nil {
+		log.Fatalf("failed to bind environment variables for root: %v", err)
+	}
 }
 
 func rootPersistentPreRun(cmd *cobra.Command, args []string) {
@@ -44,13 +55,25 @@ func rootPersistentPreRun(cmd *cobra.Command, args []string) {
 }
 
 func initLogger() error {
+	level, componentLevels, err := logger.ParseVerbosity(viper.GetString("verbosity"))
+	if err != nil {
+		return err
+	}
+
 	outputFilePath := fmt.Sprintf("./eksa-cli-%s.log", time.Now().Format("2006-01-02T15_04_05"))
 	if err := logger.InitZap(logger.ZapOpts{
-		Level:          viper.GetInt("verbosity"),
-		OutputFilePath: outputFilePath,
+		Level:           level,
+		ComponentLevels: componentLevels,
+		OutputFilePath:  outputFilePath,
+		Format:          viper.GetString("log-format"),
 	}); err != nil {
 		return fmt.Errorf("failed init zap logger in root command: %v", err)
 	}
+	executables.RegisterCredsForRedaction()
+
+	if err := logger.InitEvents(viper.GetString("events-output")); err != nil {
+		return fmt.Errorf("failed init events output in root command: %v", err)
+	}
 
 	return nil
 }
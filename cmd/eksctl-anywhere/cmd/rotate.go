@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var rotateCmd = &cobra.Command{
+	Use:   "rotate",
+	Short: "Rotate credentials",
+	Long:  "Use eksctl anywhere rotate to rotate credentials, such as the GitOps credentials",
+}
+
+func init() {
+	rootCmd.AddCommand(rotateCmd)
+}
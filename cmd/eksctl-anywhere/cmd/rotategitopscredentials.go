@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type rotateGitOpsCredentialsOptions struct {
+	clusterOptions
+	wConfig string
+}
+
+var rgc = &rotateGitOpsCredentialsOptions{}
+
+var rotateGitOpsCredentialsCmd = &cobra.Command{
+	Use:          "gitops-credentials",
+	Short:        "Rotate the GitOps credentials of a management cluster",
+	Long:         "This command re-creates the flux-system secret with the current git provider credentials, registers a new deploy key with the git provider and forces flux to reconcile",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rgc.rotateGitOpsCredentials(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to rotate gitops credentials: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rotateCmd.AddCommand(rotateGitOpsCredentialsCmd)
+	applyClusterOptionFlags(rotateGitOpsCredentialsCmd.Flags(), &rgc.clusterOptions)
+	rotateGitOpsCredentialsCmd.Flags().StringVarP(&rgc.wConfig, "w-config", "w", "", "Kubeconfig file of the cluster flux runs in")
+
+	if err := rotateGitOpsCredentialsCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (r *rotateGitOpsCredentialsOptions) rotateGitOpsCredentials(ctx context.Context) error {
+	clusterSpec, err := newClusterSpec(r.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	if clusterSpec.FluxConfig == nil {
+		return fmt.Errorf("cluster %s has no GitOps configuration", clusterSpec.Cluster.Name)
+	}
+
+	cliConfig := buildCliConfig(clusterSpec)
+	dirs, err := r.directoriesToMount(clusterSpec, cliConfig)
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(dirs...).
+		WithCliConfig(cliConfig).
+		WithGitOpsFlux(clusterSpec.Cluster, clusterSpec.FluxConfig, cliConfig).
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	managementCluster := &types.Cluster{
+		Name:           clusterSpec.Cluster.Name,
+		KubeconfigFile: getKubeconfigPath(clusterSpec.Cluster.Name, r.wConfig),
+	}
+
+	logger.Info("Rotating GitOps credentials", "cluster", managementCluster.Name)
+	if err := deps.GitOpsFlux.RotateCredentials(ctx, managementCluster, clusterSpec); err != nil {
+		return err
+	}
+	logger.MarkSuccess("GitOps credentials rotated")
+
+	return nil
+}
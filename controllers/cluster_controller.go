@@ -41,6 +41,7 @@ type ClusterReconciler struct {
 	client                     client.Client
 	providerReconcilerRegistry ProviderClusterReconcilerRegistry
 	awsIamAuth                 AWSIamConfigReconciler
+	gitOpsReverseSyncer        GitOpsReverseSyncer
 }
 
 type ProviderClusterReconcilerRegistry interface {
@@ -54,13 +55,33 @@ type AWSIamConfigReconciler interface {
 	ReconcileDelete(ctx context.Context, logger logr.Logger, cluster *anywherev1.Cluster) error
 }
 
+// GitOpsReverseSyncer commits the changes made to the cluster config in the cluster back to its gitops repository.
+type GitOpsReverseSyncer interface {
+	ReverseSync(ctx context.Context, logger logr.Logger, cluster *anywherev1.Cluster) error
+}
+
+// ClusterReconcilerOption configures optional behavior of the ClusterReconciler.
+type ClusterReconcilerOption func(*ClusterReconciler)
+
+// WithGitOpsReverseSync makes the ClusterReconciler commit the cluster config of self-managed clusters back to
+// their gitops repository after they are reconciled.
+func WithGitOpsReverseSync(syncer GitOpsReverseSyncer) ClusterReconcilerOption {
+	return func(r *ClusterReconciler) {
+		r.gitOpsReverseSyncer = syncer
+	}
+}
+
 // NewClusterReconciler constructs a new ClusterReconciler.
-func NewClusterReconciler(client client.Client, registry ProviderClusterReconcilerRegistry, awsIamAuth AWSIamConfigReconciler) *ClusterReconciler {
-	return &ClusterReconciler{
+func NewClusterReconciler(client client.Client, registry ProviderClusterReconcilerRegistry, awsIamAuth AWSIamConfigReconciler, opts ...ClusterReconcilerOption) *ClusterReconciler {
+	r := &ClusterReconciler{
 		client:                     client,
 		providerReconcilerRegistry: registry,
 		awsIamAuth:                 awsIamAuth,
 	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -238,6 +259,13 @@ func (r *ClusterReconciler) postClusterProviderReconcile(ctx context.Context, lo
 		}
 	}
 
+	// A failure to commit to git shouldn't block the reconciliation, the changes are committed on the next one.
+	if r.gitOpsReverseSyncer != nil && cluster.IsSelfManaged() {
+		if err := r.gitOpsReverseSyncer.ReverseSync(ctx, log, cluster); err != nil {
+			log.Error(err, "Failed to commit cluster config changes to git")
+		}
+	}
+
 	return controller.Result{}, nil
 }
 
@@ -2,6 +2,9 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
@@ -11,9 +14,11 @@ import (
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	awsiamconfigreconciler "github.com/aws/eks-anywhere/pkg/awsiamauth/reconciler"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/controller/clusters"
 	"github.com/aws/eks-anywhere/pkg/crypto"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
+	fluxreconciler "github.com/aws/eks-anywhere/pkg/gitops/flux/reconciler"
 	ciliumreconciler "github.com/aws/eks-anywhere/pkg/networking/cilium/reconciler"
 	cnireconciler "github.com/aws/eks-anywhere/pkg/networking/reconciler"
 	dockerreconciler "github.com/aws/eks-anywhere/pkg/providers/docker/reconciler"
@@ -40,6 +45,7 @@ type Factory struct {
 	cniReconciler               *cnireconciler.Reconciler
 	ipValidator                 *clusters.IPValidator
 	awsIamConfigReconciler      *awsiamconfigreconciler.Reconciler
+	gitOpsReverseSyncer         *fluxreconciler.ReverseSyncer
 	logger                      logr.Logger
 	deps                        *dependencies.Dependencies
 }
@@ -84,7 +90,7 @@ func (f *Factory) Build(ctx context.Context) (*Reconcilers, error) {
 
 func (f *Factory) WithClusterReconciler(capiProviders []clusterctlv1.Provider) *Factory {
 	f.dependencyFactory.WithGovc()
-	f.withTracker().WithProviderClusterReconcilerRegistry(capiProviders).withAWSIamConfigReconciler()
+	f.withTracker().WithProviderClusterReconcilerRegistry(capiProviders).withAWSIamConfigReconciler().withGitOpsReverseSyncer()
 
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.reconcilers.ClusterReconciler != nil {
@@ -95,6 +101,7 @@ func (f *Factory) WithClusterReconciler(capiProviders []clusterctlv1.Provider) *
 			f.manager.GetClient(),
 			f.registry,
 			f.awsIamConfigReconciler,
+			WithGitOpsReverseSync(f.gitOpsReverseSyncer),
 		)
 
 		return nil
@@ -375,3 +382,41 @@ func (f *Factory) withAWSIamConfigReconciler() *Factory {
 
 	return f
 }
+
+func (f *Factory) withGitOpsReverseSyncer() *Factory {
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.gitOpsReverseSyncer != nil {
+			return nil
+		}
+
+		f.gitOpsReverseSyncer = fluxreconciler.NewReverseSyncer(
+			f.manager.GetClient(),
+			buildGitOpsFlux,
+		)
+
+		return nil
+	})
+
+	return f
+}
+
+// buildGitOpsFlux builds the GitOpsFlux of the cluster, cloning the repository in a directory of its own that is
+// cleaned up before every build so a previous clone doesn't get in the way. The git credentials are read from the
+// environment of the controller.
+func buildGitOpsFlux(ctx context.Context, clusterSpec *cluster.Spec) (fluxreconciler.GitOpsFlux, error) {
+	folder := filepath.Join(os.TempDir(), "eksa-reverse-sync", clusterSpec.Cluster.Name)
+	if err := os.RemoveAll(folder); err != nil {
+		return nil, fmt.Errorf("cleaning up reverse sync folder: %v", err)
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithLocalExecutables().
+		WithWriterFolder(folder).
+		WithGitOpsFlux(clusterSpec.Cluster, clusterSpec.FluxConfig, nil).
+		Build(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return deps.GitOpsFlux, nil
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/aws/eks-anywhere/controllers (interfaces: AWSIamConfigReconciler)
+// Source: github.com/aws/eks-anywhere/controllers (interfaces: AWSIamConfigReconciler,GitOpsReverseSyncer)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -80,3 +80,40 @@ func (mr *MockAWSIamConfigReconcilerMockRecorder) ReconcileDelete(arg0, arg1, ar
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileDelete", reflect.TypeOf((*MockAWSIamConfigReconciler)(nil).ReconcileDelete), arg0, arg1, arg2)
 }
+
+// MockGitOpsReverseSyncer is a mock of GitOpsReverseSyncer interface.
+type MockGitOpsReverseSyncer struct {
+	ctrl     *gomock.Controller
+	recorder *MockGitOpsReverseSyncerMockRecorder
+}
+
+// MockGitOpsReverseSyncerMockRecorder is the mock recorder for MockGitOpsReverseSyncer.
+type MockGitOpsReverseSyncerMockRecorder struct {
+	mock *MockGitOpsReverseSyncer
+}
+
+// NewMockGitOpsReverseSyncer creates a new mock instance.
+func NewMockGitOpsReverseSyncer(ctrl *gomock.Controller) *MockGitOpsReverseSyncer {
+	mock := &MockGitOpsReverseSyncer{ctrl: ctrl}
+	mock.recorder = &MockGitOpsReverseSyncerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGitOpsReverseSyncer) EXPECT() *MockGitOpsReverseSyncerMockRecorder {
+	return m.recorder
+}
+
+// ReverseSync mocks base method.
+func (m *MockGitOpsReverseSyncer) ReverseSync(arg0 context.Context, arg1 logr.Logger, arg2 *v1alpha1.Cluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReverseSync", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReverseSync indicates an expected call of ReverseSync.
+func (mr *MockGitOpsReverseSyncerMockRecorder) ReverseSync(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReverseSync", reflect.TypeOf((*MockGitOpsReverseSyncer)(nil).ReverseSync), arg0, arg1, arg2)
+}
@@ -47,7 +47,10 @@ type ClusterSpec struct {
 	DatacenterRef                 Ref                            `json:"datacenterRef,omitempty"`
 	IdentityProviderRefs          []Ref                          `json:"identityProviderRefs,omitempty"`
 	GitOpsRef                     *Ref                           `json:"gitOpsRef,omitempty"`
-	ClusterNetwork                ClusterNetwork                 `json:"clusterNetwork,omitempty"`
+	// AdditionalGitOpsRefs are FluxConfigs of other repositories reconciled by the flux of the cluster, like the
+	// repositories of the applications. The cluster config is only committed to the repository of the GitOpsRef.
+	AdditionalGitOpsRefs []Ref          `json:"additionalGitOpsRefs,omitempty"`
+	ClusterNetwork       ClusterNetwork `json:"clusterNetwork,omitempty"`
 	// +kubebuilder:validation:Optional
 	ExternalEtcdConfiguration   *ExternalEtcdConfiguration   `json:"externalEtcdConfiguration,omitempty"`
 	ProxyConfiguration          *ProxyConfiguration          `json:"proxyConfiguration,omitempty"`
@@ -94,6 +97,9 @@ func (n *Cluster) Equal(o *Cluster) bool {
 	if !n.Spec.GitOpsRef.Equal(o.Spec.GitOpsRef) {
 		return false
 	}
+	if !RefSliceEqual(n.Spec.AdditionalGitOpsRefs, o.Spec.AdditionalGitOpsRefs) {
+		return false
+	}
 	if !n.Spec.ClusterNetwork.Equal(&o.Spec.ClusterNetwork) {
 		return false
 	}
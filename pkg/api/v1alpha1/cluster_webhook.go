@@ -189,7 +189,7 @@ func validateImmutableFieldsCluster(new, old *Cluster) field.ErrorList {
 		}
 	}
 
-	if !new.Spec.GitOpsRef.Equal(old.Spec.GitOpsRef) {
+	if !new.Spec.GitOpsRef.Equal(old.Spec.GitOpsRef) && !isGitOpsConfigMigration(old.Spec.GitOpsRef, new.Spec.GitOpsRef) {
 		allErrs = append(
 			allErrs,
 			field.Forbidden(specPath.Child("GitOpsRef"), fmt.Sprintf("field is immutable %v", new.Spec.GitOpsRef)))
@@ -251,3 +251,11 @@ func (r *Cluster) ValidateDelete() error {
 
 	return nil
 }
+
+// isGitOpsConfigMigration returns true if the gitOpsRef is switched from a GitOpsConfig to the FluxConfig it was
+// migrated to, which keeps its name.
+func isGitOpsConfigMigration(old, new *Ref) bool {
+	return old != nil && new != nil &&
+		old.Kind == GitOpsConfigKind && new.Kind == FluxConfigKind &&
+		old.Name == new.Name
+}
@@ -712,6 +712,30 @@ func TestClusterValidateUpdateGitOpsRefImmutableName(t *testing.T) {
 	g.Expect(c.ValidateUpdate(cOld)).To(MatchError(ContainSubstring("spec.GitOpsRef: Forbidden: field is immutable")))
 }
 
+func TestClusterValidateUpdateGitOpsRefMigrateToFluxConfig(t *testing.T) {
+	cOld := createCluster()
+	cOld.Spec.GitOpsRef = &v1alpha1.Ref{
+		Name: "test", Kind: v1alpha1.GitOpsConfigKind,
+	}
+	c := cOld.DeepCopy()
+	c.Spec.GitOpsRef = &v1alpha1.Ref{Name: "test", Kind: v1alpha1.FluxConfigKind}
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(cOld)).To(Succeed())
+}
+
+func TestClusterValidateUpdateGitOpsRefMigrateToFluxConfigName(t *testing.T) {
+	cOld := createCluster()
+	cOld.Spec.GitOpsRef = &v1alpha1.Ref{
+		Name: "test", Kind: v1alpha1.GitOpsConfigKind,
+	}
+	c := cOld.DeepCopy()
+	c.Spec.GitOpsRef = &v1alpha1.Ref{Name: "test2", Kind: v1alpha1.FluxConfigKind}
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(cOld)).To(MatchError(ContainSubstring("spec.GitOpsRef: Forbidden: field is immutable")))
+}
+
 func TestClusterValidateUpdateGitOpsRefImmutableKind(t *testing.T) {
 	cOld := createCluster()
 	cOld.Spec.GitOpsRef = &v1alpha1.Ref{
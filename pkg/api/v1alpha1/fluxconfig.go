@@ -3,7 +3,17 @@ package v1alpha1
 import (
 	"errors"
 	"fmt"
+	"io"
 	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/pkg/logger"
 )
@@ -13,14 +23,63 @@ const (
 	RsaAlgorithm     = "rsa"
 	EcdsaAlgorithm   = "ecdsa"
 	Ed25519Algorithm = "ed25519"
+
+	FluxDefaultOCITag = "latest"
+
+	FluxBucketGenericProvider = "generic"
+	FluxBucketAWSProvider     = "aws"
+	FluxDefaultBucketRegion   = "us-east-1"
+
+	FluxSopsDecryptionProvider = "sops"
+
+	FluxDefaultSealedSecretsRepositoryUrl = "https://bitnami-labs.github.io/sealed-secrets"
+	FluxDefaultSealedSecretsNamespace     = "kube-system"
+
+	FluxSecretStoreKind                      = "SecretStore"
+	FluxClusterSecretStoreKind               = "ClusterSecretStore"
+	FluxDefaultExternalSecretRefreshInterval = "1h"
+
+	FluxNotificationSeverityInfo  = "info"
+	FluxNotificationSeverityError = "error"
+
+	FluxSourceController          = "source-controller"
+	FluxKustomizeController       = "kustomize-controller"
+	FluxHelmController            = "helm-controller"
+	FluxNotificationController    = "notification-controller"
+	FluxImageReflectorController  = "image-reflector-controller"
+	FluxImageAutomationController = "image-automation-controller"
+)
+
+var fluxReceiverTypes = []string{"generic", "generic-hmac", "github", "gitlab", "bitbucket", "harbor", "dockerhub", "quay", "gcr", "nexus", "acr"}
+
+var fluxNotificationTypes = []string{"slack", "msteams", "discord", "googlechat", "generic"}
+
+var (
+	fluxComponents      = []string{FluxSourceController, FluxKustomizeController, FluxHelmController, FluxNotificationController}
+	fluxExtraComponents = []string{FluxImageReflectorController, FluxImageAutomationController}
 )
 
+// ComponentEnabled returns true if the toolkit component is installed at bootstrap, either as one of the selected
+// components, all the default components when none are selected, or as an extra component.
+func (s *FluxConfigSpec) ComponentEnabled(component string) bool {
+	if sliceContains(fluxComponents, component) {
+		return len(s.Components) == 0 || sliceContains(s.Components, component)
+	}
+	return sliceContains(s.ComponentsExtra, component) || (s.ImageAutomation && sliceContains(fluxExtraComponents, component))
+}
+
 func validateFluxConfig(config *FluxConfig) error {
-	if config.Spec.Git != nil && config.Spec.Github != nil {
+	providers := 0
+	for _, configured := range []bool{config.Spec.Git != nil, config.Spec.Github != nil, config.Spec.Gitlab != nil, config.Spec.BitbucketServer != nil, config.Spec.AzureDevOps != nil, config.Spec.Gitea != nil, config.Spec.CodeCommit != nil, config.Spec.OCIRepository != nil, config.Spec.Bucket != nil} {
+		if configured {
+			providers++
+		}
+	}
+	if providers > 1 {
 		return errors.New("must specify only one provider")
 	}
-	if config.Spec.Git == nil && config.Spec.Github == nil {
-		return errors.New("must specify a provider. Valid options are git and github")
+	if providers == 0 {
+		return errors.New("must specify a provider. Valid options are git, github, gitlab, bitbucketServer, azureDevOps, gitea, codeCommit, ociRepository and bucket")
 	}
 	if config.Spec.Github != nil {
 		err := validateGithubProviderConfig(*config.Spec.Github)
@@ -28,6 +87,48 @@ func validateFluxConfig(config *FluxConfig) error {
 			return err
 		}
 	}
+	if config.Spec.Gitlab != nil {
+		err := validateGitlabProviderConfig(*config.Spec.Gitlab)
+		if err != nil {
+			return err
+		}
+	}
+	if config.Spec.BitbucketServer != nil {
+		err := validateBitbucketServerProviderConfig(*config.Spec.BitbucketServer)
+		if err != nil {
+			return err
+		}
+	}
+	if config.Spec.AzureDevOps != nil {
+		err := validateAzureDevOpsProviderConfig(*config.Spec.AzureDevOps)
+		if err != nil {
+			return err
+		}
+	}
+	if config.Spec.Gitea != nil {
+		err := validateGiteaProviderConfig(*config.Spec.Gitea)
+		if err != nil {
+			return err
+		}
+	}
+	if config.Spec.CodeCommit != nil {
+		err := validateCodeCommitProviderConfig(*config.Spec.CodeCommit)
+		if err != nil {
+			return err
+		}
+	}
+	if config.Spec.OCIRepository != nil {
+		err := validateOCIRepositoryConfig(*config.Spec.OCIRepository)
+		if err != nil {
+			return err
+		}
+	}
+	if config.Spec.Bucket != nil {
+		err := validateBucketConfig(*config.Spec.Bucket)
+		if err != nil {
+			return err
+		}
+	}
 	if config.Spec.Git != nil {
 		err := validateGitProviderConfig(*config.Spec.Git)
 		if err != nil {
@@ -35,13 +136,493 @@ func validateFluxConfig(config *FluxConfig) error {
 		}
 	}
 
-	if len(config.Spec.Branch) > 0 {
-		err := validateGitBranchName(config.Spec.Branch)
+	// A templated branch is validated once resolved for the cluster
+	if len(config.Spec.Branch) > 0 && !strings.Contains(config.Spec.Branch, "{{") {
+		err := ValidateGitBranchName(config.Spec.Branch)
 		if err != nil {
 			return err
 		}
 	}
 
+	if config.Spec.Receiver != nil {
+		if err := validateFluxReceiverConfig(*config.Spec.Receiver); err != nil {
+			return err
+		}
+		// The webhook registered in Github is signed with the token, which is only verified by the github receiver type
+		if config.Spec.Github != nil && len(config.Spec.Receiver.WebhookUrl) > 0 && config.Spec.Receiver.Type != "github" {
+			return fmt.Errorf("'type' %s is not valid in receiver with a webhookUrl and the github provider; type must be github", config.Spec.Receiver.Type)
+		}
+	}
+
+	if err := validateFluxNotifications(config.Spec.Notifications); err != nil {
+		return err
+	}
+
+	if err := validateFluxHelmCharts(config.Spec.HelmCharts); err != nil {
+		return err
+	}
+
+	if config.Spec.MultiTenancy != nil {
+		if err := validateFluxMultiTenancyConfig(*config.Spec.MultiTenancy); err != nil {
+			return err
+		}
+		// Tenant Kustomizations sync from a path of the git repository, which isn't available in the OCI and bucket layouts.
+		if config.Spec.MultiTenancy.ClusterTenants && (config.Spec.OCIRepository != nil || config.Spec.Bucket != nil) {
+			return errors.New("'clusterTenants' is not supported in multiTenancy with an ociRepository or bucket source")
+		}
+	}
+
+	if config.Spec.HubAndSpoke {
+		// The workload cluster Kustomizations sync from a path of the git repository, next to the cluster config.
+		if config.Spec.OCIRepository != nil || config.Spec.Bucket != nil {
+			return errors.New("'hubAndSpoke' is not supported with an ociRepository or bucket source")
+		}
+		if config.Spec.MultiTenancy != nil && config.Spec.MultiTenancy.ClusterTenants {
+			return errors.New("'hubAndSpoke' is not supported with 'clusterTenants' in multiTenancy")
+		}
+	}
+
+	if config.Spec.SealedSecrets != nil {
+		if err := validateFluxSealedSecretsConfig(*config.Spec.SealedSecrets); err != nil {
+			return err
+		}
+	}
+
+	if config.Spec.ExternalSecret != nil {
+		// OCI repositories and buckets authenticate with their own secrets, the flux-system secret only holds the git credentials.
+		if config.Spec.OCIRepository != nil || config.Spec.Bucket != nil {
+			return errors.New("'externalSecret' is only supported with a git repository")
+		}
+		if err := validateFluxExternalSecretConfig(*config.Spec.ExternalSecret); err != nil {
+			return err
+		}
+	}
+
+	if err := validateFluxDependsOn(config.Spec.DependsOn); err != nil {
+		return err
+	}
+
+	if config.Spec.Decryption != nil {
+		if err := validateFluxDecryptionConfig(*config.Spec.Decryption); err != nil {
+			return err
+		}
+	}
+
+	if config.Spec.Sync != nil {
+		if err := validateFluxSyncConfig(*config.Spec.Sync, config.Spec.OCIRepository != nil || config.Spec.Bucket != nil); err != nil {
+			return err
+		}
+	}
+
+	if err := validateFluxComponents(config.Spec); err != nil {
+		return err
+	}
+
+	if err := validateFluxControllerResources(config.Spec); err != nil {
+		return err
+	}
+
+	if config.Spec.ControllerScheduling != nil {
+		if err := validateFluxSchedulingConfig(*config.Spec.ControllerScheduling); err != nil {
+			return err
+		}
+	}
+
+	if config.Spec.EksaSystemKustomize != nil {
+		if err := validateFluxKustomizeConfig(*config.Spec.EksaSystemKustomize); err != nil {
+			return err
+		}
+	}
+
+	if config.Spec.SystemNamespaceMetadata != nil {
+		if err := validateFluxNamespaceMetadata(*config.Spec.SystemNamespaceMetadata); err != nil {
+			return err
+		}
+	}
+
+	if len(config.Spec.CredentialsSecretRef) > 0 {
+		if err := validateFluxCredentialsSecretRef(config.Spec); err != nil {
+			return err
+		}
+	}
+
+	// The controller commits the cluster config changes to the repository, which OCI artifacts and buckets aren't.
+	if config.Spec.ReverseSync && (config.Spec.OCIRepository != nil || config.Spec.Bucket != nil) {
+		return errors.New("'reverseSync' is only supported with a git repository")
+	}
+
+	return nil
+}
+
+// validateFluxCredentialsSecretRef checks the credentials secret is only set for the providers authenticated with an access token.
+func validateFluxCredentialsSecretRef(spec FluxConfigSpec) error {
+	if spec.Github == nil && spec.Gitlab == nil && spec.BitbucketServer == nil && spec.AzureDevOps == nil && spec.Gitea == nil {
+		return errors.New("'credentialsSecretRef' is only supported with the github, gitlab, bitbucketServer, azureDevOps and gitea providers")
+	}
+	if errs := validation.IsDNS1123Subdomain(spec.CredentialsSecretRef); len(errs) > 0 {
+		return fmt.Errorf("'credentialsSecretRef' %s is not valid; credentialsSecretRef must be a lowercase RFC 1123 subdomain", spec.CredentialsSecretRef)
+	}
+	return nil
+}
+
+func validateFluxMultiTenancyConfig(config FluxMultiTenancyConfig) error {
+	if len(config.ClusterDomain) > 0 {
+		if errs := validation.IsDNS1123Subdomain(config.ClusterDomain); len(errs) > 0 {
+			return fmt.Errorf("'clusterDomain' %s is not valid in multiTenancy; clusterDomain must be a lowercase RFC 1123 subdomain", config.ClusterDomain)
+		}
+	}
+	if len(config.ServiceAccountName) > 0 {
+		if errs := validation.IsDNS1123Subdomain(config.ServiceAccountName); len(errs) > 0 {
+			return fmt.Errorf("'serviceAccountName' %s is not valid in multiTenancy; serviceAccountName must be a lowercase RFC 1123 subdomain", config.ServiceAccountName)
+		}
+	}
+	if len(config.TargetNamespace) > 0 {
+		if errs := validation.IsDNS1123Label(config.TargetNamespace); len(errs) > 0 {
+			return fmt.Errorf("'targetNamespace' %s is not valid in multiTenancy; targetNamespace must be a lowercase RFC 1123 label", config.TargetNamespace)
+		}
+		// Without impersonation the kustomize-controller applies with its own cluster-admin permissions,
+		// so overriding the namespace alone doesn't prevent reconciling cluster-scoped resources.
+		if len(config.ServiceAccountName) <= 0 {
+			return errors.New("'serviceAccountName' is not set or empty in multiTenancy; serviceAccountName is required when targetNamespace is set")
+		}
+	}
+	return nil
+}
+
+func validateFluxNotifications(notifications []FluxNotificationConfig) error {
+	names := make(map[string]struct{}, len(notifications))
+	for _, n := range notifications {
+		if errs := validation.IsDNS1123Subdomain(n.Name); len(errs) > 0 {
+			return fmt.Errorf("'name' %s is not valid in notifications; name must be a lowercase RFC 1123 subdomain", n.Name)
+		}
+		if _, ok := names[n.Name]; ok {
+			return fmt.Errorf("'name' %s is duplicated in notifications; notification names must be unique", n.Name)
+		}
+		names[n.Name] = struct{}{}
+
+		if !sliceContains(fluxNotificationTypes, n.Type) {
+			return fmt.Errorf("'type' %s is not valid in notifications %s; type must be amongst %s", n.Type, n.Name, strings.Join(fluxNotificationTypes, ", "))
+		}
+		if len(n.SecretName) <= 0 {
+			return fmt.Errorf("'secretName' is not set or empty in notifications %s; secretName is a required field", n.Name)
+		}
+		if len(n.EventSeverity) > 0 && n.EventSeverity != FluxNotificationSeverityInfo && n.EventSeverity != FluxNotificationSeverityError {
+			return fmt.Errorf("'eventSeverity' %s is not valid in notifications %s; eventSeverity must be %s or %s", n.EventSeverity, n.Name, FluxNotificationSeverityInfo, FluxNotificationSeverityError)
+		}
+	}
+	return nil
+}
+
+func validateFluxHelmCharts(charts []FluxHelmChartConfig) error {
+	names := make(map[string]struct{}, len(charts))
+	for _, chart := range charts {
+		if errs := validation.IsDNS1123Subdomain(chart.Name); len(errs) > 0 {
+			return fmt.Errorf("'name' %s is not valid in helmCharts; name must be a lowercase RFC 1123 subdomain", chart.Name)
+		}
+		if _, ok := names[chart.Name]; ok {
+			return fmt.Errorf("'name' %s is duplicated in helmCharts; chart names must be unique", chart.Name)
+		}
+		names[chart.Name] = struct{}{}
+
+		if len(chart.RepositoryUrl) <= 0 {
+			return fmt.Errorf("'repositoryUrl' is not set or empty in helmCharts %s; repositoryUrl is a required field", chart.Name)
+		}
+		u, err := url.Parse(chart.RepositoryUrl)
+		if err != nil {
+			return fmt.Errorf("unable to parse repository url in helmCharts %s: %v", chart.Name, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("invalid repository url scheme in helmCharts %s: %v", chart.Name, u.Scheme)
+		}
+	}
+	return nil
+}
+
+// validateFluxSealedSecretsConfig checks the sealed-secrets settings left empty are defaulted afterwards.
+func validateFluxSealedSecretsConfig(config FluxSealedSecretsConfig) error {
+	if len(config.RepositoryUrl) > 0 {
+		u, err := url.Parse(config.RepositoryUrl)
+		if err != nil {
+			return fmt.Errorf("unable to parse repository url in sealedSecrets: %v", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("invalid repository url scheme in sealedSecrets: %v", u.Scheme)
+		}
+	}
+	if len(config.Namespace) > 0 {
+		if errs := validation.IsDNS1123Label(config.Namespace); len(errs) > 0 {
+			return fmt.Errorf("'namespace' %s is not valid in sealedSecrets; namespace must be a lowercase RFC 1123 label", config.Namespace)
+		}
+	}
+	return nil
+}
+
+func validateFluxExternalSecretConfig(config FluxExternalSecretConfig) error {
+	if len(config.SecretStoreRef.Name) <= 0 {
+		return errors.New("'name' is not set or empty in externalSecret secretStoreRef; name is a required field")
+	}
+	if len(config.SecretStoreRef.Kind) > 0 && config.SecretStoreRef.Kind != FluxSecretStoreKind && config.SecretStoreRef.Kind != FluxClusterSecretStoreKind {
+		return fmt.Errorf("'kind' %s is not valid in externalSecret secretStoreRef; kind must be %s or %s", config.SecretStoreRef.Kind, FluxSecretStoreKind, FluxClusterSecretStoreKind)
+	}
+	if len(config.RemoteKey) <= 0 {
+		return errors.New("'remoteKey' is not set or empty in externalSecret; remoteKey is a required field")
+	}
+	if len(config.RefreshInterval) > 0 {
+		if v, err := time.ParseDuration(config.RefreshInterval); err != nil || v <= 0 {
+			return fmt.Errorf("'refreshInterval' %s is not valid in externalSecret; refreshInterval must be a positive duration such as 1h0m0s", config.RefreshInterval)
+		}
+	}
+	return nil
+}
+
+func validateFluxDependsOn(dependencies []FluxKustomizationDependency) error {
+	for _, d := range dependencies {
+		if len(d.Name) <= 0 {
+			return errors.New("'name' is not set or empty in dependsOn; name is a required field")
+		}
+	}
+	return nil
+}
+
+func validateFluxDecryptionConfig(config FluxDecryptionConfig) error {
+	if len(config.Provider) > 0 && config.Provider != FluxSopsDecryptionProvider {
+		return fmt.Errorf("'provider' %s is not supported in decryption; the only supported provider is %s", config.Provider, FluxSopsDecryptionProvider)
+	}
+	if config.Age == nil && config.AwsKms == nil {
+		return errors.New("must specify a key in decryption. Valid options are age and awsKms")
+	}
+	if config.Age != nil && config.AwsKms != nil {
+		return errors.New("must specify only one key in decryption")
+	}
+	if config.Age != nil {
+		if len(config.Age.SecretName) <= 0 {
+			return errors.New("'secretName' is not set or empty in decryption age; secretName is a required field")
+		}
+		if errs := validation.IsDNS1123Subdomain(config.Age.SecretName); len(errs) > 0 {
+			return fmt.Errorf("'secretName' %s is not valid in decryption age; secretName must be a lowercase RFC 1123 subdomain", config.Age.SecretName)
+		}
+	}
+	if config.AwsKms != nil && len(config.AwsKms.SecretName) > 0 {
+		if errs := validation.IsDNS1123Subdomain(config.AwsKms.SecretName); len(errs) > 0 {
+			return fmt.Errorf("'secretName' %s is not valid in decryption awsKms; secretName must be a lowercase RFC 1123 subdomain", config.AwsKms.SecretName)
+		}
+	}
+	return nil
+}
+
+func validateFluxComponents(spec FluxConfigSpec) error {
+	for _, c := range spec.Components {
+		if !sliceContains(fluxComponents, c) {
+			return fmt.Errorf("'%s' is not valid in components; components must be amongst %s", c, strings.Join(fluxComponents, ", "))
+		}
+	}
+	for _, c := range spec.ComponentsExtra {
+		if !sliceContains(fluxExtraComponents, c) {
+			return fmt.Errorf("'%s' is not valid in componentsExtra; componentsExtra must be amongst %s", c, strings.Join(fluxExtraComponents, ", "))
+		}
+	}
+	if len(spec.Components) == 0 {
+		return nil
+	}
+
+	// The flux-system source and Kustomization reconcile the cluster config, so they can't be omitted.
+	for _, c := range []string{FluxSourceController, FluxKustomizeController} {
+		if !sliceContains(spec.Components, c) {
+			return fmt.Errorf("'%s' is required in components", c)
+		}
+	}
+	if len(spec.HelmCharts) > 0 && !sliceContains(spec.Components, FluxHelmController) {
+		return fmt.Errorf("'%s' is required in components to deliver helmCharts", FluxHelmController)
+	}
+	if spec.SealedSecrets != nil && !sliceContains(spec.Components, FluxHelmController) {
+		return fmt.Errorf("'%s' is required in components to install sealedSecrets", FluxHelmController)
+	}
+	if (spec.Receiver != nil || len(spec.Notifications) > 0) && !sliceContains(spec.Components, FluxNotificationController) {
+		return fmt.Errorf("'%s' is required in components to generate a receiver or notifications", FluxNotificationController)
+	}
+	return nil
+}
+
+func validateFluxControllerResources(spec FluxConfigSpec) error {
+	controllers := append(append([]string{}, fluxComponents...), fluxExtraComponents...)
+	for controller, r := range spec.ControllerResources {
+		if !sliceContains(controllers, controller) {
+			return fmt.Errorf("'%s' is not valid in controllerResources; controllers must be amongst %s", controller, strings.Join(controllers, ", "))
+		}
+		if !spec.ComponentEnabled(controller) {
+			return fmt.Errorf("'%s' in controllerResources is not installed; it must be enabled in components or componentsExtra", controller)
+		}
+
+		quantities := []struct {
+			resource, request, limit string
+		}{
+			{resource: "cpu", request: r.Requests.CPU, limit: r.Limits.CPU},
+			{resource: "memory", request: r.Requests.Memory, limit: r.Limits.Memory},
+		}
+		for _, q := range quantities {
+			request, err := parseFluxQuantity(controller, "requests", q.resource, q.request)
+			if err != nil {
+				return err
+			}
+			limit, err := parseFluxQuantity(controller, "limits", q.resource, q.limit)
+			if err != nil {
+				return err
+			}
+			if request != nil && limit != nil && request.Cmp(*limit) > 0 {
+				return fmt.Errorf("%s request %s of '%s' in controllerResources is greater than its limit %s", q.resource, q.request, controller, q.limit)
+			}
+		}
+	}
+	return nil
+}
+
+func parseFluxQuantity(controller, field, resourceName, value string) (*resource.Quantity, error) {
+	if len(value) <= 0 {
+		return nil, nil
+	}
+	q, err := resource.ParseQuantity(value)
+	if err != nil {
+		return nil, fmt.Errorf("'%s' is not a valid %s %s quantity of '%s' in controllerResources", value, resourceName, field, controller)
+	}
+	return &q, nil
+}
+
+func validateFluxSchedulingConfig(config FluxSchedulingConfig) error {
+	for key, value := range config.NodeSelector {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("'%s' is not a valid label key in controllerScheduling nodeSelector: %s", key, strings.Join(errs, ", "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf("'%s' is not a valid label value of '%s' in controllerScheduling nodeSelector: %s", value, key, strings.Join(errs, ", "))
+		}
+	}
+	for i, t := range config.Tolerations {
+		switch t.Operator {
+		case "", corev1.TolerationOpEqual:
+			if len(t.Key) <= 0 {
+				return fmt.Errorf("'key' is required in controllerScheduling tolerations[%d] with the Equal operator", i)
+			}
+		case corev1.TolerationOpExists:
+			if len(t.Value) > 0 {
+				return fmt.Errorf("'value' must be empty in controllerScheduling tolerations[%d] with the Exists operator", i)
+			}
+		default:
+			return fmt.Errorf("'%s' is not a valid operator in controllerScheduling tolerations[%d]; operator must be Equal or Exists", t.Operator, i)
+		}
+		switch t.Effect {
+		case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+		default:
+			return fmt.Errorf("'%s' is not a valid effect in controllerScheduling tolerations[%d]; effect must be NoSchedule, PreferNoSchedule or NoExecute", t.Effect, i)
+		}
+	}
+	return nil
+}
+
+// validateFluxSyncConfig validates the sync settings. renderedSync is true when the flux-system Kustomization is
+// rendered by the CLI, instead of generated by flux bootstrap with a Kustomization version that doesn't support wait.
+func validateFluxSyncConfig(config FluxSyncConfig, renderedSync bool) error {
+	durations := []struct {
+		field, value string
+	}{
+		{field: "interval", value: config.Interval},
+		{field: "timeout", value: config.Timeout},
+		{field: "retryInterval", value: config.RetryInterval},
+	}
+	for _, d := range durations {
+		if len(d.value) <= 0 {
+			continue
+		}
+		if v, err := time.ParseDuration(d.value); err != nil || v <= 0 {
+			return fmt.Errorf("'%s' %s is not valid in sync; %s must be a positive duration such as 5m0s", d.field, d.value, d.field)
+		}
+	}
+
+	if config.Wait && !renderedSync {
+		return errors.New("'wait' in sync is only supported with ociRepository or bucket; list the objects to wait for in healthChecks instead")
+	}
+
+	for i, h := range config.HealthChecks {
+		if len(h.Kind) <= 0 {
+			return fmt.Errorf("'kind' is not set or empty in sync healthChecks[%d]; kind is a required field", i)
+		}
+		if len(h.Name) <= 0 {
+			return fmt.Errorf("'name' is not set or empty in sync healthChecks[%d]; name is a required field", i)
+		}
+	}
+	return nil
+}
+
+func validateFluxKustomizeConfig(config FluxKustomizeConfig) error {
+	for _, r := range config.Resources {
+		if len(r) <= 0 {
+			return errors.New("'resources' contains an empty path in eksaSystemKustomize")
+		}
+		if path.IsAbs(r) || strings.HasPrefix(path.Clean(r), "..") {
+			return fmt.Errorf("'resources' %s is not valid in eksaSystemKustomize; resources must be relative to the eksa-system directory", r)
+		}
+	}
+	for k, v := range config.CommonLabels {
+		if errs := validation.IsQualifiedName(k); len(errs) > 0 {
+			return fmt.Errorf("'commonLabels' key %s is not valid in eksaSystemKustomize; label keys must be qualified names", k)
+		}
+		if errs := validation.IsValidLabelValue(v); len(errs) > 0 {
+			return fmt.Errorf("'commonLabels' value %s of %s is not valid in eksaSystemKustomize; label values must be valid kubernetes label values", v, k)
+		}
+	}
+	for k := range config.CommonAnnotations {
+		if errs := validation.IsQualifiedName(k); len(errs) > 0 {
+			return fmt.Errorf("'commonAnnotations' key %s is not valid in eksaSystemKustomize; annotation keys must be qualified names", k)
+		}
+	}
+	for i, p := range config.Patches {
+		if len(strings.TrimSpace(p.Patch)) <= 0 {
+			return fmt.Errorf("'patch' is not set or empty in eksaSystemKustomize patches[%d]; patch is a required field", i)
+		}
+		var content interface{}
+		if err := yaml.Unmarshal([]byte(p.Patch), &content); err != nil {
+			return fmt.Errorf("unable to parse patch in eksaSystemKustomize patches[%d]: %v", i, err)
+		}
+		// JSON 6902 patches are a list of operations and don't identify the object they are applied to.
+		if _, ok := content.([]interface{}); ok && p.Target == nil {
+			return fmt.Errorf("'target' is required in eksaSystemKustomize patches[%d] for a JSON 6902 patch", i)
+		}
+	}
+	return nil
+}
+
+func validateFluxNamespaceMetadata(metadata FluxNamespaceMetadata) error {
+	for k, v := range metadata.Labels {
+		if errs := validation.IsQualifiedName(k); len(errs) > 0 {
+			return fmt.Errorf("'labels' key %s is not valid in systemNamespaceMetadata; label keys must be qualified names", k)
+		}
+		if errs := validation.IsValidLabelValue(v); len(errs) > 0 {
+			return fmt.Errorf("'labels' value %s of %s is not valid in systemNamespaceMetadata; label values must be valid kubernetes label values", v, k)
+		}
+	}
+	for k := range metadata.Annotations {
+		if errs := validation.IsQualifiedName(k); len(errs) > 0 {
+			return fmt.Errorf("'annotations' key %s is not valid in systemNamespaceMetadata; annotation keys must be qualified names", k)
+		}
+	}
+	return nil
+}
+
+func validateFluxReceiverConfig(config FluxReceiverConfig) error {
+	if !sliceContains(fluxReceiverTypes, config.Type) {
+		return fmt.Errorf("'type' %s is not valid in receiver; type must be amongst %s", config.Type, strings.Join(fluxReceiverTypes, ", "))
+	}
+	if len(config.SecretName) <= 0 {
+		return errors.New("'secretName' is not set or empty in receiver; secretName is a required field")
+	}
+	if len(config.WebhookUrl) > 0 {
+		u, err := url.Parse(config.WebhookUrl)
+		if err != nil {
+			return fmt.Errorf("unable to parse webhook url in receiver: %v", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("invalid webhook url scheme in receiver: %v", u.Scheme)
+		}
+	}
 	return nil
 }
 
@@ -56,10 +637,30 @@ func validateGitProviderConfig(gitProviderConfig GitProviderConfig) error {
 	} else {
 		logger.Info("Warning: 'sshKeyAlgorithm' is not set, defaulting to 'ecdsa'")
 	}
+	if len(gitProviderConfig.KnownHosts) > 0 {
+		if err := validateKnownHosts(gitProviderConfig.KnownHosts); err != nil {
+			return err
+		}
+	}
 
 	return validateRepositoryUrl(gitProviderConfig.RepositoryUrl)
 }
 
+// validateKnownHosts checks every entry of knownHosts is a valid known_hosts line.
+func validateKnownHosts(knownHosts string) error {
+	rest := []byte(knownHosts)
+	for {
+		_, _, _, _, next, err := ssh.ParseKnownHosts(rest)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("'knownHosts' is not valid in gitProviderConfig: %v", err)
+		}
+		rest = next
+	}
+}
+
 func validateGithubProviderConfig(config GithubProviderConfig) error {
 	if len(config.Owner) <= 0 {
 		return errors.New("'owner' is not set or empty in githubProviderConfig; owner is a required field")
@@ -71,6 +672,142 @@ func validateGithubProviderConfig(config GithubProviderConfig) error {
 	if err != nil {
 		return err
 	}
+	if len(config.Hostname) > 0 {
+		if errs := validation.IsDNS1123Subdomain(config.Hostname); len(errs) > 0 {
+			return fmt.Errorf("'hostname' %s is not valid in githubProviderConfig; hostname must be a valid DNS name without scheme", config.Hostname)
+		}
+	}
+	return nil
+}
+
+func validateGitlabProviderConfig(config GitlabProviderConfig) error {
+	if len(config.Owner) <= 0 {
+		return errors.New("'owner' is not set or empty in gitlabProviderConfig; owner is a required field")
+	}
+	if len(config.Repository) <= 0 {
+		return errors.New("'repository' is not set or empty in gitlabProviderConfig; repository is a required field")
+	}
+	if err := validateGitRepoName(config.Repository); err != nil {
+		return err
+	}
+	if len(config.Hostname) > 0 {
+		if errs := validation.IsDNS1123Subdomain(config.Hostname); len(errs) > 0 {
+			return fmt.Errorf("'hostname' %s is not valid in gitlabProviderConfig; hostname must be a valid DNS name without scheme", config.Hostname)
+		}
+	}
+	return nil
+}
+
+func validateBitbucketServerProviderConfig(config BitbucketServerProviderConfig) error {
+	if len(config.Owner) <= 0 {
+		return errors.New("'owner' is not set or empty in bitbucketServerProviderConfig; owner is a required field")
+	}
+	if len(config.Repository) <= 0 {
+		return errors.New("'repository' is not set or empty in bitbucketServerProviderConfig; repository is a required field")
+	}
+	if err := validateGitRepoName(config.Repository); err != nil {
+		return err
+	}
+	if len(config.Username) <= 0 {
+		return errors.New("'username' is not set or empty in bitbucketServerProviderConfig; username is a required field")
+	}
+	if len(config.Hostname) <= 0 {
+		return errors.New("'hostname' is not set or empty in bitbucketServerProviderConfig; hostname is a required field")
+	}
+	if errs := validation.IsDNS1123Subdomain(config.Hostname); len(errs) > 0 {
+		return fmt.Errorf("'hostname' %s is not valid in bitbucketServerProviderConfig; hostname must be a valid DNS name without scheme", config.Hostname)
+	}
+	return nil
+}
+
+func validateAzureDevOpsProviderConfig(config AzureDevOpsProviderConfig) error {
+	if len(config.Organization) <= 0 {
+		return errors.New("'organization' is not set or empty in azureDevOpsProviderConfig; organization is a required field")
+	}
+	if len(config.Project) <= 0 {
+		return errors.New("'project' is not set or empty in azureDevOpsProviderConfig; project is a required field")
+	}
+	if len(config.Repository) <= 0 {
+		return errors.New("'repository' is not set or empty in azureDevOpsProviderConfig; repository is a required field")
+	}
+	if err := validateGitRepoName(config.Repository); err != nil {
+		return err
+	}
+	return nil
+}
+
+func validateGiteaProviderConfig(config GiteaProviderConfig) error {
+	if len(config.Owner) <= 0 {
+		return errors.New("'owner' is not set or empty in giteaProviderConfig; owner is a required field")
+	}
+	if len(config.Repository) <= 0 {
+		return errors.New("'repository' is not set or empty in giteaProviderConfig; repository is a required field")
+	}
+	if err := validateGitRepoName(config.Repository); err != nil {
+		return err
+	}
+	if len(config.Hostname) <= 0 {
+		return errors.New("'hostname' is not set or empty in giteaProviderConfig; hostname is a required field")
+	}
+	if errs := validation.IsDNS1123Subdomain(strings.Split(config.Hostname, ":")[0]); len(errs) > 0 {
+		return fmt.Errorf("'hostname' %s is not valid in giteaProviderConfig; hostname must be a valid DNS name without scheme", config.Hostname)
+	}
+	return nil
+}
+
+func validateCodeCommitProviderConfig(config CodeCommitProviderConfig) error {
+	if len(config.Region) <= 0 {
+		return errors.New("'region' is not set or empty in codeCommitProviderConfig; region is a required field")
+	}
+	if len(config.Repository) <= 0 {
+		return errors.New("'repository' is not set or empty in codeCommitProviderConfig; repository is a required field")
+	}
+	return validateGitRepoName(config.Repository)
+}
+
+func validateOCIRepositoryConfig(config OCIRepositoryConfig) error {
+	if len(config.Url) <= 0 {
+		return errors.New("'url' is not set or empty in ociRepository; url is a required field")
+	}
+	u, err := url.Parse(config.Url)
+	if err != nil {
+		return fmt.Errorf("unable to parse url in ociRepository: %v", err)
+	}
+	if u.Scheme != "oci" {
+		return fmt.Errorf("invalid url scheme in ociRepository: %v", u.Scheme)
+	}
+	if strings.Contains(path.Base(u.Path), ":") || strings.Contains(u.Path, "@") {
+		return errors.New("'url' must not contain a tag or digest in ociRepository; use the tag field instead")
+	}
+	if len(config.SecretRef) > 0 {
+		if errs := validation.IsDNS1123Subdomain(config.SecretRef); len(errs) > 0 {
+			return fmt.Errorf("'secretRef' %s is not valid in ociRepository; secretRef must be a lowercase RFC 1123 subdomain", config.SecretRef)
+		}
+	}
+	return nil
+}
+
+func validateBucketConfig(config BucketConfig) error {
+	if len(config.BucketName) <= 0 {
+		return errors.New("'bucketName' is not set or empty in bucket; bucketName is a required field")
+	}
+	if errs := validation.IsDNS1123Subdomain(config.BucketName); len(errs) > 0 {
+		return fmt.Errorf("'bucketName' %s is not valid in bucket; bucketName must be a lowercase RFC 1123 subdomain", config.BucketName)
+	}
+	if len(config.Endpoint) <= 0 {
+		return errors.New("'endpoint' is not set or empty in bucket; endpoint is a required field")
+	}
+	if strings.Contains(config.Endpoint, "://") {
+		return fmt.Errorf("'endpoint' %s must not contain a scheme in bucket; use the insecure field for plain HTTP", config.Endpoint)
+	}
+	if len(config.Provider) > 0 && config.Provider != FluxBucketGenericProvider && config.Provider != FluxBucketAWSProvider {
+		return fmt.Errorf("'provider' %s is not supported in bucket; supported providers are %s and %s", config.Provider, FluxBucketGenericProvider, FluxBucketAWSProvider)
+	}
+	if len(config.SecretRef) > 0 {
+		if errs := validation.IsDNS1123Subdomain(config.SecretRef); len(errs) > 0 {
+			return fmt.Errorf("'secretRef' %s is not valid in bucket; secretRef must be a lowercase RFC 1123 subdomain", config.SecretRef)
+		}
+	}
 	return nil
 }
 
@@ -82,6 +819,11 @@ func validateRepositoryUrl(repositoryUrl string) error {
 	if url.Scheme != "ssh" {
 		return fmt.Errorf("invalid repository url scheme: %v", url.Scheme)
 	}
+	if url.User != nil {
+		if _, ok := url.User.Password(); ok {
+			return errors.New("'repositoryUrl' must not contain embedded credentials; remove them from the url and provide the private key through the EKSA_GIT_PRIVATE_KEY environment variable instead")
+		}
+	}
 	return nil
 }
 
@@ -105,4 +847,54 @@ func setFluxConfigDefaults(flux *FluxConfig) {
 	if len(c.Branch) == 0 {
 		c.Branch = FluxDefaultBranch
 	}
+
+	if c.OCIRepository != nil && len(c.OCIRepository.Tag) == 0 {
+		c.OCIRepository.Tag = FluxDefaultOCITag
+	}
+
+	if c.Bucket != nil {
+		if len(c.Bucket.Region) == 0 {
+			c.Bucket.Region = FluxDefaultBucketRegion
+		}
+		if len(c.Bucket.Provider) == 0 {
+			c.Bucket.Provider = FluxBucketGenericProvider
+		}
+	}
+
+	for i := range c.Notifications {
+		if len(c.Notifications[i].EventSeverity) == 0 {
+			c.Notifications[i].EventSeverity = FluxNotificationSeverityError
+		}
+	}
+
+	if c.Decryption != nil && len(c.Decryption.Provider) == 0 {
+		c.Decryption.Provider = FluxSopsDecryptionProvider
+	}
+
+	if c.SealedSecrets != nil {
+		if len(c.SealedSecrets.RepositoryUrl) == 0 {
+			c.SealedSecrets.RepositoryUrl = FluxDefaultSealedSecretsRepositoryUrl
+		}
+		if len(c.SealedSecrets.Namespace) == 0 {
+			c.SealedSecrets.Namespace = FluxDefaultSealedSecretsNamespace
+		}
+	}
+
+	if c.ExternalSecret != nil {
+		if len(c.ExternalSecret.SecretStoreRef.Kind) == 0 {
+			c.ExternalSecret.SecretStoreRef.Kind = FluxClusterSecretStoreKind
+		}
+		if len(c.ExternalSecret.RefreshInterval) == 0 {
+			c.ExternalSecret.RefreshInterval = FluxDefaultExternalSecretRefreshInterval
+		}
+	}
+}
+
+func sliceContains(s []string, str string) bool {
+	for _, elem := range s {
+		if elem == str {
+			return true
+		}
+	}
+	return false
 }
@@ -15,6 +15,7 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -23,9 +24,21 @@ type FluxConfigSpec struct {
 	// SystemNamespace scope for this operation. Defaults to flux-system
 	SystemNamespace string `json:"systemNamespace,omitempty"`
 
+	// Used to set labels and annotations on the system namespace, like pod security admission labels. The namespace
+	// is created with them before flux is bootstrapped.
+	SystemNamespaceMetadata *FluxNamespaceMetadata `json:"systemNamespaceMetadata,omitempty"`
+
 	// ClusterConfigPath relative to the repository root, when specified the cluster sync will be scoped to this path.
 	ClusterConfigPath string `json:"clusterConfigPath,omitempty"`
 
+	// EksaSystemDir is the directory of the cluster config files, relative to the cluster config path. It can be a
+	// template using the cluster metadata. Defaults to <cluster name>/eksa-system.
+	EksaSystemDir string `json:"eksaSystemDir,omitempty"`
+
+	// FluxSystemDir is the directory of the flux components, relative to the cluster config path. It can be a template
+	// using the cluster metadata and must end with the system namespace. Defaults to the system namespace.
+	FluxSystemDir string `json:"fluxSystemDir,omitempty"`
+
 	// Git branch. Defaults to main.
 	// +kubebuilder:default:="main"
 	Branch string `json:"branch,omitempty"`
@@ -35,6 +48,89 @@ type FluxConfigSpec struct {
 
 	// Used to specify Git provider that will be used to host the git files
 	Git *GitProviderConfig `json:"git,omitempty"`
+
+	// Used to specify Gitlab provider to host the Git repo and host the git files
+	Gitlab *GitlabProviderConfig `json:"gitlab,omitempty"`
+
+	// Used to specify Bitbucket Server provider to host the Git repo and host the git files
+	BitbucketServer *BitbucketServerProviderConfig `json:"bitbucketServer,omitempty"`
+
+	// Used to specify Azure DevOps Repos provider to host the Git repo and host the git files
+	AzureDevOps *AzureDevOpsProviderConfig `json:"azureDevOps,omitempty"`
+
+	// Used to specify Gitea provider to host the Git repo and host the git files
+	Gitea *GiteaProviderConfig `json:"gitea,omitempty"`
+
+	// Used to specify AWS CodeCommit provider to host the Git repo and host the git files
+	CodeCommit *CodeCommitProviderConfig `json:"codeCommit,omitempty"`
+
+	// Used to publish the cluster manifests as an OCI artifact that flux syncs from instead of a Git repo
+	OCIRepository *OCIRepositoryConfig `json:"ociRepository,omitempty"`
+
+	// Used to upload the cluster manifests to an S3 compatible bucket that flux syncs from instead of a Git repo
+	Bucket *BucketConfig `json:"bucket,omitempty"`
+
+	// Used to generate a Flux notification Receiver so reconciliation can be triggered by a webhook
+	Receiver *FluxReceiverConfig `json:"receiver,omitempty"`
+
+	// Used to generate Flux notification Providers and Alerts so reconciliation events are sent to chat
+	Notifications []FluxNotificationConfig `json:"notifications,omitempty"`
+
+	// Used to generate Flux HelmRepository and HelmRelease manifests so add-ons can be delivered through the helm-controller
+	HelmCharts []FluxHelmChartConfig `json:"helmCharts,omitempty"`
+
+	// Used to install the sealed-secrets controller with the helm-controller, so SealedSecrets can be committed to the repository
+	SealedSecrets *FluxSealedSecretsConfig `json:"sealedSecrets,omitempty"`
+
+	// Used to source the flux-system git credentials from an External Secrets Operator ExternalSecret, so the
+	// credentials are rotated from the external secret store instead of being stored statically in the cluster
+	ExternalSecret *FluxExternalSecretConfig `json:"externalSecret,omitempty"`
+
+	// Used to bootstrap flux with multi-tenancy lockdown options
+	MultiTenancy *FluxMultiTenancyConfig `json:"multiTenancy,omitempty"`
+
+	// Used to reconcile a manifests directory of each workload cluster into the workload cluster from the flux of the
+	// management cluster, through the kubeconfig secret of the cluster, so flux only runs in the management cluster
+	HubAndSpoke bool `json:"hubAndSpoke,omitempty"`
+
+	// Used to order the reconciliation of the flux-system Kustomization after other Flux Kustomizations
+	DependsOn []FluxKustomizationDependency `json:"dependsOn,omitempty"`
+
+	// Used to configure the decryption of SOPS encrypted manifests by the flux-system Kustomization
+	Decryption *FluxDecryptionConfig `json:"decryption,omitempty"`
+
+	// Used to install the image-reflector-controller and image-automation-controller with the toolkit components
+	ImageAutomation bool `json:"imageAutomation,omitempty"`
+
+	// Used to configure the intervals and timeouts of the flux-system source and Kustomization
+	Sync *FluxSyncConfig `json:"sync,omitempty"`
+
+	// Used to select the toolkit components installed at bootstrap. Defaults to all the default flux components
+	Components []string `json:"components,omitempty"`
+
+	// Used to install additional toolkit components at bootstrap
+	ComponentsExtra []string `json:"componentsExtra,omitempty"`
+
+	// Used to set the resource requests and limits of the toolkit controllers, keyed by controller name
+	ControllerResources map[string]FluxResourceRequirements `json:"controllerResources,omitempty"`
+
+	// Used to schedule the toolkit controllers on specific nodes, like the control plane or infrastructure nodes
+	ControllerScheduling *FluxSchedulingConfig `json:"controllerScheduling,omitempty"`
+
+	// Used to merge extra resources, patches and common metadata into the generated kustomization of the eksa-system directory
+	EksaSystemKustomize *FluxKustomizeConfig `json:"eksaSystemKustomize,omitempty"`
+
+	// Used to commit the EKS-A bundles and the EKS Distro release next to the cluster config in the eksa-system directory,
+	// so a disconnected management cluster can reconcile them from the repository
+	CommitReleaseManifests bool `json:"commitReleaseManifests,omitempty"`
+
+	// CredentialsSecretRef is the name of a secret in the system namespace of the management cluster with the
+	// provider access token in its token key. When set, it's used instead of the token environment variable.
+	CredentialsSecretRef string `json:"credentialsSecretRef,omitempty"`
+
+	// Used to let the controller commit changes made to the cluster config in the management cluster back to the
+	// repository, so flux doesn't revert them. Only supported with a git repository.
+	ReverseSync bool `json:"reverseSync,omitempty"`
 }
 
 type GithubProviderConfig struct {
@@ -46,6 +142,107 @@ type GithubProviderConfig struct {
 
 	// if true, the owner is assumed to be a Git user; otherwise an org.
 	Personal bool `json:"personal,omitempty"`
+
+	// Hostname of the Github Enterprise Server instance. Defaults to github.com.
+	Hostname string `json:"hostname,omitempty"`
+}
+
+type GitlabProviderConfig struct {
+	// Owner is the user or group path of the Gitlab project.
+	Owner string `json:"owner"`
+
+	// Repository name of the Gitlab project.
+	Repository string `json:"repository"`
+
+	// Hostname of the Gitlab instance, for self-hosted Gitlab. Defaults to gitlab.com.
+	Hostname string `json:"hostname,omitempty"`
+
+	// if true, the owner is assumed to be a Gitlab user; otherwise a group.
+	Personal bool `json:"personal,omitempty"`
+}
+
+type BitbucketServerProviderConfig struct {
+	// Owner is the project key, or the user name for personal repositories.
+	Owner string `json:"owner"`
+
+	// Repository name.
+	Repository string `json:"repository"`
+
+	// Hostname of the Bitbucket Server instance.
+	Hostname string `json:"hostname"`
+
+	// Username used to authenticate to the Bitbucket Server instance.
+	Username string `json:"username"`
+
+	// if true, the owner is assumed to be a Bitbucket user; otherwise a project.
+	Personal bool `json:"personal,omitempty"`
+}
+
+type AzureDevOpsProviderConfig struct {
+	// Organization is the Azure DevOps organization name.
+	Organization string `json:"organization"`
+
+	// Project is the Azure DevOps project holding the repository.
+	Project string `json:"project"`
+
+	// Repository name.
+	Repository string `json:"repository"`
+}
+
+type GiteaProviderConfig struct {
+	// Owner is the user or organization name of the Gitea repository.
+	Owner string `json:"owner"`
+
+	// Repository name.
+	Repository string `json:"repository"`
+
+	// Hostname of the Gitea instance.
+	Hostname string `json:"hostname"`
+
+	// if true, the owner is assumed to be a Gitea user; otherwise an org.
+	Personal bool `json:"personal,omitempty"`
+}
+
+type CodeCommitProviderConfig struct {
+	// Region is the AWS region of the CodeCommit repository.
+	Region string `json:"region"`
+
+	// Repository name.
+	Repository string `json:"repository"`
+}
+
+type OCIRepositoryConfig struct {
+	// Url of the OCI artifact without tag, i.e. oci://registry.local/eksa/fleet.
+	Url string `json:"url"`
+
+	// Tag of the OCI artifact. Defaults to latest.
+	Tag string `json:"tag,omitempty"`
+
+	// SecretRef is the name of the docker-registry secret in the system namespace used to pull the artifact.
+	SecretRef string `json:"secretRef,omitempty"`
+
+	// Insecure allows connecting to a registry over plain HTTP.
+	Insecure bool `json:"insecure,omitempty"`
+}
+
+type BucketConfig struct {
+	// BucketName is the name of the bucket holding the cluster manifests.
+	BucketName string `json:"bucketName"`
+
+	// Endpoint of the S3 compatible API without scheme, i.e. s3.us-west-2.amazonaws.com or minio.local:9000.
+	Endpoint string `json:"endpoint"`
+
+	// Region of the bucket. Defaults to us-east-1.
+	Region string `json:"region,omitempty"`
+
+	// Provider of the bucket, generic for any S3 compatible storage or aws for Amazon S3 with IAM auth. Defaults to generic.
+	Provider string `json:"provider,omitempty"`
+
+	// SecretRef is the name of the secret in the system namespace with the accesskey and secretkey used to read the bucket.
+	SecretRef string `json:"secretRef,omitempty"`
+
+	// Insecure allows connecting to the endpoint over plain HTTP.
+	Insecure bool `json:"insecure,omitempty"`
 }
 
 type GitProviderConfig struct {
@@ -54,6 +251,239 @@ type GitProviderConfig struct {
 
 	// SSH public key algorithm for the private key specified (rsa, ecdsa, ed25519) (default ecdsa)
 	SshKeyAlgorithm string `json:"sshKeyAlgorithm,omitempty"`
+
+	// KnownHosts are known_hosts entries pinning the SSH host keys of the repository host. When set, they're used
+	// to verify the host instead of the EKSA_GIT_KNOWN_HOSTS file.
+	KnownHosts string `json:"knownHosts,omitempty"`
+}
+
+type FluxReceiverConfig struct {
+	// Type of the webhook sender (github, gitlab, bitbucket, harbor, dockerhub, quay, gcr, nexus, acr, generic, generic-hmac).
+	Type string `json:"type"`
+
+	// SecretName is the name of the secret in the system namespace holding the webhook token.
+	SecretName string `json:"secretName"`
+
+	// Events filter the webhook events that trigger a reconciliation. Defaults to all events.
+	Events []string `json:"events,omitempty"`
+
+	// WebhookUrl is the external url the notification-controller webhook receiver is exposed at. When set, the CLI
+	// generates the webhook token secret and, with the github provider, registers the webhook in the repository.
+	WebhookUrl string `json:"webhookUrl,omitempty"`
+}
+
+type FluxNotificationConfig struct {
+	// Name of the generated Provider and Alert.
+	Name string `json:"name"`
+
+	// Type of the notification provider (slack, msteams, discord, googlechat, generic).
+	Type string `json:"type"`
+
+	// Channel the events are posted to, for the providers that support it like slack.
+	Channel string `json:"channel,omitempty"`
+
+	// SecretName is the name of the secret in the system namespace holding the webhook url in the address key.
+	SecretName string `json:"secretName"`
+
+	// EventSeverity of the events sent to the provider, info or error. Defaults to error.
+	EventSeverity string `json:"eventSeverity,omitempty"`
+}
+
+type FluxHelmChartConfig struct {
+	// Name of the generated HelmRepository and HelmRelease.
+	Name string `json:"name"`
+
+	// Chart name in the Helm repository. Defaults to Name.
+	Chart string `json:"chart,omitempty"`
+
+	// Version of the chart, it can be a semver range. Defaults to the latest version.
+	Version string `json:"version,omitempty"`
+
+	// RepositoryUrl of the Helm repository hosting the chart. Must be an HTTP or HTTPS url.
+	RepositoryUrl string `json:"repositoryUrl"`
+
+	// TargetNamespace the release is installed in. Defaults to the system namespace.
+	TargetNamespace string `json:"targetNamespace,omitempty"`
+}
+
+type FluxSealedSecretsConfig struct {
+	// Version of the sealed-secrets chart, it can be a semver range. Defaults to the latest version.
+	Version string `json:"version,omitempty"`
+
+	// RepositoryUrl of the Helm repository hosting the sealed-secrets chart, i.e. a mirror in air-gapped environments.
+	// Defaults to the repository of the sealed-secrets project.
+	RepositoryUrl string `json:"repositoryUrl,omitempty"`
+
+	// Namespace the controller is installed in. Defaults to kube-system, where kubeseal looks for it.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type FluxExternalSecretConfig struct {
+	// SecretStoreRef is the SecretStore or ClusterSecretStore of the External Secrets Operator holding the credentials.
+	SecretStoreRef FluxSecretStoreRef `json:"secretStoreRef"`
+
+	// RemoteKey is the key of the secret in the external store, i.e. the name of the AWS Secrets Manager secret or
+	// the path of the Vault secret. Its properties are copied to the flux-system secret, so it must hold the keys
+	// flux expects: identity, identity.pub and known_hosts for ssh, or username and password for https.
+	RemoteKey string `json:"remoteKey"`
+
+	// RefreshInterval is how often the External Secrets Operator syncs the credentials from the store. Defaults to 1h.
+	RefreshInterval string `json:"refreshInterval,omitempty"`
+}
+
+type FluxSecretStoreRef struct {
+	// Name of the secret store.
+	Name string `json:"name"`
+
+	// Kind of the secret store, SecretStore or ClusterSecretStore. Defaults to ClusterSecretStore.
+	Kind string `json:"kind,omitempty"`
+}
+
+type FluxMultiTenancyConfig struct {
+	// ClusterDomain is the internal domain of the cluster passed to flux bootstrap. Defaults to cluster.local.
+	ClusterDomain string `json:"clusterDomain,omitempty"`
+
+	// ServiceAccountName the flux-system Kustomization impersonates when reconciling the repository.
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// TargetNamespace overrides the namespace of the namespaced resources reconciled by the flux-system Kustomization.
+	TargetNamespace string `json:"targetNamespace,omitempty"`
+
+	// ClusterTenants gives each workload cluster its own tenant namespace, service account and Kustomization, scoped with
+	// RBAC to the namespace of the cluster, instead of reconciling it with the flux-system Kustomization.
+	ClusterTenants bool `json:"clusterTenants,omitempty"`
+}
+
+type FluxKustomizationDependency struct {
+	// Name of the Flux Kustomization the flux-system Kustomization depends on.
+	Name string `json:"name"`
+
+	// Namespace of the Flux Kustomization. Defaults to the system namespace.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type FluxDecryptionConfig struct {
+	// Provider of the decryption. Only sops is supported. Defaults to sops.
+	Provider string `json:"provider,omitempty"`
+
+	// Age configures the decryption with an age private key.
+	Age *FluxAgeDecryptionConfig `json:"age,omitempty"`
+
+	// AwsKms configures the decryption with an AWS KMS key.
+	AwsKms *FluxAwsKmsDecryptionConfig `json:"awsKms,omitempty"`
+}
+
+type FluxAgeDecryptionConfig struct {
+	// SecretName is the name of the secret in the system namespace holding the age private key in a key ending with .agekey.
+	SecretName string `json:"secretName"`
+}
+
+type FluxAwsKmsDecryptionConfig struct {
+	// SecretName is the name of the secret in the system namespace holding the AWS credentials in the sops.aws-kms key.
+	// When not set, the kustomize-controller decrypts with the credentials of its own IAM role.
+	SecretName string `json:"secretName,omitempty"`
+}
+
+type FluxSyncConfig struct {
+	// Interval at which the flux-system source is fetched and the flux-system Kustomization is reconciled.
+	Interval string `json:"interval,omitempty"`
+
+	// Timeout of the fetch of the flux-system source and of the apply and health checks of the flux-system Kustomization.
+	Timeout string `json:"timeout,omitempty"`
+
+	// RetryInterval is the interval at which a failed reconciliation of the flux-system Kustomization is retried.
+	// Defaults to the interval.
+	RetryInterval string `json:"retryInterval,omitempty"`
+
+	// Prune enables garbage collection of the objects removed from the repository by the Kustomizations reconciling
+	// the cluster config files. Defaults to true.
+	Prune *bool `json:"prune,omitempty"`
+
+	// Wait makes the flux-system Kustomization wait for all the objects it applies to be ready, so it only reports
+	// Ready once they're reconciled. Only supported with an OCI repository or a bucket.
+	Wait bool `json:"wait,omitempty"`
+
+	// HealthChecks are the objects the flux-system Kustomization waits to be ready, like the EKS-A Cluster object,
+	// before it reports Ready.
+	HealthChecks []FluxHealthCheck `json:"healthChecks,omitempty"`
+}
+
+type FluxHealthCheck struct {
+	// APIVersion of the object, like anywhere.eks.amazonaws.com/v1alpha1.
+	APIVersion string `json:"apiVersion,omitempty"`
+
+	// Kind of the object, like Cluster.
+	Kind string `json:"kind"`
+
+	// Name of the object.
+	Name string `json:"name"`
+
+	// Namespace of the object. Defaults to the namespace of the Kustomization.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type FluxResourceRequirements struct {
+	// Requests are the minimum resources the controller is scheduled with.
+	Requests FluxResourceList `json:"requests,omitempty"`
+
+	// Limits are the maximum resources the controller can use.
+	Limits FluxResourceList `json:"limits,omitempty"`
+}
+
+type FluxResourceList struct {
+	// CPU quantity, for example 100m.
+	CPU string `json:"cpu,omitempty"`
+
+	// Memory quantity, for example 256Mi.
+	Memory string `json:"memory,omitempty"`
+}
+
+type FluxSchedulingConfig struct {
+	// NodeSelector are the labels a node must have for the controllers to be scheduled on it.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations allow the controllers to be scheduled on nodes with matching taints.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+type FluxKustomizeConfig struct {
+	// Resources are extra files or directories added to the kustomization, relative to the eksa-system directory.
+	Resources []string `json:"resources,omitempty"`
+
+	// Patches are strategic merge or JSON 6902 patches applied to the resources of the kustomization.
+	Patches []FluxKustomizePatch `json:"patches,omitempty"`
+
+	// CommonLabels are added to all the resources of the kustomization.
+	CommonLabels map[string]string `json:"commonLabels,omitempty"`
+
+	// CommonAnnotations are added to all the resources of the kustomization.
+	CommonAnnotations map[string]string `json:"commonAnnotations,omitempty"`
+}
+
+type FluxNamespaceMetadata struct {
+	// Labels are added to the namespace.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are added to the namespace.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type FluxKustomizePatch struct {
+	// Patch is the content of the strategic merge or JSON 6902 patch.
+	Patch string `json:"patch"`
+
+	// Target selects the resources the patch is applied to. Required for JSON 6902 patches.
+	Target *FluxKustomizePatchTarget `json:"target,omitempty"`
+}
+
+type FluxKustomizePatchTarget struct {
+	Group              string `json:"group,omitempty"`
+	Version            string `json:"version,omitempty"`
+	Kind               string `json:"kind,omitempty"`
+	Name               string `json:"name,omitempty"`
+	Namespace          string `json:"namespace,omitempty"`
+	LabelSelector      string `json:"labelSelector,omitempty"`
+	AnnotationSelector string `json:"annotationSelector,omitempty"`
 }
 
 // FluxConfigStatus defines the observed state of FluxConfig.
@@ -97,7 +527,58 @@ func (e *FluxConfigSpec) Equal(n *FluxConfigSpec) bool {
 	if e.ClusterConfigPath != n.ClusterConfigPath {
 		return false
 	}
-	return e.Git.Equal(n.Git) && e.Github.Equal(n.Github)
+	if e.EksaSystemDir != n.EksaSystemDir || e.FluxSystemDir != n.FluxSystemDir {
+		return false
+	}
+	if e.CredentialsSecretRef != n.CredentialsSecretRef {
+		return false
+	}
+	if e.ImageAutomation != n.ImageAutomation || e.ReverseSync != n.ReverseSync || e.HubAndSpoke != n.HubAndSpoke {
+		return false
+	}
+	if !SliceEqual(e.Components, n.Components) || !SliceEqual(e.ComponentsExtra, n.ComponentsExtra) {
+		return false
+	}
+	return e.Git.Equal(n.Git) && e.Github.Equal(n.Github) && e.Gitlab.Equal(n.Gitlab) && e.BitbucketServer.Equal(n.BitbucketServer) && e.AzureDevOps.Equal(n.AzureDevOps) && e.Gitea.Equal(n.Gitea) && e.CodeCommit.Equal(n.CodeCommit) && e.OCIRepository.Equal(n.OCIRepository) && e.Bucket.Equal(n.Bucket) && e.Receiver.Equal(n.Receiver) && notificationsEqual(e.Notifications, n.Notifications) && helmChartsEqual(e.HelmCharts, n.HelmCharts) &&
+		e.MultiTenancy.Equal(n.MultiTenancy) && dependsOnEqual(e.DependsOn, n.DependsOn) && e.Decryption.Equal(n.Decryption) && e.Sync.Equal(n.Sync) &&
+		e.EksaSystemKustomize.Equal(n.EksaSystemKustomize) && e.SystemNamespaceMetadata.Equal(n.SystemNamespaceMetadata) && e.SealedSecrets.Equal(n.SealedSecrets) &&
+		e.ExternalSecret.Equal(n.ExternalSecret)
+}
+
+func notificationsEqual(a, b []FluxNotificationConfig) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func helmChartsEqual(a, b []FluxHelmChartConfig) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func dependsOnEqual(a, b []FluxKustomizationDependency) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
 }
 
 func (e *GithubProviderConfig) Equal(n *GithubProviderConfig) bool {
@@ -110,6 +591,76 @@ func (e *GithubProviderConfig) Equal(n *GithubProviderConfig) bool {
 	return *e == *n
 }
 
+func (e *GitlabProviderConfig) Equal(n *GitlabProviderConfig) bool {
+	if e == n {
+		return true
+	}
+	if e == nil || n == nil {
+		return false
+	}
+	return *e == *n
+}
+
+func (e *BitbucketServerProviderConfig) Equal(n *BitbucketServerProviderConfig) bool {
+	if e == n {
+		return true
+	}
+	if e == nil || n == nil {
+		return false
+	}
+	return *e == *n
+}
+
+func (e *AzureDevOpsProviderConfig) Equal(n *AzureDevOpsProviderConfig) bool {
+	if e == n {
+		return true
+	}
+	if e == nil || n == nil {
+		return false
+	}
+	return *e == *n
+}
+
+func (e *GiteaProviderConfig) Equal(n *GiteaProviderConfig) bool {
+	if e == n {
+		return true
+	}
+	if e == nil || n == nil {
+		return false
+	}
+	return *e == *n
+}
+
+func (e *CodeCommitProviderConfig) Equal(n *CodeCommitProviderConfig) bool {
+	if e == n {
+		return true
+	}
+	if e == nil || n == nil {
+		return false
+	}
+	return *e == *n
+}
+
+func (e *OCIRepositoryConfig) Equal(n *OCIRepositoryConfig) bool {
+	if e == n {
+		return true
+	}
+	if e == nil || n == nil {
+		return false
+	}
+	return *e == *n
+}
+
+func (e *BucketConfig) Equal(n *BucketConfig) bool {
+	if e == n {
+		return true
+	}
+	if e == nil || n == nil {
+		return false
+	}
+	return *e == *n
+}
+
 func (e *GitProviderConfig) Equal(n *GitProviderConfig) bool {
 	if e == n {
 		return true
@@ -120,6 +671,147 @@ func (e *GitProviderConfig) Equal(n *GitProviderConfig) bool {
 	return *e == *n
 }
 
+func (e *FluxMultiTenancyConfig) Equal(n *FluxMultiTenancyConfig) bool {
+	if e == n {
+		return true
+	}
+	if e == nil || n == nil {
+		return false
+	}
+	return *e == *n
+}
+
+func (e *FluxReceiverConfig) Equal(n *FluxReceiverConfig) bool {
+	if e == n {
+		return true
+	}
+	if e == nil || n == nil {
+		return false
+	}
+	return e.Type == n.Type && e.SecretName == n.SecretName && SliceEqual(e.Events, n.Events) && e.WebhookUrl == n.WebhookUrl
+}
+
+func (e *FluxSyncConfig) Equal(n *FluxSyncConfig) bool {
+	if e == n {
+		return true
+	}
+	if e == nil || n == nil {
+		return false
+	}
+	if len(e.HealthChecks) != len(n.HealthChecks) {
+		return false
+	}
+	for i := range e.HealthChecks {
+		if e.HealthChecks[i] != n.HealthChecks[i] {
+			return false
+		}
+	}
+	return e.Interval == n.Interval && e.Timeout == n.Timeout && e.RetryInterval == n.RetryInterval && e.PruneEnabled() == n.PruneEnabled() &&
+		e.Wait == n.Wait
+}
+
+// IsEmpty returns true if none of the sync settings are configured.
+func (e *FluxSyncConfig) IsEmpty() bool {
+	return e == nil || (e.Interval == "" && e.Timeout == "" && e.RetryInterval == "" && e.Prune == nil && !e.Wait && len(e.HealthChecks) == 0)
+}
+
+// PruneEnabled returns true if the Kustomizations reconciling the cluster config files prune the objects removed from
+// the repository, which is the default.
+func (e *FluxSyncConfig) PruneEnabled() bool {
+	return e == nil || e.Prune == nil || *e.Prune
+}
+
+func (e *FluxKustomizeConfig) Equal(n *FluxKustomizeConfig) bool {
+	if e == n {
+		return true
+	}
+	if e == nil || n == nil {
+		return false
+	}
+	if !SliceEqual(e.Resources, n.Resources) || !MapEqual(e.CommonLabels, n.CommonLabels) || !MapEqual(e.CommonAnnotations, n.CommonAnnotations) {
+		return false
+	}
+	if len(e.Patches) != len(n.Patches) {
+		return false
+	}
+	for i := range e.Patches {
+		if e.Patches[i].Patch != n.Patches[i].Patch || !e.Patches[i].Target.Equal(n.Patches[i].Target) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *FluxSealedSecretsConfig) Equal(n *FluxSealedSecretsConfig) bool {
+	if e == n {
+		return true
+	}
+	if e == nil || n == nil {
+		return false
+	}
+	return *e == *n
+}
+
+func (e *FluxExternalSecretConfig) Equal(n *FluxExternalSecretConfig) bool {
+	if e == n {
+		return true
+	}
+	if e == nil || n == nil {
+		return false
+	}
+	return *e == *n
+}
+
+func (e *FluxNamespaceMetadata) Equal(n *FluxNamespaceMetadata) bool {
+	if e == n {
+		return true
+	}
+	if e == nil || n == nil {
+		return false
+	}
+	return MapEqual(e.Labels, n.Labels) && MapEqual(e.Annotations, n.Annotations)
+}
+
+func (e *FluxKustomizePatchTarget) Equal(n *FluxKustomizePatchTarget) bool {
+	if e == n {
+		return true
+	}
+	if e == nil || n == nil {
+		return false
+	}
+	return *e == *n
+}
+
+func (e *FluxDecryptionConfig) Equal(n *FluxDecryptionConfig) bool {
+	if e == n {
+		return true
+	}
+	if e == nil || n == nil {
+		return false
+	}
+	return e.Provider == n.Provider && e.Age.Equal(n.Age) && e.AwsKms.Equal(n.AwsKms)
+}
+
+func (e *FluxAgeDecryptionConfig) Equal(n *FluxAgeDecryptionConfig) bool {
+	if e == n {
+		return true
+	}
+	if e == nil || n == nil {
+		return false
+	}
+	return *e == *n
+}
+
+func (e *FluxAwsKmsDecryptionConfig) Equal(n *FluxAwsKmsDecryptionConfig) bool {
+	if e == n {
+		return true
+	}
+	if e == nil || n == nil {
+		return false
+	}
+	return *e == *n
+}
+
 //+kubebuilder:object:root=true
 
 // FluxConfigList contains a list of FluxConfig.
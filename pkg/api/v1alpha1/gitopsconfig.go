@@ -30,7 +30,7 @@ func validateGitOpsConfig(config *GitOpsConfig) error {
 		return err
 	}
 	if len(flux.Github.Branch) > 0 {
-		err := validateGitBranchName(config.Spec.Flux.Github.Branch)
+		err := ValidateGitBranchName(config.Spec.Flux.Github.Branch)
 		if err != nil {
 			return err
 		}
@@ -39,7 +39,8 @@ func validateGitOpsConfig(config *GitOpsConfig) error {
 	return nil
 }
 
-func validateGitBranchName(branchName string) error {
+// ValidateGitBranchName returns an error if the branch name is not a valid git branch name.
+func ValidateGitBranchName(branchName string) error {
 	allowedGitBranchNameRegex := regexp.MustCompile(`^([0-9A-Za-z\_\+,]+)\.?\/?([0-9A-Za-z\-\_\+,]+)$`)
 
 	if !allowedGitBranchNameRegex.MatchString(branchName) {
@@ -238,6 +238,51 @@ func (in *AutoScalingConfiguration) DeepCopy() *AutoScalingConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureDevOpsProviderConfig) DeepCopyInto(out *AzureDevOpsProviderConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureDevOpsProviderConfig.
+func (in *AzureDevOpsProviderConfig) DeepCopy() *AzureDevOpsProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureDevOpsProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BitbucketServerProviderConfig) DeepCopyInto(out *BitbucketServerProviderConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BitbucketServerProviderConfig.
+func (in *BitbucketServerProviderConfig) DeepCopy() *BitbucketServerProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BitbucketServerProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketConfig) DeepCopyInto(out *BucketConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketConfig.
+func (in *BucketConfig) DeepCopy() *BucketConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BundlesRef) DeepCopyInto(out *BundlesRef) {
 	*out = *in
@@ -696,6 +741,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(Ref)
 		**out = **in
 	}
+	if in.AdditionalGitOpsRefs != nil {
+		in, out := &in.AdditionalGitOpsRefs, &out.AdditionalGitOpsRefs
+		*out = make([]Ref, len(*in))
+		copy(*out, *in)
+	}
 	in.ClusterNetwork.DeepCopyInto(&out.ClusterNetwork)
 	if in.ExternalEtcdConfiguration != nil {
 		in, out := &in.ExternalEtcdConfiguration, &out.ExternalEtcdConfiguration
@@ -767,6 +817,21 @@ func (in *ClusterStatus) DeepCopy() *ClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CodeCommitProviderConfig) DeepCopyInto(out *CodeCommitProviderConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CodeCommitProviderConfig.
+func (in *CodeCommitProviderConfig) DeepCopy() *CodeCommitProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CodeCommitProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControlPlaneConfiguration) DeepCopyInto(out *ControlPlaneConfiguration) {
 	*out = *in
@@ -1017,6 +1082,36 @@ func (in *Flux) DeepCopy() *Flux {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluxAgeDecryptionConfig) DeepCopyInto(out *FluxAgeDecryptionConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxAgeDecryptionConfig.
+func (in *FluxAgeDecryptionConfig) DeepCopy() *FluxAgeDecryptionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FluxAgeDecryptionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluxAwsKmsDecryptionConfig) DeepCopyInto(out *FluxAwsKmsDecryptionConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxAwsKmsDecryptionConfig.
+func (in *FluxAwsKmsDecryptionConfig) DeepCopy() *FluxAwsKmsDecryptionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FluxAwsKmsDecryptionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FluxConfig) DeepCopyInto(out *FluxConfig) {
 	*out = *in
@@ -1079,6 +1174,11 @@ func (in *FluxConfigList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FluxConfigSpec) DeepCopyInto(out *FluxConfigSpec) {
 	*out = *in
+	if in.SystemNamespaceMetadata != nil {
+		in, out := &in.SystemNamespaceMetadata, &out.SystemNamespaceMetadata
+		*out = new(FluxNamespaceMetadata)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Github != nil {
 		in, out := &in.Github, &out.Github
 		*out = new(GithubProviderConfig)
@@ -1089,6 +1189,113 @@ func (in *FluxConfigSpec) DeepCopyInto(out *FluxConfigSpec) {
 		*out = new(GitProviderConfig)
 		**out = **in
 	}
+	if in.Gitlab != nil {
+		in, out := &in.Gitlab, &out.Gitlab
+		*out = new(GitlabProviderConfig)
+		**out = **in
+	}
+	if in.BitbucketServer != nil {
+		in, out := &in.BitbucketServer, &out.BitbucketServer
+		*out = new(BitbucketServerProviderConfig)
+		**out = **in
+	}
+	if in.AzureDevOps != nil {
+		in, out := &in.AzureDevOps, &out.AzureDevOps
+		*out = new(AzureDevOpsProviderConfig)
+		**out = **in
+	}
+	if in.Gitea != nil {
+		in, out := &in.Gitea, &out.Gitea
+		*out = new(GiteaProviderConfig)
+		**out = **in
+	}
+	if in.CodeCommit != nil {
+		in, out := &in.CodeCommit, &out.CodeCommit
+		*out = new(CodeCommitProviderConfig)
+		**out = **in
+	}
+	if in.OCIRepository != nil {
+		in, out := &in.OCIRepository, &out.OCIRepository
+		*out = new(OCIRepositoryConfig)
+		**out = **in
+	}
+	if in.Bucket != nil {
+		in, out := &in.Bucket, &out.Bucket
+		*out = new(BucketConfig)
+		**out = **in
+	}
+	if in.Receiver != nil {
+		in, out := &in.Receiver, &out.Receiver
+		*out = new(FluxReceiverConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]FluxNotificationConfig, len(*in))
+		copy(*out, *in)
+	}
+	if in.HelmCharts != nil {
+		in, out := &in.HelmCharts, &out.HelmCharts
+		*out = make([]FluxHelmChartConfig, len(*in))
+		copy(*out, *in)
+	}
+	if in.SealedSecrets != nil {
+		in, out := &in.SealedSecrets, &out.SealedSecrets
+		*out = new(FluxSealedSecretsConfig)
+		**out = **in
+	}
+	if in.ExternalSecret != nil {
+		in, out := &in.ExternalSecret, &out.ExternalSecret
+		*out = new(FluxExternalSecretConfig)
+		**out = **in
+	}
+	if in.MultiTenancy != nil {
+		in, out := &in.MultiTenancy, &out.MultiTenancy
+		*out = new(FluxMultiTenancyConfig)
+		**out = **in
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]FluxKustomizationDependency, len(*in))
+		copy(*out, *in)
+	}
+	if in.Decryption != nil {
+		in, out := &in.Decryption, &out.Decryption
+		*out = new(FluxDecryptionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sync != nil {
+		in, out := &in.Sync, &out.Sync
+		*out = new(FluxSyncConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ComponentsExtra != nil {
+		in, out := &in.ComponentsExtra, &out.ComponentsExtra
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ControllerResources != nil {
+		in, out := &in.ControllerResources, &out.ControllerResources
+		*out = make(map[string]FluxResourceRequirements, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ControllerScheduling != nil {
+		in, out := &in.ControllerScheduling, &out.ControllerScheduling
+		*out = new(FluxSchedulingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EksaSystemKustomize != nil {
+		in, out := &in.EksaSystemKustomize, &out.EksaSystemKustomize
+		*out = new(FluxKustomizeConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxConfigSpec.
@@ -1116,6 +1323,362 @@ func (in *FluxConfigStatus) DeepCopy() *FluxConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluxDecryptionConfig) DeepCopyInto(out *FluxDecryptionConfig) {
+	*out = *in
+	if in.Age != nil {
+		in, out := &in.Age, &out.Age
+		*out = new(FluxAgeDecryptionConfig)
+		**out = **in
+	}
+	if in.AwsKms != nil {
+		in, out := &in.AwsKms, &out.AwsKms
+		*out = new(FluxAwsKmsDecryptionConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxDecryptionConfig.
+func (in *FluxDecryptionConfig) DeepCopy() *FluxDecryptionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FluxDecryptionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluxExternalSecretConfig) DeepCopyInto(out *FluxExternalSecretConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxExternalSecretConfig.
+func (in *FluxExternalSecretConfig) DeepCopy() *FluxExternalSecretConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FluxExternalSecretConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluxHealthCheck) DeepCopyInto(out *FluxHealthCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxHealthCheck.
+func (in *FluxHealthCheck) DeepCopy() *FluxHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(FluxHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluxHelmChartConfig) DeepCopyInto(out *FluxHelmChartConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxHelmChartConfig.
+func (in *FluxHelmChartConfig) DeepCopy() *FluxHelmChartConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FluxHelmChartConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluxKustomizationDependency) DeepCopyInto(out *FluxKustomizationDependency) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxKustomizationDependency.
+func (in *FluxKustomizationDependency) DeepCopy() *FluxKustomizationDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(FluxKustomizationDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluxKustomizeConfig) DeepCopyInto(out *FluxKustomizeConfig) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Patches != nil {
+		in, out := &in.Patches, &out.Patches
+		*out = make([]FluxKustomizePatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CommonLabels != nil {
+		in, out := &in.CommonLabels, &out.CommonLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CommonAnnotations != nil {
+		in, out := &in.CommonAnnotations, &out.CommonAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxKustomizeConfig.
+func (in *FluxKustomizeConfig) DeepCopy() *FluxKustomizeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FluxKustomizeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluxKustomizePatch) DeepCopyInto(out *FluxKustomizePatch) {
+	*out = *in
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(FluxKustomizePatchTarget)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxKustomizePatch.
+func (in *FluxKustomizePatch) DeepCopy() *FluxKustomizePatch {
+	if in == nil {
+		return nil
+	}
+	out := new(FluxKustomizePatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluxKustomizePatchTarget) DeepCopyInto(out *FluxKustomizePatchTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxKustomizePatchTarget.
+func (in *FluxKustomizePatchTarget) DeepCopy() *FluxKustomizePatchTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(FluxKustomizePatchTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluxMultiTenancyConfig) DeepCopyInto(out *FluxMultiTenancyConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxMultiTenancyConfig.
+func (in *FluxMultiTenancyConfig) DeepCopy() *FluxMultiTenancyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FluxMultiTenancyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluxNamespaceMetadata) DeepCopyInto(out *FluxNamespaceMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxNamespaceMetadata.
+func (in *FluxNamespaceMetadata) DeepCopy() *FluxNamespaceMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(FluxNamespaceMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluxNotificationConfig) DeepCopyInto(out *FluxNotificationConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxNotificationConfig.
+func (in *FluxNotificationConfig) DeepCopy() *FluxNotificationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FluxNotificationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluxReceiverConfig) DeepCopyInto(out *FluxReceiverConfig) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxReceiverConfig.
+func (in *FluxReceiverConfig) DeepCopy() *FluxReceiverConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FluxReceiverConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluxResourceList) DeepCopyInto(out *FluxResourceList) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxResourceList.
+func (in *FluxResourceList) DeepCopy() *FluxResourceList {
+	if in == nil {
+		return nil
+	}
+	out := new(FluxResourceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluxResourceRequirements) DeepCopyInto(out *FluxResourceRequirements) {
+	*out = *in
+	out.Requests = in.Requests
+	out.Limits = in.Limits
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxResourceRequirements.
+func (in *FluxResourceRequirements) DeepCopy() *FluxResourceRequirements {
+	if in == nil {
+		return nil
+	}
+	out := new(FluxResourceRequirements)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluxSchedulingConfig) DeepCopyInto(out *FluxSchedulingConfig) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxSchedulingConfig.
+func (in *FluxSchedulingConfig) DeepCopy() *FluxSchedulingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FluxSchedulingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluxSealedSecretsConfig) DeepCopyInto(out *FluxSealedSecretsConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxSealedSecretsConfig.
+func (in *FluxSealedSecretsConfig) DeepCopy() *FluxSealedSecretsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FluxSealedSecretsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluxSecretStoreRef) DeepCopyInto(out *FluxSecretStoreRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxSecretStoreRef.
+func (in *FluxSecretStoreRef) DeepCopy() *FluxSecretStoreRef {
+	if in == nil {
+		return nil
+	}
+	out := new(FluxSecretStoreRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluxSyncConfig) DeepCopyInto(out *FluxSyncConfig) {
+	*out = *in
+	if in.Prune != nil {
+		in, out := &in.Prune, &out.Prune
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HealthChecks != nil {
+		in, out := &in.HealthChecks, &out.HealthChecks
+		*out = make([]FluxHealthCheck, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxSyncConfig.
+func (in *FluxSyncConfig) DeepCopy() *FluxSyncConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FluxSyncConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitOpsConfig) DeepCopyInto(out *GitOpsConfig) {
 	*out = *in
@@ -1221,6 +1784,21 @@ func (in *GitProviderConfig) DeepCopy() *GitProviderConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GiteaProviderConfig) DeepCopyInto(out *GiteaProviderConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GiteaProviderConfig.
+func (in *GiteaProviderConfig) DeepCopy() *GiteaProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GiteaProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Github) DeepCopyInto(out *Github) {
 	*out = *in
@@ -1251,6 +1829,21 @@ func (in *GithubProviderConfig) DeepCopy() *GithubProviderConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitlabProviderConfig) DeepCopyInto(out *GitlabProviderConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitlabProviderConfig.
+func (in *GitlabProviderConfig) DeepCopy() *GitlabProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GitlabProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in HardwareSelector) DeepCopyInto(out *HardwareSelector) {
 	{
@@ -1629,6 +2222,21 @@ func (in *OCINamespace) DeepCopy() *OCINamespace {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCIRepositoryConfig) DeepCopyInto(out *OCIRepositoryConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCIRepositoryConfig.
+func (in *OCIRepositoryConfig) DeepCopy() *OCIRepositoryConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OCIRepositoryConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OIDCConfig) DeepCopyInto(out *OIDCConfig) {
 	*out = *in
@@ -0,0 +1,132 @@
+package bucket
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// maxDeleteKeys is the max number of keys accepted by a DeleteObjects request.
+const maxDeleteKeys = 1000
+
+// S3Client uploads and deletes the objects of an S3 compatible bucket, i.e. Amazon S3 or MinIO.
+type S3Client struct {
+	s3     s3iface.S3API
+	bucket string
+}
+
+// NewS3Client builds a S3Client for the bucket of a FluxConfig.
+// Credentials are taken from the default AWS credentials chain, i.e. the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY env vars.
+func NewS3Client(config *v1alpha1.BucketConfig) (*S3Client, error) {
+	scheme := "https"
+	if config.Insecure {
+		scheme = "http"
+	}
+
+	region := config.Region
+	if region == "" {
+		region = v1alpha1.FluxDefaultBucketRegion
+	}
+
+	sess, err := session.NewSession(&aws.Config{
+		Endpoint: aws.String(scheme + "://" + config.Endpoint),
+		Region:   aws.String(region),
+		// Most S3 compatible storage, like MinIO, doesn't support virtual hosted buckets
+		S3ForcePathStyle: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating session for bucket %s: %v", config.BucketName, err)
+	}
+
+	return NewS3ClientFromAPI(s3.New(sess), config.BucketName), nil
+}
+
+// NewS3ClientFromAPI builds a S3Client from an existing S3 api client.
+func NewS3ClientFromAPI(api s3iface.S3API, bucket string) *S3Client {
+	return &S3Client{
+		s3:     api,
+		bucket: bucket,
+	}
+}
+
+// Upload uploads all the files under prefix in the local dir, using their path relative to dir as object key.
+func (c *S3Client) Upload(ctx context.Context, dir, prefix string) error {
+	return filepath.WalkDir(filepath.Join(dir, prefix), func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+
+		f, err := os.Open(p)
+		if err != nil {
+			return fmt.Errorf("opening file for upload: %v", err)
+		}
+		defer f.Close()
+
+		logger.V(4).Info("Uploading file to bucket", "bucket", c.bucket, "key", key)
+		if _, err = c.s3.PutObjectWithContext(ctx, &s3.PutObjectInput{
+			Bucket: aws.String(c.bucket),
+			Key:    aws.String(key),
+			Body:   f,
+		}); err != nil {
+			return fmt.Errorf("uploading %s to bucket %s: %v", key, c.bucket, err)
+		}
+		return nil
+	})
+}
+
+// Delete deletes all the objects under prefix.
+func (c *S3Client) Delete(ctx context.Context, prefix string) error {
+	var keys []*s3.ObjectIdentifier
+	err := c.s3.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(c.bucket),
+		Prefix: aws.String(strings.TrimSuffix(prefix, "/") + "/"),
+	}, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, o := range page.Contents {
+			keys = append(keys, &s3.ObjectIdentifier{Key: o.Key})
+		}
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("listing objects in bucket %s: %v", c.bucket, err)
+	}
+
+	for start := 0; start < len(keys); start += maxDeleteKeys {
+		end := start + maxDeleteKeys
+		if end > len(keys) {
+			end = len(keys)
+		}
+		logger.V(4).Info("Deleting objects from bucket", "bucket", c.bucket, "prefix", prefix, "count", end-start)
+		out, err := c.s3.DeleteObjectsWithContext(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(c.bucket),
+			Delete: &s3.Delete{Objects: keys[start:end], Quiet: aws.Bool(true)},
+		})
+		if err != nil {
+			return fmt.Errorf("deleting %s from bucket %s: %v", prefix, c.bucket, err)
+		}
+		if len(out.Errors) > 0 {
+			e := out.Errors[0]
+			return fmt.Errorf("deleting %s from bucket %s: %s: %s", aws.StringValue(e.Key), c.bucket, aws.StringValue(e.Code), aws.StringValue(e.Message))
+		}
+	}
+	return nil
+}
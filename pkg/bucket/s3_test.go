@@ -0,0 +1,194 @@
+package bucket_test
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/bucket"
+)
+
+// fakeS3 is an in-memory S3 compatible api for a single bucket, supporting the path style requests used by S3Client.
+type fakeS3 struct {
+	sync.Mutex
+	bucket  string
+	objects map[string]string
+	failPut bool
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+
+	if !strings.Contains(r.Header.Get("Authorization"), "Credential=AKIDEXAMPLE/") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	p := strings.TrimPrefix(r.URL.Path, "/")
+	if p != f.bucket && !strings.HasPrefix(p, f.bucket+"/") {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+	key := strings.TrimPrefix(strings.TrimPrefix(p, f.bucket), "/")
+
+	switch {
+	case r.Method == http.MethodPut && key != "":
+		if f.failPut {
+			writeS3Error(w, http.StatusForbidden, "AccessDenied")
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		f.objects[key] = string(body)
+	case r.Method == http.MethodGet && r.URL.Query().Get("list-type") == "2":
+		var keys []string
+		for k := range f.objects {
+			if strings.HasPrefix(k, r.URL.Query().Get("prefix")) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		fmt.Fprint(w, "<ListBucketResult>")
+		for _, k := range keys {
+			fmt.Fprintf(w, "<Contents><Key>%s</Key></Contents>", k)
+		}
+		fmt.Fprint(w, "<IsTruncated>false</IsTruncated></ListBucketResult>")
+	case r.Method == http.MethodPost && r.URL.Query().Has("delete"):
+		d := struct {
+			Objects []struct {
+				Key string `xml:"Key"`
+			} `xml:"Object"`
+		}{}
+		if err := xml.NewDecoder(r.Body).Decode(&d); err != nil {
+			writeS3Error(w, http.StatusBadRequest, "MalformedXML")
+			return
+		}
+		for _, o := range d.Objects {
+			delete(f.objects, o.Key)
+		}
+		fmt.Fprint(w, "<DeleteResult></DeleteResult>")
+	default:
+		writeS3Error(w, http.StatusNotImplemented, "NotImplemented")
+	}
+}
+
+func writeS3Error(w http.ResponseWriter, status int, code string) {
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<Error><Code>%s</Code><Message>%s</Message></Error>", code, code)
+}
+
+type s3Test struct {
+	*WithT
+	ctx    context.Context
+	fake   *fakeS3
+	client *bucket.S3Client
+}
+
+func newS3Test(t *testing.T) *s3Test {
+	t.Setenv("AWS_CONFIG_FILE", "testdata/nonexistent")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", "testdata/nonexistent")
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+
+	fake := &fakeS3{bucket: "eksa-fleet", objects: map[string]string{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client, err := bucket.NewS3Client(&v1alpha1.BucketConfig{
+		BucketName: "eksa-fleet",
+		Endpoint:   strings.TrimPrefix(server.URL, "http://"),
+		Insecure:   true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &s3Test{
+		WithT:  NewWithT(t),
+		ctx:    context.Background(),
+		fake:   fake,
+		client: client,
+	}
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	p := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(p), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestS3ClientUpload(t *testing.T) {
+	g := newS3Test(t)
+	dir := t.TempDir()
+	writeFile(t, dir, "clusters/mgmt/mgmt/eksa-system/eksa-cluster.yaml", "cluster")
+	writeFile(t, dir, "clusters/mgmt/flux-system/gotk-sync.yaml", "sync")
+	writeFile(t, dir, "clusters/other/eksa-cluster.yaml", "other")
+
+	g.Expect(g.client.Upload(g.ctx, dir, "clusters/mgmt")).To(Succeed())
+	g.Expect(g.fake.objects).To(Equal(map[string]string{
+		"clusters/mgmt/mgmt/eksa-system/eksa-cluster.yaml": "cluster",
+		"clusters/mgmt/flux-system/gotk-sync.yaml":         "sync",
+	}))
+}
+
+func TestS3ClientUploadError(t *testing.T) {
+	g := newS3Test(t)
+	g.fake.failPut = true
+	dir := t.TempDir()
+	writeFile(t, dir, "clusters/mgmt/kustomization.yaml", "kustomization")
+
+	g.Expect(g.client.Upload(g.ctx, dir, "clusters/mgmt")).To(MatchError(ContainSubstring("uploading clusters/mgmt/kustomization.yaml to bucket eksa-fleet")))
+}
+
+func TestS3ClientUploadMissingDir(t *testing.T) {
+	g := newS3Test(t)
+
+	g.Expect(g.client.Upload(g.ctx, t.TempDir(), "clusters/mgmt")).NotTo(Succeed())
+}
+
+func TestS3ClientDelete(t *testing.T) {
+	g := newS3Test(t)
+	g.fake.objects = map[string]string{
+		"clusters/mgmt/workload/eksa-system/eksa-cluster.yaml":   "workload",
+		"clusters/mgmt/workload/eksa-system/kustomization.yaml":  "workload",
+		"clusters/mgmt/workload-2/eksa-system/eksa-cluster.yaml": "workload-2",
+		"clusters/mgmt/mgmt/eksa-system/eksa-cluster.yaml":       "mgmt",
+	}
+
+	g.Expect(g.client.Delete(g.ctx, "clusters/mgmt/workload")).To(Succeed())
+	g.Expect(g.fake.objects).To(Equal(map[string]string{
+		"clusters/mgmt/workload-2/eksa-system/eksa-cluster.yaml": "workload-2",
+		"clusters/mgmt/mgmt/eksa-system/eksa-cluster.yaml":       "mgmt",
+	}))
+}
+
+func TestS3ClientDeleteEmptyPrefix(t *testing.T) {
+	g := newS3Test(t)
+	g.fake.objects = map[string]string{"clusters/mgmt/mgmt/eksa-system/eksa-cluster.yaml": "mgmt"}
+
+	g.Expect(g.client.Delete(g.ctx, "clusters/mgmt/workload")).To(Succeed())
+	g.Expect(g.fake.objects).To(HaveLen(1))
+}
+
+func TestS3ClientDeleteNoSuchBucket(t *testing.T) {
+	g := newS3Test(t)
+	g.fake.bucket = "other-bucket"
+
+	g.Expect(g.client.Delete(g.ctx, "clusters/mgmt")).To(MatchError(ContainSubstring("listing objects in bucket eksa-fleet")))
+}
@@ -27,6 +27,7 @@ type Config struct {
 	AWSIAMConfigs             map[string]*anywherev1.AWSIamConfig
 	GitOpsConfig              *anywherev1.GitOpsConfig
 	FluxConfig                *anywherev1.FluxConfig
+	AdditionalFluxConfigs     map[string]*anywherev1.FluxConfig
 	SnowCredentialsSecret     *v1.Secret
 	SnowIPPools               map[string]*anywherev1.SnowIPPool
 }
@@ -56,6 +57,11 @@ func (c *Config) AWSIamConfig(name string) *anywherev1.AWSIamConfig {
 	return c.AWSIAMConfigs[name]
 }
 
+// AdditionalFluxConfig returns an additional FluxConfig based on a name.
+func (c *Config) AdditionalFluxConfig(name string) *anywherev1.FluxConfig {
+	return c.AdditionalFluxConfigs[name]
+}
+
 func (c *Config) NutanixMachineConfig(name string) *anywherev1.NutanixMachineConfig {
 	return c.NutanixMachineConfigs[name]
 }
@@ -102,6 +108,13 @@ func (c *Config) DeepCopy() *Config {
 		c2.AWSIAMConfigs[k] = v.DeepCopy()
 	}
 
+	if c.AdditionalFluxConfigs != nil {
+		c2.AdditionalFluxConfigs = make(map[string]*anywherev1.FluxConfig, len(c.AdditionalFluxConfigs))
+	}
+	for k, v := range c.AdditionalFluxConfigs {
+		c2.AdditionalFluxConfigs[k] = v.DeepCopy()
+	}
+
 	if c.NutanixMachineConfigs != nil {
 		c2.NutanixMachineConfigs = make(map[string]*anywherev1.NutanixMachineConfig, len(c.NutanixMachineConfigs))
 	}
@@ -196,6 +209,10 @@ func (c *Config) ChildObjects() []kubernetes.Object {
 		objs = appendIfNotNil(objs, e)
 	}
 
+	for _, e := range c.AdditionalFluxConfigs {
+		objs = appendIfNotNil(objs, e)
+	}
+
 	return objs
 }
 
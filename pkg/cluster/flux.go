@@ -2,6 +2,8 @@ package cluster
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"path"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
@@ -14,7 +16,7 @@ func fluxEntry() *ConfigManagerEntry {
 				return &anywherev1.FluxConfig{}
 			},
 		},
-		Processors: []ParsedProcessor{processFlux},
+		Processors: []ParsedProcessor{processFlux, processAdditionalFlux},
 		Defaulters: []Defaulter{
 			setFluxDefaults,
 			SetDefaultFluxConfigPath,
@@ -22,6 +24,7 @@ func fluxEntry() *ConfigManagerEntry {
 		Validations: []Validation{
 			validateFlux,
 			validateFluxNamespace,
+			validateAdditionalFlux,
 		},
 	}
 }
@@ -41,6 +44,23 @@ func processFlux(c *Config, objects ObjectLookup) {
 	}
 }
 
+func processAdditionalFlux(c *Config, objects ObjectLookup) {
+	if c.AdditionalFluxConfigs == nil {
+		c.AdditionalFluxConfigs = map[string]*anywherev1.FluxConfig{}
+	}
+
+	for _, ref := range c.Cluster.Spec.AdditionalGitOpsRefs {
+		if ref.Kind != anywherev1.FluxConfigKind {
+			continue
+		}
+		flux := objects.GetFromRef(c.Cluster.APIVersion, ref)
+		if flux == nil {
+			continue
+		}
+		c.AdditionalFluxConfigs[flux.GetName()] = flux.(*anywherev1.FluxConfig)
+	}
+}
+
 func validateFlux(c *Config) error {
 	if c.FluxConfig != nil {
 		return c.FluxConfig.Validate()
@@ -57,10 +77,59 @@ func validateFluxNamespace(c *Config) error {
 	return nil
 }
 
+// validateAdditionalFlux validates the FluxConfigs of the additional repositories. They're reconciled by the flux
+// bootstrapped from the repository of the GitOpsRef, so they require it, and only Git repositories are supported.
+func validateAdditionalFlux(c *Config) error {
+	refs := c.Cluster.Spec.AdditionalGitOpsRefs
+	if len(refs) == 0 {
+		return nil
+	}
+
+	if c.FluxConfig == nil {
+		return errors.New("additionalGitOpsRefs requires a gitOpsRef to a FluxConfig")
+	}
+
+	if !c.Cluster.IsSelfManaged() {
+		return errors.New("additionalGitOpsRefs is only supported for management clusters")
+	}
+
+	seen := map[string]bool{c.FluxConfig.Name: true}
+	for _, ref := range refs {
+		if ref.Kind != anywherev1.FluxConfigKind {
+			return fmt.Errorf("invalid additionalGitOpsRefs kind %s, only %s is supported", ref.Kind, anywherev1.FluxConfigKind)
+		}
+		if seen[ref.Name] {
+			return fmt.Errorf("FluxConfig %s is referenced more than once by gitOpsRef and additionalGitOpsRefs", ref.Name)
+		}
+		seen[ref.Name] = true
+
+		flux := c.AdditionalFluxConfig(ref.Name)
+		if flux == nil {
+			return fmt.Errorf("FluxConfig %s referenced in additionalGitOpsRefs not found", ref.Name)
+		}
+		if err := flux.Validate(); err != nil {
+			return fmt.Errorf("validating FluxConfig %s: %v", ref.Name, err)
+		}
+		if err := validateSameNamespace(c, flux); err != nil {
+			return err
+		}
+		if flux.Spec.OCIRepository != nil || flux.Spec.Bucket != nil {
+			return fmt.Errorf("FluxConfig %s referenced in additionalGitOpsRefs must use a Git repository", ref.Name)
+		}
+	}
+	return nil
+}
+
 func setFluxDefaults(c *Config) error {
 	if c.FluxConfig != nil {
 		c.FluxConfig.SetDefaults()
 	}
+	for _, f := range c.AdditionalFluxConfigs {
+		f.SetDefaults()
+		if f.Spec.ClusterConfigPath == "" {
+			f.Spec.ClusterConfigPath = path.Join("clusters", c.Cluster.Name)
+		}
+	}
 	return nil
 }
 
@@ -94,5 +163,20 @@ func getFluxConfig(ctx context.Context, client Client, c *Config) error {
 
 	c.FluxConfig = fluxConfig
 
+	if c.AdditionalFluxConfigs == nil {
+		c.AdditionalFluxConfigs = map[string]*anywherev1.FluxConfig{}
+	}
+
+	for _, ref := range c.Cluster.Spec.AdditionalGitOpsRefs {
+		if ref.Kind != anywherev1.FluxConfigKind {
+			continue
+		}
+		additional := &anywherev1.FluxConfig{}
+		if err := client.Get(ctx, ref.Name, c.Cluster.Namespace, additional); err != nil {
+			return err
+		}
+		c.AdditionalFluxConfigs[additional.Name] = additional
+	}
+
 	return nil
 }
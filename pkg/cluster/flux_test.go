@@ -53,3 +53,187 @@ func TestDefaultConfigClientBuilderFluxConfig(t *testing.T) {
 	g.Expect(config.Cluster).To(Equal(cluster))
 	g.Expect(config.FluxConfig).To(Equal(fluxConfig))
 }
+
+func TestDefaultConfigClientBuilderAdditionalFluxConfigs(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	b := cluster.NewDefaultConfigClientBuilder()
+	ctrl := gomock.NewController(t)
+	client := mocks.NewMockClient(ctrl)
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+		},
+		Spec: anywherev1.ClusterSpec{
+			GitOpsRef: &anywherev1.Ref{
+				Kind: anywherev1.FluxConfigKind,
+				Name: "my-flux",
+			},
+			AdditionalGitOpsRefs: []anywherev1.Ref{
+				{Kind: anywherev1.FluxConfigKind, Name: "apps"},
+			},
+		},
+	}
+
+	client.EXPECT().Get(ctx, gomock.Any(), "default", &anywherev1.FluxConfig{}).DoAndReturn(
+		func(ctx context.Context, name, namespace string, obj runtime.Object) error {
+			c := obj.(*anywherev1.FluxConfig)
+			c.ObjectMeta = metav1.ObjectMeta{Name: name, Namespace: namespace}
+			return nil
+		},
+	).Times(2)
+
+	config, err := b.Build(ctx, client, cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.FluxConfig.Name).To(Equal("my-flux"))
+	g.Expect(config.AdditionalFluxConfigs).To(HaveLen(1))
+	g.Expect(config.AdditionalFluxConfig("apps").Name).To(Equal("apps"))
+}
+
+const additionalFluxConfigsManifest = `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: my-cluster
+  namespace: default
+spec:
+  kubernetesVersion: "1.23"
+  controlPlaneConfiguration:
+    count: 1
+    endpoint:
+      host: 1.2.3.4
+  workerNodeGroupConfigurations:
+  - count: 1
+    name: md-0
+  clusterNetwork:
+    cniConfig:
+      cilium: {}
+    pods:
+      cidrBlocks:
+      - 192.168.0.0/16
+    services:
+      cidrBlocks:
+      - 10.96.0.0/12
+  datacenterRef:
+    kind: DockerDatacenterConfig
+    name: my-cluster
+  gitOpsRef:
+    kind: FluxConfig
+    name: infra
+  additionalGitOpsRefs:
+  - kind: FluxConfig
+    name: apps
+---
+apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: FluxConfig
+metadata:
+  name: infra
+  namespace: default
+spec:
+  github:
+    owner: janedoe
+    repository: infra
+---
+apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: FluxConfig
+metadata:
+  name: apps
+  namespace: default
+spec:
+  github:
+    owner: janedoe
+    repository: apps
+`
+
+func TestParseConfigAdditionalFluxConfigs(t *testing.T) {
+	g := NewWithT(t)
+	c, err := cluster.ParseConfig([]byte(additionalFluxConfigsManifest))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cluster.SetConfigDefaults(c)).To(Succeed())
+	g.Expect(cluster.ValidateConfig(c)).To(Succeed())
+
+	g.Expect(c.FluxConfig.Name).To(Equal("infra"))
+	apps := c.AdditionalFluxConfig("apps")
+	g.Expect(apps).NotTo(BeNil())
+	g.Expect(apps.Spec.Branch).To(Equal("main"))
+	g.Expect(apps.Spec.ClusterConfigPath).To(Equal("clusters/my-cluster"))
+	g.Expect(c.ChildObjects()).To(ContainElement(apps))
+}
+
+func TestValidateConfigAdditionalFluxConfigs(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(c *cluster.Config)
+		wantErr string
+	}{
+		{
+			name: "missing gitOpsRef",
+			mutate: func(c *cluster.Config) {
+				c.Cluster.Spec.GitOpsRef = nil
+				c.FluxConfig = nil
+			},
+			wantErr: "additionalGitOpsRefs requires a gitOpsRef to a FluxConfig",
+		},
+		{
+			name: "workload cluster",
+			mutate: func(c *cluster.Config) {
+				c.Cluster.SetManagedBy("management-cluster")
+			},
+			wantErr: "additionalGitOpsRefs is only supported for management clusters",
+		},
+		{
+			name: "invalid kind",
+			mutate: func(c *cluster.Config) {
+				c.Cluster.Spec.AdditionalGitOpsRefs[0].Kind = anywherev1.GitOpsConfigKind
+			},
+			wantErr: "invalid additionalGitOpsRefs kind GitOpsConfig, only FluxConfig is supported",
+		},
+		{
+			name: "same as gitOpsRef",
+			mutate: func(c *cluster.Config) {
+				c.Cluster.Spec.AdditionalGitOpsRefs[0].Name = "infra"
+			},
+			wantErr: "FluxConfig infra is referenced more than once by gitOpsRef and additionalGitOpsRefs",
+		},
+		{
+			name: "not found",
+			mutate: func(c *cluster.Config) {
+				delete(c.AdditionalFluxConfigs, "apps")
+			},
+			wantErr: "FluxConfig apps referenced in additionalGitOpsRefs not found",
+		},
+		{
+			name: "invalid FluxConfig",
+			mutate: func(c *cluster.Config) {
+				c.AdditionalFluxConfig("apps").Spec.Github = nil
+			},
+			wantErr: "validating FluxConfig apps: must specify a provider",
+		},
+		{
+			name: "different namespace",
+			mutate: func(c *cluster.Config) {
+				c.AdditionalFluxConfig("apps").Namespace = "other"
+			},
+			wantErr: "FluxConfig and Cluster objects must have the same namespace specified",
+		},
+		{
+			name: "oci repository",
+			mutate: func(c *cluster.Config) {
+				c.AdditionalFluxConfig("apps").Spec.Github = nil
+				c.AdditionalFluxConfig("apps").Spec.OCIRepository = &anywherev1.OCIRepositoryConfig{Url: "oci://public.ecr.aws/apps"}
+			},
+			wantErr: "FluxConfig apps referenced in additionalGitOpsRefs must use a Git repository",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			c, err := cluster.ParseConfig([]byte(additionalFluxConfigsManifest))
+			g.Expect(err).NotTo(HaveOccurred())
+			tt.mutate(c)
+
+			g.Expect(cluster.ValidateConfig(c)).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
@@ -290,6 +290,11 @@ func BuildSpecFromBundles(cluster *eksav1alpha1.Cluster, bundlesManifest *v1alph
 	return s, nil
 }
 
+// EKSDRelease returns the EKS Distro release of the versions bundle of the spec.
+func (s *Spec) EKSDRelease() *eksdv1alpha1.Release {
+	return s.eksdRelease
+}
+
 func (s *Spec) newReader() *files.Reader {
 	return files.NewReader(files.WithEmbedFS(s.configFS), files.WithUserAgent(s.userAgent))
 }
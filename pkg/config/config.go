@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 const (
 	EksaGitPassphraseTokenEnv = "EKSA_GIT_SSH_KEY_PASSPHRASE"
 	EksaGitPrivateKeyTokenEnv = "EKSA_GIT_PRIVATE_KEY"
@@ -10,10 +12,167 @@ const (
 	AwsAccessKeyIdEnv         = "AWS_ACCESS_KEY_ID"
 	AwsSecretAccessKeyEnv     = "AWS_SECRET_ACCESS_KEY"
 	EksaRegionEnv             = "EKSA_AWS_REGION"
+	RegistryUsernameEnv       = "REGISTRY_USERNAME"
+	RegistryPasswordEnv       = "REGISTRY_PASSWORD"
+
+	// EksaGitSigningKeyFileEnv is the GPG or SSH private key file used to sign the commits pushed to the flux repository.
+	EksaGitSigningKeyFileEnv       = "EKSA_GIT_SIGNING_KEY"
+	EksaGitSigningKeyPassphraseEnv = "EKSA_GIT_SIGNING_KEY_PASSPHRASE"
+
+	// EksaGitCloneDepthEnv limits the number of commits fetched when cloning the flux repository.
+	EksaGitCloneDepthEnv = "EKSA_GIT_CLONE_DEPTH"
+	// EksaGitSparseCheckoutEnv enables only checking out the cluster config path of the flux repository.
+	EksaGitSparseCheckoutEnv = "EKSA_GIT_SPARSE_CHECKOUT"
+	// EksaGitProxyEnv is the http, https or socks5 proxy url the git operations and git provider requests go through.
+	EksaGitProxyEnv = "EKSA_GIT_PROXY"
+	// EksaGitAuthorNameEnv and EksaGitAuthorEmailEnv are the identity the commits pushed to the flux repository are attributed to.
+	EksaGitAuthorNameEnv  = "EKSA_GIT_AUTHOR_NAME"
+	EksaGitAuthorEmailEnv = "EKSA_GIT_AUTHOR_EMAIL"
+	// EksaGitRepositoryCacheDirEnv is the directory the flux repository is cloned to and reused from by the next operations.
+	EksaGitRepositoryCacheDirEnv = "EKSA_GIT_REPO_CACHE_DIR"
+	// EksaGitWorkspaceDirEnv is the directory the flux repository is cloned to, instead of the cluster directory.
+	EksaGitWorkspaceDirEnv = "EKSA_GIT_WORKSPACE_DIR"
+	// EksaGitWorkspaceCleanupEnv is when the local flux repository is removed after the operation: never, always or on-success.
+	EksaGitWorkspaceCleanupEnv = "EKSA_GIT_WORKSPACE_CLEANUP"
+	// EksaGitWorkspaceClusterScopedEnv enables cloning the flux repository to a directory of the cluster in the workspace directory.
+	EksaGitWorkspaceClusterScopedEnv = "EKSA_GIT_WORKSPACE_CLUSTER_SCOPED"
+	// EksaGitOpsReconcileTimeoutEnv is how long to wait for flux to apply the new revision after forcing a reconcile.
+	EksaGitOpsReconcileTimeoutEnv = "EKSA_GITOPS_RECONCILE_TIMEOUT"
+	// EksaGitOpsControllersRolloutTimeoutEnv is how long to wait for the flux controllers to run the new images after a flux upgrade.
+	EksaGitOpsControllersRolloutTimeoutEnv = "EKSA_GITOPS_CONTROLLERS_ROLLOUT_TIMEOUT"
+	// EksaGitOpsUpdateSquashWindowEnv is how recent the last cluster config update commit must be to be amended by the next update.
+	EksaGitOpsUpdateSquashWindowEnv = "EKSA_GITOPS_UPDATE_SQUASH_WINDOW"
+	// EksaGitOpsRetryInitialBackoffEnv, EksaGitOpsRetryMaxBackoffEnv and EksaGitOpsRetryMaxElapsedTimeEnv configure the
+	// exponential backoff of the retried flux and git operations.
+	EksaGitOpsRetryInitialBackoffEnv = "EKSA_GITOPS_RETRY_INITIAL_BACKOFF"
+	EksaGitOpsRetryMaxBackoffEnv     = "EKSA_GITOPS_RETRY_MAX_BACKOFF"
+	EksaGitOpsRetryMaxElapsedTimeEnv = "EKSA_GITOPS_RETRY_MAX_ELAPSED_TIME"
+	// EksaGitOpsPullRequestFallbackEnv enables opening a pull request with the cluster config changes when the
+	// sync branch is protected against direct pushes.
+	EksaGitOpsPullRequestFallbackEnv = "EKSA_GITOPS_PULL_REQUEST_FALLBACK"
+	// EksaGitOpsPullRequestBranchPrefixEnv enables always opening a pull request with the cluster config changes,
+	// from a branch named with this prefix.
+	EksaGitOpsPullRequestBranchPrefixEnv = "EKSA_GITOPS_PULL_REQUEST_BRANCH_PREFIX"
+	// EksaGitOpsStagingBranchEnv enables pushing the initial cluster config to a staging branch and only
+	// fast-forwarding the sync branch to it once flux is bootstrapped.
+	EksaGitOpsStagingBranchEnv = "EKSA_GITOPS_STAGING_BRANCH"
+	// EksaGitOpsCommitMessageTemplateEnv is the text/template of the messages of the cluster config commits.
+	EksaGitOpsCommitMessageTemplateEnv = "EKSA_GITOPS_COMMIT_MESSAGE_TEMPLATE"
+	// EksaGitOpsCommitTrailersEnv are the semicolon separated "key: value" trailers appended to the cluster config commits.
+	EksaGitOpsCommitTrailersEnv = "EKSA_GITOPS_COMMIT_TRAILERS"
+	// EksaGitOpsCommitExcludeEnv are the semicolon separated gitignore-style patterns of the files never committed to the repository.
+	EksaGitOpsCommitExcludeEnv = "EKSA_GITOPS_COMMIT_EXCLUDE"
+	// EksaGitOpsChangelogEnv enables recording the operations on the cluster in a changelog file in the repository.
+	EksaGitOpsChangelogEnv = "EKSA_GITOPS_CHANGELOG"
+	// EksaGitOpsUpgradeTagsEnv enables tagging the commit of the cluster config after a successful upgrade.
+	EksaGitOpsUpgradeTagsEnv = "EKSA_GITOPS_UPGRADE_TAGS"
+	// EksaGitOpsLocalChangesEnv is how the changes of the local repository missing in the remote branch are handled
+	// before updating it: fail, reset or rebase.
+	EksaGitOpsLocalChangesEnv = "EKSA_GITOPS_LOCAL_CHANGES"
+	// EksaGitOpsRevertOnBootstrapFailureEnv enables removing the pushed cluster config when flux fails to bootstrap.
+	EksaGitOpsRevertOnBootstrapFailureEnv = "EKSA_GITOPS_REVERT_ON_BOOTSTRAP_FAILURE"
+	// EksaGitOpsVerifyManifestsEnv enables a server-side dry-run of the committed eks-a manifests before bootstrapping flux.
+	EksaGitOpsVerifyManifestsEnv = "EKSA_GITOPS_VERIFY_MANIFESTS"
+	// EksaGitOpsRepoCleanupEnv is how the repository of a deleted management cluster is cleaned up:
+	// delete-branch, archive-repo or delete-repo.
+	EksaGitOpsRepoCleanupEnv = "EKSA_GITOPS_REPO_CLEANUP"
+	// EksaGitOpsVerifyClusterConfigEnv enables checking the committed cluster config parses back to the cluster spec.
+	EksaGitOpsVerifyClusterConfigEnv = "EKSA_GITOPS_VERIFY_CLUSTER_CONFIG"
+	// EksaGitOpsAdoptExistingFluxEnv enables adopting a flux already installed in a management cluster instead of bootstrapping it.
+	EksaGitOpsAdoptExistingFluxEnv = "EKSA_GITOPS_ADOPT_EXISTING_FLUX"
+	// EksaGitOpsMaxFileSizeEnv and EksaGitOpsMaxCommitSizeEnv are the max sizes in bytes of a committed file and of all
+	// the files of a commit. Zero disables the check.
+	EksaGitOpsMaxFileSizeEnv   = "EKSA_GITOPS_MAX_FILE_SIZE"
+	EksaGitOpsMaxCommitSizeEnv = "EKSA_GITOPS_MAX_COMMIT_SIZE"
+	// EksaGitProviderRateLimitEnv is the max number of git provider API requests per hour.
+	EksaGitProviderRateLimitEnv = "EKSA_GIT_PROVIDER_RATE_LIMIT"
 )
 
 type CliConfig struct {
 	GitSshKeyPassphrase string
 	GitPrivateKeyFile   string
 	GitKnownHostsFile   string
+	// GitCloneDepth is the number of commits of history fetched when cloning the flux repository.
+	// Zero clones the full history.
+	GitCloneDepth int
+	// GitSparseCheckout only checks out the cluster config path of the flux repository.
+	GitSparseCheckout bool
+	// GitProxy is the proxy url of the git operations and git provider requests.
+	// It takes precedence over the cluster proxy configuration.
+	GitProxy string
+	// GitAuthorName and GitAuthorEmail are the author and committer of the commits pushed to the flux repository.
+	// An empty name defaults to the EKS-A author.
+	GitAuthorName  string
+	GitAuthorEmail string
+	// GitRepositoryCacheDir is the directory the flux repository is kept in between operations, so it's fetched
+	// instead of cloned again. Empty clones the repository in the cluster directory.
+	GitRepositoryCacheDir string
+	// GitWorkspaceDir is the directory the flux repository is cloned to. Empty clones it in the cluster directory.
+	GitWorkspaceDir string
+	// GitWorkspaceCleanup is when the local flux repository is removed after the operation. Empty never removes it.
+	GitWorkspaceCleanup string
+	// GitWorkspaceClusterScoped clones the flux repository to a directory named after the cluster in the workspace
+	// directory, so operations on different clusters don't share the same clone.
+	GitWorkspaceClusterScoped bool
+	// GitOpsReconcileTimeout is how long to wait for flux to apply the new revision after forcing a reconcile.
+	// Zero doesn't wait.
+	GitOpsReconcileTimeout time.Duration
+	// GitOpsControllersRolloutTimeout is how long a flux upgrade waits for the flux controllers to run the images of
+	// the new bundle. Zero keeps the default.
+	GitOpsControllersRolloutTimeout time.Duration
+	// GitOpsUpdateSquashWindow is how recent the last cluster config update commit must be to be amended and force
+	// pushed by the next update, instead of creating a new commit. Zero doesn't squash the updates.
+	GitOpsUpdateSquashWindow time.Duration
+	// GitOpsRetryInitialBackoff and GitOpsRetryMaxBackoff are the first and longest waits between the retries
+	// of a failed flux or git operation, which double after each retry.
+	GitOpsRetryInitialBackoff time.Duration
+	GitOpsRetryMaxBackoff     time.Duration
+	// GitOpsRetryMaxElapsedTime is the max time spent retrying a failed flux or git operation.
+	GitOpsRetryMaxElapsedTime time.Duration
+	// GitOpsPullRequestFallback opens a pull request with the cluster config changes when the sync branch
+	// is protected against direct pushes, instead of failing.
+	GitOpsPullRequestFallback bool
+	// GitOpsPullRequestBranchPrefix pushes the cluster config changes to a new branch named with this prefix and
+	// opens a pull request against the sync branch, instead of pushing to the sync branch. Empty pushes directly.
+	GitOpsPullRequestBranchPrefix string
+	// GitOpsStagingBranch pushes the initial cluster config to a staging branch and only fast-forwards the sync
+	// branch to it once flux is bootstrapped.
+	GitOpsStagingBranch bool
+	// GitOpsCommitMessageTemplate is the text/template of the messages of the commits creating, updating and
+	// deleting the cluster config. Empty keeps the default messages.
+	GitOpsCommitMessageTemplate string
+	// GitOpsCommitTrailers are the "key: value" trailers appended to the cluster config commits.
+	GitOpsCommitTrailers []string
+	// GitOpsCommitExcludePatterns are the gitignore-style patterns of the files in the committed directories that
+	// are never staged nor pushed.
+	GitOpsCommitExcludePatterns []string
+	// GitOpsChangelog appends the create, upgrade and delete operations to a changelog file in the directory of the
+	// cluster in the repository.
+	GitOpsChangelog bool
+	// GitOpsUpgradeTags creates and pushes a tag at the commit of the cluster config after a successful upgrade.
+	GitOpsUpgradeTags bool
+	// GitOpsLocalChanges is how the uncommitted changes and unpushed commits of the local repository are handled
+	// before updating it. Empty doesn't check them.
+	GitOpsLocalChanges string
+	// GitOpsRevertOnBootstrapFailure removes the cluster config pushed for a new management cluster from the
+	// repository when flux fails to bootstrap.
+	GitOpsRevertOnBootstrapFailure bool
+	// GitOpsVerifyManifests checks the committed eks-a manifests can be applied to a new management cluster with
+	// a server-side dry-run before bootstrapping flux.
+	GitOpsVerifyManifests bool
+	// GitOpsRepoCleanup is how the sync branch or repository of a management cluster is cleaned up when it's deleted.
+	// Empty only removes the cluster dir.
+	GitOpsRepoCleanup string
+	// GitOpsVerifyClusterConfig checks the committed cluster config of a management cluster parses back to the
+	// cluster spec it was generated from.
+	GitOpsVerifyClusterConfig bool
+	// GitOpsAdoptExistingFlux adopts the flux already running in a self-managed cluster instead of bootstrapping it,
+	// only committing the eksa-system manifests and a Kustomization reconciling them from the existing sync.
+	GitOpsAdoptExistingFlux bool
+	// GitOpsMaxFileSize and GitOpsMaxCommitSize are the max sizes in bytes of a committed file and of all the files
+	// of a commit. Zero keeps the default limit and a negative value disables the check.
+	GitOpsMaxFileSize   int64
+	GitOpsMaxCommitSize int64
+	// GitProviderRequestsPerHour is the max number of git provider API requests per hour. Zero doesn't limit them.
+	GitProviderRequestsPerHour int
 }
@@ -1,19 +1,19 @@
 package config
 
 import (
-	"errors"
+	"fmt"
 	"os"
 )
 
 func ReadCredentials() (username, password string, err error) {
-	username, ok := os.LookupEnv("REGISTRY_USERNAME")
+	username, ok := os.LookupEnv(RegistryUsernameEnv)
 	if !ok {
-		return "", "", errors.New("please set REGISTRY_USERNAME env var")
+		return "", "", fmt.Errorf("please set %s env var", RegistryUsernameEnv)
 	}
 
-	password, ok = os.LookupEnv("REGISTRY_PASSWORD")
+	password, ok = os.LookupEnv(RegistryPasswordEnv)
 	if !ok {
-		return "", "", errors.New("please set REGISTRY_PASSWORD env var")
+		return "", "", fmt.Errorf("please set %s env var", RegistryPasswordEnv)
 	}
 
 	return username, password, nil
@@ -75,7 +75,7 @@ func newPackageControllerTests(t *testing.T) []*packageControllerTest {
 		Auth:          true,
 		CACertContent: "-----BEGIN CERTIFICATE-----\nabc\nefg\n-----END CERTIFICATE-----\n",
 	}
-	writer, _ := filewriter.NewWriter(clusterName)
+	writer, _ := filewriter.NewWriter(filepath.Join(t.TempDir(), clusterName))
 	return []*packageControllerTest{
 		{
 			WithT:          NewWithT(t),
@@ -182,7 +182,7 @@ func newPackageControllerTests(t *testing.T) []*packageControllerTest {
 func TestEnableCuratedPackagesSuccess(t *testing.T) {
 	for _, tt := range newPackageControllerTests(t) {
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.TempDir(), valueFileName)
 		ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 		sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries()
 		sourceRegistry = fmt.Sprintf("sourceRegistry=%s", sourceRegistry)
@@ -264,7 +264,7 @@ func TestEnableCuratedPackagesWithProxy(t *testing.T) {
 			curatedpackages.WithValuesFileWriter(tt.writer),
 		)
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.TempDir(), valueFileName)
 		httpProxy := fmt.Sprintf("proxy.HTTP_PROXY=%s", tt.httpProxy)
 		httpsProxy := fmt.Sprintf("proxy.HTTPS_PROXY=%s", tt.httpsProxy)
 		noProxy := fmt.Sprintf("proxy.NO_PROXY=%s", strings.Join(tt.noProxy, "\\,"))
@@ -315,7 +315,7 @@ func TestEnableCuratedPackagesWithEmptyProxy(t *testing.T) {
 			curatedpackages.WithValuesFileWriter(tt.writer),
 		)
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.TempDir(), valueFileName)
 		ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 		sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries()
 		sourceRegistry = fmt.Sprintf("sourceRegistry=%s", sourceRegistry)
@@ -351,7 +351,7 @@ func TestEnableCuratedPackagesWithEmptyProxy(t *testing.T) {
 func TestEnableCuratedPackagesFail(t *testing.T) {
 	for _, tt := range newPackageControllerTests(t) {
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.TempDir(), valueFileName)
 		ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 		sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries()
 		sourceRegistry = fmt.Sprintf("sourceRegistry=%s", sourceRegistry)
@@ -382,7 +382,7 @@ func TestEnableCuratedPackagesFail(t *testing.T) {
 func TestEnableCuratedPackagesFailNoActiveBundle(t *testing.T) {
 	for _, tt := range newPackageControllerTests(t) {
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.TempDir(), valueFileName)
 		ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 		sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries()
 		sourceRegistry = fmt.Sprintf("sourceRegistry=%s", sourceRegistry)
@@ -467,7 +467,7 @@ func TestPbcCreationFail(t *testing.T) {
 func TestEnableCuratedPackagesSuccessWhenCronJobFails(t *testing.T) {
 	for _, tt := range newPackageControllerTests(t) {
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.TempDir(), valueFileName)
 		ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 		sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries()
 		sourceRegistry = fmt.Sprintf("sourceRegistry=%s", sourceRegistry)
@@ -532,7 +532,7 @@ func TestEnableCuratedPackagesActiveBundleCustomTimeout(t *testing.T) {
 			curatedpackages.WithValuesFileWriter(tt.writer),
 		)
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.TempDir(), valueFileName)
 		ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 		sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries()
 		sourceRegistry = fmt.Sprintf("sourceRegistry=%s", sourceRegistry)
@@ -568,7 +568,7 @@ func TestEnableCuratedPackagesActiveBundleCustomTimeout(t *testing.T) {
 func TestEnableCuratedPackagesActiveBundleWaitLoops(t *testing.T) {
 	for _, tt := range newPackageControllerTests(t) {
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.TempDir(), valueFileName)
 		ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 		sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries()
 		sourceRegistry = fmt.Sprintf("sourceRegistry=%s", sourceRegistry)
@@ -625,7 +625,7 @@ func TestEnableCuratedPackagesActiveBundleTimesOut(t *testing.T) {
 			curatedpackages.WithValuesFileWriter(tt.writer),
 		)
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.TempDir(), valueFileName)
 		ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 		sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries()
 		sourceRegistry = fmt.Sprintf("sourceRegistry=%s", sourceRegistry)
@@ -674,7 +674,7 @@ func TestCreateHelmOverrideValuesYaml(t *testing.T) {
 		}
 		filePath, content, err := tt.command.CreateHelmOverrideValuesYaml()
 		tt.Expect(err).To(BeNil())
-		tt.Expect(filePath).To(Equal(filepath.Join(tt.clusterName, filewriter.DefaultTmpFolder, "values.yaml")))
+		tt.Expect(filePath).To(Equal(filepath.Join(tt.writer.TempDir(), "values.yaml")))
 		test.AssertContentToFile(t, string(content), tt.wantValueFile)
 	}
 }
@@ -687,7 +687,7 @@ func TestCreateHelmOverrideValuesYamlFail(t *testing.T) {
 			tt.Expect(filePath).To(Equal(""))
 		} else {
 			tt.Expect(err).To(BeNil())
-			tt.Expect(filePath).To(Equal(filepath.Join(tt.clusterName, filewriter.DefaultTmpFolder, "values.yaml")))
+			tt.Expect(filePath).To(Equal(filepath.Join(tt.writer.TempDir(), "values.yaml")))
 			test.AssertContentToFile(t, string(content), tt.wantValueFile)
 		}
 	}
@@ -18,6 +18,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/awsiamauth"
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
+	"github.com/aws/eks-anywhere/pkg/bucket"
 	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
@@ -29,8 +30,10 @@ import (
 	"github.com/aws/eks-anywhere/pkg/eksd"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/executables/cmk"
+	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/files"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/git"
 	gitfactory "github.com/aws/eks-anywhere/pkg/git/factory"
 	"github.com/aws/eks-anywhere/pkg/gitops/flux"
 	"github.com/aws/eks-anywhere/pkg/govmomi"
@@ -49,6 +52,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
 	"github.com/aws/eks-anywhere/pkg/providers/validator"
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
+	"github.com/aws/eks-anywhere/pkg/registry"
 	"github.com/aws/eks-anywhere/pkg/registrymirror"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/version"
@@ -648,7 +652,12 @@ func (f *Factory) WithFlux() *Factory {
 			return nil
 		}
 
-		f.dependencies.Flux = f.executablesConfig.builder.BuildFluxExecutable()
+		var opts []executables.FluxOpt
+		if f.registryMirror != nil {
+			opts = append(opts, executables.WithFluxRegistryMirror(f.registryMirror))
+		}
+
+		f.dependencies.Flux = f.executablesConfig.builder.BuildFluxExecutable(opts...)
 		return nil
 	})
 
@@ -901,16 +910,54 @@ func (f *Factory) WithEksdUpgrader() *Factory {
 
 func (f *Factory) WithGit(clusterConfig *v1alpha1.Cluster, fluxConfig *v1alpha1.FluxConfig) *Factory {
 	f.WithWriter()
+	if fluxConfig != nil && fluxConfig.Spec.CredentialsSecretRef != "" {
+		f.WithKubectl()
+	}
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.dependencies.Git != nil {
 			return nil
 		}
 
-		if fluxConfig == nil {
+		// OCI repository and bucket sources don't need a git repository nor provider
+		if fluxConfig == nil || fluxConfig.Spec.OCIRepository != nil || fluxConfig.Spec.Bucket != nil {
 			return nil
 		}
 
-		tools, err := gitfactory.Build(ctx, clusterConfig, fluxConfig, f.dependencies.Writer)
+		var opts []gitfactory.GitToolsOpt
+		if f.dependencies.CliConfig != nil && f.dependencies.CliConfig.GitCloneDepth > 0 {
+			opts = append(opts, gitfactory.WithCloneDepth(f.dependencies.CliConfig.GitCloneDepth))
+		}
+		if f.dependencies.CliConfig != nil && f.dependencies.CliConfig.GitAuthorName != "" {
+			opts = append(opts, gitfactory.WithCommitAuthor(f.dependencies.CliConfig.GitAuthorName, f.dependencies.CliConfig.GitAuthorEmail))
+		}
+		if f.dependencies.CliConfig != nil && f.dependencies.CliConfig.GitRepositoryCacheDir != "" {
+			opts = append(opts, gitfactory.WithRepositoryCache(f.dependencies.CliConfig.GitRepositoryCacheDir))
+		}
+		if f.dependencies.CliConfig != nil && f.dependencies.CliConfig.GitWorkspaceDir != "" {
+			opts = append(opts, gitfactory.WithWorkspaceDirectory(f.dependencies.CliConfig.GitWorkspaceDir))
+		}
+		if f.dependencies.CliConfig != nil && f.dependencies.CliConfig.GitWorkspaceClusterScoped {
+			opts = append(opts, gitfactory.WithClusterScopedDirectory())
+		}
+		if f.dependencies.CliConfig != nil && f.dependencies.CliConfig.GitWorkspaceCleanup != "" {
+			opts = append(opts, gitfactory.WithWorkspaceCleanup(gitfactory.WorkspaceCleanupPolicy(f.dependencies.CliConfig.GitWorkspaceCleanup)))
+		}
+		if f.dependencies.CliConfig != nil && f.dependencies.CliConfig.GitProviderRequestsPerHour > 0 {
+			limiter := git.NewProviderRateLimiter(f.dependencies.CliConfig.GitProviderRequestsPerHour, git.DefaultProviderBurst)
+			opts = append(opts, gitfactory.WithProviderRateLimiter(limiter))
+		}
+		if proxy := gitProxy(clusterConfig, f.dependencies.CliConfig); proxy != nil {
+			opts = append(opts, gitfactory.WithProxy(proxy))
+		}
+		if fluxConfig.Spec.CredentialsSecretRef != "" {
+			credentials, err := readGitCredentials(ctx, f.dependencies.Kubectl, clusterConfig, fluxConfig)
+			if err != nil {
+				return err
+			}
+			opts = append(opts, gitfactory.WithCredentials(credentials))
+		}
+
+		tools, err := gitfactory.Build(ctx, clusterConfig, fluxConfig, f.dependencies.Writer, opts...)
 		if err != nil {
 			return fmt.Errorf("creating Git provider: %v", err)
 		}
@@ -935,6 +982,47 @@ func (f *Factory) WithGit(clusterConfig *v1alpha1.Cluster, fluxConfig *v1alpha1.
 	return f
 }
 
+// readGitCredentials reads the git credentials from the secret in the management cluster. The management cluster
+// doesn't exist yet when it's being created, so the credentials are then read from the environment instead.
+func readGitCredentials(ctx context.Context, kubectl *executables.Kubectl, clusterConfig *v1alpha1.Cluster, fluxConfig *v1alpha1.FluxConfig) (*git.Credentials, error) {
+	managementClusterName := clusterConfig.ManagedBy()
+	if clusterConfig.IsSelfManaged() {
+		managementClusterName = clusterConfig.Name
+	}
+	managementCluster := &types.Cluster{
+		Name:           managementClusterName,
+		KubeconfigFile: kubeconfig.FromClusterName(managementClusterName),
+	}
+
+	if _, err := os.Stat(managementCluster.KubeconfigFile); err != nil {
+		logger.V(3).Info("Management cluster kubeconfig not found, reading git credentials from the environment", "kubeconfig", managementCluster.KubeconfigFile)
+		return nil, nil
+	}
+
+	credentials, err := flux.ReadGitCredentials(ctx, kubectl, managementCluster, fluxConfig)
+	if err != nil {
+		return nil, fmt.Errorf("reading git credentials: %v", err)
+	}
+	return credentials, nil
+}
+
+// gitProxy returns the proxy of the git operations, from the cli config or from the cluster proxy configuration.
+// It returns nil when no proxy is configured.
+func gitProxy(clusterConfig *v1alpha1.Cluster, cliConfig *config.CliConfig) *git.ProxyConfig {
+	if cliConfig != nil && cliConfig.GitProxy != "" {
+		return &git.ProxyConfig{HttpProxy: cliConfig.GitProxy, HttpsProxy: cliConfig.GitProxy}
+	}
+	if clusterConfig == nil || clusterConfig.Spec.ProxyConfiguration == nil {
+		return nil
+	}
+	proxy := clusterConfig.Spec.ProxyConfiguration
+	return &git.ProxyConfig{
+		HttpProxy:  proxy.HttpProxy,
+		HttpsProxy: proxy.HttpsProxy,
+		NoProxy:    proxy.NoProxy,
+	}
+}
+
 func (f *Factory) WithGitOpsFlux(clusterConfig *v1alpha1.Cluster, fluxConfig *v1alpha1.FluxConfig, cliConfig *config.CliConfig) *Factory {
 	f.WithWriter().WithFlux().WithKubectl().WithGit(clusterConfig, fluxConfig)
 
@@ -943,7 +1031,137 @@ func (f *Factory) WithGitOpsFlux(clusterConfig *v1alpha1.Cluster, fluxConfig *v1
 			return nil
 		}
 
-		f.dependencies.GitOpsFlux = flux.NewFlux(f.dependencies.Flux, f.dependencies.Kubectl, f.dependencies.Git, cliConfig)
+		var opts []flux.Opt
+		if fluxConfig != nil && fluxConfig.Spec.OCIRepository != nil {
+			w, err := f.dependencies.Writer.WithDir("oci")
+			if err != nil {
+				return fmt.Errorf("creating OCI artifact writer: %v", err)
+			}
+			w.CleanUpTemp()
+			opts = append(opts, flux.WithWriter(w))
+		}
+
+		if fluxConfig != nil && fluxConfig.Spec.Bucket != nil {
+			w, err := f.dependencies.Writer.WithDir("bucket")
+			if err != nil {
+				return fmt.Errorf("creating bucket files writer: %v", err)
+			}
+			w.CleanUpTemp()
+			client, err := bucket.NewS3Client(fluxConfig.Spec.Bucket)
+			if err != nil {
+				return fmt.Errorf("creating bucket client: %v", err)
+			}
+			opts = append(opts, flux.WithWriter(w), flux.WithBucketClient(client))
+		}
+
+		if cliConfig != nil && cliConfig.GitSparseCheckout {
+			opts = append(opts, flux.WithSparseCheckout())
+		}
+
+		if cliConfig != nil && cliConfig.GitOpsPullRequestFallback {
+			opts = append(opts, flux.WithPullRequestFallback())
+		}
+
+		if cliConfig != nil && cliConfig.GitOpsPullRequestBranchPrefix != "" {
+			opts = append(opts, flux.WithPullRequests(cliConfig.GitOpsPullRequestBranchPrefix))
+		}
+
+		if cliConfig != nil && cliConfig.GitOpsStagingBranch {
+			opts = append(opts, flux.WithStagingBranch())
+		}
+
+		if cliConfig != nil && cliConfig.GitRepositoryCacheDir != "" {
+			opts = append(opts, flux.WithRepositoryCache())
+		}
+
+		if cliConfig != nil && cliConfig.GitOpsLocalChanges != "" {
+			if err := flux.ValidateLocalChangesPolicy(cliConfig.GitOpsLocalChanges); err != nil {
+				return err
+			}
+			opts = append(opts, flux.WithLocalChangesPolicy(flux.LocalChangesPolicy(cliConfig.GitOpsLocalChanges)))
+		}
+
+		if cliConfig != nil && cliConfig.GitOpsRepoCleanup != "" {
+			if err := flux.ValidateGitRepoCleanup(cliConfig.GitOpsRepoCleanup); err != nil {
+				return err
+			}
+			opts = append(opts, flux.WithGitRepoCleanup(flux.GitRepoCleanup(cliConfig.GitOpsRepoCleanup)))
+		}
+
+		if cliConfig != nil && cliConfig.GitOpsChangelog {
+			opts = append(opts, flux.WithChangelog())
+		}
+
+		if cliConfig != nil && cliConfig.GitOpsUpgradeTags {
+			opts = append(opts, flux.WithUpgradeTags())
+		}
+
+		if cliConfig != nil && cliConfig.GitOpsRevertOnBootstrapFailure {
+			opts = append(opts, flux.WithRevertOnBootstrapFailure())
+		}
+
+		if cliConfig != nil && cliConfig.GitOpsVerifyManifests {
+			opts = append(opts, flux.WithVerifyManifestsBeforeBootstrap())
+		}
+
+		if cliConfig != nil && cliConfig.GitOpsVerifyClusterConfig {
+			opts = append(opts, flux.WithVerifyCommittedClusterConfig())
+		}
+
+		if cliConfig != nil && cliConfig.GitOpsAdoptExistingFlux {
+			opts = append(opts, flux.WithAdoptExistingFlux())
+		}
+
+		if features.IsActive(features.CheckpointEnabled()) {
+			opts = append(opts, flux.WithInstallCheckpoint())
+		}
+
+		if cliConfig != nil && (cliConfig.GitOpsCommitMessageTemplate != "" || len(cliConfig.GitOpsCommitTrailers) > 0) {
+			if err := flux.ValidateCommitMessageTemplate(cliConfig.GitOpsCommitMessageTemplate, cliConfig.GitOpsCommitTrailers); err != nil {
+				return err
+			}
+			opts = append(opts, flux.WithCommitMessageTemplate(cliConfig.GitOpsCommitMessageTemplate, cliConfig.GitOpsCommitTrailers...))
+		}
+
+		if cliConfig != nil && len(cliConfig.GitOpsCommitExcludePatterns) > 0 {
+			if err := flux.ValidateCommitExcludePatterns(cliConfig.GitOpsCommitExcludePatterns); err != nil {
+				return err
+			}
+			opts = append(opts, flux.WithCommitExcludePatterns(cliConfig.GitOpsCommitExcludePatterns...))
+		}
+
+		if cliConfig != nil && cliConfig.GitOpsReconcileTimeout > 0 {
+			opts = append(opts, flux.WithReconcileWait(cliConfig.GitOpsReconcileTimeout))
+		}
+
+		if cliConfig != nil && cliConfig.GitOpsControllersRolloutTimeout > 0 {
+			opts = append(opts, flux.WithControllersRolloutTimeout(cliConfig.GitOpsControllersRolloutTimeout))
+		}
+
+		if cliConfig != nil && cliConfig.GitOpsUpdateSquashWindow > 0 {
+			opts = append(opts, flux.WithUpdateCommitSquash(cliConfig.GitOpsUpdateSquashWindow))
+		}
+
+		if cliConfig != nil && (cliConfig.GitOpsMaxFileSize != 0 || cliConfig.GitOpsMaxCommitSize != 0) {
+			maxFileSize, maxCommitSize := flux.DefaultMaxFileSize, flux.DefaultMaxCommitSize
+			if cliConfig.GitOpsMaxFileSize != 0 {
+				maxFileSize = cliConfig.GitOpsMaxFileSize
+			}
+			if cliConfig.GitOpsMaxCommitSize != 0 {
+				maxCommitSize = cliConfig.GitOpsMaxCommitSize
+			}
+			opts = append(opts, flux.WithCommitSizeLimits(maxFileSize, maxCommitSize))
+		}
+
+		if f.registryMirror != nil {
+			checker, err := newRegistryMirrorImageChecker(f.registryMirror)
+			if err != nil {
+				return err
+			}
+			opts = append(opts, flux.WithImageChecker(checker))
+		}
+
+		f.dependencies.GitOpsFlux = flux.NewFlux(f.dependencies.Flux, f.dependencies.Kubectl, f.dependencies.Git, cliConfig, opts...)
 
 		return nil
 	})
@@ -951,6 +1169,23 @@ func (f *Factory) WithGitOpsFlux(clusterConfig *v1alpha1.Cluster, fluxConfig *v1
 	return f
 }
 
+// newRegistryMirrorImageChecker returns an image checker for the registry mirror, authenticating with the docker
+// credentials and trusting the registry mirror CA.
+func newRegistryMirrorImageChecker(mirror *registrymirror.RegistryMirror) (*registry.ImageChecker, error) {
+	credentialStore := registry.NewCredentialStore()
+	if err := credentialStore.Init(); err != nil {
+		return nil, fmt.Errorf("initializing registry credential store: %v", err)
+	}
+
+	var certificates *x509.CertPool
+	if mirror.CACertContent != "" {
+		certificates = x509.NewCertPool()
+		certificates.AppendCertsFromPEM([]byte(mirror.CACertContent))
+	}
+
+	return registry.NewImageChecker(registry.NewCache(), credentialStore, certificates, mirror.InsecureSkipVerify), nil
+}
+
 func (f *Factory) WithPackageInstaller(spec *cluster.Spec, packagesLocation, kubeConfig string) *Factory {
 	f.WithKubectl().WithPackageControllerClient(spec, kubeConfig).WithPackageClient()
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
@@ -185,6 +185,59 @@ func TestFactoryBuildWithClusterManagerWithoutCliConfig(t *testing.T) {
 	tt.Expect(deps.ClusterManager).NotTo(BeNil())
 }
 
+func TestFactoryBuildWithGitOpsFluxOCIRepository(t *testing.T) {
+	tt := newTest(t, vsphere)
+	fluxConfig := &anywherev1.FluxConfig{
+		Spec: anywherev1.FluxConfigSpec{
+			OCIRepository: &anywherev1.OCIRepositoryConfig{Url: "oci://registry.local/eksa/fleet", Tag: "latest"},
+		},
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithLocalExecutables().
+		WithGitOpsFlux(tt.clusterSpec.Cluster, fluxConfig, nil).
+		Build(context.Background())
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(deps.Git).To(BeNil(), "no git repository is needed for an oci repository")
+	tt.Expect(deps.GitOpsFlux).NotTo(BeNil())
+}
+
+func TestFactoryBuildWithGitOpsFluxBucket(t *testing.T) {
+	tt := newTest(t, vsphere)
+	fluxConfig := &anywherev1.FluxConfig{
+		Spec: anywherev1.FluxConfigSpec{
+			Bucket: &anywherev1.BucketConfig{BucketName: "eksa-fleet", Endpoint: "minio.local:9000"},
+		},
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithLocalExecutables().
+		WithGitOpsFlux(tt.clusterSpec.Cluster, fluxConfig, nil).
+		Build(context.Background())
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(deps.Git).To(BeNil(), "no git repository is needed for a bucket")
+	tt.Expect(deps.GitOpsFlux).NotTo(BeNil())
+}
+
+func TestFactoryBuildWithGitOpsFluxInvalidCommitExcludePatterns(t *testing.T) {
+	tt := newTest(t, vsphere)
+	fluxConfig := &anywherev1.FluxConfig{
+		Spec: anywherev1.FluxConfigSpec{
+			OCIRepository: &anywherev1.OCIRepositoryConfig{Url: "oci://registry.local/eksa/fleet", Tag: "latest"},
+		},
+	}
+	cliConfig := &config.CliConfig{GitOpsCommitExcludePatterns: []string{"*.bak", "[secret"}}
+
+	_, err := dependencies.NewFactory().
+		WithLocalExecutables().
+		WithGitOpsFlux(tt.clusterSpec.Cluster, fluxConfig, cliConfig).
+		Build(context.Background())
+
+	tt.Expect(err).To(MatchError(ContainSubstring("invalid commit exclude pattern \"[secret\"")))
+}
+
 func TestFactoryBuildWithMultipleDependencies(t *testing.T) {
 	configString := test.ReadFile(t, "testdata/cloudstack_config_multiple_profiles.ini")
 	encodedConfig := base64.StdEncoding.EncodeToString([]byte(configString))
@@ -57,8 +57,8 @@ func (b *ExecutablesBuilder) BuildAwsCli() *AwsCli {
 	return NewAwsCli(b.executableBuilder.Build(awsCliPath))
 }
 
-func (b *ExecutablesBuilder) BuildFluxExecutable() *Flux {
-	return NewFlux(b.executableBuilder.Build(fluxPath))
+func (b *ExecutablesBuilder) BuildFluxExecutable(opts ...FluxOpt) *Flux {
+	return NewFlux(b.executableBuilder.Build(fluxPath), opts...)
 }
 
 func (b *ExecutablesBuilder) BuildTroubleshootExecutable() *Troubleshoot {
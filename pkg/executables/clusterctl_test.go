@@ -42,14 +42,18 @@ func newClusterctlTest(t *testing.T) *clusterctlTest {
 	ctrl := gomock.NewController(t)
 	_, writer := test.NewWriter(t)
 	e := mockexecutables.NewMockExecutable(ctrl)
+	cluster := &types.Cluster{
+		Name:           "cluster-name",
+		KubeconfigFile: "config/c.kubeconfig",
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(cluster.Name)
+	})
 
 	return &clusterctlTest{
-		WithT: NewWithT(t),
-		ctx:   context.Background(),
-		cluster: &types.Cluster{
-			Name:           "cluster-name",
-			KubeconfigFile: "config/c.kubeconfig",
-		},
+		WithT:          NewWithT(t),
+		ctx:            context.Background(),
+		cluster:        cluster,
 		e:              e,
 		provider:       mockproviders.NewMockProvider(ctrl),
 		clusterctl:     executables.NewClusterctl(e, writer),
@@ -27,10 +27,18 @@ var redactedEnvKeys = []string{
 	decoder.CloudStackCloudConfigB64SecretKey,
 	eksaGithubTokenEnv,
 	githubTokenEnv,
+	gitlabTokenEnv,
+	bitbucketTokenEnv,
+	azureDevOpsTokenEnv,
+	giteaTokenEnv,
+	codeCommitGitPasswordEnv,
 	config.EksaAccessKeyIdEnv,
 	config.EksaSecretAccessKeyEnv,
 	config.AwsAccessKeyIdEnv,
 	config.AwsSecretAccessKeyEnv,
+	config.EksaGitPassphraseTokenEnv,
+	config.EksaGitSigningKeyPassphraseEnv,
+	config.RegistryPasswordEnv,
 	constants.SnowCredentialsKey,
 	constants.SnowCertsKey,
 	constants.NutanixUsernameKey,
@@ -97,7 +105,22 @@ func RedactCreds(cmd string, envMap map[string]string) string {
 	return cmd
 }
 
+// RegisterCredsForRedaction masks the values of the credentials environment variables in all the log output,
+// including the output of the executables.
+func RegisterCredsForRedaction() {
+	for _, key := range redactedEnvKeys {
+		logger.RegisterSecrets(os.Getenv(key))
+	}
+}
+
+func registerEnvCredsForRedaction(envMap map[string]string) {
+	for _, key := range redactedEnvKeys {
+		logger.RegisterSecrets(envMap[key])
+	}
+}
+
 func execute(ctx context.Context, cli string, in []byte, envVars map[string]string, args ...string) (stdout bytes.Buffer, err error) {
+	registerEnvCredsForRedaction(envVars)
 	var stderr bytes.Buffer
 	cmd := exec.CommandContext(ctx, cli, args...)
 	logger.V(6).Info("Executing command", "cmd", RedactCreds(cmd.String(), envVars))
@@ -5,6 +5,7 @@ import (
 
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/logger"
 )
 
 func TestRedactCreds(t *testing.T) {
@@ -17,3 +18,24 @@ func TestRedactCreds(t *testing.T) {
 		t.Fatalf("executables.RedactCreds expected = %s, got = %s", expected, redactedStr)
 	}
 }
+
+func TestRedactCredsGitProviderTokens(t *testing.T) {
+	str := "flux bootstrap git --password azuretoken123 with gitlabtoken456"
+	envMap := map[string]string{"EKSA_AZURE_DEVOPS_TOKEN": "azuretoken123", "GITLAB_TOKEN": "gitlabtoken456"}
+	expected := "flux bootstrap git --password ***** with *****"
+
+	redactedStr := executables.RedactCreds(str, envMap)
+	if redactedStr != expected {
+		t.Fatalf("executables.RedactCreds expected = %s, got = %s", expected, redactedStr)
+	}
+}
+
+func TestRegisterCredsForRedaction(t *testing.T) {
+	t.Setenv("EKSA_GITHUB_TOKEN", "my-github-token")
+
+	executables.RegisterCredsForRedaction()
+
+	if got := logger.Redact("pushing with my-github-token"); got != "pushing with *****" {
+		t.Fatalf("logger.Redact() = %s, want token redacted", got)
+	}
+}
@@ -3,10 +3,18 @@ package executables
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/git/providers/azuredevops"
+	"github.com/aws/eks-anywhere/pkg/git/providers/bitbucket"
+	"github.com/aws/eks-anywhere/pkg/git/providers/codecommit"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitea"
 	"github.com/aws/eks-anywhere/pkg/git/providers/github"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab"
+	"github.com/aws/eks-anywhere/pkg/registrymirror"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
 
@@ -15,18 +23,43 @@ const (
 	eksaGithubTokenEnv         = "EKSA_GITHUB_TOKEN"
 	githubTokenEnv             = "GITHUB_TOKEN"
 	githubProvider             = "github"
+	gitlabProvider             = "gitlab"
+	gitlabTokenEnv             = "GITLAB_TOKEN"
+	bitbucketServerProvider    = "bitbucket-server"
+	bitbucketTokenEnv          = "BITBUCKET_TOKEN"
+	azureDevOpsTokenEnv        = "EKSA_AZURE_DEVOPS_TOKEN"
+	giteaProvider              = "gitea"
+	giteaTokenEnv              = "GITEA_TOKEN"
+	codeCommitGitPasswordEnv   = "EKSA_CODECOMMIT_GIT_PASSWORD"
 	gitProvider                = "git"
 	defaultPrivateKeyAlgorithm = "ecdsa"
+	// defaultFluxRegistry is the registry flux pulls the toolkit components images from by default.
+	defaultFluxRegistry = "ghcr.io/fluxcd"
 )
 
 type Flux struct {
 	Executable
+	registryMirror *registrymirror.RegistryMirror
 }
 
-func NewFlux(executable Executable) *Flux {
-	return &Flux{
+type FluxOpt func(*Flux)
+
+// WithFluxRegistryMirror makes flux install the toolkit components with the images of the registry mirror,
+// when it mirrors the flux registry.
+func WithFluxRegistryMirror(mirror *registrymirror.RegistryMirror) FluxOpt {
+	return func(f *Flux) {
+		f.registryMirror = mirror
+	}
+}
+
+func NewFlux(executable Executable, opts ...FluxOpt) *Flux {
+	f := &Flux{
 		Executable: executable,
 	}
+	for _, o := range opts {
+		o(f)
+	}
+	return f
 }
 
 // BootstrapGithub creates the GitHub repository if it doesn’t exist, and commits the toolkit
@@ -42,11 +75,14 @@ func (f *Flux) BootstrapGithub(ctx context.Context, cluster *types.Cluster, flux
 		"--path", c.ClusterConfigPath,
 		"--ssh-key-algorithm", defaultPrivateKeyAlgorithm,
 	}
-	params = setUpCommonParamsBootstrap(cluster, fluxConfig, params)
+	params = f.setUpCommonParamsBootstrap(cluster, fluxConfig, params)
 
 	if c.Github.Personal {
 		params = append(params, "--personal")
 	}
+	if c.Github.Hostname != "" {
+		params = append(params, "--hostname", c.Github.Hostname)
+	}
 
 	token, err := github.GetGithubAccessTokenFromEnv()
 	if err != nil {
@@ -64,21 +100,199 @@ func (f *Flux) BootstrapGithub(ctx context.Context, cluster *types.Cluster, flux
 	return err
 }
 
+// BootstrapGitlab creates the Gitlab project if it doesn't exist, and commits the toolkit
+// components manifests to the main branch. Then it configures the target cluster to synchronize with the repository.
+// If the toolkit components are present on the cluster, the bootstrap command will perform an upgrade if needed.
+func (f *Flux) BootstrapGitlab(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error {
+	c := fluxConfig.Spec
+	params := []string{
+		"bootstrap",
+		gitlabProvider,
+		"--repository", c.Gitlab.Repository,
+		"--owner", c.Gitlab.Owner,
+		"--hostname", gitlab.Hostname(c.Gitlab),
+		"--path", c.ClusterConfigPath,
+		"--ssh-key-algorithm", defaultPrivateKeyAlgorithm,
+	}
+	params = f.setUpCommonParamsBootstrap(cluster, fluxConfig, params)
+
+	if c.Gitlab.Personal {
+		params = append(params, "--personal")
+	}
+
+	token, err := gitlab.GetGitlabAccessTokenFromEnv()
+	if err != nil {
+		return fmt.Errorf("setting token env: %v", err)
+	}
+
+	env := make(map[string]string)
+	env[gitlabTokenEnv] = token
+
+	_, err = f.ExecuteWithEnv(ctx, env, params...)
+	if err != nil {
+		return fmt.Errorf("executing flux bootstrap gitlab: %v", err)
+	}
+
+	return err
+}
+
+// BootstrapBitbucketServer creates the Bitbucket Server repository if it doesn't exist, and commits the toolkit
+// components manifests to the main branch. Then it configures the target cluster to synchronize with the repository.
+// If the toolkit components are present on the cluster, the bootstrap command will perform an upgrade if needed.
+func (f *Flux) BootstrapBitbucketServer(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error {
+	c := fluxConfig.Spec
+	params := []string{
+		"bootstrap",
+		bitbucketServerProvider,
+		"--repository", c.BitbucketServer.Repository,
+		"--owner", c.BitbucketServer.Owner,
+		"--hostname", c.BitbucketServer.Hostname,
+		"--username", c.BitbucketServer.Username,
+		"--path", c.ClusterConfigPath,
+		"--ssh-key-algorithm", defaultPrivateKeyAlgorithm,
+	}
+	params = f.setUpCommonParamsBootstrap(cluster, fluxConfig, params)
+
+	if c.BitbucketServer.Personal {
+		params = append(params, "--personal")
+	}
+
+	token, err := bitbucket.GetBitbucketAccessTokenFromEnv()
+	if err != nil {
+		return fmt.Errorf("setting token env: %v", err)
+	}
+
+	env := make(map[string]string)
+	env[bitbucketTokenEnv] = token
+
+	_, err = f.ExecuteWithEnv(ctx, env, params...)
+	if err != nil {
+		return fmt.Errorf("executing flux bootstrap bitbucket-server: %v", err)
+	}
+
+	return err
+}
+
+// BootstrapGitea creates the Gitea repository if it doesn't exist, and commits the toolkit components manifests
+// to the main branch. Then it configures the target cluster to synchronize with the repository.
+// If the toolkit components are present on the cluster, the bootstrap command will perform an upgrade if needed.
+func (f *Flux) BootstrapGitea(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error {
+	c := fluxConfig.Spec
+	params := []string{
+		"bootstrap",
+		giteaProvider,
+		"--repository", c.Gitea.Repository,
+		"--owner", c.Gitea.Owner,
+		"--hostname", c.Gitea.Hostname,
+		"--path", c.ClusterConfigPath,
+		"--ssh-key-algorithm", defaultPrivateKeyAlgorithm,
+	}
+	params = f.setUpCommonParamsBootstrap(cluster, fluxConfig, params)
+
+	if c.Gitea.Personal {
+		params = append(params, "--personal")
+	}
+
+	token, err := gitea.GetGiteaAccessTokenFromEnv()
+	if err != nil {
+		return fmt.Errorf("setting token env: %v", err)
+	}
+
+	env := make(map[string]string)
+	env[giteaTokenEnv] = token
+
+	_, err = f.ExecuteWithEnv(ctx, env, params...)
+	if err != nil {
+		return fmt.Errorf("executing flux bootstrap gitea: %v", err)
+	}
+
+	return err
+}
+
+// BootstrapAzureDevOps commits the toolkit components manifests to the branch of an existing Azure DevOps repository
+// using the generic git bootstrap with token auth, since flux doesn't have a dedicated Azure DevOps bootstrap command.
+// It then configures the target cluster to synchronize with the repository.
+func (f *Flux) BootstrapAzureDevOps(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error {
+	c := fluxConfig.Spec
+	token, err := azuredevops.GetAzureDevOpsAccessTokenFromEnv()
+	if err != nil {
+		return fmt.Errorf("setting token env: %v", err)
+	}
+
+	params := []string{
+		"bootstrap",
+		gitProvider,
+		"--url", azuredevops.RepoUrl(c.AzureDevOps.Organization, c.AzureDevOps.Project, c.AzureDevOps.Repository),
+		"--path", c.ClusterConfigPath,
+		"--token-auth",
+		"--password", token,
+		"--silent",
+	}
+	params = f.setUpCommonParamsBootstrap(cluster, fluxConfig, params)
+
+	// flux only takes the token as a flag, passing it in the env too makes it redacted from the logged command
+	env := map[string]string{azureDevOpsTokenEnv: token}
+	_, err = f.ExecuteWithEnv(ctx, env, params...)
+	if err != nil {
+		return fmt.Errorf("executing flux bootstrap git for azure devops: %v", err)
+	}
+
+	return err
+}
+
+// BootstrapCodeCommit commits the toolkit components manifests to the branch of an existing CodeCommit repository
+// using the generic git bootstrap over HTTPS, since flux doesn't have a dedicated CodeCommit bootstrap command.
+// The cluster syncs with CodeCommit HTTPS Git credentials because SigV4 signed passwords expire after a few minutes.
+func (f *Flux) BootstrapCodeCommit(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error {
+	c := fluxConfig.Spec
+	username, password, err := codecommit.GetGitCredentialsFromEnv()
+	if err != nil {
+		return fmt.Errorf("setting git credentials env: %v", err)
+	}
+
+	params := []string{
+		"bootstrap",
+		gitProvider,
+		"--url", codecommit.RepoUrl(c.CodeCommit.Region, c.CodeCommit.Repository),
+		"--path", c.ClusterConfigPath,
+		"--token-auth",
+		"--username", username,
+		"--password", password,
+		"--silent",
+	}
+	params = f.setUpCommonParamsBootstrap(cluster, fluxConfig, params)
+
+	// flux only takes the password as a flag, passing it in the env too makes it redacted from the logged command
+	env := map[string]string{codeCommitGitPasswordEnv: password}
+	_, err = f.ExecuteWithEnv(ctx, env, params...)
+	if err != nil {
+		return fmt.Errorf("executing flux bootstrap git for codecommit: %v", err)
+	}
+
+	return err
+}
+
 // BootstrapGit commits the toolkit components manifests to the branch of a Git repository.
 // It then configures the target cluster to synchronize with the repository. If the toolkit components are present on the cluster, the
 // bootstrap command will perform an upgrade if needed.
 func (f *Flux) BootstrapGit(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig, cliConfig *config.CliConfig) error {
 	c := fluxConfig.Spec
+	// flux renders the url into the committed GitRepository source, so credentials must never be part of it
+	repoUrl, _, err := git.SplitUrlCredentials(c.Git.RepositoryUrl)
+	if err != nil {
+		return fmt.Errorf("executing flux bootstrap git: %v", err)
+	}
+
 	params := []string{
 		"bootstrap",
 		gitProvider,
-		"--url", c.Git.RepositoryUrl,
+		"--url", repoUrl,
 		"--path", c.ClusterConfigPath,
 		"--private-key-file", cliConfig.GitPrivateKeyFile,
 		"--silent",
 	}
 
-	params = setUpCommonParamsBootstrap(cluster, fluxConfig, params)
+	params = f.setUpCommonParamsBootstrap(cluster, fluxConfig, params)
 	if fluxConfig.Spec.Git.SshKeyAlgorithm != "" {
 		params = append(params, "--ssh-key-algorithm", fluxConfig.Spec.Git.SshKeyAlgorithm)
 	} else {
@@ -91,14 +305,14 @@ func (f *Flux) BootstrapGit(ctx context.Context, cluster *types.Cluster, fluxCon
 
 	env := make(map[string]string)
 	env["SSH_KNOWN_HOSTS"] = cliConfig.GitKnownHostsFile
-	_, err := f.ExecuteWithEnv(ctx, env, params...)
+	_, err = f.ExecuteWithEnv(ctx, env, params...)
 	if err != nil {
 		return fmt.Errorf("executing flux bootstrap git: %v", err)
 	}
 	return err
 }
 
-func setUpCommonParamsBootstrap(cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig, params []string) []string {
+func (f *Flux) setUpCommonParamsBootstrap(cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig, params []string) []string {
 	c := fluxConfig.Spec
 	if cluster.KubeconfigFile != "" {
 		params = append(params, "--kubeconfig", cluster.KubeconfigFile)
@@ -109,7 +323,10 @@ func setUpCommonParamsBootstrap(cluster *types.Cluster, fluxConfig *v1alpha1.Flu
 	if c.SystemNamespace != "" {
 		params = append(params, "--namespace", c.SystemNamespace)
 	}
-	return params
+	if c.MultiTenancy != nil && c.MultiTenancy.ClusterDomain != "" {
+		params = append(params, "--cluster-domain", c.MultiTenancy.ClusterDomain)
+	}
+	return f.setUpComponentsParams(c, params)
 }
 
 func (f *Flux) Uninstall(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error {
@@ -173,6 +390,12 @@ func (f *Flux) ResumeKustomization(ctx context.Context, cluster *types.Cluster,
 func (f *Flux) Reconcile(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error {
 	c := fluxConfig.Spec
 	params := []string{"reconcile", "source", "git"}
+	switch {
+	case c.OCIRepository != nil:
+		params = []string{"reconcile", "source", "oci"}
+	case c.Bucket != nil:
+		params = []string{"reconcile", "source", "bucket"}
+	}
 
 	if c.SystemNamespace != "" {
 		params = append(params, c.SystemNamespace, "--namespace", c.SystemNamespace)
@@ -190,3 +413,113 @@ func (f *Flux) Reconcile(ctx context.Context, cluster *types.Cluster, fluxConfig
 
 	return nil
 }
+
+// InstallComponents installs the toolkit components in the cluster without configuring a Git source for them,
+// for clusters that sync from an OCI artifact or a bucket.
+func (f *Flux) InstallComponents(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error {
+	params := []string{"install"}
+	params = f.setUpCommonParamsInstall(fluxConfig, params)
+	if cluster.KubeconfigFile != "" {
+		params = append(params, "--kubeconfig", cluster.KubeconfigFile)
+	}
+
+	if _, err := f.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("executing flux install: %v", err)
+	}
+	return nil
+}
+
+// ExportComponents returns the toolkit components manifests, the same ones InstallComponents applies to the cluster.
+func (f *Flux) ExportComponents(ctx context.Context, fluxConfig *v1alpha1.FluxConfig) ([]byte, error) {
+	params := []string{"install", "--export"}
+	params = f.setUpCommonParamsInstall(fluxConfig, params)
+
+	out, err := f.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("executing flux install export: %v", err)
+	}
+	return out.Bytes(), nil
+}
+
+func (f *Flux) setUpCommonParamsInstall(fluxConfig *v1alpha1.FluxConfig, params []string) []string {
+	c := fluxConfig.Spec
+	if c.SystemNamespace != "" {
+		params = append(params, "--namespace", c.SystemNamespace)
+	}
+	if c.MultiTenancy != nil && c.MultiTenancy.ClusterDomain != "" {
+		params = append(params, "--cluster-domain", c.MultiTenancy.ClusterDomain)
+	}
+	return f.setUpComponentsParams(c, params)
+}
+
+// setUpComponentsParams selects the toolkit components to install, adding the image automation controllers to the
+// extra components when image automation is enabled, and the registry mirror their images are pulled from.
+func (f *Flux) setUpComponentsParams(c v1alpha1.FluxConfigSpec, params []string) []string {
+	if registry := f.registryMirror.ReplaceRegistry(defaultFluxRegistry); registry != defaultFluxRegistry {
+		params = append(params, "--registry", registry)
+	}
+
+	if len(c.Components) > 0 {
+		params = append(params, "--components", strings.Join(c.Components, ","))
+	}
+
+	extra := c.ComponentsExtra
+	if c.ImageAutomation {
+		extra = append(append([]string{}, extra...), v1alpha1.FluxImageReflectorController, v1alpha1.FluxImageAutomationController)
+	}
+	extra = uniqueComponents(extra)
+	if len(extra) > 0 {
+		params = append(params, "--components-extra", strings.Join(extra, ","))
+	}
+	return params
+}
+
+func uniqueComponents(components []string) []string {
+	seen := make(map[string]struct{}, len(components))
+	unique := make([]string, 0, len(components))
+	for _, c := range components {
+		if _, ok := seen[c]; ok {
+			continue
+		}
+		seen[c] = struct{}{}
+		unique = append(unique, c)
+	}
+	return unique
+}
+
+// PushArtifact packages the manifests in dir and pushes them to the OCI repository of the FluxConfig.
+func (f *Flux) PushArtifact(ctx context.Context, fluxConfig *v1alpha1.FluxConfig, dir string) error {
+	c := fluxConfig.Spec.OCIRepository
+	params := []string{
+		"push", "artifact", ociArtifactRef(c),
+		"--path", dir,
+		"--source", c.Url,
+		"--revision", c.Tag,
+	}
+	if c.Insecure {
+		params = append(params, "--insecure-registry")
+	}
+
+	if _, err := f.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("executing flux push artifact: %v", err)
+	}
+	return nil
+}
+
+// PullArtifact pulls the OCI artifact of the FluxConfig and extracts its manifests in dir.
+func (f *Flux) PullArtifact(ctx context.Context, fluxConfig *v1alpha1.FluxConfig, dir string) error {
+	c := fluxConfig.Spec.OCIRepository
+	params := []string{"pull", "artifact", ociArtifactRef(c), "--output", dir}
+	if c.Insecure {
+		params = append(params, "--insecure-registry")
+	}
+
+	if _, err := f.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("executing flux pull artifact: %v", err)
+	}
+	return nil
+}
+
+func ociArtifactRef(c *v1alpha1.OCIRepositoryConfig) string {
+	return c.Url + ":" + c.Tag
+}
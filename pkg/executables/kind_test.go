@@ -147,6 +147,7 @@ func TestKindCreateBootstrapClusterSuccessWithRegistryMirror(t *testing.T) {
 	eksClusterName := "test_cluster-eks-a-cluster"
 	kubeConfigFile := "test_cluster.kind.kubeconfig"
 	registryMirror := "registry-mirror.test"
+	defer os.RemoveAll(clusterName)
 	registryMirrorWithPort := net.JoinHostPort(registryMirror, constants.DefaultHttpsPort)
 
 	// Initialize gomock
@@ -218,6 +218,23 @@ func (k *Kubectl) ApplyManifest(ctx context.Context, kubeconfigPath, manifestPat
 	return nil
 }
 
+// DryRunApplyKustomization verifies the kustomization in dir can be applied to the cluster, using a server-side dry-run
+// so the objects are validated by the api server and admission webhooks without being persisted.
+func (k *Kubectl) DryRunApplyKustomization(ctx context.Context, cluster *types.Cluster, dir string) error {
+	if _, err := k.Execute(ctx, "apply", "-k", dir, "--dry-run=server", "--kubeconfig", cluster.KubeconfigFile); err != nil {
+		return fmt.Errorf("executing server-side dry-run apply of kustomization %s: %v", dir, err)
+	}
+	return nil
+}
+
+// ApplyKustomization applies the kustomization in dir to the cluster.
+func (k *Kubectl) ApplyKustomization(ctx context.Context, cluster *types.Cluster, dir string) error {
+	if _, err := k.Execute(ctx, "apply", "-k", dir, "--kubeconfig", cluster.KubeconfigFile); err != nil {
+		return fmt.Errorf("executing apply of kustomization %s: %v", dir, err)
+	}
+	return nil
+}
+
 func (k *Kubectl) ApplyKubeSpecWithNamespace(ctx context.Context, cluster *types.Cluster, spec string, namespace string) error {
 	params := []string{"apply", "-f", spec, "--namespace", namespace}
 	if cluster.KubeconfigFile != "" {
@@ -1420,6 +1437,17 @@ func (k *Kubectl) RemoveAnnotationInNamespace(ctx context.Context, resourceType,
 	return k.RemoveAnnotation(ctx, resourceType, objectName, key, WithCluster(cluster), WithNamespace(namespace))
 }
 
+// MergePatchResource applies a JSON merge patch to a resource.
+func (k *Kubectl) MergePatchResource(ctx context.Context, resourceType, objectName, patch string, opts ...KubectlOpt) error {
+	params := []string{"patch", resourceType, objectName, "--type=merge", "-p", patch}
+	applyOpts(&params, opts...)
+	_, err := k.Execute(ctx, params...)
+	if err != nil {
+		return fmt.Errorf("patching %s %s: %v", resourceType, objectName, err)
+	}
+	return nil
+}
+
 func (k *Kubectl) GetEksaCluster(ctx context.Context, cluster *types.Cluster, clusterName string) (*v1alpha1.Cluster, error) {
 	params := []string{"get", eksaClusterResourceType, "-A", "-o", "jsonpath={.items[0]}", "--kubeconfig", cluster.KubeconfigFile, "--field-selector=metadata.name=" + clusterName}
 	stdOut, err := k.Execute(ctx, params...)
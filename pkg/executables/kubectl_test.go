@@ -118,6 +118,50 @@ func TestKubectlApplyManifestError(t *testing.T) {
 	}
 }
 
+func TestKubectlDryRunApplyKustomizationSuccess(t *testing.T) {
+	dir := "clusters/test-cluster/test-cluster/eksa-system"
+
+	k, ctx, cluster, e := newKubectl(t)
+	expectedParam := []string{"apply", "-k", dir, "--dry-run=server", "--kubeconfig", cluster.KubeconfigFile}
+	e.EXPECT().Execute(ctx, gomock.Eq(expectedParam)).Return(bytes.Buffer{}, nil)
+	if err := k.DryRunApplyKustomization(ctx, cluster, dir); err != nil {
+		t.Errorf("Kubectl.DryRunApplyKustomization() error = %v, want nil", err)
+	}
+}
+
+func TestKubectlDryRunApplyKustomizationError(t *testing.T) {
+	dir := "clusters/test-cluster/test-cluster/eksa-system"
+
+	k, ctx, cluster, e := newKubectl(t)
+	expectedParam := []string{"apply", "-k", dir, "--dry-run=server", "--kubeconfig", cluster.KubeconfigFile}
+	e.EXPECT().Execute(ctx, gomock.Eq(expectedParam)).Return(bytes.Buffer{}, errors.New("error from execute"))
+	if err := k.DryRunApplyKustomization(ctx, cluster, dir); err == nil {
+		t.Errorf("Kubectl.DryRunApplyKustomization() error = nil, want not nil")
+	}
+}
+
+func TestKubectlApplyKustomizationSuccess(t *testing.T) {
+	dir := "clusters/test-cluster/flux-system"
+
+	k, ctx, cluster, e := newKubectl(t)
+	expectedParam := []string{"apply", "-k", dir, "--kubeconfig", cluster.KubeconfigFile}
+	e.EXPECT().Execute(ctx, gomock.Eq(expectedParam)).Return(bytes.Buffer{}, nil)
+	if err := k.ApplyKustomization(ctx, cluster, dir); err != nil {
+		t.Errorf("Kubectl.ApplyKustomization() error = %v, want nil", err)
+	}
+}
+
+func TestKubectlApplyKustomizationError(t *testing.T) {
+	dir := "clusters/test-cluster/flux-system"
+
+	k, ctx, cluster, e := newKubectl(t)
+	expectedParam := []string{"apply", "-k", dir, "--kubeconfig", cluster.KubeconfigFile}
+	e.EXPECT().Execute(ctx, gomock.Eq(expectedParam)).Return(bytes.Buffer{}, errors.New("error from execute"))
+	if err := k.ApplyKustomization(ctx, cluster, dir); err == nil {
+		t.Errorf("Kubectl.ApplyKustomization() error = nil, want not nil")
+	}
+}
+
 func TestKubectlApplyKubeSpecFromBytesSuccess(t *testing.T) {
 	var data []byte
 
@@ -1951,6 +1995,34 @@ func TestKubectlUpdateAnnotation(t *testing.T) {
 	}
 }
 
+func TestKubectlMergePatchResource(t *testing.T) {
+	k, ctx, cluster, e := newKubectl(t)
+	patch := `{"spec":{"ref":{"branch":"main"}}}`
+	e.EXPECT().Execute(ctx, []string{
+		"patch", "gitrepositories", "flux-system", "--type=merge", "-p", patch,
+		"--kubeconfig", cluster.KubeconfigFile, "--namespace", "flux-system",
+	})
+
+	err := k.MergePatchResource(ctx, "gitrepositories", "flux-system", patch, executables.WithCluster(cluster), executables.WithNamespace("flux-system"))
+	if err != nil {
+		t.Fatalf("Kubectl.MergePatchResource() error = %v, want nil", err)
+	}
+}
+
+func TestKubectlMergePatchResourceError(t *testing.T) {
+	k, ctx, cluster, e := newKubectl(t)
+	patch := `{"spec":{"ref":{"branch":"main"}}}`
+	e.EXPECT().Execute(ctx, []string{
+		"patch", "gitrepositories", "flux-system", "--type=merge", "-p", patch,
+		"--kubeconfig", cluster.KubeconfigFile,
+	}).Return(bytes.Buffer{}, errors.New("error in patch"))
+
+	err := k.MergePatchResource(ctx, "gitrepositories", "flux-system", patch, executables.WithCluster(cluster))
+	if err == nil {
+		t.Fatal("Kubectl.MergePatchResource() error = nil, want not nil")
+	}
+}
+
 func TestKubectlRemoveAnnotation(t *testing.T) {
 	k, ctx, cluster, e := newKubectl(t)
 	e.EXPECT().Execute(ctx, []string{
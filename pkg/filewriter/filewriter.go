@@ -18,6 +18,18 @@ type FileWriter interface {
 type FileOptions struct {
 	IsTemp      bool
 	Permissions os.FileMode
+	LineEnding  LineEnding
 }
 
+// LineEnding is the sequence used to terminate the lines of the written files.
+type LineEnding string
+
+const (
+	// LF terminates lines with a line feed. This is the default so the generated files
+	// are identical regardless of the OS the CLI runs on.
+	LF LineEnding = "\n"
+	// CRLF terminates lines with a carriage return and a line feed.
+	CRLF LineEnding = "\r\n"
+)
+
 type FileOptionsFunc func(op *FileOptions)
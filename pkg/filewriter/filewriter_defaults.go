@@ -7,7 +7,7 @@ import (
 const DefaultTmpFolder = "generated"
 
 func defaultFileOptions() *FileOptions {
-	return &FileOptions{true, os.ModePerm}
+	return &FileOptions{true, os.ModePerm, LF}
 }
 
 func Permission0600(op *FileOptions) {
@@ -17,3 +17,10 @@ func Permission0600(op *FileOptions) {
 func PersistentFile(op *FileOptions) {
 	op.IsTemp = false
 }
+
+// WithLineEnding sets the line ending used when writing the file content.
+func WithLineEnding(l LineEnding) FileOptionsFunc {
+	return func(op *FileOptions) {
+		op.LineEnding = l
+	}
+}
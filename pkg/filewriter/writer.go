@@ -1,6 +1,7 @@
 package filewriter
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -30,7 +31,7 @@ func (w *writer) Write(fileName string, content []byte, opts ...FileOptionsFunc)
 	o := buildOptions(w, opts)
 
 	filePath := filepath.Join(o.BasePath, fileName)
-	err := ioutil.WriteFile(filePath, content, o.Permissions)
+	err := ioutil.WriteFile(filePath, normalizeLineEndings(content, o.LineEnding), o.Permissions)
 	if err != nil {
 		return "", fmt.Errorf("writing to file [%s]: %v", filePath, err)
 	}
@@ -76,6 +77,7 @@ func (w *writer) Create(name string, opts ...FileOptionsFunc) (_ io.WriteCloser,
 type options struct {
 	BasePath    string
 	Permissions fs.FileMode
+	LineEnding  LineEnding
 }
 
 // buildOptions converts a set of FileOptionsFunc's to a single options struct.
@@ -95,5 +97,15 @@ func buildOptions(w *writer, opts []FileOptionsFunc) options {
 	return options{
 		BasePath:    basePath,
 		Permissions: op.Permissions,
+		LineEnding:  op.LineEnding,
 	}
 }
+
+// normalizeLineEndings converts all the line endings in content to l.
+func normalizeLineEndings(content []byte, l LineEnding) []byte {
+	content = bytes.ReplaceAll(content, []byte(CRLF), []byte(LF))
+	if l == CRLF {
+		content = bytes.ReplaceAll(content, []byte(LF), []byte(CRLF))
+	}
+	return content
+}
@@ -157,6 +157,62 @@ func TestWriterWritePersistent(t *testing.T) {
 	}
 }
 
+func TestWriterWriteLineEndings(t *testing.T) {
+	folder := "tmp_folder_line_endings"
+	defer os.RemoveAll(folder)
+
+	tests := []struct {
+		testName    string
+		content     []byte
+		options     []filewriter.FileOptionsFunc
+		wantContent string
+	}{
+		{
+			testName:    "default LF from CRLF",
+			content:     []byte("apiVersion: v1\r\nkind: ConfigMap\r\n"),
+			wantContent: "apiVersion: v1\nkind: ConfigMap\n",
+		},
+		{
+			testName:    "default LF from mixed",
+			content:     []byte("apiVersion: v1\r\nkind: ConfigMap\n"),
+			wantContent: "apiVersion: v1\nkind: ConfigMap\n",
+		},
+		{
+			testName:    "default LF unchanged",
+			content:     []byte("apiVersion: v1\nkind: ConfigMap\n"),
+			wantContent: "apiVersion: v1\nkind: ConfigMap\n",
+		},
+		{
+			testName:    "CRLF from mixed",
+			content:     []byte("apiVersion: v1\r\nkind: ConfigMap\n"),
+			options:     []filewriter.FileOptionsFunc{filewriter.WithLineEnding(filewriter.CRLF)},
+			wantContent: "apiVersion: v1\r\nkind: ConfigMap\r\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			tr, err := filewriter.NewWriter(folder)
+			if err != nil {
+				t.Fatalf("failed creating writer error = %v", err)
+			}
+
+			gotPath, err := tr.Write("TestWriterWriteLineEndings.yaml", tt.content, tt.options...)
+			if err != nil {
+				t.Fatalf("writer.Write() error = %v", err)
+			}
+
+			got, err := os.ReadFile(gotPath)
+			if err != nil {
+				t.Fatalf("reading written file error = %v", err)
+			}
+
+			if string(got) != tt.wantContent {
+				t.Errorf("writer.Write() content = %q, want %q", got, tt.wantContent)
+			}
+		})
+	}
+}
+
 func TestWriterDir(t *testing.T) {
 	rootFolder := "folder_root"
 	defer os.RemoveAll(rootFolder)
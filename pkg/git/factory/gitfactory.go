@@ -2,8 +2,12 @@ package gitfactory
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	nethttp "net/http"
 	"os"
 	"path"
 	"path/filepath"
@@ -20,7 +24,13 @@ import (
 	"github.com/aws/eks-anywhere/pkg/git"
 	"github.com/aws/eks-anywhere/pkg/git/gitclient"
 	"github.com/aws/eks-anywhere/pkg/git/gogithub"
+	"github.com/aws/eks-anywhere/pkg/git/providers/azuredevops"
+	"github.com/aws/eks-anywhere/pkg/git/providers/bitbucket"
+	"github.com/aws/eks-anywhere/pkg/git/providers/codecommit"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitea"
 	"github.com/aws/eks-anywhere/pkg/git/providers/github"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab"
+	"github.com/aws/eks-anywhere/pkg/logger"
 )
 
 type GitTools struct {
@@ -28,10 +38,43 @@ type GitTools struct {
 	Client              git.Client
 	Writer              filewriter.FileWriter
 	RepositoryDirectory string
+	// KnownHostsFile is the file the pinned known hosts of the FluxConfig are written to, empty if they aren't set.
+	KnownHostsFile string
+
+	clusterScopedDirectory bool
+	providerRateLimiter    *git.ProviderRateLimiter
+	cloneDepth             int
+	proxy                  *git.ProxyConfig
+	authorName             string
+	authorEmail            string
+	credentials            *git.Credentials
+	githubAppTokenSource   *gogithub.InstallationTokenSource
+	cacheDirectory         string
+	workspaceDirectory     string
+	workspaceCleanup       WorkspaceCleanupPolicy
 }
 
 type GitToolsOpt func(opts *GitTools)
 
+// WorkspaceCleanupPolicy is when the local repository is removed after an operation.
+type WorkspaceCleanupPolicy string
+
+const (
+	// WorkspaceCleanupNever keeps the local repository after the operation. It's the default.
+	WorkspaceCleanupNever WorkspaceCleanupPolicy = "never"
+	// WorkspaceCleanupAlways removes the local repository after the operation, even if it failed.
+	WorkspaceCleanupAlways WorkspaceCleanupPolicy = "always"
+	// WorkspaceCleanupOnSuccess removes the local repository after a successful operation, keeping it to debug
+	// failed ones.
+	WorkspaceCleanupOnSuccess WorkspaceCleanupPolicy = "on-success"
+)
+
+const knownHostsFileName = "git-known-hosts"
+
+type providerHTTPClient interface {
+	Do(req *nethttp.Request) (*nethttp.Response, error)
+}
+
 func Build(ctx context.Context, cluster *v1alpha1.Cluster, fluxConfig *v1alpha1.FluxConfig, writer filewriter.FileWriter, opts ...GitToolsOpt) (*GitTools, error) {
 	var repo string
 	var repoUrl string
@@ -39,6 +82,27 @@ func Build(ctx context.Context, cluster *v1alpha1.Cluster, fluxConfig *v1alpha1.
 	var err error
 	var tools GitTools
 
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&tools)
+		}
+	}
+	if err = tools.exportCredentials(fluxConfig); err != nil {
+		return nil, err
+	}
+	if err = validateWorkspaceCleanup(tools.workspaceCleanup); err != nil {
+		return nil, err
+	}
+	if err = tools.setupGithubAppToken(ctx, fluxConfig); err != nil {
+		return nil, err
+	}
+
+	// A nil client makes the providers use their default http client
+	var httpClient providerHTTPClient
+	if tools.proxy != nil {
+		httpClient = tools.proxy.HTTPClient()
+	}
+
 	switch {
 	case fluxConfig.Spec.Github != nil:
 		githubToken, err := github.GetGithubAccessTokenFromEnv()
@@ -46,40 +110,147 @@ func Build(ctx context.Context, cluster *v1alpha1.Cluster, fluxConfig *v1alpha1.
 			return nil, err
 		}
 
-		tools.Provider, err = buildGithubProvider(ctx, githubToken, fluxConfig.Spec.Github)
+		tools.Provider, err = buildGithubProvider(ctx, githubToken, fluxConfig.Spec.Github, tools.proxy, tools.githubAppTokenSource)
 		if err != nil {
 			return nil, fmt.Errorf("building github provider: %v", err)
 		}
 
 		gitAuth = &http.BasicAuth{Password: githubToken, Username: fluxConfig.Spec.Github.Owner}
+		if tools.githubAppTokenSource != nil {
+			gitAuth = github.NewAppTokenAuth(tools.githubAppTokenSource)
+		}
 		repo = fluxConfig.Spec.Github.Repository
-		repoUrl = github.RepoUrl(fluxConfig.Spec.Github.Owner, repo)
+		repoUrl = github.RepoUrl(github.Hostname(fluxConfig.Spec.Github), fluxConfig.Spec.Github.Owner, repo)
+	case fluxConfig.Spec.Gitlab != nil:
+		gitlabToken, err := gitlab.GetGitlabAccessTokenFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		auth := git.TokenAuth{Token: gitlabToken, Username: fluxConfig.Spec.Gitlab.Owner}
+		tools.Provider, err = gitlab.New(httpClient, fluxConfig.Spec.Gitlab, auth)
+		if err != nil {
+			return nil, fmt.Errorf("building gitlab provider: %v", err)
+		}
+		gitAuth = &http.BasicAuth{Password: gitlabToken, Username: "oauth2"}
+		repo = fluxConfig.Spec.Gitlab.Repository
+		repoUrl = gitlab.RepoUrl(gitlab.Hostname(fluxConfig.Spec.Gitlab), fluxConfig.Spec.Gitlab.Owner, repo)
+	case fluxConfig.Spec.BitbucketServer != nil:
+		bitbucketToken, err := bitbucket.GetBitbucketAccessTokenFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		config := fluxConfig.Spec.BitbucketServer
+		auth := git.TokenAuth{Token: bitbucketToken, Username: config.Username}
+		tools.Provider, err = bitbucket.New(httpClient, config, auth)
+		if err != nil {
+			return nil, fmt.Errorf("building bitbucket server provider: %v", err)
+		}
+		gitAuth = &http.BasicAuth{Password: bitbucketToken, Username: config.Username}
+		repo = config.Repository
+		repoUrl = bitbucket.RepoUrl(config.Hostname, config.Owner, repo, config.Personal)
+	case fluxConfig.Spec.AzureDevOps != nil:
+		azureDevOpsToken, err := azuredevops.GetAzureDevOpsAccessTokenFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		config := fluxConfig.Spec.AzureDevOps
+		tools.Provider, err = azuredevops.New(httpClient, config, git.TokenAuth{Token: azureDevOpsToken})
+		if err != nil {
+			return nil, fmt.Errorf("building azure devops provider: %v", err)
+		}
+		gitAuth = &http.BasicAuth{Password: azureDevOpsToken, Username: "git"}
+		repo = config.Repository
+		repoUrl = azuredevops.RepoUrl(config.Organization, config.Project, repo)
+	case fluxConfig.Spec.Gitea != nil:
+		giteaToken, err := gitea.GetGiteaAccessTokenFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		config := fluxConfig.Spec.Gitea
+		tools.Provider, err = gitea.New(httpClient, config, git.TokenAuth{Token: giteaToken, Username: config.Owner})
+		if err != nil {
+			return nil, fmt.Errorf("building gitea provider: %v", err)
+		}
+		gitAuth = &http.BasicAuth{Password: giteaToken, Username: config.Owner}
+		repo = config.Repository
+		repoUrl = gitea.RepoUrl(config.Hostname, config.Owner, repo)
+	case fluxConfig.Spec.CodeCommit != nil:
+		config := fluxConfig.Spec.CodeCommit
+		credentials, err := codecommit.RetrieveCredentials(ctx, config.Region)
+		if err != nil {
+			return nil, err
+		}
+		tools.Provider, err = codecommit.New(httpClient, config, credentials)
+		if err != nil {
+			return nil, fmt.Errorf("building codecommit provider: %v", err)
+		}
+		gitAuth = codecommit.NewSigV4Auth(credentials, config.Region, config.Repository)
+		repo = config.Repository
+		repoUrl = codecommit.RepoUrl(config.Region, repo)
 	case fluxConfig.Spec.Git != nil:
 		privateKeyFile := os.Getenv(config.EksaGitPrivateKeyTokenEnv)
 		privateKeyPassphrase := os.Getenv(config.EksaGitPassphraseTokenEnv)
 		gitKnownHosts := os.Getenv(config.EksaGitKnownHostsFileEnv)
+		if fluxConfig.Spec.Git.KnownHosts != "" {
+			if gitKnownHosts, err = writer.Write(knownHostsFileName, []byte(fluxConfig.Spec.Git.KnownHosts)); err != nil {
+				return nil, fmt.Errorf("writing pinned known hosts: %v", err)
+			}
+			tools.KnownHostsFile = gitKnownHosts
+		}
 		if err = os.Setenv(config.SshKnownHostsEnv, gitKnownHosts); err != nil {
 			return nil, fmt.Errorf("unable to set %s: %v", config.SshKnownHostsEnv, err)
 		}
-		gitAuth, err = getSshAuthFromPrivateKey(privateKeyFile, privateKeyPassphrase)
+		var credentials *git.UrlCredentials
+		repoUrl, credentials, err = git.SplitUrlCredentials(fluxConfig.Spec.Git.RepositoryUrl)
 		if err != nil {
 			return nil, err
 		}
-		repoUrl = fluxConfig.Spec.Git.RepositoryUrl
+		if credentials != nil {
+			logger.Info("Warning: 'repositoryUrl' contains embedded credentials, they won't be logged or committed to the repository. "+
+				"Remove them from the url and provide the private key through the EKSA_GIT_PRIVATE_KEY environment variable instead", "repositoryUrl", repoUrl)
+		}
+		sshAuth, err := getSshAuth(privateKeyFile, privateKeyPassphrase, credentials)
+		if err != nil {
+			return nil, err
+		}
+		if gitKnownHosts != "" {
+			if err = setHostKeyCallback(sshAuth, gitKnownHosts); err != nil {
+				return nil, err
+			}
+		}
+		gitAuth = sshAuth
 		repo = path.Base(strings.TrimSuffix(repoUrl, filepath.Ext(repoUrl)))
 	default:
 		return nil, fmt.Errorf("no valid git provider in FluxConfigSpec. Spec: %v", fluxConfig)
 	}
 
-	tools.RepositoryDirectory = filepath.Join(cluster.Name, "git", repo)
-	for _, opt := range opts {
-		if opt != nil {
-			opt(&tools)
-		}
+	if tools.Provider != nil && tools.providerRateLimiter != nil {
+		tools.Provider = git.NewRateLimitedProviderClient(tools.Provider, tools.providerRateLimiter)
+	}
+
+	localGitRepoPath := filepath.Join("git", repo)
+	if tools.clusterScopedDirectory {
+		localGitRepoPath = filepath.Join("git", cluster.Name, repo)
+	}
+	switch {
+	case tools.cacheDirectory != "":
+		tools.RepositoryDirectory = cachedRepositoryDirectory(tools.cacheDirectory, repo, repoUrl, cluster.Name)
+	case tools.workspaceDirectory != "":
+		tools.RepositoryDirectory = filepath.Join(tools.workspaceDirectory, localGitRepoPath)
+	case tools.RepositoryDirectory == "":
+		tools.RepositoryDirectory = filepath.Join(cluster.Name, localGitRepoPath)
 	}
-	tools.Client = buildGitClient(ctx, gitAuth, repoUrl, tools.RepositoryDirectory)
+	signer, err := buildCommitSigner()
+	if err != nil {
+		return nil, err
+	}
+	tools.Client = buildGitClient(ctx, gitAuth, repoUrl, signer, &tools)
 
-	tools.Writer, err = newRepositoryWriter(writer, repo)
+	if tools.cacheDirectory != "" || tools.workspaceDirectory != "" {
+		tools.Writer, err = newRepositoryWriterAt(tools.RepositoryDirectory)
+	} else {
+		tools.Writer, err = newRepositoryWriter(writer, localGitRepoPath)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -87,19 +258,48 @@ func Build(ctx context.Context, cluster *v1alpha1.Cluster, fluxConfig *v1alpha1.
 	return &tools, nil
 }
 
-func buildGitClient(ctx context.Context, auth transport.AuthMethod, repoUrl string, repo string) *gitclient.GitClient {
+func buildGitClient(ctx context.Context, auth transport.AuthMethod, repoUrl string, signer gitclient.CommitSigner, tools *GitTools) *gitclient.GitClient {
 	opts := []gitclient.Opt{
 		gitclient.WithRepositoryUrl(repoUrl),
-		gitclient.WithRepositoryDirectory(repo),
+		gitclient.WithRepositoryDirectory(tools.RepositoryDirectory),
 		gitclient.WithAuth(auth),
+		gitclient.WithCloneDepth(tools.cloneDepth),
+		gitclient.WithCommitAuthor(tools.authorName, tools.authorEmail),
+	}
+	if signer != nil {
+		opts = append(opts, gitclient.WithCommitSigner(signer))
+	}
+	if tools.proxy != nil {
+		opts = append(opts, gitclient.WithProxy(tools.proxy))
 	}
 
 	return gitclient.New(opts...)
 }
 
-func buildGithubProvider(ctx context.Context, githubToken string, config *v1alpha1.GithubProviderConfig) (git.ProviderClient, error) {
+// buildCommitSigner loads the GPG or SSH key to sign commits with from the environment.
+// It returns a nil signer if no signing key is configured, so commits are left unsigned.
+func buildCommitSigner() (gitclient.CommitSigner, error) {
+	keyFile := os.Getenv(config.EksaGitSigningKeyFileEnv)
+	if keyFile == "" {
+		return nil, nil
+	}
+
+	signer, err := gitclient.LoadCommitSigner(keyFile, os.Getenv(config.EksaGitSigningKeyPassphraseEnv))
+	if err != nil {
+		return nil, fmt.Errorf("building git commit signer: %v", err)
+	}
+	return signer, nil
+}
+
+func buildGithubProvider(ctx context.Context, githubToken string, config *v1alpha1.GithubProviderConfig, proxy *git.ProxyConfig, appTokenSource *gogithub.InstallationTokenSource) (git.ProviderClient, error) {
 	auth := git.TokenAuth{Token: githubToken, Username: config.Owner}
-	gogithubOpts := gogithub.Options{Auth: auth}
+	gogithubOpts := gogithub.Options{Auth: auth, Hostname: config.Hostname}
+	if proxy != nil {
+		gogithubOpts.HTTPClient = proxy.HTTPClient()
+	}
+	if appTokenSource != nil {
+		gogithubOpts.TokenSource = appTokenSource
+	}
 	githubProviderClient := gogithub.New(ctx, gogithubOpts)
 	provider, err := github.New(githubProviderClient, config, auth)
 	if err != nil {
@@ -109,9 +309,26 @@ func buildGithubProvider(ctx context.Context, githubToken string, config *v1alph
 	return provider, nil
 }
 
-func newRepositoryWriter(writer filewriter.FileWriter, repository string) (filewriter.FileWriter, error) {
-	localGitWriterPath := filepath.Join("git", repository)
-	gitwriter, err := writer.WithDir(localGitWriterPath)
+func newRepositoryWriter(writer filewriter.FileWriter, localGitRepoPath string) (filewriter.FileWriter, error) {
+	gitwriter, err := writer.WithDir(localGitRepoPath)
+	if err != nil {
+		return nil, fmt.Errorf("creating file writer: %v", err)
+	}
+	gitwriter.CleanUpTemp()
+	return gitwriter, nil
+}
+
+// cachedRepositoryDirectory returns the directory of the repository in the cache directory. It's suffixed by a hash
+// of the repository url and the cluster name, so repositories with the same name from different owners or providers
+// don't share it, and concurrent operations on different clusters sharing the repository don't use the same clone.
+func cachedRepositoryDirectory(cacheDir, repo, repoUrl, clusterName string) string {
+	sum := sha256.Sum256([]byte(repoUrl + "\x00" + clusterName))
+	return filepath.Join(cacheDir, fmt.Sprintf("%s-%s", repo, hex.EncodeToString(sum[:])[:12]))
+}
+
+// newRepositoryWriterAt returns a writer rooted at the repository directory, outside of the cluster directory.
+func newRepositoryWriterAt(repoDir string) (filewriter.FileWriter, error) {
+	gitwriter, err := filewriter.NewWriter(repoDir)
 	if err != nil {
 		return nil, fmt.Errorf("creating file writer: %v", err)
 	}
@@ -125,6 +342,170 @@ func WithRepositoryDirectory(repoDir string) GitToolsOpt {
 	}
 }
 
+// WithRepositoryCache keeps the local repository in a directory of cacheDir that is stable for the repository url,
+// instead of the cluster directory, so it's reused by the next operations instead of being cloned again.
+// It takes precedence over WithRepositoryDirectory and WithClusterScopedDirectory.
+func WithRepositoryCache(cacheDir string) GitToolsOpt {
+	return func(opts *GitTools) {
+		opts.cacheDirectory = cacheDir
+	}
+}
+
+// WithWorkspaceDirectory clones the local repository under workspaceDir, instead of the cluster directory, for example
+// to keep it on a volume with more space. It takes precedence over WithRepositoryDirectory.
+func WithWorkspaceDirectory(workspaceDir string) GitToolsOpt {
+	return func(opts *GitTools) {
+		opts.workspaceDirectory = workspaceDir
+	}
+}
+
+// WithWorkspaceCleanup sets when CleanUpWorkspace removes the local repository.
+func WithWorkspaceCleanup(policy WorkspaceCleanupPolicy) GitToolsOpt {
+	return func(opts *GitTools) {
+		opts.workspaceCleanup = policy
+	}
+}
+
+// WithClusterScopedDirectory namespaces the local repository directory by cluster name (git/<clusterName>/<repo>)
+// so operations on different clusters sharing the same writer root don't reuse the same working tree.
+func WithClusterScopedDirectory() GitToolsOpt {
+	return func(opts *GitTools) {
+		opts.clusterScopedDirectory = true
+	}
+}
+
+// WithProviderRateLimiter rate limits the git provider requests with the given limiter.
+// Share the same limiter between the GitTools of multiple clusters to limit their combined requests.
+func WithProviderRateLimiter(limiter *git.ProviderRateLimiter) GitToolsOpt {
+	return func(opts *GitTools) {
+		opts.providerRateLimiter = limiter
+	}
+}
+
+// WithCloneDepth makes the git client clone only the last depth commits of each branch, so large repositories
+// can be cloned faster and with less disk. A depth lower or equal to zero clones the full history.
+func WithCloneDepth(depth int) GitToolsOpt {
+	return func(opts *GitTools) {
+		opts.cloneDepth = depth
+	}
+}
+
+// WithProxy sends the git provider API requests and the clones, pulls and pushes of the git client through the proxy.
+func WithProxy(proxy *git.ProxyConfig) GitToolsOpt {
+	return func(opts *GitTools) {
+		opts.proxy = proxy
+	}
+}
+
+// WithCommitAuthor attributes the commits of the git client to the author name and email, instead of the default EKS-A author.
+func WithCommitAuthor(name, email string) GitToolsOpt {
+	return func(opts *GitTools) {
+		opts.authorName = name
+		opts.authorEmail = email
+	}
+}
+
+// WithCredentials authenticates to the git provider with the credentials instead of the token environment variable.
+func WithCredentials(credentials *git.Credentials) GitToolsOpt {
+	return func(opts *GitTools) {
+		opts.credentials = credentials
+	}
+}
+
+// exportCredentials sets the provider token environment variable to the configured credentials token, so it's
+// used by both the git tools and the flux bootstrap. It fails for the providers that don't authenticate with a token.
+func (t *GitTools) exportCredentials(fluxConfig *v1alpha1.FluxConfig) error {
+	if t.credentials == nil || t.credentials.Token == "" {
+		return nil
+	}
+
+	var env string
+	switch {
+	case fluxConfig.Spec.Github != nil:
+		env = github.EksaGithubTokenEnv
+	case fluxConfig.Spec.Gitlab != nil:
+		env = gitlab.EksaGitlabTokenEnv
+	case fluxConfig.Spec.BitbucketServer != nil:
+		env = bitbucket.EksaBitbucketTokenEnv
+	case fluxConfig.Spec.AzureDevOps != nil:
+		env = azuredevops.EksaAzureDevOpsTokenEnv
+	case fluxConfig.Spec.Gitea != nil:
+		env = gitea.EksaGiteaTokenEnv
+	case fluxConfig.Spec.CodeCommit != nil:
+		return errors.New("git credentials secret is not supported with the codecommit provider, which authenticates with the AWS credentials")
+	case fluxConfig.Spec.Git != nil:
+		return errors.New("git credentials secret is not supported with the git provider, which authenticates with the EKSA_GIT_PRIVATE_KEY ssh key")
+	default:
+		return fmt.Errorf("git credentials secret is not supported without a git provider in FluxConfig %s", fluxConfig.Name)
+	}
+
+	if err := os.Setenv(env, t.credentials.Token); err != nil {
+		return fmt.Errorf("unable to set %s: %v", env, err)
+	}
+	return nil
+}
+
+// setupGithubAppToken builds the installation token source for the Github App configured in the environment. The
+// git tools and the flux bootstrap read the token from it before each remote operation, so it's refreshed before
+// it expires.
+func (t *GitTools) setupGithubAppToken(ctx context.Context, fluxConfig *v1alpha1.FluxConfig) error {
+	if fluxConfig.Spec.Github == nil {
+		return nil
+	}
+
+	app, err := github.GetGithubAppCredentialsFromEnv()
+	if err != nil || app == nil {
+		return err
+	}
+
+	var httpClient *nethttp.Client
+	if t.proxy != nil {
+		httpClient = t.proxy.HTTPClient()
+	}
+	source := gogithub.NewInstallationTokenSource(ctx, gogithub.AppInstallation{
+		AppID:          app.AppID,
+		InstallationID: app.InstallationID,
+		PrivateKey:     app.PrivateKey,
+		Hostname:       fluxConfig.Spec.Github.Hostname,
+	}, httpClient)
+	if _, err := source.Token(); err != nil {
+		return err
+	}
+
+	github.SetAppTokenSource(source)
+	t.githubAppTokenSource = source
+	return nil
+}
+
+// getSshAuth builds the ssh auth method from the configured private key. Credentials embedded in the repository url
+// are only used for password auth when no private key is configured.
+func getSshAuth(privateKeyFile, passphrase string, credentials *git.UrlCredentials) (gogitssh.AuthMethod, error) {
+	if credentials == nil || privateKeyFile != "" {
+		return getSshAuthFromPrivateKey(privateKeyFile, passphrase)
+	}
+
+	return &gogitssh.Password{
+		User:     credentials.Username,
+		Password: credentials.Password,
+	}, nil
+}
+
+// setHostKeyCallback makes the ssh auth verify the host keys against the known hosts file.
+func setHostKeyCallback(auth gogitssh.AuthMethod, knownHostsFile string) error {
+	callback, err := git.NewKnownHostsCallback(knownHostsFile)
+	if err != nil {
+		return err
+	}
+
+	switch a := auth.(type) {
+	case *gogitssh.PublicKeys:
+		a.HostKeyCallback = callback
+	case *gogitssh.Password:
+		a.HostKeyCallback = callback
+	}
+	return nil
+}
+
 func getSshAuthFromPrivateKey(privateKeyFile string, passphrase string) (gogitssh.AuthMethod, error) {
 	signer, err := getSignerFromPrivateKeyFile(privateKeyFile, passphrase)
 	if err != nil {
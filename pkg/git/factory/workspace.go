@@ -0,0 +1,42 @@
+package gitfactory
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+func validateWorkspaceCleanup(policy WorkspaceCleanupPolicy) error {
+	switch policy {
+	case "", WorkspaceCleanupNever, WorkspaceCleanupAlways, WorkspaceCleanupOnSuccess:
+		return nil
+	default:
+		return fmt.Errorf("invalid git workspace cleanup policy %q, it must be one of %s, %s or %s", policy, WorkspaceCleanupNever, WorkspaceCleanupAlways, WorkspaceCleanupOnSuccess)
+	}
+}
+
+// CleanUpWorkspace removes the local repository according to the workspace cleanup policy, given the error of the
+// operation that used it. The repository cache is never removed, since it's meant to be reused by the next operations.
+// Failing to remove the repository is only logged.
+func (t *GitTools) CleanUpWorkspace(operationErr error) {
+	if t.Writer == nil || t.cacheDirectory != "" {
+		return
+	}
+
+	switch t.workspaceCleanup {
+	case WorkspaceCleanupAlways:
+	case WorkspaceCleanupOnSuccess:
+		if operationErr != nil {
+			logger.V(3).Info("Operation failed, keeping the local git repository", "directory", t.Writer.Dir())
+			return
+		}
+	default:
+		return
+	}
+
+	logger.V(3).Info("Removing the local git repository", "directory", t.Writer.Dir())
+	if err := os.RemoveAll(t.Writer.Dir()); err != nil {
+		logger.Info("Warning: failed to remove the local git repository", "directory", t.Writer.Dir(), "error", err.Error())
+	}
+}
@@ -0,0 +1,113 @@
+package gitfactory_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	gitFactory "github.com/aws/eks-anywhere/pkg/git/factory"
+)
+
+func buildWorkspaceGitTools(t *testing.T, opts ...gitFactory.GitToolsOpt) (*gitFactory.GitTools, error) {
+	setupContext(t)
+	cluster := &v1alpha1.Cluster{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "testCluster",
+		},
+	}
+	fluxConfig := &v1alpha1.FluxConfig{
+		Spec: v1alpha1.FluxConfigSpec{
+			Github: &v1alpha1.GithubProviderConfig{
+				Owner:      "Jeff",
+				Repository: "testRepo",
+				Personal:   true,
+			},
+		},
+	}
+	_, w := test.NewWriter(t)
+	return gitFactory.Build(context.Background(), cluster, fluxConfig, w, opts...)
+}
+
+func TestGitFactoryWorkspaceDirectory(t *testing.T) {
+	g := NewWithT(t)
+	workspace := t.TempDir()
+
+	tools, err := buildWorkspaceGitTools(t, gitFactory.WithWorkspaceDirectory(workspace), gitFactory.WithClusterScopedDirectory())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tools.RepositoryDirectory).To(Equal(filepath.Join(workspace, "git", "testCluster", "testRepo")))
+	g.Expect(tools.Writer.Dir()).To(Equal(tools.RepositoryDirectory))
+}
+
+func TestGitFactoryInvalidWorkspaceCleanup(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := buildWorkspaceGitTools(t, gitFactory.WithWorkspaceCleanup("sometimes"))
+	g.Expect(err).To(MatchError(ContainSubstring(`invalid git workspace cleanup policy "sometimes"`)))
+}
+
+func TestGitToolsCleanUpWorkspace(t *testing.T) {
+	tests := []struct {
+		testName     string
+		policy       gitFactory.WorkspaceCleanupPolicy
+		operationErr error
+		wantRemoved  bool
+	}{
+		{
+			testName: "default",
+		},
+		{
+			testName: "never",
+			policy:   gitFactory.WorkspaceCleanupNever,
+		},
+		{
+			testName:     "always after failure",
+			policy:       gitFactory.WorkspaceCleanupAlways,
+			operationErr: errors.New("upgrade failed"),
+			wantRemoved:  true,
+		},
+		{
+			testName:    "on success after success",
+			policy:      gitFactory.WorkspaceCleanupOnSuccess,
+			wantRemoved: true,
+		},
+		{
+			testName:     "on success after failure",
+			policy:       gitFactory.WorkspaceCleanupOnSuccess,
+			operationErr: errors.New("upgrade failed"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			tools, err := buildWorkspaceGitTools(t, gitFactory.WithWorkspaceDirectory(t.TempDir()), gitFactory.WithWorkspaceCleanup(tt.policy))
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(os.MkdirAll(filepath.Join(tools.RepositoryDirectory, ".git"), 0o755)).To(Succeed())
+
+			tools.CleanUpWorkspace(tt.operationErr)
+
+			if tt.wantRemoved {
+				g.Expect(tools.RepositoryDirectory).NotTo(BeADirectory())
+			} else {
+				g.Expect(tools.RepositoryDirectory).To(BeADirectory())
+			}
+		})
+	}
+}
+
+func TestGitToolsCleanUpWorkspaceRepositoryCache(t *testing.T) {
+	g := NewWithT(t)
+	tools, err := buildWorkspaceGitTools(t, gitFactory.WithRepositoryCache(t.TempDir()), gitFactory.WithWorkspaceCleanup(gitFactory.WorkspaceCleanupAlways))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	tools.CleanUpWorkspace(nil)
+
+	g.Expect(tools.RepositoryDirectory).To(BeADirectory())
+}
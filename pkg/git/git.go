@@ -3,8 +3,13 @@ package git
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 )
 
+// CommitAuthor is the author name of the commits created by EKS-A.
+const CommitAuthor = "EKS-A"
+
 type Client interface {
 	Add(filename string) error
 	Remove(filename string) error
@@ -12,9 +17,24 @@ type Client interface {
 	Commit(message string) error
 	Push(ctx context.Context) error
 	Pull(ctx context.Context, branch string) error
+	FetchAndReset(ctx context.Context, branch string) error
 	Init() error
 	Branch(name string) error
 	ValidateRemoteExists(ctx context.Context) error
+	LastCommit() (*Commit, error)
+	AmendCommit(message string) error
+	ForcePush(ctx context.Context, lease string) error
+	ResetSoft(commit string) error
+	DeleteRemoteBranch(ctx context.Context, branch string) error
+	PushToBranch(ctx context.Context, branch string) error
+	Tag(name, message string) error
+	PushTag(ctx context.Context, name string) error
+	SetSparseCheckoutDirectories(dirs ...string)
+	RebaseOnRemote(ctx context.Context) error
+	LocalChanges(ctx context.Context, branch string) (*LocalChanges, error)
+	StagedFiles() ([]string, error)
+	RemoteBranchExists(ctx context.Context, branch string) (bool, error)
+	RemoteBranches(ctx context.Context) ([]string, error)
 }
 
 type ProviderClient interface {
@@ -24,6 +44,7 @@ type ProviderClient interface {
 	AddDeployKeyToRepo(ctx context.Context, opts AddDeployKeyOpts) error
 	Validate(ctx context.Context) error
 	PathExists(ctx context.Context, owner, repo, branch, path string) (bool, error)
+	CreatePullRequest(ctx context.Context, opts CreatePullRequestOpts) (*PullRequest, error)
 }
 
 type CreateRepoOpts struct {
@@ -53,6 +74,64 @@ type AddDeployKeyOpts struct {
 	ReadOnly   bool
 }
 
+// CreatePullRequestOpts describes a pull request from the Head branch into the Base branch of the configured repository.
+type CreatePullRequestOpts struct {
+	Title       string
+	Description string
+	Head        string
+	Base        string
+}
+
+// WebhookProviderClient is implemented by the git providers that can register webhooks in the repository.
+type WebhookProviderClient interface {
+	CreateWebhook(ctx context.Context, opts CreateWebhookOpts) error
+	WebhookExists(ctx context.Context, url string) (bool, error)
+}
+
+// CreateWebhookOpts describes a webhook of the configured repository, signed with the Secret and sent to the Url on Events.
+type CreateWebhookOpts struct {
+	Url    string
+	Secret string
+	Events []string
+}
+
+// PermissionsProviderClient is implemented by the git providers that can check the permissions of their credentials.
+type PermissionsProviderClient interface {
+	// ValidatePermissions checks the credentials can create the repository, write its contents and add deploy keys to it.
+	ValidatePermissions(ctx context.Context) error
+}
+
+// PushAccessProviderClient is implemented by the git providers that can check whether their credentials can push
+// to the repository.
+type PushAccessProviderClient interface {
+	// ValidatePushAccess returns an error if the credentials can't push to an existing repository.
+	ValidatePushAccess(ctx context.Context) error
+}
+
+// BranchProtectionProviderClient is implemented by the git providers that can check the protection rules of a branch.
+type BranchProtectionProviderClient interface {
+	// ValidateBranchPush returns a ProtectedBranchError if the protection rules of the branch reject direct pushes
+	// from the credentials.
+	ValidateBranchPush(ctx context.Context, branch string) error
+}
+
+// ArchiveRepoProviderClient is implemented by the git providers that can archive a repository, making it read-only.
+type ArchiveRepoProviderClient interface {
+	ArchiveRepo(ctx context.Context, opts ArchiveRepoOpts) error
+}
+
+// ArchiveRepoOpts describes the repository to archive.
+type ArchiveRepoOpts struct {
+	Owner      string
+	Repository string
+}
+
+// PullRequest describes a pull request, or merge request, opened in the git provider.
+type PullRequest struct {
+	Number int
+	Url    string
+}
+
 type Repository struct {
 	Name         string
 	Owner        string
@@ -60,11 +139,24 @@ type Repository struct {
 	CloneUrl     string
 }
 
+// Commit describes a commit in the local repository.
+type Commit struct {
+	Hash    string
+	Message string
+	Author  string
+	When    time.Time
+}
+
 type TokenAuth struct {
 	Username string
 	Token    string
 }
 
+// Credentials are the git provider credentials read from a secret, used instead of the ones from the environment.
+type Credentials struct {
+	Token string
+}
+
 type RepositoryDoesNotExistError struct {
 	repository string
 	owner      string
@@ -97,3 +189,89 @@ type RemoteBranchDoesNotExistError struct {
 func (e *RemoteBranchDoesNotExistError) Error() string {
 	return fmt.Sprintf("error pulling from repository %s: remote branch %s does not exist", e.Repository, e.Branch)
 }
+
+// PushRejectedError is returned when the remote rejects a push because its branch has commits missing in the
+// local branch, like when another operation pushed to the same branch concurrently.
+type PushRejectedError struct {
+	Branch string
+	Err    error
+}
+
+func (e *PushRejectedError) Error() string {
+	return fmt.Sprintf("push to branch %s rejected, the remote branch has new commits: %v", e.Branch, e.Err)
+}
+
+func (e *PushRejectedError) Unwrap() error {
+	return e.Err
+}
+
+// ProtectedBranchError is returned when the protection rules of a branch reject direct pushes, like when the branch
+// requires pull request reviews or status checks.
+type ProtectedBranchError struct {
+	Branch string
+	// Rules are the protection rules rejecting the pushes, empty if unknown.
+	Rules []string
+	Err   error
+}
+
+func (e *ProtectedBranchError) Error() string {
+	msg := fmt.Sprintf("branch %s is protected and doesn't accept direct pushes", e.Branch)
+	if len(e.Rules) > 0 {
+		msg = fmt.Sprintf("%s, it requires %s", msg, strings.Join(e.Rules, " and "))
+	}
+	if e.Err != nil {
+		msg = fmt.Sprintf("%s: %v", msg, e.Err)
+	}
+	return msg
+}
+
+func (e *ProtectedBranchError) Unwrap() error {
+	return e.Err
+}
+
+// RebaseConflictError is returned when a commit can't be replayed on top of the remote branch because the
+// commits of the remote changed the same files.
+type RebaseConflictError struct {
+	Paths []string
+}
+
+func (e *RebaseConflictError) Error() string {
+	return fmt.Sprintf("files changed both locally and in the remote branch: %s", strings.Join(e.Paths, ", "))
+}
+
+// LocalChanges are the changes of a local repository branch that aren't in its remote branch.
+type LocalChanges struct {
+	// UncommittedFiles are the tracked files changed in the worktree or the index and not committed, sorted.
+	// Untracked files aren't included.
+	UncommittedFiles []string
+	// UnpushedCommits are the hashes of the commits of the local branch missing in the remote branch, newest first.
+	UnpushedCommits []string
+	// Diverged is true when the remote branch also has commits missing in the local branch.
+	Diverged bool
+	// OnBranch is true when the branch is the one checked out.
+	OnBranch bool
+}
+
+// IsEmpty returns true if the local branch has no uncommitted changes nor unpushed commits.
+func (c *LocalChanges) IsEmpty() bool {
+	return len(c.UncommittedFiles) == 0 && len(c.UnpushedCommits) == 0
+}
+
+// String summarizes the local changes, like "uncommitted changes to a.yaml and b.yaml and 2 unpushed commits".
+func (c *LocalChanges) String() string {
+	var parts []string
+	if len(c.UncommittedFiles) > 0 {
+		parts = append(parts, fmt.Sprintf("uncommitted changes to %s", strings.Join(c.UncommittedFiles, ", ")))
+	}
+	if len(c.UnpushedCommits) > 0 {
+		commits := fmt.Sprintf("%d unpushed commits", len(c.UnpushedCommits))
+		if len(c.UnpushedCommits) == 1 {
+			commits = "1 unpushed commit"
+		}
+		if c.Diverged {
+			commits += " diverging from the remote branch"
+		}
+		parts = append(parts, commits)
+	}
+	return strings.Join(parts, " and ")
+}
@@ -4,7 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
+	"io"
 	"strings"
 	"time"
 
@@ -33,18 +33,25 @@ type GitClient struct {
 	RepoUrl       string
 	RepoDirectory string
 	Retrier       *retrier.Retrier
+	progress      io.Writer
+	signer        CommitSigner
+	cloneDepth    int
+	sparseDirs    []string
+	authorName    string
+	authorEmail   string
 }
 
 type Opt func(*GitClient)
 
 func New(opts ...Opt) *GitClient {
 	c := &GitClient{
-		Client:  &goGit{},
-		Retrier: retrier.NewWithMaxRetries(maxRetries, backOffPeriod),
+		Retrier:  retrier.NewWithMaxRetries(maxRetries, backOffPeriod),
+		progress: newProgressLogger(),
 	}
 	for _, opt := range opts {
 		opt(c)
 	}
+	c.Client = &goGit{progress: c.progress}
 	return c
 }
 
@@ -66,14 +73,68 @@ func WithRepositoryDirectory(repoDir string) Opt {
 	}
 }
 
+// WithProgressWriter configures the writer where the output of the git remote operations (clone, push and pull)
+// is streamed to. By default it's streamed to the logger when max logging is enabled and discarded otherwise.
+func WithProgressWriter(w io.Writer) Opt {
+	return func(c *GitClient) {
+		c.progress = w
+	}
+}
+
+// WithCloneDepth limits the history fetched by Clone to the given number of commits of each branch.
+// A depth lower or equal to zero clones the full history, which is the default.
+func WithCloneDepth(depth int) Opt {
+	return func(c *GitClient) {
+		c.cloneDepth = depth
+	}
+}
+
+// WithCommitAuthor sets the name and email of the author and committer of the commits created by the client,
+// so they are attributed to a given identity. An empty name defaults to git.CommitAuthor.
+func WithCommitAuthor(name, email string) Opt {
+	return func(c *GitClient) {
+		c.authorName = name
+		c.authorEmail = email
+	}
+}
+
+func (g *GitClient) commitSignature() *object.Signature {
+	name := g.authorName
+	if name == "" {
+		name = git.CommitAuthor
+	}
+	return &object.Signature{
+		Name:  name,
+		Email: g.authorEmail,
+		When:  time.Now(),
+	}
+}
+
 func (g *GitClient) Clone(ctx context.Context) error {
-	_, err := g.Client.Clone(ctx, g.RepoDirectory, g.RepoUrl, g.Auth)
+	if g.cloneDepth > 0 {
+		logger.V(3).Info("Shallow cloning repository", "repo", g.RepoDirectory, "depth", g.cloneDepth)
+	}
+	opts := CloneOpts{Depth: g.cloneDepth, NoCheckout: g.sparse()}
+	r, err := g.Client.Clone(ctx, g.RepoDirectory, g.RepoUrl, g.Auth, opts)
 	if err != nil && strings.Contains(err.Error(), emptyRepoError) {
 		return &git.RepositoryIsEmptyError{
 			Repository: g.RepoDirectory,
 		}
 	}
-	return err
+	if err != nil {
+		return err
+	}
+
+	if g.sparse() {
+		if err = g.sparseCheckoutHead(r); err != nil {
+			return fmt.Errorf("cloning repository: %v", err)
+		}
+	}
+
+	if err = g.updateSubmodules(ctx, r); err != nil {
+		return fmt.Errorf("cloning repository: %v", err)
+	}
+	return nil
 }
 
 func (g *GitClient) Add(filename string) error {
@@ -89,6 +150,14 @@ func (g *GitClient) Add(filename string) error {
 		return err
 	}
 
+	submodules, err := g.submodulePaths()
+	if err != nil {
+		return err
+	}
+	if len(submodules) > 0 {
+		return g.addSkippingSubmodules(w, filename, submodules)
+	}
+
 	logger.V(3).Info("Tracking specified files", "file", filename)
 	err = g.Client.AddGlob(filename, w)
 	return err
@@ -127,21 +196,244 @@ func (g *GitClient) Commit(message string) error {
 	}
 
 	logger.V(3).Info("Generating Commit object...")
-	commitSignature := &object.Signature{
-		Name: "EKS-A",
-		When: time.Now(),
-	}
+	commitSignature := g.commitSignature()
 	commit, err := g.Client.Commit(message, commitSignature, w)
 	if err != nil {
 		return err
 	}
 
+	if g.signer != nil {
+		logger.V(3).Info("Signing commit", "hash", commit)
+		if commit, err = g.Client.SignCommit(r, commit, g.signer); err != nil {
+			return fmt.Errorf("signing commit: %v", err)
+		}
+	}
+
 	logger.V(3).Info("Committing Object to local repo", "repo", g.RepoDirectory)
 	finalizedCommit, err := g.Client.CommitObject(r, commit)
 	logger.Info("Finalized commit and committed to local repository", "hash", finalizedCommit.Hash)
+	if err == nil && logger.MaxLogging() {
+		logCommitStats(finalizedCommit)
+	}
 	return err
 }
 
+func logCommitStats(commit *object.Commit) {
+	stats, err := commit.Stats()
+	if err != nil {
+		logger.V(logger.MaxLoggingLevel()).Info("Failed getting commit stats", "hash", commit.Hash, "error", err)
+		return
+	}
+	for _, s := range stats {
+		logger.V(logger.MaxLoggingLevel()).Info("git output", "line", strings.TrimSpace(s.String()))
+	}
+}
+
+// LastCommit returns the commit HEAD points to in the local repository.
+func (g *GitClient) LastCommit() (*git.Commit, error) {
+	r, err := g.Client.OpenDir(g.RepoDirectory)
+	if err != nil {
+		return nil, fmt.Errorf("getting last commit: %v", err)
+	}
+
+	commit, err := g.headCommit(r)
+	if err != nil {
+		return nil, fmt.Errorf("getting last commit: %v", err)
+	}
+
+	return &git.Commit{
+		Hash:    commit.Hash.String(),
+		Message: commit.Message,
+		Author:  commit.Author.Name,
+		When:    commit.Author.When,
+	}, nil
+}
+
+// AmendCommit replaces the commit HEAD points to with a new one including the staged changes,
+// keeping the same parents.
+func (g *GitClient) AmendCommit(message string) error {
+	r, err := g.Client.OpenDir(g.RepoDirectory)
+	if err != nil {
+		return fmt.Errorf("amending commit: %v", err)
+	}
+
+	head, err := g.headCommit(r)
+	if err != nil {
+		return fmt.Errorf("amending commit: %v", err)
+	}
+
+	if len(head.ParentHashes) == 0 {
+		return fmt.Errorf("amending commit: commit %s is the root commit and can't be amended", head.Hash)
+	}
+
+	w, err := g.Client.OpenWorktree(r)
+	if err != nil {
+		return fmt.Errorf("amending commit: %v", err)
+	}
+
+	commitSignature := g.commitSignature()
+	logger.V(3).Info("Amending commit", "hash", head.Hash)
+	commit, err := g.Client.CommitWithParents(message, commitSignature, w, head.ParentHashes)
+	if err != nil {
+		return fmt.Errorf("amending commit: %v", err)
+	}
+
+	if g.signer != nil {
+		if commit, err = g.Client.SignCommit(r, commit, g.signer); err != nil {
+			return fmt.Errorf("amending commit: signing commit: %v", err)
+		}
+	}
+
+	logger.Info("Amended commit in local repository", "previous", head.Hash, "hash", commit)
+	return nil
+}
+
+func (g *GitClient) headCommit(r *gogit.Repository) (*object.Commit, error) {
+	ref, err := g.Client.Head(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.Client.CommitObject(r, ref.Hash())
+}
+
+// ForcePush pushes the current branch to the remote, overwriting the remote branch history. The remote branch is only
+// overwritten if it still points to the lease commit, so the commits pushed concurrently by other operations are never
+// lost, otherwise a PushRejectedError is returned. Only the current branch is pushed.
+func (g *GitClient) ForcePush(ctx context.Context, lease string) error {
+	logger.V(3).Info("Force pushing to remote", "repo", g.RepoDirectory)
+	r, err := g.Client.OpenDir(g.RepoDirectory)
+	if err != nil {
+		return fmt.Errorf("force pushing: %v", err)
+	}
+
+	ref, err := g.Client.Head(r)
+	if err != nil {
+		return fmt.Errorf("force pushing: %v", err)
+	}
+
+	err = g.Client.ForcePushWithContext(ctx, r, g.Auth, ref.Name(), plumbing.NewHash(lease))
+	if isLeaseRejected(err) {
+		return &git.PushRejectedError{Branch: ref.Name().Short(), Err: err}
+	}
+	if err != nil {
+		return fmt.Errorf("force pushing: %v", err)
+	}
+	return nil
+}
+
+// isLeaseRejected returns true if the force push failed because the remote branch doesn't point to the lease commit.
+func isLeaseRejected(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "required to be")
+}
+
+// ResetSoft points the current branch to the commit, keeping the changes of the commits after it staged.
+func (g *GitClient) ResetSoft(commit string) error {
+	r, err := g.Client.OpenDir(g.RepoDirectory)
+	if err != nil {
+		return fmt.Errorf("resetting to commit %s: %v", commit, err)
+	}
+
+	w, err := g.Client.OpenWorktree(r)
+	if err != nil {
+		return fmt.Errorf("resetting to commit %s: %v", commit, err)
+	}
+
+	if err = g.Client.Reset(w, &gogit.ResetOptions{Commit: plumbing.NewHash(commit), Mode: gogit.SoftReset}); err != nil {
+		return fmt.Errorf("resetting to commit %s: %v", commit, err)
+	}
+	return nil
+}
+
+// DeleteRemoteBranch deletes a branch in the remote repository. It succeeds if the branch doesn't exist in the remote.
+func (g *GitClient) DeleteRemoteBranch(ctx context.Context, branch string) error {
+	logger.V(3).Info("Deleting remote branch", "repo", g.RepoDirectory, "branch", branch)
+	r, err := g.Client.OpenDir(g.RepoDirectory)
+	if err != nil {
+		return fmt.Errorf("deleting remote branch %s: %v", branch, err)
+	}
+
+	err = g.Client.DeleteRemoteBranchWithContext(ctx, r, g.Auth, plumbing.NewBranchReferenceName(branch))
+	if errors.Is(err, gogit.NoErrAlreadyUpToDate) {
+		logger.V(3).Info("Remote branch does not exist, nothing to delete", "branch", branch)
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("deleting remote branch %s: %v", branch, err)
+	}
+	return nil
+}
+
+// PushToBranch pushes the current branch to another branch in the remote. The remote rejects the push if it's not
+// a fast-forward of the remote branch, so its history is never rewritten.
+func (g *GitClient) PushToBranch(ctx context.Context, branch string) error {
+	logger.V(3).Info("Pushing to remote branch", "repo", g.RepoDirectory, "branch", branch)
+	r, err := g.Client.OpenDir(g.RepoDirectory)
+	if err != nil {
+		return fmt.Errorf("pushing to branch %s: %v", branch, err)
+	}
+
+	ref, err := g.Client.Head(r)
+	if err != nil {
+		return fmt.Errorf("pushing to branch %s: %v", branch, err)
+	}
+
+	err = g.Client.PushToBranchWithContext(ctx, r, g.Auth, ref.Name(), plumbing.NewBranchReferenceName(branch))
+	if errors.Is(err, gogit.NoErrAlreadyUpToDate) {
+		logger.V(3).Info("Remote branch already up-to-date, nothing to push", "branch", branch)
+		return nil
+	}
+
+	if isPushRejected(err) {
+		return &git.PushRejectedError{Branch: branch, Err: err}
+	}
+
+	if err != nil {
+		return fmt.Errorf("pushing to branch %s: %v", branch, err)
+	}
+	return nil
+}
+
+// Tag creates an annotated tag with the message at the current commit.
+func (g *GitClient) Tag(name, message string) error {
+	logger.V(3).Info("Creating tag", "repo", g.RepoDirectory, "tag", name)
+	r, err := g.Client.OpenDir(g.RepoDirectory)
+	if err != nil {
+		return fmt.Errorf("creating tag %s: %v", name, err)
+	}
+
+	ref, err := g.Client.Head(r)
+	if err != nil {
+		return fmt.Errorf("creating tag %s: %v", name, err)
+	}
+
+	if _, err := g.Client.CreateTag(r, name, ref.Hash(), &gogit.CreateTagOptions{Tagger: g.commitSignature(), Message: message}); err != nil {
+		return fmt.Errorf("creating tag %s: %v", name, err)
+	}
+	return nil
+}
+
+// PushTag pushes the tag to the remote. The remote rejects the push if the tag already exists, so tags are never moved.
+func (g *GitClient) PushTag(ctx context.Context, name string) error {
+	logger.V(3).Info("Pushing tag to remote", "repo", g.RepoDirectory, "tag", name)
+	r, err := g.Client.OpenDir(g.RepoDirectory)
+	if err != nil {
+		return fmt.Errorf("pushing tag %s: %v", name, err)
+	}
+
+	err = g.Client.PushTagWithContext(ctx, r, g.Auth, plumbing.NewTagReferenceName(name))
+	if errors.Is(err, gogit.NoErrAlreadyUpToDate) {
+		logger.V(3).Info("Remote tag already up-to-date, nothing to push", "tag", name)
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("pushing tag %s: %v", name, err)
+	}
+	return nil
+}
+
 func (g *GitClient) Push(ctx context.Context) error {
 	logger.V(3).Info("Pushing to remote", "repo", g.RepoDirectory)
 	r, err := g.Client.OpenDir(g.RepoDirectory)
@@ -150,12 +442,31 @@ func (g *GitClient) Push(ctx context.Context) error {
 	}
 
 	err = g.Client.PushWithContext(ctx, r, g.Auth)
+	if errors.Is(err, gogit.NoErrAlreadyUpToDate) {
+		logger.V(3).Info("Remote already up-to-date, nothing to push", "repo", g.RepoDirectory, "remote", gogit.DefaultRemoteName)
+		return nil
+	}
+
+	if isPushRejected(err) {
+		return &git.PushRejectedError{Branch: g.currentBranch(r), Err: err}
+	}
+
+	if isProtectedBranchRejection(err) {
+		return &git.ProtectedBranchError{Branch: g.currentBranch(r), Err: err}
+	}
+
 	if err != nil {
 		return fmt.Errorf("pushing: %v", err)
 	}
 	return err
 }
 
+// isProtectedBranchRejection returns true if the remote rejected the push because of the protection rules of the branch,
+// like GitHub's "GH006: Protected branch update failed" or GitLab's "not allowed to push code to protected branches".
+func isProtectedBranchRejection(err error) bool {
+	return err != nil && strings.Contains(strings.ToLower(err.Error()), "protected branch")
+}
+
 func (g *GitClient) Pull(ctx context.Context, branch string) error {
 	logger.V(3).Info("Pulling from remote", "repo", g.RepoDirectory, "remote", gogit.DefaultRemoteName)
 	r, err := g.Client.OpenDir(g.RepoDirectory)
@@ -170,6 +481,10 @@ func (g *GitClient) Pull(ctx context.Context, branch string) error {
 
 	branchRef := plumbing.NewBranchReferenceName(branch)
 
+	if err = g.checkoutIfNotOnBranch(r, w, branchRef, false); err != nil {
+		return fmt.Errorf("pulling from remote: %v", err)
+	}
+
 	err = g.Client.PullWithContext(ctx, w, g.Auth, branchRef)
 
 	if errors.Is(err, gogit.NoErrAlreadyUpToDate) {
@@ -177,10 +492,20 @@ func (g *GitClient) Pull(ctx context.Context, branch string) error {
 		return &git.RepositoryUpToDateError{}
 	}
 
+	if errors.Is(err, gogit.ErrUnstagedChanges) && g.sparse() {
+		// The files outside the sparse checkout directories are missing from the worktree, so the
+		// pulled commit can't be merged into it and is checked out instead.
+		err = g.resetToRemoteBranch(r, w, branch)
+	}
+
 	if err != nil {
 		return fmt.Errorf("pulling from remote: %v", err)
 	}
 
+	if err = g.updateSubmodules(ctx, r); err != nil {
+		return fmt.Errorf("pulling from remote: %v", err)
+	}
+
 	ref, err := g.Client.Head(r)
 	if err != nil {
 		return fmt.Errorf("pulling from remote: %v", err)
@@ -194,6 +519,87 @@ func (g *GitClient) Pull(ctx context.Context, branch string) error {
 	return nil
 }
 
+// checkoutIfNotOnBranch checks out branchRef when HEAD is detached or points to a different branch,
+// so pulling updates the branch and not just the detached HEAD. Unless force is set, the checkout fails
+// instead of discarding the local changes.
+func (g *GitClient) checkoutIfNotOnBranch(r *gogit.Repository, w *gogit.Worktree, branchRef plumbing.ReferenceName, force bool) error {
+	head, err := g.Client.Head(r)
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		// Repository without commits, there is nothing to check out
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if head.Name() == branchRef {
+		return nil
+	}
+
+	logger.V(3).Info("Local repo is not on the branch to pull, checking it out", "head", head.Name(), "branch", branchRef.Short())
+	err = g.checkout(r, w, &gogit.CheckoutOptions{Branch: branchRef, Force: force})
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		err = g.checkout(r, w, &gogit.CheckoutOptions{Branch: branchRef, Hash: head.Hash(), Create: true, Force: force})
+	}
+	if err != nil {
+		return fmt.Errorf("checking out branch %s: %v", branchRef.Short(), err)
+	}
+	return nil
+}
+
+// resetToRemoteBranch hard resets the local branch to the already fetched remote branch.
+func (g *GitClient) resetToRemoteBranch(r *gogit.Repository, w *gogit.Worktree, branch string) error {
+	remoteRef, err := g.Client.Reference(r, plumbing.NewRemoteReferenceName(gogit.DefaultRemoteName, branch))
+	if err != nil {
+		return fmt.Errorf("getting remote branch %s: %v", branch, err)
+	}
+
+	if err = g.hardReset(r, w, remoteRef.Hash()); err != nil {
+		return fmt.Errorf("resetting to remote branch %s: %v", branch, err)
+	}
+	return nil
+}
+
+// FetchAndReset fetches the branch and hard resets the local repository to it, discarding the local commits and
+// changes left behind by previous operations, so an existing local repository can be reused instead of cloned again.
+// If the branch doesn't exist in the remote yet, it's checked out like Branch does.
+func (g *GitClient) FetchAndReset(ctx context.Context, branch string) error {
+	r, err := g.Client.OpenDir(g.RepoDirectory)
+	if err != nil {
+		return fmt.Errorf("fetching and resetting to branch %s: %v", branch, err)
+	}
+
+	branchRef := plumbing.NewBranchReferenceName(branch)
+	logger.V(3).Info("Fetching remote branch", "repo", g.RepoDirectory, "branch", branch)
+	err = g.Client.FetchWithContext(ctx, r, g.Auth, branchRef)
+	if errors.Is(err, gogit.NoMatchingRefSpecError{}) || (err != nil && strings.Contains(err.Error(), emptyRepoError)) {
+		logger.V(3).Info("Branch does not exist in remote, checking it out", "branch", branch)
+		return g.Branch(branch)
+	}
+	if err != nil && !errors.Is(err, gogit.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("fetching and resetting to branch %s: %v", branch, err)
+	}
+
+	w, err := g.Client.OpenWorktree(r)
+	if err != nil {
+		return fmt.Errorf("fetching and resetting to branch %s: %v", branch, err)
+	}
+
+	if err = g.checkoutIfNotOnBranch(r, w, branchRef, true); err != nil {
+		return fmt.Errorf("fetching and resetting to branch %s: %v", branch, err)
+	}
+
+	if err = g.resetToRemoteBranch(r, w, branch); err != nil {
+		return fmt.Errorf("fetching and resetting to branch %s: %v", branch, err)
+	}
+
+	if err = g.updateSubmodules(ctx, r); err != nil {
+		return fmt.Errorf("fetching and resetting to branch %s: %v", branch, err)
+	}
+	logger.V(3).Info("Local repo reset to remote branch", "repo", g.RepoDirectory, "branch", branch)
+	return nil
+}
+
 func (g *GitClient) Init() error {
 	r, err := g.Client.Init(g.RepoDirectory)
 	if err != nil {
@@ -250,7 +656,7 @@ func (g *GitClient) Branch(name string) error {
 		return fmt.Errorf("creating branch %s: %v", name, err)
 	}
 
-	err = g.Client.Checkout(w, &gogit.CheckoutOptions{
+	err = g.checkout(r, w, &gogit.CheckoutOptions{
 		Branch: plumbing.ReferenceName(localBranchRef.String()),
 		Force:  true,
 	})
@@ -263,11 +669,15 @@ func (g *GitClient) Branch(name string) error {
 		return fmt.Errorf("creating branch %s: %v", name, err)
 	}
 
+	if err = g.updateSubmodules(context.Background(), r); err != nil {
+		return fmt.Errorf("creating branch %s: %v", name, err)
+	}
+
 	return nil
 }
 
 func (g *GitClient) ValidateRemoteExists(ctx context.Context) error {
-	logger.V(3).Info("Validating git setup", "repoUrl", g.RepoUrl)
+	logger.V(3).Info("Validating git setup", "repoUrl", git.RedactUrl(g.RepoUrl))
 	remote := g.Client.NewRemote(g.RepoUrl, gogit.DefaultRemoteName)
 	// Check if we are able to make a connection to the remote by attempting to list refs
 	_, err := g.Client.ListWithContext(ctx, remote, g.Auth)
@@ -277,6 +687,43 @@ func (g *GitClient) ValidateRemoteExists(ctx context.Context) error {
 	return nil
 }
 
+// RemoteBranchExists lists the references of the remote repository to check if it has the branch.
+// An empty remote repository has no branches.
+func (g *GitClient) RemoteBranchExists(ctx context.Context, branch string) (bool, error) {
+	branches, err := g.RemoteBranches(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	for _, b := range branches {
+		if b == branch {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// RemoteBranches lists the references of the remote repository and returns the names of its branches.
+// An empty remote repository has no branches.
+func (g *GitClient) RemoteBranches(ctx context.Context) ([]string, error) {
+	remote := g.Client.NewRemote(g.RepoUrl, gogit.DefaultRemoteName)
+	refs, err := g.Client.ListWithContext(ctx, remote, g.Auth)
+	if err != nil {
+		if strings.Contains(err.Error(), emptyRepoError) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("listing branches of remote repository: %v", err)
+	}
+
+	var branches []string
+	for _, ref := range refs {
+		if ref.Name().IsBranch() {
+			branches = append(branches, ref.Name().Short())
+		}
+	}
+	return branches, nil
+}
+
 func (g *GitClient) pullIfRemoteExists(r *gogit.Repository, w *gogit.Worktree, branchName string, localBranchRef plumbing.ReferenceName) error {
 	err := g.Retrier.Retry(func() error {
 		remoteExists, err := g.remoteBranchExists(r, localBranchRef)
@@ -286,6 +733,11 @@ func (g *GitClient) pullIfRemoteExists(r *gogit.Repository, w *gogit.Worktree, b
 
 		if remoteExists {
 			err = g.Client.PullWithContext(context.Background(), w, g.Auth, localBranchRef)
+			if errors.Is(err, gogit.ErrUnstagedChanges) && g.sparse() {
+				// The files outside the sparse checkout directories are missing from the worktree, so the
+				// pulled commit can't be merged into it and is checked out instead.
+				err = g.resetToRemoteBranch(r, w, branchName)
+			}
 			if err != nil && !errors.Is(err, gogit.NoErrAlreadyUpToDate) && !errors.Is(err, gogit.ErrRemoteNotFound) {
 				return fmt.Errorf("pulling from remote when checking out existing branch %s: %v", branchName, err)
 			}
@@ -315,8 +767,9 @@ func (g *GitClient) remoteBranchExists(r *gogit.Repository, localBranchRef plumb
 type GoGit interface {
 	AddGlob(f string, w *gogit.Worktree) error
 	Checkout(w *gogit.Worktree, opts *gogit.CheckoutOptions) error
-	Clone(ctx context.Context, dir string, repoUrl string, auth transport.AuthMethod) (*gogit.Repository, error)
+	Clone(ctx context.Context, dir string, repoUrl string, auth transport.AuthMethod, opts CloneOpts) (*gogit.Repository, error)
 	Commit(m string, sig *object.Signature, w *gogit.Worktree) (plumbing.Hash, error)
+	CommitWithParents(m string, sig *object.Signature, w *gogit.Worktree, parents []plumbing.Hash) (plumbing.Hash, error)
 	CommitObject(r *gogit.Repository, h plumbing.Hash) (*object.Commit, error)
 	Create(r *gogit.Repository, url string) (*gogit.Remote, error)
 	CreateBranch(r *gogit.Repository, config *config.Branch) error
@@ -326,24 +779,52 @@ type GoGit interface {
 	OpenDir(dir string) (*gogit.Repository, error)
 	OpenWorktree(r *gogit.Repository) (*gogit.Worktree, error)
 	PushWithContext(ctx context.Context, r *gogit.Repository, auth transport.AuthMethod) error
+	ForcePushWithContext(ctx context.Context, r *gogit.Repository, auth transport.AuthMethod, branch plumbing.ReferenceName, lease plumbing.Hash) error
+	DeleteRemoteBranchWithContext(ctx context.Context, r *gogit.Repository, auth transport.AuthMethod, branch plumbing.ReferenceName) error
+	PushToBranchWithContext(ctx context.Context, r *gogit.Repository, auth transport.AuthMethod, src, dst plumbing.ReferenceName) error
 	PullWithContext(ctx context.Context, w *gogit.Worktree, auth transport.AuthMethod, ref plumbing.ReferenceName) error
+	CreateTag(r *gogit.Repository, name string, h plumbing.Hash, opts *gogit.CreateTagOptions) (*plumbing.Reference, error)
+	PushTagWithContext(ctx context.Context, r *gogit.Repository, auth transport.AuthMethod, tag plumbing.ReferenceName) error
+	Reference(r *gogit.Repository, name plumbing.ReferenceName) (*plumbing.Reference, error)
+	Reset(w *gogit.Worktree, opts *gogit.ResetOptions) error
 	ListRemotes(r *gogit.Repository, auth transport.AuthMethod) ([]*plumbing.Reference, error)
 	ListWithContext(ctx context.Context, r *gogit.Remote, auth transport.AuthMethod) ([]*plumbing.Reference, error)
 	Remove(f string, w *gogit.Worktree) (plumbing.Hash, error)
 	SetRepositoryReference(r *gogit.Repository, p *plumbing.Reference) error
+	SignCommit(r *gogit.Repository, h plumbing.Hash, signer CommitSigner) (plumbing.Hash, error)
+	SparseCheckout(r *gogit.Repository, w *gogit.Worktree, h plumbing.Hash, dirs []string) error
+	FetchWithContext(ctx context.Context, r *gogit.Repository, auth transport.AuthMethod, branch plumbing.ReferenceName) error
+	RebaseChanges(r *gogit.Repository, h, onto plumbing.Hash) ([]FileChange, error)
+	UpdateSubmodules(ctx context.Context, w *gogit.Worktree, auth transport.AuthMethod, paths []string) error
+	Status(w *gogit.Worktree) (gogit.Status, error)
+	UnpushedCommits(r *gogit.Repository, local, remote plumbing.Hash) ([]plumbing.Hash, bool, error)
+}
+
+// CloneOpts are the options of a clone. A Depth lower or equal to zero clones the full history,
+// and NoCheckout leaves the worktree and index empty.
+type CloneOpts struct {
+	Depth      int
+	NoCheckout bool
 }
 
-type goGit struct{}
+type goGit struct {
+	progress io.Writer
+}
 
-func (gg *goGit) Clone(ctx context.Context, dir string, repourl string, auth transport.AuthMethod) (*gogit.Repository, error) {
+func (gg *goGit) Clone(ctx context.Context, dir string, repourl string, auth transport.AuthMethod, opts CloneOpts) (*gogit.Repository, error) {
 	ctx, cancel := context.WithTimeout(ctx, gitTimeout)
 	defer cancel()
 
-	return gogit.PlainCloneContext(ctx, dir, false, &gogit.CloneOptions{
-		Auth:     auth,
-		URL:      repourl,
-		Progress: os.Stdout,
-	})
+	cloneOpts := &gogit.CloneOptions{
+		Auth:       auth,
+		URL:        repourl,
+		Progress:   gg.progress,
+		NoCheckout: opts.NoCheckout,
+	}
+	if opts.Depth > 0 {
+		cloneOpts.Depth = opts.Depth
+	}
+	return gogit.PlainCloneContext(ctx, dir, false, cloneOpts)
 }
 
 func (gg *goGit) OpenDir(dir string) (*gogit.Repository, error) {
@@ -364,16 +845,118 @@ func (gg *goGit) Commit(m string, sig *object.Signature, w *gogit.Worktree) (plu
 	})
 }
 
+func (gg *goGit) CommitWithParents(m string, sig *object.Signature, w *gogit.Worktree, parents []plumbing.Hash) (plumbing.Hash, error) {
+	return w.Commit(m, &gogit.CommitOptions{
+		Author:  sig,
+		Parents: parents,
+	})
+}
+
+// SignCommit replaces the commit HEAD points to with the same commit signed with the signer, and points HEAD to it.
+func (gg *goGit) SignCommit(r *gogit.Repository, h plumbing.Hash, signer CommitSigner) (plumbing.Hash, error) {
+	commit, err := r.CommitObject(h)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	unsigned := &plumbing.MemoryObject{}
+	if err := commit.EncodeWithoutSignature(unsigned); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	reader, err := unsigned.Reader()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if commit.PGPSignature, err = signer.Sign(reader); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	signed := r.Storer.NewEncodedObject()
+	if err := commit.Encode(signed); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	signedHash, err := r.Storer.SetEncodedObject(signed)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	head, err := r.Head()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if err := r.Storer.SetReference(plumbing.NewHashReference(head.Name(), signedHash)); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return signedHash, nil
+}
+
 func (gg *goGit) CommitObject(r *gogit.Repository, h plumbing.Hash) (*object.Commit, error) {
 	return r.CommitObject(h)
 }
 
+// PushWithContext pushes the checked out branch only, so other local branches are never pushed by accident.
 func (gg *goGit) PushWithContext(ctx context.Context, r *gogit.Repository, auth transport.AuthMethod) error {
 	ctx, cancel := context.WithTimeout(ctx, gitTimeout)
 	defer cancel()
 
+	head, err := r.Head()
+	if err != nil {
+		return err
+	}
+
+	return r.PushContext(ctx, &gogit.PushOptions{
+		Auth:     auth,
+		RefSpecs: []config.RefSpec{config.RefSpec(fmt.Sprintf("%s:%s", head.Name(), head.Name()))},
+		Progress: gg.progress,
+	})
+}
+
+func (gg *goGit) ForcePushWithContext(ctx context.Context, r *gogit.Repository, auth transport.AuthMethod, branch plumbing.ReferenceName, lease plumbing.Hash) error {
+	ctx, cancel := context.WithTimeout(ctx, gitTimeout)
+	defer cancel()
+
+	return r.PushContext(ctx, &gogit.PushOptions{
+		Auth:              auth,
+		RefSpecs:          []config.RefSpec{config.RefSpec(fmt.Sprintf("+%s:%s", branch, branch))},
+		RequireRemoteRefs: []config.RefSpec{config.RefSpec(fmt.Sprintf("%s:%s", lease, branch))},
+		Progress:          gg.progress,
+	})
+}
+
+func (gg *goGit) PushToBranchWithContext(ctx context.Context, r *gogit.Repository, auth transport.AuthMethod, src, dst plumbing.ReferenceName) error {
+	ctx, cancel := context.WithTimeout(ctx, gitTimeout)
+	defer cancel()
+
 	return r.PushContext(ctx, &gogit.PushOptions{
-		Auth: auth,
+		Auth:     auth,
+		RefSpecs: []config.RefSpec{config.RefSpec(fmt.Sprintf("%s:%s", src, dst))},
+		Progress: gg.progress,
+	})
+}
+
+func (gg *goGit) CreateTag(r *gogit.Repository, name string, h plumbing.Hash, opts *gogit.CreateTagOptions) (*plumbing.Reference, error) {
+	return r.CreateTag(name, h, opts)
+}
+
+func (gg *goGit) PushTagWithContext(ctx context.Context, r *gogit.Repository, auth transport.AuthMethod, tag plumbing.ReferenceName) error {
+	ctx, cancel := context.WithTimeout(ctx, gitTimeout)
+	defer cancel()
+
+	return r.PushContext(ctx, &gogit.PushOptions{
+		Auth:     auth,
+		RefSpecs: []config.RefSpec{config.RefSpec(fmt.Sprintf("%s:%s", tag, tag))},
+		Progress: gg.progress,
+	})
+}
+
+func (gg *goGit) DeleteRemoteBranchWithContext(ctx context.Context, r *gogit.Repository, auth transport.AuthMethod, branch plumbing.ReferenceName) error {
+	ctx, cancel := context.WithTimeout(ctx, gitTimeout)
+	defer cancel()
+
+	return r.PushContext(ctx, &gogit.PushOptions{
+		Auth:     auth,
+		RefSpecs: []config.RefSpec{config.RefSpec(fmt.Sprintf(":%s", branch))},
+		Progress: gg.progress,
 	})
 }
 
@@ -381,7 +964,15 @@ func (gg *goGit) PullWithContext(ctx context.Context, w *gogit.Worktree, auth tr
 	ctx, cancel := context.WithTimeout(ctx, gitTimeout)
 	defer cancel()
 
-	return w.PullContext(ctx, &gogit.PullOptions{RemoteName: gogit.DefaultRemoteName, Auth: auth, ReferenceName: ref})
+	return w.PullContext(ctx, &gogit.PullOptions{RemoteName: gogit.DefaultRemoteName, Auth: auth, ReferenceName: ref, Progress: gg.progress})
+}
+
+func (gg *goGit) Reference(r *gogit.Repository, name plumbing.ReferenceName) (*plumbing.Reference, error) {
+	return r.Reference(name, true)
+}
+
+func (gg *goGit) Reset(w *gogit.Worktree, opts *gogit.ResetOptions) error {
+	return w.Reset(opts)
 }
 
 func (gg *goGit) Head(r *gogit.Repository) (*plumbing.Reference, error) {
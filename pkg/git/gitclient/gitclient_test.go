@@ -2,9 +2,13 @@ package gitclient_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	goGit "github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
@@ -56,7 +60,7 @@ func TestGoGitClone(t *testing.T) {
 				Client:        client,
 			}
 
-			client.EXPECT().Clone(ctx, repoDir, repoUrl, auth).Return(&goGit.Repository{}, tt.throwError)
+			client.EXPECT().Clone(ctx, repoDir, repoUrl, auth, gitclient.CloneOpts{}).Return(&goGit.Repository{}, tt.throwError)
 
 			err := g.Clone(ctx)
 			if (err != nil) != tt.wantErr {
@@ -72,6 +76,72 @@ func TestGoGitClone(t *testing.T) {
 	}
 }
 
+func TestGoGitCloneWithDepth(t *testing.T) {
+	ctx, client := newGoGitMock(t)
+	repoUrl := "testurl"
+	auth := &http.BasicAuth{}
+
+	g := gitclient.New(
+		gitclient.WithRepositoryDirectory(repoDir),
+		gitclient.WithRepositoryUrl(repoUrl),
+		gitclient.WithAuth(auth),
+		gitclient.WithCloneDepth(1),
+	)
+	g.Client = client
+
+	client.EXPECT().Clone(ctx, repoDir, repoUrl, auth, gitclient.CloneOpts{Depth: 1}).Return(&goGit.Repository{}, nil)
+
+	if err := g.Clone(ctx); err != nil {
+		t.Errorf("Clone() error = %v, want nil", err)
+	}
+}
+
+func TestGoGitCloneWithSparseCheckout(t *testing.T) {
+	ctx, client := newGoGitMock(t)
+	repoUrl := "testurl"
+	auth := &http.BasicAuth{}
+	r := &goGit.Repository{}
+	w := &goGit.Worktree{}
+	headHash := plumbing.NewHash("3f6e0a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f")
+
+	g := gitclient.New(
+		gitclient.WithRepositoryDirectory(repoDir),
+		gitclient.WithRepositoryUrl(repoUrl),
+		gitclient.WithAuth(auth),
+		gitclient.WithSparseCheckoutDirectories("/clusters/mgmt/"),
+	)
+	g.Client = client
+
+	client.EXPECT().Clone(ctx, repoDir, repoUrl, auth, gitclient.CloneOpts{NoCheckout: true}).Return(r, nil)
+	client.EXPECT().OpenWorktree(r).Return(w, nil)
+	client.EXPECT().Head(r).Return(plumbing.NewHashReference(plumbing.NewBranchReferenceName("main"), headHash), nil)
+	client.EXPECT().SparseCheckout(r, w, headHash, []string{"clusters/mgmt"}).Return(nil)
+
+	if err := g.Clone(ctx); err != nil {
+		t.Errorf("Clone() error = %v, want nil", err)
+	}
+}
+
+func TestGoGitCloneWithSparseCheckoutRootDirectory(t *testing.T) {
+	ctx, client := newGoGitMock(t)
+	repoUrl := "testurl"
+	auth := &http.BasicAuth{}
+
+	g := gitclient.New(
+		gitclient.WithRepositoryDirectory(repoDir),
+		gitclient.WithRepositoryUrl(repoUrl),
+		gitclient.WithAuth(auth),
+		gitclient.WithSparseCheckoutDirectories("."),
+	)
+	g.Client = client
+
+	client.EXPECT().Clone(ctx, repoDir, repoUrl, auth, gitclient.CloneOpts{}).Return(&goGit.Repository{}, nil)
+
+	if err := g.Clone(ctx); err != nil {
+		t.Errorf("Clone() error = %v, want nil", err)
+	}
+}
+
 func TestGoGitAdd(t *testing.T) {
 	_, client := newGoGitMock(t)
 	filename := "testfile"
@@ -133,6 +203,328 @@ func TestGoGitCommit(t *testing.T) {
 	}
 }
 
+func TestGoGitCommitWithCommitAuthor(t *testing.T) {
+	_, client := newGoGitMock(t)
+
+	client.EXPECT().OpenDir(repoDir).Return(&goGit.Repository{}, nil)
+	client.EXPECT().OpenWorktree(gomock.Any()).Return(&goGit.Worktree{}, nil)
+	client.EXPECT().Commit("message", gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ string, sig *object.Signature, _ *goGit.Worktree) (plumbing.Hash, error) {
+			if sig.Name != "service-account" || sig.Email != "service-account@example.com" {
+				t.Errorf("Commit() signature = %s <%s>, want service-account <service-account@example.com>", sig.Name, sig.Email)
+			}
+			return plumbing.Hash{}, nil
+		},
+	)
+	client.EXPECT().CommitObject(gomock.Any(), gomock.Any()).Return(&object.Commit{}, nil)
+
+	g := gitclient.New(gitclient.WithRepositoryDirectory(repoDir), gitclient.WithCommitAuthor("service-account", "service-account@example.com"))
+	g.Client = client
+
+	if err := g.Commit("message"); err != nil {
+		t.Errorf("Commit() error = %v", err)
+	}
+}
+
+func TestGoGitLastCommit(t *testing.T) {
+	_, client := newGoGitMock(t)
+	when := time.Now()
+	head := plumbing.NewHashReference(plumbing.NewBranchReferenceName("main"), plumbing.NewHash("a1"))
+
+	client.EXPECT().OpenDir(repoDir).Return(&goGit.Repository{}, nil)
+	client.EXPECT().Head(gomock.Any()).Return(head, nil)
+	client.EXPECT().CommitObject(gomock.Any(), head.Hash()).Return(&object.Commit{
+		Hash:    head.Hash(),
+		Message: "message",
+		Author:  object.Signature{Name: git.CommitAuthor, When: when},
+	}, nil)
+
+	g := &gitclient.GitClient{
+		RepoDirectory: repoDir,
+		Client:        client,
+	}
+
+	got, err := g.LastCommit()
+	if err != nil {
+		t.Fatalf("LastCommit() error = %v", err)
+	}
+
+	want := &git.Commit{Hash: head.Hash().String(), Message: "message", Author: git.CommitAuthor, When: when}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LastCommit() = %v, want %v", got, want)
+	}
+}
+
+func TestGoGitAmendCommit(t *testing.T) {
+	_, client := newGoGitMock(t)
+	parents := []plumbing.Hash{plumbing.NewHash("b2")}
+	head := plumbing.NewHashReference(plumbing.NewBranchReferenceName("main"), plumbing.NewHash("a1"))
+
+	client.EXPECT().OpenDir(repoDir).Return(&goGit.Repository{}, nil)
+	client.EXPECT().Head(gomock.Any()).Return(head, nil)
+	client.EXPECT().CommitObject(gomock.Any(), head.Hash()).Return(&object.Commit{Hash: head.Hash(), ParentHashes: parents}, nil)
+	client.EXPECT().OpenWorktree(gomock.Any()).Return(&goGit.Worktree{}, nil)
+	client.EXPECT().CommitWithParents("message", gomock.Any(), gomock.Any(), parents).Return(plumbing.NewHash("c3"), nil)
+
+	g := &gitclient.GitClient{
+		RepoDirectory: repoDir,
+		Client:        client,
+	}
+
+	if err := g.AmendCommit("message"); err != nil {
+		t.Errorf("AmendCommit() error = %v", err)
+	}
+}
+
+func TestGoGitCommitSigned(t *testing.T) {
+	_, client := newGoGitMock(t)
+	signer := &fakeSigner{}
+	unsigned := plumbing.NewHash("a1")
+	signed := plumbing.NewHash("b2")
+
+	client.EXPECT().OpenDir(repoDir).Return(&goGit.Repository{}, nil)
+	client.EXPECT().OpenWorktree(gomock.Any()).Return(&goGit.Worktree{}, nil)
+	client.EXPECT().Commit("message", gomock.Any(), gomock.Any()).Return(unsigned, nil)
+	client.EXPECT().SignCommit(gomock.Any(), unsigned, signer).Return(signed, nil)
+	client.EXPECT().CommitObject(gomock.Any(), signed).Return(&object.Commit{Hash: signed}, nil)
+
+	g := gitclient.New(gitclient.WithRepositoryDirectory(repoDir), gitclient.WithCommitSigner(signer))
+	g.Client = client
+
+	if err := g.Commit("message"); err != nil {
+		t.Errorf("Commit() error = %v", err)
+	}
+}
+
+func TestGoGitCommitSignError(t *testing.T) {
+	_, client := newGoGitMock(t)
+	signer := &fakeSigner{}
+
+	client.EXPECT().OpenDir(repoDir).Return(&goGit.Repository{}, nil)
+	client.EXPECT().OpenWorktree(gomock.Any()).Return(&goGit.Worktree{}, nil)
+	client.EXPECT().Commit("message", gomock.Any(), gomock.Any()).Return(plumbing.NewHash("a1"), nil)
+	client.EXPECT().SignCommit(gomock.Any(), gomock.Any(), signer).Return(plumbing.ZeroHash, errors.New("key expired"))
+
+	g := gitclient.New(gitclient.WithRepositoryDirectory(repoDir), gitclient.WithCommitSigner(signer))
+	g.Client = client
+
+	wantErr := "signing commit: key expired"
+	if err := g.Commit("message"); err == nil || err.Error() != wantErr {
+		t.Errorf("Commit() error = %v, want %s", err, wantErr)
+	}
+}
+
+func TestGoGitAmendCommitSigned(t *testing.T) {
+	_, client := newGoGitMock(t)
+	signer := &fakeSigner{}
+	parents := []plumbing.Hash{plumbing.NewHash("b2")}
+	head := plumbing.NewHashReference(plumbing.NewBranchReferenceName("main"), plumbing.NewHash("a1"))
+
+	client.EXPECT().OpenDir(repoDir).Return(&goGit.Repository{}, nil)
+	client.EXPECT().Head(gomock.Any()).Return(head, nil)
+	client.EXPECT().CommitObject(gomock.Any(), head.Hash()).Return(&object.Commit{Hash: head.Hash(), ParentHashes: parents}, nil)
+	client.EXPECT().OpenWorktree(gomock.Any()).Return(&goGit.Worktree{}, nil)
+	client.EXPECT().CommitWithParents("message", gomock.Any(), gomock.Any(), parents).Return(plumbing.NewHash("c3"), nil)
+	client.EXPECT().SignCommit(gomock.Any(), plumbing.NewHash("c3"), signer).Return(plumbing.NewHash("d4"), nil)
+
+	g := gitclient.New(gitclient.WithRepositoryDirectory(repoDir), gitclient.WithCommitSigner(signer))
+	g.Client = client
+
+	if err := g.AmendCommit("message"); err != nil {
+		t.Errorf("AmendCommit() error = %v", err)
+	}
+}
+
+func TestGoGitAmendCommitRootCommit(t *testing.T) {
+	_, client := newGoGitMock(t)
+	head := plumbing.NewHashReference(plumbing.NewBranchReferenceName("main"), plumbing.NewHash("a1"))
+
+	client.EXPECT().OpenDir(repoDir).Return(&goGit.Repository{}, nil)
+	client.EXPECT().Head(gomock.Any()).Return(head, nil)
+	client.EXPECT().CommitObject(gomock.Any(), head.Hash()).Return(&object.Commit{Hash: head.Hash()}, nil)
+
+	g := &gitclient.GitClient{
+		RepoDirectory: repoDir,
+		Client:        client,
+	}
+
+	if err := g.AmendCommit("message"); err == nil {
+		t.Error("AmendCommit() error = nil, want root commit error")
+	}
+}
+
+func TestGoGitForcePush(t *testing.T) {
+	ctx, client := newGoGitMock(t)
+	head := plumbing.NewHashReference(plumbing.NewBranchReferenceName("main"), plumbing.NewHash("a1"))
+
+	client.EXPECT().OpenDir(repoDir).Return(&goGit.Repository{}, nil)
+	client.EXPECT().Head(gomock.Any()).Return(head, nil)
+	client.EXPECT().ForcePushWithContext(ctx, gomock.Any(), gomock.Any(), plumbing.NewBranchReferenceName("main"), plumbing.NewHash("b2")).Return(nil)
+
+	g := &gitclient.GitClient{
+		RepoDirectory: repoDir,
+		Client:        client,
+	}
+
+	if err := g.ForcePush(ctx, plumbing.NewHash("b2").String()); err != nil {
+		t.Errorf("ForcePush() error = %v", err)
+	}
+}
+
+func TestGoGitForcePushLeaseRejected(t *testing.T) {
+	ctx, client := newGoGitMock(t)
+	head := plumbing.NewHashReference(plumbing.NewBranchReferenceName("main"), plumbing.NewHash("a1"))
+	lease := plumbing.NewHash("b2")
+
+	client.EXPECT().OpenDir(repoDir).Return(&goGit.Repository{}, nil)
+	client.EXPECT().Head(gomock.Any()).Return(head, nil)
+	client.EXPECT().ForcePushWithContext(ctx, gomock.Any(), gomock.Any(), plumbing.NewBranchReferenceName("main"), lease).
+		Return(fmt.Errorf("remote ref refs/heads/main required to be %s but is %s", lease, plumbing.NewHash("c3")))
+
+	g := &gitclient.GitClient{
+		RepoDirectory: repoDir,
+		Client:        client,
+	}
+
+	err := g.ForcePush(ctx, lease.String())
+	var rejected *git.PushRejectedError
+	if !errors.As(err, &rejected) || rejected.Branch != "main" {
+		t.Errorf("ForcePush() error = %v, want PushRejectedError for branch main", err)
+	}
+}
+
+func TestGoGitResetSoft(t *testing.T) {
+	_, client := newGoGitMock(t)
+	r := &goGit.Repository{}
+	w := &goGit.Worktree{}
+	commit := plumbing.NewHash("a1")
+
+	client.EXPECT().OpenDir(repoDir).Return(r, nil)
+	client.EXPECT().OpenWorktree(r).Return(w, nil)
+	client.EXPECT().Reset(w, &goGit.ResetOptions{Commit: commit, Mode: goGit.SoftReset}).Return(nil)
+
+	g := &gitclient.GitClient{
+		RepoDirectory: repoDir,
+		Client:        client,
+	}
+
+	if err := g.ResetSoft(commit.String()); err != nil {
+		t.Errorf("ResetSoft() error = %v", err)
+	}
+}
+
+func TestGoGitPushToBranch(t *testing.T) {
+	ctx, client := newGoGitMock(t)
+	head := plumbing.NewHashReference(plumbing.NewBranchReferenceName("eksa/staging/cluster-1"), plumbing.NewHash("a1"))
+
+	client.EXPECT().OpenDir(repoDir).Return(&goGit.Repository{}, nil)
+	client.EXPECT().Head(gomock.Any()).Return(head, nil)
+	client.EXPECT().PushToBranchWithContext(ctx, gomock.Any(), gomock.Any(), plumbing.NewBranchReferenceName("eksa/staging/cluster-1"), plumbing.NewBranchReferenceName("main")).Return(nil)
+
+	g := &gitclient.GitClient{
+		RepoDirectory: repoDir,
+		Client:        client,
+	}
+
+	if err := g.PushToBranch(ctx, "main"); err != nil {
+		t.Errorf("PushToBranch() error = %v", err)
+	}
+}
+
+func TestGoGitPushToBranchRejected(t *testing.T) {
+	ctx, client := newGoGitMock(t)
+	head := plumbing.NewHashReference(plumbing.NewBranchReferenceName("eksa/staging/cluster-1"), plumbing.NewHash("a1"))
+
+	client.EXPECT().OpenDir(repoDir).Return(&goGit.Repository{}, nil)
+	client.EXPECT().Head(gomock.Any()).Return(head, nil)
+	client.EXPECT().PushToBranchWithContext(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(goGit.ErrForceNeeded)
+
+	g := &gitclient.GitClient{
+		RepoDirectory: repoDir,
+		Client:        client,
+	}
+
+	var rejected *git.PushRejectedError
+	if err := g.PushToBranch(ctx, "main"); !errors.As(err, &rejected) {
+		t.Errorf("PushToBranch() error = %v, want PushRejectedError", err)
+	}
+}
+
+func TestGoGitTag(t *testing.T) {
+	_, client := newGoGitMock(t)
+	head := plumbing.NewHashReference(plumbing.NewBranchReferenceName("main"), plumbing.NewHash("a1"))
+
+	client.EXPECT().OpenDir(repoDir).Return(&goGit.Repository{}, nil)
+	client.EXPECT().Head(gomock.Any()).Return(head, nil)
+	client.EXPECT().CreateTag(gomock.Any(), "cluster-1/v1.28-2024-06-01-120000", plumbing.NewHash("a1"), gomock.Any()).DoAndReturn(
+		func(_ *goGit.Repository, _ string, _ plumbing.Hash, opts *goGit.CreateTagOptions) (*plumbing.Reference, error) {
+			if opts.Message != "upgrade" || opts.Tagger.Name != git.CommitAuthor {
+				t.Errorf("CreateTag() opts = %+v, want message upgrade by %s", opts, git.CommitAuthor)
+			}
+			return nil, nil
+		},
+	)
+
+	g := &gitclient.GitClient{
+		RepoDirectory: repoDir,
+		Client:        client,
+	}
+
+	if err := g.Tag("cluster-1/v1.28-2024-06-01-120000", "upgrade"); err != nil {
+		t.Errorf("Tag() error = %v", err)
+	}
+}
+
+func TestGoGitTagExists(t *testing.T) {
+	_, client := newGoGitMock(t)
+	head := plumbing.NewHashReference(plumbing.NewBranchReferenceName("main"), plumbing.NewHash("a1"))
+
+	client.EXPECT().OpenDir(repoDir).Return(&goGit.Repository{}, nil)
+	client.EXPECT().Head(gomock.Any()).Return(head, nil)
+	client.EXPECT().CreateTag(gomock.Any(), "v1", gomock.Any(), gomock.Any()).Return(nil, goGit.ErrTagExists)
+
+	g := &gitclient.GitClient{
+		RepoDirectory: repoDir,
+		Client:        client,
+	}
+
+	if err := g.Tag("v1", "upgrade"); err == nil || !strings.Contains(err.Error(), goGit.ErrTagExists.Error()) {
+		t.Errorf("Tag() error = %v, want %v", err, goGit.ErrTagExists)
+	}
+}
+
+func TestGoGitPushTag(t *testing.T) {
+	ctx, client := newGoGitMock(t)
+
+	client.EXPECT().OpenDir(repoDir).Return(&goGit.Repository{}, nil)
+	client.EXPECT().PushTagWithContext(ctx, gomock.Any(), gomock.Any(), plumbing.NewTagReferenceName("cluster-1/v1.28-2024-06-01-120000")).Return(nil)
+
+	g := &gitclient.GitClient{
+		RepoDirectory: repoDir,
+		Client:        client,
+	}
+
+	if err := g.PushTag(ctx, "cluster-1/v1.28-2024-06-01-120000"); err != nil {
+		t.Errorf("PushTag() error = %v", err)
+	}
+}
+
+func TestGoGitPushTagError(t *testing.T) {
+	ctx, client := newGoGitMock(t)
+
+	client.EXPECT().OpenDir(repoDir).Return(&goGit.Repository{}, nil)
+	client.EXPECT().PushTagWithContext(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("already exists"))
+
+	g := &gitclient.GitClient{
+		RepoDirectory: repoDir,
+		Client:        client,
+	}
+
+	if err := g.PushTag(ctx, "v1"); err == nil || !strings.Contains(err.Error(), "pushing tag v1: already exists") {
+		t.Errorf("PushTag() error = %v, want pushing tag error", err)
+	}
+}
+
 func TestGoGitPush(t *testing.T) {
 	ctx, client := newGoGitMock(t)
 
@@ -151,6 +543,116 @@ func TestGoGitPush(t *testing.T) {
 	}
 }
 
+func TestGoGitPushAlreadyUpToDate(t *testing.T) {
+	ctx, client := newGoGitMock(t)
+
+	g := &gitclient.GitClient{
+		RepoDirectory: repoDir,
+		Client:        client,
+	}
+
+	client.EXPECT().OpenDir(repoDir).Return(&goGit.Repository{}, nil)
+	client.EXPECT().PushWithContext(ctx, gomock.Any(), gomock.Any()).Return(goGit.NoErrAlreadyUpToDate)
+
+	err := g.Push(ctx)
+	if err != nil {
+		t.Errorf("Push() error = %v, want nil", err)
+	}
+}
+
+func TestGoGitPushRejected(t *testing.T) {
+	ctx, client := newGoGitMock(t)
+
+	g := &gitclient.GitClient{
+		RepoDirectory: repoDir,
+		Client:        client,
+	}
+
+	client.EXPECT().OpenDir(repoDir).Return(&goGit.Repository{}, nil)
+	client.EXPECT().PushWithContext(ctx, gomock.Any(), gomock.Any()).Return(goGit.ErrForceNeeded)
+	client.EXPECT().Head(gomock.Any()).Return(plumbing.NewHashReference(plumbing.NewBranchReferenceName("main"), plumbing.ZeroHash), nil)
+
+	err := g.Push(ctx)
+	var rejected *git.PushRejectedError
+	if !errors.As(err, &rejected) {
+		t.Fatalf("Push() error = %v, want PushRejectedError", err)
+	}
+	if rejected.Branch != "main" {
+		t.Errorf("Push() rejected branch = %s, want main", rejected.Branch)
+	}
+}
+
+func TestGoGitPushProtectedBranch(t *testing.T) {
+	ctx, client := newGoGitMock(t)
+
+	g := &gitclient.GitClient{
+		RepoDirectory: repoDir,
+		Client:        client,
+	}
+
+	client.EXPECT().OpenDir(repoDir).Return(&goGit.Repository{}, nil)
+	client.EXPECT().PushWithContext(ctx, gomock.Any(), gomock.Any()).Return(errors.New("command error on refs/heads/main: protected branch hook declined"))
+	client.EXPECT().Head(gomock.Any()).Return(plumbing.NewHashReference(plumbing.NewBranchReferenceName("main"), plumbing.ZeroHash), nil)
+
+	err := g.Push(ctx)
+	var protected *git.ProtectedBranchError
+	if !errors.As(err, &protected) {
+		t.Fatalf("Push() error = %v, want ProtectedBranchError", err)
+	}
+	if protected.Branch != "main" {
+		t.Errorf("Push() protected branch = %s, want main", protected.Branch)
+	}
+}
+
+func TestGoGitDeleteRemoteBranch(t *testing.T) {
+	ctx, client := newGoGitMock(t)
+
+	g := &gitclient.GitClient{
+		RepoDirectory: repoDir,
+		Client:        client,
+	}
+
+	client.EXPECT().OpenDir(repoDir).Return(&goGit.Repository{}, nil)
+	client.EXPECT().DeleteRemoteBranchWithContext(ctx, gomock.Any(), gomock.Any(), plumbing.NewBranchReferenceName("cluster-1")).Return(nil)
+
+	if err := g.DeleteRemoteBranch(ctx, "cluster-1"); err != nil {
+		t.Errorf("DeleteRemoteBranch() error = %v", err)
+	}
+}
+
+func TestGoGitDeleteRemoteBranchNotInRemote(t *testing.T) {
+	ctx, client := newGoGitMock(t)
+
+	g := &gitclient.GitClient{
+		RepoDirectory: repoDir,
+		Client:        client,
+	}
+
+	client.EXPECT().OpenDir(repoDir).Return(&goGit.Repository{}, nil)
+	client.EXPECT().DeleteRemoteBranchWithContext(ctx, gomock.Any(), gomock.Any(), plumbing.NewBranchReferenceName("cluster-1")).Return(goGit.NoErrAlreadyUpToDate)
+
+	if err := g.DeleteRemoteBranch(ctx, "cluster-1"); err != nil {
+		t.Errorf("DeleteRemoteBranch() error = %v, want nil", err)
+	}
+}
+
+func TestGoGitDeleteRemoteBranchError(t *testing.T) {
+	ctx, client := newGoGitMock(t)
+
+	g := &gitclient.GitClient{
+		RepoDirectory: repoDir,
+		Client:        client,
+	}
+
+	client.EXPECT().OpenDir(repoDir).Return(&goGit.Repository{}, nil)
+	client.EXPECT().DeleteRemoteBranchWithContext(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("authentication required"))
+
+	wantErr := "deleting remote branch cluster-1: authentication required"
+	if err := g.DeleteRemoteBranch(ctx, "cluster-1"); err == nil || err.Error() != wantErr {
+		t.Errorf("DeleteRemoteBranch() error = %v, want %s", err, wantErr)
+	}
+}
+
 func TestGoGitPull(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -181,6 +683,7 @@ func TestGoGitPull(t *testing.T) {
 
 			client.EXPECT().OpenDir(repoDir).Return(&goGit.Repository{}, nil)
 			client.EXPECT().OpenWorktree(gomock.Any()).Do(func(arg0 *goGit.Repository) {}).Return(&goGit.Worktree{}, nil)
+			client.EXPECT().Head(gomock.Any()).Return(plumbing.NewHashReference(plumbing.NewBranchReferenceName(branch), plumbing.ZeroHash), nil)
 			client.EXPECT().PullWithContext(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Do(func(arg0 context.Context, arg1 *goGit.Worktree, arg2 transport.AuthMethod, name plumbing.ReferenceName) {
 			}).Return(tt.throwError)
 			if !tt.wantErr {
@@ -202,6 +705,150 @@ func TestGoGitPull(t *testing.T) {
 	}
 }
 
+func TestGoGitPullDetachedHead(t *testing.T) {
+	ctx, client := newGoGitMock(t)
+	branch := "testbranch"
+	branchRef := plumbing.NewBranchReferenceName(branch)
+	headHash := plumbing.NewHash("3f6e0a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f")
+	r := &goGit.Repository{}
+	w := &goGit.Worktree{}
+
+	g := &gitclient.GitClient{
+		RepoDirectory: repoDir,
+		Client:        client,
+	}
+
+	client.EXPECT().OpenDir(repoDir).Return(r, nil)
+	client.EXPECT().OpenWorktree(r).Return(w, nil)
+	client.EXPECT().Head(r).Return(plumbing.NewHashReference(plumbing.HEAD, headHash), nil)
+	client.EXPECT().Checkout(w, &goGit.CheckoutOptions{Branch: branchRef}).Return(nil)
+	client.EXPECT().PullWithContext(ctx, w, nil, branchRef).Return(nil)
+	client.EXPECT().Head(r).Return(plumbing.NewHashReference(branchRef, headHash), nil)
+	client.EXPECT().CommitObject(r, headHash).Return(&object.Commit{}, nil)
+
+	if err := g.Pull(ctx, branch); err != nil {
+		t.Errorf("Pull() error = %v, want nil", err)
+	}
+}
+
+func TestGoGitPullDetachedHeadMissingLocalBranch(t *testing.T) {
+	ctx, client := newGoGitMock(t)
+	branch := "testbranch"
+	branchRef := plumbing.NewBranchReferenceName(branch)
+	headHash := plumbing.NewHash("3f6e0a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f")
+	r := &goGit.Repository{}
+	w := &goGit.Worktree{}
+
+	g := &gitclient.GitClient{
+		RepoDirectory: repoDir,
+		Client:        client,
+	}
+
+	client.EXPECT().OpenDir(repoDir).Return(r, nil)
+	client.EXPECT().OpenWorktree(r).Return(w, nil)
+	client.EXPECT().Head(r).Return(plumbing.NewHashReference(plumbing.HEAD, headHash), nil)
+	client.EXPECT().Checkout(w, &goGit.CheckoutOptions{Branch: branchRef}).Return(plumbing.ErrReferenceNotFound)
+	client.EXPECT().Checkout(w, &goGit.CheckoutOptions{Branch: branchRef, Hash: headHash, Create: true}).Return(nil)
+	client.EXPECT().PullWithContext(ctx, w, nil, branchRef).Return(goGit.NoErrAlreadyUpToDate)
+
+	err := g.Pull(ctx, branch)
+	if !reflect.DeepEqual(err, &git.RepositoryUpToDateError{}) {
+		t.Errorf("Pull() error = %v, want %v", err, &git.RepositoryUpToDateError{})
+	}
+}
+
+func TestGoGitPullDetachedHeadCheckoutError(t *testing.T) {
+	ctx, client := newGoGitMock(t)
+	branch := "testbranch"
+	r := &goGit.Repository{}
+	w := &goGit.Worktree{}
+
+	g := &gitclient.GitClient{
+		RepoDirectory: repoDir,
+		Client:        client,
+	}
+
+	client.EXPECT().OpenDir(repoDir).Return(r, nil)
+	client.EXPECT().OpenWorktree(r).Return(w, nil)
+	client.EXPECT().Head(r).Return(plumbing.NewHashReference(plumbing.HEAD, plumbing.ZeroHash), nil)
+	client.EXPECT().Checkout(w, gomock.Any()).Return(errors.New("error in checkout"))
+
+	wantErr := "pulling from remote: checking out branch testbranch: error in checkout"
+	if err := g.Pull(ctx, branch); err == nil || err.Error() != wantErr {
+		t.Errorf("Pull() error = %v, want %s", err, wantErr)
+	}
+}
+
+func TestGoGitPullNonFastForwardDoesNotReset(t *testing.T) {
+	tests := []struct {
+		name      string
+		pullError error
+		wantErr   string
+	}{
+		{
+			name:      "non fast-forward",
+			pullError: goGit.ErrNonFastForwardUpdate,
+			wantErr:   "pulling from remote: non-fast-forward update",
+		},
+		{
+			name:      "unstaged changes",
+			pullError: goGit.ErrUnstagedChanges,
+			wantErr:   "pulling from remote: worktree contains unstaged changes",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, client := newGoGitMock(t)
+			branch := "testbranch"
+			branchRef := plumbing.NewBranchReferenceName(branch)
+			r := &goGit.Repository{}
+			w := &goGit.Worktree{}
+
+			g := &gitclient.GitClient{
+				RepoDirectory: repoDir,
+				Client:        client,
+			}
+
+			client.EXPECT().OpenDir(repoDir).Return(r, nil)
+			client.EXPECT().OpenWorktree(r).Return(w, nil)
+			client.EXPECT().Head(r).Return(plumbing.NewHashReference(branchRef, plumbing.ZeroHash), nil)
+			client.EXPECT().PullWithContext(ctx, w, nil, branchRef).Return(tt.pullError)
+
+			if err := g.Pull(ctx, branch); err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Pull() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGoGitPullWithSparseCheckoutResetsToRemote(t *testing.T) {
+	ctx, client := newGoGitMock(t)
+	branch := "testbranch"
+	branchRef := plumbing.NewBranchReferenceName(branch)
+	remoteRef := plumbing.NewHashReference(plumbing.NewRemoteReferenceName("origin", branch), plumbing.NewHash("3f6e0a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f"))
+	r := &goGit.Repository{}
+	w := &goGit.Worktree{}
+
+	g := gitclient.New(
+		gitclient.WithRepositoryDirectory(repoDir),
+		gitclient.WithSparseCheckoutDirectories("clusters"),
+	)
+	g.Client = client
+
+	client.EXPECT().OpenDir(repoDir).Return(r, nil)
+	client.EXPECT().OpenWorktree(r).Return(w, nil)
+	client.EXPECT().Head(r).Return(plumbing.NewHashReference(branchRef, plumbing.ZeroHash), nil)
+	client.EXPECT().PullWithContext(ctx, w, nil, branchRef).Return(goGit.ErrUnstagedChanges)
+	client.EXPECT().Reference(r, remoteRef.Name()).Return(remoteRef, nil)
+	client.EXPECT().SparseCheckout(r, w, remoteRef.Hash(), []string{"clusters"}).Return(nil)
+	client.EXPECT().Head(r).Return(plumbing.NewHashReference(branchRef, remoteRef.Hash()), nil)
+	client.EXPECT().CommitObject(r, remoteRef.Hash()).Return(&object.Commit{}, nil)
+
+	if err := g.Pull(ctx, branch); err != nil {
+		t.Errorf("Pull() error = %v, want nil", err)
+	}
+}
+
 func TestGoGitInit(t *testing.T) {
 	_, client := newGoGitMock(t)
 	url := "testurl"
@@ -302,6 +949,51 @@ func TestGoGitBranchRemoteExists(t *testing.T) {
 	}
 }
 
+func TestGoGitBranchRemoteExistsWithSparseCheckout(t *testing.T) {
+	_, client := newGoGitMock(t)
+
+	repo := &goGit.Repository{}
+	worktree := &goGit.Worktree{}
+	localBranchRef := plumbing.NewBranchReferenceName("testBranch")
+	headRef := plumbing.NewHashReference(localBranchRef, plumbing.NewHash("3f6e0a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f"))
+	remoteRef := plumbing.NewHashReference(plumbing.NewRemoteReferenceName("origin", "testBranch"), plumbing.NewHash("9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b"))
+	bOpts := &config.Branch{
+		Name:   "testBranch",
+		Remote: "origin",
+		Merge:  "refs/heads/testBranch",
+		Rebase: "true",
+	}
+	cOpts := &goGit.CheckoutOptions{
+		Branch: localBranchRef,
+		Keep:   true,
+	}
+
+	returnReferences := []*plumbing.Reference{
+		plumbing.NewHashReference(localBranchRef, headRef.Hash()),
+	}
+
+	client.EXPECT().OpenDir(repoDir).Return(repo, nil)
+	client.EXPECT().CreateBranch(repo, bOpts).Return(goGit.ErrBranchExists)
+	client.EXPECT().OpenWorktree(repo).Return(worktree, nil)
+	client.EXPECT().Checkout(worktree, cOpts).Return(nil)
+	client.EXPECT().Head(repo).Return(headRef, nil)
+	client.EXPECT().SparseCheckout(repo, worktree, headRef.Hash(), []string{"clusters"}).Return(nil)
+	client.EXPECT().ListRemotes(repo, gomock.Any()).Return(returnReferences, nil)
+	client.EXPECT().PullWithContext(gomock.Any(), worktree, gomock.Any(), localBranchRef).Return(goGit.ErrUnstagedChanges)
+	client.EXPECT().Reference(repo, remoteRef.Name()).Return(remoteRef, nil)
+	client.EXPECT().SparseCheckout(repo, worktree, remoteRef.Hash(), []string{"clusters"}).Return(nil)
+
+	g := gitclient.New(
+		gitclient.WithRepositoryDirectory(repoDir),
+		gitclient.WithSparseCheckoutDirectories("clusters"),
+	)
+	g.Client = client
+
+	if err := g.Branch("testBranch"); err != nil {
+		t.Errorf("Branch() error = %v", err)
+	}
+}
+
 func TestGoGitValidateRemoteExists(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -339,6 +1031,143 @@ func TestGoGitValidateRemoteExists(t *testing.T) {
 	}
 }
 
+func TestGoGitRemoteBranches(t *testing.T) {
+	ctx, client := newGoGitMock(t)
+	g := &gitclient.GitClient{
+		RepoUrl: "testurl",
+		Client:  client,
+	}
+	remote := &goGit.Remote{}
+	refs := []*plumbing.Reference{
+		plumbing.NewSymbolicReference(plumbing.HEAD, "refs/heads/main"),
+		plumbing.NewHashReference("refs/heads/main", plumbing.ZeroHash),
+		plumbing.NewHashReference("refs/heads/clusters/mgmt", plumbing.ZeroHash),
+		plumbing.NewHashReference("refs/tags/v1", plumbing.ZeroHash),
+	}
+
+	client.EXPECT().NewRemote(g.RepoUrl, goGit.DefaultRemoteName).Return(remote)
+	client.EXPECT().ListWithContext(ctx, remote, g.Auth).Return(refs, nil)
+
+	branches, err := g.RemoteBranches(ctx)
+	if err != nil {
+		t.Fatalf("RemoteBranches() error = %v", err)
+	}
+	if want := []string{"main", "clusters/mgmt"}; !reflect.DeepEqual(branches, want) {
+		t.Errorf("RemoteBranches() = %v, want %v", branches, want)
+	}
+}
+
+func TestGoGitStagedFiles(t *testing.T) {
+	_, client := newGoGitMock(t)
+	g := &gitclient.GitClient{
+		RepoDirectory: repoDir,
+		Client:        client,
+	}
+	r := &goGit.Repository{}
+	w := &goGit.Worktree{}
+	status := goGit.Status{
+		"clusters/mgmt/eksa-system/kustomization.yaml": {Staging: goGit.Modified, Worktree: goGit.Unmodified},
+		"clusters/mgmt/eksa-system/eksa-cluster.yaml":  {Staging: goGit.Added, Worktree: goGit.Unmodified},
+		"clusters/mgmt/eksa-system/removed.yaml":       {Staging: goGit.Deleted, Worktree: goGit.Unmodified},
+		"clusters/mgmt/eksa-system/edited.yaml":        {Staging: goGit.Unmodified, Worktree: goGit.Modified},
+		"notes.txt":                                    {Staging: goGit.Untracked, Worktree: goGit.Untracked},
+	}
+
+	client.EXPECT().OpenDir(repoDir).Return(r, nil)
+	client.EXPECT().OpenWorktree(r).Return(w, nil)
+	client.EXPECT().Status(w).Return(status, nil)
+
+	files, err := g.StagedFiles()
+	if err != nil {
+		t.Fatalf("StagedFiles() error = %v", err)
+	}
+	want := []string{"clusters/mgmt/eksa-system/eksa-cluster.yaml", "clusters/mgmt/eksa-system/kustomization.yaml"}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("StagedFiles() = %v, want %v", files, want)
+	}
+}
+
+func TestGoGitStagedFilesStatusError(t *testing.T) {
+	_, client := newGoGitMock(t)
+	g := &gitclient.GitClient{
+		RepoDirectory: repoDir,
+		Client:        client,
+	}
+	r := &goGit.Repository{}
+	w := &goGit.Worktree{}
+
+	client.EXPECT().OpenDir(repoDir).Return(r, nil)
+	client.EXPECT().OpenWorktree(r).Return(w, nil)
+	client.EXPECT().Status(w).Return(nil, errors.New("error in status"))
+
+	wantErr := "listing staged files: error in status"
+	if _, err := g.StagedFiles(); err == nil || err.Error() != wantErr {
+		t.Errorf("StagedFiles() error = %v, want %s", err, wantErr)
+	}
+}
+
+func TestGoGitRemoteBranchExists(t *testing.T) {
+	tests := []struct {
+		name       string
+		refs       []*plumbing.Reference
+		throwError error
+		wantExists bool
+		wantErr    string
+	}{
+		{
+			name:       "branch exists",
+			refs:       []*plumbing.Reference{plumbing.NewHashReference("refs/heads/main", plumbing.ZeroHash), plumbing.NewHashReference("refs/heads/testBranch", plumbing.ZeroHash)},
+			wantExists: true,
+		},
+		{
+			name: "branch doesn't exist",
+			refs: []*plumbing.Reference{plumbing.NewHashReference("refs/heads/main", plumbing.ZeroHash), plumbing.NewHashReference("refs/tags/testBranch", plumbing.ZeroHash)},
+		},
+		{
+			name:       "empty repository",
+			throwError: fmt.Errorf("remote repository is empty"),
+		},
+		{
+			name:       "list error",
+			throwError: fmt.Errorf("authentication required"),
+			wantErr:    "listing branches of remote repository: authentication required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, client := newGoGitMock(t)
+			g := &gitclient.GitClient{
+				RepoUrl: "testurl",
+				Client:  client,
+			}
+			remote := &goGit.Remote{}
+
+			client.EXPECT().NewRemote(g.RepoUrl, goGit.DefaultRemoteName).Return(remote)
+			client.EXPECT().ListWithContext(ctx, remote, g.Auth).Return(tt.refs, tt.throwError)
+
+			exists, err := g.RemoteBranchExists(ctx, "testBranch")
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("RemoteBranchExists() error = %v, wantErr = %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RemoteBranchExists() error = %v", err)
+			}
+			if exists != tt.wantExists {
+				t.Errorf("RemoteBranchExists() = %v, want %v", exists, tt.wantExists)
+			}
+		})
+	}
+}
+
+type fakeSigner struct{}
+
+func (s *fakeSigner) Sign(message io.Reader) (string, error) {
+	return "signature", nil
+}
+
 func newGoGitMock(t *testing.T) (context.Context, *mockGitClient.MockGoGit) {
 	ctx := context.Background()
 	ctrl := gomock.NewController(t)
@@ -0,0 +1,162 @@
+package gitclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// LocalChanges returns the uncommitted changes of the local repository and the commits of the local branch that
+// aren't in the remote branch, fetching it first. With a sparse checkout, only the files under the sparse checkout
+// directories are checked for uncommitted changes. Commits aren't compared if the branch doesn't exist locally or
+// in the remote.
+func (g *GitClient) LocalChanges(ctx context.Context, branch string) (*git.LocalChanges, error) {
+	r, err := g.Client.OpenDir(g.RepoDirectory)
+	if err != nil {
+		return nil, fmt.Errorf("checking local changes: %v", err)
+	}
+
+	w, err := g.Client.OpenWorktree(r)
+	if err != nil {
+		return nil, fmt.Errorf("checking local changes: %v", err)
+	}
+
+	status, err := g.Client.Status(w)
+	if err != nil {
+		return nil, fmt.Errorf("checking local changes: %v", err)
+	}
+
+	changes := &git.LocalChanges{}
+	for file, s := range status {
+		if s.Worktree == gogit.Untracked || (s.Staging == gogit.Unmodified && s.Worktree == gogit.Unmodified) {
+			continue
+		}
+		if g.sparse() && !inDirs(file, g.sparseDirs) {
+			// Files outside of the sparse checkout directories are missing from the worktree on purpose
+			continue
+		}
+		changes.UncommittedFiles = append(changes.UncommittedFiles, file)
+	}
+	sort.Strings(changes.UncommittedFiles)
+
+	branchRef := plumbing.NewBranchReferenceName(branch)
+	localRef, err := g.Client.Reference(r, branchRef)
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return changes, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("checking local changes: %v", err)
+	}
+
+	if head, err := g.Client.Head(r); err == nil {
+		changes.OnBranch = head.Name() == branchRef
+	}
+
+	logger.V(3).Info("Fetching remote branch", "repo", g.RepoDirectory, "branch", branch)
+	err = g.Client.FetchWithContext(ctx, r, g.Auth, branchRef)
+	if errors.Is(err, gogit.NoMatchingRefSpecError{}) || (err != nil && strings.Contains(err.Error(), emptyRepoError)) {
+		return changes, nil
+	}
+	if err != nil && !errors.Is(err, gogit.NoErrAlreadyUpToDate) {
+		return nil, fmt.Errorf("checking local changes: fetching branch %s: %v", branch, err)
+	}
+
+	remoteRef, err := g.Client.Reference(r, plumbing.NewRemoteReferenceName(gogit.DefaultRemoteName, branch))
+	if err != nil {
+		return nil, fmt.Errorf("checking local changes: getting remote branch %s: %v", branch, err)
+	}
+	if remoteRef.Hash() == localRef.Hash() {
+		return changes, nil
+	}
+
+	unpushed, diverged, err := g.Client.UnpushedCommits(r, localRef.Hash(), remoteRef.Hash())
+	if errors.Is(err, plumbing.ErrObjectNotFound) {
+		// Shallow clones don't have the history to find the common ancestor of the branches
+		logger.V(3).Info("Could not compare the local and remote branches, the history is incomplete", "branch", branch)
+		return changes, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("checking local changes: comparing with remote branch %s: %v", branch, err)
+	}
+
+	for _, h := range unpushed {
+		changes.UnpushedCommits = append(changes.UnpushedCommits, h.String())
+	}
+	changes.Diverged = diverged
+	return changes, nil
+}
+
+// StagedFiles returns the files added or modified in the index of the local repository, relative to its root, so they
+// can be checked before they're committed. Deleted files aren't returned.
+func (g *GitClient) StagedFiles() ([]string, error) {
+	r, err := g.Client.OpenDir(g.RepoDirectory)
+	if err != nil {
+		return nil, fmt.Errorf("listing staged files: %v", err)
+	}
+
+	w, err := g.Client.OpenWorktree(r)
+	if err != nil {
+		return nil, fmt.Errorf("listing staged files: %v", err)
+	}
+
+	status, err := g.Client.Status(w)
+	if err != nil {
+		return nil, fmt.Errorf("listing staged files: %v", err)
+	}
+
+	var files []string
+	for file, s := range status {
+		switch s.Staging {
+		case gogit.Added, gogit.Modified, gogit.Renamed, gogit.Copied:
+			files = append(files, file)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func (gg *goGit) Status(w *gogit.Worktree) (gogit.Status, error) {
+	return w.Status()
+}
+
+// UnpushedCommits returns the commits reachable from local, following the first parents, until the common ancestor
+// with remote, and whether remote also has commits missing in local.
+func (gg *goGit) UnpushedCommits(r *gogit.Repository, local, remote plumbing.Hash) ([]plumbing.Hash, bool, error) {
+	localCommit, err := r.CommitObject(local)
+	if err != nil {
+		return nil, false, err
+	}
+	remoteCommit, err := r.CommitObject(remote)
+	if err != nil {
+		return nil, false, err
+	}
+
+	bases, err := localCommit.MergeBase(remoteCommit)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(bases) == 0 {
+		return []plumbing.Hash{local}, true, nil
+	}
+	base := bases[0].Hash
+
+	var unpushed []plumbing.Hash
+	for c := localCommit; c.Hash != base; {
+		unpushed = append(unpushed, c.Hash)
+		if c.NumParents() == 0 {
+			break
+		}
+		if c, err = c.Parent(0); err != nil {
+			return nil, false, err
+		}
+	}
+	return unpushed, remote != base, nil
+}
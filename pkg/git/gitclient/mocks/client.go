@@ -8,6 +8,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	gitclient "github.com/aws/eks-anywhere/pkg/git/gitclient"
 	git "github.com/go-git/go-git/v5"
 	config "github.com/go-git/go-git/v5/config"
 	plumbing "github.com/go-git/go-git/v5/plumbing"
@@ -68,18 +69,18 @@ func (mr *MockGoGitMockRecorder) Checkout(arg0, arg1 interface{}) *gomock.Call {
 }
 
 // Clone mocks base method.
-func (m *MockGoGit) Clone(arg0 context.Context, arg1, arg2 string, arg3 transport.AuthMethod) (*git.Repository, error) {
+func (m *MockGoGit) Clone(arg0 context.Context, arg1, arg2 string, arg3 transport.AuthMethod, arg4 gitclient.CloneOpts) (*git.Repository, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Clone", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "Clone", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(*git.Repository)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Clone indicates an expected call of Clone.
-func (mr *MockGoGitMockRecorder) Clone(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockGoGitMockRecorder) Clone(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clone", reflect.TypeOf((*MockGoGit)(nil).Clone), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clone", reflect.TypeOf((*MockGoGit)(nil).Clone), arg0, arg1, arg2, arg3, arg4)
 }
 
 // Commit mocks base method.
@@ -112,6 +113,21 @@ func (mr *MockGoGitMockRecorder) CommitObject(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitObject", reflect.TypeOf((*MockGoGit)(nil).CommitObject), arg0, arg1)
 }
 
+// CommitWithParents mocks base method.
+func (m *MockGoGit) CommitWithParents(arg0 string, arg1 *object.Signature, arg2 *git.Worktree, arg3 []plumbing.Hash) (plumbing.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitWithParents", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(plumbing.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommitWithParents indicates an expected call of CommitWithParents.
+func (mr *MockGoGitMockRecorder) CommitWithParents(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitWithParents", reflect.TypeOf((*MockGoGit)(nil).CommitWithParents), arg0, arg1, arg2, arg3)
+}
+
 // Create mocks base method.
 func (m *MockGoGit) Create(arg0 *git.Repository, arg1 string) (*git.Remote, error) {
 	m.ctrl.T.Helper()
@@ -141,6 +157,63 @@ func (mr *MockGoGitMockRecorder) CreateBranch(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBranch", reflect.TypeOf((*MockGoGit)(nil).CreateBranch), arg0, arg1)
 }
 
+// CreateTag mocks base method.
+func (m *MockGoGit) CreateTag(arg0 *git.Repository, arg1 string, arg2 plumbing.Hash, arg3 *git.CreateTagOptions) (*plumbing.Reference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTag", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*plumbing.Reference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTag indicates an expected call of CreateTag.
+func (mr *MockGoGitMockRecorder) CreateTag(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTag", reflect.TypeOf((*MockGoGit)(nil).CreateTag), arg0, arg1, arg2, arg3)
+}
+
+// DeleteRemoteBranchWithContext mocks base method.
+func (m *MockGoGit) DeleteRemoteBranchWithContext(arg0 context.Context, arg1 *git.Repository, arg2 transport.AuthMethod, arg3 plumbing.ReferenceName) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRemoteBranchWithContext", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRemoteBranchWithContext indicates an expected call of DeleteRemoteBranchWithContext.
+func (mr *MockGoGitMockRecorder) DeleteRemoteBranchWithContext(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRemoteBranchWithContext", reflect.TypeOf((*MockGoGit)(nil).DeleteRemoteBranchWithContext), arg0, arg1, arg2, arg3)
+}
+
+// FetchWithContext mocks base method.
+func (m *MockGoGit) FetchWithContext(arg0 context.Context, arg1 *git.Repository, arg2 transport.AuthMethod, arg3 plumbing.ReferenceName) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchWithContext", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FetchWithContext indicates an expected call of FetchWithContext.
+func (mr *MockGoGitMockRecorder) FetchWithContext(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchWithContext", reflect.TypeOf((*MockGoGit)(nil).FetchWithContext), arg0, arg1, arg2, arg3)
+}
+
+// ForcePushWithContext mocks base method.
+func (m *MockGoGit) ForcePushWithContext(arg0 context.Context, arg1 *git.Repository, arg2 transport.AuthMethod, arg3 plumbing.ReferenceName, arg4 plumbing.Hash) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForcePushWithContext", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForcePushWithContext indicates an expected call of ForcePushWithContext.
+func (mr *MockGoGitMockRecorder) ForcePushWithContext(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForcePushWithContext", reflect.TypeOf((*MockGoGit)(nil).ForcePushWithContext), arg0, arg1, arg2, arg3, arg4)
+}
+
 // Head mocks base method.
 func (m *MockGoGit) Head(arg0 *git.Repository) (*plumbing.Reference, error) {
 	m.ctrl.T.Helper()
@@ -259,6 +332,34 @@ func (mr *MockGoGitMockRecorder) PullWithContext(arg0, arg1, arg2, arg3 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PullWithContext", reflect.TypeOf((*MockGoGit)(nil).PullWithContext), arg0, arg1, arg2, arg3)
 }
 
+// PushTagWithContext mocks base method.
+func (m *MockGoGit) PushTagWithContext(arg0 context.Context, arg1 *git.Repository, arg2 transport.AuthMethod, arg3 plumbing.ReferenceName) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PushTagWithContext", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PushTagWithContext indicates an expected call of PushTagWithContext.
+func (mr *MockGoGitMockRecorder) PushTagWithContext(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushTagWithContext", reflect.TypeOf((*MockGoGit)(nil).PushTagWithContext), arg0, arg1, arg2, arg3)
+}
+
+// PushToBranchWithContext mocks base method.
+func (m *MockGoGit) PushToBranchWithContext(arg0 context.Context, arg1 *git.Repository, arg2 transport.AuthMethod, arg3, arg4 plumbing.ReferenceName) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PushToBranchWithContext", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PushToBranchWithContext indicates an expected call of PushToBranchWithContext.
+func (mr *MockGoGitMockRecorder) PushToBranchWithContext(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushToBranchWithContext", reflect.TypeOf((*MockGoGit)(nil).PushToBranchWithContext), arg0, arg1, arg2, arg3, arg4)
+}
+
 // PushWithContext mocks base method.
 func (m *MockGoGit) PushWithContext(arg0 context.Context, arg1 *git.Repository, arg2 transport.AuthMethod) error {
 	m.ctrl.T.Helper()
@@ -273,6 +374,36 @@ func (mr *MockGoGitMockRecorder) PushWithContext(arg0, arg1, arg2 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushWithContext", reflect.TypeOf((*MockGoGit)(nil).PushWithContext), arg0, arg1, arg2)
 }
 
+// RebaseChanges mocks base method.
+func (m *MockGoGit) RebaseChanges(arg0 *git.Repository, arg1, arg2 plumbing.Hash) ([]gitclient.FileChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebaseChanges", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]gitclient.FileChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RebaseChanges indicates an expected call of RebaseChanges.
+func (mr *MockGoGitMockRecorder) RebaseChanges(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebaseChanges", reflect.TypeOf((*MockGoGit)(nil).RebaseChanges), arg0, arg1, arg2)
+}
+
+// Reference mocks base method.
+func (m *MockGoGit) Reference(arg0 *git.Repository, arg1 plumbing.ReferenceName) (*plumbing.Reference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reference", arg0, arg1)
+	ret0, _ := ret[0].(*plumbing.Reference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reference indicates an expected call of Reference.
+func (mr *MockGoGitMockRecorder) Reference(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reference", reflect.TypeOf((*MockGoGit)(nil).Reference), arg0, arg1)
+}
+
 // Remove mocks base method.
 func (m *MockGoGit) Remove(arg0 string, arg1 *git.Worktree) (plumbing.Hash, error) {
 	m.ctrl.T.Helper()
@@ -288,6 +419,20 @@ func (mr *MockGoGitMockRecorder) Remove(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockGoGit)(nil).Remove), arg0, arg1)
 }
 
+// Reset mocks base method.
+func (m *MockGoGit) Reset(arg0 *git.Worktree, arg1 *git.ResetOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockGoGitMockRecorder) Reset(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockGoGit)(nil).Reset), arg0, arg1)
+}
+
 // SetRepositoryReference mocks base method.
 func (m *MockGoGit) SetRepositoryReference(arg0 *git.Repository, arg1 *plumbing.Reference) error {
 	m.ctrl.T.Helper()
@@ -301,3 +446,77 @@ func (mr *MockGoGitMockRecorder) SetRepositoryReference(arg0, arg1 interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRepositoryReference", reflect.TypeOf((*MockGoGit)(nil).SetRepositoryReference), arg0, arg1)
 }
+
+// SignCommit mocks base method.
+func (m *MockGoGit) SignCommit(arg0 *git.Repository, arg1 plumbing.Hash, arg2 gitclient.CommitSigner) (plumbing.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignCommit", arg0, arg1, arg2)
+	ret0, _ := ret[0].(plumbing.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignCommit indicates an expected call of SignCommit.
+func (mr *MockGoGitMockRecorder) SignCommit(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignCommit", reflect.TypeOf((*MockGoGit)(nil).SignCommit), arg0, arg1, arg2)
+}
+
+// SparseCheckout mocks base method.
+func (m *MockGoGit) SparseCheckout(arg0 *git.Repository, arg1 *git.Worktree, arg2 plumbing.Hash, arg3 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SparseCheckout", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SparseCheckout indicates an expected call of SparseCheckout.
+func (mr *MockGoGitMockRecorder) SparseCheckout(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SparseCheckout", reflect.TypeOf((*MockGoGit)(nil).SparseCheckout), arg0, arg1, arg2, arg3)
+}
+
+// Status mocks base method.
+func (m *MockGoGit) Status(arg0 *git.Worktree) (git.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status", arg0)
+	ret0, _ := ret[0].(git.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockGoGitMockRecorder) Status(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockGoGit)(nil).Status), arg0)
+}
+
+// UnpushedCommits mocks base method.
+func (m *MockGoGit) UnpushedCommits(arg0 *git.Repository, arg1, arg2 plumbing.Hash) ([]plumbing.Hash, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnpushedCommits", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]plumbing.Hash)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UnpushedCommits indicates an expected call of UnpushedCommits.
+func (mr *MockGoGitMockRecorder) UnpushedCommits(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnpushedCommits", reflect.TypeOf((*MockGoGit)(nil).UnpushedCommits), arg0, arg1, arg2)
+}
+
+// UpdateSubmodules mocks base method.
+func (m *MockGoGit) UpdateSubmodules(arg0 context.Context, arg1 *git.Worktree, arg2 transport.AuthMethod, arg3 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubmodules", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSubmodules indicates an expected call of UpdateSubmodules.
+func (mr *MockGoGitMockRecorder) UpdateSubmodules(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubmodules", reflect.TypeOf((*MockGoGit)(nil).UpdateSubmodules), arg0, arg1, arg2, arg3)
+}
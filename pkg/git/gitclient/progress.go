@@ -0,0 +1,46 @@
+package gitclient
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// progressLogger is an io.Writer that streams the output of the git operations to the logger,
+// one entry per line, only when max logging is enabled.
+type progressLogger struct {
+	enabled func() bool
+	log     func(line string)
+	buf     []byte
+}
+
+func newProgressLogger() *progressLogger {
+	return &progressLogger{
+		enabled: logger.MaxLogging,
+		log: func(line string) {
+			logger.V(logger.MaxLoggingLevel()).Info("git output", "line", line)
+		},
+	}
+}
+
+func (p *progressLogger) Write(b []byte) (int, error) {
+	if !p.enabled() {
+		return len(b), nil
+	}
+
+	p.buf = append(p.buf, b...)
+	for {
+		// git uses carriage returns to update progress lines in place
+		i := bytes.IndexAny(p.buf, "\r\n")
+		if i < 0 {
+			break
+		}
+		if line := strings.TrimSpace(string(p.buf[:i])); line != "" {
+			p.log(line)
+		}
+		p.buf = p.buf[i+1:]
+	}
+
+	return len(b), nil
+}
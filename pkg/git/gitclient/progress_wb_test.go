@@ -0,0 +1,38 @@
+package gitclient
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func newTestProgressLogger(enabled bool) (*progressLogger, *[]string) {
+	lines := []string{}
+	return &progressLogger{
+		enabled: func() bool { return enabled },
+		log:     func(line string) { lines = append(lines, line) },
+	}, &lines
+}
+
+func TestProgressLoggerWrite(t *testing.T) {
+	g := NewWithT(t)
+	p, lines := newTestProgressLogger(true)
+
+	for _, chunk := range []string{"Counting objects:  50% (1/2)\rCounting obj", "ects: 100% (2/2), done.\n", "\n", "Total 2 (delta 0)"} {
+		n, err := p.Write([]byte(chunk))
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(n).To(Equal(len(chunk)))
+	}
+
+	g.Expect(*lines).To(Equal([]string{"Counting objects:  50% (1/2)", "Counting objects: 100% (2/2), done."}))
+}
+
+func TestProgressLoggerWriteDisabled(t *testing.T) {
+	g := NewWithT(t)
+	p, lines := newTestProgressLogger(false)
+
+	n, err := p.Write([]byte("Counting objects: 100% (2/2), done.\n"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(n).To(Equal(36))
+	g.Expect(*lines).To(BeEmpty())
+}
@@ -0,0 +1,41 @@
+package gitclient
+
+import (
+	"os"
+
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const allProxyEnv = "ALL_PROXY"
+
+// WithProxy makes the clones, pulls and pushes connect to the remote through the proxy.
+// The go-git transports are registered for the whole process, so the proxy applies to every GitClient.
+// Ssh remotes only support socks5 proxies, which are configured through the ALL_PROXY environment variable
+// unless it's already set.
+func WithProxy(proxy *git.ProxyConfig) Opt {
+	return func(c *GitClient) {
+		installProxy(proxy)
+	}
+}
+
+func installProxy(proxy *git.ProxyConfig) {
+	httpClient := githttp.NewClient(proxy.HTTPClient())
+	client.InstallProtocol("http", httpClient)
+	client.InstallProtocol("https", httpClient)
+
+	socks := proxy.SocksProxy()
+	if socks == "" {
+		return
+	}
+	if _, ok := os.LookupEnv(allProxyEnv); ok {
+		logger.V(3).Info("ALL_PROXY is already set, ssh remotes won't use the configured proxy", "proxy", socks)
+		return
+	}
+	if err := os.Setenv(allProxyEnv, socks); err != nil {
+		logger.V(3).Info("Unable to set ALL_PROXY, ssh remotes won't use the configured proxy", "error", err)
+	}
+}
@@ -0,0 +1,251 @@
+package gitclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
+
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// FileChange is a file added, modified or deleted by a commit. Content is empty for deleted files.
+type FileChange struct {
+	Path    string
+	Content []byte
+	Deleted bool
+}
+
+// isPushRejected returns true if the push failed because the remote branch isn't an ancestor of the local one,
+// either detected locally or reported by the remote.
+func isPushRejected(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, gogit.ErrForceNeeded) {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "non-fast-forward") || strings.Contains(msg, "fetch first")
+}
+
+func (g *GitClient) currentBranch(r *gogit.Repository) string {
+	head, err := g.Client.Head(r)
+	if err != nil {
+		return ""
+	}
+	return head.Name().Short()
+}
+
+// RebaseOnRemote fetches the current branch and replays the last local commit on top of the remote branch,
+// so it can be pushed after another operation pushed to the same branch. The replayed commit keeps the message
+// of the original one. It fails with a RebaseConflictError if the remote commits changed the same files.
+func (g *GitClient) RebaseOnRemote(ctx context.Context) error {
+	r, err := g.Client.OpenDir(g.RepoDirectory)
+	if err != nil {
+		return fmt.Errorf("rebasing on remote: %v", err)
+	}
+
+	w, err := g.Client.OpenWorktree(r)
+	if err != nil {
+		return fmt.Errorf("rebasing on remote: %v", err)
+	}
+
+	head, err := g.Client.Head(r)
+	if err != nil {
+		return fmt.Errorf("rebasing on remote: %v", err)
+	}
+	if !head.Name().IsBranch() {
+		return fmt.Errorf("rebasing on remote: HEAD is not on a branch")
+	}
+	branch := head.Name().Short()
+
+	logger.V(3).Info("Fetching remote branch", "repo", g.RepoDirectory, "branch", branch)
+	if err = g.Client.FetchWithContext(ctx, r, g.Auth, head.Name()); err != nil && !errors.Is(err, gogit.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("rebasing on remote: fetching branch %s: %v", branch, err)
+	}
+
+	remoteRef, err := g.Client.Reference(r, plumbing.NewRemoteReferenceName(gogit.DefaultRemoteName, branch))
+	if err != nil {
+		return fmt.Errorf("rebasing on remote: getting remote branch %s: %v", branch, err)
+	}
+	if remoteRef.Hash() == head.Hash() {
+		logger.V(3).Info("Local branch is already up-to-date with the remote branch", "branch", branch)
+		return nil
+	}
+
+	commit, err := g.Client.CommitObject(r, head.Hash())
+	if err != nil {
+		return fmt.Errorf("rebasing on remote: %v", err)
+	}
+
+	changes, err := g.Client.RebaseChanges(r, head.Hash(), remoteRef.Hash())
+	if err != nil {
+		return fmt.Errorf("rebasing on remote: %w", err)
+	}
+
+	if err = g.hardReset(r, w, remoteRef.Hash()); err != nil {
+		return fmt.Errorf("rebasing on remote: resetting to remote branch %s: %v", branch, err)
+	}
+
+	for _, c := range changes {
+		if err = g.applyChange(w, c); err != nil {
+			return fmt.Errorf("rebasing on remote: %v", err)
+		}
+	}
+
+	rebased, err := g.Client.Commit(commit.Message, g.commitSignature(), w)
+	if err != nil {
+		return fmt.Errorf("rebasing on remote: %v", err)
+	}
+
+	if g.signer != nil {
+		if rebased, err = g.Client.SignCommit(r, rebased, g.signer); err != nil {
+			return fmt.Errorf("rebasing on remote: signing commit: %v", err)
+		}
+	}
+
+	logger.V(3).Info("Rebased commit on remote branch", "branch", branch, "previous", head.Hash(), "hash", rebased, "files", len(changes))
+	return nil
+}
+
+func (g *GitClient) applyChange(w *gogit.Worktree, c FileChange) error {
+	file := filepath.Join(g.RepoDirectory, c.Path)
+	if c.Deleted {
+		if _, err := g.Client.Remove(c.Path, w); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("removing %s: %v", c.Path, err)
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return fmt.Errorf("writing %s: %v", c.Path, err)
+	}
+	if err := os.WriteFile(file, c.Content, 0o644); err != nil {
+		return fmt.Errorf("writing %s: %v", c.Path, err)
+	}
+	if err := g.Client.AddGlob(c.Path, w); err != nil {
+		return fmt.Errorf("adding %s: %v", c.Path, err)
+	}
+	return nil
+}
+
+// FetchWithContext fetches branch from the default remote, updating its remote tracking reference even if the
+// remote branch was force pushed.
+func (gg *goGit) FetchWithContext(ctx context.Context, r *gogit.Repository, auth transport.AuthMethod, branch plumbing.ReferenceName) error {
+	ctx, cancel := context.WithTimeout(ctx, gitTimeout)
+	defer cancel()
+
+	remoteRef := plumbing.NewRemoteReferenceName(gogit.DefaultRemoteName, branch.Short())
+	return r.FetchContext(ctx, &gogit.FetchOptions{
+		RemoteName: gogit.DefaultRemoteName,
+		Auth:       auth,
+		RefSpecs:   []config.RefSpec{config.RefSpec(fmt.Sprintf("+%s:%s", branch, remoteRef))},
+		Progress:   gg.progress,
+	})
+}
+
+// RebaseChanges returns the files changed by the commit h, relative to its parent, so they can be applied on top
+// of the commit onto. It fails with a RebaseConflictError if the same files changed between the parent and onto.
+func (gg *goGit) RebaseChanges(r *gogit.Repository, h, onto plumbing.Hash) ([]FileChange, error) {
+	commit, err := r.CommitObject(h)
+	if err != nil {
+		return nil, err
+	}
+	if commit.NumParents() != 1 {
+		return nil, fmt.Errorf("commit %s has %d parents, only commits with one parent can be rebased", h, commit.NumParents())
+	}
+
+	parent, err := commit.Parent(0)
+	if err != nil {
+		return nil, err
+	}
+	ontoCommit, err := r.CommitObject(onto)
+	if err != nil {
+		return nil, err
+	}
+
+	ours, err := diffCommits(parent, commit)
+	if err != nil {
+		return nil, err
+	}
+	theirs, err := diffCommits(parent, ontoCommit)
+	if err != nil {
+		return nil, err
+	}
+
+	changedByRemote := map[string]bool{}
+	for _, c := range theirs {
+		changedByRemote[changePath(c)] = true
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []FileChange
+	var conflicts []string
+	for _, c := range ours {
+		p := changePath(c)
+		if changedByRemote[p] {
+			conflicts = append(conflicts, p)
+			continue
+		}
+
+		action, err := c.Action()
+		if err != nil {
+			return nil, err
+		}
+		if action == merkletrie.Delete {
+			changes = append(changes, FileChange{Path: p, Deleted: true})
+			continue
+		}
+
+		f, err := tree.File(p)
+		if err != nil {
+			return nil, err
+		}
+		content, err := f.Contents()
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, FileChange{Path: p, Content: []byte(content)})
+	}
+
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return nil, &git.RebaseConflictError{Paths: conflicts}
+	}
+	return changes, nil
+}
+
+func diffCommits(from, to *object.Commit) (object.Changes, error) {
+	fromTree, err := from.Tree()
+	if err != nil {
+		return nil, err
+	}
+	toTree, err := to.Tree()
+	if err != nil {
+		return nil, err
+	}
+	return object.DiffTree(fromTree, toTree)
+}
+
+func changePath(c *object.Change) string {
+	if c.To.Name != "" {
+		return c.To.Name
+	}
+	return c.From.Name
+}
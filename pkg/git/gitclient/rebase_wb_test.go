@@ -0,0 +1,251 @@
+package gitclient
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/git"
+)
+
+// localGoGit doesn't fetch, the remote branch is set directly in the local repository.
+type localGoGit struct {
+	*goGit
+}
+
+func (gg *localGoGit) FetchWithContext(_ context.Context, _ *gogit.Repository, _ transport.AuthMethod, _ plumbing.ReferenceName) error {
+	return gogit.NoErrAlreadyUpToDate
+}
+
+type rebaseTest struct {
+	*WithT
+	dir string
+	r   *gogit.Repository
+	w   *gogit.Worktree
+}
+
+func newRebaseTest(t *testing.T) *rebaseTest {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	r, err := gogit.PlainInit(dir, false)
+	g.Expect(err).NotTo(HaveOccurred())
+	w, err := r.Worktree()
+	g.Expect(err).NotTo(HaveOccurred())
+	return &rebaseTest{WithT: g, dir: dir, r: r, w: w}
+}
+
+func (tt *rebaseTest) commit(msg string, files map[string]string) plumbing.Hash {
+	for name, content := range files {
+		tt.Expect(os.MkdirAll(filepath.Join(tt.dir, filepath.Dir(name)), 0o755)).To(Succeed())
+		tt.Expect(os.WriteFile(filepath.Join(tt.dir, name), []byte(content), 0o644)).To(Succeed())
+		_, err := tt.w.Add(name)
+		tt.Expect(err).NotTo(HaveOccurred())
+	}
+	h, err := tt.w.Commit(msg, &gogit.CommitOptions{Author: &object.Signature{Name: "test", When: time.Now()}})
+	tt.Expect(err).NotTo(HaveOccurred())
+	return h
+}
+
+// setupDivergedBranches commits theirs as the remote branch and ours as the local branch, both on top of a base commit.
+func (tt *rebaseTest) setupDivergedBranches(ours, theirs map[string]string) plumbing.Hash {
+	base := tt.commit("base", map[string]string{
+		"clusters/mgmt/eksa-system/eksa-cluster.yaml":     "name: mgmt",
+		"clusters/workload/eksa-system/eksa-cluster.yaml": "name: workload",
+	})
+
+	remote := tt.commit("theirs", theirs)
+	tt.Expect(tt.r.Storer.SetReference(plumbing.NewHashReference(plumbing.NewRemoteReferenceName(gogit.DefaultRemoteName, "master"), remote))).To(Succeed())
+	tt.Expect(tt.w.Reset(&gogit.ResetOptions{Commit: base, Mode: gogit.HardReset})).To(Succeed())
+
+	tt.commit("ours", ours)
+	return remote
+}
+
+func (tt *rebaseTest) client() *GitClient {
+	return &GitClient{Client: &localGoGit{goGit: &goGit{}}, RepoDirectory: tt.dir}
+}
+
+func (tt *rebaseTest) expectFile(name, content string) {
+	b, err := os.ReadFile(filepath.Join(tt.dir, name))
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(string(b)).To(Equal(content))
+}
+
+func TestGitClientRebaseOnRemote(t *testing.T) {
+	tt := newRebaseTest(t)
+	remote := tt.setupDivergedBranches(
+		map[string]string{
+			"clusters/mgmt/eksa-system/eksa-cluster.yaml":  "name: mgmt\nversion: 2",
+			"clusters/mgmt/eksa-system/kustomization.yaml": "resources: []",
+		},
+		map[string]string{"clusters/workload/eksa-system/eksa-cluster.yaml": "name: workload\nversion: 2"},
+	)
+
+	tt.Expect(tt.client().RebaseOnRemote(context.Background())).To(Succeed())
+
+	head, err := tt.r.Head()
+	tt.Expect(err).NotTo(HaveOccurred())
+	commit, err := tt.r.CommitObject(head.Hash())
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(commit.Message).To(Equal("ours"))
+	tt.Expect(commit.ParentHashes).To(Equal([]plumbing.Hash{remote}))
+
+	tt.expectFile("clusters/mgmt/eksa-system/eksa-cluster.yaml", "name: mgmt\nversion: 2")
+	tt.expectFile("clusters/mgmt/eksa-system/kustomization.yaml", "resources: []")
+	tt.expectFile("clusters/workload/eksa-system/eksa-cluster.yaml", "name: workload\nversion: 2")
+
+	status, err := tt.w.Status()
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(status.IsClean()).To(BeTrue())
+}
+
+func TestGitClientRebaseOnRemoteDeletedFile(t *testing.T) {
+	tt := newRebaseTest(t)
+	base := tt.commit("base", map[string]string{
+		"clusters/mgmt/eksa-system/eksa-cluster.yaml":     "name: mgmt",
+		"clusters/workload/eksa-system/eksa-cluster.yaml": "name: workload",
+	})
+	remote := tt.commit("theirs", map[string]string{"clusters/other/eksa-system/eksa-cluster.yaml": "name: other"})
+	tt.Expect(tt.r.Storer.SetReference(plumbing.NewHashReference(plumbing.NewRemoteReferenceName(gogit.DefaultRemoteName, "master"), remote))).To(Succeed())
+	tt.Expect(tt.w.Reset(&gogit.ResetOptions{Commit: base, Mode: gogit.HardReset})).To(Succeed())
+	_, err := tt.w.Remove("clusters/workload/eksa-system/eksa-cluster.yaml")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.commit("delete workload", nil)
+
+	tt.Expect(tt.client().RebaseOnRemote(context.Background())).To(Succeed())
+
+	tt.Expect(filepath.Join(tt.dir, "clusters/workload/eksa-system/eksa-cluster.yaml")).NotTo(BeAnExistingFile())
+	tt.expectFile("clusters/other/eksa-system/eksa-cluster.yaml", "name: other")
+}
+
+func TestGitClientRebaseOnRemoteConflict(t *testing.T) {
+	tt := newRebaseTest(t)
+	tt.setupDivergedBranches(
+		map[string]string{"clusters/mgmt/eksa-system/eksa-cluster.yaml": "name: mgmt\nversion: 2"},
+		map[string]string{"clusters/mgmt/eksa-system/eksa-cluster.yaml": "name: mgmt\nversion: 3"},
+	)
+
+	err := tt.client().RebaseOnRemote(context.Background())
+	var conflict *git.RebaseConflictError
+	tt.Expect(errors.As(err, &conflict)).To(BeTrue())
+	tt.Expect(conflict.Paths).To(ConsistOf("clusters/mgmt/eksa-system/eksa-cluster.yaml"))
+}
+
+func TestGitClientRebaseOnRemoteUpToDate(t *testing.T) {
+	tt := newRebaseTest(t)
+	h := tt.commit("base", map[string]string{"README.md": "# fleet"})
+	tt.Expect(tt.r.Storer.SetReference(plumbing.NewHashReference(plumbing.NewRemoteReferenceName(gogit.DefaultRemoteName, "master"), h))).To(Succeed())
+
+	tt.Expect(tt.client().RebaseOnRemote(context.Background())).To(Succeed())
+
+	head, err := tt.r.Head()
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(head.Hash()).To(Equal(h))
+}
+
+func TestIsPushRejected(t *testing.T) {
+	tests := []struct {
+		testName string
+		err      error
+		want     bool
+	}{
+		{testName: "no error"},
+		{testName: "force needed", err: gogit.ErrForceNeeded, want: true},
+		{testName: "non fast forward", err: errors.New("non-fast-forward update: refs/heads/main"), want: true},
+		{testName: "rejected by remote", err: errors.New("refs/heads/main: rejected (fetch first)"), want: true},
+		{testName: "other error", err: errors.New("authentication required")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			NewWithT(t).Expect(isPushRejected(tt.err)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestGitClientFetchAndReset(t *testing.T) {
+	tt := newRebaseTest(t)
+	remote := tt.setupDivergedBranches(
+		map[string]string{"clusters/mgmt/eksa-system/eksa-cluster.yaml": "name: mgmt\ncount: 2"},
+		map[string]string{"clusters/workload/eksa-system/eksa-cluster.yaml": "name: workload\ncount: 3"},
+	)
+	tt.Expect(os.WriteFile(filepath.Join(tt.dir, "clusters/mgmt/eksa-system/eksa-cluster.yaml"), []byte("uncommitted"), 0o644)).To(Succeed())
+
+	tt.Expect(tt.client().FetchAndReset(context.Background(), "master")).To(Succeed())
+
+	head, err := tt.r.Head()
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(head.Name()).To(Equal(plumbing.NewBranchReferenceName("master")))
+	tt.Expect(head.Hash()).To(Equal(remote))
+	tt.expectFile("clusters/mgmt/eksa-system/eksa-cluster.yaml", "name: mgmt")
+	tt.expectFile("clusters/workload/eksa-system/eksa-cluster.yaml", "name: workload\ncount: 3")
+}
+
+func TestGitClientFetchAndResetOtherBranch(t *testing.T) {
+	tt := newRebaseTest(t)
+	base := tt.commit("base", map[string]string{"clusters/mgmt/eksa-system/eksa-cluster.yaml": "name: mgmt"})
+	remote := tt.commit("theirs", map[string]string{"clusters/mgmt/eksa-system/eksa-cluster.yaml": "name: mgmt\ncount: 2"})
+	tt.Expect(tt.r.Storer.SetReference(plumbing.NewHashReference(plumbing.NewRemoteReferenceName(gogit.DefaultRemoteName, "prod"), remote))).To(Succeed())
+	tt.Expect(tt.w.Reset(&gogit.ResetOptions{Commit: base, Mode: gogit.HardReset})).To(Succeed())
+
+	tt.Expect(tt.client().FetchAndReset(context.Background(), "prod")).To(Succeed())
+
+	head, err := tt.r.Head()
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(head.Name()).To(Equal(plumbing.NewBranchReferenceName("prod")))
+	tt.Expect(head.Hash()).To(Equal(remote))
+	tt.expectFile("clusters/mgmt/eksa-system/eksa-cluster.yaml", "name: mgmt\ncount: 2")
+}
+
+func TestGitClientLocalChanges(t *testing.T) {
+	tt := newRebaseTest(t)
+	tt.setupDivergedBranches(
+		map[string]string{"clusters/mgmt/eksa-system/eksa-cluster.yaml": "name: mgmt\ncount: 2"},
+		map[string]string{"clusters/workload/eksa-system/eksa-cluster.yaml": "name: workload\ncount: 3"},
+	)
+	ours, err := tt.r.Head()
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(os.WriteFile(filepath.Join(tt.dir, "clusters/workload/eksa-system/eksa-cluster.yaml"), []byte("uncommitted"), 0o644)).To(Succeed())
+	tt.Expect(os.WriteFile(filepath.Join(tt.dir, "untracked.yaml"), []byte("untracked"), 0o644)).To(Succeed())
+
+	changes, err := tt.client().LocalChanges(context.Background(), "master")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(changes).To(Equal(&git.LocalChanges{
+		UncommittedFiles: []string{"clusters/workload/eksa-system/eksa-cluster.yaml"},
+		UnpushedCommits:  []string{ours.Hash().String()},
+		Diverged:         true,
+		OnBranch:         true,
+	}))
+}
+
+func TestGitClientLocalChangesAhead(t *testing.T) {
+	tt := newRebaseTest(t)
+	remote := tt.commit("base", map[string]string{"clusters/mgmt/eksa-system/eksa-cluster.yaml": "name: mgmt"})
+	tt.Expect(tt.r.Storer.SetReference(plumbing.NewHashReference(plumbing.NewRemoteReferenceName(gogit.DefaultRemoteName, "master"), remote))).To(Succeed())
+	first := tt.commit("first", map[string]string{"clusters/mgmt/eksa-system/eksa-cluster.yaml": "name: mgmt\ncount: 2"})
+	second := tt.commit("second", map[string]string{"clusters/mgmt/eksa-system/eksa-cluster.yaml": "name: mgmt\ncount: 3"})
+
+	changes, err := tt.client().LocalChanges(context.Background(), "master")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(changes.UncommittedFiles).To(BeEmpty())
+	tt.Expect(changes.UnpushedCommits).To(Equal([]string{second.String(), first.String()}))
+	tt.Expect(changes.Diverged).To(BeFalse())
+}
+
+func TestGitClientLocalChangesUpToDate(t *testing.T) {
+	tt := newRebaseTest(t)
+	remote := tt.commit("base", map[string]string{"clusters/mgmt/eksa-system/eksa-cluster.yaml": "name: mgmt"})
+	tt.Expect(tt.r.Storer.SetReference(plumbing.NewHashReference(plumbing.NewRemoteReferenceName(gogit.DefaultRemoteName, "master"), remote))).To(Succeed())
+
+	changes, err := tt.client().LocalChanges(context.Background(), "master")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(changes.IsEmpty()).To(BeTrue())
+}
@@ -0,0 +1,198 @@
+package gitclient_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	goGit "github.com/go-git/go-git/v5"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/aws/eks-anywhere/pkg/git/gitclient"
+)
+
+func gpgKey(t *testing.T) (privateKey, publicKey []byte) {
+	entity, err := openpgp.NewEntity("EKS-A", "", "eksa@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var private, public bytes.Buffer
+	w, err := armor.Encode(&private, openpgp.PrivateKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := entity.SerializePrivate(w, nil); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+
+	w, err = armor.Encode(&public, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := entity.Serialize(w); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+
+	return private.Bytes(), public.Bytes()
+}
+
+func sshKey(t *testing.T) ([]byte, ed25519.PublicKey) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), public
+}
+
+func TestGPGSignerSign(t *testing.T) {
+	g := NewWithT(t)
+	private, public := gpgKey(t)
+	signer, err := gitclient.NewGPGSigner(private, "")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	signature, err := signer.Sign(strings.NewReader("commit content"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(signature).To(HavePrefix("-----BEGIN PGP SIGNATURE-----"))
+
+	keyRing, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(public))
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = openpgp.CheckArmoredDetachedSignature(keyRing, strings.NewReader("commit content"), strings.NewReader(signature), nil)
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestNewGPGSignerInvalidKey(t *testing.T) {
+	g := NewWithT(t)
+	_, err := gitclient.NewGPGSigner([]byte("not a key"), "")
+	g.Expect(err).To(MatchError(ContainSubstring("reading gpg signing key")))
+}
+
+func TestSSHSignerSign(t *testing.T) {
+	g := NewWithT(t)
+	private, public := sshKey(t)
+	signer, err := gitclient.NewSSHSigner(private, "")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	signature, err := signer.Sign(strings.NewReader("commit content"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(signature).To(HavePrefix("-----BEGIN SSH SIGNATURE-----\n"))
+	g.Expect(signature).To(HaveSuffix("-----END SSH SIGNATURE-----\n"))
+
+	lines := strings.Split(strings.TrimSpace(signature), "\n")
+	for _, l := range lines[1 : len(lines)-1] {
+		g.Expect(len(l)).To(BeNumerically("<=", 70))
+	}
+	blob, err := base64.StdEncoding.DecodeString(strings.Join(lines[1:len(lines)-1], ""))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(blob[:6])).To(Equal("SSHSIG"))
+
+	sig := struct {
+		Version       uint32
+		PublicKey     []byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     []byte
+	}{}
+	g.Expect(ssh.Unmarshal(blob[6:], &sig)).To(Succeed())
+	g.Expect(sig.Version).To(BeEquivalentTo(1))
+	g.Expect(sig.Namespace).To(Equal("git"))
+	g.Expect(sig.HashAlgorithm).To(Equal("sha512"))
+
+	publicKey, err := ssh.NewPublicKey(public)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(sig.PublicKey).To(Equal(publicKey.Marshal()))
+
+	hash := sha512.Sum512([]byte("commit content"))
+	signedData := append([]byte("SSHSIG"), ssh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          []byte
+	}{"git", "", "sha512", hash[:]})...)
+	sshSignature := &ssh.Signature{}
+	g.Expect(ssh.Unmarshal(sig.Signature, sshSignature)).To(Succeed())
+	g.Expect(publicKey.Verify(signedData, sshSignature)).To(Succeed())
+}
+
+func TestNewSSHSignerInvalidKey(t *testing.T) {
+	g := NewWithT(t)
+	_, err := gitclient.NewSSHSigner([]byte("not a key"), "")
+	g.Expect(err).To(MatchError(ContainSubstring("reading ssh signing key")))
+}
+
+func TestLoadCommitSigner(t *testing.T) {
+	gpgPrivate, _ := gpgKey(t)
+	sshPrivate, _ := sshKey(t)
+	tests := []struct {
+		testName   string
+		key        []byte
+		wantPrefix string
+	}{
+		{testName: "gpg key", key: gpgPrivate, wantPrefix: "-----BEGIN PGP SIGNATURE-----"},
+		{testName: "ssh key", key: sshPrivate, wantPrefix: "-----BEGIN SSH SIGNATURE-----"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			keyFile := filepath.Join(t.TempDir(), "signing-key")
+			g.Expect(os.WriteFile(keyFile, tt.key, 0o600)).To(Succeed())
+
+			signer, err := gitclient.LoadCommitSigner(keyFile, "")
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(signer.Sign(strings.NewReader("commit content"))).To(HavePrefix(tt.wantPrefix))
+		})
+	}
+}
+
+func TestLoadCommitSignerMissingFile(t *testing.T) {
+	g := NewWithT(t)
+	_, err := gitclient.LoadCommitSigner(filepath.Join(t.TempDir(), "missing"), "")
+	g.Expect(err).To(MatchError(ContainSubstring("reading git signing key file")))
+}
+
+func TestGitClientCommitSignedWithGPGKey(t *testing.T) {
+	g := NewWithT(t)
+	private, public := gpgKey(t)
+	signer, err := gitclient.NewGPGSigner(private, "")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	dir := t.TempDir()
+	c := gitclient.New(
+		gitclient.WithRepositoryDirectory(dir),
+		gitclient.WithRepositoryUrl("https://git.example.com/fleet.git"),
+		gitclient.WithCommitSigner(signer),
+	)
+	g.Expect(c.Init()).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "cluster.yaml"), []byte("kind: Cluster"), 0o644)).To(Succeed())
+	g.Expect(c.Add("cluster.yaml")).To(Succeed())
+	g.Expect(c.Commit("Initial commit")).To(Succeed())
+
+	r, err := goGit.PlainOpen(dir)
+	g.Expect(err).NotTo(HaveOccurred())
+	head, err := r.Head()
+	g.Expect(err).NotTo(HaveOccurred())
+	commit, err := r.CommitObject(head.Hash())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(commit.Message).To(Equal("Initial commit"))
+	_, err = commit.Verify(string(public))
+	g.Expect(err).NotTo(HaveOccurred())
+}
@@ -0,0 +1,165 @@
+package gitclient
+
+import (
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/go-git/go-billy/v5"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// WithSparseCheckoutDirectories makes the GitClient only materialize the files under dirs in the worktree.
+// See SetSparseCheckoutDirectories.
+func WithSparseCheckoutDirectories(dirs ...string) Opt {
+	return func(c *GitClient) {
+		c.SetSparseCheckoutDirectories(dirs...)
+	}
+}
+
+// SetSparseCheckoutDirectories restricts the files written to the worktree by the following clones, checkouts and
+// pulls to the ones under dirs, relative to the root of the repository. The index still tracks all the files
+// of the checked out commit, so commits keep the files outside dirs. No dirs disables the sparse checkout.
+func (g *GitClient) SetSparseCheckoutDirectories(dirs ...string) {
+	g.sparseDirs = nil
+	for _, d := range dirs {
+		d = path.Clean(d)
+		if d == "." || d == "/" {
+			// The root directory includes every file, so there is nothing to leave out of the worktree
+			g.sparseDirs = nil
+			return
+		}
+		g.sparseDirs = append(g.sparseDirs, strings.TrimPrefix(d, "/"))
+	}
+}
+
+func (g *GitClient) sparse() bool {
+	return len(g.sparseDirs) > 0
+}
+
+// sparseCheckoutHead populates the worktree of a repository cloned without checkout with the commit HEAD points to.
+func (g *GitClient) sparseCheckoutHead(r *gogit.Repository) error {
+	w, err := g.Client.OpenWorktree(r)
+	if err != nil {
+		return err
+	}
+
+	head, err := g.Client.Head(r)
+	if err != nil {
+		return err
+	}
+
+	logger.V(3).Info("Sparse checking out repository", "repo", g.RepoDirectory, "directories", g.sparseDirs)
+	return g.Client.SparseCheckout(r, w, head.Hash(), g.sparseDirs)
+}
+
+// checkout checks out a branch, only writing the sparse checkout directories to the worktree when configured.
+func (g *GitClient) checkout(r *gogit.Repository, w *gogit.Worktree, opts *gogit.CheckoutOptions) error {
+	if !g.sparse() {
+		return g.Client.Checkout(w, opts)
+	}
+
+	// Keep only moves HEAD to the branch, the index and worktree are then updated by the sparse checkout
+	sparseOpts := *opts
+	sparseOpts.Force = false
+	sparseOpts.Keep = true
+	if err := g.Client.Checkout(w, &sparseOpts); err != nil {
+		return err
+	}
+
+	head, err := g.Client.Head(r)
+	if err != nil {
+		return err
+	}
+	return g.Client.SparseCheckout(r, w, head.Hash(), g.sparseDirs)
+}
+
+// hardReset resets the current branch, index and worktree to the commit h, only writing the sparse checkout
+// directories to the worktree when configured.
+func (g *GitClient) hardReset(r *gogit.Repository, w *gogit.Worktree, h plumbing.Hash) error {
+	if g.sparse() {
+		return g.Client.SparseCheckout(r, w, h, g.sparseDirs)
+	}
+	return g.Client.Reset(w, &gogit.ResetOptions{Commit: h, Mode: gogit.HardReset})
+}
+
+// SparseCheckout points the current branch to the commit h and resets the index to it. Only the files of the
+// commit under dirs are written to the worktree, any other file of the commit is removed from it.
+func (gg *goGit) SparseCheckout(r *gogit.Repository, w *gogit.Worktree, h plumbing.Hash, dirs []string) error {
+	if err := w.Reset(&gogit.ResetOptions{Commit: h, Mode: gogit.MixedReset}); err != nil {
+		return err
+	}
+
+	commit, err := r.CommitObject(h)
+	if err != nil {
+		return err
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return err
+	}
+
+	return tree.Files().ForEach(func(f *object.File) error {
+		if !inDirs(f.Name, dirs) {
+			if err := w.Filesystem.Remove(f.Name); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			return nil
+		}
+		return writeWorktreeFile(w.Filesystem, f)
+	})
+}
+
+func inDirs(file string, dirs []string) bool {
+	for _, d := range dirs {
+		if strings.HasPrefix(file, d+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func writeWorktreeFile(fs billy.Filesystem, f *object.File) error {
+	content, err := f.Reader()
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	if err := fs.MkdirAll(path.Dir(f.Name), 0o755); err != nil {
+		return err
+	}
+
+	if f.Mode == filemode.Symlink {
+		target, err := io.ReadAll(content)
+		if err != nil {
+			return err
+		}
+		if err := fs.Remove(f.Name); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return fs.Symlink(string(target), f.Name)
+	}
+
+	mode, err := f.Mode.ToOSFileMode()
+	if err != nil {
+		return err
+	}
+
+	file, err := fs.OpenFile(f.Name, os.O_RDWR|os.O_CREATE|os.O_TRUNC, mode.Perm())
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(file, content); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,51 @@
+package gitclient
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	. "github.com/onsi/gomega"
+)
+
+func TestGoGitSparseCheckout(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	r, err := gogit.PlainInit(dir, false)
+	g.Expect(err).NotTo(HaveOccurred())
+	w, err := r.Worktree()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	files := map[string]string{
+		"clusters/mgmt/eksa-system/eksa-cluster.yaml":  "kind: Cluster",
+		"clusters/other/eksa-system/eksa-cluster.yaml": "kind: Cluster",
+		"README.md": "# fleet",
+	}
+	for name, content := range files {
+		g.Expect(os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0o755)).To(Succeed())
+		g.Expect(os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644)).To(Succeed())
+		_, err = w.Add(name)
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+	h, err := w.Commit("init", &gogit.CommitOptions{Author: &object.Signature{Name: "test", When: time.Now()}})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(os.RemoveAll(filepath.Join(dir, "clusters"))).To(Succeed())
+	g.Expect((&goGit{}).SparseCheckout(r, w, h, []string{"clusters/mgmt"})).To(Succeed())
+
+	g.Expect(filepath.Join(dir, "clusters/mgmt/eksa-system/eksa-cluster.yaml")).To(BeAnExistingFile())
+	g.Expect(filepath.Join(dir, "clusters/other/eksa-system/eksa-cluster.yaml")).NotTo(BeAnExistingFile())
+	g.Expect(filepath.Join(dir, "README.md")).NotTo(BeAnExistingFile())
+
+	idx, err := r.Storer.Index()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(idx.Entries).To(HaveLen(len(files)))
+
+	status, err := w.Status()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(status).NotTo(HaveKey("clusters/mgmt/eksa-system/eksa-cluster.yaml"))
+	g.Expect(status.File("README.md").Staging).To(Equal(gogit.Unmodified))
+}
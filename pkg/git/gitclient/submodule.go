@@ -0,0 +1,159 @@
+package gitclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const gitModulesFile = ".gitmodules"
+
+// submodulePaths returns the paths of the submodules declared in the .gitmodules file of the local repository,
+// relative to its root. It's empty if the repository doesn't have submodules.
+func (g *GitClient) submodulePaths() ([]string, error) {
+	content, err := os.ReadFile(filepath.Join(g.RepoDirectory, gitModulesFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %v", gitModulesFile, err)
+	}
+
+	modules := config.NewModules()
+	if err = modules.Unmarshal(content); err != nil {
+		return nil, fmt.Errorf("parsing %s: %v", gitModulesFile, err)
+	}
+
+	paths := make([]string, 0, len(modules.Submodules))
+	for _, s := range modules.Submodules {
+		paths = append(paths, path.Clean(s.Path))
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// updateSubmodules initializes and updates the submodules of the local repository to the commits the checked out
+// commit points to. With a sparse checkout, only the submodules under the sparse checkout directories are updated.
+func (g *GitClient) updateSubmodules(ctx context.Context, r *gogit.Repository) error {
+	paths, err := g.submodulePaths()
+	if err != nil {
+		return fmt.Errorf("updating submodules: %v", err)
+	}
+
+	if g.sparse() {
+		var sparsePaths []string
+		for _, p := range paths {
+			if inDirs(p, g.sparseDirs) {
+				sparsePaths = append(sparsePaths, p)
+			}
+		}
+		paths = sparsePaths
+	}
+
+	if len(paths) == 0 {
+		return nil
+	}
+
+	w, err := g.Client.OpenWorktree(r)
+	if err != nil {
+		return fmt.Errorf("updating submodules: %v", err)
+	}
+
+	logger.V(3).Info("Updating submodules", "repo", g.RepoDirectory, "submodules", paths)
+	if err = g.Client.UpdateSubmodules(ctx, w, g.Auth, paths); err != nil {
+		return fmt.Errorf("updating submodules: %v", err)
+	}
+	return nil
+}
+
+// addSkippingSubmodules stages the files under filename, leaving out the submodules. go-git would otherwise stage
+// the files of the checked out submodules as regular files of the repository, replacing the submodule pointers.
+func (g *GitClient) addSkippingSubmodules(w *gogit.Worktree, filename string, submodules []string) error {
+	name := path.Clean(filename)
+	var nested []string
+	for _, s := range submodules {
+		if name == s || strings.HasPrefix(name, s+"/") {
+			logger.V(3).Info("Skipping submodule, its pointer is not updated", "file", filename, "submodule", s)
+			return nil
+		}
+		if name == "." || strings.HasPrefix(s, name+"/") {
+			nested = append(nested, s)
+		}
+	}
+
+	if len(nested) == 0 {
+		return g.Client.AddGlob(filename, w)
+	}
+
+	logger.V(3).Info("Tracking specified files, skipping submodules", "file", filename, "submodules", nested)
+	root := filepath.Join(g.RepoDirectory, name)
+	return filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(g.RepoDirectory, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			if d.Name() == gogit.GitDirName || contains(nested, rel) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		return g.Client.AddGlob(rel, w)
+	})
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateSubmodules initializes and updates the submodules at paths, and their own submodules, to the commits
+// the checked out commit points to.
+func (gg *goGit) UpdateSubmodules(ctx context.Context, w *gogit.Worktree, auth transport.AuthMethod, paths []string) error {
+	ctx, cancel := context.WithTimeout(ctx, gitTimeout)
+	defer cancel()
+
+	submodules, err := w.Submodules()
+	if err != nil {
+		return err
+	}
+
+	for _, s := range submodules {
+		if !contains(paths, path.Clean(s.Config().Path)) {
+			continue
+		}
+
+		err = s.UpdateContext(ctx, &gogit.SubmoduleUpdateOptions{
+			Init:              true,
+			Auth:              auth,
+			RecurseSubmodules: gogit.DefaultSubmoduleRecursionDepth,
+		})
+		if err != nil {
+			return fmt.Errorf("updating submodule %s: %v", s.Config().Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package gitclient_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	goGit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/git/gitclient"
+)
+
+const testGitModules = `[submodule "shared"]
+	path = clusters/mgmt/bases/shared
+	url = https://github.com/janedoe/kustomize-bases.git
+[submodule "tools"]
+	path = vendor/tools
+	url = https://github.com/janedoe/tools.git
+`
+
+func writeRepoFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestGoGitCloneWithSubmodules(t *testing.T) {
+	g := NewWithT(t)
+	ctx, client := newGoGitMock(t)
+	dir := t.TempDir()
+	writeRepoFiles(t, dir, map[string]string{".gitmodules": testGitModules})
+	auth := &http.BasicAuth{}
+	r := &goGit.Repository{}
+	w := &goGit.Worktree{}
+
+	c := gitclient.New(
+		gitclient.WithRepositoryDirectory(dir),
+		gitclient.WithRepositoryUrl("testurl"),
+		gitclient.WithAuth(auth),
+	)
+	c.Client = client
+
+	client.EXPECT().Clone(ctx, dir, "testurl", auth, gitclient.CloneOpts{}).Return(r, nil)
+	client.EXPECT().OpenWorktree(r).Return(w, nil)
+	client.EXPECT().UpdateSubmodules(ctx, w, auth, []string{"clusters/mgmt/bases/shared", "vendor/tools"}).Return(nil)
+
+	g.Expect(c.Clone(ctx)).To(Succeed())
+}
+
+func TestGoGitCloneWithSubmodulesSparseCheckout(t *testing.T) {
+	g := NewWithT(t)
+	ctx, client := newGoGitMock(t)
+	dir := t.TempDir()
+	writeRepoFiles(t, dir, map[string]string{".gitmodules": testGitModules})
+	auth := &http.BasicAuth{}
+	r := &goGit.Repository{}
+	w := &goGit.Worktree{}
+	headHash := plumbing.NewHash("3f6e0a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f")
+
+	c := gitclient.New(
+		gitclient.WithRepositoryDirectory(dir),
+		gitclient.WithRepositoryUrl("testurl"),
+		gitclient.WithAuth(auth),
+		gitclient.WithSparseCheckoutDirectories("clusters/mgmt"),
+	)
+	c.Client = client
+
+	client.EXPECT().Clone(ctx, dir, "testurl", auth, gitclient.CloneOpts{NoCheckout: true}).Return(r, nil)
+	client.EXPECT().OpenWorktree(r).Return(w, nil).Times(2)
+	client.EXPECT().Head(r).Return(plumbing.NewHashReference(plumbing.NewBranchReferenceName("main"), headHash), nil)
+	client.EXPECT().SparseCheckout(r, w, headHash, []string{"clusters/mgmt"}).Return(nil)
+	client.EXPECT().UpdateSubmodules(ctx, w, auth, []string{"clusters/mgmt/bases/shared"}).Return(nil)
+
+	g.Expect(c.Clone(ctx)).To(Succeed())
+}
+
+func TestGoGitCloneWithSubmodulesUpdateError(t *testing.T) {
+	g := NewWithT(t)
+	ctx, client := newGoGitMock(t)
+	dir := t.TempDir()
+	writeRepoFiles(t, dir, map[string]string{".gitmodules": testGitModules})
+	r := &goGit.Repository{}
+	w := &goGit.Worktree{}
+
+	c := gitclient.New(gitclient.WithRepositoryDirectory(dir), gitclient.WithRepositoryUrl("testurl"))
+	c.Client = client
+
+	client.EXPECT().Clone(ctx, dir, "testurl", nil, gitclient.CloneOpts{}).Return(r, nil)
+	client.EXPECT().OpenWorktree(r).Return(w, nil)
+	client.EXPECT().UpdateSubmodules(ctx, w, nil, []string{"clusters/mgmt/bases/shared", "vendor/tools"}).Return(errors.New("authentication required"))
+
+	g.Expect(c.Clone(ctx)).To(MatchError("cloning repository: updating submodules: authentication required"))
+}
+
+func TestGoGitAddSkipsSubmodules(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	r, err := goGit.PlainInit(dir, false)
+	g.Expect(err).NotTo(HaveOccurred())
+	writeRepoFiles(t, dir, map[string]string{
+		".gitmodules": testGitModules,
+		"clusters/mgmt/eksa-system/eksa-cluster.yaml":   "name: mgmt",
+		"clusters/mgmt/bases/shared/.git":               "gitdir: ../../../../.git/modules/shared",
+		"clusters/mgmt/bases/shared/kustomization.yaml": "resources: []",
+		"vendor/tools/.git":                             "gitdir: ../../.git/modules/tools",
+		"vendor/tools/README.md":                        "tools",
+	})
+
+	c := gitclient.New(gitclient.WithRepositoryDirectory(dir))
+	g.Expect(c.Add("clusters/mgmt")).To(Succeed())
+	g.Expect(c.Add("clusters/mgmt/bases/shared")).To(Succeed())
+	g.Expect(c.Add("vendor/tools/README.md")).To(Succeed())
+
+	idx, err := r.Storer.Index()
+	g.Expect(err).NotTo(HaveOccurred())
+	var staged []string
+	for _, e := range idx.Entries {
+		staged = append(staged, e.Name)
+	}
+	g.Expect(staged).To(ConsistOf("clusters/mgmt/eksa-system/eksa-cluster.yaml"))
+}
@@ -0,0 +1,129 @@
+package gogithub
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"golang.org/x/oauth2"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	// appJWTExpiration is how long the JWT authenticating as the Github App is valid for, the max allowed is 10 minutes.
+	appJWTExpiration = 5 * time.Minute
+	// installationTokenExpiration is how long an installation token is valid for when Github doesn't return it.
+	installationTokenExpiration = time.Hour
+	// installationTokenRefreshMargin is how long before it expires an installation token is replaced by a new one, so
+	// the operations using it, like a flux bootstrap, have time to complete.
+	installationTokenRefreshMargin = 10 * time.Minute
+)
+
+// AppInstallation is a Github App installation, which authenticates with short-lived installation tokens
+// instead of a personal access token.
+type AppInstallation struct {
+	AppID          int64
+	InstallationID int64
+	// PrivateKey is the PEM encoded private key of the Github App.
+	PrivateKey []byte
+	// Hostname is the Github Enterprise Server host the app is registered in, github.com if empty.
+	Hostname string
+}
+
+// CreateInstallationToken returns a new access token for the Github App installation. The token expires after an hour.
+// httpClient is the client the requests are sent with, http.DefaultClient if nil.
+func CreateInstallationToken(ctx context.Context, installation AppInstallation, httpClient *http.Client) (string, error) {
+	token, err := createInstallationToken(ctx, installation, httpClient)
+	if err != nil {
+		return "", err
+	}
+	return token.AccessToken, nil
+}
+
+func createInstallationToken(ctx context.Context, installation AppInstallation, httpClient *http.Client) (*oauth2.Token, error) {
+	appJWT, err := installation.signJWT(time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("creating github app %d installation token: %v", installation.AppID, err)
+	}
+
+	if httpClient != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, httpClient)
+	}
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: appJWT, TokenType: "Bearer"})
+	client := newGoGithubClient(installation.Hostname, oauth2.NewClient(ctx, ts))
+
+	logger.V(3).Info("Creating Github App installation token", "app", installation.AppID, "installation", installation.InstallationID)
+	token, _, err := client.Apps.CreateInstallationToken(ctx, installation.InstallationID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating github app %d installation token: %v", installation.AppID, err)
+	}
+
+	expiry := token.GetExpiresAt()
+	if expiry.IsZero() {
+		expiry = time.Now().Add(installationTokenExpiration)
+	}
+	return &oauth2.Token{AccessToken: token.GetToken(), Expiry: expiry}, nil
+}
+
+// InstallationTokenSource returns the access tokens of a Github App installation. A token is reused until it's about
+// to expire and a new one is created then, so the git operations and provider requests of long running commands
+// never use an expired token.
+type InstallationTokenSource struct {
+	ctx          context.Context
+	installation AppInstallation
+	httpClient   *http.Client
+	now          func() time.Time
+
+	mu    sync.Mutex
+	token *oauth2.Token
+}
+
+// NewInstallationTokenSource builds an InstallationTokenSource for the Github App installation. httpClient is the
+// client the requests are sent with, http.DefaultClient if nil.
+func NewInstallationTokenSource(ctx context.Context, installation AppInstallation, httpClient *http.Client) *InstallationTokenSource {
+	return &InstallationTokenSource{
+		ctx:          ctx,
+		installation: installation,
+		httpClient:   httpClient,
+		now:          time.Now,
+	}
+}
+
+// Token returns the current installation token, creating a new one if there's none yet or if it expires within
+// the refresh margin.
+func (s *InstallationTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != nil && s.now().Add(installationTokenRefreshMargin).Before(s.token.Expiry) {
+		return s.token, nil
+	}
+
+	token, err := createInstallationToken(s.ctx, s.installation, s.httpClient)
+	if err != nil {
+		return nil, err
+	}
+	s.token = token
+	return token, nil
+}
+
+// signJWT returns the JWT authenticating as the Github App, signed with its private key.
+func (a AppInstallation) signJWT(now time.Time) (string, error) {
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(a.PrivateKey)
+	if err != nil {
+		return "", fmt.Errorf("parsing private key: %v", err)
+	}
+
+	// Issued in the past to allow for clock drift, as recommended by the Github documentation
+	claims := jwt.RegisteredClaims{
+		Issuer:    strconv.FormatInt(a.AppID, 10),
+		IssuedAt:  jwt.NewNumericDate(now.Add(-time.Minute)),
+		ExpiresAt: jwt.NewNumericDate(now.Add(appJWTExpiration)),
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(key)
+}
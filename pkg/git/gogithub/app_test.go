@@ -0,0 +1,205 @@
+package gogithub_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/git/gogithub"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func newAppPrivateKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating private key: %v", err)
+	}
+	return key, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+func TestCreateInstallationToken(t *testing.T) {
+	g := NewWithT(t)
+	key, keyPEM := newAppPrivateKey(t)
+
+	var claims jwt.RegisteredClaims
+	client := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		g.Expect(r.Method).To(Equal("POST"))
+		g.Expect(r.URL.String()).To(Equal("https://api.github.com/app/installations/42/access_tokens"))
+
+		appJWT := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		_, err := jwt.ParseWithClaims(appJWT, &claims, func(*jwt.Token) (interface{}, error) {
+			return &key.PublicKey, nil
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"token": "ghs_installationtoken", "expires_at": "2100-01-01T00:00:00Z"}`)),
+			Request:    r,
+		}, nil
+	})}
+
+	token, err := gogithub.CreateInstallationToken(context.Background(), gogithub.AppInstallation{
+		AppID:          1234,
+		InstallationID: 42,
+		PrivateKey:     keyPEM,
+	}, client)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(token).To(Equal("ghs_installationtoken"))
+	g.Expect(claims.Issuer).To(Equal("1234"))
+}
+
+func TestCreateInstallationTokenEnterpriseServer(t *testing.T) {
+	g := NewWithT(t)
+	_, keyPEM := newAppPrivateKey(t)
+	client := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		g.Expect(r.URL.String()).To(Equal("https://github.example.com/api/v3/app/installations/42/access_tokens"))
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"token": "ghs_installationtoken"}`)),
+			Request:    r,
+		}, nil
+	})}
+
+	token, err := gogithub.CreateInstallationToken(context.Background(), gogithub.AppInstallation{
+		AppID:          1234,
+		InstallationID: 42,
+		PrivateKey:     keyPEM,
+		Hostname:       "github.example.com",
+	}, client)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(token).To(Equal("ghs_installationtoken"))
+}
+
+func TestCreateInstallationTokenInvalidPrivateKey(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := gogithub.CreateInstallationToken(context.Background(), gogithub.AppInstallation{
+		AppID:      1234,
+		PrivateKey: []byte("not a key"),
+	}, nil)
+	g.Expect(err).To(MatchError(ContainSubstring("creating github app 1234 installation token: parsing private key")))
+}
+
+func TestCreateInstallationTokenError(t *testing.T) {
+	g := NewWithT(t)
+	_, keyPEM := newAppPrivateKey(t)
+	client := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       io.NopCloser(strings.NewReader(`{"message": "Not Found"}`)),
+			Request:    r,
+		}, nil
+	})}
+
+	_, err := gogithub.CreateInstallationToken(context.Background(), gogithub.AppInstallation{
+		AppID:          1234,
+		InstallationID: 42,
+		PrivateKey:     keyPEM,
+	}, client)
+	g.Expect(err).To(MatchError(ContainSubstring("creating github app 1234 installation token")))
+	g.Expect(err).To(MatchError(ContainSubstring("404 Not Found")))
+}
+
+func TestGetAccessTokenPermissionsEnterpriseServer(t *testing.T) {
+	g := NewWithT(t)
+	client := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		g.Expect(r.URL.String()).To(Equal("https://github.example.com/api/v3/user"))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"X-Oauth-Scopes": []string{"repo, admin:repo_hook"}},
+			Body:       io.NopCloser(strings.NewReader("")),
+			Request:    r,
+		}, nil
+	})}
+	gh := &gogithub.GoGithub{Opts: gogithub.Options{Hostname: "github.example.com", HTTPClient: client}}
+
+	g.Expect(gh.GetAccessTokenPermissions("token")).To(Equal("repo, admin:repo_hook"))
+}
+
+func TestInstallationTokenSourceReusesToken(t *testing.T) {
+	g := NewWithT(t)
+	_, keyPEM := newAppPrivateKey(t)
+	requests := 0
+	client := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		requests++
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"token": "ghs_installationtoken", "expires_at": "2100-01-01T00:00:00Z"}`)),
+			Request:    r,
+		}, nil
+	})}
+
+	source := gogithub.NewInstallationTokenSource(context.Background(), gogithub.AppInstallation{
+		AppID:          1234,
+		InstallationID: 42,
+		PrivateKey:     keyPEM,
+	}, client)
+	for i := 0; i < 2; i++ {
+		token, err := source.Token()
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(token.AccessToken).To(Equal("ghs_installationtoken"))
+	}
+	g.Expect(requests).To(Equal(1))
+}
+
+func TestInstallationTokenSourceRefreshesExpiringToken(t *testing.T) {
+	g := NewWithT(t)
+	_, keyPEM := newAppPrivateKey(t)
+	requests := 0
+	client := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		requests++
+		expiresAt := time.Now().Add(time.Minute).UTC().Format(time.RFC3339)
+		body := fmt.Sprintf(`{"token": "ghs_installationtoken%d", "expires_at": "%s"}`, requests, expiresAt)
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	})}
+
+	source := gogithub.NewInstallationTokenSource(context.Background(), gogithub.AppInstallation{
+		AppID:          1234,
+		InstallationID: 42,
+		PrivateKey:     keyPEM,
+	}, client)
+	token, err := source.Token()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(token.AccessToken).To(Equal("ghs_installationtoken1"))
+
+	token, err = source.Token()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(token.AccessToken).To(Equal("ghs_installationtoken2"))
+	g.Expect(requests).To(Equal(2))
+}
+
+func TestInstallationTokenSourceError(t *testing.T) {
+	g := NewWithT(t)
+
+	source := gogithub.NewInstallationTokenSource(context.Background(), gogithub.AppInstallation{
+		AppID:      1234,
+		PrivateKey: []byte("not a key"),
+	}, nil)
+	_, err := source.Token()
+	g.Expect(err).To(MatchError(ContainSubstring("creating github app 1234 installation token: parsing private key")))
+}
@@ -16,6 +16,8 @@ import (
 	"github.com/aws/eks-anywhere/pkg/retrier"
 )
 
+const defaultHostname = "github.com"
+
 type GoGithub struct {
 	Opts   Options
 	Client Client
@@ -23,6 +25,13 @@ type GoGithub struct {
 
 type Options struct {
 	Auth git.TokenAuth
+	// HTTPClient is the client the authenticated requests are sent with, http.DefaultClient if nil.
+	HTTPClient *http.Client
+	// Hostname is the Github Enterprise Server host the requests are sent to, github.com if empty.
+	Hostname string
+	// TokenSource returns the token the requests are authenticated with, instead of the Auth token. It's used with
+	// Github App installation tokens, which expire.
+	TokenSource oauth2.TokenSource
 }
 
 func New(ctx context.Context, opts Options) *GoGithub {
@@ -46,6 +55,12 @@ type Client interface {
 		fileContent *goGithub.RepositoryContent, directoryContent []*goGithub.RepositoryContent, resp *goGithub.Response, err error,
 	)
 	DeleteRepo(ctx context.Context, owner, repo string) (*goGithub.Response, error)
+	CreatePullRequest(ctx context.Context, owner, repo string, pull *goGithub.NewPullRequest) (*goGithub.PullRequest, *goGithub.Response, error)
+	CreateHook(ctx context.Context, owner, repo string, hook *goGithub.Hook) (*goGithub.Hook, *goGithub.Response, error)
+	ListHooks(ctx context.Context, owner, repo string, opts *goGithub.ListOptions) ([]*goGithub.Hook, *goGithub.Response, error)
+	EditRepo(ctx context.Context, owner, repo string, repository *goGithub.Repository) (*goGithub.Repository, *goGithub.Response, error)
+	GetBranch(ctx context.Context, owner, repo, branch string) (*goGithub.Branch, *goGithub.Response, error)
+	GetBranchProtection(ctx context.Context, owner, repo, branch string) (*goGithub.Protection, *goGithub.Response, error)
 }
 
 type githubClient struct {
@@ -82,6 +97,30 @@ func (ggc *githubClient) DeleteRepo(ctx context.Context, owner, repo string) (*g
 	return ggc.client.Repositories.Delete(ctx, owner, repo)
 }
 
+func (ggc *githubClient) CreatePullRequest(ctx context.Context, owner, repo string, pull *goGithub.NewPullRequest) (*goGithub.PullRequest, *goGithub.Response, error) {
+	return ggc.client.PullRequests.Create(ctx, owner, repo, pull)
+}
+
+func (ggc *githubClient) CreateHook(ctx context.Context, owner, repo string, hook *goGithub.Hook) (*goGithub.Hook, *goGithub.Response, error) {
+	return ggc.client.Repositories.CreateHook(ctx, owner, repo, hook)
+}
+
+func (ggc *githubClient) ListHooks(ctx context.Context, owner, repo string, opts *goGithub.ListOptions) ([]*goGithub.Hook, *goGithub.Response, error) {
+	return ggc.client.Repositories.ListHooks(ctx, owner, repo, opts)
+}
+
+func (ggc *githubClient) EditRepo(ctx context.Context, owner, repo string, repository *goGithub.Repository) (*goGithub.Repository, *goGithub.Response, error) {
+	return ggc.client.Repositories.Edit(ctx, owner, repo, repository)
+}
+
+func (ggc *githubClient) GetBranch(ctx context.Context, owner, repo, branch string) (*goGithub.Branch, *goGithub.Response, error) {
+	return ggc.client.Repositories.GetBranch(ctx, owner, repo, branch)
+}
+
+func (ggc *githubClient) GetBranchProtection(ctx context.Context, owner, repo, branch string) (*goGithub.Protection, *goGithub.Response, error) {
+	return ggc.client.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+}
+
 func (ggc *githubClient) AddDeployKeyToRepo(ctx context.Context, owner, repo string, key *goGithub.Key) error {
 	_, resp, err := ggc.client.Repositories.CreateKey(ctx, owner, repo, key)
 	if err != nil {
@@ -108,7 +147,11 @@ func (g *GoGithub) CreateRepo(ctx context.Context, opts git.CreateRepoOpts) (rep
 		logger.V(4).Info("Not a personal repository; using repository Owner as Org", "org", org, "owner", opts.Owner)
 	}
 
-	repo, _, err := g.Client.CreateRepo(ctx, org, r)
+	var repo *goGithub.Repository
+	err = retryOnRateLimit(ctx, func() (err error) {
+		repo, _, err = g.Client.CreateRepo(ctx, org, r)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new Github repo %s: %v", opts.Name, err)
 	}
@@ -122,16 +165,21 @@ func (g *GoGithub) CreateRepo(ctx context.Context, opts git.CreateRepoOpts) (rep
 }
 
 func (g *GoGithub) GetAccessTokenPermissions(accessToken string) (string, error) {
-	req, err := http.NewRequest("HEAD", "https://api.github.com/users/codertocat", nil)
+	req, err := http.NewRequest("HEAD", accessTokenPermissionsURL(g.Opts.Hostname), nil)
 	if err != nil {
 		return "", err
 	}
 	req.Header.Set("Authorization", "token "+accessToken)
 
+	var httpClient HTTPClient = HttpClient
+	if g.Opts.HTTPClient != nil {
+		httpClient = g.Opts.HTTPClient
+	}
+
 	var resp *http.Response
 	r := retrier.New(3 * time.Minute)
 	err = r.Retry(func() error {
-		resp, err = HttpClient.Do(req)
+		resp, err = httpClient.Do(req)
 		if err != nil {
 			return fmt.Errorf("getting Github Personal Access Token permissions %v", err)
 		}
@@ -158,11 +206,16 @@ func (g *GoGithub) CheckAccessTokenPermissions(checkPATPermission string, allPer
 
 // GetRepo describes a remote repository, return the repo name if it exists.
 // If the repo does not exist, resulting in a 404 exception, it returns a `RepoDoesNotExist` error.
+// Requests rejected by a rate limit are retried once the limit allows it.
 func (g *GoGithub) GetRepo(ctx context.Context, opts git.GetRepoOpts) (*git.Repository, error) {
 	r := opts.Repository
 	o := opts.Owner
 	logger.V(3).Info("Describing Github repository", "name", r, "owner", o)
-	repo, _, err := g.Client.Repo(ctx, o, r)
+	var repo *goGithub.Repository
+	err := retryOnRateLimit(ctx, func() (err error) {
+		repo, _, err = g.Client.Repo(ctx, o, r)
+		return err
+	})
 	if err != nil {
 		if isNotFound(err) {
 			return nil, &git.RepositoryDoesNotExistError{Err: err}
@@ -177,8 +230,62 @@ func (g *GoGithub) GetRepo(ctx context.Context, opts git.GetRepoOpts) (*git.Repo
 	}, err
 }
 
+// RepoPermissions returns the permissions of the authenticated user or app on a repository, such as admin or push.
+// If the repo does not exist, it returns a `RepositoryDoesNotExistError`.
+func (g *GoGithub) RepoPermissions(ctx context.Context, owner, repo string) (map[string]bool, error) {
+	var r *goGithub.Repository
+	err := retryOnRateLimit(ctx, func() (err error) {
+		r, _, err = g.Client.Repo(ctx, owner, repo)
+		return err
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, &git.RepositoryDoesNotExistError{Err: err}
+		}
+		return nil, fmt.Errorf("getting permissions on repository %s: %v", repo, err)
+	}
+	return r.GetPermissions(), nil
+}
+
+// BranchProtected returns true if the branch has protection rules. It returns false if the branch doesn't exist.
+func (g *GoGithub) BranchProtected(ctx context.Context, owner, repo, branch string) (bool, error) {
+	var b *goGithub.Branch
+	err := retryOnRateLimit(ctx, func() (err error) {
+		b, _, err = g.Client.GetBranch(ctx, owner, repo, branch)
+		return err
+	})
+	if isNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("getting branch %s of repository %s: %v", branch, repo, err)
+	}
+	return b.GetProtected(), nil
+}
+
+// BranchProtection returns the protection rules of the branch, or nil if the branch isn't protected or doesn't exist.
+// Reading the protection rules requires admin permissions on the repository.
+func (g *GoGithub) BranchProtection(ctx context.Context, owner, repo, branch string) (*goGithub.Protection, error) {
+	var p *goGithub.Protection
+	err := retryOnRateLimit(ctx, func() (err error) {
+		p, _, err = g.Client.GetBranchProtection(ctx, owner, repo, branch)
+		return err
+	})
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting protection of branch %s of repository %s: %v", branch, repo, err)
+	}
+	return p, nil
+}
+
 func (g *GoGithub) AuthenticatedUser(ctx context.Context) (*goGithub.User, error) {
-	githubUser, _, err := g.Client.User(ctx, "") // passing the empty string will fetch the authenticated
+	var githubUser *goGithub.User
+	err := retryOnRateLimit(ctx, func() (err error) {
+		githubUser, _, err = g.Client.User(ctx, "") // passing the empty string will fetch the authenticated
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed while getting the authenticated github user %v", err)
 	}
@@ -186,7 +293,11 @@ func (g *GoGithub) AuthenticatedUser(ctx context.Context) (*goGithub.User, error
 }
 
 func (g *GoGithub) Organization(ctx context.Context, org string) (*goGithub.Organization, error) {
-	organization, _, err := g.Client.Organization(ctx, org)
+	var organization *goGithub.Organization
+	err := retryOnRateLimit(ctx, func() (err error) {
+		organization, _, err = g.Client.Organization(ctx, org)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed while getting github organization %s details %v", org, err)
 	}
@@ -194,15 +305,18 @@ func (g *GoGithub) Organization(ctx context.Context, org string) (*goGithub.Orga
 }
 
 // PathExists checks if a path exists in the remote repository. If the owner, repository or branch doesn't exist,
-// it returns false and no error.
+// it returns false and no error. Requests rejected by a rate limit are retried once the limit allows it.
 func (g *GoGithub) PathExists(ctx context.Context, owner, repo, branch, path string) (bool, error) {
-	_, _, _, err := g.Client.GetContents(
-		ctx,
-		owner,
-		repo,
-		path,
-		&goGithub.RepositoryContentGetOptions{Ref: branch},
-	)
+	err := retryOnRateLimit(ctx, func() (err error) {
+		_, _, _, err = g.Client.GetContents(
+			ctx,
+			owner,
+			repo,
+			path,
+			&goGithub.RepositoryContentGetOptions{Ref: branch},
+		)
+		return err
+	})
 
 	if isNotFound(err) {
 		return false, nil
@@ -237,10 +351,119 @@ func (g *GoGithub) DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts) erro
 	return nil
 }
 
+// ArchiveRepo archives a Github repository, making it read-only.
+func (g *GoGithub) ArchiveRepo(ctx context.Context, opts git.ArchiveRepoOpts) error {
+	r := opts.Repository
+	o := opts.Owner
+	logger.V(3).Info("Archiving Github repository", "name", r, "owner", o)
+	if _, _, err := g.Client.EditRepo(ctx, o, r, &goGithub.Repository{Archived: goGithub.Bool(true)}); err != nil {
+		return fmt.Errorf("archiving repository %s: %v", r, err)
+	}
+	return nil
+}
+
+// CreatePullRequest opens a pull request in a Github repository.
+func (g *GoGithub) CreatePullRequest(ctx context.Context, owner, repo string, opts git.CreatePullRequestOpts) (*git.PullRequest, error) {
+	logger.V(3).Info("Creating Github pull request", "repository", repo, "owner", owner, "head", opts.Head, "base", opts.Base)
+	p := &goGithub.NewPullRequest{
+		Title: &opts.Title,
+		Body:  &opts.Description,
+		Head:  &opts.Head,
+		Base:  &opts.Base,
+	}
+	pr, _, err := g.Client.CreatePullRequest(ctx, owner, repo, p)
+	if err != nil {
+		return nil, fmt.Errorf("creating pull request in repository %s: %v", repo, err)
+	}
+	return &git.PullRequest{
+		Number: pr.GetNumber(),
+		Url:    pr.GetHTMLURL(),
+	}, nil
+}
+
+// CreateWebhook registers a webhook in a Github repository, which sends json payloads signed with the secret.
+func (g *GoGithub) CreateWebhook(ctx context.Context, owner, repo string, opts git.CreateWebhookOpts) error {
+	logger.V(3).Info("Creating Github webhook", "repository", repo, "owner", owner, "events", opts.Events)
+	h := &goGithub.Hook{
+		Events: opts.Events,
+		Active: goGithub.Bool(true),
+		Config: map[string]interface{}{
+			"url":          opts.Url,
+			"content_type": "json",
+			"secret":       opts.Secret,
+		},
+	}
+	if _, _, err := g.Client.CreateHook(ctx, owner, repo, h); err != nil {
+		return fmt.Errorf("creating webhook in repository %s: %v", repo, err)
+	}
+	return nil
+}
+
+// WebhookExists checks if a webhook sending to the url is registered in a Github repository.
+func (g *GoGithub) WebhookExists(ctx context.Context, owner, repo, url string) (bool, error) {
+	opts := &goGithub.ListOptions{PerPage: 100}
+	for {
+		hooks, resp, err := g.Client.ListHooks(ctx, owner, repo, opts)
+		if err != nil {
+			return false, fmt.Errorf("listing webhooks in repository %s: %v", repo, err)
+		}
+		for _, h := range hooks {
+			if hookURL, ok := h.Config["url"].(string); ok && hookURL == url {
+				return true, nil
+			}
+		}
+		if resp == nil || resp.NextPage == 0 {
+			return false, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
 func newClient(ctx context.Context, opts Options) Client {
-	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: opts.Auth.Token})
+	if opts.HTTPClient != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, opts.HTTPClient)
+	}
+	ts := opts.TokenSource
+	if ts == nil {
+		ts = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: opts.Auth.Token})
+	}
 	tc := oauth2.NewClient(ctx, ts)
-	return &githubClient{goGithub.NewClient(tc)}
+	return &githubClient{newGoGithubClient(opts.Hostname, tc)}
+}
+
+// newGoGithubClient returns a client for the Github Enterprise Server API at hostname, or for github.com if empty.
+func newGoGithubClient(hostname string, httpClient *http.Client) *goGithub.Client {
+	if !isEnterprise(hostname) {
+		return goGithub.NewClient(httpClient)
+	}
+
+	// NewEnterpriseClient only fails parsing the urls, which are always valid for a valid hostname
+	client, err := goGithub.NewEnterpriseClient(enterpriseAPIURL(hostname), enterpriseUploadURL(hostname), httpClient)
+	if err != nil {
+		logger.V(3).Info("Invalid Github Enterprise Server hostname, using github.com", "hostname", hostname, "error", err)
+		return goGithub.NewClient(httpClient)
+	}
+	return client
+}
+
+func isEnterprise(hostname string) bool {
+	return hostname != "" && hostname != defaultHostname
+}
+
+func enterpriseAPIURL(hostname string) string {
+	return fmt.Sprintf("https://%s/api/v3/", hostname)
+}
+
+func enterpriseUploadURL(hostname string) string {
+	return fmt.Sprintf("https://%s/api/uploads/", hostname)
+}
+
+// accessTokenPermissionsURL returns the url of a request returning the scopes of the access token in its headers.
+func accessTokenPermissionsURL(hostname string) string {
+	if !isEnterprise(hostname) {
+		return "https://api.github.com/users/codertocat"
+	}
+	return enterpriseAPIURL(hostname) + "user"
 }
 
 func isNotFound(err error) bool {
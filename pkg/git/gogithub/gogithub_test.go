@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-github/v35/github"
@@ -445,6 +446,213 @@ func TestPathExistsItDoesNot(t *testing.T) {
 	tt.Expect(tt.g.PathExists(tt.ctx, owner, repo, branch, path)).To(BeTrue())
 }
 
+func TestPathExistsSecondaryRateLimit(t *testing.T) {
+	tt := newTest(t)
+	owner, repo, branch, path := pathArgs()
+	retryAfter := time.Millisecond
+	gomock.InOrder(
+		tt.client.EXPECT().GetContents(
+			tt.ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: branch},
+		).Return(nil, nil, nil, &github.AbuseRateLimitError{Response: forbiddenResponse(), RetryAfter: &retryAfter}),
+		tt.client.EXPECT().GetContents(
+			tt.ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: branch},
+		).Return(nil, nil, nil, nil),
+	)
+
+	tt.Expect(tt.g.PathExists(tt.ctx, owner, repo, branch, path)).To(BeTrue())
+}
+
+func TestGetRepoPrimaryRateLimit(t *testing.T) {
+	tt := newTest(t)
+	reset := github.Rate{Limit: 5000, Reset: github.Timestamp{Time: time.Now()}}
+	gomock.InOrder(
+		tt.client.EXPECT().Repo(tt.ctx, "owner1", "repo1").Return(nil, nil, &github.RateLimitError{Rate: reset, Response: forbiddenResponse()}),
+		tt.client.EXPECT().Repo(tt.ctx, "owner1", "repo1").Return(&github.Repository{Name: github.String("repo1")}, nil, nil),
+	)
+
+	repo, err := tt.g.GetRepo(tt.ctx, git.GetRepoOpts{Owner: "owner1", Repository: "repo1"})
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(repo.Name).To(Equal("repo1"))
+}
+
+func TestGetRepoPrimaryRateLimitResetTooLate(t *testing.T) {
+	tt := newTest(t)
+	reset := github.Rate{Limit: 5000, Reset: github.Timestamp{Time: time.Now().Add(time.Hour)}}
+	tt.client.EXPECT().Repo(tt.ctx, "owner1", "repo1").Return(nil, nil, &github.RateLimitError{Rate: reset, Response: forbiddenResponse()})
+
+	_, err := tt.g.GetRepo(tt.ctx, git.GetRepoOpts{Owner: "owner1", Repository: "repo1"})
+	tt.Expect(err).To(MatchError(ContainSubstring("more than the max wait of 15m0s")))
+}
+
+func TestOrganizationSecondaryRateLimitRetriesExhausted(t *testing.T) {
+	tt := newTest(t)
+	retryAfter := time.Millisecond
+	tt.client.EXPECT().Organization(tt.ctx, "org1").Return(nil, nil, &github.AbuseRateLimitError{Response: forbiddenResponse(), RetryAfter: &retryAfter}).Times(6)
+
+	_, err := tt.g.Organization(tt.ctx, "org1")
+	tt.Expect(err).To(MatchError(ContainSubstring("failed while getting github organization org1 details")))
+}
+
+func TestAuthenticatedUserRateLimitContextCanceled(t *testing.T) {
+	tt := newTest(t)
+	ctx, cancel := context.WithCancel(tt.ctx)
+	cancel()
+	retryAfter := time.Minute
+	tt.client.EXPECT().User(ctx, "").Return(nil, nil, &github.AbuseRateLimitError{Response: forbiddenResponse(), RetryAfter: &retryAfter})
+
+	_, err := tt.g.AuthenticatedUser(ctx)
+	tt.Expect(err).To(MatchError(ContainSubstring("waiting for github rate limit: context canceled")))
+}
+
+func TestRepoPermissionsSuccess(t *testing.T) {
+	tt := newTest(t)
+	tt.client.EXPECT().Repo(tt.ctx, "owner1", "repo1").Return(&github.Repository{Permissions: map[string]bool{"admin": true, "push": true}}, nil, nil)
+
+	tt.Expect(tt.g.RepoPermissions(tt.ctx, "owner1", "repo1")).To(Equal(map[string]bool{"admin": true, "push": true}))
+}
+
+func TestRepoPermissionsNotFound(t *testing.T) {
+	tt := newTest(t)
+	tt.client.EXPECT().Repo(tt.ctx, "owner1", "repo1").Return(nil, nil, notFoundError())
+
+	_, err := tt.g.RepoPermissions(tt.ctx, "owner1", "repo1")
+	var notExist *git.RepositoryDoesNotExistError
+	tt.Expect(errors.As(err, &notExist)).To(BeTrue())
+}
+
+func TestBranchProtected(t *testing.T) {
+	tt := newTest(t)
+	tt.client.EXPECT().GetBranch(tt.ctx, "owner1", "repo1", "main").Return(&github.Branch{Protected: github.Bool(true)}, nil, nil)
+
+	tt.Expect(tt.g.BranchProtected(tt.ctx, "owner1", "repo1", "main")).To(BeTrue())
+}
+
+func TestBranchProtectedNotFound(t *testing.T) {
+	tt := newTest(t)
+	tt.client.EXPECT().GetBranch(tt.ctx, "owner1", "repo1", "main").Return(nil, nil, notFoundError())
+
+	tt.Expect(tt.g.BranchProtected(tt.ctx, "owner1", "repo1", "main")).To(BeFalse())
+}
+
+func TestBranchProtectionSuccess(t *testing.T) {
+	tt := newTest(t)
+	protection := &github.Protection{EnforceAdmins: &github.AdminEnforcement{Enabled: true}}
+	tt.client.EXPECT().GetBranchProtection(tt.ctx, "owner1", "repo1", "main").Return(protection, nil, nil)
+
+	tt.Expect(tt.g.BranchProtection(tt.ctx, "owner1", "repo1", "main")).To(Equal(protection))
+}
+
+func TestBranchProtectionNotProtected(t *testing.T) {
+	tt := newTest(t)
+	tt.client.EXPECT().GetBranchProtection(tt.ctx, "owner1", "repo1", "main").Return(nil, nil, notFoundError())
+
+	tt.Expect(tt.g.BranchProtection(tt.ctx, "owner1", "repo1", "main")).To(BeNil())
+}
+
+func TestBranchProtectionError(t *testing.T) {
+	tt := newTest(t)
+	tt.client.EXPECT().GetBranchProtection(tt.ctx, "owner1", "repo1", "main").Return(nil, nil, errors.New("server error"))
+
+	_, err := tt.g.BranchProtection(tt.ctx, "owner1", "repo1", "main")
+	tt.Expect(err).To(MatchError("getting protection of branch main of repository repo1: server error"))
+}
+
+func TestCreatePullRequestSuccess(t *testing.T) {
+	tt := newTest(t)
+	opts := git.CreatePullRequestOpts{Title: "Update cluster config", Description: "desc", Head: "eksa/mgmt", Base: "main"}
+	tt.client.EXPECT().CreatePullRequest(tt.ctx, "owner1", "repo1", &github.NewPullRequest{
+		Title: &opts.Title,
+		Body:  &opts.Description,
+		Head:  &opts.Head,
+		Base:  &opts.Base,
+	}).Return(&github.PullRequest{Number: github.Int(4), HTMLURL: github.String("https://github.com/owner1/repo1/pull/4")}, nil, nil)
+
+	tt.Expect(tt.g.CreatePullRequest(tt.ctx, "owner1", "repo1", opts)).To(Equal(&git.PullRequest{
+		Number: 4,
+		Url:    "https://github.com/owner1/repo1/pull/4",
+	}))
+}
+
+func TestCreatePullRequestError(t *testing.T) {
+	tt := newTest(t)
+	tt.client.EXPECT().CreatePullRequest(tt.ctx, "owner1", "repo1", gomock.Any()).Return(nil, nil, errors.New("validation failed"))
+
+	_, err := tt.g.CreatePullRequest(tt.ctx, "owner1", "repo1", git.CreatePullRequestOpts{Head: "eksa/mgmt", Base: "main"})
+	tt.Expect(err).To(MatchError(ContainSubstring("creating pull request in repository repo1: validation failed")))
+}
+
+func TestCreateWebhookSuccess(t *testing.T) {
+	tt := newTest(t)
+	opts := git.CreateWebhookOpts{Url: "https://flux.example.com/hook/abc", Secret: "token", Events: []string{"push"}}
+	tt.client.EXPECT().CreateHook(tt.ctx, "owner1", "repo1", &github.Hook{
+		Events: []string{"push"},
+		Active: github.Bool(true),
+		Config: map[string]interface{}{
+			"url":          "https://flux.example.com/hook/abc",
+			"content_type": "json",
+			"secret":       "token",
+		},
+	}).Return(&github.Hook{}, nil, nil)
+
+	tt.Expect(tt.g.CreateWebhook(tt.ctx, "owner1", "repo1", opts)).To(Succeed())
+}
+
+func TestCreateWebhookError(t *testing.T) {
+	tt := newTest(t)
+	tt.client.EXPECT().CreateHook(tt.ctx, "owner1", "repo1", gomock.Any()).Return(nil, nil, errors.New("hook already exists"))
+
+	err := tt.g.CreateWebhook(tt.ctx, "owner1", "repo1", git.CreateWebhookOpts{Url: "https://flux.example.com/hook/abc"})
+	tt.Expect(err).To(MatchError(ContainSubstring("creating webhook in repository repo1: hook already exists")))
+}
+
+func TestWebhookExists(t *testing.T) {
+	tt := newTest(t)
+	url := "https://flux.example.com/hook/abc"
+	tt.client.EXPECT().ListHooks(tt.ctx, "owner1", "repo1", &github.ListOptions{PerPage: 100}).Return(
+		[]*github.Hook{{Config: map[string]interface{}{"url": "https://other.example.com"}}},
+		&github.Response{NextPage: 2}, nil,
+	)
+	tt.client.EXPECT().ListHooks(tt.ctx, "owner1", "repo1", &github.ListOptions{Page: 2, PerPage: 100}).Return(
+		[]*github.Hook{{Config: map[string]interface{}{"url": url}}},
+		&github.Response{}, nil,
+	)
+
+	tt.Expect(tt.g.WebhookExists(tt.ctx, "owner1", "repo1", url)).To(BeTrue())
+}
+
+func TestWebhookExistsNotFound(t *testing.T) {
+	tt := newTest(t)
+	tt.client.EXPECT().ListHooks(tt.ctx, "owner1", "repo1", gomock.Any()).Return(
+		[]*github.Hook{{Config: map[string]interface{}{"url": "https://other.example.com"}}}, &github.Response{}, nil,
+	)
+
+	tt.Expect(tt.g.WebhookExists(tt.ctx, "owner1", "repo1", "https://flux.example.com/hook/abc")).To(BeFalse())
+}
+
+func TestWebhookExistsError(t *testing.T) {
+	tt := newTest(t)
+	tt.client.EXPECT().ListHooks(tt.ctx, "owner1", "repo1", gomock.Any()).Return(nil, nil, errors.New("forbidden"))
+
+	_, err := tt.g.WebhookExists(tt.ctx, "owner1", "repo1", "https://flux.example.com/hook/abc")
+	tt.Expect(err).To(MatchError(ContainSubstring("listing webhooks in repository repo1: forbidden")))
+}
+
+func TestArchiveRepoSuccess(t *testing.T) {
+	tt := newTest(t)
+	opts := git.ArchiveRepoOpts{Owner: "owner1", Repository: "repo1"}
+	tt.client.EXPECT().EditRepo(tt.ctx, "owner1", "repo1", &github.Repository{Archived: github.Bool(true)}).Return(&github.Repository{}, nil, nil)
+
+	tt.Expect(tt.g.ArchiveRepo(tt.ctx, opts)).To(Succeed())
+}
+
+func TestArchiveRepoError(t *testing.T) {
+	tt := newTest(t)
+	opts := git.ArchiveRepoOpts{Owner: "owner1", Repository: "repo1"}
+	tt.client.EXPECT().EditRepo(tt.ctx, "owner1", "repo1", gomock.Any()).Return(nil, nil, errors.New("forbidden"))
+
+	tt.Expect(tt.g.ArchiveRepo(tt.ctx, opts)).To(MatchError(ContainSubstring("archiving repository repo1: forbidden")))
+}
+
 type gogithubTest struct {
 	*WithT
 	g      *gogithub.GoGithub
@@ -500,3 +708,13 @@ func notFoundError() error {
 		},
 	}
 }
+
+func forbiddenResponse() *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusForbidden,
+		Request: &http.Request{
+			Method: "GET",
+			URL:    &url.URL{},
+		},
+	}
+}
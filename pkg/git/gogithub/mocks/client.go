@@ -49,6 +49,38 @@ func (mr *MockClientMockRecorder) AddDeployKeyToRepo(arg0, arg1, arg2, arg3 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDeployKeyToRepo", reflect.TypeOf((*MockClient)(nil).AddDeployKeyToRepo), arg0, arg1, arg2, arg3)
 }
 
+// CreateHook mocks base method.
+func (m *MockClient) CreateHook(arg0 context.Context, arg1, arg2 string, arg3 *github.Hook) (*github.Hook, *github.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHook", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*github.Hook)
+	ret1, _ := ret[1].(*github.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateHook indicates an expected call of CreateHook.
+func (mr *MockClientMockRecorder) CreateHook(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHook", reflect.TypeOf((*MockClient)(nil).CreateHook), arg0, arg1, arg2, arg3)
+}
+
+// CreatePullRequest mocks base method.
+func (m *MockClient) CreatePullRequest(arg0 context.Context, arg1, arg2 string, arg3 *github.NewPullRequest) (*github.PullRequest, *github.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePullRequest", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*github.PullRequest)
+	ret1, _ := ret[1].(*github.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreatePullRequest indicates an expected call of CreatePullRequest.
+func (mr *MockClientMockRecorder) CreatePullRequest(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePullRequest", reflect.TypeOf((*MockClient)(nil).CreatePullRequest), arg0, arg1, arg2, arg3)
+}
+
 // CreateRepo mocks base method.
 func (m *MockClient) CreateRepo(arg0 context.Context, arg1 string, arg2 *github.Repository) (*github.Repository, *github.Response, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +112,54 @@ func (mr *MockClientMockRecorder) DeleteRepo(arg0, arg1, arg2 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRepo", reflect.TypeOf((*MockClient)(nil).DeleteRepo), arg0, arg1, arg2)
 }
 
+// EditRepo mocks base method.
+func (m *MockClient) EditRepo(arg0 context.Context, arg1, arg2 string, arg3 *github.Repository) (*github.Repository, *github.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditRepo", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*github.Repository)
+	ret1, _ := ret[1].(*github.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// EditRepo indicates an expected call of EditRepo.
+func (mr *MockClientMockRecorder) EditRepo(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditRepo", reflect.TypeOf((*MockClient)(nil).EditRepo), arg0, arg1, arg2, arg3)
+}
+
+// GetBranch mocks base method.
+func (m *MockClient) GetBranch(arg0 context.Context, arg1, arg2, arg3 string) (*github.Branch, *github.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBranch", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*github.Branch)
+	ret1, _ := ret[1].(*github.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetBranch indicates an expected call of GetBranch.
+func (mr *MockClientMockRecorder) GetBranch(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBranch", reflect.TypeOf((*MockClient)(nil).GetBranch), arg0, arg1, arg2, arg3)
+}
+
+// GetBranchProtection mocks base method.
+func (m *MockClient) GetBranchProtection(arg0 context.Context, arg1, arg2, arg3 string) (*github.Protection, *github.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBranchProtection", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*github.Protection)
+	ret1, _ := ret[1].(*github.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetBranchProtection indicates an expected call of GetBranchProtection.
+func (mr *MockClientMockRecorder) GetBranchProtection(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBranchProtection", reflect.TypeOf((*MockClient)(nil).GetBranchProtection), arg0, arg1, arg2, arg3)
+}
+
 // GetContents mocks base method.
 func (m *MockClient) GetContents(arg0 context.Context, arg1, arg2, arg3 string, arg4 *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
 	m.ctrl.T.Helper()
@@ -97,6 +177,22 @@ func (mr *MockClientMockRecorder) GetContents(arg0, arg1, arg2, arg3, arg4 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContents", reflect.TypeOf((*MockClient)(nil).GetContents), arg0, arg1, arg2, arg3, arg4)
 }
 
+// ListHooks mocks base method.
+func (m *MockClient) ListHooks(arg0 context.Context, arg1, arg2 string, arg3 *github.ListOptions) ([]*github.Hook, *github.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHooks", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*github.Hook)
+	ret1, _ := ret[1].(*github.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListHooks indicates an expected call of ListHooks.
+func (mr *MockClientMockRecorder) ListHooks(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHooks", reflect.TypeOf((*MockClient)(nil).ListHooks), arg0, arg1, arg2, arg3)
+}
+
 // Organization mocks base method.
 func (m *MockClient) Organization(arg0 context.Context, arg1 string) (*github.Organization, *github.Response, error) {
 	m.ctrl.T.Helper()
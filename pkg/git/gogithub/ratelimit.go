@@ -0,0 +1,67 @@
+package gogithub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	goGithub "github.com/google/go-github/v35/github"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	// maxRateLimitRetries is the number of times a request rejected by a rate limit is retried.
+	maxRateLimitRetries = 5
+	// maxRateLimitWait caps the wait before retrying, so a reset far in the future fails instead of hanging.
+	maxRateLimitWait = 15 * time.Minute
+	// defaultSecondaryRateLimitWait is the wait when a secondary rate limit doesn't return a Retry-After header,
+	// as recommended by the Github documentation.
+	defaultSecondaryRateLimitWait = time.Minute
+)
+
+// rateLimitWait returns how long to wait before retrying a request that failed because of a rate limit.
+// Primary rate limits are retried once the limit resets, while secondary (abuse detection) limits are
+// retried after the Retry-After header, or a minute if not set.
+func rateLimitWait(err error) (time.Duration, bool) {
+	var primary *goGithub.RateLimitError
+	if errors.As(err, &primary) {
+		wait := time.Until(primary.Rate.Reset.Time)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	var secondary *goGithub.AbuseRateLimitError
+	if errors.As(err, &secondary) {
+		if secondary.RetryAfter != nil {
+			return *secondary.RetryAfter, true
+		}
+		return defaultSecondaryRateLimitWait, true
+	}
+
+	return 0, false
+}
+
+// retryOnRateLimit runs request, sleeping and retrying it while it's rejected by a Github rate limit.
+func retryOnRateLimit(ctx context.Context, request func() error) error {
+	for retries := 0; ; retries++ {
+		err := request()
+		wait, limited := rateLimitWait(err)
+		if !limited || retries == maxRateLimitRetries {
+			return err
+		}
+		if wait > maxRateLimitWait {
+			return fmt.Errorf("github rate limit resets in %v, more than the max wait of %v: %w", wait.Round(time.Second), maxRateLimitWait, err)
+		}
+
+		logger.Info("Github API rate limit exceeded, waiting before retrying", "wait", wait.Round(time.Second))
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for github rate limit: %v", ctx.Err())
+		case <-time.After(wait):
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// NewKnownHostsCallback returns a host key callback verifying the ssh host keys against the known_hosts file.
+// Unknown hosts and mismatched host keys fail with an error naming the host and the fingerprint of the offered key,
+// instead of the opaque error of the ssh handshake.
+func NewKnownHostsCallback(knownHostsFile string) (ssh.HostKeyCallback, error) {
+	callback, err := knownhosts.New(knownHostsFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading known hosts file %s: %v", knownHostsFile, err)
+	}
+
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		err := callback(hostname, remote, key)
+		var keyErr *knownhosts.KeyError
+		if !errors.As(err, &keyErr) {
+			return err
+		}
+
+		fingerprint := ssh.FingerprintSHA256(key)
+		if len(keyErr.Want) == 0 {
+			return fmt.Errorf("host %s is not in the known hosts, add its %s host key with fingerprint %s to them", hostname, key.Type(), fingerprint)
+		}
+
+		want := make([]string, 0, len(keyErr.Want))
+		for _, k := range keyErr.Want {
+			want = append(want, fmt.Sprintf("%s %s", k.Key.Type(), ssh.FingerprintSHA256(k.Key)))
+		}
+		return fmt.Errorf("host key mismatch for %s: got %s host key with fingerprint %s, the known hosts pin %v", hostname, key.Type(), fingerprint, want)
+	}, nil
+}
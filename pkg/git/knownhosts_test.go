@@ -0,0 +1,87 @@
+package git_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/aws/eks-anywhere/pkg/git"
+)
+
+func newHostKey(t *testing.T) ssh.PublicKey {
+	t.Helper()
+	public, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ssh.NewPublicKey(public)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestNewKnownHostsCallback(t *testing.T) {
+	pinned := newHostKey(t)
+	other := newHostKey(t)
+	remote := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 22}
+
+	knownHostsFile := filepath.Join(t.TempDir(), "known_hosts")
+	line := knownhosts.Line([]string{"git.example.com"}, pinned) + "\n"
+	if err := os.WriteFile(knownHostsFile, []byte(line), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		testName string
+		hostname string
+		key      ssh.PublicKey
+		wantErr  string
+	}{
+		{
+			testName: "pinned key",
+			hostname: "git.example.com:22",
+			key:      pinned,
+		},
+		{
+			testName: "mismatched key",
+			hostname: "git.example.com:22",
+			key:      other,
+			wantErr:  "host key mismatch for git.example.com:22: got ssh-ed25519 host key with fingerprint " + ssh.FingerprintSHA256(other) + ", the known hosts pin [ssh-ed25519 " + ssh.FingerprintSHA256(pinned) + "]",
+		},
+		{
+			testName: "unknown host",
+			hostname: "other.example.com:22",
+			key:      pinned,
+			wantErr:  "host other.example.com:22 is not in the known hosts, add its ssh-ed25519 host key with fingerprint " + ssh.FingerprintSHA256(pinned) + " to them",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			callback, err := git.NewKnownHostsCallback(knownHostsFile)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			err = callback(tt.hostname, remote, tt.key)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(tt.wantErr))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func TestNewKnownHostsCallbackMissingFile(t *testing.T) {
+	g := NewWithT(t)
+	_, err := git.NewKnownHostsCallback(filepath.Join(t.TempDir(), "missing"))
+	g.Expect(err).To(MatchError(ContainSubstring("reading known hosts file")))
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/aws/eks-anywhere/pkg/git (interfaces: Client,ProviderClient)
+// Source: github.com/aws/eks-anywhere/pkg/git (interfaces: Client,ProviderClient,WebhookProviderClient,ArchiveRepoProviderClient,PermissionsProviderClient,PushAccessProviderClient,BranchProtectionProviderClient)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -49,6 +49,20 @@ func (mr *MockClientMockRecorder) Add(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockClient)(nil).Add), arg0)
 }
 
+// AmendCommit mocks base method.
+func (m *MockClient) AmendCommit(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AmendCommit", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AmendCommit indicates an expected call of AmendCommit.
+func (mr *MockClientMockRecorder) AmendCommit(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AmendCommit", reflect.TypeOf((*MockClient)(nil).AmendCommit), arg0)
+}
+
 // Branch mocks base method.
 func (m *MockClient) Branch(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -91,6 +105,48 @@ func (mr *MockClientMockRecorder) Commit(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockClient)(nil).Commit), arg0)
 }
 
+// DeleteRemoteBranch mocks base method.
+func (m *MockClient) DeleteRemoteBranch(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRemoteBranch", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRemoteBranch indicates an expected call of DeleteRemoteBranch.
+func (mr *MockClientMockRecorder) DeleteRemoteBranch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRemoteBranch", reflect.TypeOf((*MockClient)(nil).DeleteRemoteBranch), arg0, arg1)
+}
+
+// FetchAndReset mocks base method.
+func (m *MockClient) FetchAndReset(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchAndReset", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FetchAndReset indicates an expected call of FetchAndReset.
+func (mr *MockClientMockRecorder) FetchAndReset(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAndReset", reflect.TypeOf((*MockClient)(nil).FetchAndReset), arg0, arg1)
+}
+
+// ForcePush mocks base method.
+func (m *MockClient) ForcePush(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForcePush", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForcePush indicates an expected call of ForcePush.
+func (mr *MockClientMockRecorder) ForcePush(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForcePush", reflect.TypeOf((*MockClient)(nil).ForcePush), arg0, arg1)
+}
+
 // Init mocks base method.
 func (m *MockClient) Init() error {
 	m.ctrl.T.Helper()
@@ -105,6 +161,36 @@ func (mr *MockClientMockRecorder) Init() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Init", reflect.TypeOf((*MockClient)(nil).Init))
 }
 
+// LastCommit mocks base method.
+func (m *MockClient) LastCommit() (*git.Commit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastCommit")
+	ret0, _ := ret[0].(*git.Commit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LastCommit indicates an expected call of LastCommit.
+func (mr *MockClientMockRecorder) LastCommit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastCommit", reflect.TypeOf((*MockClient)(nil).LastCommit))
+}
+
+// LocalChanges mocks base method.
+func (m *MockClient) LocalChanges(arg0 context.Context, arg1 string) (*git.LocalChanges, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LocalChanges", arg0, arg1)
+	ret0, _ := ret[0].(*git.LocalChanges)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LocalChanges indicates an expected call of LocalChanges.
+func (mr *MockClientMockRecorder) LocalChanges(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LocalChanges", reflect.TypeOf((*MockClient)(nil).LocalChanges), arg0, arg1)
+}
+
 // Pull mocks base method.
 func (m *MockClient) Pull(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -133,6 +219,78 @@ func (mr *MockClientMockRecorder) Push(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockClient)(nil).Push), arg0)
 }
 
+// PushTag mocks base method.
+func (m *MockClient) PushTag(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PushTag", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PushTag indicates an expected call of PushTag.
+func (mr *MockClientMockRecorder) PushTag(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushTag", reflect.TypeOf((*MockClient)(nil).PushTag), arg0, arg1)
+}
+
+// PushToBranch mocks base method.
+func (m *MockClient) PushToBranch(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PushToBranch", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PushToBranch indicates an expected call of PushToBranch.
+func (mr *MockClientMockRecorder) PushToBranch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushToBranch", reflect.TypeOf((*MockClient)(nil).PushToBranch), arg0, arg1)
+}
+
+// RebaseOnRemote mocks base method.
+func (m *MockClient) RebaseOnRemote(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebaseOnRemote", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RebaseOnRemote indicates an expected call of RebaseOnRemote.
+func (mr *MockClientMockRecorder) RebaseOnRemote(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebaseOnRemote", reflect.TypeOf((*MockClient)(nil).RebaseOnRemote), arg0)
+}
+
+// RemoteBranchExists mocks base method.
+func (m *MockClient) RemoteBranchExists(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoteBranchExists", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoteBranchExists indicates an expected call of RemoteBranchExists.
+func (mr *MockClientMockRecorder) RemoteBranchExists(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoteBranchExists", reflect.TypeOf((*MockClient)(nil).RemoteBranchExists), arg0, arg1)
+}
+
+// RemoteBranches mocks base method.
+func (m *MockClient) RemoteBranches(arg0 context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoteBranches", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoteBranches indicates an expected call of RemoteBranches.
+func (mr *MockClientMockRecorder) RemoteBranches(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoteBranches", reflect.TypeOf((*MockClient)(nil).RemoteBranches), arg0)
+}
+
 // Remove mocks base method.
 func (m *MockClient) Remove(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -147,6 +305,65 @@ func (mr *MockClientMockRecorder) Remove(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockClient)(nil).Remove), arg0)
 }
 
+// ResetSoft mocks base method.
+func (m *MockClient) ResetSoft(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetSoft", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetSoft indicates an expected call of ResetSoft.
+func (mr *MockClientMockRecorder) ResetSoft(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetSoft", reflect.TypeOf((*MockClient)(nil).ResetSoft), arg0)
+}
+
+// SetSparseCheckoutDirectories mocks base method.
+func (m *MockClient) SetSparseCheckoutDirectories(arg0 ...string) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "SetSparseCheckoutDirectories", varargs...)
+}
+
+// SetSparseCheckoutDirectories indicates an expected call of SetSparseCheckoutDirectories.
+func (mr *MockClientMockRecorder) SetSparseCheckoutDirectories(arg0 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSparseCheckoutDirectories", reflect.TypeOf((*MockClient)(nil).SetSparseCheckoutDirectories), arg0...)
+}
+
+// StagedFiles mocks base method.
+func (m *MockClient) StagedFiles() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StagedFiles")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StagedFiles indicates an expected call of StagedFiles.
+func (mr *MockClientMockRecorder) StagedFiles() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StagedFiles", reflect.TypeOf((*MockClient)(nil).StagedFiles))
+}
+
+// Tag mocks base method.
+func (m *MockClient) Tag(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tag", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Tag indicates an expected call of Tag.
+func (mr *MockClientMockRecorder) Tag(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tag", reflect.TypeOf((*MockClient)(nil).Tag), arg0, arg1)
+}
+
 // ValidateRemoteExists mocks base method.
 func (m *MockClient) ValidateRemoteExists(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -198,6 +415,21 @@ func (mr *MockProviderClientMockRecorder) AddDeployKeyToRepo(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDeployKeyToRepo", reflect.TypeOf((*MockProviderClient)(nil).AddDeployKeyToRepo), arg0, arg1)
 }
 
+// CreatePullRequest mocks base method.
+func (m *MockProviderClient) CreatePullRequest(arg0 context.Context, arg1 git.CreatePullRequestOpts) (*git.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePullRequest", arg0, arg1)
+	ret0, _ := ret[0].(*git.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePullRequest indicates an expected call of CreatePullRequest.
+func (mr *MockProviderClientMockRecorder) CreatePullRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePullRequest", reflect.TypeOf((*MockProviderClient)(nil).CreatePullRequest), arg0, arg1)
+}
+
 // CreateRepo mocks base method.
 func (m *MockProviderClient) CreateRepo(arg0 context.Context, arg1 git.CreateRepoOpts) (*git.Repository, error) {
 	m.ctrl.T.Helper()
@@ -270,3 +502,203 @@ func (mr *MockProviderClientMockRecorder) Validate(arg0 interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockProviderClient)(nil).Validate), arg0)
 }
+
+// MockWebhookProviderClient is a mock of WebhookProviderClient interface.
+type MockWebhookProviderClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookProviderClientMockRecorder
+}
+
+// MockWebhookProviderClientMockRecorder is the mock recorder for MockWebhookProviderClient.
+type MockWebhookProviderClientMockRecorder struct {
+	mock *MockWebhookProviderClient
+}
+
+// NewMockWebhookProviderClient creates a new mock instance.
+func NewMockWebhookProviderClient(ctrl *gomock.Controller) *MockWebhookProviderClient {
+	mock := &MockWebhookProviderClient{ctrl: ctrl}
+	mock.recorder = &MockWebhookProviderClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookProviderClient) EXPECT() *MockWebhookProviderClientMockRecorder {
+	return m.recorder
+}
+
+// CreateWebhook mocks base method.
+func (m *MockWebhookProviderClient) CreateWebhook(arg0 context.Context, arg1 git.CreateWebhookOpts) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockWebhookProviderClientMockRecorder) CreateWebhook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockWebhookProviderClient)(nil).CreateWebhook), arg0, arg1)
+}
+
+// WebhookExists mocks base method.
+func (m *MockWebhookProviderClient) WebhookExists(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WebhookExists", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WebhookExists indicates an expected call of WebhookExists.
+func (mr *MockWebhookProviderClientMockRecorder) WebhookExists(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WebhookExists", reflect.TypeOf((*MockWebhookProviderClient)(nil).WebhookExists), arg0, arg1)
+}
+
+// MockArchiveRepoProviderClient is a mock of ArchiveRepoProviderClient interface.
+type MockArchiveRepoProviderClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockArchiveRepoProviderClientMockRecorder
+}
+
+// MockArchiveRepoProviderClientMockRecorder is the mock recorder for MockArchiveRepoProviderClient.
+type MockArchiveRepoProviderClientMockRecorder struct {
+	mock *MockArchiveRepoProviderClient
+}
+
+// NewMockArchiveRepoProviderClient creates a new mock instance.
+func NewMockArchiveRepoProviderClient(ctrl *gomock.Controller) *MockArchiveRepoProviderClient {
+	mock := &MockArchiveRepoProviderClient{ctrl: ctrl}
+	mock.recorder = &MockArchiveRepoProviderClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArchiveRepoProviderClient) EXPECT() *MockArchiveRepoProviderClientMockRecorder {
+	return m.recorder
+}
+
+// ArchiveRepo mocks base method.
+func (m *MockArchiveRepoProviderClient) ArchiveRepo(arg0 context.Context, arg1 git.ArchiveRepoOpts) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveRepo", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ArchiveRepo indicates an expected call of ArchiveRepo.
+func (mr *MockArchiveRepoProviderClientMockRecorder) ArchiveRepo(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveRepo", reflect.TypeOf((*MockArchiveRepoProviderClient)(nil).ArchiveRepo), arg0, arg1)
+}
+
+// MockPermissionsProviderClient is a mock of PermissionsProviderClient interface.
+type MockPermissionsProviderClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockPermissionsProviderClientMockRecorder
+}
+
+// MockPermissionsProviderClientMockRecorder is the mock recorder for MockPermissionsProviderClient.
+type MockPermissionsProviderClientMockRecorder struct {
+	mock *MockPermissionsProviderClient
+}
+
+// NewMockPermissionsProviderClient creates a new mock instance.
+func NewMockPermissionsProviderClient(ctrl *gomock.Controller) *MockPermissionsProviderClient {
+	mock := &MockPermissionsProviderClient{ctrl: ctrl}
+	mock.recorder = &MockPermissionsProviderClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPermissionsProviderClient) EXPECT() *MockPermissionsProviderClientMockRecorder {
+	return m.recorder
+}
+
+// ValidatePermissions mocks base method.
+func (m *MockPermissionsProviderClient) ValidatePermissions(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidatePermissions", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidatePermissions indicates an expected call of ValidatePermissions.
+func (mr *MockPermissionsProviderClientMockRecorder) ValidatePermissions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidatePermissions", reflect.TypeOf((*MockPermissionsProviderClient)(nil).ValidatePermissions), arg0)
+}
+
+// MockPushAccessProviderClient is a mock of PushAccessProviderClient interface.
+type MockPushAccessProviderClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockPushAccessProviderClientMockRecorder
+}
+
+// MockPushAccessProviderClientMockRecorder is the mock recorder for MockPushAccessProviderClient.
+type MockPushAccessProviderClientMockRecorder struct {
+	mock *MockPushAccessProviderClient
+}
+
+// NewMockPushAccessProviderClient creates a new mock instance.
+func NewMockPushAccessProviderClient(ctrl *gomock.Controller) *MockPushAccessProviderClient {
+	mock := &MockPushAccessProviderClient{ctrl: ctrl}
+	mock.recorder = &MockPushAccessProviderClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPushAccessProviderClient) EXPECT() *MockPushAccessProviderClientMockRecorder {
+	return m.recorder
+}
+
+// ValidatePushAccess mocks base method.
+func (m *MockPushAccessProviderClient) ValidatePushAccess(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidatePushAccess", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidatePushAccess indicates an expected call of ValidatePushAccess.
+func (mr *MockPushAccessProviderClientMockRecorder) ValidatePushAccess(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidatePushAccess", reflect.TypeOf((*MockPushAccessProviderClient)(nil).ValidatePushAccess), arg0)
+}
+
+// MockBranchProtectionProviderClient is a mock of BranchProtectionProviderClient interface.
+type MockBranchProtectionProviderClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockBranchProtectionProviderClientMockRecorder
+}
+
+// MockBranchProtectionProviderClientMockRecorder is the mock recorder for MockBranchProtectionProviderClient.
+type MockBranchProtectionProviderClientMockRecorder struct {
+	mock *MockBranchProtectionProviderClient
+}
+
+// NewMockBranchProtectionProviderClient creates a new mock instance.
+func NewMockBranchProtectionProviderClient(ctrl *gomock.Controller) *MockBranchProtectionProviderClient {
+	mock := &MockBranchProtectionProviderClient{ctrl: ctrl}
+	mock.recorder = &MockBranchProtectionProviderClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBranchProtectionProviderClient) EXPECT() *MockBranchProtectionProviderClientMockRecorder {
+	return m.recorder
+}
+
+// ValidateBranchPush mocks base method.
+func (m *MockBranchProtectionProviderClient) ValidateBranchPush(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateBranchPush", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateBranchPush indicates an expected call of ValidateBranchPush.
+func (mr *MockBranchProtectionProviderClientMockRecorder) ValidateBranchPush(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateBranchPush", reflect.TypeOf((*MockBranchProtectionProviderClient)(nil).ValidateBranchPush), arg0, arg1)
+}
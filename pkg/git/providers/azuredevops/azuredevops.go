@@ -0,0 +1,267 @@
+package azuredevops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	GitProviderName         = "azure-devops"
+	EksaAzureDevOpsTokenEnv = "EKSA_AZURE_DEVOPS_TOKEN"
+	azureDevOpsHost         = "dev.azure.com"
+	azureDevOpsUrlTemplate  = "https://%v/%v/%v/_git/%v"
+	apiVersion              = "7.0"
+)
+
+// HTTPClient represents the attributes that the Azure DevOps provider requires of a client to interact with the Azure DevOps REST API.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type azureDevOpsProvider struct {
+	httpClient HTTPClient
+	config     *v1alpha1.AzureDevOpsProviderConfig
+	auth       git.TokenAuth
+}
+
+type repository struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	RemoteUrl string `json:"remoteUrl"`
+}
+
+type pullRequest struct {
+	PullRequestId int `json:"pullRequestId"`
+}
+
+type project struct {
+	ID string `json:"id"`
+}
+
+type apiError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("azure devops api returned status %d: %s", e.StatusCode, e.Message)
+}
+
+func New(httpClient HTTPClient, config *v1alpha1.AzureDevOpsProviderConfig, auth git.TokenAuth) (*azureDevOpsProvider, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &azureDevOpsProvider{
+		httpClient: httpClient,
+		config:     config,
+		auth:       auth,
+	}, nil
+}
+
+// CreateRepo creates an empty repository in the configured Azure DevOps project. The project must already exist.
+// An empty repository can't be cloned, so it must be initialized locally before pushing to it.
+func (a *azureDevOpsProvider) CreateRepo(ctx context.Context, opts git.CreateRepoOpts) (*git.Repository, error) {
+	logger.V(3).Info("Attempting to create new Azure DevOps repo", "repo", opts.Name, "organization", a.config.Organization, "project", a.config.Project)
+	p := &project{}
+	if err := a.do(ctx, http.MethodGet, "/_apis/projects/"+url.PathEscape(a.config.Project), nil, p); err != nil {
+		return nil, fmt.Errorf("failed to get Azure DevOps project %s: %v", a.config.Project, err)
+	}
+
+	body := map[string]interface{}{
+		"name":    opts.Name,
+		"project": map[string]string{"id": p.ID},
+	}
+	r := &repository{}
+	if err := a.do(ctx, http.MethodPost, a.projectPath()+"/_apis/git/repositories", body, r); err != nil {
+		return nil, fmt.Errorf("failed to create new Azure DevOps repo %s: %v", opts.Name, err)
+	}
+	logger.V(3).Info("Successfully created new Azure DevOps repo", "repo", r.Name, "project", a.config.Project)
+	return r.toRepository(a.config.Organization), nil
+}
+
+// GetRepo describes a remote repository, return the repo name if it exists.
+// If the repo does not exist, a nil repo is returned.
+func (a *azureDevOpsProvider) GetRepo(ctx context.Context) (*git.Repository, error) {
+	r := a.config.Repository
+	logger.V(3).Info("Describing Azure DevOps repository", "name", r, "organization", a.config.Organization, "project", a.config.Project)
+	repo, err := a.getRepo(ctx, r)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error when describing repository %s: %w", r, err)
+	}
+	return repo.toRepository(a.config.Organization), nil
+}
+
+// AddDeployKeyToRepo is not supported, Azure DevOps only allows ssh keys to be registered for users.
+func (a *azureDevOpsProvider) AddDeployKeyToRepo(ctx context.Context, opts git.AddDeployKeyOpts) error {
+	return errors.New("azure devops doesn't support repository deploy keys")
+}
+
+// DeleteRepo deletes an Azure DevOps repository.
+func (a *azureDevOpsProvider) DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts) error {
+	repo, err := a.getRepo(ctx, opts.Repository)
+	if err != nil {
+		return fmt.Errorf("deleting repository %s: %v", opts.Repository, err)
+	}
+	if err := a.do(ctx, http.MethodDelete, a.projectPath()+"/_apis/git/repositories/"+url.PathEscape(repo.ID), nil, nil); err != nil {
+		return fmt.Errorf("deleting repository %s: %v", opts.Repository, err)
+	}
+	return nil
+}
+
+// CreatePullRequest opens a pull request in the configured repository.
+func (a *azureDevOpsProvider) CreatePullRequest(ctx context.Context, opts git.CreatePullRequestOpts) (*git.PullRequest, error) {
+	r := a.config.Repository
+	logger.V(3).Info("Creating Azure DevOps pull request", "repository", r, "project", a.config.Project, "head", opts.Head, "base", opts.Base)
+	body := map[string]interface{}{
+		"sourceRefName": "refs/heads/" + opts.Head,
+		"targetRefName": "refs/heads/" + opts.Base,
+		"title":         opts.Title,
+		"description":   opts.Description,
+	}
+
+	pr := &pullRequest{}
+	if err := a.do(ctx, http.MethodPost, a.projectPath()+"/_apis/git/repositories/"+url.PathEscape(r)+"/pullrequests", body, pr); err != nil {
+		return nil, fmt.Errorf("creating pull request in repository %s: %v", r, err)
+	}
+	return &git.PullRequest{
+		Number: pr.PullRequestId,
+		Url:    fmt.Sprintf("%s/pullrequest/%d", RepoUrl(a.config.Organization, a.config.Project, r), pr.PullRequestId),
+	}, nil
+}
+
+// Validate validates the Azure DevOps access token can read the configured project.
+func (a *azureDevOpsProvider) Validate(ctx context.Context) error {
+	if err := a.do(ctx, http.MethodGet, "/_apis/projects/"+url.PathEscape(a.config.Project), nil, &project{}); err != nil {
+		return fmt.Errorf("the Azure DevOps access token doesn't have proper access to project %s in organization %s, %v", a.config.Project, a.config.Organization, err)
+	}
+	logger.MarkPass("Azure DevOps access token has access to the project")
+	return nil
+}
+
+// PathExists checks if a path exists in the branch of a repository in the configured project.
+// Owner is ignored since repositories are scoped by the configured organization and project.
+func (a *azureDevOpsProvider) PathExists(ctx context.Context, owner, repo, branch, path string) (bool, error) {
+	query := url.Values{
+		"path":                          []string{"/" + strings.TrimPrefix(path, "/")},
+		"versionDescriptor.version":     []string{branch},
+		"versionDescriptor.versionType": []string{"branch"},
+	}
+	err := a.do(ctx, http.MethodGet, a.projectPath()+"/_apis/git/repositories/"+url.PathEscape(repo)+"/items?"+query.Encode(), nil, nil)
+	if isNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed checking if path %s exists in remote azure devops repository: %v", path, err)
+	}
+	return true, nil
+}
+
+func (a *azureDevOpsProvider) getRepo(ctx context.Context, name string) (*repository, error) {
+	r := &repository{}
+	if err := a.do(ctx, http.MethodGet, a.projectPath()+"/_apis/git/repositories/"+url.PathEscape(name), nil, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (a *azureDevOpsProvider) projectPath() string {
+	return "/" + url.PathEscape(a.config.Project)
+}
+
+func (a *azureDevOpsProvider) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshalling azure devops request body: %v", err)
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	u, err := url.Parse(fmt.Sprintf("https://%s/%s%s", azureDevOpsHost, url.PathEscape(a.config.Organization), path))
+	if err != nil {
+		return err
+	}
+	query := u.Query()
+	query.Set("api-version", apiVersion)
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reqBody)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth("", a.auth.Token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading azure devops response: %v", err)
+	}
+
+	// Azure DevOps answers requests with an invalid token with a 203 redirecting to the sign in page.
+	if resp.StatusCode == http.StatusNonAuthoritativeInfo {
+		return &apiError{StatusCode: http.StatusUnauthorized, Message: "access token is invalid or expired"}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &apiError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(respBody))}
+	}
+
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("unmarshalling azure devops response: %v", err)
+	}
+	return nil
+}
+
+func (r *repository) toRepository(organization string) *git.Repository {
+	return &git.Repository{
+		Name:         r.Name,
+		Organization: organization,
+		CloneUrl:     r.RemoteUrl,
+	}
+}
+
+func isNotFound(err error) bool {
+	var e *apiError
+	return errors.As(err, &e) && e.StatusCode == http.StatusNotFound
+}
+
+func GetAzureDevOpsAccessTokenFromEnv() (string, error) {
+	logger.V(4).Info("Checking validity of Azure DevOps Access Token environment variable", "env var", EksaAzureDevOpsTokenEnv)
+	val, ok := os.LookupEnv(EksaAzureDevOpsTokenEnv)
+	if !ok || len(val) == 0 {
+		return "", fmt.Errorf("azure devops access token environment variable %s is invalid; could not get var from environment", EksaAzureDevOpsTokenEnv)
+	}
+	return val, nil
+}
+
+// RepoUrl returns the https clone url of an Azure DevOps repository.
+func RepoUrl(organization, project, repo string) string {
+	return fmt.Sprintf(azureDevOpsUrlTemplate, azureDevOpsHost, url.PathEscape(organization), url.PathEscape(project), url.PathEscape(repo))
+}
@@ -0,0 +1,249 @@
+package azuredevops_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/git/providers/azuredevops"
+)
+
+const testToken = "azure-devops-test-token"
+
+// redirectClient sends the requests for dev.azure.com to a test server.
+type redirectClient struct {
+	server *httptest.Server
+}
+
+func (c *redirectClient) Do(req *http.Request) (*http.Response, error) {
+	u, err := url.Parse(c.server.URL)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.Scheme = u.Scheme
+	req.URL.Host = u.Host
+	return c.server.Client().Do(req)
+}
+
+type azureDevOpsTest struct {
+	*WithT
+	ctx      context.Context
+	mux      *http.ServeMux
+	provider git.ProviderClient
+}
+
+func newAzureDevOpsTest(t *testing.T) *azureDevOpsTest {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, password, ok := r.BasicAuth(); !ok || password != testToken {
+			w.WriteHeader(http.StatusNonAuthoritativeInfo)
+			return
+		}
+		if r.URL.Query().Get("api-version") != "7.0" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	config := &v1alpha1.AzureDevOpsProviderConfig{
+		Organization: "contoso",
+		Project:      "platform",
+		Repository:   "fleet",
+	}
+	provider, err := azuredevops.New(&redirectClient{server: server}, config, git.TokenAuth{Token: testToken})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &azureDevOpsTest{
+		WithT:    NewWithT(t),
+		ctx:      context.Background(),
+		mux:      mux,
+		provider: provider,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestAzureDevOpsGetRepoSuccess(t *testing.T) {
+	g := newAzureDevOpsTest(t)
+	g.mux.HandleFunc("/contoso/platform/_apis/git/repositories/fleet", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{"id": "1234", "name": "fleet", "remoteUrl": "https://contoso@dev.azure.com/contoso/platform/_git/fleet"})
+	})
+
+	repo, err := g.provider.GetRepo(g.ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo).To(Equal(&git.Repository{
+		Name:         "fleet",
+		Organization: "contoso",
+		CloneUrl:     "https://contoso@dev.azure.com/contoso/platform/_git/fleet",
+	}))
+}
+
+func TestAzureDevOpsGetRepoNotFound(t *testing.T) {
+	g := newAzureDevOpsTest(t)
+
+	repo, err := g.provider.GetRepo(g.ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo).To(BeNil())
+}
+
+func TestAzureDevOpsCreateRepo(t *testing.T) {
+	g := newAzureDevOpsTest(t)
+	g.mux.HandleFunc("/contoso/_apis/projects/platform", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{"id": "project-id"})
+	})
+	var body map[string]interface{}
+	g.mux.HandleFunc("/contoso/platform/_apis/git/repositories", func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.Method).To(Equal(http.MethodPost))
+		g.Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, map[string]string{"id": "1234", "name": "fleet"})
+	})
+
+	repo, err := g.provider.CreateRepo(g.ctx, git.CreateRepoOpts{Name: "fleet", Owner: "contoso"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo.Name).To(Equal("fleet"))
+	g.Expect(body).To(HaveKeyWithValue("project", HaveKeyWithValue("id", "project-id")))
+}
+
+func TestAzureDevOpsCreateRepoProjectNotFound(t *testing.T) {
+	g := newAzureDevOpsTest(t)
+
+	_, err := g.provider.CreateRepo(g.ctx, git.CreateRepoOpts{Name: "fleet"})
+	g.Expect(err).To(MatchError(ContainSubstring("failed to get Azure DevOps project platform")))
+}
+
+func TestAzureDevOpsValidateSuccess(t *testing.T) {
+	g := newAzureDevOpsTest(t)
+	g.mux.HandleFunc("/contoso/_apis/projects/platform", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{"id": "project-id"})
+	})
+
+	g.Expect(g.provider.Validate(g.ctx)).To(Succeed())
+}
+
+func TestAzureDevOpsValidateInvalidToken(t *testing.T) {
+	g := NewWithT(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNonAuthoritativeInfo)
+	}))
+	defer server.Close()
+	config := &v1alpha1.AzureDevOpsProviderConfig{Organization: "contoso", Project: "platform", Repository: "fleet"}
+	provider, err := azuredevops.New(&redirectClient{server: server}, config, git.TokenAuth{Token: "invalid"})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(provider.Validate(context.Background())).To(MatchError(ContainSubstring("access token is invalid or expired")))
+}
+
+func TestAzureDevOpsPathExists(t *testing.T) {
+	tests := []struct {
+		testName string
+		path     string
+		want     bool
+	}{
+		{testName: "existing path", path: "clusters/mgmt", want: true},
+		{testName: "missing path", path: "clusters/other", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := newAzureDevOpsTest(t)
+			g.mux.HandleFunc("/contoso/platform/_apis/git/repositories/fleet/items", func(w http.ResponseWriter, r *http.Request) {
+				g.Expect(r.URL.Query().Get("versionDescriptor.version")).To(Equal("main"))
+				if r.URL.Query().Get("path") != "/clusters/mgmt" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				writeJSON(w, map[string]string{"path": "/clusters/mgmt"})
+			})
+
+			exists, err := g.provider.PathExists(g.ctx, "contoso", "fleet", "main", tt.path)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(exists).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestAzureDevOpsPathExistsError(t *testing.T) {
+	g := newAzureDevOpsTest(t)
+	g.mux.HandleFunc("/contoso/platform/_apis/git/repositories/fleet/items", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	_, err := g.provider.PathExists(g.ctx, "contoso", "fleet", "main", "clusters")
+	g.Expect(err).To(MatchError(ContainSubstring("status 500")))
+}
+
+func TestAzureDevOpsDeleteRepo(t *testing.T) {
+	g := newAzureDevOpsTest(t)
+	g.mux.HandleFunc("/contoso/platform/_apis/git/repositories/fleet", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{"id": "1234", "name": "fleet"})
+	})
+	deleted := false
+	g.mux.HandleFunc("/contoso/platform/_apis/git/repositories/1234", func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.Method).To(Equal(http.MethodDelete))
+		deleted = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	g.Expect(g.provider.DeleteRepo(g.ctx, git.DeleteRepoOpts{Repository: "fleet"})).To(Succeed())
+	g.Expect(deleted).To(BeTrue())
+}
+
+func TestAzureDevOpsCreatePullRequest(t *testing.T) {
+	g := newAzureDevOpsTest(t)
+	var body map[string]string
+	g.mux.HandleFunc("/contoso/platform/_apis/git/repositories/fleet/pullrequests", func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.Method).To(Equal(http.MethodPost))
+		g.Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, map[string]int{"pullRequestId": 12})
+	})
+
+	pr, err := g.provider.CreatePullRequest(g.ctx, git.CreatePullRequestOpts{Title: "Update cluster config", Head: "eksa/mgmt", Base: "main"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pr).To(Equal(&git.PullRequest{Number: 12, Url: "https://dev.azure.com/contoso/platform/_git/fleet/pullrequest/12"}))
+	g.Expect(body).To(HaveKeyWithValue("sourceRefName", "refs/heads/eksa/mgmt"))
+	g.Expect(body).To(HaveKeyWithValue("targetRefName", "refs/heads/main"))
+}
+
+func TestAzureDevOpsAddDeployKeyNotSupported(t *testing.T) {
+	g := newAzureDevOpsTest(t)
+
+	g.Expect(g.provider.AddDeployKeyToRepo(g.ctx, git.AddDeployKeyOpts{})).To(MatchError(ContainSubstring("doesn't support repository deploy keys")))
+}
+
+func TestGetAzureDevOpsAccessTokenFromEnv(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(azuredevops.EksaAzureDevOpsTokenEnv, testToken)
+
+	token, err := azuredevops.GetAzureDevOpsAccessTokenFromEnv()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(token).To(Equal(testToken))
+}
+
+func TestGetAzureDevOpsAccessTokenFromEnvMissing(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(azuredevops.EksaAzureDevOpsTokenEnv, "")
+
+	_, err := azuredevops.GetAzureDevOpsAccessTokenFromEnv()
+	g.Expect(err).To(MatchError(ContainSubstring(azuredevops.EksaAzureDevOpsTokenEnv)))
+}
+
+func TestRepoUrl(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(azuredevops.RepoUrl("contoso", "platform team", "fleet")).To(Equal("https://dev.azure.com/contoso/platform%20team/_git/fleet"))
+}
@@ -0,0 +1,307 @@
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	GitProviderName       = "bitbucket-server"
+	EksaBitbucketTokenEnv = "EKSA_BITBUCKET_TOKEN"
+	BitbucketTokenEnv     = "BITBUCKET_TOKEN"
+	bitbucketUrlTemplate  = "https://%v/scm/%v/%v.git"
+	readPermission        = "REPO_READ"
+	writePermission       = "REPO_WRITE"
+)
+
+// HTTPClient represents the attributes that the Bitbucket provider requires of a client to interact with the Bitbucket Server REST API.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type bitbucketProvider struct {
+	httpClient HTTPClient
+	config     *v1alpha1.BitbucketServerProviderConfig
+	auth       git.TokenAuth
+	baseUrl    string
+}
+
+type repository struct {
+	Slug    string `json:"slug"`
+	Name    string `json:"name"`
+	Project struct {
+		Key  string `json:"key"`
+		Type string `json:"type"`
+	} `json:"project"`
+	Links struct {
+		Clone []struct {
+			Href string `json:"href"`
+			Name string `json:"name"`
+		} `json:"clone"`
+	} `json:"links"`
+}
+
+type pullRequest struct {
+	ID    int `json:"id"`
+	Links struct {
+		Self []struct {
+			Href string `json:"href"`
+		} `json:"self"`
+	} `json:"links"`
+}
+
+type apiError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("bitbucket server api returned status %d: %s", e.StatusCode, e.Message)
+}
+
+func New(httpClient HTTPClient, config *v1alpha1.BitbucketServerProviderConfig, auth git.TokenAuth) (*bitbucketProvider, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &bitbucketProvider{
+		httpClient: httpClient,
+		config:     config,
+		auth:       auth,
+		baseUrl:    fmt.Sprintf("https://%s/rest", config.Hostname),
+	}, nil
+}
+
+// CreateRepo creates an empty Bitbucket Server repository in the owner project, or in the owner personal
+// project when the repository is personal. Bitbucket Server can't initialize repositories, so AutoInit is ignored.
+func (b *bitbucketProvider) CreateRepo(ctx context.Context, opts git.CreateRepoOpts) (*git.Repository, error) {
+	logger.V(3).Info("Attempting to create new Bitbucket Server repo", "repo", opts.Name, "owner", opts.Owner)
+	body := map[string]interface{}{
+		"name":   opts.Name,
+		"public": !opts.Privacy,
+	}
+	if opts.Description != "" {
+		body["description"] = opts.Description
+	}
+
+	r := &repository{}
+	if err := b.do(ctx, http.MethodPost, projectPath(opts.Owner, opts.Personal)+"/repos", body, r); err != nil {
+		return nil, fmt.Errorf("failed to create new Bitbucket Server repo %s: %v", opts.Name, err)
+	}
+	logger.V(3).Info("Successfully created new Bitbucket Server repo", "repo", r.Name, "owner", opts.Owner)
+	return r.toRepository(), nil
+}
+
+// GetRepo describes a remote repository, return the repo name if it exists.
+// If the repo does not exist, a nil repo is returned.
+func (b *bitbucketProvider) GetRepo(ctx context.Context) (*git.Repository, error) {
+	r := b.config.Repository
+	o := b.config.Owner
+	logger.V(3).Info("Describing Bitbucket Server repository", "name", r, "owner", o)
+	repo := &repository{}
+	err := b.do(ctx, http.MethodGet, repoPath(o, r, b.config.Personal), nil, repo)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error when describing repository %s: %w", r, err)
+	}
+	return repo.toRepository(), nil
+}
+
+func (b *bitbucketProvider) AddDeployKeyToRepo(ctx context.Context, opts git.AddDeployKeyOpts) error {
+	logger.V(3).Info("Adding deploy key to repository", "repository", opts.Repository, "owner", opts.Owner)
+	permission := writePermission
+	if opts.ReadOnly {
+		permission = readPermission
+	}
+	body := map[string]interface{}{
+		"key":        map[string]string{"text": opts.Key, "label": opts.Title},
+		"permission": permission,
+	}
+	return b.do(ctx, http.MethodPost, "/keys/1.0"+repoResource(opts.Owner, opts.Repository, b.config.Personal)+"/ssh", body, nil)
+}
+
+// DeleteRepo deletes a Bitbucket Server repository.
+func (b *bitbucketProvider) DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts) error {
+	if err := b.do(ctx, http.MethodDelete, repoPath(opts.Owner, opts.Repository, b.config.Personal), nil, nil); err != nil {
+		return fmt.Errorf("deleting repository %s: %v", opts.Repository, err)
+	}
+	return nil
+}
+
+// CreatePullRequest opens a pull request in the configured repository, from and to branches of the same repository.
+func (b *bitbucketProvider) CreatePullRequest(ctx context.Context, opts git.CreatePullRequestOpts) (*git.PullRequest, error) {
+	r := b.config.Repository
+	logger.V(3).Info("Creating Bitbucket Server pull request", "repository", r, "owner", b.config.Owner, "head", opts.Head, "base", opts.Base)
+	body := map[string]interface{}{
+		"title":       opts.Title,
+		"description": opts.Description,
+		"fromRef":     map[string]string{"id": "refs/heads/" + opts.Head},
+		"toRef":       map[string]string{"id": "refs/heads/" + opts.Base},
+	}
+
+	pr := &pullRequest{}
+	if err := b.do(ctx, http.MethodPost, repoPath(b.config.Owner, r, b.config.Personal)+"/pull-requests", body, pr); err != nil {
+		return nil, fmt.Errorf("creating pull request in repository %s: %v", r, err)
+	}
+	p := &git.PullRequest{Number: pr.ID}
+	if len(pr.Links.Self) > 0 {
+		p.Url = pr.Links.Self[0].Href
+	}
+	return p, nil
+}
+
+// Validate validates the Bitbucket Server setup and access.
+func (b *bitbucketProvider) Validate(ctx context.Context) error {
+	if err := b.do(ctx, http.MethodGet, "/api/1.0/users/"+url.PathEscape(b.config.Username), nil, nil); err != nil {
+		return fmt.Errorf("validating Bitbucket Server access token for user %s: %v", b.config.Username, err)
+	}
+	logger.MarkPass("Bitbucket Server access token is valid")
+
+	if b.config.Personal {
+		if !strings.EqualFold(b.config.Owner, b.config.Username) {
+			return fmt.Errorf("the Bitbucket Server username %s and owner %s specified in the EKS-A gitops spec don't match; personal repositories must be owned by the authenticated user", b.config.Username, b.config.Owner)
+		}
+		return nil
+	}
+
+	if err := b.do(ctx, http.MethodGet, projectPath(b.config.Owner, false), nil, nil); err != nil {
+		return fmt.Errorf("the authenticated bitbucket server user doesn't have proper access to project %s, %v", b.config.Owner, err)
+	}
+	return nil
+}
+
+func (b *bitbucketProvider) PathExists(ctx context.Context, owner, repo, branch, path string) (bool, error) {
+	query := url.Values{"at": []string{"refs/heads/" + branch}, "limit": []string{"1"}}
+	err := b.do(ctx, http.MethodGet, repoPath(owner, repo, b.config.Personal)+"/browse/"+escapePath(path)+"?"+query.Encode(), nil, nil)
+	if isNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed checking if path %s exists in remote bitbucket server repository: %v", path, err)
+	}
+	return true, nil
+}
+
+func (b *bitbucketProvider) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		m, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshalling bitbucket server request body: %v", err)
+		}
+		reqBody = bytes.NewReader(m)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, b.baseUrl+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+b.auth.Token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading bitbucket server response: %v", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &apiError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(respBody))}
+	}
+
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("unmarshalling bitbucket server response: %v", err)
+	}
+	return nil
+}
+
+func (r *repository) toRepository() *git.Repository {
+	repo := &git.Repository{Name: r.Slug}
+	for _, l := range r.Links.Clone {
+		if l.Name == "http" {
+			repo.CloneUrl = l.Href
+		}
+	}
+	if r.Project.Type == "PERSONAL" {
+		repo.Owner = strings.TrimPrefix(r.Project.Key, "~")
+	} else {
+		repo.Organization = r.Project.Key
+	}
+	return repo
+}
+
+// projectKey returns the key of the project holding the repositories of the owner. Personal projects
+// are addressed with the user name prefixed by ~.
+func projectKey(owner string, personal bool) string {
+	if personal {
+		return "~" + owner
+	}
+	return owner
+}
+
+func projectPath(owner string, personal bool) string {
+	return "/api/1.0/projects/" + url.PathEscape(projectKey(owner, personal))
+}
+
+// repoResource returns the repository resource path shared by the core and the ssh keys apis.
+func repoResource(owner, repo string, personal bool) string {
+	return "/projects/" + url.PathEscape(projectKey(owner, personal)) + "/repos/" + url.PathEscape(strings.ToLower(repo))
+}
+
+func repoPath(owner, repo string, personal bool) string {
+	return "/api/1.0" + repoResource(owner, repo, personal)
+}
+
+func escapePath(p string) string {
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+func isNotFound(err error) bool {
+	var e *apiError
+	return errors.As(err, &e) && e.StatusCode == http.StatusNotFound
+}
+
+func GetBitbucketAccessTokenFromEnv() (string, error) {
+	logger.V(4).Info("Checking validity of Bitbucket Server Access Token environment variable", "env var", EksaBitbucketTokenEnv)
+	val, ok := os.LookupEnv(EksaBitbucketTokenEnv)
+	if !ok || len(val) == 0 {
+		return "", fmt.Errorf("bitbucket server access token environment variable %s is invalid; could not get var from environment", EksaBitbucketTokenEnv)
+	}
+	if err := os.Setenv(BitbucketTokenEnv, val); err != nil {
+		return "", fmt.Errorf("unable to set %s: %v", BitbucketTokenEnv, err)
+	}
+	return val, nil
+}
+
+// RepoUrl returns the http clone url of a Bitbucket Server repository.
+func RepoUrl(hostname, owner, repo string, personal bool) string {
+	return fmt.Sprintf(bitbucketUrlTemplate, hostname, strings.ToLower(projectKey(owner, personal)), strings.ToLower(repo))
+}
@@ -0,0 +1,276 @@
+package bitbucket_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/git/providers/bitbucket"
+)
+
+const testToken = "bitbucket-test-token"
+
+type bitbucketTest struct {
+	*WithT
+	ctx      context.Context
+	mux      *http.ServeMux
+	provider git.ProviderClient
+}
+
+func newBitbucketTest(t *testing.T, owner string, personal bool) *bitbucketTest {
+	mux := http.NewServeMux()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+testToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	config := &v1alpha1.BitbucketServerProviderConfig{
+		Owner:      owner,
+		Repository: "Fleet",
+		Hostname:   strings.TrimPrefix(server.URL, "https://"),
+		Username:   "janedoe",
+		Personal:   personal,
+	}
+	provider, err := bitbucket.New(server.Client(), config, git.TokenAuth{Username: "janedoe", Token: testToken})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &bitbucketTest{
+		WithT:    NewWithT(t),
+		ctx:      context.Background(),
+		mux:      mux,
+		provider: provider,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func repoResponse(projectKey, projectType string) map[string]interface{} {
+	return map[string]interface{}{
+		"slug":    "fleet",
+		"name":    "Fleet",
+		"project": map[string]string{"key": projectKey, "type": projectType},
+		"links": map[string]interface{}{
+			"clone": []map[string]string{
+				{"name": "ssh", "href": "ssh://git@bitbucket.example.com:7999/plat/fleet.git"},
+				{"name": "http", "href": "https://bitbucket.example.com/scm/plat/fleet.git"},
+			},
+		},
+	}
+}
+
+func TestBitbucketGetRepoSuccess(t *testing.T) {
+	g := newBitbucketTest(t, "PLAT", false)
+	g.mux.HandleFunc("/rest/api/1.0/projects/PLAT/repos/fleet", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, repoResponse("PLAT", "NORMAL"))
+	})
+
+	repo, err := g.provider.GetRepo(g.ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo).To(Equal(&git.Repository{
+		Name:         "fleet",
+		Organization: "PLAT",
+		CloneUrl:     "https://bitbucket.example.com/scm/plat/fleet.git",
+	}))
+}
+
+func TestBitbucketGetRepoPersonal(t *testing.T) {
+	g := newBitbucketTest(t, "janedoe", true)
+	g.mux.HandleFunc("/rest/api/1.0/projects/~janedoe/repos/fleet", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, repoResponse("~JANEDOE", "PERSONAL"))
+	})
+
+	repo, err := g.provider.GetRepo(g.ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo.Owner).To(Equal("JANEDOE"))
+}
+
+func TestBitbucketGetRepoNotFound(t *testing.T) {
+	g := newBitbucketTest(t, "PLAT", false)
+
+	repo, err := g.provider.GetRepo(g.ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo).To(BeNil())
+}
+
+func TestBitbucketCreateRepo(t *testing.T) {
+	g := newBitbucketTest(t, "PLAT", false)
+	var body map[string]interface{}
+	g.mux.HandleFunc("/rest/api/1.0/projects/PLAT/repos", func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.Method).To(Equal(http.MethodPost))
+		g.Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, repoResponse("PLAT", "NORMAL"))
+	})
+
+	repo, err := g.provider.CreateRepo(g.ctx, git.CreateRepoOpts{Name: "Fleet", Owner: "PLAT", Privacy: true})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo.Name).To(Equal("fleet"))
+	g.Expect(body).To(HaveKeyWithValue("name", "Fleet"))
+	g.Expect(body).To(HaveKeyWithValue("public", false))
+}
+
+func TestBitbucketCreateRepoError(t *testing.T) {
+	g := newBitbucketTest(t, "PLAT", false)
+	g.mux.HandleFunc("/rest/api/1.0/projects/PLAT/repos", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	_, err := g.provider.CreateRepo(g.ctx, git.CreateRepoOpts{Name: "Fleet", Owner: "PLAT"})
+	g.Expect(err).To(MatchError(ContainSubstring("failed to create new Bitbucket Server repo Fleet")))
+}
+
+func TestBitbucketAddDeployKey(t *testing.T) {
+	g := newBitbucketTest(t, "PLAT", false)
+	var body map[string]interface{}
+	g.mux.HandleFunc("/rest/keys/1.0/projects/PLAT/repos/fleet/ssh", func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	g.Expect(g.provider.AddDeployKeyToRepo(g.ctx, git.AddDeployKeyOpts{Owner: "PLAT", Repository: "Fleet", Key: "ssh-ed25519 AAAA", ReadOnly: true})).To(Succeed())
+	g.Expect(body).To(HaveKeyWithValue("permission", "REPO_READ"))
+}
+
+func TestBitbucketCreatePullRequest(t *testing.T) {
+	g := newBitbucketTest(t, "PLAT", false)
+	var body map[string]interface{}
+	g.mux.HandleFunc("/rest/api/1.0/projects/PLAT/repos/fleet/pull-requests", func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.Method).To(Equal(http.MethodPost))
+		g.Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, map[string]interface{}{
+			"id":    5,
+			"links": map[string]interface{}{"self": []map[string]string{{"href": "https://bitbucket.example.com/projects/PLAT/repos/fleet/pull-requests/5"}}},
+		})
+	})
+
+	pr, err := g.provider.CreatePullRequest(g.ctx, git.CreatePullRequestOpts{Title: "Update cluster config", Head: "eksa/mgmt", Base: "main"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pr).To(Equal(&git.PullRequest{Number: 5, Url: "https://bitbucket.example.com/projects/PLAT/repos/fleet/pull-requests/5"}))
+	g.Expect(body).To(HaveKeyWithValue("fromRef", HaveKeyWithValue("id", "refs/heads/eksa/mgmt")))
+	g.Expect(body).To(HaveKeyWithValue("toRef", HaveKeyWithValue("id", "refs/heads/main")))
+}
+
+func TestBitbucketValidateProject(t *testing.T) {
+	g := newBitbucketTest(t, "PLAT", false)
+	g.mux.HandleFunc("/rest/api/1.0/users/janedoe", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{"name": "janedoe"})
+	})
+	g.mux.HandleFunc("/rest/api/1.0/projects/PLAT", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{"key": "PLAT"})
+	})
+
+	g.Expect(g.provider.Validate(g.ctx)).To(Succeed())
+}
+
+func TestBitbucketValidateProjectNoAccess(t *testing.T) {
+	g := newBitbucketTest(t, "PLAT", false)
+	g.mux.HandleFunc("/rest/api/1.0/users/janedoe", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{"name": "janedoe"})
+	})
+
+	g.Expect(g.provider.Validate(g.ctx)).To(MatchError(ContainSubstring("doesn't have proper access to project PLAT")))
+}
+
+func TestBitbucketValidateInvalidToken(t *testing.T) {
+	g := newBitbucketTest(t, "PLAT", false)
+	g.mux.HandleFunc("/rest/api/1.0/users/janedoe", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	g.Expect(g.provider.Validate(g.ctx)).To(MatchError(ContainSubstring("validating Bitbucket Server access token for user janedoe")))
+}
+
+func TestBitbucketValidatePersonalOwnerMismatch(t *testing.T) {
+	g := newBitbucketTest(t, "johndoe", true)
+	g.mux.HandleFunc("/rest/api/1.0/users/janedoe", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{"name": "janedoe"})
+	})
+
+	g.Expect(g.provider.Validate(g.ctx)).To(MatchError(ContainSubstring("don't match")))
+}
+
+func TestBitbucketPathExists(t *testing.T) {
+	tests := []struct {
+		testName string
+		path     string
+		want     bool
+	}{
+		{testName: "existing path", path: "clusters/mgmt", want: true},
+		{testName: "missing path", path: "clusters/other", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := newBitbucketTest(t, "PLAT", false)
+			g.mux.HandleFunc("/rest/api/1.0/projects/PLAT/repos/fleet/browse/clusters/mgmt", func(w http.ResponseWriter, r *http.Request) {
+				g.Expect(r.URL.Query().Get("at")).To(Equal("refs/heads/main"))
+				writeJSON(w, map[string]interface{}{"children": map[string]interface{}{"size": 1}})
+			})
+
+			exists, err := g.provider.PathExists(g.ctx, "PLAT", "Fleet", "main", tt.path)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(exists).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestBitbucketPathExistsError(t *testing.T) {
+	g := newBitbucketTest(t, "PLAT", false)
+	g.mux.HandleFunc("/rest/api/1.0/projects/PLAT/repos/fleet/browse/clusters", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	_, err := g.provider.PathExists(g.ctx, "PLAT", "Fleet", "main", "clusters")
+	g.Expect(err).To(MatchError(ContainSubstring("status 500")))
+}
+
+func TestBitbucketDeleteRepo(t *testing.T) {
+	g := newBitbucketTest(t, "PLAT", false)
+	g.mux.HandleFunc("/rest/api/1.0/projects/PLAT/repos/fleet", func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.Method).To(Equal(http.MethodDelete))
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	g.Expect(g.provider.DeleteRepo(g.ctx, git.DeleteRepoOpts{Owner: "PLAT", Repository: "Fleet"})).To(Succeed())
+}
+
+func TestGetBitbucketAccessTokenFromEnv(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(bitbucket.EksaBitbucketTokenEnv, testToken)
+	t.Setenv(bitbucket.BitbucketTokenEnv, "")
+
+	token, err := bitbucket.GetBitbucketAccessTokenFromEnv()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(token).To(Equal(testToken))
+}
+
+func TestGetBitbucketAccessTokenFromEnvMissing(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(bitbucket.EksaBitbucketTokenEnv, "")
+
+	_, err := bitbucket.GetBitbucketAccessTokenFromEnv()
+	g.Expect(err).To(MatchError(ContainSubstring(bitbucket.EksaBitbucketTokenEnv)))
+}
+
+func TestRepoUrl(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(bitbucket.RepoUrl("bitbucket.example.com", "PLAT", "Fleet", false)).To(Equal("https://bitbucket.example.com/scm/plat/fleet.git"))
+	g.Expect(bitbucket.RepoUrl("bitbucket.example.com", "janedoe", "fleet", true)).To(Equal("https://bitbucket.example.com/scm/~janedoe/fleet.git"))
+}
@@ -0,0 +1,91 @@
+package codecommit
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+const (
+	sigV4AuthName   = "http-sigv4-auth"
+	sigV4Algorithm  = "AWS4-HMAC-SHA256"
+	sigV4TimeFormat = "20060102T150405"
+	sigV4DateFormat = "20060102"
+)
+
+// SigV4Auth authenticates git HTTPS requests to CodeCommit with a password derived from a SigV4 signature
+// of the repository path, the same way the git-remote-codecommit helper does. The signed password is only
+// valid for a short period of time, so it's computed again for every request.
+type SigV4Auth struct {
+	credentials aws.Credentials
+	region      string
+	repository  string
+	now         func() time.Time
+}
+
+// NewSigV4Auth builds a SigV4Auth for a CodeCommit repository.
+func NewSigV4Auth(credentials aws.Credentials, region, repository string) *SigV4Auth {
+	return &SigV4Auth{
+		credentials: credentials,
+		region:      region,
+		repository:  repository,
+		now:         time.Now,
+	}
+}
+
+// SetAuth sets the signed basic auth credentials in the request.
+func (a *SigV4Auth) SetAuth(r *http.Request) {
+	r.SetBasicAuth(a.Username(), a.Password(a.now()))
+}
+
+func (a *SigV4Auth) Name() string {
+	return sigV4AuthName
+}
+
+func (a *SigV4Auth) String() string {
+	return fmt.Sprintf("%s - %s:%s", a.Name(), a.credentials.AccessKeyID, "*******")
+}
+
+// Username returns the access key id, followed by the session token for temporary credentials.
+func (a *SigV4Auth) Username() string {
+	if a.credentials.SessionToken != "" {
+		return a.credentials.AccessKeyID + "%" + a.credentials.SessionToken
+	}
+	return a.credentials.AccessKeyID
+}
+
+// Password returns the SigV4 signed password for the repository at the given time.
+func (a *SigV4Auth) Password(t time.Time) string {
+	t = t.UTC()
+	timestamp := t.Format(sigV4TimeFormat)
+	date := t.Format(sigV4DateFormat)
+	host := fmt.Sprintf(codeCommitGitHostTemplate, a.region)
+	path := fmt.Sprintf(codeCommitRepoPathTemplate, a.repository)
+
+	canonicalRequest := fmt.Sprintf("GIT\n%s\n\nhost:%s\n\nhost\n", path, host)
+	scope := fmt.Sprintf("%s/%s/%s/aws4_request", date, a.region, signingService)
+	stringToSign := fmt.Sprintf("%s\n%sZ\n%s\n%s", sigV4Algorithm, timestamp, scope, hashHex(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+a.credentials.SecretAccessKey), date)
+	key = hmacSHA256(key, a.region)
+	key = hmacSHA256(key, signingService)
+	key = hmacSHA256(key, "aws4_request")
+
+	return timestamp + "Z" + hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func hashHex(data string) string {
+	h := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(h[:])
+}
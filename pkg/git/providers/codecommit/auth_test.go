@@ -0,0 +1,62 @@
+package codecommit_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/git/providers/codecommit"
+)
+
+func TestSigV4AuthPassword(t *testing.T) {
+	g := NewWithT(t)
+	auth := codecommit.NewSigV4Auth(testCredentials, "us-west-2", "fleet")
+
+	g.Expect(auth.Password(time.Date(2022, 10, 1, 12, 30, 45, 0, time.UTC))).To(
+		Equal("20221001T123045Zf8a030e9e4a65380e76f54e9d8a9bb316dccb0ad809787d366f9fafb274b3bd0"),
+	)
+}
+
+func TestSigV4AuthUsername(t *testing.T) {
+	tests := []struct {
+		testName    string
+		credentials aws.Credentials
+		want        string
+	}{
+		{
+			testName:    "long term credentials",
+			credentials: aws.Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"},
+			want:        "AKIDEXAMPLE",
+		},
+		{
+			testName:    "temporary credentials",
+			credentials: aws.Credentials{AccessKeyID: "ASIAEXAMPLE", SecretAccessKey: "secret", SessionToken: "token"},
+			want:        "ASIAEXAMPLE%token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(codecommit.NewSigV4Auth(tt.credentials, "us-west-2", "fleet").Username()).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestSigV4AuthSetAuth(t *testing.T) {
+	g := NewWithT(t)
+	auth := codecommit.NewSigV4Auth(testCredentials, "us-west-2", "fleet")
+	req, err := http.NewRequest(http.MethodGet, "https://git-codecommit.us-west-2.amazonaws.com/v1/repos/fleet/info/refs", nil)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	auth.SetAuth(req)
+
+	username, password, ok := req.BasicAuth()
+	g.Expect(ok).To(BeTrue())
+	g.Expect(username).To(Equal("AKIDEXAMPLE"))
+	g.Expect(password).To(MatchRegexp(`^\d{8}T\d{6}Z[0-9a-f]{64}$`))
+	g.Expect(auth.String()).NotTo(ContainSubstring(testCredentials.SecretAccessKey))
+}
@@ -0,0 +1,300 @@
+package codecommit
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	GitProviderName            = "codecommit"
+	EksaCodeCommitGitUsername  = "EKSA_CODECOMMIT_GIT_USERNAME"
+	EksaCodeCommitGitPassword  = "EKSA_CODECOMMIT_GIT_PASSWORD"
+	codeCommitApiUrlTemplate   = "https://codecommit.%s.amazonaws.com"
+	codeCommitGitHostTemplate  = "git-codecommit.%s.amazonaws.com"
+	codeCommitRepoPathTemplate = "/v1/repos/%s"
+	codeCommitTargetPrefix     = "CodeCommit_20150413."
+	codeCommitPullRequestUrl   = "https://%s.console.aws.amazon.com/codesuite/codecommit/repositories/%s/pull-requests/%d"
+	signingService             = "codecommit"
+)
+
+// HTTPClient represents the attributes that the CodeCommit provider requires of a client to interact with the CodeCommit API.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type codeCommitProvider struct {
+	httpClient  HTTPClient
+	config      *v1alpha1.CodeCommitProviderConfig
+	credentials aws.Credentials
+	signer      *v4.Signer
+	baseUrl     string
+}
+
+type repositoryMetadata struct {
+	AccountId      string `json:"accountId"`
+	RepositoryName string `json:"repositoryName"`
+	CloneUrlHttp   string `json:"cloneUrlHttp"`
+}
+
+type repositoryResponse struct {
+	RepositoryMetadata repositoryMetadata `json:"repositoryMetadata"`
+}
+
+type pullRequestTarget struct {
+	RepositoryName       string `json:"repositoryName"`
+	SourceReference      string `json:"sourceReference"`
+	DestinationReference string `json:"destinationReference"`
+}
+
+type pullRequestResponse struct {
+	PullRequest struct {
+		PullRequestId string `json:"pullRequestId"`
+	} `json:"pullRequest"`
+}
+
+type apiError struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("codecommit api returned status %d: %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+func New(httpClient HTTPClient, config *v1alpha1.CodeCommitProviderConfig, credentials aws.Credentials) (*codeCommitProvider, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &codeCommitProvider{
+		httpClient:  httpClient,
+		config:      config,
+		credentials: credentials,
+		signer:      v4.NewSigner(),
+		baseUrl:     fmt.Sprintf(codeCommitApiUrlTemplate, config.Region),
+	}, nil
+}
+
+// CreateRepo creates an empty CodeCommit repository in the configured region.
+// Owner and privacy are ignored since CodeCommit repositories are always private to the AWS account.
+func (c *codeCommitProvider) CreateRepo(ctx context.Context, opts git.CreateRepoOpts) (*git.Repository, error) {
+	logger.V(3).Info("Attempting to create new CodeCommit repo", "repo", opts.Name, "region", c.config.Region)
+	body := map[string]string{"repositoryName": opts.Name}
+	if opts.Description != "" {
+		body["repositoryDescription"] = opts.Description
+	}
+
+	r := &repositoryResponse{}
+	if err := c.do(ctx, "CreateRepository", body, r); err != nil {
+		return nil, fmt.Errorf("failed to create new CodeCommit repo %s: %v", opts.Name, err)
+	}
+	logger.V(3).Info("Successfully created new CodeCommit repo", "repo", r.RepositoryMetadata.RepositoryName, "region", c.config.Region)
+	return r.RepositoryMetadata.toRepository(), nil
+}
+
+// GetRepo describes a remote repository, return the repo name if it exists.
+// If the repo does not exist, a nil repo is returned.
+func (c *codeCommitProvider) GetRepo(ctx context.Context) (*git.Repository, error) {
+	r := c.config.Repository
+	logger.V(3).Info("Describing CodeCommit repository", "name", r, "region", c.config.Region)
+	repo := &repositoryResponse{}
+	err := c.do(ctx, "GetRepository", map[string]string{"repositoryName": r}, repo)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error when describing repository %s: %w", r, err)
+	}
+	return repo.RepositoryMetadata.toRepository(), nil
+}
+
+// AddDeployKeyToRepo is not supported, CodeCommit only allows ssh keys to be registered for IAM users.
+func (c *codeCommitProvider) AddDeployKeyToRepo(ctx context.Context, opts git.AddDeployKeyOpts) error {
+	return errors.New("codecommit doesn't support repository deploy keys")
+}
+
+// DeleteRepo deletes a CodeCommit repository.
+func (c *codeCommitProvider) DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts) error {
+	if err := c.do(ctx, "DeleteRepository", map[string]string{"repositoryName": opts.Repository}, nil); err != nil {
+		return fmt.Errorf("deleting repository %s: %v", opts.Repository, err)
+	}
+	return nil
+}
+
+// CreatePullRequest opens a pull request in the configured repository.
+func (c *codeCommitProvider) CreatePullRequest(ctx context.Context, opts git.CreatePullRequestOpts) (*git.PullRequest, error) {
+	body := map[string]interface{}{
+		"title":       opts.Title,
+		"description": opts.Description,
+		"targets": []pullRequestTarget{{
+			RepositoryName:       c.config.Repository,
+			SourceReference:      opts.Head,
+			DestinationReference: opts.Base,
+		}},
+	}
+
+	r := &pullRequestResponse{}
+	if err := c.do(ctx, "CreatePullRequest", body, r); err != nil {
+		return nil, fmt.Errorf("creating pull request in repository %s: %v", c.config.Repository, err)
+	}
+
+	id, err := strconv.Atoi(r.PullRequest.PullRequestId)
+	if err != nil {
+		return nil, fmt.Errorf("parsing pull request id %s: %v", r.PullRequest.PullRequestId, err)
+	}
+	return &git.PullRequest{
+		Number: id,
+		Url:    fmt.Sprintf(codeCommitPullRequestUrl, c.config.Region, c.config.Repository, id),
+	}, nil
+}
+
+// Validate validates the AWS credentials can access CodeCommit in the configured region.
+func (c *codeCommitProvider) Validate(ctx context.Context) error {
+	if err := c.do(ctx, "ListRepositories", map[string]string{}, nil); err != nil {
+		return fmt.Errorf("the AWS credentials don't have proper access to CodeCommit in region %s, %v", c.config.Region, err)
+	}
+	logger.MarkPass("AWS credentials have access to CodeCommit")
+	return nil
+}
+
+// PathExists checks if a folder or a file exists in the branch of a repository.
+// Owner is ignored since repositories are scoped by the AWS account and region.
+func (c *codeCommitProvider) PathExists(ctx context.Context, owner, repo, branch, path string) (bool, error) {
+	p := "/" + strings.Trim(path, "/")
+	err := c.do(ctx, "GetFolder", map[string]string{"repositoryName": repo, "commitSpecifier": branch, "folderPath": p}, nil)
+	if isNotFound(err) {
+		err = c.do(ctx, "GetFile", map[string]string{"repositoryName": repo, "commitSpecifier": branch, "filePath": p}, nil)
+	}
+	if isNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed checking if path %s exists in remote codecommit repository: %v", path, err)
+	}
+	return true, nil
+}
+
+func (c *codeCommitProvider) do(ctx context.Context, operation string, body, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("marshalling codecommit request body: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseUrl+"/", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", codeCommitTargetPrefix+operation)
+
+	payloadHash := sha256.Sum256(payload)
+	if err := c.signer.SignHTTP(ctx, c.credentials, req, hex.EncodeToString(payloadHash[:]), signingService, c.config.Region, time.Now()); err != nil {
+		return fmt.Errorf("signing codecommit request: %v", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading codecommit response: %v", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newApiError(resp.StatusCode, respBody)
+	}
+
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("unmarshalling codecommit response: %v", err)
+	}
+	return nil
+}
+
+func newApiError(statusCode int, body []byte) *apiError {
+	e := struct {
+		Type    string `json:"__type"`
+		Message string `json:"message"`
+	}{}
+	if err := json.Unmarshal(body, &e); err != nil {
+		return &apiError{StatusCode: statusCode, Message: strings.TrimSpace(string(body))}
+	}
+	// The error type can be prefixed by the namespace of the service, i.e. com.amazonaws.codecommit#RepositoryDoesNotExistException
+	code := e.Type[strings.LastIndex(e.Type, "#")+1:]
+	return &apiError{StatusCode: statusCode, Code: code, Message: e.Message}
+}
+
+func (r repositoryMetadata) toRepository() *git.Repository {
+	return &git.Repository{
+		Name:         r.RepositoryName,
+		Organization: r.AccountId,
+		CloneUrl:     r.CloneUrlHttp,
+	}
+}
+
+var notFoundCodes = map[string]bool{
+	"RepositoryDoesNotExistException": true,
+	"FolderDoesNotExistException":     true,
+	"FileDoesNotExistException":       true,
+	// Returned for a branch without commits, which is the case of a new repository
+	"CommitDoesNotExistException": true,
+}
+
+func isNotFound(err error) bool {
+	var e *apiError
+	return errors.As(err, &e) && notFoundCodes[e.Code]
+}
+
+// RetrieveCredentials retrieves AWS credentials for the region from the default credentials chain.
+func RetrieveCredentials(ctx context.Context, region string) (aws.Credentials, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+	if err != nil {
+		return aws.Credentials{}, fmt.Errorf("loading aws config for codecommit: %v", err)
+	}
+	creds, err := cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return aws.Credentials{}, fmt.Errorf("retrieving aws credentials for codecommit: %v", err)
+	}
+	return creds, nil
+}
+
+// GetGitCredentialsFromEnv returns the CodeCommit HTTPS Git credentials used by Flux to sync the repository from the cluster.
+// Unlike SigV4 signed passwords, these credentials don't expire.
+func GetGitCredentialsFromEnv() (username, password string, err error) {
+	logger.V(4).Info("Checking validity of CodeCommit HTTPS Git credentials environment variables", "env vars", []string{EksaCodeCommitGitUsername, EksaCodeCommitGitPassword})
+	for _, env := range []string{EksaCodeCommitGitUsername, EksaCodeCommitGitPassword} {
+		if val, ok := os.LookupEnv(env); !ok || len(val) == 0 {
+			return "", "", fmt.Errorf("codecommit git credentials environment variable %s is invalid; could not get var from environment", env)
+		}
+	}
+	return os.Getenv(EksaCodeCommitGitUsername), os.Getenv(EksaCodeCommitGitPassword), nil
+}
+
+// RepoUrl returns the https clone url of a CodeCommit repository.
+func RepoUrl(region, repo string) string {
+	return "https://" + fmt.Sprintf(codeCommitGitHostTemplate, region) + fmt.Sprintf(codeCommitRepoPathTemplate, repo)
+}
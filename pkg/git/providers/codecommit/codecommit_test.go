@@ -0,0 +1,312 @@
+package codecommit_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/git/providers/codecommit"
+)
+
+var testCredentials = aws.Credentials{
+	AccessKeyID:     "AKIDEXAMPLE",
+	SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+}
+
+// redirectClient sends the requests for the CodeCommit api to a test server.
+type redirectClient struct {
+	server *httptest.Server
+}
+
+func (c *redirectClient) Do(req *http.Request) (*http.Response, error) {
+	u, err := url.Parse(c.server.URL)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.Scheme = u.Scheme
+	req.URL.Host = u.Host
+	return c.server.Client().Do(req)
+}
+
+type operationHandler func(w http.ResponseWriter, body map[string]string)
+
+type codeCommitTest struct {
+	*WithT
+	ctx        context.Context
+	operations map[string]operationHandler
+	provider   git.ProviderClient
+}
+
+func newCodeCommitTest(t *testing.T) *codeCommitTest {
+	tt := &codeCommitTest{
+		WithT:      NewWithT(t),
+		ctx:        context.Background(),
+		operations: map[string]operationHandler{},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") ||
+			!strings.Contains(r.Header.Get("Authorization"), "/us-west-2/codecommit/aws4_request") {
+			writeError(w, http.StatusForbidden, "UnrecognizedClientException", "invalid signature")
+			return
+		}
+		operation := strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "CodeCommit_20150413.")
+		handler, ok := tt.operations[operation]
+		if !ok {
+			writeError(w, http.StatusBadRequest, "RepositoryDoesNotExistException", "fleet does not exist")
+			return
+		}
+		body := map[string]string{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		handler(w, body)
+	}))
+	t.Cleanup(server.Close)
+
+	config := &v1alpha1.CodeCommitProviderConfig{
+		Region:     "us-west-2",
+		Repository: "fleet",
+	}
+	provider, err := codecommit.New(&redirectClient{server: server}, config, testCredentials)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tt.provider = provider
+
+	return tt
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"__type": "com.amazonaws.codecommit#" + code, "message": message})
+}
+
+func repositoryResponse() map[string]interface{} {
+	return map[string]interface{}{
+		"repositoryMetadata": map[string]string{
+			"accountId":      "123456789012",
+			"repositoryName": "fleet",
+			"cloneUrlHttp":   "https://git-codecommit.us-west-2.amazonaws.com/v1/repos/fleet",
+		},
+	}
+}
+
+func TestCodeCommitGetRepoSuccess(t *testing.T) {
+	g := newCodeCommitTest(t)
+	g.operations["GetRepository"] = func(w http.ResponseWriter, body map[string]string) {
+		g.Expect(body).To(HaveKeyWithValue("repositoryName", "fleet"))
+		writeJSON(w, repositoryResponse())
+	}
+
+	repo, err := g.provider.GetRepo(g.ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo).To(Equal(&git.Repository{
+		Name:         "fleet",
+		Organization: "123456789012",
+		CloneUrl:     "https://git-codecommit.us-west-2.amazonaws.com/v1/repos/fleet",
+	}))
+}
+
+func TestCodeCommitGetRepoNotFound(t *testing.T) {
+	g := newCodeCommitTest(t)
+
+	repo, err := g.provider.GetRepo(g.ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo).To(BeNil())
+}
+
+func TestCodeCommitGetRepoError(t *testing.T) {
+	g := newCodeCommitTest(t)
+	g.operations["GetRepository"] = func(w http.ResponseWriter, body map[string]string) {
+		writeError(w, http.StatusBadRequest, "EncryptionKeyAccessDeniedException", "access denied")
+	}
+
+	_, err := g.provider.GetRepo(g.ctx)
+	g.Expect(err).To(MatchError(ContainSubstring("EncryptionKeyAccessDeniedException")))
+}
+
+func TestCodeCommitCreateRepo(t *testing.T) {
+	g := newCodeCommitTest(t)
+	var received map[string]string
+	g.operations["CreateRepository"] = func(w http.ResponseWriter, body map[string]string) {
+		received = body
+		writeJSON(w, repositoryResponse())
+	}
+
+	repo, err := g.provider.CreateRepo(g.ctx, git.CreateRepoOpts{Name: "fleet", Description: "cluster configuration"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo.Name).To(Equal("fleet"))
+	g.Expect(received).To(Equal(map[string]string{"repositoryName": "fleet", "repositoryDescription": "cluster configuration"}))
+}
+
+func TestCodeCommitValidateSuccess(t *testing.T) {
+	g := newCodeCommitTest(t)
+	g.operations["ListRepositories"] = func(w http.ResponseWriter, body map[string]string) {
+		writeJSON(w, map[string]interface{}{"repositories": []interface{}{}})
+	}
+
+	g.Expect(g.provider.Validate(g.ctx)).To(Succeed())
+}
+
+func TestCodeCommitValidateAccessDenied(t *testing.T) {
+	g := newCodeCommitTest(t)
+	g.operations["ListRepositories"] = func(w http.ResponseWriter, body map[string]string) {
+		writeError(w, http.StatusBadRequest, "AccessDeniedException", "not authorized")
+	}
+
+	g.Expect(g.provider.Validate(g.ctx)).To(MatchError(ContainSubstring("don't have proper access to CodeCommit in region us-west-2")))
+}
+
+func TestCodeCommitPathExists(t *testing.T) {
+	tests := []struct {
+		testName string
+		path     string
+		want     bool
+	}{
+		{testName: "folder", path: "clusters/mgmt", want: true},
+		{testName: "file", path: "clusters/mgmt/kustomization.yaml", want: true},
+		{testName: "missing", path: "clusters/other", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := newCodeCommitTest(t)
+			g.operations["GetFolder"] = func(w http.ResponseWriter, body map[string]string) {
+				g.Expect(body).To(HaveKeyWithValue("commitSpecifier", "main"))
+				if body["folderPath"] != "/clusters/mgmt" {
+					writeError(w, http.StatusBadRequest, "FolderDoesNotExistException", "folder does not exist")
+					return
+				}
+				writeJSON(w, map[string]string{"folderPath": "clusters/mgmt"})
+			}
+			g.operations["GetFile"] = func(w http.ResponseWriter, body map[string]string) {
+				if body["filePath"] != "/clusters/mgmt/kustomization.yaml" {
+					writeError(w, http.StatusBadRequest, "FileDoesNotExistException", "file does not exist")
+					return
+				}
+				writeJSON(w, map[string]string{"filePath": "clusters/mgmt/kustomization.yaml"})
+			}
+
+			exists, err := g.provider.PathExists(g.ctx, "", "fleet", "main", tt.path)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(exists).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestCodeCommitPathExistsEmptyRepository(t *testing.T) {
+	g := newCodeCommitTest(t)
+	g.operations["GetFolder"] = func(w http.ResponseWriter, body map[string]string) {
+		writeError(w, http.StatusBadRequest, "CommitDoesNotExistException", "main does not exist")
+	}
+	g.operations["GetFile"] = g.operations["GetFolder"]
+
+	exists, err := g.provider.PathExists(g.ctx, "", "fleet", "main", "clusters")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(exists).To(BeFalse())
+}
+
+func TestCodeCommitPathExistsError(t *testing.T) {
+	g := newCodeCommitTest(t)
+	g.operations["GetFolder"] = func(w http.ResponseWriter, body map[string]string) {
+		writeError(w, http.StatusInternalServerError, "InternalFailure", "")
+	}
+
+	_, err := g.provider.PathExists(g.ctx, "", "fleet", "main", "clusters")
+	g.Expect(err).To(MatchError(ContainSubstring("status 500")))
+}
+
+func TestCodeCommitDeleteRepo(t *testing.T) {
+	g := newCodeCommitTest(t)
+	deleted := false
+	g.operations["DeleteRepository"] = func(w http.ResponseWriter, body map[string]string) {
+		deleted = body["repositoryName"] == "fleet"
+		writeJSON(w, map[string]string{"repositoryId": "id"})
+	}
+
+	g.Expect(g.provider.DeleteRepo(g.ctx, git.DeleteRepoOpts{Repository: "fleet"})).To(Succeed())
+	g.Expect(deleted).To(BeTrue())
+}
+
+func TestCodeCommitCreatePullRequest(t *testing.T) {
+	g := newCodeCommitTest(t)
+	g.operations["CreatePullRequest"] = func(w http.ResponseWriter, body map[string]string) {
+		g.Expect(body).To(HaveKeyWithValue("title", "Update cluster config"))
+		writeJSON(w, map[string]interface{}{"pullRequest": map[string]string{"pullRequestId": "7"}})
+	}
+
+	pr, err := g.provider.CreatePullRequest(g.ctx, git.CreatePullRequestOpts{Title: "Update cluster config", Head: "eksa/mgmt", Base: "main"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pr).To(Equal(&git.PullRequest{
+		Number: 7,
+		Url:    "https://us-west-2.console.aws.amazon.com/codesuite/codecommit/repositories/fleet/pull-requests/7",
+	}))
+}
+
+func TestCodeCommitCreatePullRequestError(t *testing.T) {
+	g := newCodeCommitTest(t)
+	g.operations["CreatePullRequest"] = func(w http.ResponseWriter, body map[string]string) {
+		writeError(w, http.StatusBadRequest, "ReferenceDoesNotExistException", "eksa/mgmt does not exist")
+	}
+
+	_, err := g.provider.CreatePullRequest(g.ctx, git.CreatePullRequestOpts{Head: "eksa/mgmt", Base: "main"})
+	g.Expect(err).To(MatchError(ContainSubstring("creating pull request in repository fleet")))
+}
+
+func TestCodeCommitAddDeployKeyNotSupported(t *testing.T) {
+	g := newCodeCommitTest(t)
+
+	g.Expect(g.provider.AddDeployKeyToRepo(g.ctx, git.AddDeployKeyOpts{})).To(MatchError(ContainSubstring("doesn't support repository deploy keys")))
+}
+
+func TestGetGitCredentialsFromEnv(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(codecommit.EksaCodeCommitGitUsername, "jane-at-123456789012")
+	t.Setenv(codecommit.EksaCodeCommitGitPassword, "secret")
+
+	username, password, err := codecommit.GetGitCredentialsFromEnv()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(username).To(Equal("jane-at-123456789012"))
+	g.Expect(password).To(Equal("secret"))
+}
+
+func TestGetGitCredentialsFromEnvMissing(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(codecommit.EksaCodeCommitGitUsername, "jane-at-123456789012")
+	t.Setenv(codecommit.EksaCodeCommitGitPassword, "")
+
+	_, _, err := codecommit.GetGitCredentialsFromEnv()
+	g.Expect(err).To(MatchError(ContainSubstring(codecommit.EksaCodeCommitGitPassword)))
+}
+
+func TestRetrieveCredentials(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv("AWS_CONFIG_FILE", "testdata/nonexistent")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", "testdata/nonexistent")
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_SESSION_TOKEN", "token")
+
+	creds, err := codecommit.RetrieveCredentials(context.Background(), "us-west-2")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(creds.AccessKeyID).To(Equal("AKIDEXAMPLE"))
+	g.Expect(creds.SessionToken).To(Equal("token"))
+}
+
+func TestRepoUrl(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(codecommit.RepoUrl("us-west-2", "fleet")).To(Equal("https://git-codecommit.us-west-2.amazonaws.com/v1/repos/fleet"))
+}
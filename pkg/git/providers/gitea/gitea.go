@@ -0,0 +1,284 @@
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	GitProviderName   = "gitea"
+	EksaGiteaTokenEnv = "EKSA_GITEA_TOKEN"
+	GiteaTokenEnv     = "GITEA_TOKEN"
+	giteaUrlTemplate  = "https://%v/%v/%v.git"
+)
+
+// HTTPClient represents the attributes that the Gitea provider requires of a client to interact with the Gitea REST API.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type giteaProvider struct {
+	httpClient HTTPClient
+	config     *v1alpha1.GiteaProviderConfig
+	auth       git.TokenAuth
+	baseUrl    string
+}
+
+type repository struct {
+	Name     string `json:"name"`
+	CloneUrl string `json:"clone_url"`
+	Owner    struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+}
+
+type pullRequest struct {
+	Number  int    `json:"number"`
+	HtmlUrl string `json:"html_url"`
+}
+
+type user struct {
+	Login string `json:"login"`
+}
+
+type apiError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("gitea api returned status %d: %s", e.StatusCode, e.Message)
+}
+
+func New(httpClient HTTPClient, config *v1alpha1.GiteaProviderConfig, auth git.TokenAuth) (*giteaProvider, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &giteaProvider{
+		httpClient: httpClient,
+		config:     config,
+		auth:       auth,
+		baseUrl:    fmt.Sprintf("https://%s/api/v1", config.Hostname),
+	}, nil
+}
+
+// CreateRepo creates a Gitea repository owned by the authenticated user if personal, or by the owner organization otherwise.
+func (g *giteaProvider) CreateRepo(ctx context.Context, opts git.CreateRepoOpts) (*git.Repository, error) {
+	logger.V(3).Info("Attempting to create new Gitea repo", "repo", opts.Name, "owner", opts.Owner)
+	body := map[string]interface{}{
+		"name":      opts.Name,
+		"private":   opts.Privacy,
+		"auto_init": opts.AutoInit,
+	}
+	if opts.Description != "" {
+		body["description"] = opts.Description
+	}
+
+	path := "/orgs/" + url.PathEscape(opts.Owner) + "/repos"
+	if opts.Personal {
+		path = "/user/repos"
+	}
+
+	r := &repository{}
+	if err := g.do(ctx, http.MethodPost, path, body, r); err != nil {
+		return nil, fmt.Errorf("failed to create new Gitea repo %s: %v", opts.Name, err)
+	}
+	logger.V(3).Info("Successfully created new Gitea repo", "repo", r.Name, "owner", r.Owner.Login)
+	return r.toRepository(opts.Personal), nil
+}
+
+// GetRepo describes a remote repository, return the repo name if it exists.
+// If the repo does not exist, a nil repo is returned.
+func (g *giteaProvider) GetRepo(ctx context.Context) (*git.Repository, error) {
+	r := g.config.Repository
+	o := g.config.Owner
+	logger.V(3).Info("Describing Gitea repository", "name", r, "owner", o)
+	repo := &repository{}
+	err := g.do(ctx, http.MethodGet, repoPath(o, r), nil, repo)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error when describing repository %s: %w", r, err)
+	}
+	return repo.toRepository(g.config.Personal), nil
+}
+
+func (g *giteaProvider) AddDeployKeyToRepo(ctx context.Context, opts git.AddDeployKeyOpts) error {
+	logger.V(3).Info("Adding deploy key to repository", "repository", opts.Repository, "owner", opts.Owner)
+	body := map[string]interface{}{
+		"title":     opts.Title,
+		"key":       opts.Key,
+		"read_only": opts.ReadOnly,
+	}
+	return g.do(ctx, http.MethodPost, repoPath(opts.Owner, opts.Repository)+"/keys", body, nil)
+}
+
+// DeleteRepo deletes a Gitea repository.
+func (g *giteaProvider) DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts) error {
+	if err := g.do(ctx, http.MethodDelete, repoPath(opts.Owner, opts.Repository), nil, nil); err != nil {
+		return fmt.Errorf("deleting repository %s: %v", opts.Repository, err)
+	}
+	return nil
+}
+
+// ArchiveRepo archives a Gitea repository, making it read-only.
+func (g *giteaProvider) ArchiveRepo(ctx context.Context, opts git.ArchiveRepoOpts) error {
+	if err := g.do(ctx, http.MethodPatch, repoPath(opts.Owner, opts.Repository), map[string]interface{}{"archived": true}, nil); err != nil {
+		return fmt.Errorf("archiving repository %s: %v", opts.Repository, err)
+	}
+	return nil
+}
+
+// CreatePullRequest opens a pull request in the configured repository.
+func (g *giteaProvider) CreatePullRequest(ctx context.Context, opts git.CreatePullRequestOpts) (*git.PullRequest, error) {
+	logger.V(3).Info("Creating Gitea pull request", "repository", g.config.Repository, "owner", g.config.Owner, "head", opts.Head, "base", opts.Base)
+	body := map[string]interface{}{
+		"title": opts.Title,
+		"body":  opts.Description,
+		"head":  opts.Head,
+		"base":  opts.Base,
+	}
+
+	pr := &pullRequest{}
+	if err := g.do(ctx, http.MethodPost, repoPath(g.config.Owner, g.config.Repository)+"/pulls", body, pr); err != nil {
+		return nil, fmt.Errorf("creating pull request in repository %s: %v", g.config.Repository, err)
+	}
+	return &git.PullRequest{Number: pr.Number, Url: pr.HtmlUrl}, nil
+}
+
+// Validate validates the Gitea setup and access.
+func (g *giteaProvider) Validate(ctx context.Context) error {
+	u := &user{}
+	if err := g.do(ctx, http.MethodGet, "/user", nil, u); err != nil {
+		return fmt.Errorf("validating Gitea access token: %v", err)
+	}
+	logger.MarkPass("Gitea access token is valid")
+
+	if g.config.Personal {
+		if !strings.EqualFold(g.config.Owner, u.Login) {
+			return fmt.Errorf("the authenticated Gitea user %s and owner %s specified in the EKS-A gitops spec don't match; confirm access token owner is %s", u.Login, g.config.Owner, g.config.Owner)
+		}
+		return nil
+	}
+
+	if err := g.do(ctx, http.MethodGet, "/orgs/"+url.PathEscape(g.config.Owner), nil, nil); err != nil {
+		return fmt.Errorf("the authenticated gitea user doesn't have proper access to organization %s, %v", g.config.Owner, err)
+	}
+	return nil
+}
+
+func (g *giteaProvider) PathExists(ctx context.Context, owner, repo, branch, path string) (bool, error) {
+	query := url.Values{"ref": []string{branch}}
+	err := g.do(ctx, http.MethodGet, repoPath(owner, repo)+"/contents/"+escapePath(path)+"?"+query.Encode(), nil, nil)
+	if isNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed checking if path %s exists in remote gitea repository: %v", path, err)
+	}
+	return true, nil
+}
+
+func (g *giteaProvider) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		m, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshalling gitea request body: %v", err)
+		}
+		reqBody = bytes.NewReader(m)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, g.baseUrl+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "token "+g.auth.Token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading gitea response: %v", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &apiError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(respBody))}
+	}
+
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("unmarshalling gitea response: %v", err)
+	}
+	return nil
+}
+
+func (r *repository) toRepository(personal bool) *git.Repository {
+	repo := &git.Repository{
+		Name:     r.Name,
+		CloneUrl: r.CloneUrl,
+	}
+	if personal {
+		repo.Owner = r.Owner.Login
+	} else {
+		repo.Organization = r.Owner.Login
+	}
+	return repo
+}
+
+func repoPath(owner, repo string) string {
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo)
+}
+
+func escapePath(p string) string {
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+func isNotFound(err error) bool {
+	var e *apiError
+	return errors.As(err, &e) && e.StatusCode == http.StatusNotFound
+}
+
+func GetGiteaAccessTokenFromEnv() (string, error) {
+	logger.V(4).Info("Checking validity of Gitea Access Token environment variable", "env var", EksaGiteaTokenEnv)
+	val, ok := os.LookupEnv(EksaGiteaTokenEnv)
+	if !ok || len(val) == 0 {
+		return "", fmt.Errorf("gitea access token environment variable %s is invalid; could not get var from environment", EksaGiteaTokenEnv)
+	}
+	if err := os.Setenv(GiteaTokenEnv, val); err != nil {
+		return "", fmt.Errorf("unable to set %s: %v", GiteaTokenEnv, err)
+	}
+	return val, nil
+}
+
+// RepoUrl returns the https clone url of a Gitea repository.
+func RepoUrl(hostname, owner, repo string) string {
+	return fmt.Sprintf(giteaUrlTemplate, hostname, owner, repo)
+}
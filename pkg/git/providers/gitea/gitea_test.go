@@ -0,0 +1,290 @@
+package gitea_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitea"
+)
+
+const testToken = "gitea-test-token"
+
+type giteaTest struct {
+	*WithT
+	ctx      context.Context
+	mux      *http.ServeMux
+	provider git.ProviderClient
+}
+
+func newGiteaTest(t *testing.T, personal bool) *giteaTest {
+	mux := http.NewServeMux()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token "+testToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	config := &v1alpha1.GiteaProviderConfig{
+		Owner:      "platform",
+		Repository: "fleet",
+		Hostname:   strings.TrimPrefix(server.URL, "https://"),
+		Personal:   personal,
+	}
+	provider, err := gitea.New(server.Client(), config, git.TokenAuth{Username: "platform", Token: testToken})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &giteaTest{
+		WithT:    NewWithT(t),
+		ctx:      context.Background(),
+		mux:      mux,
+		provider: provider,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func repoResponse() map[string]interface{} {
+	return map[string]interface{}{
+		"name":      "fleet",
+		"clone_url": "https://gitea.lab.local/platform/fleet.git",
+		"owner":     map[string]string{"login": "platform"},
+	}
+}
+
+func TestGiteaGetRepoSuccess(t *testing.T) {
+	g := newGiteaTest(t, false)
+	g.mux.HandleFunc("/api/v1/repos/platform/fleet", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, repoResponse())
+	})
+
+	repo, err := g.provider.GetRepo(g.ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo).To(Equal(&git.Repository{
+		Name:         "fleet",
+		Organization: "platform",
+		CloneUrl:     "https://gitea.lab.local/platform/fleet.git",
+	}))
+}
+
+func TestGiteaGetRepoNotFound(t *testing.T) {
+	g := newGiteaTest(t, false)
+
+	repo, err := g.provider.GetRepo(g.ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo).To(BeNil())
+}
+
+func TestGiteaCreateRepo(t *testing.T) {
+	tests := []struct {
+		testName string
+		personal bool
+		path     string
+	}{
+		{testName: "organization", personal: false, path: "/api/v1/orgs/platform/repos"},
+		{testName: "personal", personal: true, path: "/api/v1/user/repos"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := newGiteaTest(t, tt.personal)
+			var body map[string]interface{}
+			g.mux.HandleFunc(tt.path, func(w http.ResponseWriter, r *http.Request) {
+				g.Expect(r.Method).To(Equal(http.MethodPost))
+				g.Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+				w.WriteHeader(http.StatusCreated)
+				writeJSON(w, repoResponse())
+			})
+
+			repo, err := g.provider.CreateRepo(g.ctx, git.CreateRepoOpts{Name: "fleet", Owner: "platform", Personal: tt.personal, Privacy: true, AutoInit: true})
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(repo.Name).To(Equal("fleet"))
+			g.Expect(body).To(HaveKeyWithValue("private", true))
+			g.Expect(body).To(HaveKeyWithValue("auto_init", true))
+		})
+	}
+}
+
+func TestGiteaCreateRepoError(t *testing.T) {
+	g := newGiteaTest(t, false)
+	g.mux.HandleFunc("/api/v1/orgs/platform/repos", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	_, err := g.provider.CreateRepo(g.ctx, git.CreateRepoOpts{Name: "fleet", Owner: "platform"})
+	g.Expect(err).To(MatchError(ContainSubstring("failed to create new Gitea repo fleet")))
+}
+
+func TestGiteaAddDeployKey(t *testing.T) {
+	g := newGiteaTest(t, false)
+	var body map[string]interface{}
+	g.mux.HandleFunc("/api/v1/repos/platform/fleet/keys", func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	g.Expect(g.provider.AddDeployKeyToRepo(g.ctx, git.AddDeployKeyOpts{Owner: "platform", Repository: "fleet", Key: "ssh-ed25519 AAAA", ReadOnly: true})).To(Succeed())
+	g.Expect(body).To(HaveKeyWithValue("read_only", true))
+}
+
+func TestGiteaCreatePullRequest(t *testing.T) {
+	g := newGiteaTest(t, false)
+	var body map[string]interface{}
+	g.mux.HandleFunc("/api/v1/repos/platform/fleet/pulls", func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.Method).To(Equal(http.MethodPost))
+		g.Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, map[string]interface{}{"number": 3, "html_url": "https://gitea.lab.local/platform/fleet/pulls/3"})
+	})
+
+	pr, err := g.provider.CreatePullRequest(g.ctx, git.CreatePullRequestOpts{Title: "Update cluster config", Head: "eksa/mgmt", Base: "main"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pr).To(Equal(&git.PullRequest{Number: 3, Url: "https://gitea.lab.local/platform/fleet/pulls/3"}))
+	g.Expect(body).To(HaveKeyWithValue("head", "eksa/mgmt"))
+	g.Expect(body).To(HaveKeyWithValue("base", "main"))
+}
+
+func TestGiteaCreatePullRequestError(t *testing.T) {
+	g := newGiteaTest(t, false)
+	g.mux.HandleFunc("/api/v1/repos/platform/fleet/pulls", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	})
+
+	_, err := g.provider.CreatePullRequest(g.ctx, git.CreatePullRequestOpts{Head: "eksa/mgmt", Base: "main"})
+	g.Expect(err).To(MatchError(ContainSubstring("creating pull request in repository fleet")))
+}
+
+func TestGiteaValidateOrganization(t *testing.T) {
+	g := newGiteaTest(t, false)
+	g.mux.HandleFunc("/api/v1/user", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{"login": "janedoe"})
+	})
+	g.mux.HandleFunc("/api/v1/orgs/platform", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{"username": "platform"})
+	})
+
+	g.Expect(g.provider.Validate(g.ctx)).To(Succeed())
+}
+
+func TestGiteaValidateOrganizationNoAccess(t *testing.T) {
+	g := newGiteaTest(t, false)
+	g.mux.HandleFunc("/api/v1/user", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{"login": "janedoe"})
+	})
+
+	g.Expect(g.provider.Validate(g.ctx)).To(MatchError(ContainSubstring("doesn't have proper access to organization platform")))
+}
+
+func TestGiteaValidatePersonalOwnerMismatch(t *testing.T) {
+	g := newGiteaTest(t, true)
+	g.mux.HandleFunc("/api/v1/user", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{"login": "janedoe"})
+	})
+
+	g.Expect(g.provider.Validate(g.ctx)).To(MatchError(ContainSubstring("don't match")))
+}
+
+func TestGiteaValidateInvalidToken(t *testing.T) {
+	g := newGiteaTest(t, true)
+	g.mux.HandleFunc("/api/v1/user", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	g.Expect(g.provider.Validate(g.ctx)).To(MatchError(ContainSubstring("validating Gitea access token")))
+}
+
+func TestGiteaPathExists(t *testing.T) {
+	tests := []struct {
+		testName string
+		path     string
+		want     bool
+	}{
+		{testName: "existing path", path: "clusters/mgmt", want: true},
+		{testName: "missing path", path: "clusters/other", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := newGiteaTest(t, false)
+			g.mux.HandleFunc("/api/v1/repos/platform/fleet/contents/clusters/mgmt", func(w http.ResponseWriter, r *http.Request) {
+				g.Expect(r.URL.Query().Get("ref")).To(Equal("main"))
+				writeJSON(w, []map[string]string{{"name": "kustomization.yaml"}})
+			})
+
+			exists, err := g.provider.PathExists(g.ctx, "platform", "fleet", "main", tt.path)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(exists).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestGiteaPathExistsError(t *testing.T) {
+	g := newGiteaTest(t, false)
+	g.mux.HandleFunc("/api/v1/repos/platform/fleet/contents/clusters", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	_, err := g.provider.PathExists(g.ctx, "platform", "fleet", "main", "clusters")
+	g.Expect(err).To(MatchError(ContainSubstring("status 500")))
+}
+
+func TestGiteaDeleteRepo(t *testing.T) {
+	g := newGiteaTest(t, false)
+	g.mux.HandleFunc("/api/v1/repos/platform/fleet", func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.Method).To(Equal(http.MethodDelete))
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	g.Expect(g.provider.DeleteRepo(g.ctx, git.DeleteRepoOpts{Owner: "platform", Repository: "fleet"})).To(Succeed())
+}
+
+func TestGiteaArchiveRepo(t *testing.T) {
+	g := newGiteaTest(t, false)
+	var body map[string]interface{}
+	g.mux.HandleFunc("/api/v1/repos/platform/fleet", func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.Method).To(Equal(http.MethodPatch))
+		g.Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+		writeJSON(w, map[string]interface{}{"name": "fleet", "archived": true})
+	})
+
+	g.Expect(g.provider.(git.ArchiveRepoProviderClient).ArchiveRepo(g.ctx, git.ArchiveRepoOpts{Owner: "platform", Repository: "fleet"})).To(Succeed())
+	g.Expect(body).To(HaveKeyWithValue("archived", true))
+}
+
+func TestGetGiteaAccessTokenFromEnv(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(gitea.EksaGiteaTokenEnv, testToken)
+	t.Setenv(gitea.GiteaTokenEnv, "")
+
+	token, err := gitea.GetGiteaAccessTokenFromEnv()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(token).To(Equal(testToken))
+}
+
+func TestGetGiteaAccessTokenFromEnvMissing(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(gitea.EksaGiteaTokenEnv, "")
+
+	_, err := gitea.GetGiteaAccessTokenFromEnv()
+	g.Expect(err).To(MatchError(ContainSubstring(gitea.EksaGiteaTokenEnv)))
+}
+
+func TestRepoUrl(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(gitea.RepoUrl("gitea.lab.local:3000", "platform", "fleet")).To(Equal("https://gitea.lab.local:3000/platform/fleet.git"))
+}
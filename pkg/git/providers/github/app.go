@@ -0,0 +1,147 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+
+	"golang.org/x/oauth2"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	EksaGithubAppIDEnv             = "EKSA_GITHUB_APP_ID"
+	EksaGithubAppInstallationIDEnv = "EKSA_GITHUB_APP_INSTALLATION_ID"
+	// EksaGithubAppPrivateKeyEnv is the path to the PEM encoded private key of the Github App.
+	EksaGithubAppPrivateKeyEnv = "EKSA_GITHUB_APP_PRIVATE_KEY"
+	// AppTokenUsername is the username git authenticates with when using a Github App installation token.
+	AppTokenUsername = "x-access-token"
+
+	appTokenAuthName = "http-github-app-auth"
+)
+
+var (
+	appTokenSourceMu sync.Mutex
+	appTokenSource   oauth2.TokenSource
+)
+
+// AppCredentials are the credentials of a Github App installation, used to create short-lived installation
+// tokens instead of using a personal access token.
+type AppCredentials struct {
+	AppID          int64
+	InstallationID int64
+	PrivateKey     []byte
+}
+
+// AppAuthConfigured returns true if the Github App credentials environment variables are set.
+func AppAuthConfigured() bool {
+	_, ok := os.LookupEnv(EksaGithubAppIDEnv)
+	return ok
+}
+
+// GetGithubAppCredentialsFromEnv reads the Github App credentials from the environment. It returns nil
+// if the Github App id is not set.
+func GetGithubAppCredentialsFromEnv() (*AppCredentials, error) {
+	if !AppAuthConfigured() {
+		return nil, nil
+	}
+
+	appID, err := int64FromEnv(EksaGithubAppIDEnv)
+	if err != nil {
+		return nil, err
+	}
+	installationID, err := int64FromEnv(EksaGithubAppInstallationIDEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	keyFile, ok := os.LookupEnv(EksaGithubAppPrivateKeyEnv)
+	if !ok || keyFile == "" {
+		return nil, fmt.Errorf("github app private key environment variable %s is required with %s", EksaGithubAppPrivateKeyEnv, EksaGithubAppIDEnv)
+	}
+	key, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading github app private key: %v", err)
+	}
+
+	return &AppCredentials{
+		AppID:          appID,
+		InstallationID: installationID,
+		PrivateKey:     key,
+	}, nil
+}
+
+func int64FromEnv(env string) (int64, error) {
+	val, ok := os.LookupEnv(env)
+	if !ok || val == "" {
+		return 0, fmt.Errorf("github app environment variable %s is required with %s", env, EksaGithubAppIDEnv)
+	}
+	i, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("github app environment variable %s is invalid; must be a number", env)
+	}
+	return i, nil
+}
+
+// SetAppTokenSource makes GetGithubAccessTokenFromEnv export a token of the Github App installation from source before
+// reading it, so the callers reading it right before a remote operation, like the flux bootstrap, never get an expired
+// token. A nil source stops refreshing it.
+func SetAppTokenSource(source oauth2.TokenSource) {
+	appTokenSourceMu.Lock()
+	defer appTokenSourceMu.Unlock()
+	appTokenSource = source
+}
+
+// exportAppToken sets the Github token environment variable to the current token of the Github App installation,
+// if a token source is set.
+func exportAppToken() error {
+	appTokenSourceMu.Lock()
+	source := appTokenSource
+	appTokenSourceMu.Unlock()
+	if source == nil {
+		return nil
+	}
+
+	token, err := source.Token()
+	if err != nil {
+		return err
+	}
+	if err := os.Setenv(EksaGithubTokenEnv, token.AccessToken); err != nil {
+		return fmt.Errorf("unable to set %s: %v", EksaGithubTokenEnv, err)
+	}
+	return nil
+}
+
+// AppTokenAuth authenticates git HTTPS requests with the current token of a Github App installation. Installation
+// tokens expire after an hour, so the token is read from its source for every request and replaced once it's about
+// to expire.
+type AppTokenAuth struct {
+	source oauth2.TokenSource
+}
+
+// NewAppTokenAuth builds an AppTokenAuth reading the installation tokens from source.
+func NewAppTokenAuth(source oauth2.TokenSource) *AppTokenAuth {
+	return &AppTokenAuth{source: source}
+}
+
+// SetAuth sets the basic auth credentials of the current installation token in the request. The request is left
+// unauthenticated if a new token can't be created, so it's rejected by Github.
+func (a *AppTokenAuth) SetAuth(r *http.Request) {
+	token, err := a.source.Token()
+	if err != nil {
+		logger.Info("Warning: could not refresh the github app installation token", "error", err)
+		return
+	}
+	r.SetBasicAuth(AppTokenUsername, token.AccessToken)
+}
+
+func (a *AppTokenAuth) Name() string {
+	return appTokenAuthName
+}
+
+func (a *AppTokenAuth) String() string {
+	return fmt.Sprintf("%s - %s:%s", a.Name(), AppTokenUsername, "*******")
+}
@@ -0,0 +1,170 @@
+package github_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	goGithub "github.com/google/go-github/v35/github"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/git/providers/github"
+	"github.com/aws/eks-anywhere/pkg/git/providers/github/mocks"
+)
+
+func setupAppContext(t *testing.T, appID, installationID string) string {
+	keyFile := filepath.Join(t.TempDir(), "app.pem")
+	if err := os.WriteFile(keyFile, []byte("private key"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(github.EksaGithubAppIDEnv, appID)
+	t.Setenv(github.EksaGithubAppInstallationIDEnv, installationID)
+	t.Setenv(github.EksaGithubAppPrivateKeyEnv, keyFile)
+	return keyFile
+}
+
+func TestGetGithubAppCredentialsFromEnv(t *testing.T) {
+	setupAppContext(t, "1234", "42")
+
+	app, err := github.GetGithubAppCredentialsFromEnv()
+	assert.NoError(t, err)
+	assert.Equal(t, &github.AppCredentials{AppID: 1234, InstallationID: 42, PrivateKey: []byte("private key")}, app)
+}
+
+func TestGetGithubAppCredentialsFromEnvNotConfigured(t *testing.T) {
+	app, err := github.GetGithubAppCredentialsFromEnv()
+	assert.NoError(t, err)
+	assert.Nil(t, app)
+}
+
+func TestGetGithubAppCredentialsFromEnvInvalid(t *testing.T) {
+	tests := []struct {
+		testName       string
+		appID          string
+		installationID string
+		privateKey     string
+		wantErr        string
+	}{
+		{
+			testName:       "invalid app id",
+			appID:          "my-app",
+			installationID: "42",
+			wantErr:        "github app environment variable EKSA_GITHUB_APP_ID is invalid; must be a number",
+		},
+		{
+			testName: "missing installation id",
+			appID:    "1234",
+			wantErr:  "github app environment variable EKSA_GITHUB_APP_INSTALLATION_ID is required with EKSA_GITHUB_APP_ID",
+		},
+		{
+			testName:       "missing private key",
+			appID:          "1234",
+			installationID: "42",
+			privateKey:     "/does/not/exist.pem",
+			wantErr:        "reading github app private key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			setupAppContext(t, tt.appID, tt.installationID)
+			if tt.privateKey != "" {
+				t.Setenv(github.EksaGithubAppPrivateKeyEnv, tt.privateKey)
+			}
+
+			_, err := github.GetGithubAppCredentialsFromEnv()
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateAppAuth(t *testing.T) {
+	setupAppContext(t, "1234", "42")
+	ctx := context.Background()
+	client := mocks.NewMockGithubClient(gomock.NewController(t))
+	config := &v1alpha1.GithubProviderConfig{Owner: "orgA", Repository: "testRepo"}
+	client.EXPECT().Organization(ctx, "orgA").Return(&goGithub.Organization{Login: goGithub.String("orgA")}, nil)
+
+	provider, err := github.New(client, config, git.TokenAuth{Token: validPATValue, Username: github.AppTokenUsername})
+	assert.NoError(t, err)
+	assert.NoError(t, provider.Validate(ctx))
+}
+
+func TestValidateAppAuthOrganizationError(t *testing.T) {
+	setupAppContext(t, "1234", "42")
+	ctx := context.Background()
+	client := mocks.NewMockGithubClient(gomock.NewController(t))
+	config := &v1alpha1.GithubProviderConfig{Owner: "orgA", Repository: "testRepo"}
+	client.EXPECT().Organization(ctx, "orgA").Return(nil, errors.New("not found"))
+
+	provider, err := github.New(client, config, git.TokenAuth{Token: validPATValue, Username: github.AppTokenUsername})
+	assert.NoError(t, err)
+	assert.ErrorContains(t, provider.Validate(ctx), "the github app installation doesn't have proper access to github organization orgA")
+}
+
+func TestValidateAppAuthPersonal(t *testing.T) {
+	setupAppContext(t, "1234", "42")
+	client := mocks.NewMockGithubClient(gomock.NewController(t))
+	config := &v1alpha1.GithubProviderConfig{Owner: "Jeff", Repository: "testRepo", Personal: true}
+
+	provider, err := github.New(client, config, git.TokenAuth{Token: validPATValue, Username: github.AppTokenUsername})
+	assert.NoError(t, err)
+	assert.ErrorContains(t, provider.Validate(context.Background()), "github app authentication is not supported for personal repositories")
+}
+
+type errTokenSource struct{}
+
+func (errTokenSource) Token() (*oauth2.Token, error) {
+	return nil, errors.New("token error")
+}
+
+func TestAppTokenAuthSetAuth(t *testing.T) {
+	auth := github.NewAppTokenAuth(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: validPATValue}))
+	r := httptest.NewRequest(http.MethodGet, "https://github.com/orgA/testRepo.git/info/refs", nil)
+
+	auth.SetAuth(r)
+
+	username, password, ok := r.BasicAuth()
+	assert.True(t, ok)
+	assert.Equal(t, github.AppTokenUsername, username)
+	assert.Equal(t, validPATValue, password)
+	assert.NotContains(t, auth.String(), validPATValue)
+}
+
+func TestAppTokenAuthSetAuthTokenError(t *testing.T) {
+	auth := github.NewAppTokenAuth(errTokenSource{})
+	r := httptest.NewRequest(http.MethodGet, "https://github.com/orgA/testRepo.git/info/refs", nil)
+
+	auth.SetAuth(r)
+
+	_, _, ok := r.BasicAuth()
+	assert.False(t, ok)
+}
+
+func TestGetGithubAccessTokenFromEnvWithAppTokenSource(t *testing.T) {
+	t.Setenv(github.EksaGithubTokenEnv, "")
+	t.Setenv(github.GithubTokenEnv, "")
+	github.SetAppTokenSource(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: validPATValue}))
+	t.Cleanup(func() { github.SetAppTokenSource(nil) })
+
+	token, err := github.GetGithubAccessTokenFromEnv()
+	assert.NoError(t, err)
+	assert.Equal(t, validPATValue, token)
+	assert.Equal(t, validPATValue, os.Getenv(github.EksaGithubTokenEnv))
+}
+
+func TestGetGithubAccessTokenFromEnvWithAppTokenSourceError(t *testing.T) {
+	t.Setenv(github.EksaGithubTokenEnv, "")
+	github.SetAppTokenSource(errTokenSource{})
+	t.Cleanup(func() { github.SetAppTokenSource(nil) })
+
+	_, err := github.GetGithubAccessTokenFromEnv()
+	assert.ErrorContains(t, err, "token error")
+}
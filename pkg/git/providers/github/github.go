@@ -19,7 +19,8 @@ const (
 	GitProviderName    = "github"
 	EksaGithubTokenEnv = "EKSA_GITHUB_TOKEN"
 	GithubTokenEnv     = "GITHUB_TOKEN"
-	githubUrlTemplate  = "https://github.com/%v/%v.git"
+	DefaultHostname    = "github.com"
+	githubUrlTemplate  = "https://%v/%v/%v.git"
 	patRegex           = "^[A-Za-z0-9_]{40}$"
 	repoPermissions    = "repo"
 )
@@ -47,6 +48,13 @@ type GithubClient interface {
 	CheckAccessTokenPermissions(checkPATPermission string, allPermissionScopes string) error
 	PathExists(ctx context.Context, owner, repo, branch, path string) (bool, error)
 	DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts) error
+	ArchiveRepo(ctx context.Context, opts git.ArchiveRepoOpts) error
+	CreatePullRequest(ctx context.Context, owner, repo string, opts git.CreatePullRequestOpts) (*git.PullRequest, error)
+	CreateWebhook(ctx context.Context, owner, repo string, opts git.CreateWebhookOpts) error
+	WebhookExists(ctx context.Context, owner, repo, url string) (bool, error)
+	RepoPermissions(ctx context.Context, owner, repo string) (map[string]bool, error)
+	BranchProtected(ctx context.Context, owner, repo, branch string) (bool, error)
+	BranchProtection(ctx context.Context, owner, repo, branch string) (*goGithub.Protection, error)
 }
 
 func New(githubProviderClient GithubClient, config *v1alpha1.GithubProviderConfig, auth git.TokenAuth) (*githubProvider, error) {
@@ -85,8 +93,27 @@ func (g *githubProvider) AddDeployKeyToRepo(ctx context.Context, opts git.AddDep
 	return g.githubProviderClient.AddDeployKeyToRepo(ctx, opts)
 }
 
+// CreatePullRequest opens a pull request in the configured repository.
+func (g *githubProvider) CreatePullRequest(ctx context.Context, opts git.CreatePullRequestOpts) (*git.PullRequest, error) {
+	return g.githubProviderClient.CreatePullRequest(ctx, g.config.Owner, g.config.Repository, opts)
+}
+
+// CreateWebhook registers a webhook in the configured repository.
+func (g *githubProvider) CreateWebhook(ctx context.Context, opts git.CreateWebhookOpts) error {
+	return g.githubProviderClient.CreateWebhook(ctx, g.config.Owner, g.config.Repository, opts)
+}
+
+// WebhookExists checks if a webhook sending to the url is registered in the configured repository.
+func (g *githubProvider) WebhookExists(ctx context.Context, url string) (bool, error) {
+	return g.githubProviderClient.WebhookExists(ctx, g.config.Owner, g.config.Repository, url)
+}
+
 // validates the github setup and access.
 func (g *githubProvider) Validate(ctx context.Context) error {
+	if AppAuthConfigured() {
+		return g.validateAppAuth(ctx)
+	}
+
 	user, err := g.githubProviderClient.AuthenticatedUser(ctx)
 	if err != nil {
 		return err
@@ -117,6 +144,19 @@ func (g *githubProvider) Validate(ctx context.Context) error {
 	return nil
 }
 
+// validateAppAuth validates the access of a Github App installation. The installation tokens don't belong to
+// a user nor have scopes, so only the access to the organization is checked.
+func (g *githubProvider) validateAppAuth(ctx context.Context) error {
+	if g.config.Personal {
+		return fmt.Errorf("github app authentication is not supported for personal repositories, use a personal access token instead")
+	}
+	if _, err := g.githubProviderClient.Organization(ctx, g.config.Owner); err != nil {
+		return fmt.Errorf("the github app installation doesn't have proper access to github organization %s, %v", g.config.Owner, err)
+	}
+	logger.MarkPass("Github app installation has access to the organization", "organization", g.config.Owner)
+	return nil
+}
+
 func validateGithubAccessToken() error {
 	r := regexp.MustCompile(patRegex)
 	logger.V(4).Info("Checking validity of Github Access Token environment variable", "env var", EksaGithubTokenEnv)
@@ -130,7 +170,13 @@ func validateGithubAccessToken() error {
 	return nil
 }
 
+// GetGithubAccessTokenFromEnv returns the Github token from the environment, after exporting a new token of the Github
+// App installation if it's about to expire.
 func GetGithubAccessTokenFromEnv() (string, error) {
+	if err := exportAppToken(); err != nil {
+		return "", err
+	}
+
 	err := validateGithubAccessToken()
 	if err != nil {
 		return "", err
@@ -154,6 +200,11 @@ func (g *githubProvider) DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts
 	return g.githubProviderClient.DeleteRepo(ctx, opts)
 }
 
+// ArchiveRepo archives a Github repository, making it read-only.
+func (g *githubProvider) ArchiveRepo(ctx context.Context, opts git.ArchiveRepoOpts) error {
+	return g.githubProviderClient.ArchiveRepo(ctx, opts)
+}
+
 type GitProviderNotFoundError struct {
 	Provider string
 }
@@ -162,6 +213,14 @@ func (e *GitProviderNotFoundError) Error() string {
 	return fmt.Sprintf("git provider %s not found", e.Provider)
 }
 
-func RepoUrl(owner string, repo string) string {
-	return fmt.Sprintf(githubUrlTemplate, owner, repo)
+// Hostname returns the configured Github Enterprise Server hostname or the default github.com.
+func Hostname(config *v1alpha1.GithubProviderConfig) string {
+	if config.Hostname == "" {
+		return DefaultHostname
+	}
+	return config.Hostname
+}
+
+func RepoUrl(hostname, owner, repo string) string {
+	return fmt.Sprintf(githubUrlTemplate, hostname, owner, repo)
 }
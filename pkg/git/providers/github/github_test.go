@@ -237,3 +237,27 @@ func TestGetNonExistantRepoSucceeds(t *testing.T) {
 		})
 	}
 }
+
+func TestRepoUrl(t *testing.T) {
+	tests := []struct {
+		testName string
+		config   *v1alpha1.GithubProviderConfig
+		want     string
+	}{
+		{
+			testName: "github.com",
+			config:   &v1alpha1.GithubProviderConfig{Owner: "orgA", Repository: "testRepo"},
+			want:     "https://github.com/orgA/testRepo.git",
+		},
+		{
+			testName: "enterprise server",
+			config:   &v1alpha1.GithubProviderConfig{Owner: "orgA", Repository: "testRepo", Hostname: "github.example.com"},
+			want:     "https://github.example.com/orgA/testRepo.git",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			assert.Equal(t, tt.want, github.RepoUrl(github.Hostname(tt.config), tt.config.Owner, tt.config.Repository))
+		})
+	}
+}
@@ -50,6 +50,20 @@ func (mr *MockGithubClientMockRecorder) AddDeployKeyToRepo(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDeployKeyToRepo", reflect.TypeOf((*MockGithubClient)(nil).AddDeployKeyToRepo), arg0, arg1)
 }
 
+// ArchiveRepo mocks base method.
+func (m *MockGithubClient) ArchiveRepo(arg0 context.Context, arg1 git.ArchiveRepoOpts) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveRepo", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ArchiveRepo indicates an expected call of ArchiveRepo.
+func (mr *MockGithubClientMockRecorder) ArchiveRepo(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveRepo", reflect.TypeOf((*MockGithubClient)(nil).ArchiveRepo), arg0, arg1)
+}
+
 // AuthenticatedUser mocks base method.
 func (m *MockGithubClient) AuthenticatedUser(arg0 context.Context) (*github.User, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +79,36 @@ func (mr *MockGithubClientMockRecorder) AuthenticatedUser(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticatedUser", reflect.TypeOf((*MockGithubClient)(nil).AuthenticatedUser), arg0)
 }
 
+// BranchProtected mocks base method.
+func (m *MockGithubClient) BranchProtected(arg0 context.Context, arg1, arg2, arg3 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BranchProtected", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BranchProtected indicates an expected call of BranchProtected.
+func (mr *MockGithubClientMockRecorder) BranchProtected(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BranchProtected", reflect.TypeOf((*MockGithubClient)(nil).BranchProtected), arg0, arg1, arg2, arg3)
+}
+
+// BranchProtection mocks base method.
+func (m *MockGithubClient) BranchProtection(arg0 context.Context, arg1, arg2, arg3 string) (*github.Protection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BranchProtection", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*github.Protection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BranchProtection indicates an expected call of BranchProtection.
+func (mr *MockGithubClientMockRecorder) BranchProtection(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BranchProtection", reflect.TypeOf((*MockGithubClient)(nil).BranchProtection), arg0, arg1, arg2, arg3)
+}
+
 // CheckAccessTokenPermissions mocks base method.
 func (m *MockGithubClient) CheckAccessTokenPermissions(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -79,6 +123,21 @@ func (mr *MockGithubClientMockRecorder) CheckAccessTokenPermissions(arg0, arg1 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAccessTokenPermissions", reflect.TypeOf((*MockGithubClient)(nil).CheckAccessTokenPermissions), arg0, arg1)
 }
 
+// CreatePullRequest mocks base method.
+func (m *MockGithubClient) CreatePullRequest(arg0 context.Context, arg1, arg2 string, arg3 git.CreatePullRequestOpts) (*git.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePullRequest", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*git.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePullRequest indicates an expected call of CreatePullRequest.
+func (mr *MockGithubClientMockRecorder) CreatePullRequest(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePullRequest", reflect.TypeOf((*MockGithubClient)(nil).CreatePullRequest), arg0, arg1, arg2, arg3)
+}
+
 // CreateRepo mocks base method.
 func (m *MockGithubClient) CreateRepo(arg0 context.Context, arg1 git.CreateRepoOpts) (*git.Repository, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +153,20 @@ func (mr *MockGithubClientMockRecorder) CreateRepo(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRepo", reflect.TypeOf((*MockGithubClient)(nil).CreateRepo), arg0, arg1)
 }
 
+// CreateWebhook mocks base method.
+func (m *MockGithubClient) CreateWebhook(arg0 context.Context, arg1, arg2 string, arg3 git.CreateWebhookOpts) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockGithubClientMockRecorder) CreateWebhook(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockGithubClient)(nil).CreateWebhook), arg0, arg1, arg2, arg3)
+}
+
 // DeleteRepo mocks base method.
 func (m *MockGithubClient) DeleteRepo(arg0 context.Context, arg1 git.DeleteRepoOpts) error {
 	m.ctrl.T.Helper()
@@ -167,3 +240,33 @@ func (mr *MockGithubClientMockRecorder) PathExists(arg0, arg1, arg2, arg3, arg4
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PathExists", reflect.TypeOf((*MockGithubClient)(nil).PathExists), arg0, arg1, arg2, arg3, arg4)
 }
+
+// RepoPermissions mocks base method.
+func (m *MockGithubClient) RepoPermissions(arg0 context.Context, arg1, arg2 string) (map[string]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepoPermissions", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[string]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RepoPermissions indicates an expected call of RepoPermissions.
+func (mr *MockGithubClientMockRecorder) RepoPermissions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepoPermissions", reflect.TypeOf((*MockGithubClient)(nil).RepoPermissions), arg0, arg1, arg2)
+}
+
+// WebhookExists mocks base method.
+func (m *MockGithubClient) WebhookExists(arg0 context.Context, arg1, arg2, arg3 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WebhookExists", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WebhookExists indicates an expected call of WebhookExists.
+func (mr *MockGithubClientMockRecorder) WebhookExists(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WebhookExists", reflect.TypeOf((*MockGithubClient)(nil).WebhookExists), arg0, arg1, arg2, arg3)
+}
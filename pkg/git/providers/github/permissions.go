@@ -0,0 +1,116 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// requiredRepoPermissions are the repository permissions required by flux bootstrap, without a classic
+// token scope: push to write the repository contents and admin to add the deploy key.
+var requiredRepoPermissions = []string{"push", "admin"}
+
+// ValidatePermissions checks the token can create the repository, write its contents and add the deploy key
+// flux bootstrap uses, so missing permissions are reported before anything is changed. Classic personal access
+// tokens need the repo scope, while the permissions of fine-grained tokens and app installation tokens, which
+// don't have scopes, are checked on the repository.
+func (g *githubProvider) ValidatePermissions(ctx context.Context) error {
+	if !AppAuthConfigured() {
+		scopes, err := g.githubProviderClient.GetAccessTokenPermissions(g.auth.Token)
+		if err != nil {
+			return err
+		}
+		if scopes != "" {
+			return g.githubProviderClient.CheckAccessTokenPermissions(repoPermissions, scopes)
+		}
+	}
+
+	permissions, err := g.githubProviderClient.RepoPermissions(ctx, g.config.Owner, g.config.Repository)
+	var notExist *git.RepositoryDoesNotExistError
+	if errors.As(err, &notExist) {
+		logger.V(3).Info("Github repository doesn't exist, the permissions to create it can't be checked", "repository", g.config.Repository, "owner", g.config.Owner)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	for _, p := range requiredRepoPermissions {
+		if !permissions[p] {
+			missing = append(missing, p)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("github credentials are missing the %s permissions on repository %s/%s", strings.Join(missing, ", "), g.config.Owner, g.config.Repository)
+	}
+	return nil
+}
+
+// ValidatePushAccess checks the authenticated user or app installation can push to the repository. Unlike the token
+// scopes, the repository permissions account for the user's role in the organization, like a read-only collaborator.
+// A repository that doesn't exist yet is created by flux bootstrap, so there's nothing to check.
+func (g *githubProvider) ValidatePushAccess(ctx context.Context) error {
+	permissions, err := g.githubProviderClient.RepoPermissions(ctx, g.config.Owner, g.config.Repository)
+	var notExist *git.RepositoryDoesNotExistError
+	if errors.As(err, &notExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !permissions["push"] {
+		return fmt.Errorf("github credentials can't push to repository %s/%s", g.config.Owner, g.config.Repository)
+	}
+	return nil
+}
+
+// ValidateBranchPush checks the protection rules of the branch don't reject direct pushes. Admins can read the
+// protection rules, which only reject their pushes when enforced for admins too. The rules can't be read without
+// admin permissions, so any protection of the branch is considered to reject the pushes.
+func (g *githubProvider) ValidateBranchPush(ctx context.Context, branch string) error {
+	permissions, err := g.githubProviderClient.RepoPermissions(ctx, g.config.Owner, g.config.Repository)
+	var notExist *git.RepositoryDoesNotExistError
+	if errors.As(err, &notExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !permissions["admin"] {
+		protected, err := g.githubProviderClient.BranchProtected(ctx, g.config.Owner, g.config.Repository, branch)
+		if err != nil {
+			return err
+		}
+		if protected {
+			return &git.ProtectedBranchError{Branch: branch}
+		}
+		return nil
+	}
+
+	protection, err := g.githubProviderClient.BranchProtection(ctx, g.config.Owner, g.config.Repository, branch)
+	if err != nil {
+		return err
+	}
+	if protection == nil || protection.EnforceAdmins == nil || !protection.EnforceAdmins.Enabled {
+		return nil
+	}
+
+	var rules []string
+	if protection.RequiredPullRequestReviews != nil {
+		rules = append(rules, "pull request reviews")
+	}
+	if protection.RequiredStatusChecks != nil {
+		rules = append(rules, "status checks")
+	}
+	if len(rules) > 0 {
+		return &git.ProtectedBranchError{Branch: branch, Rules: rules}
+	}
+	return nil
+}
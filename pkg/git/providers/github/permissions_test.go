@@ -0,0 +1,229 @@
+package github_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	goGithub "github.com/google/go-github/v35/github"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/git/providers/github"
+	"github.com/aws/eks-anywhere/pkg/git/providers/github/mocks"
+)
+
+func TestValidatePermissions(t *testing.T) {
+	tests := []struct {
+		testName    string
+		scopes      string
+		permissions map[string]bool
+		repoErr     error
+		wantErr     string
+	}{
+		{
+			testName: "classic token with repo scope",
+			scopes:   "repo, admin:repo_hook",
+		},
+		{
+			testName: "classic token without repo scope",
+			scopes:   "public_repo",
+			wantErr:  "github access token does not have repo permissions",
+		},
+		{
+			testName:    "fine-grained token with push and admin permissions",
+			permissions: map[string]bool{"admin": true, "push": true, "pull": true},
+		},
+		{
+			testName:    "fine-grained token without admin permission",
+			permissions: map[string]bool{"push": true, "pull": true},
+			wantErr:     "github credentials are missing the admin permissions on repository orgA/testRepo",
+		},
+		{
+			testName:    "fine-grained token with read only permission",
+			permissions: map[string]bool{"pull": true},
+			wantErr:     "github credentials are missing the push, admin permissions on repository orgA/testRepo",
+		},
+		{
+			testName: "fine-grained token and repository doesn't exist",
+			repoErr:  &git.RepositoryDoesNotExistError{Err: errors.New("not found")},
+		},
+		{
+			testName: "fine-grained token and error getting the repository",
+			repoErr:  errors.New("server error"),
+			wantErr:  "server error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctx := context.Background()
+			client := mocks.NewMockGithubClient(gomock.NewController(t))
+			config := &v1alpha1.GithubProviderConfig{Owner: "orgA", Repository: "testRepo"}
+
+			client.EXPECT().GetAccessTokenPermissions(validPATValue).Return(tt.scopes, nil)
+			if tt.scopes != "" {
+				client.EXPECT().CheckAccessTokenPermissions("repo", tt.scopes).DoAndReturn(func(permission, scopes string) error {
+					if tt.wantErr != "" {
+						return errors.New(tt.wantErr)
+					}
+					return nil
+				})
+			} else {
+				client.EXPECT().RepoPermissions(ctx, "orgA", "testRepo").Return(tt.permissions, tt.repoErr)
+			}
+
+			provider, err := github.New(client, config, git.TokenAuth{Token: validPATValue, Username: "orgA"})
+			assert.NoError(t, err)
+
+			err = provider.ValidatePermissions(ctx)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidatePermissionsAppAuth(t *testing.T) {
+	setupAppContext(t, "1234", "42")
+	ctx := context.Background()
+	client := mocks.NewMockGithubClient(gomock.NewController(t))
+	config := &v1alpha1.GithubProviderConfig{Owner: "orgA", Repository: "testRepo"}
+	client.EXPECT().RepoPermissions(ctx, "orgA", "testRepo").Return(map[string]bool{"admin": true, "push": true}, nil)
+
+	provider, err := github.New(client, config, git.TokenAuth{Token: validPATValue, Username: github.AppTokenUsername})
+	assert.NoError(t, err)
+	assert.NoError(t, provider.ValidatePermissions(ctx))
+}
+
+func TestValidatePushAccess(t *testing.T) {
+	tests := []struct {
+		testName    string
+		permissions map[string]bool
+		repoErr     error
+		wantErr     string
+	}{
+		{
+			testName:    "push permission",
+			permissions: map[string]bool{"push": true, "pull": true},
+		},
+		{
+			testName:    "read only collaborator",
+			permissions: map[string]bool{"pull": true},
+			wantErr:     "github credentials can't push to repository orgA/testRepo",
+		},
+		{
+			testName: "repository doesn't exist",
+			repoErr:  &git.RepositoryDoesNotExistError{Err: errors.New("not found")},
+		},
+		{
+			testName: "error getting the repository",
+			repoErr:  errors.New("server error"),
+			wantErr:  "server error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctx := context.Background()
+			client := mocks.NewMockGithubClient(gomock.NewController(t))
+			config := &v1alpha1.GithubProviderConfig{Owner: "orgA", Repository: "testRepo"}
+			client.EXPECT().RepoPermissions(ctx, "orgA", "testRepo").Return(tt.permissions, tt.repoErr)
+
+			provider, err := github.New(client, config, git.TokenAuth{Token: validPATValue, Username: "orgA"})
+			assert.NoError(t, err)
+
+			err = provider.ValidatePushAccess(ctx)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateBranchPush(t *testing.T) {
+	tests := []struct {
+		testName    string
+		permissions map[string]bool
+		repoErr     error
+		protected   bool
+		protection  *goGithub.Protection
+		wantErr     string
+	}{
+		{
+			testName:    "unprotected branch",
+			permissions: map[string]bool{"push": true},
+		},
+		{
+			testName:    "protected branch",
+			permissions: map[string]bool{"push": true},
+			protected:   true,
+			wantErr:     "branch main is protected and doesn't accept direct pushes",
+		},
+		{
+			testName: "repository doesn't exist",
+			repoErr:  &git.RepositoryDoesNotExistError{Err: errors.New("not found")},
+		},
+		{
+			testName:    "admin and unprotected branch",
+			permissions: map[string]bool{"admin": true, "push": true},
+		},
+		{
+			testName:    "admin and rules not enforced for admins",
+			permissions: map[string]bool{"admin": true, "push": true},
+			protection: &goGithub.Protection{
+				RequiredPullRequestReviews: &goGithub.PullRequestReviewsEnforcement{RequiredApprovingReviewCount: 1},
+				EnforceAdmins:              &goGithub.AdminEnforcement{Enabled: false},
+			},
+		},
+		{
+			testName:    "admin and rules enforced for admins",
+			permissions: map[string]bool{"admin": true, "push": true},
+			protection: &goGithub.Protection{
+				RequiredPullRequestReviews: &goGithub.PullRequestReviewsEnforcement{RequiredApprovingReviewCount: 1},
+				RequiredStatusChecks:       &goGithub.RequiredStatusChecks{Contexts: []string{"ci"}},
+				EnforceAdmins:              &goGithub.AdminEnforcement{Enabled: true},
+			},
+			wantErr: "branch main is protected and doesn't accept direct pushes, it requires pull request reviews and status checks",
+		},
+		{
+			testName:    "admin and only force pushes rules enforced for admins",
+			permissions: map[string]bool{"admin": true, "push": true},
+			protection: &goGithub.Protection{
+				AllowForcePushes: &goGithub.AllowForcePushes{Enabled: false},
+				EnforceAdmins:    &goGithub.AdminEnforcement{Enabled: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctx := context.Background()
+			client := mocks.NewMockGithubClient(gomock.NewController(t))
+			config := &v1alpha1.GithubProviderConfig{Owner: "orgA", Repository: "testRepo"}
+			client.EXPECT().RepoPermissions(ctx, "orgA", "testRepo").Return(tt.permissions, tt.repoErr)
+			if tt.repoErr == nil {
+				if tt.permissions["admin"] {
+					client.EXPECT().BranchProtection(ctx, "orgA", "testRepo", "main").Return(tt.protection, nil)
+				} else {
+					client.EXPECT().BranchProtected(ctx, "orgA", "testRepo", "main").Return(tt.protected, nil)
+				}
+			}
+
+			provider, err := github.New(client, config, git.TokenAuth{Token: validPATValue, Username: "orgA"})
+			assert.NoError(t, err)
+
+			err = provider.ValidateBranchPush(ctx, "main")
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+				var protected *git.ProtectedBranchError
+				assert.ErrorAs(t, err, &protected)
+			}
+		})
+	}
+}
@@ -0,0 +1,331 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	GitProviderName    = "gitlab"
+	EksaGitlabTokenEnv = "EKSA_GITLAB_TOKEN"
+	GitlabTokenEnv     = "GITLAB_TOKEN"
+	DefaultHostname    = "gitlab.com"
+	gitlabUrlTemplate  = "https://%v/%v/%v.git"
+	apiScope           = "api"
+	privateTokenHeader = "PRIVATE-TOKEN"
+)
+
+// HTTPClient represents the attributes that the Gitlab provider requires of a client to interact with the Gitlab REST API.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type gitlabProvider struct {
+	httpClient HTTPClient
+	config     *v1alpha1.GitlabProviderConfig
+	auth       git.TokenAuth
+	baseUrl    string
+}
+
+type project struct {
+	Name          string `json:"name"`
+	HTTPURLToRepo string `json:"http_url_to_repo"`
+	Namespace     struct {
+		Path string `json:"path"`
+		Kind string `json:"kind"`
+	} `json:"namespace"`
+}
+
+type mergeRequest struct {
+	IID    int    `json:"iid"`
+	WebUrl string `json:"web_url"`
+}
+
+type namespace struct {
+	ID int `json:"id"`
+}
+
+type user struct {
+	Username string `json:"username"`
+}
+
+type personalAccessToken struct {
+	Scopes []string `json:"scopes"`
+}
+
+// apiError is returned for any non successful response from the Gitlab API.
+type apiError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("gitlab api returned status %d: %s", e.StatusCode, e.Message)
+}
+
+func New(httpClient HTTPClient, config *v1alpha1.GitlabProviderConfig, auth git.TokenAuth) (*gitlabProvider, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &gitlabProvider{
+		httpClient: httpClient,
+		config:     config,
+		auth:       auth,
+		baseUrl:    fmt.Sprintf("https://%s/api/v4", Hostname(config)),
+	}, nil
+}
+
+// Hostname returns the configured Gitlab hostname or the default gitlab.com.
+func Hostname(config *v1alpha1.GitlabProviderConfig) string {
+	if config.Hostname == "" {
+		return DefaultHostname
+	}
+	return config.Hostname
+}
+
+// CreateRepo creates an empty Gitlab project. Projects for non personal owners are created in the group
+// with the owner path, which must already exist.
+func (g *gitlabProvider) CreateRepo(ctx context.Context, opts git.CreateRepoOpts) (*git.Repository, error) {
+	logger.V(3).Info("Attempting to create new Gitlab project", "repo", opts.Name, "owner", opts.Owner)
+	visibility := "public"
+	if opts.Privacy {
+		visibility = "private"
+	}
+	body := map[string]interface{}{
+		"name":                   opts.Name,
+		"path":                   opts.Name,
+		"description":            opts.Description,
+		"visibility":             visibility,
+		"initialize_with_readme": opts.AutoInit,
+	}
+
+	if !opts.Personal {
+		ns := &namespace{}
+		if err := g.do(ctx, http.MethodGet, "/namespaces/"+url.PathEscape(opts.Owner), nil, ns); err != nil {
+			return nil, fmt.Errorf("failed to get Gitlab group %s: %v", opts.Owner, err)
+		}
+		body["namespace_id"] = ns.ID
+	}
+
+	p := &project{}
+	if err := g.do(ctx, http.MethodPost, "/projects", body, p); err != nil {
+		return nil, fmt.Errorf("failed to create new Gitlab project %s: %v", opts.Name, err)
+	}
+	logger.V(3).Info("Successfully created new Gitlab project", "repo", p.Name, "owner", opts.Owner)
+	return p.toRepository(), nil
+}
+
+// GetRepo describes a remote repository, return the repo name if it exists.
+// If the repo does not exist, a nil repo is returned.
+func (g *gitlabProvider) GetRepo(ctx context.Context) (*git.Repository, error) {
+	r := g.config.Repository
+	o := g.config.Owner
+	logger.V(3).Info("Describing Gitlab project", "name", r, "owner", o)
+	p := &project{}
+	err := g.do(ctx, http.MethodGet, projectPath(o, r), nil, p)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error when describing repository %s: %w", r, err)
+	}
+	return p.toRepository(), nil
+}
+
+func (g *gitlabProvider) AddDeployKeyToRepo(ctx context.Context, opts git.AddDeployKeyOpts) error {
+	logger.V(3).Info("Adding deploy key to repository", "repository", opts.Repository, "owner", opts.Owner)
+	body := map[string]interface{}{
+		"key":      opts.Key,
+		"title":    opts.Title,
+		"can_push": !opts.ReadOnly,
+	}
+	return g.do(ctx, http.MethodPost, projectPath(opts.Owner, opts.Repository)+"/deploy_keys", body, nil)
+}
+
+// DeleteRepo deletes a Gitlab project.
+func (g *gitlabProvider) DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts) error {
+	if err := g.do(ctx, http.MethodDelete, projectPath(opts.Owner, opts.Repository), nil, nil); err != nil {
+		return fmt.Errorf("deleting repository %s: %v", opts.Repository, err)
+	}
+	return nil
+}
+
+// ArchiveRepo archives a Gitlab project, making it read-only.
+func (g *gitlabProvider) ArchiveRepo(ctx context.Context, opts git.ArchiveRepoOpts) error {
+	if err := g.do(ctx, http.MethodPost, projectPath(opts.Owner, opts.Repository)+"/archive", nil, nil); err != nil {
+		return fmt.Errorf("archiving repository %s: %v", opts.Repository, err)
+	}
+	return nil
+}
+
+// CreatePullRequest opens a merge request in the configured project.
+func (g *gitlabProvider) CreatePullRequest(ctx context.Context, opts git.CreatePullRequestOpts) (*git.PullRequest, error) {
+	r := g.config.Repository
+	logger.V(3).Info("Creating Gitlab merge request", "repository", r, "owner", g.config.Owner, "head", opts.Head, "base", opts.Base)
+	body := map[string]interface{}{
+		"source_branch": opts.Head,
+		"target_branch": opts.Base,
+		"title":         opts.Title,
+		"description":   opts.Description,
+	}
+
+	mr := &mergeRequest{}
+	if err := g.do(ctx, http.MethodPost, projectPath(g.config.Owner, r)+"/merge_requests", body, mr); err != nil {
+		return nil, fmt.Errorf("creating merge request in repository %s: %v", r, err)
+	}
+	return &git.PullRequest{Number: mr.IID, Url: mr.WebUrl}, nil
+}
+
+// Validate validates the Gitlab setup and access.
+func (g *gitlabProvider) Validate(ctx context.Context) error {
+	token := &personalAccessToken{}
+	if err := g.do(ctx, http.MethodGet, "/personal_access_tokens/self", nil, token); err != nil {
+		return fmt.Errorf("getting Gitlab access token scopes: %v", err)
+	}
+	if !hasScope(token.Scopes, apiScope) {
+		return fmt.Errorf("gitlab access token does not have the required %s scope; scopes: %s", apiScope, strings.Join(token.Scopes, ","))
+	}
+	logger.MarkPass("Gitlab access token has the required api scope")
+
+	if g.config.Personal {
+		u := &user{}
+		if err := g.do(ctx, http.MethodGet, "/user", nil, u); err != nil {
+			return fmt.Errorf("getting authenticated Gitlab user: %v", err)
+		}
+		if !strings.EqualFold(g.config.Owner, u.Username) {
+			return fmt.Errorf("the authenticated Gitlab user and owner %s specified in the EKS-A gitops spec don't match; confirm access token owner is %s", g.config.Owner, g.config.Owner)
+		}
+		return nil
+	}
+
+	if err := g.do(ctx, http.MethodGet, "/groups/"+url.PathEscape(g.config.Owner), nil, nil); err != nil {
+		return fmt.Errorf("the authenticated gitlab user doesn't have proper access to gitlab group %s, %v", g.config.Owner, err)
+	}
+	return nil
+}
+
+// PathExists checks if a directory or file exists in the branch of the remote project.
+// The tree of a file path is empty, so the files api is used as a fallback before reporting the path as missing.
+func (g *gitlabProvider) PathExists(ctx context.Context, owner, repo, branch, path string) (bool, error) {
+	query := url.Values{"ref": []string{branch}, "path": []string{path}, "per_page": []string{"1"}}
+	var tree []json.RawMessage
+	err := g.do(ctx, http.MethodGet, projectPath(owner, repo)+"/repository/tree?"+query.Encode(), nil, &tree)
+	if err != nil && !isNotFound(err) {
+		return false, fmt.Errorf("failed checking if path %s exists in remote gitlab repository: %v", path, err)
+	}
+	if len(tree) > 0 {
+		return true, nil
+	}
+
+	query = url.Values{"ref": []string{branch}}
+	err = g.do(ctx, http.MethodHead, projectPath(owner, repo)+"/repository/files/"+url.PathEscape(path)+"?"+query.Encode(), nil, nil)
+	if isNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed checking if path %s exists in remote gitlab repository: %v", path, err)
+	}
+	return true, nil
+}
+
+func (g *gitlabProvider) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshalling gitlab request body: %v", err)
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, g.baseUrl+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(privateTokenHeader, g.auth.Token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading gitlab response: %v", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &apiError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(respBody))}
+	}
+
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("unmarshalling gitlab response: %v", err)
+	}
+	return nil
+}
+
+func (p *project) toRepository() *git.Repository {
+	r := &git.Repository{
+		Name:     p.Name,
+		CloneUrl: p.HTTPURLToRepo,
+	}
+	if p.Namespace.Kind == "group" {
+		r.Organization = p.Namespace.Path
+	} else {
+		r.Owner = p.Namespace.Path
+	}
+	return r
+}
+
+func projectPath(owner, repo string) string {
+	return "/projects/" + url.PathEscape(owner+"/"+repo)
+}
+
+func isNotFound(err error) bool {
+	var e *apiError
+	return errors.As(err, &e) && e.StatusCode == http.StatusNotFound
+}
+
+func hasScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func GetGitlabAccessTokenFromEnv() (string, error) {
+	logger.V(4).Info("Checking validity of Gitlab Access Token environment variable", "env var", EksaGitlabTokenEnv)
+	val, ok := os.LookupEnv(EksaGitlabTokenEnv)
+	if !ok || len(val) == 0 {
+		return "", fmt.Errorf("gitlab access token environment variable %s is invalid; could not get var from environment", EksaGitlabTokenEnv)
+	}
+	if err := os.Setenv(GitlabTokenEnv, val); err != nil {
+		return "", fmt.Errorf("unable to set %s: %v", GitlabTokenEnv, err)
+	}
+	return val, nil
+}
+
+func RepoUrl(hostname, owner, repo string) string {
+	return fmt.Sprintf(gitlabUrlTemplate, hostname, owner, repo)
+}
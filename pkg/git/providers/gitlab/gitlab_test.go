@@ -0,0 +1,272 @@
+package gitlab_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab"
+)
+
+const testToken = "glpat-test-token"
+
+type gitlabTest struct {
+	*WithT
+	ctx      context.Context
+	mux      *http.ServeMux
+	provider git.ProviderClient
+}
+
+func newGitlabTest(t *testing.T, personal bool) *gitlabTest {
+	mux := http.NewServeMux()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != testToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	config := &v1alpha1.GitlabProviderConfig{
+		Owner:      "platform",
+		Repository: "fleet",
+		Hostname:   strings.TrimPrefix(server.URL, "https://"),
+		Personal:   personal,
+	}
+	provider, err := gitlab.New(server.Client(), config, git.TokenAuth{Username: "platform", Token: testToken})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &gitlabTest{
+		WithT:    NewWithT(t),
+		ctx:      context.Background(),
+		mux:      mux,
+		provider: provider,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestGitlabGetRepoSuccess(t *testing.T) {
+	g := newGitlabTest(t, false)
+	g.mux.HandleFunc("/api/v4/projects/platform/fleet", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{
+			"name":             "fleet",
+			"http_url_to_repo": "https://gitlab.example.com/platform/fleet.git",
+			"namespace":        map[string]string{"path": "platform", "kind": "group"},
+		})
+	})
+
+	repo, err := g.provider.GetRepo(g.ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo).To(Equal(&git.Repository{
+		Name:         "fleet",
+		Organization: "platform",
+		CloneUrl:     "https://gitlab.example.com/platform/fleet.git",
+	}))
+}
+
+func TestGitlabGetRepoNotFound(t *testing.T) {
+	g := newGitlabTest(t, false)
+
+	repo, err := g.provider.GetRepo(g.ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo).To(BeNil())
+}
+
+func TestGitlabCreateRepoInGroup(t *testing.T) {
+	g := newGitlabTest(t, false)
+	g.mux.HandleFunc("/api/v4/namespaces/platform", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{"id": 42})
+	})
+	var body map[string]interface{}
+	g.mux.HandleFunc("/api/v4/projects", func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.Method).To(Equal(http.MethodPost))
+		g.Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, map[string]interface{}{
+			"name":      "fleet",
+			"namespace": map[string]string{"path": "platform", "kind": "group"},
+		})
+	})
+
+	repo, err := g.provider.CreateRepo(g.ctx, git.CreateRepoOpts{Name: "fleet", Owner: "platform", Privacy: true})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo.Name).To(Equal("fleet"))
+	g.Expect(body).To(HaveKeyWithValue("namespace_id", BeNumerically("==", 42)))
+	g.Expect(body).To(HaveKeyWithValue("visibility", "private"))
+}
+
+func TestGitlabCreateRepoGroupNotFound(t *testing.T) {
+	g := newGitlabTest(t, false)
+
+	_, err := g.provider.CreateRepo(g.ctx, git.CreateRepoOpts{Name: "fleet", Owner: "platform"})
+	g.Expect(err).To(MatchError(ContainSubstring("failed to get Gitlab group platform")))
+}
+
+func TestGitlabValidatePersonalSuccess(t *testing.T) {
+	g := newGitlabTest(t, true)
+	g.mux.HandleFunc("/api/v4/personal_access_tokens/self", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{"scopes": []string{"read_user", "api"}})
+	})
+	g.mux.HandleFunc("/api/v4/user", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{"username": "Platform"})
+	})
+
+	g.Expect(g.provider.Validate(g.ctx)).To(Succeed())
+}
+
+func TestGitlabValidateMissingScope(t *testing.T) {
+	g := newGitlabTest(t, true)
+	g.mux.HandleFunc("/api/v4/personal_access_tokens/self", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{"scopes": []string{"read_repository"}})
+	})
+
+	g.Expect(g.provider.Validate(g.ctx)).To(MatchError(ContainSubstring("does not have the required api scope")))
+}
+
+func TestGitlabValidatePersonalOwnerMismatch(t *testing.T) {
+	g := newGitlabTest(t, true)
+	g.mux.HandleFunc("/api/v4/personal_access_tokens/self", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{"scopes": []string{"api"}})
+	})
+	g.mux.HandleFunc("/api/v4/user", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{"username": "janedoe"})
+	})
+
+	g.Expect(g.provider.Validate(g.ctx)).To(MatchError(ContainSubstring("don't match")))
+}
+
+func TestGitlabValidateGroupNoAccess(t *testing.T) {
+	g := newGitlabTest(t, false)
+	g.mux.HandleFunc("/api/v4/personal_access_tokens/self", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{"scopes": []string{"api"}})
+	})
+
+	g.Expect(g.provider.Validate(g.ctx)).To(MatchError(ContainSubstring("doesn't have proper access to gitlab group platform")))
+}
+
+func TestGitlabPathExists(t *testing.T) {
+	tests := []struct {
+		testName string
+		path     string
+		want     bool
+	}{
+		{testName: "directory", path: "clusters/mgmt", want: true},
+		{testName: "file", path: "clusters/mgmt/kustomization.yaml", want: true},
+		{testName: "missing", path: "clusters/other", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := newGitlabTest(t, false)
+			g.mux.HandleFunc("/api/v4/projects/platform/fleet/repository/tree", func(w http.ResponseWriter, r *http.Request) {
+				g.Expect(r.URL.Query().Get("ref")).To(Equal("main"))
+				switch r.URL.Query().Get("path") {
+				case "clusters/mgmt":
+					writeJSON(w, []map[string]string{{"name": "kustomization.yaml"}})
+				case "clusters/mgmt/kustomization.yaml":
+					writeJSON(w, []map[string]string{})
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+			g.mux.HandleFunc("/api/v4/projects/platform/fleet/repository/files/clusters/mgmt/kustomization.yaml", func(w http.ResponseWriter, r *http.Request) {
+				g.Expect(r.Method).To(Equal(http.MethodHead))
+			})
+
+			exists, err := g.provider.PathExists(g.ctx, "platform", "fleet", "main", tt.path)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(exists).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestGitlabPathExistsError(t *testing.T) {
+	g := newGitlabTest(t, false)
+	g.mux.HandleFunc("/api/v4/projects/platform/fleet/repository/tree", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	_, err := g.provider.PathExists(g.ctx, "platform", "fleet", "main", "clusters")
+	g.Expect(err).To(MatchError(ContainSubstring("status 500")))
+}
+
+func TestGitlabDeleteRepo(t *testing.T) {
+	g := newGitlabTest(t, false)
+	g.mux.HandleFunc("/api/v4/projects/platform/fleet", func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.Method).To(Equal(http.MethodDelete))
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	g.Expect(g.provider.DeleteRepo(g.ctx, git.DeleteRepoOpts{Owner: "platform", Repository: "fleet"})).To(Succeed())
+}
+
+func TestGitlabArchiveRepo(t *testing.T) {
+	g := newGitlabTest(t, false)
+	g.mux.HandleFunc("/api/v4/projects/platform/fleet/archive", func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.Method).To(Equal(http.MethodPost))
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	g.Expect(g.provider.(git.ArchiveRepoProviderClient).ArchiveRepo(g.ctx, git.ArchiveRepoOpts{Owner: "platform", Repository: "fleet"})).To(Succeed())
+}
+
+func TestGitlabCreatePullRequest(t *testing.T) {
+	g := newGitlabTest(t, false)
+	var body map[string]interface{}
+	g.mux.HandleFunc("/api/v4/projects/platform/fleet/merge_requests", func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.Method).To(Equal(http.MethodPost))
+		g.Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, map[string]interface{}{"iid": 9, "web_url": "https://gitlab.example.com/platform/fleet/-/merge_requests/9"})
+	})
+
+	pr, err := g.provider.CreatePullRequest(g.ctx, git.CreatePullRequestOpts{Title: "Update cluster config", Head: "eksa/mgmt", Base: "main"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pr).To(Equal(&git.PullRequest{Number: 9, Url: "https://gitlab.example.com/platform/fleet/-/merge_requests/9"}))
+	g.Expect(body).To(HaveKeyWithValue("source_branch", "eksa/mgmt"))
+	g.Expect(body).To(HaveKeyWithValue("target_branch", "main"))
+}
+
+func TestGitlabCreatePullRequestError(t *testing.T) {
+	g := newGitlabTest(t, false)
+
+	_, err := g.provider.CreatePullRequest(g.ctx, git.CreatePullRequestOpts{Head: "eksa/mgmt", Base: "main"})
+	g.Expect(err).To(MatchError(ContainSubstring("creating merge request in repository fleet")))
+}
+
+func TestGetGitlabAccessTokenFromEnv(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(gitlab.EksaGitlabTokenEnv, testToken)
+	t.Setenv(gitlab.GitlabTokenEnv, "")
+
+	token, err := gitlab.GetGitlabAccessTokenFromEnv()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(token).To(Equal(testToken))
+}
+
+func TestGetGitlabAccessTokenFromEnvMissing(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(gitlab.EksaGitlabTokenEnv, "")
+
+	_, err := gitlab.GetGitlabAccessTokenFromEnv()
+	g.Expect(err).To(MatchError(ContainSubstring(gitlab.EksaGitlabTokenEnv)))
+}
+
+func TestRepoUrl(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(gitlab.RepoUrl("gitlab.example.com", "platform/infra", "fleet")).To(Equal("https://gitlab.example.com/platform/infra/fleet.git"))
+}
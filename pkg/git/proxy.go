@@ -0,0 +1,47 @@
+package git
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// ProxyConfig is the proxy the git client and the git provider API requests connect through.
+// The proxies are urls with an http, https or socks5 scheme.
+type ProxyConfig struct {
+	HttpProxy  string
+	HttpsProxy string
+	NoProxy    []string
+}
+
+// ProxyFunc returns the function selecting the proxy of each http request, skipping the NoProxy hosts.
+func (p *ProxyConfig) ProxyFunc() func(*http.Request) (*url.URL, error) {
+	config := &httpproxy.Config{
+		HTTPProxy:  p.HttpProxy,
+		HTTPSProxy: p.HttpsProxy,
+		NoProxy:    strings.Join(p.NoProxy, ","),
+	}
+	proxyFunc := config.ProxyFunc()
+	return func(r *http.Request) (*url.URL, error) {
+		return proxyFunc(r.URL)
+	}
+}
+
+// HTTPClient returns an http client that sends its requests through the proxy.
+func (p *ProxyConfig) HTTPClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = p.ProxyFunc()
+	return &http.Client{Transport: transport}
+}
+
+// SocksProxy returns the https proxy if it's a socks5 proxy, the only kind of proxy ssh connections can go through.
+// It returns an empty string otherwise.
+func (p *ProxyConfig) SocksProxy() string {
+	u, err := url.Parse(p.HttpsProxy)
+	if err != nil || (u.Scheme != "socks5" && u.Scheme != "socks5h") {
+		return ""
+	}
+	return p.HttpsProxy
+}
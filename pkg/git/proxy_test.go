@@ -0,0 +1,106 @@
+package git_test
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/git"
+)
+
+func TestProxyConfigProxyFunc(t *testing.T) {
+	proxy := &git.ProxyConfig{
+		HttpProxy:  "http://proxy.example.com:3128",
+		HttpsProxy: "http://secure-proxy.example.com:3128",
+		NoProxy:    []string{"internal.example.com", "10.0.0.0/8"},
+	}
+
+	tests := []struct {
+		testName  string
+		url       string
+		wantProxy string
+	}{
+		{
+			testName:  "http url",
+			url:       "http://github.com/org/repo.git",
+			wantProxy: "http://proxy.example.com:3128",
+		},
+		{
+			testName:  "https url",
+			url:       "https://api.github.com/repos/org/repo",
+			wantProxy: "http://secure-proxy.example.com:3128",
+		},
+		{
+			testName: "no proxy host",
+			url:      "https://internal.example.com/org/repo.git",
+		},
+		{
+			testName: "no proxy cidr",
+			url:      "https://10.1.2.3/org/repo.git",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			got, err := proxy.ProxyFunc()(req)
+			g.Expect(err).NotTo(HaveOccurred())
+			if tt.wantProxy == "" {
+				g.Expect(got).To(BeNil())
+				return
+			}
+			g.Expect(got.String()).To(Equal(tt.wantProxy))
+		})
+	}
+}
+
+func TestProxyConfigHTTPClient(t *testing.T) {
+	g := NewWithT(t)
+	proxy := &git.ProxyConfig{HttpsProxy: "http://proxy.example.com:3128"}
+	req, err := http.NewRequest(http.MethodGet, "https://github.com", nil)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	transport, ok := proxy.HTTPClient().Transport.(*http.Transport)
+	g.Expect(ok).To(BeTrue())
+	got, err := transport.Proxy(req)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got.String()).To(Equal("http://proxy.example.com:3128"))
+}
+
+func TestProxyConfigSocksProxy(t *testing.T) {
+	tests := []struct {
+		testName   string
+		httpsProxy string
+		want       string
+	}{
+		{
+			testName:   "socks5 proxy",
+			httpsProxy: "socks5://proxy.example.com:1080",
+			want:       "socks5://proxy.example.com:1080",
+		},
+		{
+			testName:   "socks5h proxy",
+			httpsProxy: "socks5h://proxy.example.com:1080",
+			want:       "socks5h://proxy.example.com:1080",
+		},
+		{
+			testName:   "http proxy",
+			httpsProxy: "http://proxy.example.com:3128",
+		},
+		{
+			testName: "no proxy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			proxy := &git.ProxyConfig{HttpsProxy: tt.httpsProxy}
+			g.Expect(proxy.SocksProxy()).To(Equal(tt.want))
+		})
+	}
+}
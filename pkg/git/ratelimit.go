@@ -0,0 +1,151 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultProviderRequestsPerHour is the GitHub documented rate limit for authenticated requests.
+	DefaultProviderRequestsPerHour = 5000
+	// DefaultProviderBurst is the number of provider requests allowed in a burst before the limit applies.
+	DefaultProviderBurst = 10
+)
+
+// ProviderRateLimiter is a token bucket limiting the rate of git provider API requests.
+// The same limiter can be shared by the provider clients of multiple clusters, so their combined
+// requests stay under the provider rate limits when they are onboarded concurrently.
+type ProviderRateLimiter struct {
+	limiter *rate.Limiter
+}
+
+// NewProviderRateLimiter returns a limiter that allows requestsPerHour requests, with bursts of up to burst requests.
+func NewProviderRateLimiter(requestsPerHour, burst int) *ProviderRateLimiter {
+	return &ProviderRateLimiter{
+		limiter: rate.NewLimiter(rate.Every(time.Hour/time.Duration(requestsPerHour)), burst),
+	}
+}
+
+// NewDefaultProviderRateLimiter returns a limiter configured with the GitHub documented rate limits.
+func NewDefaultProviderRateLimiter() *ProviderRateLimiter {
+	return NewProviderRateLimiter(DefaultProviderRequestsPerHour, DefaultProviderBurst)
+}
+
+func (l *ProviderRateLimiter) wait(ctx context.Context) error {
+	if err := l.limiter.Wait(ctx); err != nil {
+		return fmt.Errorf("waiting for git provider rate limiter: %v", err)
+	}
+	return nil
+}
+
+// rateLimitedProviderClient is a ProviderClient that waits on a rate limiter before
+// making the repository requests to the underlying provider.
+type rateLimitedProviderClient struct {
+	ProviderClient
+	limiter *ProviderRateLimiter
+}
+
+// NewRateLimitedProviderClient wraps a provider client so GetRepo, CreateRepo and PathExists requests are rate limited.
+func NewRateLimitedProviderClient(client ProviderClient, limiter *ProviderRateLimiter) ProviderClient {
+	return &rateLimitedProviderClient{
+		ProviderClient: client,
+		limiter:        limiter,
+	}
+}
+
+func (c *rateLimitedProviderClient) GetRepo(ctx context.Context) (*Repository, error) {
+	if err := c.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.ProviderClient.GetRepo(ctx)
+}
+
+func (c *rateLimitedProviderClient) CreateRepo(ctx context.Context, opts CreateRepoOpts) (*Repository, error) {
+	if err := c.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.ProviderClient.CreateRepo(ctx, opts)
+}
+
+func (c *rateLimitedProviderClient) PathExists(ctx context.Context, owner, repo, branch, path string) (bool, error) {
+	if err := c.limiter.wait(ctx); err != nil {
+		return false, err
+	}
+	return c.ProviderClient.PathExists(ctx, owner, repo, branch, path)
+}
+
+// ValidatePermissions checks the permissions of the provider credentials, if the underlying provider supports it.
+func (c *rateLimitedProviderClient) ValidatePermissions(ctx context.Context) error {
+	p, ok := c.ProviderClient.(PermissionsProviderClient)
+	if !ok {
+		return nil
+	}
+	if err := c.limiter.wait(ctx); err != nil {
+		return err
+	}
+	return p.ValidatePermissions(ctx)
+}
+
+// ValidatePushAccess checks the provider credentials can push to the repository, if the underlying provider supports it.
+func (c *rateLimitedProviderClient) ValidatePushAccess(ctx context.Context) error {
+	p, ok := c.ProviderClient.(PushAccessProviderClient)
+	if !ok {
+		return nil
+	}
+	if err := c.limiter.wait(ctx); err != nil {
+		return err
+	}
+	return p.ValidatePushAccess(ctx)
+}
+
+// ValidateBranchPush checks the protection rules of the branch accept direct pushes, if the underlying provider supports it.
+func (c *rateLimitedProviderClient) ValidateBranchPush(ctx context.Context, branch string) error {
+	p, ok := c.ProviderClient.(BranchProtectionProviderClient)
+	if !ok {
+		return nil
+	}
+	if err := c.limiter.wait(ctx); err != nil {
+		return err
+	}
+	return p.ValidateBranchPush(ctx, branch)
+}
+
+// CreateWebhook registers a webhook in the repository, if the underlying provider supports it.
+func (c *rateLimitedProviderClient) CreateWebhook(ctx context.Context, opts CreateWebhookOpts) error {
+	p, ok := c.ProviderClient.(WebhookProviderClient)
+	if !ok {
+		return errors.New("webhooks are not supported by the git provider")
+	}
+	if err := c.limiter.wait(ctx); err != nil {
+		return err
+	}
+	return p.CreateWebhook(ctx, opts)
+}
+
+// WebhookExists checks if a webhook sending to the url is registered in the repository, if the underlying provider supports it.
+func (c *rateLimitedProviderClient) WebhookExists(ctx context.Context, url string) (bool, error) {
+	p, ok := c.ProviderClient.(WebhookProviderClient)
+	if !ok {
+		return false, errors.New("webhooks are not supported by the git provider")
+	}
+	if err := c.limiter.wait(ctx); err != nil {
+		return false, err
+	}
+	return p.WebhookExists(ctx, url)
+}
+
+// ArchiveRepo archives the repository, if the underlying provider supports it.
+func (c *rateLimitedProviderClient) ArchiveRepo(ctx context.Context, opts ArchiveRepoOpts) error {
+	p, ok := c.ProviderClient.(ArchiveRepoProviderClient)
+	if !ok {
+		return errors.New("archiving repositories is not supported by the git provider")
+	}
+	if err := c.limiter.wait(ctx); err != nil {
+		return err
+	}
+	return p.ArchiveRepo(ctx, opts)
+}
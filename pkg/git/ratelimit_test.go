@@ -0,0 +1,205 @@
+package git_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/git/mocks"
+)
+
+func TestRateLimitedProviderClientSharedLimiter(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	providerA := mocks.NewMockProviderClient(ctrl)
+	providerB := mocks.NewMockProviderClient(ctrl)
+	limiter := git.NewProviderRateLimiter(1, 2)
+	a := git.NewRateLimitedProviderClient(providerA, limiter)
+	b := git.NewRateLimitedProviderClient(providerB, limiter)
+
+	providerA.EXPECT().GetRepo(ctx).Return(&git.Repository{Name: "repo"}, nil)
+	providerB.EXPECT().PathExists(ctx, "owner", "repo", "main", "path").Return(true, nil)
+
+	repo, err := a.GetRepo(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo.Name).To(Equal("repo"))
+
+	exists, err := b.PathExists(ctx, "owner", "repo", "main", "path")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(exists).To(BeTrue())
+
+	// The burst is exhausted by both clients, so the next request would have to wait for the limiter.
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = a.CreateRepo(cancelled, git.CreateRepoOpts{Name: "repo"})
+	g.Expect(err).To(MatchError(ContainSubstring("waiting for git provider rate limiter")))
+}
+
+func TestRateLimitedProviderClientNotLimitedMethods(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	provider := mocks.NewMockProviderClient(gomock.NewController(t))
+	c := git.NewRateLimitedProviderClient(provider, git.NewProviderRateLimiter(1, 1))
+
+	provider.EXPECT().Validate(ctx).Return(nil).Times(2)
+
+	g.Expect(c.Validate(ctx)).To(Succeed())
+	g.Expect(c.Validate(ctx)).To(Succeed())
+}
+
+type permissionsProviderClient struct {
+	*mocks.MockProviderClient
+	*mocks.MockPermissionsProviderClient
+}
+
+func TestRateLimitedProviderClientValidatePermissions(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	permissions := mocks.NewMockPermissionsProviderClient(ctrl)
+	limiter := git.NewDefaultProviderRateLimiter()
+	c := git.NewRateLimitedProviderClient(&permissionsProviderClient{mocks.NewMockProviderClient(ctrl), permissions}, limiter)
+
+	permissions.EXPECT().ValidatePermissions(ctx).Return(nil)
+
+	p, ok := c.(git.PermissionsProviderClient)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(p.ValidatePermissions(ctx)).To(Succeed())
+}
+
+func TestRateLimitedProviderClientValidatePermissionsNotSupported(t *testing.T) {
+	g := NewWithT(t)
+	c := git.NewRateLimitedProviderClient(mocks.NewMockProviderClient(gomock.NewController(t)), git.NewDefaultProviderRateLimiter())
+
+	g.Expect(c.(git.PermissionsProviderClient).ValidatePermissions(context.Background())).To(Succeed())
+}
+
+type pushAccessProviderClient struct {
+	*mocks.MockProviderClient
+	*mocks.MockPushAccessProviderClient
+}
+
+func TestRateLimitedProviderClientValidatePushAccess(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	pushAccess := mocks.NewMockPushAccessProviderClient(ctrl)
+	c := git.NewRateLimitedProviderClient(&pushAccessProviderClient{mocks.NewMockProviderClient(ctrl), pushAccess}, git.NewDefaultProviderRateLimiter())
+
+	pushAccess.EXPECT().ValidatePushAccess(ctx).Return(errors.New("can't push"))
+
+	g.Expect(c.(git.PushAccessProviderClient).ValidatePushAccess(ctx)).To(MatchError("can't push"))
+}
+
+func TestRateLimitedProviderClientValidatePushAccessNotSupported(t *testing.T) {
+	g := NewWithT(t)
+	c := git.NewRateLimitedProviderClient(mocks.NewMockProviderClient(gomock.NewController(t)), git.NewDefaultProviderRateLimiter())
+
+	g.Expect(c.(git.PushAccessProviderClient).ValidatePushAccess(context.Background())).To(Succeed())
+}
+
+type branchProtectionProviderClient struct {
+	*mocks.MockProviderClient
+	*mocks.MockBranchProtectionProviderClient
+}
+
+func TestRateLimitedProviderClientValidateBranchPush(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	protection := mocks.NewMockBranchProtectionProviderClient(ctrl)
+	c := git.NewRateLimitedProviderClient(&branchProtectionProviderClient{mocks.NewMockProviderClient(ctrl), protection}, git.NewDefaultProviderRateLimiter())
+
+	protection.EXPECT().ValidateBranchPush(ctx, "main").Return(&git.ProtectedBranchError{Branch: "main"})
+
+	g.Expect(c.(git.BranchProtectionProviderClient).ValidateBranchPush(ctx, "main")).To(MatchError("branch main is protected and doesn't accept direct pushes"))
+}
+
+func TestRateLimitedProviderClientValidateBranchPushNotSupported(t *testing.T) {
+	g := NewWithT(t)
+	c := git.NewRateLimitedProviderClient(mocks.NewMockProviderClient(gomock.NewController(t)), git.NewDefaultProviderRateLimiter())
+
+	g.Expect(c.(git.BranchProtectionProviderClient).ValidateBranchPush(context.Background(), "main")).To(Succeed())
+}
+
+type archiveRepoProviderClient struct {
+	*mocks.MockProviderClient
+	*mocks.MockArchiveRepoProviderClient
+}
+
+func TestRateLimitedProviderClientArchiveRepo(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	archive := mocks.NewMockArchiveRepoProviderClient(ctrl)
+	limiter := git.NewProviderRateLimiter(1, 1)
+	c := git.NewRateLimitedProviderClient(&archiveRepoProviderClient{mocks.NewMockProviderClient(ctrl), archive}, limiter)
+	opts := git.ArchiveRepoOpts{Owner: "owner", Repository: "repo"}
+
+	archive.EXPECT().ArchiveRepo(ctx, opts).Return(nil)
+
+	p, ok := c.(git.ArchiveRepoProviderClient)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(p.ArchiveRepo(ctx, opts)).To(Succeed())
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	g.Expect(p.ArchiveRepo(cancelled, opts)).To(MatchError(ContainSubstring("waiting for git provider rate limiter")))
+}
+
+func TestRateLimitedProviderClientArchiveRepoNotSupported(t *testing.T) {
+	g := NewWithT(t)
+	c := git.NewRateLimitedProviderClient(mocks.NewMockProviderClient(gomock.NewController(t)), git.NewDefaultProviderRateLimiter())
+
+	g.Expect(c.(git.ArchiveRepoProviderClient).ArchiveRepo(context.Background(), git.ArchiveRepoOpts{})).To(MatchError("archiving repositories is not supported by the git provider"))
+}
+
+type webhookProviderClient struct {
+	*mocks.MockProviderClient
+	*mocks.MockWebhookProviderClient
+}
+
+func TestRateLimitedProviderClientCreateWebhook(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	webhook := mocks.NewMockWebhookProviderClient(ctrl)
+	limiter := git.NewProviderRateLimiter(1, 1)
+	c := git.NewRateLimitedProviderClient(&webhookProviderClient{mocks.NewMockProviderClient(ctrl), webhook}, limiter)
+	opts := git.CreateWebhookOpts{Url: "https://flux.example.com/hook/abc", Secret: "secret"}
+
+	webhook.EXPECT().CreateWebhook(ctx, opts).Return(nil)
+
+	p, ok := c.(git.WebhookProviderClient)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(p.CreateWebhook(ctx, opts)).To(Succeed())
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	g.Expect(p.CreateWebhook(cancelled, opts)).To(MatchError(ContainSubstring("waiting for git provider rate limiter")))
+}
+
+func TestRateLimitedProviderClientCreateWebhookNotSupported(t *testing.T) {
+	g := NewWithT(t)
+	c := git.NewRateLimitedProviderClient(mocks.NewMockProviderClient(gomock.NewController(t)), git.NewDefaultProviderRateLimiter())
+
+	g.Expect(c.(git.WebhookProviderClient).CreateWebhook(context.Background(), git.CreateWebhookOpts{})).To(MatchError("webhooks are not supported by the git provider"))
+}
+
+func TestRateLimitedProviderClientWebhookExists(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	webhook := mocks.NewMockWebhookProviderClient(ctrl)
+	c := git.NewRateLimitedProviderClient(&webhookProviderClient{mocks.NewMockProviderClient(ctrl), webhook}, git.NewDefaultProviderRateLimiter())
+	url := "https://flux.example.com/hook/abc"
+
+	webhook.EXPECT().WebhookExists(ctx, url).Return(true, nil)
+
+	g.Expect(c.(git.WebhookProviderClient).WebhookExists(ctx, url)).To(BeTrue())
+}
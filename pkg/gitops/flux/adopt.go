@@ -0,0 +1,69 @@
+package flux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+// adoptedFluxControllers are the deployments that must be running in the flux namespace to adopt a flux installation,
+// since the eksa-system Kustomization needs the GitRepository source and the Kustomization to be reconciled.
+var adoptedFluxControllers = []string{"source-controller", "kustomize-controller"}
+
+var errAdoptedFlux = errors.New("flux installation is not managed by EKS-A")
+
+// WithAdoptExistingFlux makes InstallGitOps adopt a flux already running in the flux namespace of a self-managed cluster
+// instead of bootstrapping it again. When the flux controllers are found, only the eksa-system manifests are committed
+// along with a Kustomization that reconciles them from the GitRepository of the existing sync, named after the flux
+// namespace as flux bootstrap does, and that Kustomization is applied to the cluster. Flux upgrades are skipped for
+// adopted installations, since their components are managed by their own sync.
+func WithAdoptExistingFlux() Opt {
+	return func(f *Flux) {
+		f.adoptExistingFlux = true
+	}
+}
+
+// detectExistingFlux marks the cluster flux as adopted if adoption is enabled and the flux controllers are already
+// running in the flux namespace. Flux is never adopted for managed clusters, which are reconciled by the flux of
+// their management cluster.
+func (fc *fluxForCluster) detectExistingFlux(ctx context.Context, cluster *types.Cluster) error {
+	if !fc.adoptExistingFlux || !fc.clusterSpec.Cluster.IsSelfManaged() {
+		return nil
+	}
+
+	for _, name := range adoptedFluxControllers {
+		_, err := fc.fluxClient.GetDeployment(ctx, cluster, name, fc.namespace())
+		if apierrors.IsNotFound(err) {
+			logger.V(3).Info("Flux controller not found, bootstrapping flux", "deployment", name, "namespace", fc.namespace())
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("checking for existing flux controller %s: %v", name, err)
+		}
+	}
+
+	logger.Info("Flux is already installed, adopting it instead of bootstrapping", "namespace", fc.namespace())
+	fc.adoptedFlux = true
+	return nil
+}
+
+// applyAdoptedFluxSync applies the Kustomization wiring eksa-system into the existing flux sync, since the existing
+// flux-system Kustomization might not reconcile the cluster config path.
+func (fc *fluxForCluster) applyAdoptedFluxSync(ctx context.Context, cluster *types.Cluster) error {
+	if err := fc.fluxClient.ApplyKustomization(ctx, cluster, path.Join(fc.writer.Dir(), fc.fluxSystemDir())); err != nil {
+		return fmt.Errorf("applying adopted flux sync: %v", err)
+	}
+	return nil
+}
+
+// isAdoptedFlux returns true if the flux-system directory of the local repository has the sync of an adopted flux.
+func (fc *fluxForCluster) isAdoptedFlux() bool {
+	return validations.FileExists(path.Join(fc.writer.Dir(), fc.fluxSystemDir(), adoptedSyncFileName))
+}
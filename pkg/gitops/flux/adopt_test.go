@@ -0,0 +1,177 @@
+package flux_test
+
+import (
+	"errors"
+	"path"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+func TestInstallGitOpsAdoptsExistingFlux(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithAdoptExistingFlux())
+	fluxSystemDir := path.Join(g.writer.Dir(), "clusters/management-cluster/flux-system")
+
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: clusterSpec.FluxConfig.Spec.Github.Repository}, nil)
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.flux.EXPECT().GetDeployment(g.ctx, cluster, "source-controller", "flux-system").Return(&appsv1.Deployment{}, nil)
+	g.flux.EXPECT().GetDeployment(g.ctx, cluster, "kustomize-controller", "flux-system").Return(&appsv1.Deployment{}, nil)
+	g.git.EXPECT().Add("clusters").Return(nil)
+	g.git.EXPECT().Add(pathClaimFile(clusterSpec.Cluster.Name)).Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.flux.EXPECT().ApplyKustomization(g.ctx, cluster, fluxSystemDir).Return(nil)
+
+	g.Expect(f.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(fluxSystemDir, "eksa-sync.yaml"), "./testdata/eksa-sync.yaml")
+	test.AssertFilesEquals(t, path.Join(fluxSystemDir, "kustomization.yaml"), "./testdata/eksa-sync-kustomization.yaml")
+	g.Expect(validations.FileExists(path.Join(fluxSystemDir, "gotk-patches.yaml"))).To(BeFalse())
+}
+
+func TestInstallGitOpsAdoptExistingFluxNotInstalled(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithAdoptExistingFlux())
+	notFound := apierrors.NewNotFound(schema.GroupResource{Group: "apps", Resource: "deployments"}, "source-controller")
+
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: clusterSpec.FluxConfig.Spec.Github.Repository}, nil)
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.flux.EXPECT().GetDeployment(g.ctx, cluster, "source-controller", "flux-system").Return(nil, notFound)
+	g.git.EXPECT().Add("clusters").Return(nil)
+	g.git.EXPECT().Add(pathClaimFile(clusterSpec.Cluster.Name)).Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, clusterSpec.FluxConfig).Return(nil)
+	g.git.EXPECT().Pull(g.ctx, clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+
+	g.Expect(f.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+	test.AssertFilesEquals(t, path.Join(g.writer.Dir(), "clusters/management-cluster/flux-system/gotk-patches.yaml"), "./testdata/gotk-patches.yaml")
+}
+
+func TestInstallGitOpsAdoptExistingFluxOnWorkloadCluster(t *testing.T) {
+	cluster := &types.Cluster{ExistingManagement: true}
+	clusterName := "workload-cluster"
+	clusterConfig := v1alpha1.NewCluster(clusterName)
+	clusterConfig.SetManagedBy("management-cluster")
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithAdoptExistingFlux())
+
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: clusterSpec.FluxConfig.Spec.Github.Repository}, nil)
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add("clusters").Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.git.EXPECT().Pull(g.ctx, clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+
+	g.Expect(f.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+}
+
+func TestInstallGitOpsAdoptExistingFluxGetDeploymentError(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithAdoptExistingFlux())
+
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: clusterSpec.FluxConfig.Spec.Github.Repository}, nil)
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.flux.EXPECT().GetDeployment(g.ctx, cluster, "source-controller", "flux-system").Return(nil, errors.New("error in get"))
+
+	err := f.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})
+	g.Expect(err).To(MatchError(ContainSubstring("checking for existing flux controller source-controller: error in get")))
+}
+
+func TestInstallGitOpsAdoptExistingFluxApplyError(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithAdoptExistingFlux())
+
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: clusterSpec.FluxConfig.Spec.Github.Repository}, nil)
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.flux.EXPECT().GetDeployment(g.ctx, cluster, gomock.Any(), "flux-system").Return(&appsv1.Deployment{}, nil).Times(2)
+	g.git.EXPECT().Add("clusters").Return(nil)
+	g.git.EXPECT().Add(pathClaimFile(clusterSpec.Cluster.Name)).Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.flux.EXPECT().ApplyKustomization(g.ctx, cluster, gomock.Any()).Return(errors.New("error in apply"))
+
+	err := f.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})
+	g.Expect(err).To(MatchError(ContainSubstring("applying adopted flux sync: error in apply")))
+}
+
+func TestFluxUpgradeAdoptedFluxSkipped(t *testing.T) {
+	tt := newUpgraderTest(t)
+	tt.newSpec.VersionsBundle.Flux.Version = "v0.2.0"
+	tt.newSpec.FluxConfig = &tt.fluxConfig
+	g := newFluxTest(t)
+
+	w, err := g.writer.WithDir("clusters/management-cluster/flux-system")
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = w.Write("eksa-sync.yaml", []byte("kind: Kustomization"), filewriter.PersistentFile)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.git.EXPECT().Clone(tt.ctx).Return(nil)
+	g.git.EXPECT().Branch(tt.fluxConfig.Spec.Branch).Return(nil)
+
+	tt.Expect(g.gitOpsFlux.Upgrade(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec)).To(BeNil())
+}
+
+func TestFileGeneratorWriteAdoptedFluxSyncFilesDependsOn(t *testing.T) {
+	g := NewWithT(t)
+	_, w := test.NewWriter(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.DependsOn = []v1alpha1.FluxKustomizationDependency{
+		{Name: "cert-manager"},
+		{Name: "crds", Namespace: "platform"},
+	}
+
+	gen := flux.NewFileGenerator()
+	g.Expect(gen.Init(w, "eksa-system", "flux-system")).To(Succeed())
+	g.Expect(gen.WriteAdoptedFluxSyncFiles(clusterSpec, "clusters/management-cluster/management-cluster/eksa-system")).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "flux-system", "eksa-sync.yaml"), "./testdata/eksa-sync-depends-on.yaml")
+}
+
+func TestFileGeneratorWriteAdoptedFluxSyncFilesMultiTenancy(t *testing.T) {
+	g := NewWithT(t)
+	_, w := test.NewWriter(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.MultiTenancy = &v1alpha1.FluxMultiTenancyConfig{
+		ServiceAccountName: "eksa-reconciler",
+		TargetNamespace:    "eksa-system",
+	}
+
+	gen := flux.NewFileGenerator()
+	g.Expect(gen.Init(w, "eksa-system", "flux-system")).To(Succeed())
+	g.Expect(gen.WriteAdoptedFluxSyncFiles(clusterSpec, "clusters/management-cluster/management-cluster/eksa-system")).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "flux-system", "eksa-sync.yaml"), "./testdata/eksa-sync-multi-tenancy.yaml")
+}
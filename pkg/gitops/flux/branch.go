@@ -0,0 +1,145 @@
+package flux
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+const switchBranchClusterconfigCommitMessage = "Switch branch commit of cluster configuration; generated by EKS-A CLI"
+
+// SwitchBranch moves the GitOps configuration of the cluster to newBranch.
+// The new branch is created from the currently configured one if it doesn't exist yet. If it exists but doesn't
+// contain the cluster configuration, the configuration from the current branch is copied and committed onto it.
+// Once the branch is pushed, the flux GitRepository source in the management cluster is updated to track it.
+// On success, the FluxConfig in clusterSpec is updated to reference newBranch.
+func (f *Flux) SwitchBranch(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, newBranch string) error {
+	if f.shouldSkipFlux() {
+		logger.Info("GitOps field not specified, switch branch skipped")
+		return nil
+	}
+
+	fc, err := newFluxForCluster(f, clusterSpec, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if newBranch == fc.branch() {
+		logger.V(3).Info("GitOps is already configured with branch, nothing to switch", "branch", newBranch)
+		return nil
+	}
+
+	if err := fc.syncGitRepo(ctx); err != nil {
+		return err
+	}
+
+	dir := fc.clusterConfigDir()
+	if !validations.FileExists(path.Join(f.writer.Dir(), dir)) {
+		return fmt.Errorf("cluster config %s does not exist in branch %s", dir, fc.branch())
+	}
+
+	files, err := fc.readRepoFiles(dir)
+	if err != nil {
+		return err
+	}
+
+	logger.V(3).Info("Switching GitOps branch", "from", fc.branch(), "to", newBranch)
+	if err := f.gitClient.Branch(newBranch); err != nil {
+		return fmt.Errorf("switching to branch %s: %v", newBranch, err)
+	}
+
+	if !validations.FileExists(path.Join(f.writer.Dir(), dir)) {
+		logger.V(3).Info("Cluster config does not exist in new branch, copying it from current branch", "branch", newBranch, "path", dir)
+		if err := fc.writeRepoFiles(files); err != nil {
+			return err
+		}
+
+		if err := f.gitClient.Add(dir); err != nil {
+			return fmt.Errorf("adding %s to git: %v", dir, err)
+		}
+
+		if err := f.gitClient.Commit(switchBranchClusterconfigCommitMessage); err != nil {
+			return fmt.Errorf("committing %s to git: %v", dir, err)
+		}
+	}
+
+	if err := f.gitClient.Push(ctx); err != nil {
+		return fmt.Errorf("pushing branch %s to git: %v", newBranch, err)
+	}
+
+	if err := f.fluxClient.SetGitRepositoryBranch(ctx, managementCluster, fc.namespace(), newBranch); err != nil {
+		return fmt.Errorf("updating flux git repository branch to %s: %v", newBranch, err)
+	}
+
+	clusterSpec.FluxConfig.Spec.Branch = newBranch
+	logger.V(3).Info("Finished switching GitOps branch", "repository", fc.repository(), "branch", newBranch)
+	return nil
+}
+
+// clusterConfigDir returns the repository directory owned by the cluster.
+func (fc *fluxForCluster) clusterConfigDir() string {
+	if fc.clusterSpec.Cluster.IsManaged() {
+		return fc.eksaSystemDir()
+	}
+	return fc.path()
+}
+
+// readRepoFiles returns the content of all the files under dir in the local repository, keyed by their path
+// relative to the repository root. A missing dir is read as empty.
+func (fc *fluxForCluster) readRepoFiles(dir string) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	root := path.Join(fc.writer.Dir(), dir)
+	if !validations.FileExists(root) {
+		return files, nil
+	}
+
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(fc.writer.Dir(), p)
+		if err != nil {
+			return err
+		}
+		files[rel] = content
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading cluster config files in %s: %v", dir, err)
+	}
+
+	return files, nil
+}
+
+func (fc *fluxForCluster) writeRepoFiles(files map[string][]byte) error {
+	for p, content := range files {
+		w, err := fc.writer.WithDir(path.Dir(p))
+		if err != nil {
+			return fmt.Errorf("initializing writer for %s: %v", path.Dir(p), err)
+		}
+		w.CleanUpTemp()
+
+		if filePath, err := w.Write(path.Base(p), content, filewriter.PersistentFile); err != nil {
+			return fmt.Errorf("writing file %s: %v", filePath, err)
+		}
+	}
+	return nil
+}
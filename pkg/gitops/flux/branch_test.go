@@ -0,0 +1,114 @@
+package flux_test
+
+import (
+	"errors"
+	"os"
+	"path"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	testClusterConfigDir = "clusters/management-cluster"
+	testNewBranch        = "release-1"
+)
+
+func setupSwitchBranchRepo(t *testing.T, g fluxTest) *cluster.Spec {
+	t.Helper()
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+
+	if err := os.MkdirAll(path.Join(g.writer.Dir(), ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, content := range validKustomizationRepoFiles() {
+		p := path.Join(g.writer.Dir(), name)
+		if err := os.MkdirAll(path.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	return clusterSpec
+}
+
+func TestSwitchBranchExistingConfig(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := setupSwitchBranchRepo(t, g)
+	cluster := &types.Cluster{}
+
+	g.git.EXPECT().Branch(testNewBranch).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.flux.EXPECT().SetGitRepositoryBranch(g.ctx, cluster, "flux-system", testNewBranch).Return(nil)
+
+	g.Expect(g.gitOpsFlux.SwitchBranch(g.ctx, cluster, clusterSpec, testNewBranch)).To(Succeed())
+	g.Expect(clusterSpec.FluxConfig.Spec.Branch).To(Equal(testNewBranch))
+}
+
+func TestSwitchBranchMissingConfig(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := setupSwitchBranchRepo(t, g)
+	cluster := &types.Cluster{}
+
+	g.git.EXPECT().Branch(testNewBranch).DoAndReturn(func(name string) error {
+		return os.RemoveAll(path.Join(g.writer.Dir(), testClusterConfigDir))
+	})
+	g.git.EXPECT().Add(testClusterConfigDir).Return(nil)
+	g.git.EXPECT().Commit("Switch branch commit of cluster configuration; generated by EKS-A CLI").Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.flux.EXPECT().SetGitRepositoryBranch(g.ctx, cluster, "flux-system", testNewBranch).Return(nil)
+
+	g.Expect(g.gitOpsFlux.SwitchBranch(g.ctx, cluster, clusterSpec, testNewBranch)).To(Succeed())
+	for name, content := range validKustomizationRepoFiles() {
+		test.AssertContentToFile(t, content, path.Join(g.writer.Dir(), name))
+	}
+}
+
+func TestSwitchBranchSameBranch(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+
+	g.Expect(g.gitOpsFlux.SwitchBranch(g.ctx, &types.Cluster{}, clusterSpec, clusterSpec.FluxConfig.Spec.Branch)).To(Succeed())
+}
+
+func TestSwitchBranchNoConfigInCurrentBranch(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	if err := os.MkdirAll(path.Join(g.writer.Dir(), ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+
+	err := g.gitOpsFlux.SwitchBranch(g.ctx, &types.Cluster{}, clusterSpec, testNewBranch)
+	g.Expect(err).To(MatchError(ContainSubstring("cluster config clusters/management-cluster does not exist in branch testBranch")))
+}
+
+func TestSwitchBranchSetGitRepositoryBranchError(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := setupSwitchBranchRepo(t, g)
+	cluster := &types.Cluster{}
+
+	g.git.EXPECT().Branch(testNewBranch).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.flux.EXPECT().SetGitRepositoryBranch(g.ctx, cluster, "flux-system", testNewBranch).Return(errors.New("error in patch"))
+
+	g.Expect(g.gitOpsFlux.SwitchBranch(g.ctx, cluster, clusterSpec, testNewBranch)).To(MatchError(ContainSubstring("error in patch")))
+	g.Expect(clusterSpec.FluxConfig.Spec.Branch).To(Equal("testBranch"))
+}
+
+func TestSwitchBranchSkip(t *testing.T) {
+	g := newFluxTest(t)
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, nil, nil)
+
+	g.Expect(f.SwitchBranch(g.ctx, &types.Cluster{}, g.clusterSpec, testNewBranch)).To(Succeed())
+}
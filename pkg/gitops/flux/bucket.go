@@ -0,0 +1,140 @@
+package flux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// errBucketNotSupported is returned by the operations that only make sense for a Git repository source.
+var errBucketNotSupported = errors.New("operation not supported for a flux config with a bucket source")
+
+// usesBucket returns true if the cluster syncs from an S3 compatible bucket instead of a Git repository.
+func usesBucket(clusterSpec *cluster.Spec) bool {
+	return clusterSpec.FluxConfig != nil && clusterSpec.FluxConfig.Spec.Bucket != nil
+}
+
+// installBucketGitOps uploads the cluster manifests to the bucket and, for self-managed clusters, installs flux and
+// the flux-system sync pointing to the bucket. Files are uploaded with the same layout they'd have in a Git repo,
+// so the manifests of other clusters sharing the bucket are left untouched.
+func (f *Flux) installBucketGitOps(ctx context.Context, cluster *types.Cluster, fc *fluxForCluster) error {
+	if err := fc.resetArtifactDir(); err != nil {
+		return err
+	}
+
+	logger.Info("Adding cluster configuration files to bucket")
+	if err := fc.writeArtifactFiles(ctx); err != nil {
+		return err
+	}
+
+	if f.verifyManifestsBeforeBootstrap && !cluster.ExistingManagement {
+		if err := fc.verifyEksaManifests(ctx, cluster); err != nil {
+			return err
+		}
+	}
+
+	if err := fc.uploadToBucket(ctx, fc.path()); err != nil {
+		return err
+	}
+
+	if err := f.Bootstrap(ctx, cluster, fc.clusterSpec); err != nil {
+		return err
+	}
+
+	if fc.clusterSpec.Cluster.IsSelfManaged() {
+		if f.verifyCommittedClusterConfig {
+			if err := fc.verifyCommittedClusterConfig(); err != nil {
+				return err
+			}
+		}
+		if err := fc.setupReceiver(ctx, cluster); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BootstrapBucket installs the flux components and applies the flux-system kustomization, which syncs the
+// cluster from the bucket. The manifests have to be uploaded before, since flux can't bootstrap from a bucket.
+func (f *Flux) BootstrapBucket(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if cluster.ExistingManagement || clusterSpec.FluxConfig.Spec.Bucket == nil {
+		return nil
+	}
+
+	return f.installComponentsAndSync(ctx, cluster, clusterSpec)
+}
+
+func (f *Flux) updateBucketEksaSpec(ctx context.Context, fc *fluxForCluster) error {
+	if err := fc.resetArtifactDir(); err != nil {
+		return err
+	}
+
+	g := NewFileGenerator()
+	if err := g.Init(f.writer, fc.eksaSystemDir(), fc.fluxSystemDir()); err != nil {
+		return err
+	}
+
+	if err := g.WriteEksaFiles(fc.clusterSpec, fc.datacenterConfig, fc.machineConfigs); err != nil {
+		return err
+	}
+
+	if err := fc.uploadToBucket(ctx, fc.eksaSystemDir()); err != nil {
+		return err
+	}
+	logger.V(3).Info("Finished uploading updated cluster config file to bucket", "bucket", fc.clusterSpec.FluxConfig.Spec.Bucket.BucketName)
+	return nil
+}
+
+func (f *Flux) cleanupBucket(ctx context.Context, fc *fluxForCluster) error {
+	p := fc.path()
+	if fc.clusterSpec.Cluster.IsManaged() {
+		p = fc.eksaSystemDir()
+	}
+
+	if err := f.bucketClient.Delete(ctx, p); err != nil {
+		return fmt.Errorf("deleting %s from bucket: %v", p, err)
+	}
+	logger.V(3).Info("Finished cleaning up cluster files in bucket", "bucket", fc.clusterSpec.FluxConfig.Spec.Bucket.BucketName)
+	return nil
+}
+
+func (f *Flux) upgradeBucketFiles(ctx context.Context, fc *fluxForCluster) error {
+	if err := fc.resetArtifactDir(); err != nil {
+		return err
+	}
+
+	g := NewFileGenerator()
+	if err := g.Init(f.writer, fc.eksaSystemDir(), fc.fluxSystemDir()); err != nil {
+		return err
+	}
+
+	if err := g.WriteFluxPatch(fc.clusterSpec); err != nil {
+		return err
+	}
+
+	if err := fc.writeFluxComponents(ctx, g); err != nil {
+		return err
+	}
+
+	return fc.uploadToBucket(ctx, fc.fluxSystemDir())
+}
+
+// uploadToBucket uploads the local files under prefix to the bucket, with their path relative to the writer dir as key.
+func (fc *fluxForCluster) uploadToBucket(ctx context.Context, prefix string) error {
+	logger.V(3).Info("Uploading files to bucket", "bucket", fc.clusterSpec.FluxConfig.Spec.Bucket.BucketName, "prefix", prefix)
+	if err := fc.bucketClient.Upload(ctx, fc.writer.Dir(), prefix); err != nil {
+		return fmt.Errorf("uploading files to bucket: %v", err)
+	}
+	return nil
+}
+
+// WithBucketClient sets the client used to upload the cluster manifests to the bucket of the flux config.
+func WithBucketClient(client BucketClient) Opt {
+	return func(f *Flux) {
+		f.bucketClient = client
+	}
+}
@@ -0,0 +1,183 @@
+package flux_test
+
+import (
+	"errors"
+	"path"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	fluxMocks "github.com/aws/eks-anywhere/pkg/gitops/flux/mocks"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type bucketFluxTest struct {
+	fluxTest
+	bucket *fluxMocks.MockBucketClient
+}
+
+func newBucketFluxTest(t *testing.T) bucketFluxTest {
+	g := newFluxTest(t)
+	bucket := fluxMocks.NewMockBucketClient(gomock.NewController(t))
+	g.gitOpsFlux = flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithBucketClient(bucket))
+	return bucketFluxTest{fluxTest: g, bucket: bucket}
+}
+
+func newBucketClusterSpec(t *testing.T, clusterConfig *v1alpha1.Cluster) *cluster.Spec {
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+	clusterSpec.FluxConfig.Spec.Github = nil
+	clusterSpec.FluxConfig.Spec.Bucket = &v1alpha1.BucketConfig{
+		BucketName: "eksa-fleet",
+		Endpoint:   "minio.local:9000",
+		Region:     "us-east-1",
+		Provider:   "generic",
+		SecretRef:  "minio-credentials",
+	}
+	return clusterSpec
+}
+
+func TestInstallGitOpsBucketManagementCluster(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	g := newBucketFluxTest(t)
+	clusterSpec := newBucketClusterSpec(t, v1alpha1.NewCluster(clusterName))
+	fluxSystemDir := path.Join(g.writer.Dir(), "clusters/management-cluster/flux-system")
+
+	g.flux.EXPECT().ExportComponents(g.ctx, clusterSpec.FluxConfig).Return([]byte("components"), nil)
+	g.bucket.EXPECT().Upload(g.ctx, g.writer.Dir(), "clusters/management-cluster").Return(nil)
+	g.flux.EXPECT().InstallComponents(g.ctx, cluster, clusterSpec.FluxConfig).Return(nil)
+	g.flux.EXPECT().ApplyKustomization(g.ctx, cluster, fluxSystemDir).Return(nil)
+
+	g.Expect(g.gitOpsFlux.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(g.writer.Dir(), "clusters/management-cluster/management-cluster/eksa-system", defaultEksaClusterConfigFileName), "./testdata/cluster-config-bucket-management.yaml")
+	test.AssertFilesEquals(t, path.Join(fluxSystemDir, defaultFluxSyncFileName), "./testdata/gotk-sync-bucket.yaml")
+	test.AssertContentToFile(t, "components", path.Join(fluxSystemDir, "gotk-components.yaml"))
+}
+
+func TestInstallGitOpsBucketWorkloadCluster(t *testing.T) {
+	cluster := &types.Cluster{ExistingManagement: true}
+	clusterName := "workload-cluster"
+	clusterConfig := v1alpha1.NewCluster(clusterName)
+	clusterConfig.SetManagedBy("management-cluster")
+	g := newBucketFluxTest(t)
+	clusterSpec := newBucketClusterSpec(t, clusterConfig)
+
+	g.bucket.EXPECT().Upload(g.ctx, g.writer.Dir(), "clusters/management-cluster").Return(nil)
+
+	g.Expect(g.gitOpsFlux.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(g.writer.Dir(), "clusters/management-cluster/workload-cluster/eksa-system", defaultEksaClusterConfigFileName), "./testdata/cluster-config-bucket-workload.yaml")
+	g.Expect(path.Join(g.writer.Dir(), "clusters/management-cluster/flux-system", defaultFluxSyncFileName)).NotTo(BeAnExistingFile())
+}
+
+func TestInstallGitOpsBucketUploadError(t *testing.T) {
+	cluster := &types.Cluster{}
+	g := newBucketFluxTest(t)
+	clusterSpec := newBucketClusterSpec(t, v1alpha1.NewCluster("management-cluster"))
+
+	g.flux.EXPECT().ExportComponents(g.ctx, clusterSpec.FluxConfig).Return([]byte("components"), nil)
+	g.bucket.EXPECT().Upload(g.ctx, g.writer.Dir(), "clusters/management-cluster").Return(errors.New("error in upload"))
+
+	g.Expect(g.gitOpsFlux.InstallGitOps(g.ctx, cluster, clusterSpec, nil, nil)).To(MatchError(ContainSubstring("uploading files to bucket: error in upload")))
+}
+
+func TestInstallGitOpsBucketBootstrapError(t *testing.T) {
+	cluster := &types.Cluster{}
+	g := newBucketFluxTest(t)
+	clusterSpec := newBucketClusterSpec(t, v1alpha1.NewCluster("management-cluster"))
+
+	g.flux.EXPECT().ExportComponents(g.ctx, clusterSpec.FluxConfig).Return([]byte("components"), nil)
+	g.bucket.EXPECT().Upload(g.ctx, g.writer.Dir(), "clusters/management-cluster").Return(nil)
+	g.flux.EXPECT().InstallComponents(g.ctx, cluster, clusterSpec.FluxConfig).Return(errors.New("error in install"))
+	g.flux.EXPECT().Uninstall(g.ctx, cluster, clusterSpec.FluxConfig).Return(nil)
+
+	g.Expect(g.gitOpsFlux.InstallGitOps(g.ctx, cluster, clusterSpec, nil, nil)).To(MatchError(ContainSubstring("installing bucket gitops: error in install")))
+}
+
+func TestUpdateGitEksaSpecBucket(t *testing.T) {
+	clusterName := "management-cluster"
+	g := newBucketFluxTest(t)
+	clusterSpec := newBucketClusterSpec(t, v1alpha1.NewCluster(clusterName))
+
+	g.bucket.EXPECT().Upload(g.ctx, g.writer.Dir(), "clusters/management-cluster/management-cluster/eksa-system").Return(nil)
+
+	g.Expect(g.gitOpsFlux.UpdateGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(g.writer.Dir(), "clusters/management-cluster/management-cluster/eksa-system", defaultEksaClusterConfigFileName), "./testdata/cluster-config-bucket-management.yaml")
+}
+
+func TestCleanupGitRepoBucket(t *testing.T) {
+	tests := []struct {
+		testName    string
+		clusterName string
+		managedBy   string
+		wantPrefix  string
+	}{
+		{
+			testName:    "self-managed cluster",
+			clusterName: "management-cluster",
+			wantPrefix:  "clusters/management-cluster",
+		},
+		{
+			testName:    "workload cluster",
+			clusterName: "workload-cluster",
+			managedBy:   "management-cluster",
+			wantPrefix:  "clusters/management-cluster/workload-cluster/eksa-system",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			clusterConfig := v1alpha1.NewCluster(tt.clusterName)
+			if tt.managedBy != "" {
+				clusterConfig.SetManagedBy(tt.managedBy)
+			}
+			g := newBucketFluxTest(t)
+			clusterSpec := newBucketClusterSpec(t, clusterConfig)
+
+			g.bucket.EXPECT().Delete(g.ctx, tt.wantPrefix).Return(nil)
+
+			g.Expect(g.gitOpsFlux.CleanupGitRepo(g.ctx, clusterSpec)).To(Succeed())
+		})
+	}
+}
+
+func TestCleanupGitRepoBucketError(t *testing.T) {
+	g := newBucketFluxTest(t)
+	clusterSpec := newBucketClusterSpec(t, v1alpha1.NewCluster("management-cluster"))
+
+	g.bucket.EXPECT().Delete(g.ctx, "clusters/management-cluster").Return(errors.New("error in delete"))
+
+	g.Expect(g.gitOpsFlux.CleanupGitRepo(g.ctx, clusterSpec)).To(MatchError(ContainSubstring("error in delete")))
+}
+
+func TestValidationsBucket(t *testing.T) {
+	g := newBucketFluxTest(t)
+	clusterSpec := newBucketClusterSpec(t, v1alpha1.NewCluster("management-cluster"))
+
+	g.Expect(g.gitOpsFlux.Validations(g.ctx, clusterSpec)).To(BeEmpty())
+}
+
+func TestForceReconcileGitRepoBucket(t *testing.T) {
+	cluster := &types.Cluster{}
+	g := newBucketFluxTest(t)
+	clusterSpec := newBucketClusterSpec(t, v1alpha1.NewCluster("management-cluster"))
+
+	g.flux.EXPECT().Reconcile(g.ctx, cluster, clusterSpec.FluxConfig).Return(nil)
+
+	g.Expect(g.gitOpsFlux.ForceReconcileGitRepo(g.ctx, cluster, clusterSpec)).To(Succeed())
+}
+
+func TestValidateKustomizationBucketNotSupported(t *testing.T) {
+	g := newBucketFluxTest(t)
+	clusterSpec := newBucketClusterSpec(t, v1alpha1.NewCluster("management-cluster"))
+
+	g.Expect(g.gitOpsFlux.ValidateKustomization(g.ctx, clusterSpec)).To(MatchError(ContainSubstring("not supported for a flux config with a bucket source")))
+}
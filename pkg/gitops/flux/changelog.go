@@ -0,0 +1,165 @@
+package flux
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/version"
+)
+
+const (
+	changelogFileName = "CHANGELOG.yaml"
+	// deletedChangelogsDir is the repository directory where the changelogs of the deleted clusters are kept, since
+	// their directory is removed. It's outside of the cluster config paths, so flux never reconciles it.
+	deletedChangelogsDir = ".eksa/changelogs"
+)
+
+// changelog is the history of the operations committed by EKS-A for a cluster, oldest first.
+type changelog struct {
+	Cluster string           `json:"cluster"`
+	Entries []changelogEntry `json:"entries"`
+}
+
+type changelogEntry struct {
+	Timestamp string `json:"timestamp"`
+	// Operation is create, upgrade, delete or reverse-sync.
+	Operation                 string `json:"operation"`
+	PreviousKubernetesVersion string `json:"previousKubernetesVersion,omitempty"`
+	KubernetesVersion         string `json:"kubernetesVersion,omitempty"`
+	CLIVersion                string `json:"cliVersion,omitempty"`
+}
+
+// WithChangelog makes the cluster config commits append an entry with the operation, the Kubernetes versions and the
+// EKS-A version to a CHANGELOG.yaml file in the eksa-system directory of the cluster, which is never listed in the
+// kustomization, so it isn't reconciled by flux.
+// When the cluster is deleted, its changelog is moved to .eksa/changelogs/<cluster name>.yaml.
+func WithChangelog() Opt {
+	return func(f *Flux) {
+		f.changelog = true
+	}
+}
+
+func (fc *fluxForCluster) changelogFile() string {
+	return path.Join(fc.eksaSystemDir(), changelogFileName)
+}
+
+func (fc *fluxForCluster) deletedChangelogFile() string {
+	return path.Join(deletedChangelogsDir, fc.clusterSpec.Cluster.Name+".yaml")
+}
+
+// appendChangelogEntry appends an entry for the operation to the changelog of the cluster in the local repository.
+// previousConfig is the content of the cluster config file before the operation, if any. The changelog is staged
+// with the eksa-system directory.
+func (fc *fluxForCluster) appendChangelogEntry(operation string, previousConfig []byte) error {
+	if !fc.Flux.changelog {
+		return nil
+	}
+
+	c, err := readChangelog(path.Join(fc.writer.Dir(), fc.changelogFile()))
+	if err != nil {
+		return err
+	}
+
+	c.Cluster = fc.clusterSpec.Cluster.Name
+	c.Entries = append(c.Entries, fc.changelogEntry(operation, previousConfig, string(fc.clusterSpec.Cluster.Spec.KubernetesVersion)))
+	return fc.writeChangelog(fc.changelogFile(), c)
+}
+
+// archiveChangelog appends a delete entry to the changelog of the cluster and moves it to the deleted changelogs
+// directory, after the changelog of a previous cluster with the same name, and stages it.
+func (fc *fluxForCluster) archiveChangelog() error {
+	if !fc.Flux.changelog {
+		return nil
+	}
+
+	current, err := readChangelog(path.Join(fc.writer.Dir(), fc.changelogFile()))
+	if err != nil {
+		return err
+	}
+
+	c, err := readChangelog(path.Join(fc.writer.Dir(), fc.deletedChangelogFile()))
+	if err != nil {
+		return err
+	}
+
+	previousConfig, err := fc.readClusterConfigFile()
+	if err != nil {
+		return err
+	}
+
+	c.Cluster = fc.clusterSpec.Cluster.Name
+	c.Entries = append(c.Entries, current.Entries...)
+	c.Entries = append(c.Entries, fc.changelogEntry(deleteOperation, previousConfig, ""))
+	if err := fc.writeChangelog(fc.deletedChangelogFile(), c); err != nil {
+		return err
+	}
+
+	if err := fc.gitClient.Add(fc.deletedChangelogFile()); err != nil {
+		return fmt.Errorf("adding %s to git: %v", fc.deletedChangelogFile(), err)
+	}
+	return nil
+}
+
+func (fc *fluxForCluster) changelogEntry(operation string, previousConfig []byte, kubernetesVersion string) changelogEntry {
+	e := changelogEntry{
+		Timestamp:         time.Now().UTC().Format(time.RFC3339),
+		Operation:         operation,
+		KubernetesVersion: kubernetesVersion,
+		CLIVersion:        version.Get().GitVersion,
+	}
+
+	if len(previousConfig) > 0 {
+		if previous, err := cluster.ParseConfig(previousConfig); err != nil {
+			logger.V(3).Info("Could not parse the previous cluster config, skipping its Kubernetes version in the changelog", "error", err)
+		} else {
+			e.PreviousKubernetesVersion = string(previous.Cluster.Spec.KubernetesVersion)
+		}
+	}
+	return e
+}
+
+// writeChangelog writes the changelog to the file, relative to the root of the repository.
+func (fc *fluxForCluster) writeChangelog(file string, c *changelog) error {
+	content, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("marshalling changelog: %v", err)
+	}
+
+	dir := path.Dir(file)
+	w, err := fc.writer.WithDir(dir)
+	if err != nil {
+		return fmt.Errorf("initializing writer for %s: %v", dir, err)
+	}
+	w.CleanUpTemp()
+
+	if _, err := w.Write(path.Base(file), content, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("writing changelog: %v", err)
+	}
+	return nil
+}
+
+// readChangelog returns the changelog in file, or an empty changelog if it doesn't exist.
+func readChangelog(file string) (*changelog, error) {
+	content, err := os.ReadFile(file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &changelog{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading changelog: %v", err)
+	}
+
+	c := &changelog{}
+	if err := yaml.Unmarshal(content, c); err != nil {
+		return nil, fmt.Errorf("parsing changelog %s: %v", file, err)
+	}
+	return c, nil
+}
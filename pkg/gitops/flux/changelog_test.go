@@ -0,0 +1,119 @@
+package flux_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	"github.com/aws/eks-anywhere/pkg/providers"
+)
+
+const changelogFile = "clusters/management-cluster/management-cluster/eksa-system/CHANGELOG.yaml"
+
+type testChangelog struct {
+	Cluster string `json:"cluster"`
+	Entries []struct {
+		Timestamp                 string `json:"timestamp"`
+		Operation                 string `json:"operation"`
+		PreviousKubernetesVersion string `json:"previousKubernetesVersion"`
+		KubernetesVersion         string `json:"kubernetesVersion"`
+	} `json:"entries"`
+}
+
+func (tt *driftTest) readChangelog(file string) *testChangelog {
+	content, err := os.ReadFile(path.Join(tt.writer.Dir(), file))
+	tt.Expect(err).NotTo(HaveOccurred())
+	c := &testChangelog{}
+	tt.Expect(yaml.Unmarshal(content, c)).To(Succeed())
+	return c
+}
+
+func (tt *driftTest) writeChangelog(file, content string) {
+	w, err := tt.writer.WithDir(path.Dir(file))
+	tt.Expect(err).NotTo(HaveOccurred())
+	_, err = w.Write(path.Base(file), []byte(content), filewriter.PersistentFile)
+	tt.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestUpdateGitEksaSpecChangelog(t *testing.T) {
+	tt := newDriftTest(t)
+	clusterSpec := tt.liveClusterSpec()
+	clusterSpec.Cluster.TypeMeta = tt.committed.Cluster.TypeMeta
+	clusterSpec.Cluster.Spec.KubernetesVersion = v1alpha1.Kube121
+	f := flux.NewFluxFromGitOpsFluxClient(tt.flux, tt.git, tt.writer, nil, flux.WithChangelog())
+	tt.writeChangelog(changelogFile, `cluster: management-cluster
+entries:
+- timestamp: "2022-01-01T00:00:00Z"
+  operation: create
+  kubernetesVersion: "1.19"
+`)
+
+	tt.expectSync()
+	tt.git.EXPECT().Add("clusters/management-cluster/management-cluster/eksa-system").Return(nil)
+	tt.git.EXPECT().Commit(gomock.Any()).Return(nil)
+	tt.git.EXPECT().Push(tt.ctx).Return(nil)
+
+	tt.Expect(f.UpdateGitEksaSpec(tt.ctx, clusterSpec, clusterSpec.VSphereDatacenter, []providers.MachineConfig{clusterSpec.VSphereMachineConfigs["management-cluster"]})).To(Succeed())
+
+	c := tt.readChangelog(changelogFile)
+	tt.Expect(c.Cluster).To(Equal("management-cluster"))
+	tt.Expect(c.Entries).To(HaveLen(2))
+	tt.Expect(c.Entries[0].Operation).To(Equal("create"))
+	tt.Expect(c.Entries[1].Operation).To(Equal("upgrade"))
+	tt.Expect(c.Entries[1].PreviousKubernetesVersion).To(Equal("1.19"))
+	tt.Expect(c.Entries[1].KubernetesVersion).To(Equal("1.21"))
+	tt.Expect(c.Entries[1].Timestamp).NotTo(BeEmpty())
+}
+
+func TestUpdateGitEksaSpecChangelogDisabled(t *testing.T) {
+	tt := newDriftTest(t)
+	clusterSpec := tt.liveClusterSpec()
+
+	tt.expectSync()
+	tt.git.EXPECT().Add("clusters/management-cluster/management-cluster/eksa-system").Return(nil)
+	tt.git.EXPECT().Commit(gomock.Any()).Return(nil)
+	tt.git.EXPECT().Push(tt.ctx).Return(nil)
+
+	tt.Expect(tt.gitOpsFlux.UpdateGitEksaSpec(tt.ctx, clusterSpec, clusterSpec.VSphereDatacenter, []providers.MachineConfig{clusterSpec.VSphereMachineConfigs["management-cluster"]})).To(Succeed())
+	tt.Expect(path.Join(tt.writer.Dir(), changelogFile)).NotTo(BeAnExistingFile())
+}
+
+func TestCleanupGitRepoChangelog(t *testing.T) {
+	tt := newDriftTest(t)
+	f := flux.NewFluxFromGitOpsFluxClient(tt.flux, tt.git, tt.writer, nil, flux.WithChangelog())
+	archived := ".eksa/changelogs/management-cluster.yaml"
+	tt.writeChangelog(archived, `cluster: management-cluster
+entries:
+- timestamp: "2021-01-01T00:00:00Z"
+  operation: delete
+  previousKubernetesVersion: "1.18"
+`)
+	tt.writeChangelog(changelogFile, `cluster: management-cluster
+entries:
+- timestamp: "2022-01-01T00:00:00Z"
+  operation: create
+  kubernetesVersion: "1.19"
+`)
+
+	tt.expectSync()
+	tt.git.EXPECT().Remove("clusters/management-cluster").Return(nil)
+	tt.git.EXPECT().Add(archived).Return(nil)
+	tt.git.EXPECT().Commit("Delete commit of cluster configuration; generated by EKS-A CLI").Return(nil)
+	tt.git.EXPECT().Push(tt.ctx).Return(nil)
+
+	tt.Expect(f.CleanupGitRepo(tt.ctx, tt.clusterSpec)).To(Succeed())
+
+	c := tt.readChangelog(archived)
+	tt.Expect(c.Entries).To(HaveLen(3))
+	tt.Expect(c.Entries[1].Operation).To(Equal("create"))
+	tt.Expect(c.Entries[2].Operation).To(Equal("delete"))
+	tt.Expect(c.Entries[2].PreviousKubernetesVersion).To(Equal("1.19"))
+	tt.Expect(c.Entries[2].KubernetesVersion).To(BeEmpty())
+}
@@ -0,0 +1,144 @@
+package flux
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+// installCheckpointDir is the directory of the install checkpoints in the local repository. It's in the git
+// directory so the checkpoints are never committed and are kept as long as the local repository.
+const installCheckpointDir = ".git/eksa"
+
+// installPhase is a phase of InstallGitOps recorded in the install checkpoint once it's completed.
+type installPhase string
+
+const (
+	repositoryCreatedPhase  installPhase = "repository-created"
+	manifestsCommittedPhase installPhase = "manifests-committed"
+	fluxBootstrappedPhase   installPhase = "flux-bootstrapped"
+	pulledPhase             installPhase = "pulled"
+)
+
+// installCheckpoint is the progress of the GitOps installation of a cluster.
+type installCheckpoint struct {
+	Cluster string `json:"cluster"`
+	// Path is the cluster config path the phases were completed for. The checkpoint is ignored if it changed.
+	Path            string         `json:"path"`
+	CompletedPhases []installPhase `json:"completedPhases"`
+}
+
+func (c *installCheckpoint) completed(phase installPhase) bool {
+	for _, p := range c.CompletedPhases {
+		if p == phase {
+			return true
+		}
+	}
+	return false
+}
+
+// WithInstallCheckpoint makes InstallGitOps record the phases it completed in the local repository, so a re-run
+// after a failure resumes from the failed phase instead of failing because the cluster config path already exists.
+// Use it with a git client whose repository directory is kept across the runs of the same command.
+func WithInstallCheckpoint() Opt {
+	return func(f *Flux) {
+		f.installCheckpoint = true
+	}
+}
+
+func (fc *fluxForCluster) installCheckpointFile() string {
+	return path.Join(installCheckpointDir, fmt.Sprintf("install-%s.yaml", fc.clusterSpec.Cluster.Name))
+}
+
+// readInstallCheckpoint returns the install checkpoint of the cluster in the local repository. It's empty if it
+// doesn't exist, the checkpoints are disabled or it was recorded for another cluster config path.
+func (fc *fluxForCluster) readInstallCheckpoint() (*installCheckpoint, error) {
+	empty := &installCheckpoint{Cluster: fc.clusterSpec.Cluster.Name, Path: fc.path()}
+	if !fc.Flux.installCheckpoint {
+		return empty, nil
+	}
+
+	content, err := os.ReadFile(path.Join(fc.writer.Dir(), fc.installCheckpointFile()))
+	if errors.Is(err, fs.ErrNotExist) {
+		return empty, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading gitops install checkpoint: %v", err)
+	}
+
+	c := &installCheckpoint{}
+	if err := yaml.Unmarshal(content, c); err != nil {
+		return nil, fmt.Errorf("parsing gitops install checkpoint %s: %v", fc.installCheckpointFile(), err)
+	}
+
+	if c.Path != fc.path() {
+		logger.V(3).Info("Cluster config path changed since the install checkpoint, ignoring it", "checkpointPath", c.Path, "path", fc.path())
+		return empty, nil
+	}
+	return c, nil
+}
+
+// resuming returns true if the phase was completed by a previous run of InstallGitOps.
+func (fc *fluxForCluster) resuming(phase installPhase) bool {
+	return fc.checkpoint != nil && fc.checkpoint.completed(phase)
+}
+
+// completeInstallPhase records the phase as completed in the install checkpoint. A phase that isn't recorded is
+// only run again when resuming, so a failure is only logged.
+func (fc *fluxForCluster) completeInstallPhase(phase installPhase) {
+	if !fc.Flux.installCheckpoint || fc.checkpoint == nil || fc.checkpoint.completed(phase) {
+		return
+	}
+	fc.checkpoint.CompletedPhases = append(fc.checkpoint.CompletedPhases, phase)
+
+	if err := fc.writeInstallCheckpoint(); err != nil {
+		logger.Info("Warning: failed to record the gitops install checkpoint", "phase", phase, "error", err.Error())
+		return
+	}
+	logger.V(4).Info("Recorded gitops install checkpoint", "phase", phase)
+}
+
+func (fc *fluxForCluster) writeInstallCheckpoint() error {
+	content, err := yaml.Marshal(fc.checkpoint)
+	if err != nil {
+		return fmt.Errorf("marshalling checkpoint: %v", err)
+	}
+
+	w, err := fc.writer.WithDir(installCheckpointDir)
+	if err != nil {
+		return fmt.Errorf("initializing writer for %s: %v", installCheckpointDir, err)
+	}
+	w.CleanUpTemp()
+
+	if _, err := w.Write(path.Base(fc.installCheckpointFile()), content, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("writing checkpoint: %v", err)
+	}
+	return nil
+}
+
+// removeInstallCheckpoint removes the install checkpoint once the installation completed, so a later installation
+// of a cluster with the same name starts from the beginning.
+func (fc *fluxForCluster) removeInstallCheckpoint() {
+	if !fc.Flux.installCheckpoint {
+		return
+	}
+
+	if err := os.Remove(path.Join(fc.writer.Dir(), fc.installCheckpointFile())); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		logger.Info("Warning: failed to remove the gitops install checkpoint", "file", fc.installCheckpointFile(), "error", err.Error())
+	}
+}
+
+// manifestsCommitted returns true if a previous run of InstallGitOps committed the cluster config and it's still
+// in the branch. It isn't after the staging branch was deleted or the cluster config was reverted on a failed
+// bootstrap, or when it was pushed for a pull request that isn't merged, so it's committed again.
+func (fc *fluxForCluster) manifestsCommitted() bool {
+	return fc.resuming(manifestsCommittedPhase) && validations.FileExists(path.Join(fc.writer.Dir(), fc.eksaSystemDir(), clusterConfigFileName))
+}
@@ -0,0 +1,169 @@
+package flux_test
+
+import (
+	"errors"
+	"os"
+	"path"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+func (g *fluxTest) installCheckpointFile(clusterName string) string {
+	return path.Join(g.writer.Dir(), ".git/eksa", "install-"+clusterName+".yaml")
+}
+
+func (g *fluxTest) writeInstallCheckpoint(clusterName, content string) {
+	g.Expect(os.MkdirAll(path.Join(g.writer.Dir(), ".git/eksa"), 0o755)).To(Succeed())
+	g.Expect(os.WriteFile(g.installCheckpointFile(clusterName), []byte(content), 0o644)).To(Succeed())
+}
+
+func (g *fluxTest) writeCommittedClusterConfig(configPath string) {
+	dir := path.Join(g.writer.Dir(), configPath, "management-cluster", "eksa-system")
+	g.Expect(os.MkdirAll(dir, 0o755)).To(Succeed())
+	g.Expect(os.WriteFile(path.Join(dir, "eksa-cluster.yaml"), []byte("kind: Cluster\n"), 0o644)).To(Succeed())
+}
+
+func TestInstallGitOpsCheckpointBootstrapError(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithInstallCheckpoint())
+
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: clusterSpec.FluxConfig.Spec.Github.Repository}, nil)
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add("clusters").Return(nil)
+	g.git.EXPECT().Add(pathClaimFile(clusterName)).Return(nil)
+	g.git.EXPECT().Commit(initialCommitMessage).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, clusterSpec.FluxConfig).Return(errors.New("error in bootstrap"))
+	g.flux.EXPECT().Uninstall(g.ctx, cluster, clusterSpec.FluxConfig).Return(nil)
+
+	g.Expect(f.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(MatchError(ContainSubstring("error in bootstrap")))
+
+	content, err := os.ReadFile(g.installCheckpointFile(clusterName))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(content)).To(Equal(`cluster: management-cluster
+completedPhases:
+- repository-created
+- manifests-committed
+path: clusters/management-cluster
+`))
+}
+
+func TestInstallGitOpsCheckpointResumeBootstrap(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithInstallCheckpoint())
+	g.writeInstallCheckpoint(clusterName, `cluster: management-cluster
+path: clusters/management-cluster
+completedPhases:
+- repository-created
+- manifests-committed
+`)
+	g.writeCommittedClusterConfig("clusters/management-cluster")
+
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, clusterSpec.FluxConfig).Return(nil)
+	g.git.EXPECT().Pull(g.ctx, clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+
+	g.Expect(f.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+	g.Expect(g.installCheckpointFile(clusterName)).NotTo(BeAnExistingFile())
+}
+
+func TestInstallGitOpsCheckpointResumeAfterBootstrap(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithInstallCheckpoint())
+	g.writeInstallCheckpoint(clusterName, `cluster: management-cluster
+path: clusters/management-cluster
+completedPhases:
+- repository-created
+- manifests-committed
+- flux-bootstrapped
+- pulled
+`)
+	g.writeCommittedClusterConfig("clusters/management-cluster")
+
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+
+	g.Expect(f.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+}
+
+func TestInstallGitOpsCheckpointResumeConfigNotInBranch(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithInstallCheckpoint())
+	g.writeInstallCheckpoint(clusterName, `cluster: management-cluster
+path: clusters/management-cluster
+completedPhases:
+- repository-created
+- manifests-committed
+`)
+
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add("clusters").Return(nil)
+	g.git.EXPECT().Add(pathClaimFile(clusterName)).Return(nil)
+	g.git.EXPECT().Commit(initialCommitMessage).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, clusterSpec.FluxConfig).Return(nil)
+	g.git.EXPECT().Pull(g.ctx, clusterSpec.FluxConfig.Spec.Branch).Return(&git.RepositoryUpToDateError{})
+
+	g.Expect(f.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+}
+
+func TestInstallGitOpsCheckpointPathChanged(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithInstallCheckpoint())
+	g.writeInstallCheckpoint(clusterName, `cluster: management-cluster
+path: clusters/other
+completedPhases:
+- repository-created
+`)
+
+	g.git.EXPECT().GetRepo(g.ctx).Return(nil, errors.New("error describing repo"))
+
+	g.Expect(f.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(MatchError(ContainSubstring("error describing repo")))
+}
+
+func TestInstallGitOpsCheckpointInvalid(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithInstallCheckpoint())
+	g.writeInstallCheckpoint(clusterName, "completedPhases: repository-created")
+
+	g.Expect(f.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(MatchError(ContainSubstring("parsing gitops install checkpoint")))
+}
+
+func TestValidationsPathCommittedByCheckpoint(t *testing.T) {
+	g := newFluxTest(t)
+	_, repo, path := g.setupFlux()
+	g.gitOpsFlux = flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithInstallCheckpoint())
+	g.writeInstallCheckpoint(g.clusterSpec.Cluster.Name, "path: "+path+"\ncompletedPhases:\n- repository-created\n- manifests-committed\n")
+	g.git.EXPECT().ValidateProviderPermissions(g.ctx).Return(nil)
+	g.git.EXPECT().ValidateBranchPush(g.ctx, "main").Return(nil)
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: repo}, nil)
+	g.git.EXPECT().ValidatePushAccess(g.ctx).Return(nil)
+	g.git.EXPECT().RemoteBranchExists(g.ctx, "main").Return(true, nil)
+
+	g.Expect(runValidations(g.gitOpsFlux.Validations(g.ctx, g.clusterSpec))).To(Succeed())
+}
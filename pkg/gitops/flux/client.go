@@ -2,9 +2,17 @@ package flux
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/config"
@@ -14,17 +22,28 @@ import (
 )
 
 const (
-	maxRetries          = 5
-	backOffPeriod       = 5 * time.Second
 	reconcileAnnotation = "kustomize.toolkit.fluxcd.io/reconcile"
+
+	defaultRetryInitialBackoff = time.Second
+	defaultRetryMaxBackoff     = 30 * time.Second
+	defaultRetryMaxElapsedTime = 2 * time.Minute
 )
 
 // FluxClient is an interface that abstracts the basic commands of flux executable.
 type FluxClient interface {
 	BootstrapGithub(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
+	BootstrapGitlab(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
+	BootstrapBitbucketServer(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
+	BootstrapAzureDevOps(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
+	BootstrapGitea(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
+	BootstrapCodeCommit(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
 	BootstrapGit(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig, cliConfig *config.CliConfig) error
 	Uninstall(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
 	Reconcile(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
+	InstallComponents(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
+	ExportComponents(ctx context.Context, fluxConfig *v1alpha1.FluxConfig) ([]byte, error)
+	PushArtifact(ctx context.Context, fluxConfig *v1alpha1.FluxConfig, dir string) error
+	PullArtifact(ctx context.Context, fluxConfig *v1alpha1.FluxConfig, dir string) error
 }
 
 // KubeClient is an interface that abstracts the basic commands of kubectl executable.
@@ -33,6 +52,12 @@ type KubeClient interface {
 	UpdateAnnotation(ctx context.Context, resourceType, objectName string, annotations map[string]string, opts ...executables.KubectlOpt) error
 	RemoveAnnotation(ctx context.Context, resourceType, objectName string, key string, opts ...executables.KubectlOpt) error
 	DeleteSecret(ctx context.Context, managementCluster *types.Cluster, secretName, namespace string) error
+	MergePatchResource(ctx context.Context, resourceType, objectName, patch string, opts ...executables.KubectlOpt) error
+	DryRunApplyKustomization(ctx context.Context, cluster *types.Cluster, dir string) error
+	ApplyKustomization(ctx context.Context, cluster *types.Cluster, dir string) error
+	GetDeployment(ctx context.Context, name, namespace, kubeconfig string) (*appsv1.Deployment, error)
+	ApplyKubeSpecFromBytesWithNamespace(ctx context.Context, cluster *types.Cluster, data []byte, namespace string) error
+	GetObject(ctx context.Context, resourceType, name, namespace, kubeconfig string, obj runtime.Object) error
 }
 
 type fluxClient struct {
@@ -45,8 +70,26 @@ func newFluxClient(flux FluxClient, kube KubeClient) *fluxClient {
 	return &fluxClient{
 		flux:    flux,
 		kube:    kube,
-		Retrier: retrier.NewWithMaxRetries(maxRetries, backOffPeriod),
+		Retrier: newRetrier(nil),
+	}
+}
+
+// newRetrier returns the retrier of the flux and git operations, with an exponential backoff and jitter between
+// retries. The backoff and the max time spent retrying each operation default unless set in the cli config.
+func newRetrier(cliConfig *config.CliConfig) *retrier.Retrier {
+	initial, max, maxElapsed := defaultRetryInitialBackoff, defaultRetryMaxBackoff, defaultRetryMaxElapsedTime
+	if cliConfig != nil {
+		if cliConfig.GitOpsRetryInitialBackoff > 0 {
+			initial = cliConfig.GitOpsRetryInitialBackoff
+		}
+		if cliConfig.GitOpsRetryMaxBackoff > 0 {
+			max = cliConfig.GitOpsRetryMaxBackoff
+		}
+		if cliConfig.GitOpsRetryMaxElapsedTime > 0 {
+			maxElapsed = cliConfig.GitOpsRetryMaxElapsedTime
+		}
 	}
+	return retrier.New(maxElapsed, retrier.WithExponentialBackoff(initial, max))
 }
 
 func (c *fluxClient) BootstrapGithub(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error {
@@ -57,6 +100,46 @@ func (c *fluxClient) BootstrapGithub(ctx context.Context, cluster *types.Cluster
 	)
 }
 
+func (c *fluxClient) BootstrapGitlab(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error {
+	return c.Retry(
+		func() error {
+			return c.flux.BootstrapGitlab(ctx, cluster, fluxConfig)
+		},
+	)
+}
+
+func (c *fluxClient) BootstrapBitbucketServer(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error {
+	return c.Retry(
+		func() error {
+			return c.flux.BootstrapBitbucketServer(ctx, cluster, fluxConfig)
+		},
+	)
+}
+
+func (c *fluxClient) BootstrapAzureDevOps(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error {
+	return c.Retry(
+		func() error {
+			return c.flux.BootstrapAzureDevOps(ctx, cluster, fluxConfig)
+		},
+	)
+}
+
+func (c *fluxClient) BootstrapGitea(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error {
+	return c.Retry(
+		func() error {
+			return c.flux.BootstrapGitea(ctx, cluster, fluxConfig)
+		},
+	)
+}
+
+func (c *fluxClient) BootstrapCodeCommit(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error {
+	return c.Retry(
+		func() error {
+			return c.flux.BootstrapCodeCommit(ctx, cluster, fluxConfig)
+		},
+	)
+}
+
 func (c *fluxClient) BootstrapGit(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig, cliConfig *config.CliConfig) error {
 	return c.Retry(
 		func() error {
@@ -81,6 +164,40 @@ func (c *fluxClient) Reconcile(ctx context.Context, cluster *types.Cluster, flux
 	)
 }
 
+func (c *fluxClient) InstallComponents(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error {
+	return c.Retry(
+		func() error {
+			return c.flux.InstallComponents(ctx, cluster, fluxConfig)
+		},
+	)
+}
+
+func (c *fluxClient) ExportComponents(ctx context.Context, fluxConfig *v1alpha1.FluxConfig) (components []byte, err error) {
+	err = c.Retry(
+		func() error {
+			components, err = c.flux.ExportComponents(ctx, fluxConfig)
+			return err
+		},
+	)
+	return components, err
+}
+
+func (c *fluxClient) PushArtifact(ctx context.Context, fluxConfig *v1alpha1.FluxConfig, dir string) error {
+	return c.Retry(
+		func() error {
+			return c.flux.PushArtifact(ctx, fluxConfig, dir)
+		},
+	)
+}
+
+func (c *fluxClient) PullArtifact(ctx context.Context, fluxConfig *v1alpha1.FluxConfig, dir string) error {
+	return c.Retry(
+		func() error {
+			return c.flux.PullArtifact(ctx, fluxConfig, dir)
+		},
+	)
+}
+
 func (c *fluxClient) ForceReconcile(ctx context.Context, cluster *types.Cluster, namespace string) error {
 	annotations := map[string]string{
 		"reconcile.fluxcd.io/requestedAt": strconv.FormatInt(time.Now().Unix(), 10),
@@ -113,6 +230,82 @@ func (c *fluxClient) EnableResourceReconcile(ctx context.Context, cluster *types
 	)
 }
 
+func (c *fluxClient) SetGitRepositoryBranch(ctx context.Context, cluster *types.Cluster, namespace, branch string) error {
+	patch := fmt.Sprintf(`{"spec":{"ref":{"branch":%q}}}`, branch)
+
+	return c.Retry(
+		func() error {
+			return c.kube.MergePatchResource(ctx, "gitrepositories", namespace, patch, executables.WithCluster(cluster), executables.WithNamespace(namespace))
+		},
+	)
+}
+
+// SetKustomizationPath updates the path the flux-system Kustomization of the flux namespace reconciles.
+func (c *fluxClient) SetKustomizationPath(ctx context.Context, cluster *types.Cluster, namespace, path string) error {
+	patch := fmt.Sprintf(`{"spec":{"path":%q}}`, path)
+
+	return c.Retry(
+		func() error {
+			return c.kube.MergePatchResource(ctx, "kustomizations.kustomize.toolkit.fluxcd.io", namespace, patch, executables.WithCluster(cluster), executables.WithNamespace(namespace))
+		},
+	)
+}
+
+func (c *fluxClient) DryRunApplyKustomization(ctx context.Context, cluster *types.Cluster, dir string) error {
+	return c.Retry(
+		func() error {
+			return c.kube.DryRunApplyKustomization(ctx, cluster, dir)
+		},
+	)
+}
+
+func (c *fluxClient) ApplyKustomization(ctx context.Context, cluster *types.Cluster, dir string) error {
+	return c.Retry(
+		func() error {
+			return c.kube.ApplyKustomization(ctx, cluster, dir)
+		},
+	)
+}
+
+// GetDeployment returns the deployment from the cluster. Not found errors are returned without retrying.
+func (c *fluxClient) GetDeployment(ctx context.Context, cluster *types.Cluster, name, namespace string) (deployment *appsv1.Deployment, err error) {
+	var notFoundErr error
+	err = c.Retry(
+		func() error {
+			deployment, err = c.kube.GetDeployment(ctx, name, namespace, cluster.KubeconfigFile)
+			if apierrors.IsNotFound(err) {
+				notFoundErr = err
+				return nil
+			}
+			return err
+		},
+	)
+	if notFoundErr != nil {
+		return nil, notFoundErr
+	}
+	return deployment, err
+}
+
+// GetObject gets a resource from the cluster into obj, like the status of a flux resource. Not found errors are
+// returned without retrying.
+func (c *fluxClient) GetObject(ctx context.Context, cluster *types.Cluster, resourceType, name, namespace string, obj runtime.Object) error {
+	var notFoundErr error
+	err := c.Retry(
+		func() error {
+			err := c.kube.GetObject(ctx, resourceType, name, namespace, cluster.KubeconfigFile, obj)
+			if apierrors.IsNotFound(err) {
+				notFoundErr = err
+				return nil
+			}
+			return err
+		},
+	)
+	if notFoundErr != nil {
+		return notFoundErr
+	}
+	return err
+}
+
 func (c *fluxClient) DeleteSystemSecret(ctx context.Context, cluster *types.Cluster, namespace string) error {
 	return c.Retry(
 		func() error {
@@ -121,6 +314,57 @@ func (c *fluxClient) DeleteSystemSecret(ctx context.Context, cluster *types.Clus
 	)
 }
 
+// ApplySecret creates or updates an opaque secret in the cluster with the given string data.
+func (c *fluxClient) ApplySecret(ctx context.Context, cluster *types.Cluster, name, namespace string, data map[string]string) error {
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Type:       corev1.SecretTypeOpaque,
+		StringData: data,
+	}
+	content, err := yaml.Marshal(secret)
+	if err != nil {
+		return fmt.Errorf("marshalling secret %s: %v", name, err)
+	}
+
+	return c.Retry(
+		func() error {
+			return c.kube.ApplyKubeSpecFromBytesWithNamespace(ctx, cluster, content, namespace)
+		},
+	)
+}
+
+// ApplyNamespace creates or updates a namespace in the cluster with the given labels and annotations.
+func (c *fluxClient) ApplyNamespace(ctx context.Context, cluster *types.Cluster, name string, labels, annotations map[string]string) error {
+	namespace := &corev1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Namespace",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+	}
+	content, err := yaml.Marshal(namespace)
+	if err != nil {
+		return fmt.Errorf("marshalling namespace %s: %v", name, err)
+	}
+
+	return c.Retry(
+		func() error {
+			return c.kube.ApplyKubeSpecFromBytesWithNamespace(ctx, cluster, content, name)
+		},
+	)
+}
+
 func (c *fluxClient) GetCluster(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) (eksaCluster *v1alpha1.Cluster, err error) {
 	err = c.Retry(
 		func() error {
@@ -4,17 +4,25 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/gitops/flux/mocks"
 	"github.com/aws/eks-anywhere/pkg/retrier"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
 
+const maxRetries = 5
+
 type fluxClientTest struct {
 	*WithT
 	ctx        context.Context
@@ -160,6 +168,56 @@ func TestFluxClientForceReconcileError(t *testing.T) {
 	tt.Expect(tt.c.ForceReconcile(tt.ctx, tt.cluster, "flux-system")).To(MatchError(ContainSubstring("error in force reconcile")), "fluxClient.ForceReconcile() should fail after 5 tries")
 }
 
+func TestFluxClientSetGitRepositoryBranchSuccess(t *testing.T) {
+	tt := newFluxClientTest(t)
+	patch := `{"spec":{"ref":{"branch":"release-1"}}}`
+	tt.k.EXPECT().MergePatchResource(tt.ctx, "gitrepositories", "flux-system", patch, gomock.Any(), gomock.Any()).Return(errors.New("error in patch")).Times(4)
+	tt.k.EXPECT().MergePatchResource(tt.ctx, "gitrepositories", "flux-system", patch, gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	tt.Expect(tt.c.SetGitRepositoryBranch(tt.ctx, tt.cluster, "flux-system", "release-1")).To(Succeed(), "fluxClient.SetGitRepositoryBranch() should succeed with 5 tries")
+}
+
+func TestFluxClientSetGitRepositoryBranchError(t *testing.T) {
+	tt := newFluxClientTest(t)
+	tt.k.EXPECT().MergePatchResource(tt.ctx, "gitrepositories", "flux-system", gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("error in patch")).Times(5)
+	tt.k.EXPECT().MergePatchResource(tt.ctx, "gitrepositories", "flux-system", gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	tt.Expect(tt.c.SetGitRepositoryBranch(tt.ctx, tt.cluster, "flux-system", "release-1")).To(MatchError(ContainSubstring("error in patch")), "fluxClient.SetGitRepositoryBranch() should fail after 5 tries")
+}
+
+func TestFluxClientSetKustomizationPathSuccess(t *testing.T) {
+	tt := newFluxClientTest(t)
+	patch := `{"spec":{"path":"./clusters/management"}}`
+	tt.k.EXPECT().MergePatchResource(tt.ctx, "kustomizations.kustomize.toolkit.fluxcd.io", "flux-system", patch, gomock.Any(), gomock.Any()).Return(errors.New("error in patch")).Times(4)
+	tt.k.EXPECT().MergePatchResource(tt.ctx, "kustomizations.kustomize.toolkit.fluxcd.io", "flux-system", patch, gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	tt.Expect(tt.c.SetKustomizationPath(tt.ctx, tt.cluster, "flux-system", "./clusters/management")).To(Succeed(), "fluxClient.SetKustomizationPath() should succeed with 5 tries")
+}
+
+func TestFluxClientSetKustomizationPathError(t *testing.T) {
+	tt := newFluxClientTest(t)
+	tt.k.EXPECT().MergePatchResource(tt.ctx, "kustomizations.kustomize.toolkit.fluxcd.io", "flux-system", gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("error in patch")).Times(5)
+	tt.k.EXPECT().MergePatchResource(tt.ctx, "kustomizations.kustomize.toolkit.fluxcd.io", "flux-system", gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	tt.Expect(tt.c.SetKustomizationPath(tt.ctx, tt.cluster, "flux-system", "./clusters/management")).To(MatchError(ContainSubstring("error in patch")), "fluxClient.SetKustomizationPath() should fail after 5 tries")
+}
+
+func TestFluxClientDryRunApplyKustomizationSuccess(t *testing.T) {
+	tt := newFluxClientTest(t)
+	tt.k.EXPECT().DryRunApplyKustomization(tt.ctx, tt.cluster, "eksa-system").Return(errors.New("error in dry-run")).Times(4)
+	tt.k.EXPECT().DryRunApplyKustomization(tt.ctx, tt.cluster, "eksa-system").Return(nil).Times(1)
+
+	tt.Expect(tt.c.DryRunApplyKustomization(tt.ctx, tt.cluster, "eksa-system")).To(Succeed(), "fluxClient.DryRunApplyKustomization() should succeed with 5 tries")
+}
+
+func TestFluxClientDryRunApplyKustomizationError(t *testing.T) {
+	tt := newFluxClientTest(t)
+	tt.k.EXPECT().DryRunApplyKustomization(tt.ctx, tt.cluster, "eksa-system").Return(errors.New("error in dry-run")).Times(5)
+	tt.k.EXPECT().DryRunApplyKustomization(tt.ctx, tt.cluster, "eksa-system").Return(nil).AnyTimes()
+
+	tt.Expect(tt.c.DryRunApplyKustomization(tt.ctx, tt.cluster, "eksa-system")).To(MatchError(ContainSubstring("error in dry-run")), "fluxClient.DryRunApplyKustomization() should fail after 5 tries")
+}
+
 func TestFluxClientDeleteSystemSecretSuccess(t *testing.T) {
 	tt := newFluxClientTest(t)
 	tt.k.EXPECT().DeleteSecret(tt.ctx, tt.cluster, "flux-system", "custom-namespace").Return(errors.New("error in delete secret")).Times(4)
@@ -176,6 +234,38 @@ func TestFluxClientDeleteSystemSecretError(t *testing.T) {
 	tt.Expect(tt.c.DeleteSystemSecret(tt.ctx, tt.cluster, "custom-namespace")).To(MatchError(ContainSubstring("error in delete secret")), "fluxClient.DeleteSystemSecret() should fail after 5 tries")
 }
 
+func TestFluxClientApplySecretSuccess(t *testing.T) {
+	tt := newFluxClientTest(t)
+	wantSecret := "apiVersion: v1\nkind: Secret\nmetadata:\n  creationTimestamp: null\n  name: webhook-token\n  namespace: flux-system\nstringData:\n  token: abc\ntype: Opaque\n"
+	tt.k.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, tt.cluster, []byte(wantSecret), "flux-system").Return(errors.New("error in apply")).Times(4)
+	tt.k.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, tt.cluster, []byte(wantSecret), "flux-system").Return(nil).Times(1)
+
+	tt.Expect(tt.c.ApplySecret(tt.ctx, tt.cluster, "webhook-token", "flux-system", map[string]string{"token": "abc"})).To(Succeed(), "fluxClient.ApplySecret() should succeed with 5 tries")
+}
+
+func TestFluxClientApplySecretError(t *testing.T) {
+	tt := newFluxClientTest(t)
+	tt.k.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, tt.cluster, gomock.Any(), "flux-system").Return(errors.New("error in apply")).Times(5)
+
+	tt.Expect(tt.c.ApplySecret(tt.ctx, tt.cluster, "webhook-token", "flux-system", map[string]string{"token": "abc"})).To(MatchError(ContainSubstring("error in apply")), "fluxClient.ApplySecret() should fail after 5 tries")
+}
+
+func TestFluxClientApplyNamespaceSuccess(t *testing.T) {
+	tt := newFluxClientTest(t)
+	wantNamespace := "apiVersion: v1\nkind: Namespace\nmetadata:\n  annotations:\n    example.com/cost-center: platform\n  creationTimestamp: null\n  labels:\n    pod-security.kubernetes.io/enforce: restricted\n  name: flux-system\nspec: {}\nstatus: {}\n"
+	tt.k.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, tt.cluster, []byte(wantNamespace), "flux-system").Return(errors.New("error in apply")).Times(4)
+	tt.k.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, tt.cluster, []byte(wantNamespace), "flux-system").Return(nil).Times(1)
+
+	tt.Expect(tt.c.ApplyNamespace(tt.ctx, tt.cluster, "flux-system", map[string]string{"pod-security.kubernetes.io/enforce": "restricted"}, map[string]string{"example.com/cost-center": "platform"})).To(Succeed(), "fluxClient.ApplyNamespace() should succeed with 5 tries")
+}
+
+func TestFluxClientApplyNamespaceError(t *testing.T) {
+	tt := newFluxClientTest(t)
+	tt.k.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, tt.cluster, gomock.Any(), "flux-system").Return(errors.New("error in apply")).Times(5)
+
+	tt.Expect(tt.c.ApplyNamespace(tt.ctx, tt.cluster, "flux-system", nil, nil)).To(MatchError(ContainSubstring("error in apply")), "fluxClient.ApplyNamespace() should fail after 5 tries")
+}
+
 func TestFluxClientGetClusterSuccess(t *testing.T) {
 	tt := newFluxClientTest(t)
 	tt.k.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, "fluxTestCluster").Return(nil, errors.New("error in get eksa cluster")).Times(4)
@@ -195,3 +285,116 @@ func TestFluxClientGetClusterError(t *testing.T) {
 
 	tt.Expect(err).To(MatchError(ContainSubstring("error in get eksa cluster")), "fluxClient.GetCluster() should fail after 5 tries")
 }
+
+func TestFluxClientGetDeploymentSuccess(t *testing.T) {
+	tt := newFluxClientTest(t)
+	tt.cluster.KubeconfigFile = "k.kubeconfig"
+	want := &appsv1.Deployment{}
+	tt.k.EXPECT().GetDeployment(tt.ctx, "source-controller", "flux-system", "k.kubeconfig").Return(nil, errors.New("error in get deployment")).Times(4)
+	tt.k.EXPECT().GetDeployment(tt.ctx, "source-controller", "flux-system", "k.kubeconfig").Return(want, nil).Times(1)
+
+	got, err := tt.c.GetDeployment(tt.ctx, tt.cluster, "source-controller", "flux-system")
+
+	tt.Expect(err).To(Succeed(), "fluxClient.GetDeployment() should succeed with 5 tries")
+	tt.Expect(got).To(BeIdenticalTo(want))
+}
+
+func TestFluxClientGetDeploymentError(t *testing.T) {
+	tt := newFluxClientTest(t)
+	tt.k.EXPECT().GetDeployment(tt.ctx, "source-controller", "flux-system", "").Return(nil, errors.New("error in get deployment")).Times(5)
+	tt.k.EXPECT().GetDeployment(tt.ctx, "source-controller", "flux-system", "").Return(nil, nil).AnyTimes()
+
+	_, err := tt.c.GetDeployment(tt.ctx, tt.cluster, "source-controller", "flux-system")
+
+	tt.Expect(err).To(MatchError(ContainSubstring("error in get deployment")), "fluxClient.GetDeployment() should fail after 5 tries")
+}
+
+func TestFluxClientGetDeploymentNotFound(t *testing.T) {
+	tt := newFluxClientTest(t)
+	notFound := apierrors.NewNotFound(schema.GroupResource{Group: "apps", Resource: "deployments"}, "source-controller")
+	tt.k.EXPECT().GetDeployment(tt.ctx, "source-controller", "flux-system", "").Return(nil, notFound).Times(1)
+
+	_, err := tt.c.GetDeployment(tt.ctx, tt.cluster, "source-controller", "flux-system")
+
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "fluxClient.GetDeployment() should return not found errors without retrying")
+}
+
+func TestFluxClientGetObjectSuccess(t *testing.T) {
+	tt := newFluxClientTest(t)
+	tt.cluster.KubeconfigFile = "k.kubeconfig"
+	obj := &unstructured.Unstructured{}
+	tt.k.EXPECT().GetObject(tt.ctx, "kustomizations.kustomize.toolkit.fluxcd.io", "flux-system", "flux-system", "k.kubeconfig", obj).Return(errors.New("error in get object")).Times(4)
+	tt.k.EXPECT().GetObject(tt.ctx, "kustomizations.kustomize.toolkit.fluxcd.io", "flux-system", "flux-system", "k.kubeconfig", obj).Return(nil).Times(1)
+
+	tt.Expect(tt.c.GetObject(tt.ctx, tt.cluster, "kustomizations.kustomize.toolkit.fluxcd.io", "flux-system", "flux-system", obj)).To(Succeed(), "fluxClient.GetObject() should succeed with 5 tries")
+}
+
+func TestFluxClientGetObjectNotFound(t *testing.T) {
+	tt := newFluxClientTest(t)
+	notFound := apierrors.NewNotFound(schema.GroupResource{Group: "kustomize.toolkit.fluxcd.io", Resource: "kustomizations"}, "flux-system")
+	tt.k.EXPECT().GetObject(tt.ctx, "kustomizations.kustomize.toolkit.fluxcd.io", "flux-system", "flux-system", "", gomock.Any()).Return(notFound).Times(1)
+
+	err := tt.c.GetObject(tt.ctx, tt.cluster, "kustomizations.kustomize.toolkit.fluxcd.io", "flux-system", "flux-system", &unstructured.Unstructured{})
+
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "fluxClient.GetObject() should return not found errors without retrying")
+}
+
+func TestFluxClientBootstrapGitlabSuccess(t *testing.T) {
+	tt := newFluxClientTest(t)
+	tt.f.EXPECT().BootstrapGitlab(tt.ctx, tt.cluster, tt.fluxConfig).Return(errors.New("error in bootstrap gitlab")).Times(4)
+	tt.f.EXPECT().BootstrapGitlab(tt.ctx, tt.cluster, tt.fluxConfig).Return(nil).Times(1)
+
+	tt.Expect(tt.c.BootstrapGitlab(tt.ctx, tt.cluster, tt.fluxConfig)).To(Succeed(), "fluxClient.BootstrapGitlab() should succeed with 5 tries")
+}
+
+func TestFluxClientBootstrapBitbucketServerSuccess(t *testing.T) {
+	tt := newFluxClientTest(t)
+	tt.f.EXPECT().BootstrapBitbucketServer(tt.ctx, tt.cluster, tt.fluxConfig).Return(errors.New("error in bootstrap bitbucket server")).Times(4)
+	tt.f.EXPECT().BootstrapBitbucketServer(tt.ctx, tt.cluster, tt.fluxConfig).Return(nil).Times(1)
+
+	tt.Expect(tt.c.BootstrapBitbucketServer(tt.ctx, tt.cluster, tt.fluxConfig)).To(Succeed(), "fluxClient.BootstrapBitbucketServer() should succeed with 5 tries")
+}
+
+func TestFluxClientBootstrapAzureDevOpsSuccess(t *testing.T) {
+	tt := newFluxClientTest(t)
+	tt.f.EXPECT().BootstrapAzureDevOps(tt.ctx, tt.cluster, tt.fluxConfig).Return(errors.New("error in bootstrap azure devops")).Times(4)
+	tt.f.EXPECT().BootstrapAzureDevOps(tt.ctx, tt.cluster, tt.fluxConfig).Return(nil).Times(1)
+
+	tt.Expect(tt.c.BootstrapAzureDevOps(tt.ctx, tt.cluster, tt.fluxConfig)).To(Succeed(), "fluxClient.BootstrapAzureDevOps() should succeed with 5 tries")
+}
+
+func TestFluxClientBootstrapGiteaSuccess(t *testing.T) {
+	tt := newFluxClientTest(t)
+	tt.f.EXPECT().BootstrapGitea(tt.ctx, tt.cluster, tt.fluxConfig).Return(errors.New("error in bootstrap gitea")).Times(4)
+	tt.f.EXPECT().BootstrapGitea(tt.ctx, tt.cluster, tt.fluxConfig).Return(nil).Times(1)
+
+	tt.Expect(tt.c.BootstrapGitea(tt.ctx, tt.cluster, tt.fluxConfig)).To(Succeed(), "fluxClient.BootstrapGitea() should succeed with 5 tries")
+}
+
+func TestFluxClientBootstrapCodeCommitSuccess(t *testing.T) {
+	tt := newFluxClientTest(t)
+	tt.f.EXPECT().BootstrapCodeCommit(tt.ctx, tt.cluster, tt.fluxConfig).Return(errors.New("error in bootstrap codecommit")).Times(4)
+	tt.f.EXPECT().BootstrapCodeCommit(tt.ctx, tt.cluster, tt.fluxConfig).Return(nil).Times(1)
+
+	tt.Expect(tt.c.BootstrapCodeCommit(tt.ctx, tt.cluster, tt.fluxConfig)).To(Succeed(), "fluxClient.BootstrapCodeCommit() should succeed with 5 tries")
+}
+
+func TestNewRetrierFromCliConfig(t *testing.T) {
+	g := NewWithT(t)
+	r := newRetrier(&config.CliConfig{
+		GitOpsRetryInitialBackoff: time.Millisecond,
+		GitOpsRetryMaxBackoff:     2 * time.Millisecond,
+		GitOpsRetryMaxElapsedTime: 20 * time.Millisecond,
+	})
+
+	calls := 0
+	start := time.Now()
+	err := r.Retry(func() error {
+		calls++
+		return errors.New("error in operation")
+	})
+
+	g.Expect(err).To(MatchError("error in operation"))
+	g.Expect(calls).To(BeNumerically(">", 1))
+	g.Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+}
@@ -8,10 +8,12 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/git"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
 )
 
@@ -22,15 +24,48 @@ type fluxForCluster struct {
 	clusterSpec      *cluster.Spec
 	datacenterConfig providers.DatacenterConfig
 	machineConfigs   []providers.MachineConfig
+	// configPath is the cluster config path of the FluxConfig, with any template resolved for the cluster.
+	configPath string
+	// syncBranch is the branch of the FluxConfig, with any template resolved for the cluster.
+	syncBranch string
+	// layout is the eksa-system and flux-system directories of the cluster, relative to the config path.
+	layout clusterLayout
+	// pullRequestBranch is the branch the changes were pushed to for a pull request, empty if pushed to the sync branch.
+	pullRequestBranch string
+	// stagingBranch is the branch the initial cluster config was pushed to until flux is bootstrapped, empty if pushed
+	// to the sync branch.
+	stagingBranch string
+	// adoptedFlux is true if flux was already installed in the cluster and it's adopted instead of bootstrapped.
+	adoptedFlux bool
+	// checkpoint is the progress of InstallGitOps, with the phases completed by previous runs.
+	checkpoint *installCheckpoint
 }
 
-func newFluxForCluster(flux *Flux, clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) *fluxForCluster {
+func newFluxForCluster(flux *Flux, clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) (*fluxForCluster, error) {
+	configPath, err := resolveClusterConfigPath(clusterSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	branch, err := resolveBranch(clusterSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	layout, err := resolveClusterLayout(clusterSpec)
+	if err != nil {
+		return nil, err
+	}
+
 	return &fluxForCluster{
 		Flux:             flux,
 		clusterSpec:      clusterSpec,
 		datacenterConfig: datacenterConfig,
 		machineConfigs:   machineConfigs,
-	}
+		configPath:       configPath,
+		syncBranch:       branch,
+		layout:           layout,
+	}, nil
 }
 
 // commitFluxAndClusterConfigToGit commits the cluster configuration file to the flux-managed git repository.
@@ -39,12 +74,70 @@ func newFluxForCluster(flux *Flux, clusterSpec *cluster.Spec, datacenterConfig p
 // These will later be used by Flux and our controllers to reconcile the repository contents and the cluster configuration.
 func (fc *fluxForCluster) commitFluxAndClusterConfigToGit(ctx context.Context) error {
 	logger.Info("Adding cluster configuration files to Git")
-	config := fc.clusterSpec.FluxConfig
-
 	if err := fc.validateLocalConfigPathDoesNotExist(); err != nil {
 		return err
 	}
 
+	if err := fc.validatePathNotClaimed(); err != nil {
+		return err
+	}
+
+	prBranch, err := fc.checkoutPullRequestBranch(ctx)
+	if err != nil {
+		return err
+	}
+
+	if prBranch == "" {
+		if fc.stagingBranch, err = fc.checkoutStagingBranch(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := fc.writeClusterConfigFiles(); err != nil {
+		return err
+	}
+
+	if err := fc.appendChangelogEntry(createOperation, nil); err != nil {
+		return err
+	}
+
+	p := path.Dir(fc.path())
+	if err := fc.addToGit(p); err != nil {
+		return fmt.Errorf("adding %s to git: %v", p, err)
+	}
+
+	if err := fc.writePathClaim(); err != nil {
+		return err
+	}
+
+	if err := fc.validateCommitSize(); err != nil {
+		return err
+	}
+
+	msg, err := fc.clusterconfigCommitMessage(createOperation, "")
+	if err != nil {
+		return err
+	}
+
+	if prBranch != "" {
+		if err := fc.pushAndOpenPullRequest(ctx, prBranch, p, msg); err != nil {
+			return err
+		}
+		fc.pullRequestBranch = prBranch
+		return nil
+	}
+
+	if err := fc.Flux.pushToRemoteRepo(ctx, p, msg); err != nil {
+		return err
+	}
+
+	logger.V(3).Info("Finished pushing cluster config and flux custom manifest files to git")
+	return nil
+}
+
+// writeClusterConfigFiles writes the eks-a files of the cluster and, for self-managed clusters, the flux system files
+// to the local repository, with the layout flux reconciles them from.
+func (fc *fluxForCluster) writeClusterConfigFiles() error {
 	g := NewFileGenerator()
 	if err := g.Init(fc.writer, fc.eksaSystemDir(), fc.fluxSystemDir()); err != nil {
 		return err
@@ -54,31 +147,70 @@ func (fc *fluxForCluster) commitFluxAndClusterConfigToGit(ctx context.Context) e
 		return fmt.Errorf("writing eks-a config files: %v", err)
 	}
 
-	if fc.clusterSpec.Cluster.IsSelfManaged() {
+	if fc.adoptedFlux {
+		if err := g.WriteAdoptedFluxSyncFiles(fc.clusterSpec, fc.eksaSystemDir()); err != nil {
+			return fmt.Errorf("writing adopted flux sync files: %v", err)
+		}
+	} else if fc.clusterSpec.Cluster.IsSelfManaged() {
 		if err := g.WriteFluxSystemFiles(fc.clusterSpec); err != nil {
 			return fmt.Errorf("writing flux system files: %v", err)
 		}
 	}
 
-	p := path.Dir(config.Spec.ClusterConfigPath)
-	if err := fc.gitClient.Add(p); err != nil {
-		return fmt.Errorf("adding %s to git: %v", p, err)
+	if fc.usesClusterTenants() {
+		if err := fc.writeTenantFiles(g); err != nil {
+			return err
+		}
 	}
 
-	if err := fc.Flux.pushToRemoteRepo(ctx, p, initialClusterconfigCommitMessage); err != nil {
-		return err
+	if fc.usesHubAndSpoke() {
+		if err := fc.writeSpokeFiles(g); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyEksaManifests runs a server-side dry-run apply of the eksa-system kustomization against the cluster,
+// so manifests that flux would fail to reconcile are caught before flux is installed.
+func (fc *fluxForCluster) verifyEksaManifests(ctx context.Context, cluster *types.Cluster) error {
+	dir := path.Join(fc.writer.Dir(), fc.eksaSystemDir())
+	if !validations.FileExists(path.Join(dir, kustomizeFileName)) {
+		logger.V(3).Info("Eks-a kustomization does not exist, skipping manifests verification", "path", dir)
+		return nil
 	}
 
-	logger.V(3).Info("Finished pushing cluster config and flux custom manifest files to git")
+	logger.V(3).Info("Verifying eks-a manifests can be applied before flux bootstrap", "path", fc.eksaSystemDir())
+	if err := fc.fluxClient.DryRunApplyKustomization(ctx, cluster, dir); err != nil {
+		return fmt.Errorf("verifying eks-a manifests before flux bootstrap: %v", err)
+	}
 	return nil
 }
 
 func (fc *fluxForCluster) syncGitRepo(ctx context.Context) error {
+	if usesOCIRepository(fc.clusterSpec) {
+		return errOCIRepositoryNotSupported
+	}
+
+	if usesBucket(fc.clusterSpec) {
+		return errBucketNotSupported
+	}
+
+	fc.setupSparseCheckout()
 	if !validations.FileExists(path.Join(fc.writer.Dir(), ".git")) {
 		if err := fc.clone(ctx); err != nil {
 			return fmt.Errorf("cloning git repo: %v", err)
 		}
+	} else if fc.Flux.repositoryCache {
+		// Discard whatever a previous, possibly failed, operation left in the cached repository
+		if err := fc.gitClient.FetchAndReset(ctx, fc.branch()); err != nil {
+			return fmt.Errorf("updating cached git repo to branch %s: %v", fc.branch(), err)
+		}
 	} else {
+		if err := fc.handleLocalChanges(ctx); err != nil {
+			return err
+		}
+
 		// Make sure the local git repo is on the branch specified in config and up-to-date with the remote
 		if err := fc.gitClient.Branch(fc.branch()); err != nil {
 			return fmt.Errorf("switching to git branch %s: %v", fc.branch(), err)
@@ -118,6 +250,7 @@ func (fc *fluxForCluster) initializeProviderRepositoryIfNotExists(ctx context.Co
 // if the repository exists but is empty, it will be initialized locally, as a bare repository cannot be cloned.
 // if the repository does not exist, it will be created and then initialized locally.
 func (fc *fluxForCluster) setupRepository(ctx context.Context) (err error) {
+	fc.setupSparseCheckout()
 	r, err := fc.initializeProviderRepositoryIfNotExists(ctx)
 	if err != nil {
 		return err
@@ -151,7 +284,7 @@ func (fc *fluxForCluster) clone(ctx context.Context) error {
 
 // createRemoteRepository will create a repository in the remote git provider with the user-provided configuration.
 func (fc *fluxForCluster) createRemoteRepository(ctx context.Context) error {
-	logger.V(3).Info("Remote Github repo does not exist; will create and initialize", "repo", fc.repository(), "owner", fc.owner())
+	logger.V(3).Info("Remote repo does not exist; will create and initialize", "repo", fc.repository(), "owner", fc.owner())
 
 	opts := git.CreateRepoOpts{
 		Name:        fc.repository(),
@@ -161,7 +294,7 @@ func (fc *fluxForCluster) createRemoteRepository(ctx context.Context) error {
 		Privacy:     true,
 	}
 
-	logger.V(4).Info("Creating remote Github repo", "options", opts)
+	logger.V(4).Info("Creating remote repo", "options", opts)
 	if err := fc.gitClient.CreateRepo(ctx, opts); err != nil {
 		return fmt.Errorf("creating repo: %v", err)
 	}
@@ -204,6 +337,15 @@ func (fc *fluxForCluster) validateRemoteConfigPathDoesNotExist(ctx context.Conte
 		return nil
 	}
 
+	c, err := fc.readInstallCheckpoint()
+	if err != nil {
+		return err
+	}
+	if c.completed(manifestsCommittedPhase) {
+		logger.V(3).Info("Flux path committed by a previous run of the installation, it will be resumed", "path", fc.path())
+		return nil
+	}
+
 	exists, err := fc.gitClient.PathExists(ctx, fc.owner(), fc.repository(), fc.branch(), fc.path())
 	if err != nil {
 		return fmt.Errorf("failed validating remote flux config path: %v", err)
@@ -216,6 +358,44 @@ func (fc *fluxForCluster) validateRemoteConfigPathDoesNotExist(ctx context.Conte
 	return nil
 }
 
+// validateRepositoryWriteAccess checks the credentials can push to the repository and the branch either exists in
+// the remote or can be created by the first push, so the failures surface before the cluster is created instead
+// of when pushing the cluster config. A provider repository that doesn't exist yet is created with the branch.
+func (fc *fluxForCluster) validateRepositoryWriteAccess(ctx context.Context) error {
+	if fc.gitClient == nil {
+		return nil
+	}
+
+	if fc.clusterSpec.FluxConfig.Spec.Git == nil {
+		repo, err := fc.gitClient.GetRepo(ctx)
+		if err != nil {
+			return fmt.Errorf("describing repo: %v", err)
+		}
+		if repo == nil {
+			logger.V(3).Info("Git repository doesn't exist, it will be created with the branch", "repository", fc.repository(), "branch", fc.branch())
+			return nil
+		}
+	}
+
+	if err := fc.gitClient.ValidatePushAccess(ctx); err != nil {
+		return err
+	}
+
+	exists, err := fc.gitClient.RemoteBranchExists(ctx, fc.branch())
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	if err := v1alpha1.ValidateGitBranchName(fc.branch()); err != nil {
+		return fmt.Errorf("branch %s doesn't exist in the remote repository and can't be created: %v", fc.branch(), err)
+	}
+	logger.V(3).Info("Git branch doesn't exist in the remote repository, it will be created", "branch", fc.branch())
+	return nil
+}
+
 func (fc *fluxForCluster) namespace() string {
 	return fc.clusterSpec.FluxConfig.Spec.SystemNamespace
 }
@@ -224,6 +404,21 @@ func (fc *fluxForCluster) repository() string {
 	if fc.clusterSpec.FluxConfig.Spec.Github != nil {
 		return fc.clusterSpec.FluxConfig.Spec.Github.Repository
 	}
+	if fc.clusterSpec.FluxConfig.Spec.Gitlab != nil {
+		return fc.clusterSpec.FluxConfig.Spec.Gitlab.Repository
+	}
+	if fc.clusterSpec.FluxConfig.Spec.BitbucketServer != nil {
+		return fc.clusterSpec.FluxConfig.Spec.BitbucketServer.Repository
+	}
+	if fc.clusterSpec.FluxConfig.Spec.AzureDevOps != nil {
+		return fc.clusterSpec.FluxConfig.Spec.AzureDevOps.Repository
+	}
+	if fc.clusterSpec.FluxConfig.Spec.Gitea != nil {
+		return fc.clusterSpec.FluxConfig.Spec.Gitea.Repository
+	}
+	if fc.clusterSpec.FluxConfig.Spec.CodeCommit != nil {
+		return fc.clusterSpec.FluxConfig.Spec.CodeCommit.Repository
+	}
 	if fc.clusterSpec.FluxConfig.Spec.Git != nil {
 		r := fc.clusterSpec.FluxConfig.Spec.Git.RepositoryUrl
 		return path.Base(strings.TrimSuffix(r, filepath.Ext(r)))
@@ -235,28 +430,76 @@ func (fc *fluxForCluster) owner() string {
 	if fc.clusterSpec.FluxConfig.Spec.Github != nil {
 		return fc.clusterSpec.FluxConfig.Spec.Github.Owner
 	}
+	if fc.clusterSpec.FluxConfig.Spec.Gitlab != nil {
+		return fc.clusterSpec.FluxConfig.Spec.Gitlab.Owner
+	}
+	if fc.clusterSpec.FluxConfig.Spec.BitbucketServer != nil {
+		return fc.clusterSpec.FluxConfig.Spec.BitbucketServer.Owner
+	}
+	if fc.clusterSpec.FluxConfig.Spec.AzureDevOps != nil {
+		return fc.clusterSpec.FluxConfig.Spec.AzureDevOps.Organization
+	}
+	if fc.clusterSpec.FluxConfig.Spec.Gitea != nil {
+		return fc.clusterSpec.FluxConfig.Spec.Gitea.Owner
+	}
 	return ""
 }
 
 func (fc *fluxForCluster) branch() string {
-	return fc.clusterSpec.FluxConfig.Spec.Branch
+	return fc.syncBranch
 }
 
 func (fc *fluxForCluster) personal() bool {
 	if fc.clusterSpec.FluxConfig.Spec.Github != nil {
 		return fc.clusterSpec.FluxConfig.Spec.Github.Personal
 	}
+	if fc.clusterSpec.FluxConfig.Spec.Gitlab != nil {
+		return fc.clusterSpec.FluxConfig.Spec.Gitlab.Personal
+	}
+	if fc.clusterSpec.FluxConfig.Spec.BitbucketServer != nil {
+		return fc.clusterSpec.FluxConfig.Spec.BitbucketServer.Personal
+	}
+	if fc.clusterSpec.FluxConfig.Spec.Gitea != nil {
+		return fc.clusterSpec.FluxConfig.Spec.Gitea.Personal
+	}
 	return false
 }
 
 func (fc *fluxForCluster) path() string {
-	return fc.clusterSpec.FluxConfig.Spec.ClusterConfigPath
+	return fc.configPath
 }
 
 func (fc *fluxForCluster) eksaSystemDir() string {
-	return path.Join(fc.path(), fc.clusterSpec.Cluster.GetName(), eksaSystemDirName)
+	return path.Join(fc.path(), fc.layout.eksaSystemDir)
 }
 
 func (fc *fluxForCluster) fluxSystemDir() string {
-	return path.Join(fc.path(), fc.namespace())
+	return path.Join(fc.path(), fc.layout.fluxSystemDir)
+}
+
+// tenantDir is the directory of the tenant files of a workload cluster. Since it has a kustomization, the flux-system
+// Kustomization only reconciles the tenant files in it and not the eksa-system directory below, which is reconciled
+// by the tenant Kustomization instead.
+func (fc *fluxForCluster) tenantDir() string {
+	return path.Dir(fc.eksaSystemDir())
+}
+
+// usesClusterTenants returns true if the cluster is a workload cluster reconciled by its own tenant Kustomization.
+func (fc *fluxForCluster) usesClusterTenants() bool {
+	m := fc.clusterSpec.FluxConfig.Spec.MultiTenancy
+	return m != nil && m.ClusterTenants && fc.clusterSpec.Cluster.IsManaged()
+}
+
+// writeTenantFiles writes the tenant files of the workload cluster. They're only written when the cluster config is
+// first committed, since moving the eksa-system directory of an existing cluster from the flux-system Kustomization
+// to a tenant Kustomization would make the flux-system Kustomization prune the cluster objects.
+func (fc *fluxForCluster) writeTenantFiles(g *FileGenerator) error {
+	if err := g.InitTenant(fc.writer, fc.tenantDir()); err != nil {
+		return err
+	}
+
+	if err := g.WriteTenantFiles(fc.clusterSpec, fc.eksaSystemDir()); err != nil {
+		return fmt.Errorf("writing tenant files: %v", err)
+	}
+	return nil
 }
@@ -0,0 +1,146 @@
+package flux
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/aws/eks-anywhere/pkg/version"
+)
+
+// Operations of the cluster config commits, available to the commit message template.
+const (
+	createOperation  = "create"
+	upgradeOperation = "upgrade"
+	deleteOperation  = "delete"
+	// reverseSyncOperation commits the cluster config changed in the cluster.
+	reverseSyncOperation = "reverse-sync"
+	// revertOperation removes the cluster config of a cluster whose flux bootstrap failed.
+	revertOperation = "revert"
+)
+
+// clusterconfigCommitSummaries are the default messages of the cluster config commits of each operation.
+var clusterconfigCommitSummaries = map[string]string{
+	createOperation:  "Initial commit of cluster configuration; generated by EKS-A CLI",
+	upgradeOperation: "Update commit of cluster configuration; generated by EKS-A CLI",
+	deleteOperation:  "Delete commit of cluster configuration; generated by EKS-A CLI",
+
+	reverseSyncOperation: "Reverse sync commit of cluster configuration changed in the cluster; generated by EKS-A controller",
+	revertOperation:      "Revert commit of cluster configuration after failed flux bootstrap; generated by EKS-A CLI",
+}
+
+// commitMessageData is the data the commit message template is executed with.
+type commitMessageData struct {
+	// Cluster is the name of the cluster.
+	Cluster string
+	// Operation is create, upgrade, delete, reverse-sync or revert.
+	Operation string
+	// Version is the version of the EKS-A CLI.
+	Version string
+	// Summary is the default commit message of the operation.
+	Summary string
+}
+
+// WithCommitMessageTemplate configures the messages of the commits creating, updating and deleting the cluster config
+// with a text/template, executed with the .Cluster name, the .Operation, the EKS-A .Version and the default .Summary
+// message of the operation. The trailers, like "Ticket: ABC-123", are appended to the messages after a blank line.
+// An empty template keeps the default message.
+func WithCommitMessageTemplate(tmpl string, trailers ...string) Opt {
+	return func(f *Flux) {
+		f.commitMessageTemplate = tmpl
+		f.commitTrailers = trailers
+	}
+}
+
+// ValidateCommitMessageTemplate returns an error if the commit message template is malformed or references unknown
+// fields, or if any of the trailers isn't a "key: value" pair.
+func ValidateCommitMessageTemplate(tmpl string, trailers []string) error {
+	sample := commitMessageData{
+		Cluster:   "cluster",
+		Operation: createOperation,
+		Version:   "v0.0.0",
+		Summary:   clusterconfigCommitSummaries[createOperation],
+	}
+	if _, err := renderCommitMessage(tmpl, sample); err != nil {
+		return err
+	}
+
+	for _, t := range trailers {
+		key, value, found := strings.Cut(t, ":")
+		if !found || strings.TrimSpace(key) == "" || strings.ContainsAny(strings.TrimSpace(key), " \t") || strings.TrimSpace(value) == "" {
+			return fmt.Errorf("invalid commit trailer %q: trailers must be \"key: value\" pairs", t)
+		}
+	}
+	return nil
+}
+
+// clusterconfigCommitMessage returns the message of the cluster config commit of the operation, with the summary of
+// the changes, if any, in its body before the trailers.
+func (fc *fluxForCluster) clusterconfigCommitMessage(operation, changes string) (string, error) {
+	summary := clusterconfigCommitSummaries[operation]
+	if fc.commitMessageTemplate == "" && len(fc.commitTrailers) == 0 {
+		return appendParagraph(summary, changes), nil
+	}
+
+	if err := ValidateCommitMessageTemplate(fc.commitMessageTemplate, fc.commitTrailers); err != nil {
+		return "", err
+	}
+
+	msg, err := renderCommitMessage(fc.commitMessageTemplate, commitMessageData{
+		Cluster:   fc.clusterSpec.Cluster.Name,
+		Operation: operation,
+		Version:   version.Get().GitVersion,
+		Summary:   summary,
+	})
+	if err != nil {
+		return "", err
+	}
+	msg = appendParagraph(msg, changes)
+
+	if len(fc.commitTrailers) == 0 {
+		return msg, nil
+	}
+
+	trailers := make([]string, 0, len(fc.commitTrailers))
+	for _, t := range fc.commitTrailers {
+		key, value, _ := strings.Cut(t, ":")
+		trailers = append(trailers, strings.TrimSpace(key)+": "+strings.TrimSpace(value))
+	}
+	return appendParagraph(msg, strings.Join(trailers, "\n")), nil
+}
+
+// appendParagraph appends the paragraph to the commit message after a blank line, unless it's empty.
+func appendParagraph(msg, paragraph string) string {
+	if paragraph == "" {
+		return msg
+	}
+	return msg + "\n\n" + paragraph
+}
+
+// commitSubject returns the first line of the commit message.
+func commitSubject(msg string) string {
+	return strings.SplitN(msg, "\n", 2)[0]
+}
+
+func renderCommitMessage(tmpl string, data commitMessageData) (string, error) {
+	if tmpl == "" {
+		return data.Summary, nil
+	}
+
+	t, err := template.New("commit-message").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("parsing commit message template: %v", err)
+	}
+
+	var b bytes.Buffer
+	if err := t.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("executing commit message template: %v", err)
+	}
+
+	msg := strings.TrimSpace(b.String())
+	if msg == "" {
+		return "", fmt.Errorf("commit message template %q renders an empty message", tmpl)
+	}
+	return msg, nil
+}
@@ -0,0 +1,122 @@
+package flux_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	"github.com/aws/eks-anywhere/pkg/providers"
+)
+
+func TestUpdateGitEksaSpecCommitMessageTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		trailers []string
+		wantMsg  string
+	}{
+		{
+			name:     "template",
+			template: "eksa: {{.Operation}} {{.Cluster}}",
+			wantMsg:  "eksa: upgrade management-cluster",
+		},
+		{
+			name:     "summary",
+			template: "[{{.Cluster}}] {{.Summary}}",
+			wantMsg:  "[management-cluster] " + updateCommitMessage,
+		},
+		{
+			name:     "trailers with default message",
+			trailers: []string{"Ticket: ABC-123", "Reviewed-by:jane"},
+			wantMsg:  updateCommitMessage + "\n\nTicket: ABC-123\nReviewed-by: jane",
+		},
+		{
+			name:     "template and trailers",
+			template: "Upgrade {{.Cluster}}",
+			trailers: []string{"Ticket: ABC-123"},
+			wantMsg:  "Upgrade management-cluster\n\nTicket: ABC-123",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusterName := "management-cluster"
+			g := newFluxTest(t)
+			clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+			f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithCommitMessageTemplate(tt.template, tt.trailers...))
+
+			g.git.EXPECT().Clone(g.ctx).Return(nil)
+			g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+			g.git.EXPECT().Add("clusters/management-cluster/management-cluster/eksa-system").Return(nil)
+			g.git.EXPECT().Commit(tt.wantMsg).Return(nil)
+			g.git.EXPECT().Push(g.ctx).Return(nil)
+
+			g.Expect(f.UpdateGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+		})
+	}
+}
+
+func TestUpdateGitEksaSpecCommitMessageTemplateInvalid(t *testing.T) {
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithCommitMessageTemplate("{{.Ticket}}"))
+
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+
+	err := f.UpdateGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})
+	g.Expect(err).To(MatchError(ContainSubstring("executing commit message template")))
+}
+
+func TestValidateCommitMessageTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		trailers []string
+		wantErr  string
+	}{
+		{
+			name:     "valid",
+			template: "{{.Operation}} {{.Cluster}} with {{.Version}}",
+			trailers: []string{"Ticket: ABC-123"},
+		},
+		{
+			name:     "malformed template",
+			template: "{{.Cluster",
+			wantErr:  "parsing commit message template",
+		},
+		{
+			name:     "unknown field",
+			template: "{{.Ticket}}",
+			wantErr:  "executing commit message template",
+		},
+		{
+			name:     "empty message",
+			template: "{{if false}}x{{end}}",
+			wantErr:  "renders an empty message",
+		},
+		{
+			name:     "trailer without value",
+			trailers: []string{"Ticket"},
+			wantErr:  `invalid commit trailer "Ticket"`,
+		},
+		{
+			name:     "trailer key with spaces",
+			trailers: []string{"Ticket id: ABC-123"},
+			wantErr:  `invalid commit trailer "Ticket id: ABC-123"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := flux.ValidateCommitMessageTemplate(tt.template, tt.trailers)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package flux
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	// DefaultMaxFileSize and DefaultMaxCommitSize are the size limits in bytes of a file and of all the files of a
+	// commit when WithCommitSizeLimits isn't set.
+	DefaultMaxFileSize   int64 = 10 * 1024 * 1024
+	DefaultMaxCommitSize int64 = 50 * 1024 * 1024
+)
+
+// WithCommitSizeLimits configures the max size in bytes allowed for a single file and for all the files
+// generated by EKS-A in a commit. A limit lower or equal to zero disables that check.
+func WithCommitSizeLimits(maxFileSize, maxCommitSize int64) Opt {
+	return func(f *Flux) {
+		f.maxFileSize = maxFileSize
+		f.maxCommitSize = maxCommitSize
+	}
+}
+
+// validateCommitSize inspects the files staged in the repository before they are committed and returns an error if
+// any file or the sum of all of them exceeds the configured limits. The files are read from the worktree, which is
+// what was staged since they're added right before. This protects the repository from being bloated by an
+// accidentally huge generated manifest.
+func (fc *fluxForCluster) validateCommitSize() error {
+	if fc.maxFileSize <= 0 && fc.maxCommitSize <= 0 {
+		return nil
+	}
+
+	files, err := fc.gitClient.StagedFiles()
+	if err != nil {
+		return fmt.Errorf("validating size of files to commit: %v", err)
+	}
+
+	var total int64
+	for _, file := range files {
+		info, err := os.Stat(path.Join(fc.writer.Dir(), file))
+		if err != nil {
+			return fmt.Errorf("validating size of files to commit: %v", err)
+		}
+
+		if fc.maxFileSize > 0 && info.Size() > fc.maxFileSize {
+			return fmt.Errorf("validating size of files to commit: file %s is %d bytes, which exceeds the max file size of %d bytes", file, info.Size(), fc.maxFileSize)
+		}
+		total += info.Size()
+	}
+
+	if fc.maxCommitSize > 0 && total > fc.maxCommitSize {
+		return fmt.Errorf("validating size of files to commit: files are %d bytes in total, which exceeds the max commit size of %d bytes", total, fc.maxCommitSize)
+	}
+
+	logger.V(4).Info("Validated size of files to commit", "files", len(files), "totalBytes", total)
+	return nil
+}
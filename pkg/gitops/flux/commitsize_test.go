@@ -0,0 +1,119 @@
+package flux_test
+
+import (
+	"errors"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	fluxMocks "github.com/aws/eks-anywhere/pkg/gitops/flux/mocks"
+	"github.com/aws/eks-anywhere/pkg/providers"
+)
+
+func fileSize(t *testing.T, file string) int64 {
+	t.Helper()
+	info, err := os.Stat(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return info.Size()
+}
+
+func TestUpdateGitEksaSpecCommitSizeLimits(t *testing.T) {
+	clusterName := "management-cluster"
+	eksaSystemDirPath := "clusters/management-cluster/management-cluster/eksa-system"
+	configFile := path.Join(eksaSystemDirPath, "eksa-cluster.yaml")
+	kustomizationFile := path.Join(eksaSystemDirPath, "kustomization.yaml")
+	configSize := fileSize(t, "./testdata/cluster-config-default-path-management.yaml")
+	kustomizationSize := fileSize(t, "./testdata/kustomization.yaml")
+
+	tests := []struct {
+		testName      string
+		maxFileSize   int64
+		maxCommitSize int64
+		staged        []string
+		stagedErr     error
+		wantErr       string
+	}{
+		{
+			testName:      "file at max file size",
+			maxFileSize:   configSize,
+			maxCommitSize: configSize + kustomizationSize,
+			staged:        []string{configFile, kustomizationFile},
+		},
+		{
+			testName:      "file over max file size",
+			maxFileSize:   configSize - 1,
+			maxCommitSize: configSize + kustomizationSize,
+			staged:        []string{configFile, kustomizationFile},
+			wantErr:       "exceeds the max file size",
+		},
+		{
+			testName:      "total over max commit size",
+			maxFileSize:   configSize,
+			maxCommitSize: configSize + kustomizationSize - 1,
+			staged:        []string{configFile, kustomizationFile},
+			wantErr:       "exceeds the max commit size",
+		},
+		{
+			testName:      "only staged files counted",
+			maxFileSize:   configSize,
+			maxCommitSize: configSize,
+			staged:        []string{configFile},
+		},
+		{
+			testName:      "staged files error",
+			maxFileSize:   configSize,
+			maxCommitSize: configSize + kustomizationSize,
+			stagedErr:     errors.New("error in status"),
+			wantErr:       "validating size of files to commit: error in status",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := newFluxTest(t)
+			gitClient := fluxMocks.NewMockGitClient(gomock.NewController(t))
+			clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+			f := flux.NewFluxFromGitOpsFluxClient(g.flux, gitClient, g.writer, nil, flux.WithCommitSizeLimits(tt.maxFileSize, tt.maxCommitSize))
+
+			gitClient.EXPECT().Clone(g.ctx).Return(nil)
+			gitClient.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+			gitClient.EXPECT().Add(eksaSystemDirPath).Return(nil)
+			gitClient.EXPECT().StagedFiles().Return(tt.staged, tt.stagedErr)
+			if tt.wantErr == "" {
+				gitClient.EXPECT().Commit(test.OfType("string")).Return(nil)
+				gitClient.EXPECT().Push(g.ctx).Return(nil)
+			}
+
+			err := f.UpdateGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestUpdateGitEksaSpecCommitSizeLimitsDisabled(t *testing.T) {
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	gitClient := fluxMocks.NewMockGitClient(gomock.NewController(t))
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, gitClient, g.writer, nil, flux.WithCommitSizeLimits(0, 0))
+
+	gitClient.EXPECT().Clone(g.ctx).Return(nil)
+	gitClient.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	gitClient.EXPECT().Add("clusters/management-cluster/management-cluster/eksa-system").Return(nil)
+	gitClient.EXPECT().Commit(test.OfType("string")).Return(nil)
+	gitClient.EXPECT().Push(g.ctx).Return(nil)
+
+	g.Expect(f.UpdateGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+}
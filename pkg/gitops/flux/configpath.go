@@ -0,0 +1,123 @@
+package flux
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+const templateNoValue = "<no value>"
+
+// clusterConfigPathValues is the cluster metadata available to a templated cluster config path.
+type clusterConfigPathValues struct {
+	Name      string
+	Namespace string
+	Labels    map[string]string
+}
+
+// resolveClusterConfigPath returns the cluster config path of the FluxConfig. When the path is a template, it's
+// executed with the name, namespace and labels of the cluster and the resulting path is validated.
+// A path without template actions is returned verbatim.
+func resolveClusterConfigPath(clusterSpec *cluster.Spec) (string, error) {
+	configPath := clusterSpec.FluxConfig.Spec.ClusterConfigPath
+	if !strings.Contains(configPath, "{{") {
+		return configPath, nil
+	}
+
+	values := clusterConfigPathValues{
+		Name:      clusterSpec.Cluster.Name,
+		Namespace: clusterSpec.Cluster.Namespace,
+		Labels:    clusterSpec.Cluster.Labels,
+	}
+	resolved, err := templater.Execute(configPath, values)
+	if err != nil {
+		return "", fmt.Errorf("resolving cluster config path template %s: %v", configPath, err)
+	}
+
+	p := string(resolved)
+	if err := validateResolvedClusterConfigPath(p); err != nil {
+		return "", fmt.Errorf("resolving cluster config path template %s: %v", configPath, err)
+	}
+
+	return p, nil
+}
+
+func validateResolvedClusterConfigPath(p string) error {
+	if strings.Contains(p, templateNoValue) {
+		return fmt.Errorf("resolved path %s references cluster metadata that is not set", p)
+	}
+	if p == "" {
+		return fmt.Errorf("resolved path can't be empty")
+	}
+	if path.IsAbs(p) {
+		return fmt.Errorf("resolved path %s must be relative to the repository root", p)
+	}
+	for _, segment := range strings.Split(p, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("resolved path %s can't contain empty, '.' or '..' segments", p)
+		}
+	}
+	return nil
+}
+
+// resolveBranch returns the branch of the FluxConfig. When the branch is a template, like eksa/{{.Name}}, it's executed
+// with the metadata of the cluster running flux, so each management cluster syncs from its own branch. Workload clusters
+// are reconciled by the flux of their management cluster, so their branch is resolved with the name of the management
+// cluster and without labels. A branch without template actions is returned verbatim.
+func resolveBranch(clusterSpec *cluster.Spec) (string, error) {
+	branch := clusterSpec.FluxConfig.Spec.Branch
+	if !strings.Contains(branch, "{{") {
+		return branch, nil
+	}
+
+	values := clusterConfigPathValues{
+		Name:      clusterSpec.Cluster.Name,
+		Namespace: clusterSpec.Cluster.Namespace,
+		Labels:    clusterSpec.Cluster.Labels,
+	}
+	if clusterSpec.Cluster.IsManaged() {
+		values.Name = clusterSpec.Cluster.ManagedBy()
+		values.Labels = nil
+	}
+
+	resolved, err := templater.Execute(branch, values)
+	if err != nil {
+		return "", fmt.Errorf("resolving branch template %s: %v", branch, err)
+	}
+
+	b := string(resolved)
+	if strings.Contains(b, templateNoValue) {
+		return "", fmt.Errorf("resolving branch template %s: resolved branch %s references cluster metadata that is not set", branch, b)
+	}
+	if err := v1alpha1.ValidateGitBranchName(b); err != nil {
+		return "", fmt.Errorf("resolving branch template %s: %v", branch, err)
+	}
+
+	return b, nil
+}
+
+// fluxConfigForBootstrap returns the FluxConfig to bootstrap flux with, with its branch resolved and the path flux
+// syncs from as cluster config path. The FluxConfig in the cluster spec is never modified, so the templates are what gets committed to the
+// repository.
+func fluxConfigForBootstrap(clusterSpec *cluster.Spec) (*v1alpha1.FluxConfig, error) {
+	syncPath, err := resolveSyncPath(clusterSpec)
+	if err != nil {
+		return nil, err
+	}
+	branch, err := resolveBranch(clusterSpec)
+	if err != nil {
+		return nil, err
+	}
+	if syncPath == clusterSpec.FluxConfig.Spec.ClusterConfigPath && branch == clusterSpec.FluxConfig.Spec.Branch {
+		return clusterSpec.FluxConfig, nil
+	}
+
+	fluxConfig := clusterSpec.FluxConfig.DeepCopy()
+	fluxConfig.Spec.ClusterConfigPath = syncPath
+	fluxConfig.Spec.Branch = branch
+	return fluxConfig, nil
+}
@@ -0,0 +1,174 @@
+package flux_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+func templatedPathClusterSpec(t *testing.T, configPath string) *cluster.Spec {
+	t.Helper()
+	clusterConfig := v1alpha1.NewCluster("management-cluster")
+	clusterConfig.Namespace = "default"
+	clusterConfig.Labels = map[string]string{"env": "prod", "region": "us-west-2"}
+	clusterSpec := newClusterSpec(t, clusterConfig, configPath)
+	return clusterSpec
+}
+
+func TestUpdateGitEksaSpecTemplatedClusterConfigPath(t *testing.T) {
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := templatedPathClusterSpec(t, "{{.Labels.env}}/{{.Labels.region}}/{{.Name}}")
+
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add("prod/us-west-2/management-cluster/management-cluster/eksa-system").Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+
+	g.Expect(g.gitOpsFlux.UpdateGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+	g.Expect(clusterSpec.FluxConfig.Spec.ClusterConfigPath).To(Equal("{{.Labels.env}}/{{.Labels.region}}/{{.Name}}"))
+}
+
+func TestUpdateGitEksaSpecTemplatedClusterConfigPathErrors(t *testing.T) {
+	tests := []struct {
+		testName   string
+		configPath string
+		wantErr    string
+	}{
+		{
+			testName:   "missing label",
+			configPath: "{{.Labels.team}}/{{.Name}}",
+			wantErr:    "references cluster metadata that is not set",
+		},
+		{
+			testName:   "parent directory",
+			configPath: "../{{.Name}}",
+			wantErr:    "can't contain empty, '.' or '..' segments",
+		},
+		{
+			testName:   "absolute path",
+			configPath: "/clusters/{{.Name}}",
+			wantErr:    "must be relative to the repository root",
+		},
+		{
+			testName:   "invalid template",
+			configPath: "clusters/{{.Name",
+			wantErr:    "parsing template",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			clusterName := "management-cluster"
+			g := newFluxTest(t)
+			clusterSpec := templatedPathClusterSpec(t, tt.configPath)
+
+			err := g.gitOpsFlux.UpdateGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})
+			g.Expect(err).To(MatchError(ContainSubstring("resolving cluster config path template")))
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
+
+func TestBootstrapGithubTemplatedClusterConfigPath(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{}
+	clusterSpec := templatedPathClusterSpec(t, "clusters/{{.Labels.env}}")
+
+	wantConfig := clusterSpec.FluxConfig.DeepCopy()
+	wantConfig.Spec.ClusterConfigPath = "clusters/prod"
+	g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, wantConfig).Return(nil)
+
+	g.Expect(g.gitOpsFlux.BootstrapGithub(g.ctx, cluster, clusterSpec)).To(Succeed())
+}
+
+func TestUpdateGitEksaSpecTemplatedBranch(t *testing.T) {
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := templatedPathClusterSpec(t, "")
+	clusterSpec.FluxConfig.Spec.Branch = "clusters/{{.Labels.env}}-{{.Name}}"
+
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch("clusters/prod-management-cluster").Return(nil)
+	g.git.EXPECT().Add("clusters/management-cluster/management-cluster/eksa-system").Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+
+	g.Expect(g.gitOpsFlux.UpdateGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+	g.Expect(clusterSpec.FluxConfig.Spec.Branch).To(Equal("clusters/{{.Labels.env}}-{{.Name}}"))
+}
+
+func TestUpdateGitEksaSpecTemplatedBranchWorkloadCluster(t *testing.T) {
+	clusterName := "workload-cluster"
+	g := newFluxTest(t)
+	clusterConfig := v1alpha1.NewCluster(clusterName)
+	clusterConfig.SetManagedBy("management-cluster")
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+	clusterSpec.FluxConfig.Spec.Branch = "clusters/{{.Name}}"
+
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch("clusters/management-cluster").Return(nil)
+	g.git.EXPECT().Add("clusters/management-cluster/workload-cluster/eksa-system").Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+
+	g.Expect(g.gitOpsFlux.UpdateGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+}
+
+func TestUpdateGitEksaSpecTemplatedBranchErrors(t *testing.T) {
+	tests := []struct {
+		testName string
+		branch   string
+		wantErr  string
+	}{
+		{
+			testName: "missing label",
+			branch:   "clusters/{{.Labels.team}}",
+			wantErr:  "references cluster metadata that is not set",
+		},
+		{
+			testName: "invalid resolved branch",
+			branch:   "clusters//{{.Name}}",
+			wantErr:  "is not a valid git branch name",
+		},
+		{
+			testName: "invalid template",
+			branch:   "clusters/{{.Name",
+			wantErr:  "parsing template",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			clusterName := "management-cluster"
+			g := newFluxTest(t)
+			clusterSpec := templatedPathClusterSpec(t, "")
+			clusterSpec.FluxConfig.Spec.Branch = tt.branch
+
+			err := g.gitOpsFlux.UpdateGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})
+			g.Expect(err).To(MatchError(ContainSubstring("resolving branch template")))
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
+
+func TestBootstrapGithubTemplatedBranch(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{}
+	clusterSpec := templatedPathClusterSpec(t, "clusters/{{.Labels.env}}")
+	clusterSpec.FluxConfig.Spec.Branch = "eksa/{{.Name}}"
+
+	wantConfig := clusterSpec.FluxConfig.DeepCopy()
+	wantConfig.Spec.ClusterConfigPath = "clusters/prod"
+	wantConfig.Spec.Branch = "eksa/management-cluster"
+	g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, wantConfig).Return(nil)
+
+	g.Expect(g.gitOpsFlux.BootstrapGithub(g.ctx, cluster, clusterSpec)).To(Succeed())
+}
@@ -0,0 +1,48 @@
+package flux
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// credentialsTokenKey is the key of the access token in the credentials secret.
+const credentialsTokenKey = "token"
+
+// SecretClient reads secrets from a cluster.
+type SecretClient interface {
+	GetSecretFromNamespace(ctx context.Context, kubeconfigFile, name, namespace string) (*corev1.Secret, error)
+}
+
+// ReadGitCredentials reads the git provider credentials from the credentialsSecretRef secret of the FluxConfig,
+// in its system namespace of the management cluster. It returns nil credentials when no secret is referenced.
+func ReadGitCredentials(ctx context.Context, client SecretClient, managementCluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) (*git.Credentials, error) {
+	name := fluxConfig.Spec.CredentialsSecretRef
+	if name == "" {
+		return nil, nil
+	}
+
+	namespace := fluxConfig.Spec.SystemNamespace
+	if namespace == "" {
+		namespace = v1alpha1.FluxDefaultNamespace
+	}
+
+	logger.V(3).Info("Reading git credentials from secret", "secret", name, "namespace", namespace)
+	secret, err := client.GetSecretFromNamespace(ctx, managementCluster.KubeconfigFile, name, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("reading git credentials secret %s: %v", name, err)
+	}
+
+	token := string(secret.Data[credentialsTokenKey])
+	if token == "" {
+		return nil, fmt.Errorf("reading git credentials secret %s: key %s is not set or empty", name, credentialsTokenKey)
+	}
+
+	return &git.Credentials{Token: token}, nil
+}
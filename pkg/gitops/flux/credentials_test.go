@@ -0,0 +1,90 @@
+package flux_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	fluxMocks "github.com/aws/eks-anywhere/pkg/gitops/flux/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+func TestReadGitCredentials(t *testing.T) {
+	managementCluster := &types.Cluster{Name: "management-cluster", KubeconfigFile: "management-cluster.kubeconfig"}
+
+	tests := []struct {
+		testName  string
+		secretRef string
+		namespace string
+		secret    *corev1.Secret
+		secretErr error
+		want      *git.Credentials
+		wantErr   string
+	}{
+		{
+			testName: "no secret ref",
+		},
+		{
+			testName:  "token in secret",
+			secretRef: "github-credentials",
+			namespace: "custom-flux",
+			secret:    &corev1.Secret{Data: map[string][]byte{"token": []byte("ghp_token")}},
+			want:      &git.Credentials{Token: "ghp_token"},
+		},
+		{
+			testName:  "default namespace",
+			secretRef: "github-credentials",
+			secret:    &corev1.Secret{Data: map[string][]byte{"token": []byte("ghp_token")}},
+			want:      &git.Credentials{Token: "ghp_token"},
+		},
+		{
+			testName:  "no token in secret",
+			secretRef: "github-credentials",
+			secret:    &corev1.Secret{Data: map[string][]byte{"password": []byte("ghp_token")}},
+			wantErr:   "reading git credentials secret github-credentials: key token is not set or empty",
+		},
+		{
+			testName:  "secret error",
+			secretRef: "github-credentials",
+			secretErr: errors.New("secret not found"),
+			wantErr:   "reading git credentials secret github-credentials: secret not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			client := fluxMocks.NewMockSecretClient(gomock.NewController(t))
+			fluxConfig := &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					SystemNamespace:      tt.namespace,
+					CredentialsSecretRef: tt.secretRef,
+				},
+			}
+
+			if tt.secretRef != "" {
+				namespace := tt.namespace
+				if namespace == "" {
+					namespace = v1alpha1.FluxDefaultNamespace
+				}
+				client.EXPECT().GetSecretFromNamespace(ctx, managementCluster.KubeconfigFile, tt.secretRef, namespace).Return(tt.secret, tt.secretErr)
+			}
+
+			got, err := flux.ReadGitCredentials(ctx, client, managementCluster, fluxConfig)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(tt.wantErr))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package flux
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const deploymentKind = "Deployment"
+
+// DiffInstalledFlux compares the flux controller deployments running in the cluster with the ones EKS-A installs
+// for the flux bundle in the cluster spec, and returns a human readable diff of their container images.
+// The diff is empty when the installed flux is up to date.
+func (f *Flux) DiffInstalledFlux(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) (string, error) {
+	if f.shouldSkipFlux() {
+		logger.Info("GitOps field not specified, diff installed flux skipped")
+		return "", nil
+	}
+
+	expected, err := expectedFluxDeployments(clusterSpec)
+	if err != nil {
+		return "", err
+	}
+
+	diff := &strings.Builder{}
+	for _, want := range expected {
+		live, err := f.fluxClient.GetDeployment(ctx, cluster, want.Name, want.Namespace)
+		if apierrors.IsNotFound(err) {
+			fmt.Fprintf(diff, "deployment %s/%s:\n- present\n+ not found\n", want.Namespace, want.Name)
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("getting installed flux deployment %s: %v", want.Name, err)
+		}
+
+		writeContainersDiff(diff, want, live)
+	}
+
+	return diff.String(), nil
+}
+
+// expectedFluxDeployments renders the flux-system patch and returns the controller deployments in it.
+func expectedFluxDeployments(clusterSpec *cluster.Spec) ([]*appsv1.Deployment, error) {
+	content, err := templater.Execute(fluxPatchContent, fluxPatchValues(clusterSpec))
+	if err != nil {
+		return nil, fmt.Errorf("rendering expected flux-system patch: %v", err)
+	}
+
+	var deployments []*appsv1.Deployment
+	for _, doc := range strings.Split(string(content), "\n---\n") {
+		d := &appsv1.Deployment{}
+		if err := yaml.Unmarshal([]byte(doc), d); err != nil {
+			return nil, fmt.Errorf("parsing expected flux-system patch: %v", err)
+		}
+		if d.Kind == deploymentKind {
+			deployments = append(deployments, d)
+		}
+	}
+	return deployments, nil
+}
+
+func writeContainersDiff(diff *strings.Builder, want, live *appsv1.Deployment) {
+	liveContainers := make(map[string]string, len(live.Spec.Template.Spec.Containers))
+	for _, c := range live.Spec.Template.Spec.Containers {
+		liveContainers[c.Name] = c.Image
+	}
+
+	for _, c := range want.Spec.Template.Spec.Containers {
+		liveImage, ok := liveContainers[c.Name]
+		switch {
+		case !ok:
+			fmt.Fprintf(diff, "deployment %s/%s container %s:\n- image: %s\n+ not found\n", want.Namespace, want.Name, c.Name, c.Image)
+		case liveImage != c.Image:
+			fmt.Fprintf(diff, "deployment %s/%s container %s:\n- image: %s\n+ image: %s\n", want.Namespace, want.Name, c.Name, c.Image, liveImage)
+		}
+	}
+}
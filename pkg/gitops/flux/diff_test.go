@@ -0,0 +1,107 @@
+package flux_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+func controllerDeployment(image string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "manager", Image: image}},
+				},
+			},
+		},
+	}
+}
+
+func expectInstalledFluxDeployments(g fluxTest, cluster *types.Cluster, images map[string]string) {
+	bundle := fluxBundle()
+	bundleImages := map[string]string{
+		"source-controller":       bundle.SourceController.VersionedImage(),
+		"kustomize-controller":    bundle.KustomizeController.VersionedImage(),
+		"helm-controller":         bundle.HelmController.VersionedImage(),
+		"notification-controller": bundle.NotificationController.VersionedImage(),
+	}
+	for name, image := range bundleImages {
+		if i, ok := images[name]; ok {
+			image = i
+		}
+		g.flux.EXPECT().GetDeployment(g.ctx, cluster, name, "flux-system").Return(controllerDeployment(image), nil)
+	}
+}
+
+func TestDiffInstalledFluxUpToDate(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{KubeconfigFile: "k.kubeconfig"}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	expectInstalledFluxDeployments(g, cluster, nil)
+
+	diff, err := g.gitOpsFlux.DiffInstalledFlux(g.ctx, cluster, clusterSpec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(diff).To(BeEmpty())
+}
+
+func TestDiffInstalledFluxOutdatedImage(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{KubeconfigFile: "k.kubeconfig"}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	oldImage := "public.ecr.aws/l0g8r8j6/fluxcd/source-controller:v0.11.0"
+	expectInstalledFluxDeployments(g, cluster, map[string]string{"source-controller": oldImage})
+
+	diff, err := g.gitOpsFlux.DiffInstalledFlux(g.ctx, cluster, clusterSpec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(diff).To(Equal(
+		"deployment flux-system/source-controller container manager:\n" +
+			"- image: " + fluxBundle().SourceController.VersionedImage() + "\n" +
+			"+ image: " + oldImage + "\n",
+	))
+}
+
+func TestDiffInstalledFluxMissingDeployment(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{KubeconfigFile: "k.kubeconfig"}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	notFound := apierrors.NewNotFound(schema.GroupResource{Group: "apps", Resource: "deployments"}, "helm-controller")
+
+	bundle := fluxBundle()
+	g.flux.EXPECT().GetDeployment(g.ctx, cluster, "source-controller", "flux-system").Return(controllerDeployment(bundle.SourceController.VersionedImage()), nil)
+	g.flux.EXPECT().GetDeployment(g.ctx, cluster, "kustomize-controller", "flux-system").Return(controllerDeployment(bundle.KustomizeController.VersionedImage()), nil)
+	g.flux.EXPECT().GetDeployment(g.ctx, cluster, "helm-controller", "flux-system").Return(nil, notFound)
+	g.flux.EXPECT().GetDeployment(g.ctx, cluster, "notification-controller", "flux-system").Return(controllerDeployment(bundle.NotificationController.VersionedImage()), nil)
+
+	diff, err := g.gitOpsFlux.DiffInstalledFlux(g.ctx, cluster, clusterSpec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(diff).To(Equal("deployment flux-system/helm-controller:\n- present\n+ not found\n"))
+}
+
+func TestDiffInstalledFluxGetDeploymentError(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{KubeconfigFile: "k.kubeconfig"}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	g.flux.EXPECT().GetDeployment(g.ctx, cluster, "source-controller", "flux-system").Return(nil, errors.New("error in get deployment"))
+
+	_, err := g.gitOpsFlux.DiffInstalledFlux(g.ctx, cluster, clusterSpec)
+	g.Expect(err).To(MatchError(ContainSubstring("getting installed flux deployment source-controller: error in get deployment")))
+}
+
+func TestDiffInstalledFluxSkip(t *testing.T) {
+	g := newFluxTest(t)
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, nil, nil)
+
+	diff, err := f.DiffInstalledFlux(g.ctx, &types.Cluster{}, g.clusterSpec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(diff).To(BeEmpty())
+}
@@ -0,0 +1,227 @@
+package flux
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const eksaResourceGroup = "anywhere.eks.amazonaws.com"
+
+// DriftReport lists the EKS-A objects of the committed cluster config whose live spec differs from the one in git.
+type DriftReport struct {
+	// File is the path of the committed cluster config in the repository.
+	File    string
+	Objects []ObjectDrift
+}
+
+// ObjectDrift is the drift of an EKS-A object between git and the cluster.
+type ObjectDrift struct {
+	Kind      string
+	Name      string
+	Namespace string
+	// Missing is true if the object is in git but not in the cluster.
+	Missing bool
+	Fields  []FieldDrift
+}
+
+// FieldDrift is a spec field whose live value differs from the value in git. Values are JSON encoded,
+// and empty if the field is not set.
+type FieldDrift struct {
+	// Path is the path of the field in the object, like spec.controlPlaneConfiguration.count.
+	Path string
+	Git  string
+	Live string
+}
+
+// HasDrift returns true if any object differs between git and the cluster.
+func (r *DriftReport) HasDrift() bool {
+	return len(r.Objects) > 0
+}
+
+// String returns a human readable report of the drifted objects and fields.
+func (r *DriftReport) String() string {
+	b := &strings.Builder{}
+	for _, o := range r.Objects {
+		if o.Missing {
+			fmt.Fprintf(b, "%s/%s: not found in the cluster\n", o.Kind, o.Name)
+			continue
+		}
+		fmt.Fprintf(b, "%s/%s:\n", o.Kind, o.Name)
+		for _, f := range o.Fields {
+			fmt.Fprintf(b, "  %s:\n  - git: %s\n  + live: %s\n", f.Path, f.Git, f.Live)
+		}
+	}
+	return b.String()
+}
+
+// DetectDrift compares the spec of the EKS-A objects in the cluster config committed to git with the live objects in
+// the management cluster, and reports the fields that differ, so changes made to the cluster outside of git are found
+// before flux reverts them. Only the fields set in git are compared, since the live objects also have the fields
+// defaulted by the EKS-A webhooks. Lists with a different length are reported as a whole.
+func (f *Flux) DetectDrift(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) (*DriftReport, error) {
+	if f.shouldSkipFlux() {
+		logger.Info("GitOps field not specified, detect drift skipped")
+		return nil, nil
+	}
+
+	fc, err := newFluxForCluster(f, clusterSpec, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fc.syncGitRepo(ctx); err != nil {
+		return nil, err
+	}
+
+	file, committedObjects, err := fc.readCommittedClusterConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &DriftReport{File: file}
+	for _, committed := range committedObjects {
+		drift, err := fc.objectDrift(ctx, managementCluster, committed)
+		if err != nil {
+			return nil, err
+		}
+		if drift != nil {
+			report.Objects = append(report.Objects, *drift)
+		}
+	}
+
+	logger.V(3).Info("Compared committed cluster config with the live objects", "file", file, "drifted", len(report.Objects))
+	return report, nil
+}
+
+// readCommittedClusterConfig returns the path of the cluster config committed in the local repository and its EKS-A
+// objects, starting with the Cluster.
+func (fc *fluxForCluster) readCommittedClusterConfig() (string, []kubernetes.Object, error) {
+	file := path.Join(fc.eksaSystemDir(), clusterConfigFileName)
+	content, err := os.ReadFile(path.Join(fc.writer.Dir(), file))
+	if err != nil {
+		return "", nil, fmt.Errorf("reading committed cluster config: %v", err)
+	}
+
+	parsed, err := cluster.ParseConfig(content)
+	if err != nil {
+		return "", nil, fmt.Errorf("parsing committed cluster config %s: %v", file, err)
+	}
+
+	return file, append([]kubernetes.Object{parsed.Cluster}, parsed.ChildObjects()...), nil
+}
+
+// objectDrift returns the drift of a committed object with its live version, or nil if their specs match.
+func (fc *fluxForCluster) objectDrift(ctx context.Context, managementCluster *types.Cluster, committed kubernetes.Object) (*ObjectDrift, error) {
+	kind := committed.GetObjectKind().GroupVersionKind().Kind
+	namespace := committed.GetNamespace()
+	if namespace == "" {
+		namespace = constants.DefaultNamespace
+	}
+	drift := &ObjectDrift{Kind: kind, Name: committed.GetName(), Namespace: namespace}
+
+	live := reflect.New(reflect.TypeOf(committed).Elem()).Interface().(kubernetes.Object)
+	err := fc.fluxClient.GetObject(ctx, managementCluster, eksaResourceType(kind), committed.GetName(), namespace, live)
+	if apierrors.IsNotFound(err) {
+		drift.Missing = true
+		return drift, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting live %s %s: %v", kind, committed.GetName(), err)
+	}
+
+	return specDrift(drift, committed, live)
+}
+
+// specDrift returns drift with the spec fields set in the committed object whose live value is different, or nil
+// if they all match.
+func specDrift(drift *ObjectDrift, committed, live kubernetes.Object) (*ObjectDrift, error) {
+	gitSpec, err := unstructuredSpec(committed)
+	if err != nil {
+		return nil, err
+	}
+	liveSpec, err := unstructuredSpec(live)
+	if err != nil {
+		return nil, err
+	}
+
+	drift.Fields = diffFields("spec", gitSpec, liveSpec)
+	if len(drift.Fields) == 0 {
+		return nil, nil
+	}
+	return drift, nil
+}
+
+// eksaResourceType returns the fully qualified resource type of an EKS-A API kind, whose plural is always the
+// lowercase kind with an s suffix.
+func eksaResourceType(kind string) string {
+	return fmt.Sprintf("%ss.%s", strings.ToLower(kind), eksaResourceGroup)
+}
+
+func unstructuredSpec(o kubernetes.Object) (interface{}, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(o)
+	if err != nil {
+		return nil, fmt.Errorf("converting %s to unstructured: %v", objectKey(o), err)
+	}
+	return u["spec"], nil
+}
+
+// diffFields returns the fields under path set in git whose live value is different. Maps are compared key by key
+// and lists item by item when they have the same length, otherwise the values are compared as a whole.
+func diffFields(path string, git, live interface{}) []FieldDrift {
+	switch g := git.(type) {
+	case map[string]interface{}:
+		if l, ok := live.(map[string]interface{}); ok {
+			keys := make([]string, 0, len(g))
+			for k := range g {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+
+			var diffs []FieldDrift
+			for _, k := range keys {
+				diffs = append(diffs, diffFields(path+"."+k, g[k], l[k])...)
+			}
+			return diffs
+		}
+	case []interface{}:
+		if l, ok := live.([]interface{}); ok && len(l) == len(g) {
+			var diffs []FieldDrift
+			for i := range g {
+				diffs = append(diffs, diffFields(fmt.Sprintf("%s[%d]", path, i), g[i], l[i])...)
+			}
+			return diffs
+		}
+	}
+
+	if equality.Semantic.DeepEqual(git, live) {
+		return nil
+	}
+	return []FieldDrift{{Path: path, Git: driftValue(git), Live: driftValue(live)}}
+}
+
+func driftValue(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
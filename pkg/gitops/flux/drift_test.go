@@ -0,0 +1,162 @@
+package flux_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type driftTest struct {
+	fluxTest
+	cluster     *types.Cluster
+	clusterSpec *cluster.Spec
+	committed   *cluster.Config
+}
+
+func newDriftTest(t *testing.T) *driftTest {
+	g := newFluxTest(t)
+	if err := setupTestFiles(t, g.writer); err != nil {
+		t.Fatalf("setting up files: %v", err)
+	}
+	content, err := os.ReadFile("./testdata/cluster-config-default-path-management.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	committed, err := cluster.ParseConfig(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &driftTest{
+		fluxTest:    g,
+		cluster:     &types.Cluster{KubeconfigFile: "k.kubeconfig"},
+		clusterSpec: newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), ""),
+		committed:   committed,
+	}
+}
+
+func (tt *driftTest) expectSync() {
+	tt.git.EXPECT().Clone(tt.ctx).Return(nil)
+	tt.git.EXPECT().Branch(tt.clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+}
+
+func (tt *driftTest) expectLiveObject(resourceType, name string, live runtime.Object) {
+	tt.flux.EXPECT().GetObject(tt.ctx, tt.cluster, resourceType, name, "default", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, _, _, _ string, obj runtime.Object) error {
+			content, err := json.Marshal(live)
+			if err != nil {
+				return err
+			}
+			return json.Unmarshal(content, obj)
+		},
+	)
+}
+
+func TestDetectDriftNoDrift(t *testing.T) {
+	tt := newDriftTest(t)
+	tt.expectSync()
+	tt.expectLiveObject("clusters.anywhere.eks.amazonaws.com", "management-cluster", tt.committed.Cluster)
+	tt.expectLiveObject("fluxconfigs.anywhere.eks.amazonaws.com", "test-gitops", tt.committed.FluxConfig)
+
+	report, err := tt.gitOpsFlux.DetectDrift(tt.ctx, tt.cluster, tt.clusterSpec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(report.HasDrift()).To(BeFalse())
+	tt.Expect(report.File).To(Equal("clusters/management-cluster/management-cluster/eksa-system/eksa-cluster.yaml"))
+}
+
+func TestDetectDrift(t *testing.T) {
+	tt := newDriftTest(t)
+	liveCluster := tt.committed.Cluster.DeepCopy()
+	liveCluster.Spec.KubernetesVersion = v1alpha1.Kube121
+	liveCluster.Spec.ControlPlaneConfiguration.Count = 3
+	liveCluster.Spec.ManagementCluster.Name = "other-cluster"
+
+	tt.expectSync()
+	tt.expectLiveObject("clusters.anywhere.eks.amazonaws.com", "management-cluster", liveCluster)
+	tt.expectLiveObject("fluxconfigs.anywhere.eks.amazonaws.com", "test-gitops", tt.committed.FluxConfig)
+
+	report, err := tt.gitOpsFlux.DetectDrift(tt.ctx, tt.cluster, tt.clusterSpec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(report.Objects).To(Equal([]flux.ObjectDrift{
+		{
+			Kind:      "Cluster",
+			Name:      "management-cluster",
+			Namespace: "default",
+			Fields: []flux.FieldDrift{
+				{Path: "spec.kubernetesVersion", Git: `"1.19"`, Live: `"1.21"`},
+				{Path: "spec.managementCluster.name", Git: `"management-cluster"`, Live: `"other-cluster"`},
+			},
+		},
+	}))
+	tt.Expect(report.String()).To(Equal(`Cluster/management-cluster:
+  spec.kubernetesVersion:
+  - git: "1.19"
+  + live: "1.21"
+  spec.managementCluster.name:
+  - git: "management-cluster"
+  + live: "other-cluster"
+`))
+}
+
+func TestDetectDriftMissingObject(t *testing.T) {
+	tt := newDriftTest(t)
+	liveFluxConfig := tt.committed.FluxConfig.DeepCopy()
+	liveFluxConfig.Spec.Github = nil
+	notFound := apierrors.NewNotFound(schema.GroupResource{Group: "anywhere.eks.amazonaws.com", Resource: "clusters"}, "management-cluster")
+
+	tt.expectSync()
+	tt.flux.EXPECT().GetObject(tt.ctx, tt.cluster, "clusters.anywhere.eks.amazonaws.com", "management-cluster", "default", gomock.Any()).Return(notFound)
+	tt.expectLiveObject("fluxconfigs.anywhere.eks.amazonaws.com", "test-gitops", liveFluxConfig)
+
+	report, err := tt.gitOpsFlux.DetectDrift(tt.ctx, tt.cluster, tt.clusterSpec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(report.Objects).To(Equal([]flux.ObjectDrift{
+		{
+			Kind:      "Cluster",
+			Name:      "management-cluster",
+			Namespace: "default",
+			Missing:   true,
+		},
+		{
+			Kind:      "FluxConfig",
+			Name:      "test-gitops",
+			Namespace: "default",
+			Fields: []flux.FieldDrift{
+				{Path: "spec.github", Git: `{"owner":"mFolwer","personal":true,"repository":"testRepo"}`},
+			},
+		},
+	}))
+	tt.Expect(report.String()).To(ContainSubstring("Cluster/management-cluster: not found in the cluster\n"))
+}
+
+func TestDetectDriftGetObjectError(t *testing.T) {
+	tt := newDriftTest(t)
+	tt.expectSync()
+	tt.flux.EXPECT().GetObject(tt.ctx, tt.cluster, "clusters.anywhere.eks.amazonaws.com", "management-cluster", "default", gomock.Any()).Return(errors.New("error in get"))
+
+	_, err := tt.gitOpsFlux.DetectDrift(tt.ctx, tt.cluster, tt.clusterSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("getting live Cluster management-cluster: error in get")))
+}
+
+func TestDetectDriftMissingClusterConfig(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+
+	_, err := g.gitOpsFlux.DetectDrift(g.ctx, &types.Cluster{}, clusterSpec)
+	g.Expect(err).To(MatchError(ContainSubstring("reading committed cluster config")))
+}
@@ -0,0 +1,92 @@
+package flux
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// WithCommitExcludePatterns configures gitignore-style patterns for files that must never be staged or pushed
+// by EKS-A, even if they are present in the directories it commits. Patterns are matched against the file
+// path relative to the root of the repository.
+func WithCommitExcludePatterns(patterns ...string) Opt {
+	return func(f *Flux) {
+		f.commitExcludePatterns = patterns
+	}
+}
+
+// ValidateCommitExcludePatterns returns an error if any of the gitignore-style patterns is malformed.
+func ValidateCommitExcludePatterns(patterns []string) error {
+	for _, p := range patterns {
+		trimmed := strings.TrimPrefix(strings.TrimSpace(p), "!")
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			return fmt.Errorf("invalid commit exclude pattern %q: pattern can't be empty", p)
+		}
+		for _, segment := range strings.Split(strings.Trim(trimmed, "/"), "/") {
+			if _, err := filepath.Match(segment, ""); err != nil {
+				return fmt.Errorf("invalid commit exclude pattern %q: %v", p, err)
+			}
+		}
+	}
+	return nil
+}
+
+// addToGit stages the given path of the repository, skipping any file matching the configured exclude patterns.
+// When no exclude patterns are configured, the path is staged as a whole.
+func (f *Flux) addToGit(p string) error {
+	if len(f.commitExcludePatterns) == 0 {
+		return f.gitClient.Add(p)
+	}
+
+	if err := ValidateCommitExcludePatterns(f.commitExcludePatterns); err != nil {
+		return err
+	}
+
+	patterns := make([]gitignore.Pattern, 0, len(f.commitExcludePatterns))
+	for _, p := range f.commitExcludePatterns {
+		patterns = append(patterns, gitignore.ParsePattern(strings.TrimSpace(p), nil))
+	}
+	matcher := gitignore.NewMatcher(patterns)
+
+	root := f.writer.Dir()
+	var excluded []string
+	err := filepath.WalkDir(path.Join(root, p), func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, file)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if matcher.Match(strings.Split(rel, "/"), d.IsDir()) {
+			excluded = append(excluded, rel)
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		return f.gitClient.Add(rel)
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(excluded) > 0 {
+		logger.V(3).Info("Excluded files matching commit exclude patterns from git", "files", excluded)
+	}
+	return nil
+}
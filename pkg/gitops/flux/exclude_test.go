@@ -0,0 +1,87 @@
+package flux_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	"github.com/aws/eks-anywhere/pkg/providers"
+)
+
+func TestUpdateGitEksaSpecCommitExcludePatterns(t *testing.T) {
+	clusterName := "management-cluster"
+	eksaSystemDirPath := "clusters/management-cluster/management-cluster/eksa-system"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithCommitExcludePatterns("*-secret.yaml", "local/"))
+
+	for _, file := range []string{"creds-secret.yaml", "local/kubeconfig.yaml"} {
+		p := path.Join(g.writer.Dir(), eksaSystemDirPath, file)
+		g.Expect(os.MkdirAll(path.Dir(p), 0o755)).To(Succeed())
+		g.Expect(os.WriteFile(p, []byte("kind: Secret\n"), 0o644)).To(Succeed())
+	}
+
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add(path.Join(eksaSystemDirPath, "eksa-cluster.yaml")).Return(nil)
+	g.git.EXPECT().Add(path.Join(eksaSystemDirPath, "kustomization.yaml")).Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+
+	g.Expect(f.UpdateGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+}
+
+func TestUpdateGitEksaSpecCommitExcludePatternsInvalid(t *testing.T) {
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithCommitExcludePatterns("[secret"))
+
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add(gomock.Any()).Times(0)
+
+	err := f.UpdateGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})
+	g.Expect(err).To(MatchError(ContainSubstring("invalid commit exclude pattern \"[secret\"")))
+}
+
+func TestValidateCommitExcludePatterns(t *testing.T) {
+	tests := []struct {
+		testName string
+		patterns []string
+		wantErr  string
+	}{
+		{
+			testName: "valid patterns",
+			patterns: []string{"*.secret.yaml", "/clusters/**/local/", "!keep.yaml"},
+		},
+		{
+			testName: "empty pattern",
+			patterns: []string{" "},
+			wantErr:  "pattern can't be empty",
+		},
+		{
+			testName: "malformed pattern",
+			patterns: []string{"secrets/[a-"},
+			wantErr:  "syntax error in pattern",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			err := flux.ValidateCommitExcludePatterns(tt.patterns)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package flux
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers"
+)
+
+// ExportGitOps renders the files InstallGitOps would commit for the cluster into dir, with the same layout as in the
+// repository, without cloning, committing, pushing or bootstrapping flux. The cluster config path of the FluxConfig
+// is kept under dir, so the files can be copied to the root of the repository to be reviewed and merged by an
+// external pipeline. With an OCI repository or bucket source, the flux toolkit components are exported too.
+func (f *Flux) ExportGitOps(ctx context.Context, dir string, clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) error {
+	if clusterSpec.FluxConfig == nil {
+		logger.Info("GitOps field not specified, export gitops skipped")
+		return nil
+	}
+
+	w, err := filewriter.NewWriter(dir)
+	if err != nil {
+		return fmt.Errorf("creating gitops export directory: %v", err)
+	}
+	defer w.CleanUpTemp()
+
+	exporter := *f
+	exporter.writer = w
+	fc, err := newFluxForCluster(&exporter, clusterSpec, datacenterConfig, machineConfigs)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Exporting cluster configuration files", "dir", dir)
+	if usesOCIRepository(clusterSpec) || usesBucket(clusterSpec) {
+		return fc.writeArtifactFiles(ctx)
+	}
+	return fc.writeClusterConfigFiles()
+}
@@ -0,0 +1,75 @@
+package flux_test
+
+import (
+	"errors"
+	"path"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+func TestExportGitOpsManagementCluster(t *testing.T) {
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	dir := t.TempDir()
+
+	g.Expect(g.gitOpsFlux.ExportGitOps(g.ctx, dir, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(dir, "clusters/management-cluster/management-cluster/eksa-system/eksa-cluster.yaml"), "./testdata/cluster-config-default-path-management.yaml")
+	test.AssertFilesEquals(t, path.Join(dir, "clusters/management-cluster/management-cluster/eksa-system/kustomization.yaml"), "./testdata/kustomization.yaml")
+	test.AssertFilesEquals(t, path.Join(dir, "clusters/management-cluster/flux-system/gotk-patches.yaml"), "./testdata/gotk-patches.yaml")
+	test.AssertFilesEquals(t, path.Join(dir, "clusters/management-cluster/flux-system/gotk-sync.yaml"), "./testdata/gotk-sync.yaml")
+	g.Expect(validations.FileExists(path.Join(dir, "generated"))).To(BeFalse())
+}
+
+func TestExportGitOpsWorkloadCluster(t *testing.T) {
+	clusterName := "workload-cluster"
+	clusterConfig := v1alpha1.NewCluster(clusterName)
+	clusterConfig.SetManagedBy("management-cluster")
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+	dir := t.TempDir()
+
+	g.Expect(g.gitOpsFlux.ExportGitOps(g.ctx, dir, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(dir, "clusters/management-cluster/workload-cluster/eksa-system/eksa-cluster.yaml"), "./testdata/cluster-config-default-path-workload.yaml")
+	g.Expect(validations.FileExists(path.Join(dir, "clusters/management-cluster/flux-system/gotk-patches.yaml"))).To(BeFalse())
+}
+
+func TestExportGitOpsOCIRepository(t *testing.T) {
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newOCIClusterSpec(t, v1alpha1.NewCluster(clusterName))
+	dir := t.TempDir()
+
+	g.flux.EXPECT().ExportComponents(g.ctx, clusterSpec.FluxConfig).Return([]byte("components"), nil)
+
+	g.Expect(g.gitOpsFlux.ExportGitOps(g.ctx, dir, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+	test.AssertContentToFile(t, "components", path.Join(dir, "clusters/management-cluster/flux-system/gotk-components.yaml"))
+}
+
+func TestExportGitOpsOCIRepositoryExportComponentsError(t *testing.T) {
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newOCIClusterSpec(t, v1alpha1.NewCluster(clusterName))
+
+	g.flux.EXPECT().ExportComponents(g.ctx, clusterSpec.FluxConfig).Return(nil, errors.New("error in export"))
+
+	err := g.gitOpsFlux.ExportGitOps(g.ctx, t.TempDir(), clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})
+	g.Expect(err).To(MatchError(ContainSubstring("exporting flux components: error in export")))
+}
+
+func TestExportGitOpsNoFluxConfig(t *testing.T) {
+	g := newFluxTest(t)
+	dir := path.Join(t.TempDir(), "export")
+	g.clusterSpec.FluxConfig = nil
+
+	g.Expect(g.gitOpsFlux.ExportGitOps(g.ctx, dir, g.clusterSpec, nil, nil)).To(Succeed())
+	g.Expect(validations.FileExists(dir)).To(BeFalse())
+}
@@ -0,0 +1,35 @@
+package flux
+
+import (
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+)
+
+// fluxSystemSecretName is the secret holding the git credentials of the flux-system GitRepository.
+const fluxSystemSecretName = "flux-system"
+
+// WriteFluxExternalSecret writes an ExternalSecret syncing the git credentials from the external secret store into
+// the flux-system secret. The secret is created by flux bootstrap, so the ExternalSecret merges the properties of the
+// remote secret into it instead of owning it, and the credentials are rotated by the External Secrets Operator from
+// then on. It does nothing if the external secret isn't configured.
+func (g *FileGenerator) WriteFluxExternalSecret(clusterSpec *cluster.Spec) error {
+	s := clusterSpec.FluxConfig.Spec.ExternalSecret
+	if s == nil {
+		return nil
+	}
+
+	values := map[string]string{
+		"Name":            fluxSystemSecretName,
+		"Namespace":       clusterSpec.FluxConfig.Spec.SystemNamespace,
+		"RefreshInterval": s.RefreshInterval,
+		"SecretStoreKind": s.SecretStoreRef.Kind,
+		"SecretStoreName": s.SecretStoreRef.Name,
+		"RemoteKey":       s.RemoteKey,
+	}
+	if path, err := g.fluxTemplater.WriteToFile(fluxExternalSecretContent, values, fluxExternalSecretFileName, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("creating flux-system external secret manifest file into %s: %v", path, err)
+	}
+	return nil
+}
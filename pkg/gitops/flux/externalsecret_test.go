@@ -0,0 +1,47 @@
+package flux_test
+
+import (
+	"errors"
+	"path"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+)
+
+func TestFileGeneratorWriteFluxSystemFilesWithExternalSecretContent(t *testing.T) {
+	g := NewWithT(t)
+	_, w := test.NewWriter(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.ExternalSecret = &v1alpha1.FluxExternalSecretConfig{
+		SecretStoreRef: v1alpha1.FluxSecretStoreRef{Name: "aws-secrets-manager"},
+		RemoteKey:      "eksa/flux-fleet",
+	}
+	clusterSpec.FluxConfig.SetDefaults()
+
+	gen := flux.NewFileGenerator()
+	g.Expect(gen.Init(w, "eksa-system", "flux-system")).To(Succeed())
+	g.Expect(gen.WriteFluxSystemFiles(clusterSpec)).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "flux-system", "eksa-external-secret.yaml"), "./testdata/eksa-external-secret.yaml")
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "flux-system", "kustomization.yaml"), "./testdata/flux-kustomization-external-secret.yaml")
+}
+
+func TestFileGeneratorWriteFluxExternalSecretError(t *testing.T) {
+	tt := newFileGeneratorTest(t)
+	tt.clusterSpec.FluxConfig.Spec.ExternalSecret = &v1alpha1.FluxExternalSecretConfig{}
+
+	tt.t.EXPECT().WriteToFile(gomock.Any(), gomock.Any(), "eksa-external-secret.yaml", gomock.Any()).Return("", errors.New("error in write external secret"))
+
+	tt.Expect(tt.g.WriteFluxExternalSecret(tt.clusterSpec)).To(MatchError(ContainSubstring("error in write external secret")))
+}
+
+func TestFileGeneratorWriteFluxExternalSecretSkip(t *testing.T) {
+	tt := newFileGeneratorTest(t)
+
+	tt.Expect(tt.g.WriteFluxExternalSecret(tt.clusterSpec)).To(Succeed())
+}
@@ -3,12 +3,24 @@ package flux
 import (
 	_ "embed"
 	"fmt"
+	"path"
+	"strconv"
+	"strings"
 
+	eksdv1alpha1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clustermarshaller"
+	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/registrymirror"
 	"github.com/aws/eks-anywhere/pkg/templater"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
 const (
@@ -17,20 +29,92 @@ const (
 	clusterConfigFileName = "eksa-cluster.yaml"
 	fluxSyncFileName      = "gotk-sync.yaml"
 	fluxPatchFileName     = "gotk-patches.yaml"
+	fluxReceiverFileName  = "gotk-receiver.yaml"
+	helmReleasesFileName  = "helm-releases.yaml"
+	bundlesFileName       = "eksa-bundles.yaml"
+	eksdReleaseFileName   = "eksd-release.yaml"
+
+	fluxNotificationsFileName  = "gotk-notifications.yaml"
+	fluxSourcesFileName        = "eksa-sources.yaml"
+	fluxSealedSecretsFileName  = "eksa-sealed-secrets.yaml"
+	fluxExternalSecretFileName = "eksa-external-secret.yaml"
+	tenantFileName             = "eksa-tenant.yaml"
+	tenantRolePrefix           = "eksa-tenant-"
+	spokeFileName              = "eksa-spoke.yaml"
+	spokeManifestsDirName      = "manifests"
+	spokeKustomizationPrefix   = "eksa-spoke-"
+	adoptedSyncFileName        = "eksa-sync.yaml"
+	adoptedSyncPrefix          = "eksa-"
+
+	bundlesKind     = "Bundles"
+	eksdReleaseKind = "Release"
+
+	defaultSourceInterval        = "1m0s"
+	defaultKustomizationInterval = "10m0s"
+
+	// OwnerLabel is the label on the EKS-A Cluster object that identifies its owning team.
+	// When set, its value is propagated as an annotation with the same key to all the resources
+	// reconciled from the eksa-system kustomization.
+	OwnerLabel = "anywhere.eks.amazonaws.com/owner"
 )
 
 //go:embed manifests/eksa-system/kustomization.yaml
 var eksaKustomizeContent string
 
+//go:embed manifests/eksa-system/helm-releases.yaml
+var helmReleasesContent string
+
 //go:embed manifests/flux-system/kustomization.yaml
 var fluxKustomizeContent string
 
 //go:embed manifests/flux-system/gotk-sync.yaml
 var fluxSyncContent string
 
+//go:embed manifests/flux-system/gotk-sync-oci.yaml
+var fluxOCISyncContent string
+
+//go:embed manifests/flux-system/gotk-sync-bucket.yaml
+var fluxBucketSyncContent string
+
 //go:embed manifests/flux-system/gotk-patches.yaml
 var fluxPatchContent string
 
+//go:embed manifests/flux-system/gotk-receiver.yaml
+var fluxReceiverContent string
+
+//go:embed manifests/flux-system/gotk-notifications.yaml
+var fluxNotificationsContent string
+
+//go:embed manifests/flux-system/eksa-sources.yaml
+var fluxSourcesContent string
+
+//go:embed manifests/flux-system/eksa-sealed-secrets.yaml
+var fluxSealedSecretsContent string
+
+//go:embed manifests/flux-system/eksa-external-secret.yaml
+var fluxExternalSecretContent string
+
+//go:embed manifests/tenant/kustomization.yaml
+var tenantKustomizeContent string
+
+//go:embed manifests/tenant/eksa-tenant.yaml
+var tenantContent string
+
+//go:embed manifests/spoke/kustomization.yaml
+var spokeKustomizeContent string
+
+//go:embed manifests/spoke/eksa-spoke.yaml
+var spokeContent string
+
+//go:embed manifests/spoke/manifests-kustomization.yaml
+var spokeManifestsKustomizeContent string
+
+//go:embed manifests/adopt/kustomization.yaml
+var adoptedKustomizeContent string
+
+//go:embed manifests/adopt/eksa-sync.yaml
+var adoptedSyncContent string
+
 type Templater interface {
 	WriteToFile(templateContent string, data interface{}, fileName string, f ...filewriter.FileOptionsFunc) (filePath string, err error)
 }
@@ -38,6 +122,9 @@ type Templater interface {
 type FileGenerator struct {
 	fluxWriter, eksaWriter       filewriter.FileWriter
 	fluxTemplater, eksaTemplater Templater
+	tenantTemplater              Templater
+	spokeTemplater               Templater
+	spokeManifestsTemplater      Templater
 }
 
 func NewFileGenerator() *FileGenerator {
@@ -76,6 +163,38 @@ func (g *FileGenerator) Init(writer filewriter.FileWriter, eksaSystemDir, fluxSy
 	return nil
 }
 
+// InitTenant initializes the writer for the tenant files of a workload cluster, which are written to tenantDir.
+func (g *FileGenerator) InitTenant(writer filewriter.FileWriter, tenantDir string) error {
+	tenantWriter, err := writer.WithDir(tenantDir)
+	if err != nil {
+		return fmt.Errorf("initializing tenant writer: %v", err)
+	}
+	tenantWriter.CleanUpTemp()
+
+	g.tenantTemplater = templater.New(tenantWriter)
+	return nil
+}
+
+// InitSpoke initializes the writers for the hub-and-spoke files of a workload cluster, which are written to spokeDir
+// and to its manifests directory.
+func (g *FileGenerator) InitSpoke(writer filewriter.FileWriter, spokeDir string) error {
+	spokeWriter, err := writer.WithDir(spokeDir)
+	if err != nil {
+		return fmt.Errorf("initializing spoke writer: %v", err)
+	}
+	spokeWriter.CleanUpTemp()
+
+	manifestsWriter, err := writer.WithDir(path.Join(spokeDir, spokeManifestsDirName))
+	if err != nil {
+		return fmt.Errorf("initializing spoke manifests writer: %v", err)
+	}
+	manifestsWriter.CleanUpTemp()
+
+	g.spokeTemplater = templater.New(spokeWriter)
+	g.spokeManifestsTemplater = templater.New(manifestsWriter)
+	return nil
+}
+
 func (g *FileGenerator) WriteEksaFiles(clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) error {
 	if datacenterConfig == nil && machineConfigs == nil {
 		return nil
@@ -85,7 +204,15 @@ func (g *FileGenerator) WriteEksaFiles(clusterSpec *cluster.Spec, datacenterConf
 		return err
 	}
 
-	if err := g.WriteEksaKustomization(); err != nil {
+	if err := g.WriteHelmReleases(clusterSpec); err != nil {
+		return err
+	}
+
+	if err := g.WriteReleaseManifests(clusterSpec); err != nil {
+		return err
+	}
+
+	if err := g.WriteEksaKustomization(clusterSpec); err != nil {
 		return err
 	}
 
@@ -97,14 +224,45 @@ func (g *FileGenerator) WriteFluxSystemFiles(clusterSpec *cluster.Spec) error {
 		return err
 	}
 
-	if err := g.WriteFluxSync(); err != nil {
-		return err
+	switch {
+	case clusterSpec.FluxConfig.Spec.OCIRepository != nil:
+		if err := g.WriteFluxOCISync(clusterSpec); err != nil {
+			return err
+		}
+	case clusterSpec.FluxConfig.Spec.Bucket != nil:
+		if err := g.WriteFluxBucketSync(clusterSpec); err != nil {
+			return err
+		}
+	default:
+		if err := g.WriteFluxSync(); err != nil {
+			return err
+		}
 	}
 
 	if err := g.WriteFluxPatch(clusterSpec); err != nil {
 		return err
 	}
 
+	if err := g.WriteFluxReceiver(clusterSpec); err != nil {
+		return err
+	}
+
+	if err := g.WriteFluxNotifications(clusterSpec); err != nil {
+		return err
+	}
+
+	if err := g.WriteFluxSources(clusterSpec); err != nil {
+		return err
+	}
+
+	if err := g.WriteFluxSealedSecrets(clusterSpec); err != nil {
+		return err
+	}
+
+	if err := g.WriteFluxExternalSecret(clusterSpec); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -120,10 +278,40 @@ func (g *FileGenerator) WriteClusterConfig(clusterSpec *cluster.Spec, datacenter
 	return nil
 }
 
-func (g *FileGenerator) WriteEksaKustomization() error {
-	values := map[string]string{
+// WriteEksaKustomization writes the eksa-system kustomization, merging the extra resources, patches and common
+// metadata of the flux config so they survive the regeneration of the file on every update.
+func (g *FileGenerator) WriteEksaKustomization(clusterSpec *cluster.Spec) error {
+	values := map[string]interface{}{
 		"ConfigFileName": clusterConfigFileName,
 	}
+	if len(clusterSpec.FluxConfig.Spec.HelmCharts) > 0 {
+		values["HelmReleasesFileName"] = helmReleasesFileName
+	}
+	if releaseManifests := releaseManifestFiles(clusterSpec); len(releaseManifests) > 0 {
+		values["ReleaseManifests"] = releaseManifests
+	}
+
+	annotations := map[string]string{}
+	if k := clusterSpec.FluxConfig.Spec.EksaSystemKustomize; k != nil {
+		if len(k.Resources) > 0 {
+			values["Resources"] = k.Resources
+		}
+		if len(k.CommonLabels) > 0 {
+			values["CommonLabels"] = k.CommonLabels
+		}
+		for key, value := range k.CommonAnnotations {
+			annotations[key] = value
+		}
+		if len(k.Patches) > 0 {
+			values["Patches"] = kustomizePatches(k.Patches)
+		}
+	}
+	if owner := clusterSpec.Cluster.Labels[OwnerLabel]; owner != "" {
+		annotations[OwnerLabel] = owner
+	}
+	if len(annotations) > 0 {
+		values["CommonAnnotations"] = annotations
+	}
 
 	if path, err := g.eksaTemplater.WriteToFile(eksaKustomizeContent, values, kustomizeFileName, filewriter.PersistentFile); err != nil {
 		return fmt.Errorf("writing eks-a kustomization manifest file into %s: %v", path, err)
@@ -131,10 +319,145 @@ func (g *FileGenerator) WriteEksaKustomization() error {
 	return nil
 }
 
+// WriteReleaseManifests writes the EKS-A bundles and the EKS Distro release of the cluster spec to the eksa-system
+// directory when the flux config commits them, so the management cluster doesn't need to reach the public release
+// endpoints to reconcile the cluster.
+func (g *FileGenerator) WriteReleaseManifests(clusterSpec *cluster.Spec) error {
+	if !commitsReleaseManifests(clusterSpec) {
+		return nil
+	}
+
+	if clusterSpec.Bundles != nil {
+		bundles := clusterSpec.Bundles.DeepCopy()
+		bundles.TypeMeta = metav1.TypeMeta{APIVersion: releasev1alpha1.GroupVersion.String(), Kind: bundlesKind}
+		if err := g.writeReleaseManifest(bundlesFileName, bundles, &bundles.ObjectMeta); err != nil {
+			return err
+		}
+	}
+
+	if release := clusterSpec.EKSDRelease(); release != nil {
+		release = release.DeepCopy()
+		release.TypeMeta = metav1.TypeMeta{APIVersion: eksdv1alpha1.GroupVersion.String(), Kind: eksdReleaseKind}
+		if err := g.writeReleaseManifest(eksdReleaseFileName, release, &release.ObjectMeta); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeReleaseManifest writes the object without the metadata set by the api server, which can't be applied.
+func (g *FileGenerator) writeReleaseManifest(fileName string, obj interface{}, meta *metav1.ObjectMeta) error {
+	*meta = metav1.ObjectMeta{
+		Name:        meta.Name,
+		Namespace:   meta.Namespace,
+		Labels:      meta.Labels,
+		Annotations: meta.Annotations,
+	}
+	delete(meta.Annotations, corev1.LastAppliedConfigAnnotation)
+
+	content, err := yaml.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("marshalling %s: %v", fileName, err)
+	}
+	if filePath, err := g.eksaWriter.Write(fileName, content, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("writing eks-a release manifest file into %s: %v", filePath, err)
+	}
+	return nil
+}
+
+// commitsReleaseManifests returns true if the release manifests are committed for the cluster. Only management clusters
+// commit them, the bundles of their workload clusters are reconciled from the management cluster directory.
+func commitsReleaseManifests(clusterSpec *cluster.Spec) bool {
+	return clusterSpec.FluxConfig.Spec.CommitReleaseManifests && clusterSpec.Cluster.IsSelfManaged()
+}
+
+// releaseManifestFiles returns the release manifests committed to the eksa-system directory.
+func releaseManifestFiles(clusterSpec *cluster.Spec) []string {
+	if !commitsReleaseManifests(clusterSpec) {
+		return nil
+	}
+
+	var files []string
+	if clusterSpec.Bundles != nil {
+		files = append(files, bundlesFileName)
+	}
+	if clusterSpec.EKSDRelease() != nil {
+		files = append(files, eksdReleaseFileName)
+	}
+	return files
+}
+
+// kustomizePatches returns the patches with their trailing new lines trimmed, so they can be rendered as a block scalar.
+func kustomizePatches(patches []v1alpha1.FluxKustomizePatch) []v1alpha1.FluxKustomizePatch {
+	trimmed := make([]v1alpha1.FluxKustomizePatch, 0, len(patches))
+	for _, p := range patches {
+		trimmed = append(trimmed, v1alpha1.FluxKustomizePatch{
+			Patch:  strings.TrimRight(p.Patch, "\n"),
+			Target: p.Target,
+		})
+	}
+	return trimmed
+}
+
+// WriteHelmReleases writes a Flux HelmRepository and HelmRelease for each chart in the flux config, so add-ons can be
+// installed by the helm-controller from the same repository. It does nothing if no charts are configured.
+func (g *FileGenerator) WriteHelmReleases(clusterSpec *cluster.Spec) error {
+	charts := clusterSpec.FluxConfig.Spec.HelmCharts
+	if len(charts) == 0 {
+		return nil
+	}
+
+	namespace := clusterSpec.FluxConfig.Spec.SystemNamespace
+	releases := make([]map[string]string, 0, len(charts))
+	for _, c := range charts {
+		chart := c.Chart
+		if chart == "" {
+			chart = c.Name
+		}
+		targetNamespace := c.TargetNamespace
+		if targetNamespace == "" {
+			targetNamespace = namespace
+		}
+
+		releases = append(releases, map[string]string{
+			"Name":            c.Name,
+			"Chart":           chart,
+			"Version":         c.Version,
+			"RepositoryUrl":   c.RepositoryUrl,
+			"TargetNamespace": targetNamespace,
+		})
+	}
+
+	values := map[string]interface{}{
+		"Namespace": namespace,
+		"Charts":    releases,
+	}
+	if path, err := g.eksaTemplater.WriteToFile(helmReleasesContent, values, helmReleasesFileName, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("writing eks-a helm releases manifest file into %s: %v", path, err)
+	}
+	return nil
+}
+
 func (g *FileGenerator) WriteFluxKustomization(clusterSpec *cluster.Spec) error {
 	values := map[string]string{
 		"Namespace": clusterSpec.FluxConfig.Spec.SystemNamespace,
 	}
+	if clusterSpec.FluxConfig.Spec.Receiver != nil {
+		values["ReceiverFileName"] = fluxReceiverFileName
+	}
+	if len(clusterSpec.FluxConfig.Spec.Notifications) > 0 {
+		values["NotificationsFileName"] = fluxNotificationsFileName
+	}
+	if len(clusterSpec.AdditionalFluxConfigs) > 0 {
+		values["SourcesFileName"] = fluxSourcesFileName
+	}
+	if clusterSpec.FluxConfig.Spec.SealedSecrets != nil {
+		values["SealedSecretsFileName"] = fluxSealedSecretsFileName
+	}
+	if clusterSpec.FluxConfig.Spec.ExternalSecret != nil {
+		values["ExternalSecretFileName"] = fluxExternalSecretFileName
+	}
 
 	if path, err := g.fluxTemplater.WriteToFile(fluxKustomizeContent, values, kustomizeFileName, filewriter.PersistentFile); err != nil {
 		return fmt.Errorf("creating flux-system kustomization manifest file into %s: %v", path, err)
@@ -149,16 +472,396 @@ func (g *FileGenerator) WriteFluxSync() error {
 	return nil
 }
 
-func (g *FileGenerator) WriteFluxPatch(clusterSpec *cluster.Spec) error {
-	values := map[string]string{
-		"Namespace":                   clusterSpec.FluxConfig.Spec.SystemNamespace,
-		"SourceControllerImage":       clusterSpec.VersionsBundle.Flux.SourceController.VersionedImage(),
-		"KustomizeControllerImage":    clusterSpec.VersionsBundle.Flux.KustomizeController.VersionedImage(),
-		"HelmControllerImage":         clusterSpec.VersionsBundle.Flux.HelmController.VersionedImage(),
-		"NotificationControllerImage": clusterSpec.VersionsBundle.Flux.NotificationController.VersionedImage(),
+// WriteFluxOCISync writes the flux-system OCIRepository and Kustomization that sync the cluster from the OCI artifact.
+// Unlike the Git sync, which is generated by flux bootstrap, it's rendered by the CLI since flux doesn't bootstrap from OCI.
+func (g *FileGenerator) WriteFluxOCISync(clusterSpec *cluster.Spec) error {
+	oci := clusterSpec.FluxConfig.Spec.OCIRepository
+	values := map[string]interface{}{
+		"Namespace": clusterSpec.FluxConfig.Spec.SystemNamespace,
+		"Url":       oci.Url,
+		"Tag":       oci.Tag,
+		"SecretRef": oci.SecretRef,
+		"Insecure":  oci.Insecure,
+	}
+	addSyncValues(values, clusterSpec.FluxConfig.Spec.Sync)
+	if path, err := g.fluxTemplater.WriteToFile(fluxOCISyncContent, values, fluxSyncFileName, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("creating flux-system oci sync manifest file into %s: %v", path, err)
+	}
+	return nil
+}
+
+// addSyncValues adds the values to render the intervals, timeouts, prune policy and health checks of the flux-system
+// source and Kustomization rendered by the CLI, keeping the default intervals and pruning when they're not configured.
+func addSyncValues(values map[string]interface{}, sync *v1alpha1.FluxSyncConfig) {
+	values["SourceInterval"] = defaultSourceInterval
+	values["KustomizationInterval"] = defaultKustomizationInterval
+	values["Prune"] = sync.PruneEnabled()
+	if sync == nil {
+		return
+	}
+	if sync.Interval != "" {
+		values["SourceInterval"] = sync.Interval
+		values["KustomizationInterval"] = sync.Interval
+	}
+	values["Timeout"] = sync.Timeout
+	values["RetryInterval"] = sync.RetryInterval
+	values["Wait"] = sync.Wait
+	values["HealthChecks"] = sync.HealthChecks
+}
+
+// WriteFluxBucketSync writes the flux-system Bucket and Kustomization that sync the cluster from the bucket.
+// The bucket holds the files with the same layout as a Git repo, so the Kustomization points to the cluster config path.
+func (g *FileGenerator) WriteFluxBucketSync(clusterSpec *cluster.Spec) error {
+	configPath, err := resolveSyncPath(clusterSpec)
+	if err != nil {
+		return err
 	}
+
+	bucket := clusterSpec.FluxConfig.Spec.Bucket
+	values := map[string]interface{}{
+		"Namespace":  clusterSpec.FluxConfig.Spec.SystemNamespace,
+		"Path":       configPath,
+		"Provider":   bucket.Provider,
+		"BucketName": bucket.BucketName,
+		"Endpoint":   bucket.Endpoint,
+		"Region":     bucket.Region,
+		"SecretRef":  bucket.SecretRef,
+		"Insecure":   bucket.Insecure,
+	}
+	addSyncValues(values, clusterSpec.FluxConfig.Spec.Sync)
+	if path, err := g.fluxTemplater.WriteToFile(fluxBucketSyncContent, values, fluxSyncFileName, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("creating flux-system bucket sync manifest file into %s: %v", path, err)
+	}
+	return nil
+}
+
+// WriteFluxComponents writes the toolkit components manifests, so flux can reconcile its own components from the OCI artifact or bucket.
+func (g *FileGenerator) WriteFluxComponents(components []byte) error {
+	if path, err := g.fluxWriter.Write(fluxComponentsFileName, components, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("creating flux-system components manifest file into %s: %v", path, err)
+	}
+	return nil
+}
+
+func (g *FileGenerator) WriteFluxPatch(clusterSpec *cluster.Spec) error {
+	values := fluxPatchValues(clusterSpec)
 	if path, err := g.fluxTemplater.WriteToFile(fluxPatchContent, values, fluxPatchFileName, filewriter.PersistentFile); err != nil {
 		return fmt.Errorf("creating flux-system patch manifest file into %s: %v", path, err)
 	}
 	return nil
 }
+
+// fluxPatchValues returns the values to render the flux-system patch for the flux bundle of the cluster spec.
+func fluxPatchValues(clusterSpec *cluster.Spec) map[string]interface{} {
+	values := map[string]interface{}{
+		"Namespace":                clusterSpec.FluxConfig.Spec.SystemNamespace,
+		"SourceControllerImage":    clusterSpec.VersionsBundle.Flux.SourceController.VersionedImage(),
+		"KustomizeControllerImage": clusterSpec.VersionsBundle.Flux.KustomizeController.VersionedImage(),
+		"KustomizationAPIVersion":  kustomizationAPIVersion(clusterSpec),
+	}
+	// Only the installed components can be patched, a patch for a missing Deployment fails the kustomization.
+	spec := clusterSpec.FluxConfig.Spec
+	if spec.ComponentEnabled(v1alpha1.FluxHelmController) {
+		values["HelmControllerImage"] = clusterSpec.VersionsBundle.Flux.HelmController.VersionedImage()
+	}
+	if spec.ComponentEnabled(v1alpha1.FluxNotificationController) {
+		values["NotificationControllerImage"] = clusterSpec.VersionsBundle.Flux.NotificationController.VersionedImage()
+	}
+	if spec.ComponentEnabled(v1alpha1.FluxImageReflectorController) {
+		values["ImageReflectorControllerImage"] = imageAutomationControllerImage(clusterSpec.VersionsBundle.Flux.ImageReflectorController)
+	}
+	if spec.ComponentEnabled(v1alpha1.FluxImageAutomationController) {
+		values["ImageAutomationControllerImage"] = imageAutomationControllerImage(clusterSpec.VersionsBundle.Flux.ImageAutomationController)
+	}
+	if m := clusterSpec.FluxConfig.Spec.MultiTenancy; m != nil && (m.ServiceAccountName != "" || m.TargetNamespace != "") {
+		values["KustomizationPatch"] = "true"
+		values["ServiceAccountName"] = m.ServiceAccountName
+		values["TargetNamespace"] = m.TargetNamespace
+	}
+	if dependencies := kustomizationDependencies(clusterSpec); len(dependencies) > 0 {
+		values["KustomizationPatch"] = "true"
+		values["DependsOn"] = dependencies
+	}
+	if d := clusterSpec.FluxConfig.Spec.Decryption; d != nil {
+		values["KustomizationPatch"] = "true"
+		values["DecryptionProvider"] = d.Provider
+		values["DecryptionSecretName"] = decryptionSecretName(d)
+	}
+	// The OCI and bucket syncs are rendered with their intervals and timeouts, only the Git sync generated by flux
+	// bootstrap needs to be patched.
+	if s := clusterSpec.FluxConfig.Spec.Sync; !s.IsEmpty() && sourceKind(clusterSpec) == "GitRepository" {
+		if s.Interval != "" || s.Timeout != "" {
+			values["GitRepositoryPatch"] = "true"
+		}
+		values["KustomizationPatch"] = "true"
+		values["Interval"] = s.Interval
+		values["Timeout"] = s.Timeout
+		values["RetryInterval"] = s.RetryInterval
+		if s.Prune != nil {
+			values["Prune"] = strconv.FormatBool(*s.Prune)
+		}
+		values["HealthChecks"] = s.HealthChecks
+	}
+	if m := spec.SystemNamespaceMetadata; m != nil && (len(m.Labels) > 0 || len(m.Annotations) > 0) {
+		values["NamespacePatch"] = "true"
+		values["NamespaceLabels"] = m.Labels
+		values["NamespaceAnnotations"] = m.Annotations
+	}
+	scheduling := controllerScheduling(spec.ControllerScheduling)
+	for key, controller := range fluxControllerImageValues {
+		if !spec.ComponentEnabled(controller) {
+			continue
+		}
+		prefix := strings.TrimSuffix(key, "Image")
+		if resources := controllerResources(spec.ControllerResources[controller]); resources != "" {
+			values[prefix+"Resources"] = resources
+		}
+		if scheduling != "" {
+			values[prefix+"Scheduling"] = scheduling
+		}
+	}
+	// In air-gapped environments the controllers can only pull their images from the registry mirror.
+	if clusterSpec.Cluster != nil {
+		if mirror := registrymirror.FromCluster(clusterSpec.Cluster); mirror != nil {
+			for key := range fluxControllerImageValues {
+				if image, ok := values[key].(string); ok && image != "" {
+					values[key] = mirror.ReplaceRegistry(image)
+				}
+			}
+		}
+	}
+	return values
+}
+
+// controllerResources returns the resources of a controller container as yaml, or an empty string if none are set.
+func controllerResources(r v1alpha1.FluxResourceRequirements) string {
+	resources := map[string]map[string]string{}
+	for field, list := range map[string]v1alpha1.FluxResourceList{"requests": r.Requests, "limits": r.Limits} {
+		quantities := map[string]string{}
+		if list.CPU != "" {
+			quantities["cpu"] = list.CPU
+		}
+		if list.Memory != "" {
+			quantities["memory"] = list.Memory
+		}
+		if len(quantities) > 0 {
+			resources[field] = quantities
+		}
+	}
+	if len(resources) == 0 {
+		return ""
+	}
+
+	// A map of strings can always be marshalled.
+	content, _ := yaml.Marshal(resources)
+	return strings.TrimRight(string(content), "\n")
+}
+
+// controllerScheduling returns the node selector and tolerations of the controllers pods as yaml, or an empty string if
+// none are set.
+func controllerScheduling(config *v1alpha1.FluxSchedulingConfig) string {
+	if config == nil || (len(config.NodeSelector) == 0 && len(config.Tolerations) == 0) {
+		return ""
+	}
+
+	// Node selectors and tolerations can always be marshalled.
+	content, _ := yaml.Marshal(config)
+	return strings.TrimRight(string(content), "\n")
+}
+
+// imageAutomationControllerImage returns the versioned image of an image automation controller, or an empty string to
+// keep the upstream image of the toolkit components when the bundle doesn't ship it.
+func imageAutomationControllerImage(image releasev1alpha1.Image) string {
+	if image.URI == "" {
+		return ""
+	}
+	return image.VersionedImage()
+}
+
+// decryptionSecretName returns the name of the secret holding the keys the kustomize-controller decrypts with.
+// It's empty for an AWS KMS key without secret, which is decrypted with the IAM role of the controller.
+func decryptionSecretName(d *v1alpha1.FluxDecryptionConfig) string {
+	switch {
+	case d.Age != nil:
+		return d.Age.SecretName
+	case d.AwsKms != nil:
+		return d.AwsKms.SecretName
+	default:
+		return ""
+	}
+}
+
+// kustomizationAPIVersion returns the api version of the flux-system Kustomization, so it can be patched.
+// The OCI and bucket syncs are rendered by the CLI with v1beta2, needed to reference an OCIRepository source.
+// kustomizationDependencies returns the values to render the dependsOn of the Kustomization reconciling the eks-a
+// files, nil if it has no dependencies.
+func kustomizationDependencies(clusterSpec *cluster.Spec) []map[string]string {
+	dependsOn := clusterSpec.FluxConfig.Spec.DependsOn
+	if len(dependsOn) == 0 {
+		return nil
+	}
+	dependencies := make([]map[string]string, 0, len(dependsOn))
+	for _, d := range dependsOn {
+		dependencies = append(dependencies, map[string]string{
+			"Name":      d.Name,
+			"Namespace": d.Namespace,
+		})
+	}
+	return dependencies
+}
+
+func kustomizationAPIVersion(clusterSpec *cluster.Spec) string {
+	if clusterSpec.FluxConfig.Spec.OCIRepository != nil || clusterSpec.FluxConfig.Spec.Bucket != nil {
+		return "kustomize.toolkit.fluxcd.io/v1beta2"
+	}
+	return "kustomize.toolkit.fluxcd.io/v1beta1"
+}
+
+// sourceKind returns the kind of the flux-system source the cluster syncs from.
+func sourceKind(clusterSpec *cluster.Spec) string {
+	switch {
+	case clusterSpec.FluxConfig.Spec.OCIRepository != nil:
+		return "OCIRepository"
+	case clusterSpec.FluxConfig.Spec.Bucket != nil:
+		return "Bucket"
+	default:
+		return "GitRepository"
+	}
+}
+
+// WriteFluxReceiver writes a Flux notification Receiver for the flux-system source, so reconciliation
+// can be triggered by a webhook. It does nothing if no receiver is configured.
+func (g *FileGenerator) WriteFluxReceiver(clusterSpec *cluster.Spec) error {
+	receiver := clusterSpec.FluxConfig.Spec.Receiver
+	if receiver == nil {
+		return nil
+	}
+
+	values := map[string]interface{}{
+		"Name":       receiverName(clusterSpec.FluxConfig),
+		"Namespace":  clusterSpec.FluxConfig.Spec.SystemNamespace,
+		"Type":       receiver.Type,
+		"SecretName": receiver.SecretName,
+		"Events":     receiver.Events,
+		"SourceKind": sourceKind(clusterSpec),
+	}
+	if path, err := g.fluxTemplater.WriteToFile(fluxReceiverContent, values, fluxReceiverFileName, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("creating flux-system receiver manifest file into %s: %v", path, err)
+	}
+	return nil
+}
+
+// WriteFluxNotifications writes a Flux notification Provider and Alert for each notification in the flux config, so the
+// events of the flux-system Kustomization and source, which reconcile eksa-system, are sent to chat. It does nothing
+// if no notifications are configured.
+func (g *FileGenerator) WriteFluxNotifications(clusterSpec *cluster.Spec) error {
+	notifications := clusterSpec.FluxConfig.Spec.Notifications
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	values := map[string]interface{}{
+		"Namespace":     clusterSpec.FluxConfig.Spec.SystemNamespace,
+		"SourceKind":    sourceKind(clusterSpec),
+		"Notifications": notifications,
+	}
+	if path, err := g.fluxTemplater.WriteToFile(fluxNotificationsContent, values, fluxNotificationsFileName, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("creating flux-system notifications manifest file into %s: %v", path, err)
+	}
+	return nil
+}
+
+// WriteTenantFiles writes the tenant namespace, service account, RBAC and Kustomization of a workload cluster, along
+// with the kustomization listing them. The tenant Kustomization reconciles eksaSystemDir impersonating the service
+// account, which can only manage the EKS-A objects in the namespace of the cluster.
+func (g *FileGenerator) WriteTenantFiles(clusterSpec *cluster.Spec, eksaSystemDir string) error {
+	clusterNamespace := clusterSpec.Cluster.Namespace
+	if clusterNamespace == "" {
+		clusterNamespace = constants.DefaultNamespace
+	}
+
+	values := map[string]interface{}{
+		"Name":                    clusterSpec.Cluster.Name,
+		"TenantNamespace":         clusterSpec.Cluster.Name,
+		"RoleName":                tenantRolePrefix + clusterSpec.Cluster.Name,
+		"ClusterNamespace":        clusterNamespace,
+		"FluxNamespace":           clusterSpec.FluxConfig.Spec.SystemNamespace,
+		"EksaSystemDir":           eksaSystemDir,
+		"KustomizationAPIVersion": kustomizationAPIVersion(clusterSpec),
+		"HelmReleases":            len(clusterSpec.FluxConfig.Spec.HelmCharts) > 0,
+		"Prune":                   clusterSpec.FluxConfig.Spec.Sync.PruneEnabled(),
+	}
+	if path, err := g.tenantTemplater.WriteToFile(tenantContent, values, tenantFileName, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("creating tenant manifest file into %s: %v", path, err)
+	}
+
+	values = map[string]interface{}{
+		"TenantFileName": tenantFileName,
+	}
+	if path, err := g.tenantTemplater.WriteToFile(tenantKustomizeContent, values, kustomizeFileName, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("creating tenant kustomization manifest file into %s: %v", path, err)
+	}
+	return nil
+}
+
+// WriteSpokeFiles writes the Kustomization that reconciles the manifests directory of a workload cluster into the
+// workload cluster with its kubeconfig secret, along with the kustomization listing it and eksaSystemDir, and an empty
+// kustomization in the manifests directory for the workload manifests to be added to. The kustomization keeps the
+// flux-system Kustomization from applying the workload manifests to the management cluster.
+func (g *FileGenerator) WriteSpokeFiles(clusterSpec *cluster.Spec, eksaSystemDir string) error {
+	values := map[string]interface{}{
+		"Name":                    spokeKustomizationPrefix + clusterSpec.Cluster.Name,
+		"Namespace":               constants.EksaSystemNamespace,
+		"FluxNamespace":           clusterSpec.FluxConfig.Spec.SystemNamespace,
+		"ManifestsDir":            path.Join(path.Dir(eksaSystemDir), spokeManifestsDirName),
+		"KubeconfigSecretName":    clusterSpec.Cluster.Name + "-kubeconfig",
+		"KustomizationAPIVersion": kustomizationAPIVersion(clusterSpec),
+		"Prune":                   clusterSpec.FluxConfig.Spec.Sync.PruneEnabled(),
+	}
+	if path, err := g.spokeTemplater.WriteToFile(spokeContent, values, spokeFileName, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("creating spoke manifest file into %s: %v", path, err)
+	}
+
+	values = map[string]interface{}{
+		"EksaSystemDirName": path.Base(eksaSystemDir),
+		"SpokeFileName":     spokeFileName,
+	}
+	if path, err := g.spokeTemplater.WriteToFile(spokeKustomizeContent, values, kustomizeFileName, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("creating spoke kustomization manifest file into %s: %v", path, err)
+	}
+
+	if path, err := g.spokeManifestsTemplater.WriteToFile(spokeManifestsKustomizeContent, nil, kustomizeFileName, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("creating spoke manifests kustomization file into %s: %v", path, err)
+	}
+	return nil
+}
+
+// WriteAdoptedFluxSyncFiles writes the Kustomization that reconciles eksaSystemDir from the GitRepository of a flux
+// installation not bootstrapped by EKS-A, along with the kustomization listing it. They're written to the flux-system
+// directory instead of the flux-system files generated by the bootstrap.
+func (g *FileGenerator) WriteAdoptedFluxSyncFiles(clusterSpec *cluster.Spec, eksaSystemDir string) error {
+	values := map[string]interface{}{
+		"Name":          adoptedSyncPrefix + clusterSpec.Cluster.Name,
+		"Namespace":     clusterSpec.FluxConfig.Spec.SystemNamespace,
+		"EksaSystemDir": eksaSystemDir,
+		"DependsOn":     kustomizationDependencies(clusterSpec),
+	}
+	// Without a bootstrap there's no flux-system Kustomization patch, the Kustomization reconciling eksaSystemDir is
+	// the one impersonating the service account.
+	if m := clusterSpec.FluxConfig.Spec.MultiTenancy; m != nil {
+		values["ServiceAccountName"] = m.ServiceAccountName
+		values["TargetNamespace"] = m.TargetNamespace
+	}
+	addSyncValues(values, clusterSpec.FluxConfig.Spec.Sync)
+	if path, err := g.fluxTemplater.WriteToFile(adoptedSyncContent, values, adoptedSyncFileName, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("creating adopted flux sync manifest file into %s: %v", path, err)
+	}
+
+	values = map[string]interface{}{
+		"SyncFileName": adoptedSyncFileName,
+	}
+	if path, err := g.fluxTemplater.WriteToFile(adoptedKustomizeContent, values, kustomizeFileName, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("creating adopted flux kustomization manifest file into %s: %v", path, err)
+	}
+	return nil
+}
@@ -3,17 +3,24 @@ package flux_test
 import (
 	"context"
 	"errors"
+	"path"
 	"testing"
 
+	eksdv1alpha1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	writerMocks "github.com/aws/eks-anywhere/pkg/filewriter/mocks"
 	"github.com/aws/eks-anywhere/pkg/gitops/flux"
 	fluxMocks "github.com/aws/eks-anywhere/pkg/gitops/flux/mocks"
 	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
 var wantConfig = `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
@@ -82,7 +89,59 @@ spec:
 var wantEksaKustomization = `apiVersion: kustomize.config.k8s.io/v1beta1
 kind: Kustomization
 resources:
-- {{.ConfigFileName}}`
+- {{.ConfigFileName}}
+{{- if .HelmReleasesFileName }}
+- {{.HelmReleasesFileName}}
+{{- end }}
+{{- range .ReleaseManifests }}
+- {{.}}
+{{- end }}
+{{- range .Resources }}
+- {{.}}
+{{- end }}
+{{- if .CommonLabels }}
+commonLabels:
+{{- range $key, $value := .CommonLabels }}
+  {{$key}}: "{{$value}}"
+{{- end }}
+{{- end }}
+{{- if .CommonAnnotations }}
+commonAnnotations:
+{{- range $key, $value := .CommonAnnotations }}
+  {{$key}}: "{{$value}}"
+{{- end }}
+{{- end }}
+{{- if .Patches }}
+patches:
+{{- range .Patches }}
+- patch: |-
+{{ indent 4 .Patch }}
+{{- with .Target }}
+  target:
+    {{- if .Group }}
+    group: {{.Group}}
+    {{- end }}
+    {{- if .Version }}
+    version: {{.Version}}
+    {{- end }}
+    {{- if .Kind }}
+    kind: {{.Kind}}
+    {{- end }}
+    {{- if .Name }}
+    name: {{.Name}}
+    {{- end }}
+    {{- if .Namespace }}
+    namespace: {{.Namespace}}
+    {{- end }}
+    {{- if .LabelSelector }}
+    labelSelector: "{{.LabelSelector}}"
+    {{- end }}
+    {{- if .AnnotationSelector }}
+    annotationSelector: "{{.AnnotationSelector}}"
+    {{- end }}
+{{- end }}
+{{- end }}
+{{- end }}`
 
 var wantFluxKustomization = `apiVersion: kustomize.config.k8s.io/v1beta1
 kind: Kustomization
@@ -90,6 +149,21 @@ namespace: {{.Namespace}}
 resources:
   - gotk-components.yaml
   - gotk-sync.yaml
+{{- if .ReceiverFileName }}
+  - {{.ReceiverFileName}}
+{{- end }}
+{{- if .NotificationsFileName }}
+  - {{.NotificationsFileName}}
+{{- end }}
+{{- if .SourcesFileName }}
+  - {{.SourcesFileName}}
+{{- end }}
+{{- if .SealedSecretsFileName }}
+  - {{.SealedSecretsFileName}}
+{{- end }}
+{{- if .ExternalSecretFileName }}
+  - {{.ExternalSecretFileName}}
+{{- end }}
 patchesStrategicMerge:
   - gotk-patches.yaml`
 
@@ -104,6 +178,13 @@ spec:
       containers:
       - image: {{.SourceControllerImage}}
         name: manager
+{{- with .SourceControllerResources }}
+        resources:
+{{ indent 10 . }}
+{{- end }}
+{{- with .SourceControllerScheduling }}
+{{ indent 6 . }}
+{{- end }}
 ---
 apiVersion: apps/v1
 kind: Deployment
@@ -116,6 +197,14 @@ spec:
       containers:
       - image: {{.KustomizeControllerImage}}
         name: manager
+{{- with .KustomizeControllerResources }}
+        resources:
+{{ indent 10 . }}
+{{- end }}
+{{- with .KustomizeControllerScheduling }}
+{{ indent 6 . }}
+{{- end }}
+{{- if .HelmControllerImage }}
 ---
 apiVersion: apps/v1
 kind: Deployment
@@ -128,6 +217,15 @@ spec:
       containers:
       - image: {{.HelmControllerImage}}
         name: manager
+{{- with .HelmControllerResources }}
+        resources:
+{{ indent 10 . }}
+{{- end }}
+{{- with .HelmControllerScheduling }}
+{{ indent 6 . }}
+{{- end }}
+{{- end }}
+{{- if .NotificationControllerImage }}
 ---
 apiVersion: apps/v1
 kind: Deployment
@@ -139,14 +237,164 @@ spec:
     spec:
       containers:
       - image: {{.NotificationControllerImage}}
-        name: manager`
+        name: manager
+{{- with .NotificationControllerResources }}
+        resources:
+{{ indent 10 . }}
+{{- end }}
+{{- with .NotificationControllerScheduling }}
+{{ indent 6 . }}
+{{- end }}
+{{- end }}
+{{- if or .ImageReflectorControllerImage .ImageReflectorControllerResources .ImageReflectorControllerScheduling }}
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: image-reflector-controller
+  namespace: {{.Namespace}}
+spec:
+  template:
+    spec:
+      containers:
+{{- if .ImageReflectorControllerImage }}
+      - image: {{.ImageReflectorControllerImage}}
+        name: manager
+{{- else }}
+      - name: manager
+{{- end }}
+{{- with .ImageReflectorControllerResources }}
+        resources:
+{{ indent 10 . }}
+{{- end }}
+{{- with .ImageReflectorControllerScheduling }}
+{{ indent 6 . }}
+{{- end }}
+{{- end }}
+{{- if or .ImageAutomationControllerImage .ImageAutomationControllerResources .ImageAutomationControllerScheduling }}
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: image-automation-controller
+  namespace: {{.Namespace}}
+spec:
+  template:
+    spec:
+      containers:
+{{- if .ImageAutomationControllerImage }}
+      - image: {{.ImageAutomationControllerImage}}
+        name: manager
+{{- else }}
+      - name: manager
+{{- end }}
+{{- with .ImageAutomationControllerResources }}
+        resources:
+{{ indent 10 . }}
+{{- end }}
+{{- with .ImageAutomationControllerScheduling }}
+{{ indent 6 . }}
+{{- end }}
+{{- end }}
+{{- if .NamespacePatch }}
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: {{.Namespace}}
+{{- if .NamespaceLabels }}
+  labels:
+{{- range $key, $value := .NamespaceLabels }}
+    {{$key}}: "{{$value}}"
+{{- end }}
+{{- end }}
+{{- if .NamespaceAnnotations }}
+  annotations:
+{{- range $key, $value := .NamespaceAnnotations }}
+    {{$key}}: "{{$value}}"
+{{- end }}
+{{- end }}
+{{- end }}
+{{- if .GitRepositoryPatch }}
+---
+apiVersion: source.toolkit.fluxcd.io/v1beta1
+kind: GitRepository
+metadata:
+  name: {{.Namespace}}
+  namespace: {{.Namespace}}
+spec:
+{{- if .Interval }}
+  interval: {{.Interval}}
+{{- end }}
+{{- if .Timeout }}
+  timeout: {{.Timeout}}
+{{- end }}
+{{- end }}
+{{- if .KustomizationPatch }}
+---
+apiVersion: {{.KustomizationAPIVersion}}
+kind: Kustomization
+metadata:
+  name: {{.Namespace}}
+  namespace: {{.Namespace}}
+spec:
+{{- if .Interval }}
+  interval: {{.Interval}}
+{{- end }}
+{{- if .Timeout }}
+  timeout: {{.Timeout}}
+{{- end }}
+{{- if .RetryInterval }}
+  retryInterval: {{.RetryInterval}}
+{{- end }}
+{{- with .Prune }}
+  prune: {{.}}
+{{- end }}
+{{- if .HealthChecks }}
+  healthChecks:
+{{- range .HealthChecks }}
+  - kind: {{.Kind}}
+    name: {{.Name}}
+{{- if .APIVersion }}
+    apiVersion: {{.APIVersion}}
+{{- end }}
+{{- if .Namespace }}
+    namespace: {{.Namespace}}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- if .ServiceAccountName }}
+  serviceAccountName: {{.ServiceAccountName}}
+{{- end }}
+{{- if .TargetNamespace }}
+  targetNamespace: {{.TargetNamespace}}
+{{- end }}
+{{- if .DependsOn }}
+  dependsOn:
+{{- range .DependsOn }}
+  - name: {{.Name}}
+{{- if .Namespace }}
+    namespace: {{.Namespace}}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- if .DecryptionProvider }}
+  decryption:
+    provider: {{.DecryptionProvider}}
+{{- if .DecryptionSecretName }}
+    secretRef:
+      name: {{.DecryptionSecretName}}
+{{- end }}
+{{- end }}
+{{- end }}`
 
-var wantPatchesValues = map[string]string{
+var wantPatchesValues = map[string]interface{}{
 	"Namespace":                   "flux-system",
 	"SourceControllerImage":       "public.ecr.aws/l0g8r8j6/fluxcd/source-controller:v0.12.1-8539f509df046a4f567d2182dde824b957136599",
 	"KustomizeControllerImage":    "public.ecr.aws/l0g8r8j6/fluxcd/kustomize-controller:v0.11.1-d82011942ec8a447ba89a70ff9a84bf7b9579492",
 	"HelmControllerImage":         "public.ecr.aws/l0g8r8j6/fluxcd/helm-controller:v0.10.0-d82011942ec8a447ba89a70ff9a84bf7b9579492",
 	"NotificationControllerImage": "public.ecr.aws/l0g8r8j6/fluxcd/notification-controller:v0.13.0-d82011942ec8a447ba89a70ff9a84bf7b9579492",
+	"KustomizationAPIVersion":     "kustomize.toolkit.fluxcd.io/v1beta1",
 }
 
 type fileGeneratorTest struct {
@@ -209,11 +457,135 @@ func TestFileGeneratorWriteEksaFilesSuccess(t *testing.T) {
 	tt := newFileGeneratorTest(t)
 
 	tt.w.EXPECT().Write("eksa-cluster.yaml", []byte(wantConfig), gomock.Any()).Return("", nil)
-	tt.t.EXPECT().WriteToFile(wantEksaKustomization, map[string]string{"ConfigFileName": "eksa-cluster.yaml"}, "kustomization.yaml", gomock.Any()).Return("", nil)
+	tt.t.EXPECT().WriteToFile(wantEksaKustomization, map[string]interface{}{"ConfigFileName": "eksa-cluster.yaml"}, "kustomization.yaml", gomock.Any()).Return("", nil)
 
 	tt.Expect(tt.g.WriteEksaFiles(tt.clusterSpec, tt.datacenterConfig, tt.machineConfigs)).To(Succeed())
 }
 
+func TestFileGeneratorWriteEksaKustomizationWithOwner(t *testing.T) {
+	tt := newFileGeneratorTest(t)
+	tt.clusterSpec.Cluster.Labels = map[string]string{flux.OwnerLabel: "team-a"}
+	wantValues := map[string]interface{}{
+		"ConfigFileName":    "eksa-cluster.yaml",
+		"CommonAnnotations": map[string]string{"anywhere.eks.amazonaws.com/owner": "team-a"},
+	}
+
+	tt.t.EXPECT().WriteToFile(wantEksaKustomization, wantValues, "kustomization.yaml", gomock.Any()).Return("", nil)
+
+	tt.Expect(tt.g.WriteEksaKustomization(tt.clusterSpec)).To(Succeed())
+}
+
+func TestFileGeneratorWriteEksaKustomizationWithKustomizeContent(t *testing.T) {
+	g := NewWithT(t)
+	_, w := test.NewWriter(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.Cluster.Labels = map[string]string{flux.OwnerLabel: "team-a"}
+	clusterSpec.FluxConfig.Spec.EksaSystemKustomize = &v1alpha1.FluxKustomizeConfig{
+		Resources:         []string{"org-policies.yaml"},
+		CommonLabels:      map[string]string{"example.com/team": "platform"},
+		CommonAnnotations: map[string]string{"example.com/contact": "platform@example.com"},
+		Patches: []v1alpha1.FluxKustomizePatch{
+			{
+				Patch: "apiVersion: anywhere.eks.amazonaws.com/v1alpha1\nkind: Cluster\nmetadata:\n  name: management-cluster\n  labels:\n    env: prod\n",
+			},
+			{
+				Patch:  "- op: add\n  path: /metadata/labels/tier\n  value: gold\n",
+				Target: &v1alpha1.FluxKustomizePatchTarget{Kind: "Cluster", Name: "management-cluster"},
+			},
+		},
+	}
+
+	gen := flux.NewFileGenerator()
+	g.Expect(gen.Init(w, "eksa-system", "flux-system")).To(Succeed())
+	g.Expect(gen.WriteEksaKustomization(clusterSpec)).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "eksa-system", "kustomization.yaml"), "./testdata/kustomization-kustomize.yaml")
+}
+
+func TestFileGeneratorWriteEksaFilesWithHelmChartsContent(t *testing.T) {
+	g := NewWithT(t)
+	_, w := test.NewWriter(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.HelmCharts = []v1alpha1.FluxHelmChartConfig{
+		{
+			Name:          "metrics-server",
+			Version:       "3.8.2",
+			RepositoryUrl: "https://kubernetes-sigs.github.io/metrics-server",
+		},
+		{
+			Name:            "podinfo",
+			Chart:           "podinfo-chart",
+			RepositoryUrl:   "https://stefanprodan.github.io/podinfo",
+			TargetNamespace: "apps",
+		},
+	}
+
+	gen := flux.NewFileGenerator()
+	g.Expect(gen.Init(w, "eksa-system", "flux-system")).To(Succeed())
+	g.Expect(gen.WriteEksaFiles(clusterSpec, datacenterConfig("management-cluster"), []providers.MachineConfig{machineConfig("management-cluster")})).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "eksa-system", "helm-releases.yaml"), "./testdata/helm-releases.yaml")
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "eksa-system", "kustomization.yaml"), "./testdata/kustomization-helm-releases.yaml")
+}
+
+func TestFileGeneratorWriteEksaFilesWithReleaseManifests(t *testing.T) {
+	g := NewWithT(t)
+	_, w := test.NewWriter(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.CommitReleaseManifests = true
+	clusterSpec.Bundles = &releasev1alpha1.Bundles{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "bundles-1",
+			Namespace:       "eksa-system",
+			ResourceVersion: "1234",
+			UID:             "c2e5b4a8-2c0f-4b4e-9d0e-5a0b7f0e6a11",
+		},
+		Spec: releasev1alpha1.BundlesSpec{Number: 1},
+	}
+	cluster.WithEksdRelease(&eksdv1alpha1.Release{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "kubernetes-1-21-eks-4",
+			Namespace:  "eksa-system",
+			Generation: 2,
+		},
+		Spec: eksdv1alpha1.ReleaseSpec{Channel: "1-21", Number: 4},
+	})(clusterSpec)
+
+	gen := flux.NewFileGenerator()
+	g.Expect(gen.Init(w, "eksa-system", "flux-system")).To(Succeed())
+	g.Expect(gen.WriteEksaFiles(clusterSpec, datacenterConfig("management-cluster"), []providers.MachineConfig{machineConfig("management-cluster")})).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "eksa-system", "eksa-bundles.yaml"), "./testdata/eksa-bundles.yaml")
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "eksa-system", "eksd-release.yaml"), "./testdata/eksd-release.yaml")
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "eksa-system", "kustomization.yaml"), "./testdata/kustomization-release-manifests.yaml")
+}
+
+func TestFileGeneratorWriteReleaseManifestsSkip(t *testing.T) {
+	tt := newFileGeneratorTest(t)
+
+	tt.Expect(tt.g.WriteReleaseManifests(tt.clusterSpec)).To(Succeed())
+}
+
+func TestFileGeneratorWriteHelmReleasesError(t *testing.T) {
+	tt := newFileGeneratorTest(t)
+	tt.clusterSpec.FluxConfig.Spec.HelmCharts = []v1alpha1.FluxHelmChartConfig{
+		{
+			Name:          "podinfo",
+			RepositoryUrl: "https://stefanprodan.github.io/podinfo",
+		},
+	}
+
+	tt.t.EXPECT().WriteToFile(gomock.Any(), gomock.Any(), "helm-releases.yaml", gomock.Any()).Return("", errors.New("error in write helm releases"))
+
+	tt.Expect(tt.g.WriteHelmReleases(tt.clusterSpec)).To(MatchError(ContainSubstring("error in write helm releases")))
+}
+
+func TestFileGeneratorWriteHelmReleasesSkip(t *testing.T) {
+	tt := newFileGeneratorTest(t)
+
+	tt.Expect(tt.g.WriteHelmReleases(tt.clusterSpec)).To(Succeed())
+}
+
 func TestFileGeneratorWriteEksaFilesSkip(t *testing.T) {
 	tt := newFileGeneratorTest(t)
 
@@ -232,7 +604,7 @@ func TestFileGeneratorWriteEksaFilesWriteToFileError(t *testing.T) {
 	tt := newFileGeneratorTest(t)
 
 	tt.w.EXPECT().Write("eksa-cluster.yaml", []byte(wantConfig), gomock.Any()).Return("", nil)
-	tt.t.EXPECT().WriteToFile(wantEksaKustomization, map[string]string{"ConfigFileName": "eksa-cluster.yaml"}, "kustomization.yaml", gomock.Any()).Return("", errors.New("error in write to file"))
+	tt.t.EXPECT().WriteToFile(wantEksaKustomization, map[string]interface{}{"ConfigFileName": "eksa-cluster.yaml"}, "kustomization.yaml", gomock.Any()).Return("", errors.New("error in write to file"))
 
 	tt.Expect(tt.g.WriteEksaFiles(tt.clusterSpec, tt.datacenterConfig, tt.machineConfigs)).To(MatchError(ContainSubstring("error in write to file")))
 }
@@ -273,3 +645,462 @@ func TestFileGeneratorWriteFluxSystemFilesWriteFluxPatchesError(t *testing.T) {
 
 	tt.Expect(tt.g.WriteFluxSystemFiles(tt.clusterSpec)).To(MatchError(ContainSubstring("error in write patches")))
 }
+
+func TestFileGeneratorWriteFluxPatchWithMultiTenancyContent(t *testing.T) {
+	g := NewWithT(t)
+	_, w := test.NewWriter(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.MultiTenancy = &v1alpha1.FluxMultiTenancyConfig{
+		ClusterDomain:      "cluster.internal",
+		ServiceAccountName: "tenant-reconciler",
+		TargetNamespace:    "tenant-a",
+	}
+
+	gen := flux.NewFileGenerator()
+	g.Expect(gen.Init(w, "eksa-system", "flux-system")).To(Succeed())
+	g.Expect(gen.WriteFluxPatch(clusterSpec)).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "flux-system", "gotk-patches.yaml"), "./testdata/gotk-patches-multi-tenancy.yaml")
+}
+
+func TestFileGeneratorWriteFluxPatchWithSystemNamespaceMetadata(t *testing.T) {
+	g := NewWithT(t)
+	_, w := test.NewWriter(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.SystemNamespaceMetadata = &v1alpha1.FluxNamespaceMetadata{
+		Labels:      map[string]string{"pod-security.kubernetes.io/enforce": "restricted"},
+		Annotations: map[string]string{"example.com/cost-center": "platform"},
+	}
+
+	gen := flux.NewFileGenerator()
+	g.Expect(gen.Init(w, "eksa-system", "flux-system")).To(Succeed())
+	g.Expect(gen.WriteFluxPatch(clusterSpec)).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "flux-system", "gotk-patches.yaml"), "./testdata/gotk-patches-namespace-metadata.yaml")
+}
+
+func TestFileGeneratorWriteFluxPatchWithDependsOnContent(t *testing.T) {
+	g := NewWithT(t)
+	_, w := test.NewWriter(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.DependsOn = []v1alpha1.FluxKustomizationDependency{
+		{Name: "infrastructure"},
+		{Name: "crds", Namespace: "platform"},
+	}
+
+	gen := flux.NewFileGenerator()
+	g.Expect(gen.Init(w, "eksa-system", "flux-system")).To(Succeed())
+	g.Expect(gen.WriteFluxPatch(clusterSpec)).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "flux-system", "gotk-patches.yaml"), "./testdata/gotk-patches-depends-on.yaml")
+}
+
+func TestFileGeneratorWriteFluxPatchWithDecryptionContent(t *testing.T) {
+	tests := []struct {
+		testName   string
+		decryption *v1alpha1.FluxDecryptionConfig
+		wantFile   string
+	}{
+		{
+			testName: "age",
+			decryption: &v1alpha1.FluxDecryptionConfig{
+				Provider: "sops",
+				Age:      &v1alpha1.FluxAgeDecryptionConfig{SecretName: "sops-age"},
+			},
+			wantFile: "./testdata/gotk-patches-decryption-age.yaml",
+		},
+		{
+			testName: "aws kms without secret",
+			decryption: &v1alpha1.FluxDecryptionConfig{
+				Provider: "sops",
+				AwsKms:   &v1alpha1.FluxAwsKmsDecryptionConfig{},
+			},
+			wantFile: "./testdata/gotk-patches-decryption-kms.yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			_, w := test.NewWriter(t)
+			clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+			clusterSpec.FluxConfig.Spec.Decryption = tt.decryption
+
+			gen := flux.NewFileGenerator()
+			g.Expect(gen.Init(w, "eksa-system", "flux-system")).To(Succeed())
+			g.Expect(gen.WriteFluxPatch(clusterSpec)).To(Succeed())
+
+			test.AssertFilesEquals(t, path.Join(w.Dir(), "flux-system", "gotk-patches.yaml"), tt.wantFile)
+		})
+	}
+}
+
+func TestFileGeneratorWriteFluxPatchWithClusterDomainOnly(t *testing.T) {
+	tt := newFileGeneratorTest(t)
+	tt.clusterSpec.FluxConfig.Spec.MultiTenancy = &v1alpha1.FluxMultiTenancyConfig{
+		ClusterDomain: "cluster.internal",
+	}
+
+	tt.t.EXPECT().WriteToFile(wantFluxPatches, wantPatchesValues, "gotk-patches.yaml", gomock.Any()).Return("", nil)
+
+	tt.Expect(tt.g.WriteFluxPatch(tt.clusterSpec)).To(Succeed())
+}
+
+func TestFileGeneratorWriteFluxSystemFilesWithReceiver(t *testing.T) {
+	tt := newFileGeneratorTest(t)
+	tt.clusterSpec.FluxConfig.Spec.Receiver = &v1alpha1.FluxReceiverConfig{
+		Type:       "github",
+		SecretName: "webhook-token",
+		Events:     []string{"ping", "push"},
+	}
+	wantReceiverValues := map[string]interface{}{
+		"Name":       "flux-system",
+		"Namespace":  "flux-system",
+		"Type":       "github",
+		"SecretName": "webhook-token",
+		"Events":     []string{"ping", "push"},
+		"SourceKind": "GitRepository",
+	}
+
+	tt.t.EXPECT().WriteToFile(wantFluxKustomization, map[string]string{"Namespace": "flux-system", "ReceiverFileName": "gotk-receiver.yaml"}, "kustomization.yaml", gomock.Any()).Return("", nil)
+	tt.t.EXPECT().WriteToFile("", nil, "gotk-sync.yaml", gomock.Any()).Return("", nil)
+	tt.t.EXPECT().WriteToFile(wantFluxPatches, wantPatchesValues, "gotk-patches.yaml", gomock.Any()).Return("", nil)
+	tt.t.EXPECT().WriteToFile(gomock.Any(), wantReceiverValues, "gotk-receiver.yaml", gomock.Any()).Return("", nil)
+
+	tt.Expect(tt.g.WriteFluxSystemFiles(tt.clusterSpec)).To(Succeed())
+}
+
+func TestFileGeneratorWriteFluxReceiverError(t *testing.T) {
+	tt := newFileGeneratorTest(t)
+	tt.clusterSpec.FluxConfig.Spec.Receiver = &v1alpha1.FluxReceiverConfig{
+		Type:       "generic",
+		SecretName: "webhook-token",
+	}
+
+	tt.t.EXPECT().WriteToFile(gomock.Any(), gomock.Any(), "gotk-receiver.yaml", gomock.Any()).Return("", errors.New("error in write receiver"))
+
+	tt.Expect(tt.g.WriteFluxReceiver(tt.clusterSpec)).To(MatchError(ContainSubstring("error in write receiver")))
+}
+
+func TestFileGeneratorWriteFluxReceiverSkip(t *testing.T) {
+	tt := newFileGeneratorTest(t)
+
+	tt.Expect(tt.g.WriteFluxReceiver(tt.clusterSpec)).To(Succeed())
+}
+
+func TestFileGeneratorWriteFluxSystemFilesWithNotificationsContent(t *testing.T) {
+	g := NewWithT(t)
+	_, w := test.NewWriter(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.Notifications = []v1alpha1.FluxNotificationConfig{
+		{Name: "slack", Type: "slack", Channel: "eksa-alerts", SecretName: "slack-url", EventSeverity: "error"},
+		{Name: "teams", Type: "msteams", SecretName: "teams-url", EventSeverity: "info"},
+	}
+
+	gen := flux.NewFileGenerator()
+	g.Expect(gen.Init(w, "eksa-system", "flux-system")).To(Succeed())
+	g.Expect(gen.WriteFluxSystemFiles(clusterSpec)).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "flux-system", "gotk-notifications.yaml"), "./testdata/gotk-notifications.yaml")
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "flux-system", "kustomization.yaml"), "./testdata/flux-kustomization-notifications.yaml")
+}
+
+func TestFileGeneratorWriteFluxNotificationsError(t *testing.T) {
+	tt := newFileGeneratorTest(t)
+	tt.clusterSpec.FluxConfig.Spec.Notifications = []v1alpha1.FluxNotificationConfig{
+		{Name: "slack", Type: "slack", SecretName: "slack-url", EventSeverity: "error"},
+	}
+
+	tt.t.EXPECT().WriteToFile(gomock.Any(), gomock.Any(), "gotk-notifications.yaml", gomock.Any()).Return("", errors.New("error in write notifications"))
+
+	tt.Expect(tt.g.WriteFluxNotifications(tt.clusterSpec)).To(MatchError(ContainSubstring("error in write notifications")))
+}
+
+func TestFileGeneratorWriteFluxNotificationsSkip(t *testing.T) {
+	tt := newFileGeneratorTest(t)
+
+	tt.Expect(tt.g.WriteFluxNotifications(tt.clusterSpec)).To(Succeed())
+}
+
+func TestFileGeneratorWriteFluxPatchWithImageAutomationContent(t *testing.T) {
+	tests := []struct {
+		testName   string
+		fluxBundle func(*releasev1alpha1.FluxBundle)
+		wantFile   string
+	}{
+		{
+			testName: "images in bundle",
+			fluxBundle: func(b *releasev1alpha1.FluxBundle) {
+				b.ImageReflectorController = releasev1alpha1.Image{URI: "public.ecr.aws/l0g8r8j6/fluxcd/image-reflector-controller:v0.22.1-eks-a-v0.0.0-dev-build.1"}
+				b.ImageAutomationController = releasev1alpha1.Image{URI: "public.ecr.aws/l0g8r8j6/fluxcd/image-automation-controller:v0.26.1-eks-a-v0.0.0-dev-build.1"}
+			},
+			wantFile: "./testdata/gotk-patches-image-automation.yaml",
+		},
+		{
+			testName:   "images not in bundle",
+			fluxBundle: func(b *releasev1alpha1.FluxBundle) {},
+			wantFile:   "./testdata/gotk-patches.yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			_, w := test.NewWriter(t)
+			clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+			clusterSpec.FluxConfig.Spec.ImageAutomation = true
+			tt.fluxBundle(&clusterSpec.VersionsBundle.Flux)
+
+			gen := flux.NewFileGenerator()
+			g.Expect(gen.Init(w, "eksa-system", "flux-system")).To(Succeed())
+			g.Expect(gen.WriteFluxPatch(clusterSpec)).To(Succeed())
+
+			test.AssertFilesEquals(t, path.Join(w.Dir(), "flux-system", "gotk-patches.yaml"), tt.wantFile)
+		})
+	}
+}
+
+func TestFileGeneratorWriteTenantFilesContent(t *testing.T) {
+	tests := []struct {
+		testName   string
+		helmCharts []v1alpha1.FluxHelmChartConfig
+		sync       *v1alpha1.FluxSyncConfig
+		wantFile   string
+	}{
+		{
+			testName: "without helm charts",
+			wantFile: "./testdata/eksa-tenant.yaml",
+		},
+		{
+			testName:   "with helm charts",
+			helmCharts: []v1alpha1.FluxHelmChartConfig{{Name: "podinfo", RepositoryUrl: "https://stefanprodan.github.io/podinfo"}},
+			wantFile:   "./testdata/eksa-tenant-helm-releases.yaml",
+		},
+		{
+			testName: "prune disabled",
+			sync:     &v1alpha1.FluxSyncConfig{Prune: ptr.Bool(false)},
+			wantFile: "./testdata/eksa-tenant-prune-disabled.yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			_, w := test.NewWriter(t)
+			clusterConfig := v1alpha1.NewCluster("workload-cluster")
+			clusterConfig.SetManagedBy("management-cluster")
+			clusterSpec := newClusterSpec(t, clusterConfig, "")
+			clusterSpec.FluxConfig.Spec.HelmCharts = tt.helmCharts
+			clusterSpec.FluxConfig.Spec.Sync = tt.sync
+			eksaSystemDir := "clusters/management-cluster/workload-cluster/eksa-system"
+
+			gen := flux.NewFileGenerator()
+			g.Expect(gen.InitTenant(w, path.Dir(eksaSystemDir))).To(Succeed())
+			g.Expect(gen.WriteTenantFiles(clusterSpec, eksaSystemDir)).To(Succeed())
+
+			test.AssertFilesEquals(t, path.Join(w.Dir(), path.Dir(eksaSystemDir), "eksa-tenant.yaml"), tt.wantFile)
+			test.AssertFilesEquals(t, path.Join(w.Dir(), path.Dir(eksaSystemDir), "kustomization.yaml"), "./testdata/tenant-kustomization.yaml")
+		})
+	}
+}
+
+func TestFileGeneratorWriteFluxPatchWithSyncContent(t *testing.T) {
+	g := NewWithT(t)
+	_, w := test.NewWriter(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.Sync = &v1alpha1.FluxSyncConfig{
+		Interval:      "10m0s",
+		Timeout:       "2m0s",
+		RetryInterval: "5m0s",
+	}
+
+	gen := flux.NewFileGenerator()
+	g.Expect(gen.Init(w, "eksa-system", "flux-system")).To(Succeed())
+	g.Expect(gen.WriteFluxPatch(clusterSpec)).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "flux-system", "gotk-patches.yaml"), "./testdata/gotk-patches-sync.yaml")
+}
+
+func TestFileGeneratorWriteFluxPatchWithEmptySync(t *testing.T) {
+	g := NewWithT(t)
+	_, w := test.NewWriter(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.Sync = &v1alpha1.FluxSyncConfig{}
+
+	gen := flux.NewFileGenerator()
+	g.Expect(gen.Init(w, "eksa-system", "flux-system")).To(Succeed())
+	g.Expect(gen.WriteFluxPatch(clusterSpec)).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "flux-system", "gotk-patches.yaml"), "./testdata/gotk-patches.yaml")
+}
+
+func TestFileGeneratorWriteFluxPatchWithPruneDisabled(t *testing.T) {
+	g := NewWithT(t)
+	_, w := test.NewWriter(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.Sync = &v1alpha1.FluxSyncConfig{Prune: ptr.Bool(false)}
+
+	gen := flux.NewFileGenerator()
+	g.Expect(gen.Init(w, "eksa-system", "flux-system")).To(Succeed())
+	g.Expect(gen.WriteFluxPatch(clusterSpec)).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "flux-system", "gotk-patches.yaml"), "./testdata/gotk-patches-prune-disabled.yaml")
+}
+
+func TestFileGeneratorWriteFluxPatchWithHealthChecks(t *testing.T) {
+	g := NewWithT(t)
+	_, w := test.NewWriter(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.Sync = &v1alpha1.FluxSyncConfig{
+		HealthChecks: []v1alpha1.FluxHealthCheck{
+			{APIVersion: "anywhere.eks.amazonaws.com/v1alpha1", Kind: "Cluster", Name: "management-cluster", Namespace: "default"},
+			{Kind: "Deployment", Name: "eksa-controller-manager"},
+		},
+	}
+
+	gen := flux.NewFileGenerator()
+	g.Expect(gen.Init(w, "eksa-system", "flux-system")).To(Succeed())
+	g.Expect(gen.WriteFluxPatch(clusterSpec)).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "flux-system", "gotk-patches.yaml"), "./testdata/gotk-patches-health-checks.yaml")
+}
+
+func TestFileGeneratorWriteFluxBucketSyncWithSyncContent(t *testing.T) {
+	g := NewWithT(t)
+	_, w := test.NewWriter(t)
+	clusterSpec := newBucketClusterSpec(t, v1alpha1.NewCluster("management-cluster"))
+	clusterSpec.FluxConfig.Spec.Sync = &v1alpha1.FluxSyncConfig{
+		Interval:      "15m0s",
+		Timeout:       "3m0s",
+		RetryInterval: "1m0s",
+	}
+
+	gen := flux.NewFileGenerator()
+	g.Expect(gen.Init(w, "eksa-system", "flux-system")).To(Succeed())
+	g.Expect(gen.WriteFluxBucketSync(clusterSpec)).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "flux-system", "gotk-sync.yaml"), "./testdata/gotk-sync-bucket-sync.yaml")
+}
+
+func TestFileGeneratorWriteFluxBucketSyncWithHealthChecks(t *testing.T) {
+	g := NewWithT(t)
+	_, w := test.NewWriter(t)
+	clusterSpec := newBucketClusterSpec(t, v1alpha1.NewCluster("management-cluster"))
+	clusterSpec.FluxConfig.Spec.Sync = &v1alpha1.FluxSyncConfig{
+		Wait: true,
+		HealthChecks: []v1alpha1.FluxHealthCheck{
+			{APIVersion: "anywhere.eks.amazonaws.com/v1alpha1", Kind: "Cluster", Name: "management-cluster", Namespace: "default"},
+		},
+	}
+
+	gen := flux.NewFileGenerator()
+	g.Expect(gen.Init(w, "eksa-system", "flux-system")).To(Succeed())
+	g.Expect(gen.WriteFluxBucketSync(clusterSpec)).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "flux-system", "gotk-sync.yaml"), "./testdata/gotk-sync-bucket-health-checks.yaml")
+}
+
+func TestFileGeneratorWriteFluxBucketSyncWithPruneDisabled(t *testing.T) {
+	g := NewWithT(t)
+	_, w := test.NewWriter(t)
+	clusterSpec := newBucketClusterSpec(t, v1alpha1.NewCluster("management-cluster"))
+	clusterSpec.FluxConfig.Spec.Sync = &v1alpha1.FluxSyncConfig{Prune: ptr.Bool(false)}
+
+	gen := flux.NewFileGenerator()
+	g.Expect(gen.Init(w, "eksa-system", "flux-system")).To(Succeed())
+	g.Expect(gen.WriteFluxBucketSync(clusterSpec)).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "flux-system", "gotk-sync.yaml"), "./testdata/gotk-sync-bucket-prune-disabled.yaml")
+}
+
+func TestFileGeneratorWriteFluxPatchWithComponentsContent(t *testing.T) {
+	g := NewWithT(t)
+	_, w := test.NewWriter(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.Components = []string{"source-controller", "kustomize-controller"}
+
+	gen := flux.NewFileGenerator()
+	g.Expect(gen.Init(w, "eksa-system", "flux-system")).To(Succeed())
+	g.Expect(gen.WriteFluxPatch(clusterSpec)).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "flux-system", "gotk-patches.yaml"), "./testdata/gotk-patches-components.yaml")
+}
+
+func TestFileGeneratorWriteFluxPatchWithRegistryMirror(t *testing.T) {
+	g := NewWithT(t)
+	_, w := test.NewWriter(t)
+	clusterConfig := v1alpha1.NewCluster("management-cluster")
+	clusterConfig.Spec.RegistryMirrorConfiguration = &v1alpha1.RegistryMirrorConfiguration{
+		Endpoint: "1.2.3.4",
+		Port:     "443",
+	}
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+
+	gen := flux.NewFileGenerator()
+	g.Expect(gen.Init(w, "eksa-system", "flux-system")).To(Succeed())
+	g.Expect(gen.WriteFluxPatch(clusterSpec)).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "flux-system", "gotk-patches.yaml"), "./testdata/gotk-patches-registry-mirror.yaml")
+}
+
+func TestFileGeneratorWriteReleaseManifestsWorkloadCluster(t *testing.T) {
+	g := NewWithT(t)
+	_, w := test.NewWriter(t)
+	clusterConfig := v1alpha1.NewCluster("workload-cluster")
+	clusterConfig.SetManagedBy("management-cluster")
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+	clusterSpec.FluxConfig.Spec.CommitReleaseManifests = true
+	clusterSpec.Bundles = &releasev1alpha1.Bundles{ObjectMeta: metav1.ObjectMeta{Name: "bundles-1"}}
+
+	gen := flux.NewFileGenerator()
+	g.Expect(gen.Init(w, "eksa-system", "flux-system")).To(Succeed())
+	g.Expect(gen.WriteReleaseManifests(clusterSpec)).To(Succeed())
+
+	g.Expect(path.Join(w.Dir(), "eksa-system", "eksa-bundles.yaml")).NotTo(BeAnExistingFile())
+}
+
+func TestFileGeneratorWriteFluxPatchWithControllerResources(t *testing.T) {
+	g := NewWithT(t)
+	_, w := test.NewWriter(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.ImageAutomation = true
+	clusterSpec.FluxConfig.Spec.ControllerResources = map[string]v1alpha1.FluxResourceRequirements{
+		"source-controller": {
+			Requests: v1alpha1.FluxResourceList{CPU: "50m", Memory: "64Mi"},
+			Limits:   v1alpha1.FluxResourceList{Memory: "256Mi"},
+		},
+		"kustomize-controller": {
+			Requests: v1alpha1.FluxResourceList{CPU: "1", Memory: "1Gi"},
+			Limits:   v1alpha1.FluxResourceList{CPU: "4", Memory: "4Gi"},
+		},
+		"image-reflector-controller": {
+			Limits: v1alpha1.FluxResourceList{Memory: "128Mi"},
+		},
+	}
+
+	gen := flux.NewFileGenerator()
+	g.Expect(gen.Init(w, "eksa-system", "flux-system")).To(Succeed())
+	g.Expect(gen.WriteFluxPatch(clusterSpec)).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "flux-system", "gotk-patches.yaml"), "./testdata/gotk-patches-controller-resources.yaml")
+}
+
+func TestFileGeneratorWriteFluxPatchWithControllerScheduling(t *testing.T) {
+	g := NewWithT(t)
+	_, w := test.NewWriter(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.Components = []string{v1alpha1.FluxSourceController, v1alpha1.FluxKustomizeController}
+	clusterSpec.FluxConfig.Spec.ComponentsExtra = []string{v1alpha1.FluxImageReflectorController}
+	clusterSpec.FluxConfig.Spec.ControllerScheduling = &v1alpha1.FluxSchedulingConfig{
+		NodeSelector: map[string]string{"node-role.kubernetes.io/control-plane": ""},
+		Tolerations: []corev1.Toleration{
+			{Key: "node-role.kubernetes.io/control-plane", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+		},
+	}
+
+	gen := flux.NewFileGenerator()
+	g.Expect(gen.Init(w, "eksa-system", "flux-system")).To(Succeed())
+	g.Expect(gen.WriteFluxPatch(clusterSpec)).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "flux-system", "gotk-patches.yaml"), "./testdata/gotk-patches-controller-scheduling.yaml")
+}
@@ -2,8 +2,13 @@ package flux
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
@@ -19,14 +24,17 @@ import (
 
 const (
 	defaultRemote = "origin"
-
-	initialClusterconfigCommitMessage = "Initial commit of cluster configuration; generated by EKS-A CLI"
-	updateClusterconfigCommitMessage  = "Update commit of cluster configuration; generated by EKS-A CLI"
-	deleteClusterconfigCommitMessage  = "Delete commit of cluster configuration; generated by EKS-A CLI"
+	// maxPushRebaseAttempts is how many times a rejected push is rebased on the remote branch and pushed again.
+	maxPushRebaseAttempts = 5
 )
 
 type GitOpsFluxClient interface {
 	BootstrapGithub(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
+	BootstrapGitlab(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
+	BootstrapBitbucketServer(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
+	BootstrapAzureDevOps(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
+	BootstrapGitea(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
+	BootstrapCodeCommit(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
 	BootstrapGit(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig, cliConfig *config.CliConfig) error
 	Uninstall(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
 	GetCluster(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) (eksaCluster *v1alpha1.Cluster, err error)
@@ -35,11 +43,28 @@ type GitOpsFluxClient interface {
 	Reconcile(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
 	ForceReconcile(ctx context.Context, cluster *types.Cluster, namespace string) error
 	DeleteSystemSecret(ctx context.Context, cluster *types.Cluster, namespace string) error
+	SetGitRepositoryBranch(ctx context.Context, cluster *types.Cluster, namespace, branch string) error
+	SetKustomizationPath(ctx context.Context, cluster *types.Cluster, namespace, path string) error
+	DryRunApplyKustomization(ctx context.Context, cluster *types.Cluster, dir string) error
+	ApplyKustomization(ctx context.Context, cluster *types.Cluster, dir string) error
+	InstallComponents(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
+	ExportComponents(ctx context.Context, fluxConfig *v1alpha1.FluxConfig) ([]byte, error)
+	PushArtifact(ctx context.Context, fluxConfig *v1alpha1.FluxConfig, dir string) error
+	PullArtifact(ctx context.Context, fluxConfig *v1alpha1.FluxConfig, dir string) error
+	GetDeployment(ctx context.Context, cluster *types.Cluster, name, namespace string) (*appsv1.Deployment, error)
+	ApplySecret(ctx context.Context, cluster *types.Cluster, name, namespace string, data map[string]string) error
+	ApplyNamespace(ctx context.Context, cluster *types.Cluster, name string, labels, annotations map[string]string) error
+	GetObject(ctx context.Context, cluster *types.Cluster, resourceType, name, namespace string, obj runtime.Object) error
 }
 
 type GitClient interface {
 	GetRepo(ctx context.Context) (repo *git.Repository, err error)
 	CreateRepo(ctx context.Context, opts git.CreateRepoOpts) error
+	CreatePullRequest(ctx context.Context, opts git.CreatePullRequestOpts) (*git.PullRequest, error)
+	CreateWebhook(ctx context.Context, opts git.CreateWebhookOpts) error
+	WebhookExists(ctx context.Context, url string) (exists bool, err error)
+	DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts) error
+	ArchiveRepo(ctx context.Context, opts git.ArchiveRepoOpts) error
 	Clone(ctx context.Context) error
 	Push(ctx context.Context) error
 	Pull(ctx context.Context, branch string) error
@@ -48,37 +73,142 @@ type GitClient interface {
 	Remove(filename string) error
 	Commit(message string) error
 	Branch(name string) error
+	FetchAndReset(ctx context.Context, branch string) error
 	Init() error
+	ValidateProvider(ctx context.Context) error
+	ValidateProviderPermissions(ctx context.Context) error
+	ValidatePushAccess(ctx context.Context) error
+	ValidateBranchPush(ctx context.Context, branch string) error
+	ValidateRemoteExists(ctx context.Context) error
+	RemoteBranchExists(ctx context.Context, branch string) (exists bool, err error)
+	RemoteBranches(ctx context.Context) (branches []string, err error)
+	LastCommit() (*git.Commit, error)
+	AmendCommit(message string) error
+	ForcePush(ctx context.Context, lease string) error
+	ResetSoft(commit string) error
+	DeleteRemoteBranch(ctx context.Context, branch string) error
+	PushToBranch(ctx context.Context, branch string) error
+	Tag(name, message string) error
+	PushTag(ctx context.Context, name string) error
+	SetSparseCheckoutDirectories(dirs ...string)
+	RebaseOnRemote(ctx context.Context) error
+	LocalChanges(ctx context.Context, branch string) (*git.LocalChanges, error)
+	StagedFiles() ([]string, error)
+}
+
+// BucketClient uploads the cluster manifests to the bucket flux syncs from.
+type BucketClient interface {
+	Upload(ctx context.Context, dir, prefix string) error
+	Delete(ctx context.Context, prefix string) error
 }
 
 type Flux struct {
-	fluxClient GitOpsFluxClient
-	gitClient  GitClient
-	writer     filewriter.FileWriter
-	cliConfig  *config.CliConfig
+	fluxClient    GitOpsFluxClient
+	gitClient     GitClient
+	bucketClient  BucketClient
+	writer        filewriter.FileWriter
+	cliConfig     *config.CliConfig
+	maxFileSize   int64
+	maxCommitSize int64
+	// verifyManifestsBeforeBootstrap enables a server-side dry-run of the committed eks-a manifests before bootstrapping flux.
+	verifyManifestsBeforeBootstrap bool
+	// updateSquashWindow enables amending the previous update commit when it's more recent than the window.
+	updateSquashWindow time.Duration
+	// verifyCommittedClusterConfig enables checking the committed cluster config parses back to the cluster spec.
+	verifyCommittedClusterConfig bool
+	// commitMessageTemplate and commitTrailers customize the messages of the cluster config commits.
+	commitMessageTemplate string
+	commitTrailers        []string
+	// upgradeTags enables tagging the commit of the upgraded cluster config.
+	upgradeTags bool
+	// changelog enables appending the operations to a changelog file in the directory of the cluster.
+	changelog bool
+	// repositoryCache enables reusing the local repository left by previous operations, fetching and resetting it
+	// to the remote branch instead of pulling it.
+	repositoryCache bool
+	// localChangesPolicy is how the changes of the local repository missing in the remote branch are handled
+	// before updating it. Empty doesn't check them.
+	localChangesPolicy LocalChangesPolicy
+	// revertOnBootstrapFailure enables removing the pushed cluster config when flux fails to bootstrap.
+	revertOnBootstrapFailure bool
+	// installCheckpoint enables recording the completed phases of InstallGitOps to resume it on a re-run.
+	installCheckpoint bool
+	// commitExcludePatterns are gitignore-style patterns for files that are never staged.
+	commitExcludePatterns []string
+	// pullRequestBranchPrefix enables pushing cluster config changes to a new branch with this prefix and opening a pull request.
+	pullRequestBranchPrefix string
+	// pullRequestFallback enables opening a pull request when the sync branch doesn't accept direct pushes.
+	pullRequestFallback bool
+	// stagingBranch enables pushing the initial cluster config to a staging branch until flux is bootstrapped.
+	stagingBranch bool
+	// adoptExistingFlux enables adopting a flux already installed in the cluster instead of bootstrapping it.
+	adoptExistingFlux bool
+	// gitRepoCleanup is how the repository of a management cluster is cleaned up when the cluster is deleted.
+	gitRepoCleanup GitRepoCleanup
+	// sparseCheckout enables only checking out the cluster config path in the local repository.
+	sparseCheckout bool
+	// controllersRolloutTimeout is how long Upgrade waits for the flux controllers to run the images of the new bundle.
+	controllersRolloutTimeout time.Duration
+	// reconcileWaitTimeout enables ForceReconcileGitRepo to wait up to this timeout for the new revision to be applied.
+	reconcileWaitTimeout time.Duration
+	// imageChecker enables checking the flux images can be pulled from the registry mirror before installing flux.
+	imageChecker ImageChecker
 }
 
-func NewFlux(fluxClient FluxClient, kubeClient KubeClient, gitTools *gitFactory.GitTools, cliConfig *config.CliConfig) *Flux {
+// Opt allows to customize the Flux instance.
+type Opt func(*Flux)
+
+func NewFlux(fluxClient FluxClient, kubeClient KubeClient, gitTools *gitFactory.GitTools, cliConfig *config.CliConfig, opts ...Opt) *Flux {
 	var w filewriter.FileWriter
 	if gitTools != nil {
 		w = gitTools.Writer
+		cliConfig = withKnownHostsFile(cliConfig, gitTools.KnownHostsFile)
+	}
+
+	r := newRetrier(cliConfig)
+	c := newFluxClient(fluxClient, kubeClient)
+	c.Retrier = r
+	g := newGitClient(gitTools)
+	if g == nil {
+		return newFlux(c, nil, w, cliConfig, opts...)
 	}
+	g.Retrier = r
 
-	return &Flux{
-		fluxClient: newFluxClient(fluxClient, kubeClient),
-		gitClient:  newGitClient(gitTools),
-		writer:     w,
-		cliConfig:  cliConfig,
+	return newFlux(c, g, w, cliConfig, opts...)
+}
+
+// withKnownHostsFile returns a copy of the cli config with the pinned known hosts file, so flux is bootstrapped
+// with the same known hosts the git client verifies the host with.
+func withKnownHostsFile(cliConfig *config.CliConfig, knownHostsFile string) *config.CliConfig {
+	if knownHostsFile == "" {
+		return cliConfig
+	}
+
+	c := &config.CliConfig{}
+	if cliConfig != nil {
+		*c = *cliConfig
 	}
+	c.GitKnownHostsFile = knownHostsFile
+	return c
 }
 
-func NewFluxFromGitOpsFluxClient(fluxClient GitOpsFluxClient, gitClient GitClient, writer filewriter.FileWriter, cliConfig *config.CliConfig) *Flux {
-	return &Flux{
-		fluxClient: fluxClient,
-		gitClient:  gitClient,
-		writer:     writer,
-		cliConfig:  cliConfig,
+func NewFluxFromGitOpsFluxClient(fluxClient GitOpsFluxClient, gitClient GitClient, writer filewriter.FileWriter, cliConfig *config.CliConfig, opts ...Opt) *Flux {
+	return newFlux(fluxClient, gitClient, writer, cliConfig, opts...)
+}
+
+func newFlux(fluxClient GitOpsFluxClient, gitClient GitClient, writer filewriter.FileWriter, cliConfig *config.CliConfig, opts ...Opt) *Flux {
+	f := &Flux{
+		fluxClient:    fluxClient,
+		gitClient:     gitClient,
+		writer:        writer,
+		cliConfig:     cliConfig,
+		maxFileSize:   DefaultMaxFileSize,
+		maxCommitSize: DefaultMaxCommitSize,
+	}
+	for _, opt := range opts {
+		opt(f)
 	}
+	return f
 }
 
 func (f *Flux) InstallGitOps(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) error {
@@ -87,36 +217,180 @@ func (f *Flux) InstallGitOps(ctx context.Context, cluster *types.Cluster, cluste
 		return nil
 	}
 
-	fc := newFluxForCluster(f, clusterSpec, datacenterConfig, machineConfigs)
-
-	if err := fc.setupRepository(ctx); err != nil {
+	start := time.Now()
+	if err := f.installGitOps(ctx, cluster, clusterSpec, datacenterConfig, machineConfigs); err != nil {
+		logger.MarkFailTimed(start, "GitOps installation failed")
 		return err
 	}
 
-	if err := fc.commitFluxAndClusterConfigToGit(ctx); err != nil {
+	logger.MarkSuccessTimed(start, "GitOps installed")
+	return nil
+}
+
+func (f *Flux) installGitOps(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) error {
+	fc, err := newFluxForCluster(f, clusterSpec, datacenterConfig, machineConfigs)
+	if err != nil {
 		return err
 	}
 
-	if err := f.Bootstrap(ctx, cluster, clusterSpec); err != nil {
+	if usesOCIRepository(clusterSpec) {
+		return f.installOCIGitOps(ctx, cluster, fc)
+	}
+
+	if usesBucket(clusterSpec) {
+		return f.installBucketGitOps(ctx, cluster, fc)
+	}
+
+	if fc.checkpoint, err = fc.readInstallCheckpoint(); err != nil {
 		return err
 	}
 
-	logger.V(4).Info("pulling from remote after Flux Bootstrap to ensure configuration files in local git repository are in sync",
-		"remote", defaultRemote, "branch", fc.branch())
+	if fc.resuming(repositoryCreatedPhase) {
+		logger.Info("Resuming GitOps installation, git repository already set up")
+		if err := fc.syncGitRepo(ctx); err != nil {
+			return err
+		}
+	} else {
+		if err := fc.setupRepository(ctx); err != nil {
+			return err
+		}
+		fc.completeInstallPhase(repositoryCreatedPhase)
+	}
+
+	if fc.manifestsCommitted() {
+		logger.Info("Resuming GitOps installation, cluster configuration already committed to git")
+		fc.adoptedFlux = fc.isAdoptedFlux()
+	} else {
+		if err := fc.detectExistingFlux(ctx, cluster); err != nil {
+			return err
+		}
 
-	if err := f.gitClient.Pull(ctx, fc.branch()); err != nil {
-		logger.Error(err, "error when pulling from remote repository after Flux Bootstrap; ensure local repository is up-to-date with remote (git pull)",
-			"remote", defaultRemote, "branch", fc.branch(), "error", err)
+		if err := fc.commitFluxAndClusterConfigToGit(ctx); err != nil {
+			return err
+		}
+		fc.completeInstallPhase(manifestsCommittedPhase)
 	}
+
+	if fc.resuming(fluxBootstrappedPhase) {
+		logger.Info("Resuming GitOps installation, flux already bootstrapped")
+	} else {
+		if f.verifyManifestsBeforeBootstrap && !cluster.ExistingManagement {
+			if err := fc.verifyEksaManifests(ctx, cluster); err != nil {
+				fc.revertClusterConfig(ctx)
+				return err
+			}
+		}
+
+		if fc.adoptedFlux {
+			if err := fc.applyAdoptedFluxSync(ctx, cluster); err != nil {
+				return err
+			}
+			fc.removeInstallCheckpoint()
+			return nil
+		}
+
+		if err := f.Bootstrap(ctx, cluster, fc.clusterSpecForBootstrap()); err != nil {
+			fc.deleteStagingBranch(ctx)
+			fc.revertClusterConfig(ctx)
+			return err
+		}
+
+		if err := fc.promoteStagingBranch(ctx, cluster); err != nil {
+			return err
+		}
+		fc.completeInstallPhase(fluxBootstrappedPhase)
+	}
+
+	if !fc.resuming(pulledPhase) {
+		logger.V(4).Info("pulling from remote after Flux Bootstrap to ensure configuration files in local git repository are in sync",
+			"remote", defaultRemote, "branch", fc.branch())
+
+		var upToDateErr *git.RepositoryUpToDateError
+		err := f.gitClient.Pull(ctx, fc.branch())
+		if err != nil && !errors.As(err, &upToDateErr) {
+			// Flux bootstrap pushes its own commits on top of ours, so the local branch is reset to the
+			// remote one when it can't be fast-forwarded.
+			logger.V(3).Info("Pulling after Flux Bootstrap failed, resetting local repository to the remote branch",
+				"remote", defaultRemote, "branch", fc.branch(), "reason", err)
+			err = f.gitClient.FetchAndReset(ctx, fc.branch())
+		}
+		if err != nil && !errors.As(err, &upToDateErr) {
+			logger.Error(err, "error when pulling from remote repository after Flux Bootstrap; ensure local repository is up-to-date with remote (git pull)",
+				"remote", defaultRemote, "branch", fc.branch(), "error", err)
+		} else {
+			fc.completeInstallPhase(pulledPhase)
+		}
+	}
+
+	if clusterSpec.Cluster.IsSelfManaged() {
+		// With a pull request the committed files are not in the sync branch until it's merged
+		if f.verifyCommittedClusterConfig && fc.pullRequestBranch == "" {
+			if err := fc.verifyCommittedClusterConfig(); err != nil {
+				return err
+			}
+		}
+		if err := fc.setupReceiver(ctx, cluster); err != nil {
+			return err
+		}
+	}
+
+	fc.removeInstallCheckpoint()
 	return nil
 }
 
+// WithVerifyManifestsBeforeBootstrap makes InstallGitOps verify that the committed eks-a manifests can be applied
+// to the cluster with a server-side dry-run before bootstrapping flux, aborting the install if they can't.
+func WithVerifyManifestsBeforeBootstrap() Opt {
+	return func(f *Flux) {
+		f.verifyManifestsBeforeBootstrap = true
+	}
+}
+
 func (f *Flux) Bootstrap(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if err := f.createSystemNamespace(ctx, cluster, clusterSpec); err != nil {
+		return err
+	}
+
 	if err := f.BootstrapGithub(ctx, cluster, clusterSpec); err != nil {
 		_ = f.Uninstall(ctx, cluster, clusterSpec)
 		return fmt.Errorf("installing GitHub gitops: %v", err)
 	}
 
+	if err := f.BootstrapGitlab(ctx, cluster, clusterSpec); err != nil {
+		_ = f.Uninstall(ctx, cluster, clusterSpec)
+		return fmt.Errorf("installing Gitlab gitops: %v", err)
+	}
+
+	if err := f.BootstrapBitbucketServer(ctx, cluster, clusterSpec); err != nil {
+		_ = f.Uninstall(ctx, cluster, clusterSpec)
+		return fmt.Errorf("installing Bitbucket Server gitops: %v", err)
+	}
+
+	if err := f.BootstrapAzureDevOps(ctx, cluster, clusterSpec); err != nil {
+		_ = f.Uninstall(ctx, cluster, clusterSpec)
+		return fmt.Errorf("installing Azure DevOps gitops: %v", err)
+	}
+
+	if err := f.BootstrapGitea(ctx, cluster, clusterSpec); err != nil {
+		_ = f.Uninstall(ctx, cluster, clusterSpec)
+		return fmt.Errorf("installing Gitea gitops: %v", err)
+	}
+
+	if err := f.BootstrapCodeCommit(ctx, cluster, clusterSpec); err != nil {
+		_ = f.Uninstall(ctx, cluster, clusterSpec)
+		return fmt.Errorf("installing CodeCommit gitops: %v", err)
+	}
+
+	if err := f.BootstrapOCIRepository(ctx, cluster, clusterSpec); err != nil {
+		_ = f.Uninstall(ctx, cluster, clusterSpec)
+		return fmt.Errorf("installing OCI repository gitops: %v", err)
+	}
+
+	if err := f.BootstrapBucket(ctx, cluster, clusterSpec); err != nil {
+		_ = f.Uninstall(ctx, cluster, clusterSpec)
+		return fmt.Errorf("installing bucket gitops: %v", err)
+	}
+
 	if err := f.BootstrapGit(ctx, cluster, clusterSpec); err != nil {
 		_ = f.Uninstall(ctx, cluster, clusterSpec)
 		return fmt.Errorf("installing generic git gitops: %v", err)
@@ -130,7 +404,77 @@ func (f *Flux) BootstrapGithub(ctx context.Context, cluster *types.Cluster, clus
 		return nil
 	}
 
-	return f.fluxClient.BootstrapGithub(ctx, cluster, clusterSpec.FluxConfig)
+	fluxConfig, err := fluxConfigForBootstrap(clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	return f.fluxClient.BootstrapGithub(ctx, cluster, fluxConfig)
+}
+
+func (f *Flux) BootstrapGitlab(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if cluster.ExistingManagement || clusterSpec.FluxConfig.Spec.Gitlab == nil {
+		return nil
+	}
+
+	fluxConfig, err := fluxConfigForBootstrap(clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	return f.fluxClient.BootstrapGitlab(ctx, cluster, fluxConfig)
+}
+
+func (f *Flux) BootstrapBitbucketServer(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if cluster.ExistingManagement || clusterSpec.FluxConfig.Spec.BitbucketServer == nil {
+		return nil
+	}
+
+	fluxConfig, err := fluxConfigForBootstrap(clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	return f.fluxClient.BootstrapBitbucketServer(ctx, cluster, fluxConfig)
+}
+
+func (f *Flux) BootstrapAzureDevOps(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if cluster.ExistingManagement || clusterSpec.FluxConfig.Spec.AzureDevOps == nil {
+		return nil
+	}
+
+	fluxConfig, err := fluxConfigForBootstrap(clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	return f.fluxClient.BootstrapAzureDevOps(ctx, cluster, fluxConfig)
+}
+
+func (f *Flux) BootstrapGitea(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if cluster.ExistingManagement || clusterSpec.FluxConfig.Spec.Gitea == nil {
+		return nil
+	}
+
+	fluxConfig, err := fluxConfigForBootstrap(clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	return f.fluxClient.BootstrapGitea(ctx, cluster, fluxConfig)
+}
+
+func (f *Flux) BootstrapCodeCommit(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if cluster.ExistingManagement || clusterSpec.FluxConfig.Spec.CodeCommit == nil {
+		return nil
+	}
+
+	fluxConfig, err := fluxConfigForBootstrap(clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	return f.fluxClient.BootstrapCodeCommit(ctx, cluster, fluxConfig)
 }
 
 func (f *Flux) BootstrapGit(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
@@ -138,7 +482,12 @@ func (f *Flux) BootstrapGit(ctx context.Context, cluster *types.Cluster, cluster
 		return nil
 	}
 
-	return f.fluxClient.BootstrapGit(ctx, cluster, clusterSpec.FluxConfig, f.cliConfig)
+	fluxConfig, err := fluxConfigForBootstrap(clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	return f.fluxClient.BootstrapGit(ctx, cluster, fluxConfig, f.cliConfig)
 }
 
 func (f *Flux) Uninstall(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
@@ -203,12 +552,31 @@ func (f *Flux) ResumeClusterResourcesReconcile(ctx context.Context, cluster *typ
 	return nil
 }
 
+// ForceReconcileGitRepo requests flux to reconcile its source. With WithReconcileWait, it then blocks until the new
+// revision is applied.
 func (f *Flux) ForceReconcileGitRepo(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
 	if f.shouldSkipFlux() {
 		logger.Info("GitOps not configured, force reconcile flux git repo skipped")
 		return nil
 	}
 
+	if f.reconcileWaitTimeout > 0 {
+		revision, err := f.ForceReconcileGitRepoAndWait(ctx, cluster, clusterSpec, f.reconcileWaitTimeout)
+		if err != nil {
+			return err
+		}
+		logger.V(3).Info("Flux reconciled", "revision", revision)
+		return nil
+	}
+
+	return f.forceReconcile(ctx, cluster, clusterSpec)
+}
+
+func (f *Flux) forceReconcile(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if usesOCIRepository(clusterSpec) || usesBucket(clusterSpec) {
+		return f.fluxClient.Reconcile(ctx, cluster, clusterSpec.FluxConfig)
+	}
+
 	return f.fluxClient.ForceReconcile(ctx, cluster, clusterSpec.FluxConfig.Spec.SystemNamespace)
 }
 
@@ -218,27 +586,100 @@ func (f *Flux) UpdateGitEksaSpec(ctx context.Context, clusterSpec *cluster.Spec,
 		return nil
 	}
 
-	fc := newFluxForCluster(f, clusterSpec, datacenterConfig, machineConfigs)
+	fc, err := newFluxForCluster(f, clusterSpec, datacenterConfig, machineConfigs)
+	if err != nil {
+		return err
+	}
+
+	if usesOCIRepository(clusterSpec) {
+		return f.updateOCIEksaSpec(ctx, fc)
+	}
+
+	if usesBucket(clusterSpec) {
+		return f.updateBucketEksaSpec(ctx, fc)
+	}
 
 	if err := fc.syncGitRepo(ctx); err != nil {
 		return err
 	}
 
+	if err := fc.commitEksaFiles(ctx, upgradeOperation, true); err != nil {
+		return err
+	}
+
+	fc.tagUpgrade(ctx)
+	return nil
+}
+
+// commitEksaFiles writes the eks-a files of the cluster to the local repository and pushes them with the commit
+// message of the operation, to a pull request branch if the sync branch doesn't accept direct pushes. The body of
+// the commit summarizes the changes of the cluster config. With allowSquash, the commit amends the previous update
+// commit when updates are squashed, and its body only summarizes the last changes.
+func (fc *fluxForCluster) commitEksaFiles(ctx context.Context, operation string, allowSquash bool) error {
+	f := fc.Flux
+	prBranch, err := fc.checkoutPullRequestBranch(ctx)
+	if err != nil {
+		return err
+	}
+
+	previous, err := fc.readClusterConfigFile()
+	if err != nil {
+		return err
+	}
+
 	g := NewFileGenerator()
 	if err := g.Init(f.writer, fc.eksaSystemDir(), fc.fluxSystemDir()); err != nil {
 		return err
 	}
 
-	if err := g.WriteEksaFiles(clusterSpec, datacenterConfig, machineConfigs); err != nil {
+	if err := g.WriteEksaFiles(fc.clusterSpec, fc.datacenterConfig, fc.machineConfigs); err != nil {
+		return err
+	}
+
+	if err := fc.appendChangelogEntry(operation, previous); err != nil {
+		return err
+	}
+
+	updated, err := fc.readClusterConfigFile()
+	if err != nil {
+		return err
+	}
+
+	changes, err := clusterConfigChanges(previous, updated)
+	if err != nil {
+		return err
+	}
+
+	msg, err := fc.clusterconfigCommitMessage(operation, changes)
+	if err != nil {
 		return err
 	}
 
 	path := fc.eksaSystemDir()
-	if err := f.gitClient.Add(path); err != nil {
+	if err := f.addToGit(path); err != nil {
 		return fmt.Errorf("adding %s to git: %v", path, err)
 	}
 
-	if err := f.pushToRemoteRepo(ctx, path, updateClusterconfigCommitMessage); err != nil {
+	if err := fc.validateCommitSize(); err != nil {
+		return err
+	}
+
+	if prBranch != "" {
+		fc.pullRequestBranch = prBranch
+		return fc.pushAndOpenPullRequest(ctx, prBranch, path, msg)
+	}
+
+	if allowSquash {
+		if amended, ok := fc.squashableUpdateCommit(msg); ok {
+			if err := f.amendAndForcePushToRemoteRepo(ctx, path, msg, amended); err != nil {
+				return err
+			}
+			logger.V(3).Info("Finished pushing squashed cluster config file update to git", "repository", fc.repository())
+			return nil
+		}
+	}
+
+	if err := f.pushToRemoteRepo(ctx, path, msg); err != nil {
 		return err
 	}
 	logger.V(3).Info("Finished pushing updated cluster config file to git", "repository", fc.repository())
@@ -250,9 +691,48 @@ func (f *Flux) Validations(ctx context.Context, clusterSpec *cluster.Spec) []val
 		return nil
 	}
 
-	fc := newFluxForCluster(f, clusterSpec, nil, nil)
+	if usesOCIRepository(clusterSpec) || usesBucket(clusterSpec) {
+		if clusterSpec.Cluster.Spec.RegistryMirrorConfiguration == nil {
+			return nil
+		}
+		return []validations.Validation{f.registryMirrorValidation(ctx, clusterSpec)}
+	}
+
+	fc, err := newFluxForCluster(f, clusterSpec, nil, nil)
+	if err != nil {
+		return []validations.Validation{
+			func() *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Name:        "Flux path",
+					Remediation: "Please provide a valid cluster config path template",
+					Err:         err,
+				}
+			},
+		}
+	}
 
 	return []validations.Validation{
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "Git provider permissions",
+				Remediation: "Please grant the git provider token the permissions to create the repository, write its contents and add deploy keys to it: the repo scope for a Github personal access token (classic), or read and write access to the Contents and Administration permissions for a fine-grained token or a Github App",
+				Err:         f.gitClient.ValidateProviderPermissions(ctx),
+			}
+		},
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "Git repository write access",
+				Remediation: "Please make sure the git credentials can push to the repository and the branch is a valid git branch name",
+				Err:         fc.validateRepositoryWriteAccess(ctx),
+			}
+		},
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "Git branch protection",
+				Remediation: fmt.Sprintf("Please allow the git credentials to push to the branch, or set %s to true to open a pull request with the cluster config changes instead", config.EksaGitOpsPullRequestFallbackEnv),
+				Err:         fc.validateBranchPush(ctx),
+			}
+		},
 		func() *validations.ValidationResult {
 			return &validations.ValidationResult{
 				Name:        "Flux path",
@@ -260,6 +740,7 @@ func (f *Flux) Validations(ctx context.Context, clusterSpec *cluster.Spec) []val
 				Err:         fc.validateRemoteConfigPathDoesNotExist(ctx),
 			}
 		},
+		f.registryMirrorValidation(ctx, clusterSpec),
 	}
 }
 
@@ -269,16 +750,50 @@ func (f *Flux) CleanupGitRepo(ctx context.Context, clusterSpec *cluster.Spec) er
 		return nil
 	}
 
-	fc := newFluxForCluster(f, clusterSpec, nil, nil)
+	fc, err := newFluxForCluster(f, clusterSpec, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if usesOCIRepository(clusterSpec) {
+		return f.cleanupOCIArtifact(ctx, fc)
+	}
+
+	if usesBucket(clusterSpec) {
+		return f.cleanupBucket(ctx, fc)
+	}
+
+	remoteCleanup := f.gitRepoCleanup != "" && clusterSpec.Cluster.IsSelfManaged()
+	if remoteCleanup {
+		if err := ValidateGitRepoCleanup(string(f.gitRepoCleanup)); err != nil {
+			return err
+		}
+	}
 
 	if err := fc.syncGitRepo(ctx); err != nil {
 		return err
 	}
 
+	if remoteCleanup {
+		reason, err := fc.remoteRepoSharedReason(ctx)
+		if err != nil {
+			return err
+		}
+		if reason == "" {
+			return fc.cleanupRemoteRepo(ctx)
+		}
+		logger.Info("Skipping git repo cleanup, only the cluster dir is removed", "cleanup", f.gitRepoCleanup, "reason", reason)
+	}
+
 	var p string
-	if clusterSpec.Cluster.IsManaged() {
+	switch {
+	case fc.usesClusterTenants():
+		p = fc.tenantDir()
+	case fc.usesHubAndSpoke():
+		p = fc.spokeDir()
+	case clusterSpec.Cluster.IsManaged():
 		p = fc.eksaSystemDir()
-	} else {
+	default:
 		p = fc.path()
 	}
 
@@ -291,7 +806,20 @@ func (f *Flux) CleanupGitRepo(ctx context.Context, clusterSpec *cluster.Spec) er
 		return fmt.Errorf("removing %s in git: %v", p, err)
 	}
 
-	if err := f.pushToRemoteRepo(ctx, p, deleteClusterconfigCommitMessage); err != nil {
+	if err := fc.removePathClaim(); err != nil {
+		return err
+	}
+
+	if err := fc.archiveChangelog(); err != nil {
+		return err
+	}
+
+	msg, err := fc.clusterconfigCommitMessage(deleteOperation, "")
+	if err != nil {
+		return err
+	}
+
+	if err := f.pushToRemoteRepo(ctx, p, msg); err != nil {
 		return err
 	}
 
@@ -305,7 +833,19 @@ func (f *Flux) pushToRemoteRepo(ctx context.Context, path, msg string) error {
 		return fmt.Errorf("committing %s to git: %v", path, err)
 	}
 
-	if err := f.gitClient.Push(ctx); err != nil {
+	err := f.gitClient.Push(ctx)
+	// Concurrent operations on other clusters sharing the repository make the push fail when they push first,
+	// the commit is replayed on top of their commits, which only change the files of the other clusters.
+	var rejected *git.PushRejectedError
+	for attempt := 1; attempt <= maxPushRebaseAttempts && errors.As(err, &rejected); attempt++ {
+		logger.V(3).Info("Push rejected, rebasing on the remote branch", "branch", rejected.Branch, "attempt", attempt)
+		if err := f.gitClient.RebaseOnRemote(ctx); err != nil {
+			return fmt.Errorf("rebasing %s on the remote branch: %v", path, err)
+		}
+		err = f.gitClient.Push(ctx)
+	}
+
+	if err != nil {
 		return fmt.Errorf("pushing %s to git: %v", path, err)
 	}
 	return nil
@@ -14,6 +14,7 @@ import (
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/git"
@@ -47,10 +48,17 @@ type fluxTest struct {
 	clusterSpec *cluster.Spec
 }
 
+// pathClaimFile is the file committed to claim the cluster config path of a management cluster.
+func pathClaimFile(clusterName string) string {
+	return ".eksa/claims/" + clusterName + ".yaml"
+}
+
 func newFluxTest(t *testing.T) fluxTest {
 	mockCtrl := gomock.NewController(t)
 	mockGitOpsFlux := fluxMocks.NewMockGitOpsFluxClient(mockCtrl)
 	mockGit := fluxMocks.NewMockGitClient(mockCtrl)
+	// The staged files are only checked against the size limits by the commit size tests, with their own git client.
+	mockGit.EXPECT().StagedFiles().Return(nil, nil).AnyTimes()
 	mockProvider := mocksprovider.NewMockProvider(gomock.NewController(t))
 	_, w := test.NewWriter(t)
 	f := flux.NewFluxFromGitOpsFluxClient(mockGitOpsFlux, mockGit, w, nil)
@@ -247,6 +255,7 @@ func TestInstallGitOpsOnManagementClusterWithPrexistingRepo(t *testing.T) {
 			g.git.EXPECT().Clone(g.ctx).Return(nil)
 			g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
 			g.git.EXPECT().Add(path.Dir(tt.expectedClusterConfigGitPath)).Return(nil)
+			g.git.EXPECT().Add(pathClaimFile(clusterSpec.Cluster.Name)).Return(nil)
 			g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
 			g.git.EXPECT().Push(g.ctx).Return(nil)
 			g.git.EXPECT().Pull(g.ctx, clusterSpec.FluxConfig.Spec.Branch).Return(nil)
@@ -313,6 +322,7 @@ func TestInstallGitOpsOnManagementClusterWithoutClusterSpec(t *testing.T) {
 			g.git.EXPECT().Clone(g.ctx).Return(nil)
 			g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
 			g.git.EXPECT().Add(path.Dir(tt.expectedClusterConfigGitPath)).Return(nil)
+			g.git.EXPECT().Add(pathClaimFile(clusterSpec.Cluster.Name)).Return(nil)
 			g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
 			g.git.EXPECT().Push(g.ctx).Return(nil)
 			g.git.EXPECT().Pull(g.ctx, clusterSpec.FluxConfig.Spec.Branch).Return(nil)
@@ -334,6 +344,88 @@ func TestInstallGitOpsOnManagementClusterWithoutClusterSpec(t *testing.T) {
 	}
 }
 
+func TestInstallGitOpsResetsToRemoteWhenPullAfterBootstrapFails(t *testing.T) {
+	tests := []struct {
+		name       string
+		resetError error
+	}{
+		{
+			name: "reset succeeds",
+		},
+		{
+			name:       "reset fails",
+			resetError: errors.New("error in reset"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := &types.Cluster{}
+			clusterName := "management-cluster"
+			g := newFluxTest(t)
+			clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+			f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil)
+
+			g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: clusterSpec.FluxConfig.Spec.Github.Repository}, nil)
+			g.git.EXPECT().Clone(g.ctx).Return(nil)
+			g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+			g.git.EXPECT().Add("clusters").Return(nil)
+			g.git.EXPECT().Add(pathClaimFile(clusterSpec.Cluster.Name)).Return(nil)
+			g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+			g.git.EXPECT().Push(g.ctx).Return(nil)
+			g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, clusterSpec.FluxConfig).Return(nil)
+			gomock.InOrder(
+				g.git.EXPECT().Pull(g.ctx, clusterSpec.FluxConfig.Spec.Branch).Return(errors.New("non-fast-forward update")),
+				g.git.EXPECT().FetchAndReset(g.ctx, clusterSpec.FluxConfig.Spec.Branch).Return(tt.resetError),
+			)
+
+			g.Expect(f.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+		})
+	}
+}
+
+func TestInstallGitOpsVerifyManifestsBeforeBootstrap(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithVerifyManifestsBeforeBootstrap())
+
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: clusterSpec.FluxConfig.Spec.Github.Repository}, nil)
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add("clusters").Return(nil)
+	g.git.EXPECT().Add(pathClaimFile(clusterSpec.Cluster.Name)).Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.git.EXPECT().Pull(g.ctx, clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	gomock.InOrder(
+		g.flux.EXPECT().DryRunApplyKustomization(g.ctx, cluster, path.Join(g.writer.Dir(), "clusters/management-cluster/management-cluster/eksa-system")).Return(nil),
+		g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, clusterSpec.FluxConfig).Return(nil),
+	)
+
+	g.Expect(f.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+}
+
+func TestInstallGitOpsVerifyManifestsBeforeBootstrapError(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithVerifyManifestsBeforeBootstrap())
+
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: clusterSpec.FluxConfig.Spec.Github.Repository}, nil)
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add("clusters").Return(nil)
+	g.git.EXPECT().Add(pathClaimFile(clusterSpec.Cluster.Name)).Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.flux.EXPECT().DryRunApplyKustomization(g.ctx, cluster, gomock.Any()).Return(errors.New("error in dry-run"))
+
+	err := f.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})
+	g.Expect(err).To(MatchError(ContainSubstring("verifying eks-a manifests before flux bootstrap: error in dry-run")))
+}
+
 func TestInstallGitOpsOnWorkloadClusterWithPrexistingRepo(t *testing.T) {
 	cluster := &types.Cluster{}
 	clusterName := "workload-cluster"
@@ -375,6 +467,31 @@ func TestInstallGitOpsOnWorkloadClusterWithPrexistingRepo(t *testing.T) {
 	}
 }
 
+func TestInstallGitOpsOnWorkloadClusterWithClusterTenants(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "workload-cluster"
+	clusterConfig := v1alpha1.NewCluster(clusterName)
+	clusterConfig.SetManagedBy("management-cluster")
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+	clusterSpec.FluxConfig.Spec.MultiTenancy = &v1alpha1.FluxMultiTenancyConfig{ClusterTenants: true}
+
+	g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, clusterSpec.FluxConfig)
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: clusterSpec.FluxConfig.Spec.Github.Repository}, nil)
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add(path.Dir("clusters/management-cluster")).Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.git.EXPECT().Pull(g.ctx, clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+
+	g.Expect(g.gitOpsFlux.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+
+	tenantDir := path.Join(g.writer.Dir(), "clusters/management-cluster/workload-cluster")
+	test.AssertFilesEquals(t, path.Join(tenantDir, "eksa-tenant.yaml"), "./testdata/eksa-tenant.yaml")
+	test.AssertFilesEquals(t, path.Join(tenantDir, defaultKustomizationManifestFileName), "./testdata/tenant-kustomization.yaml")
+}
+
 func TestInstallGitOpsSetupRepoError(t *testing.T) {
 	cluster := &types.Cluster{}
 	clusterName := "test-cluster"
@@ -401,6 +518,7 @@ func TestInstallGitOpsBootstrapError(t *testing.T) {
 	g.git.EXPECT().Clone(g.ctx).Return(nil)
 	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
 	g.git.EXPECT().Add(path.Dir("clusters/management-cluster")).Return(nil)
+	g.git.EXPECT().Add(pathClaimFile(clusterSpec.Cluster.Name)).Return(nil)
 	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
 	g.git.EXPECT().Push(g.ctx).Return(nil)
 	g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, clusterSpec.FluxConfig).Return(errors.New("error in bootstrap"))
@@ -422,6 +540,7 @@ func TestInstallGitOpsGitProviderSuccess(t *testing.T) {
 	g.git.EXPECT().Clone(g.ctx).Return(nil)
 	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
 	g.git.EXPECT().Add(path.Dir("clusters/management-cluster")).Return(nil)
+	g.git.EXPECT().Add(pathClaimFile(clusterSpec.Cluster.Name)).Return(nil)
 	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
 	g.git.EXPECT().Push(g.ctx).Return(nil)
 	g.git.EXPECT().Pull(g.ctx, clusterSpec.FluxConfig.Spec.Branch).Return(nil)
@@ -511,6 +630,7 @@ func TestInstallGitOpsNoPrexistingRepo(t *testing.T) {
 			g.git.EXPECT().Commit(gomock.Any()).Return(nil)
 			g.git.EXPECT().Branch(b).Return(nil)
 			g.git.EXPECT().Add(path.Dir(tt.expectedClusterConfigGitPath)).Return(nil)
+			g.git.EXPECT().Add(pathClaimFile(clusterSpec.Cluster.Name)).Return(nil)
 			g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
 			g.git.EXPECT().Push(g.ctx).Return(nil)
 			g.git.EXPECT().Pull(g.ctx, b).Return(nil)
@@ -579,6 +699,7 @@ func TestInstallGitOpsToolkitsBareRepo(t *testing.T) {
 			g.git.EXPECT().Commit(gomock.Any()).Return(nil)
 			g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
 			g.git.EXPECT().Add(path.Dir(tt.expectedClusterConfigGitPath)).Return(nil)
+			g.git.EXPECT().Add(pathClaimFile(clusterSpec.Cluster.Name)).Return(nil)
 			g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
 			g.git.EXPECT().Push(g.ctx).Return(nil)
 			g.git.EXPECT().Pull(g.ctx, clusterSpec.FluxConfig.Spec.Branch).Return(nil)
@@ -761,6 +882,86 @@ func TestUpdateGitRepoEksaSpecLocalRepoNotExists(t *testing.T) {
 	test.AssertFilesEquals(t, expectedEksaClusterConfigPath, "./testdata/cluster-config-default-path-management.yaml")
 }
 
+func TestUpdateGitRepoEksaSpecPushRejected(t *testing.T) {
+	clusterName := "management-cluster"
+	clusterConfig := v1alpha1.NewCluster(clusterName)
+	eksaSystemDirPath := "clusters/management-cluster/management-cluster/eksa-system"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+	rejected := &git.PushRejectedError{Branch: "testBranch", Err: errors.New("non-fast-forward update")}
+
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add(eksaSystemDirPath).Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	gomock.InOrder(
+		g.git.EXPECT().Push(g.ctx).Return(rejected),
+		g.git.EXPECT().RebaseOnRemote(g.ctx).Return(nil),
+		g.git.EXPECT().Push(g.ctx).Return(nil),
+	)
+
+	g.Expect(g.gitOpsFlux.UpdateGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+}
+
+func TestUpdateGitRepoEksaSpecPushRejectedConflict(t *testing.T) {
+	clusterName := "management-cluster"
+	clusterConfig := v1alpha1.NewCluster(clusterName)
+	eksaSystemDirPath := "clusters/management-cluster/management-cluster/eksa-system"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add(eksaSystemDirPath).Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(&git.PushRejectedError{Branch: "testBranch", Err: errors.New("non-fast-forward update")})
+	g.git.EXPECT().RebaseOnRemote(g.ctx).Return(&git.RebaseConflictError{Paths: []string{eksaSystemDirPath + "/eksa-cluster.yaml"}})
+
+	err := g.gitOpsFlux.UpdateGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})
+	g.Expect(err).To(MatchError(ContainSubstring("rebasing " + eksaSystemDirPath + " on the remote branch: files changed both locally and in the remote branch")))
+}
+
+func TestUpdateGitRepoEksaSpecPushRejectedMaxAttempts(t *testing.T) {
+	clusterName := "management-cluster"
+	clusterConfig := v1alpha1.NewCluster(clusterName)
+	eksaSystemDirPath := "clusters/management-cluster/management-cluster/eksa-system"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+	rejected := &git.PushRejectedError{Branch: "testBranch", Err: errors.New("non-fast-forward update")}
+
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add(eksaSystemDirPath).Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(rejected).Times(6)
+	g.git.EXPECT().RebaseOnRemote(g.ctx).Return(nil).Times(5)
+
+	err := g.gitOpsFlux.UpdateGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})
+	g.Expect(err).To(MatchError(ContainSubstring("push to branch testBranch rejected")))
+}
+
+func TestUpdateGitRepoEksaSpecWithOwnerLabel(t *testing.T) {
+	clusterName := "management-cluster"
+	clusterConfig := v1alpha1.NewCluster(clusterName)
+	clusterConfig.Labels = map[string]string{flux.OwnerLabel: "team-a"}
+	eksaSystemDirPath := "clusters/management-cluster/management-cluster/eksa-system"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add(eksaSystemDirPath).Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+
+	datacenterConfig := datacenterConfig(clusterName)
+	machineConfig := machineConfig(clusterName)
+
+	g.Expect(g.gitOpsFlux.UpdateGitEksaSpec(g.ctx, clusterSpec, datacenterConfig, []providers.MachineConfig{machineConfig})).To(Succeed())
+	expectedKustomizationPath := path.Join(g.writer.Dir(), eksaSystemDirPath, defaultKustomizationManifestFileName)
+	test.AssertFilesEquals(t, expectedKustomizationPath, "./testdata/kustomization-owner.yaml")
+}
+
 func TestUpdateGitRepoEksaSpecLocalRepoExists(t *testing.T) {
 	g := newFluxTest(t)
 	mockCtrl := gomock.NewController(t)
@@ -775,6 +976,7 @@ func TestUpdateGitRepoEksaSpecLocalRepoExists(t *testing.T) {
 	gitClient := gitMocks.NewMockClient(mockCtrl)
 	gitClient.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
 	gitClient.EXPECT().Add(eksaSystemDirPath).Return(nil)
+	gitClient.EXPECT().StagedFiles().Return([]string{path.Join(eksaSystemDirPath, defaultEksaClusterConfigFileName)}, nil)
 	gitClient.EXPECT().Commit(test.OfType("string")).Return(nil)
 	gitClient.EXPECT().Push(g.ctx).Return(nil)
 
@@ -965,6 +1167,38 @@ func TestCleanupGitRepoWorkloadCluster(t *testing.T) {
 	g.Expect(f.CleanupGitRepo(g.ctx, clusterSpec)).To(Succeed())
 }
 
+func TestCleanupGitRepoWorkloadClusterWithClusterTenants(t *testing.T) {
+	g := newFluxTest(t)
+	mockCtrl := gomock.NewController(t)
+	clusterConfig := v1alpha1.NewCluster("workload-cluster")
+	clusterConfig.SetManagedBy("management-cluster")
+	expectedClusterPath := "clusters/management-cluster/workload-cluster"
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+	clusterSpec.FluxConfig.Spec.MultiTenancy = &v1alpha1.FluxMultiTenancyConfig{ClusterTenants: true}
+
+	gitProvider := gitMocks.NewMockProviderClient(mockCtrl)
+
+	gitClient := gitMocks.NewMockClient(mockCtrl)
+	gitClient.EXPECT().Clone(g.ctx).Return(nil)
+	gitClient.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	gitClient.EXPECT().Remove(expectedClusterPath).Return(nil)
+	gitClient.EXPECT().Commit(test.OfType("string")).Return(nil)
+	gitClient.EXPECT().Push(g.ctx).Return(nil)
+
+	_, w := test.NewWriter(t)
+	if _, err := w.WithDir(expectedClusterPath); err != nil {
+		t.Errorf("failed to add %s dir: %v", expectedClusterPath, err)
+	}
+	fGitOptions := &gitFactory.GitTools{
+		Provider: gitProvider,
+		Client:   gitClient,
+		Writer:   w,
+	}
+	f := flux.NewFlux(nil, nil, fGitOptions, nil)
+
+	g.Expect(f.CleanupGitRepo(g.ctx, clusterSpec)).To(Succeed())
+}
+
 func TestCleanupGitRepoSkip(t *testing.T) {
 	clusterConfig := v1alpha1.NewCluster("management-cluster")
 	clusterSpec := newClusterSpec(t, clusterConfig, "")
@@ -1014,6 +1248,11 @@ func TestValidationsSkipFLux(t *testing.T) {
 func TestValidationsErrorFromPathExists(t *testing.T) {
 	g := newFluxTest(t)
 	owner, repo, path := g.setupFlux()
+	g.git.EXPECT().ValidateProviderPermissions(g.ctx).Return(nil)
+	g.git.EXPECT().ValidateBranchPush(g.ctx, "main").Return(nil)
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: repo}, nil)
+	g.git.EXPECT().ValidatePushAccess(g.ctx).Return(nil)
+	g.git.EXPECT().RemoteBranchExists(g.ctx, "main").Return(true, nil)
 	g.git.EXPECT().PathExists(g.ctx, owner, repo, "main", path).Return(false, errors.New("error from git"))
 
 	g.Expect(runValidations(g.gitOpsFlux.Validations(g.ctx, g.clusterSpec))).NotTo(Succeed())
@@ -1022,14 +1261,89 @@ func TestValidationsErrorFromPathExists(t *testing.T) {
 func TestValidationsPath(t *testing.T) {
 	g := newFluxTest(t)
 	owner, repo, path := g.setupFlux()
+	g.git.EXPECT().ValidateProviderPermissions(g.ctx).Return(nil)
+	g.git.EXPECT().ValidateBranchPush(g.ctx, "main").Return(nil)
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: repo}, nil)
+	g.git.EXPECT().ValidatePushAccess(g.ctx).Return(nil)
+	g.git.EXPECT().RemoteBranchExists(g.ctx, "main").Return(true, nil)
 	g.git.EXPECT().PathExists(g.ctx, owner, repo, "main", path).Return(true, nil)
 
 	g.Expect(runValidations(g.gitOpsFlux.Validations(g.ctx, g.clusterSpec))).NotTo(Succeed())
 }
 
+func TestValidationsErrorFromProviderPermissions(t *testing.T) {
+	g := newFluxTest(t)
+	g.setupFlux()
+	g.git.EXPECT().ValidateProviderPermissions(g.ctx).Return(errors.New("github access token does not have repo permissions"))
+
+	g.Expect(runValidations(g.gitOpsFlux.Validations(g.ctx, g.clusterSpec))).To(MatchError("github access token does not have repo permissions"))
+}
+
+func TestValidationsErrorFromPushAccess(t *testing.T) {
+	g := newFluxTest(t)
+	_, repo, _ := g.setupFlux()
+	g.git.EXPECT().ValidateProviderPermissions(g.ctx).Return(nil)
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: repo}, nil)
+	g.git.EXPECT().ValidatePushAccess(g.ctx).Return(errors.New("github credentials can't push to repository aws/eksa-gitops"))
+
+	g.Expect(runValidations(g.gitOpsFlux.Validations(g.ctx, g.clusterSpec))).To(MatchError("github credentials can't push to repository aws/eksa-gitops"))
+}
+
+func TestValidationsErrorFromRemoteBranchExists(t *testing.T) {
+	g := newFluxTest(t)
+	_, repo, _ := g.setupFlux()
+	g.git.EXPECT().ValidateProviderPermissions(g.ctx).Return(nil)
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: repo}, nil)
+	g.git.EXPECT().ValidatePushAccess(g.ctx).Return(nil)
+	g.git.EXPECT().RemoteBranchExists(g.ctx, "main").Return(false, errors.New("listing branches of remote repository: authentication required"))
+
+	g.Expect(runValidations(g.gitOpsFlux.Validations(g.ctx, g.clusterSpec))).To(MatchError(ContainSubstring("authentication required")))
+}
+
+func TestValidationsBranchCreated(t *testing.T) {
+	g := newFluxTest(t)
+	owner, repo, path := g.setupFlux()
+	g.git.EXPECT().ValidateProviderPermissions(g.ctx).Return(nil)
+	g.git.EXPECT().ValidateBranchPush(g.ctx, "main").Return(nil)
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: repo}, nil)
+	g.git.EXPECT().ValidatePushAccess(g.ctx).Return(nil)
+	g.git.EXPECT().RemoteBranchExists(g.ctx, "main").Return(false, nil)
+	g.git.EXPECT().PathExists(g.ctx, owner, repo, "main", path).Return(false, nil)
+
+	g.Expect(runValidations(g.gitOpsFlux.Validations(g.ctx, g.clusterSpec))).To(Succeed())
+}
+
+func TestValidationsInvalidBranchName(t *testing.T) {
+	g := newFluxTest(t)
+	_, repo, _ := g.setupFlux()
+	g.clusterSpec.FluxConfig.Spec.Branch = "feature branch"
+	g.git.EXPECT().ValidateProviderPermissions(g.ctx).Return(nil)
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: repo}, nil)
+	g.git.EXPECT().ValidatePushAccess(g.ctx).Return(nil)
+	g.git.EXPECT().RemoteBranchExists(g.ctx, "feature branch").Return(false, nil)
+
+	g.Expect(runValidations(g.gitOpsFlux.Validations(g.ctx, g.clusterSpec))).To(MatchError(ContainSubstring("branch feature branch doesn't exist in the remote repository and can't be created")))
+}
+
+func TestValidationsRepositoryDoesNotExist(t *testing.T) {
+	g := newFluxTest(t)
+	owner, repo, path := g.setupFlux()
+	g.git.EXPECT().ValidateProviderPermissions(g.ctx).Return(nil)
+	g.git.EXPECT().ValidateBranchPush(g.ctx, "main").Return(nil)
+	g.git.EXPECT().GetRepo(g.ctx).Return(nil, nil)
+	g.git.EXPECT().PathExists(g.ctx, owner, repo, "main", path).Return(false, nil)
+
+	g.Expect(runValidations(g.gitOpsFlux.Validations(g.ctx, g.clusterSpec))).To(Succeed())
+}
+
 func TestValidationsSuccess(t *testing.T) {
 	g := newFluxTest(t)
 	owner, repo, path := g.setupFlux()
+	g.git.EXPECT().ValidateProviderPermissions(g.ctx).Return(nil)
+	g.git.EXPECT().ValidateBranchPush(g.ctx, "main").Return(nil)
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: repo}, nil)
+	g.git.EXPECT().ValidatePushAccess(g.ctx).Return(nil)
+	g.git.EXPECT().RemoteBranchExists(g.ctx, "main").Return(true, nil)
 	g.git.EXPECT().PathExists(g.ctx, owner, repo, "main", path).Return(false, nil)
 
 	g.Expect(runValidations(g.gitOpsFlux.Validations(g.ctx, g.clusterSpec))).To(Succeed())
@@ -1079,3 +1393,106 @@ func TestUninstallError(t *testing.T) {
 
 	g.Expect(g.gitOpsFlux.Uninstall(g.ctx, c, g.clusterSpec)).To(MatchError(ContainSubstring("error in uninstall")))
 }
+
+func TestFluxBootstrapGitlab(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.Github = nil
+	clusterSpec.FluxConfig.Spec.Gitlab = &v1alpha1.GitlabProviderConfig{
+		Owner:      "platform",
+		Repository: "testRepo",
+		Hostname:   "gitlab.example.com",
+	}
+
+	g.flux.EXPECT().BootstrapGitlab(g.ctx, cluster, clusterSpec.FluxConfig).Return(nil)
+
+	g.Expect(g.gitOpsFlux.Bootstrap(g.ctx, cluster, clusterSpec)).To(Succeed())
+}
+
+func TestFluxBootstrapBitbucketServer(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.Github = nil
+	clusterSpec.FluxConfig.Spec.BitbucketServer = &v1alpha1.BitbucketServerProviderConfig{
+		Owner:      "PLAT",
+		Repository: "testRepo",
+		Hostname:   "bitbucket.example.com",
+		Username:   "janedoe",
+	}
+
+	g.flux.EXPECT().BootstrapBitbucketServer(g.ctx, cluster, clusterSpec.FluxConfig).Return(errors.New("error in bootstrap"))
+	g.flux.EXPECT().Uninstall(g.ctx, cluster, clusterSpec.FluxConfig).Return(nil)
+
+	g.Expect(g.gitOpsFlux.Bootstrap(g.ctx, cluster, clusterSpec)).To(MatchError(ContainSubstring("installing Bitbucket Server gitops: error in bootstrap")))
+}
+
+func TestFluxBootstrapAzureDevOps(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.Github = nil
+	clusterSpec.FluxConfig.Spec.AzureDevOps = &v1alpha1.AzureDevOpsProviderConfig{
+		Organization: "contoso",
+		Project:      "platform",
+		Repository:   "testRepo",
+	}
+
+	g.flux.EXPECT().BootstrapAzureDevOps(g.ctx, cluster, clusterSpec.FluxConfig).Return(nil)
+
+	g.Expect(g.gitOpsFlux.Bootstrap(g.ctx, cluster, clusterSpec)).To(Succeed())
+}
+
+func TestFluxBootstrapGitea(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.Github = nil
+	clusterSpec.FluxConfig.Spec.Gitea = &v1alpha1.GiteaProviderConfig{
+		Owner:      "platform",
+		Repository: "testRepo",
+		Hostname:   "gitea.lab.local",
+	}
+
+	g.flux.EXPECT().BootstrapGitea(g.ctx, cluster, clusterSpec.FluxConfig).Return(errors.New("error in bootstrap"))
+	g.flux.EXPECT().Uninstall(g.ctx, cluster, clusterSpec.FluxConfig).Return(nil)
+
+	g.Expect(g.gitOpsFlux.Bootstrap(g.ctx, cluster, clusterSpec)).To(MatchError(ContainSubstring("installing Gitea gitops: error in bootstrap")))
+}
+
+func TestFluxBootstrapCodeCommit(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.Github = nil
+	clusterSpec.FluxConfig.Spec.CodeCommit = &v1alpha1.CodeCommitProviderConfig{
+		Region:     "us-west-2",
+		Repository: "testRepo",
+	}
+
+	g.flux.EXPECT().BootstrapCodeCommit(g.ctx, cluster, clusterSpec.FluxConfig).Return(nil)
+
+	g.Expect(g.gitOpsFlux.Bootstrap(g.ctx, cluster, clusterSpec)).To(Succeed())
+}
+
+func TestBootstrapGitPinnedKnownHosts(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	mocks := fluxMocks.NewMockFluxClient(gomock.NewController(t))
+	cluster := &types.Cluster{Name: "management-cluster"}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.Github = nil
+	clusterSpec.FluxConfig.Spec.Git = &v1alpha1.GitProviderConfig{
+		RepositoryUrl: "ssh://git@example.com/org/repo.git",
+		KnownHosts:    "example.com ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl",
+	}
+	cliConfig := &config.CliConfig{GitPrivateKeyFile: "id_ed25519", GitKnownHostsFile: "known_hosts"}
+	gitTools := &gitFactory.GitTools{KnownHostsFile: "git-known-hosts"}
+
+	mocks.EXPECT().BootstrapGit(ctx, cluster, gomock.Any(), &config.CliConfig{GitPrivateKeyFile: "id_ed25519", GitKnownHostsFile: "git-known-hosts"}).Return(nil)
+
+	f := flux.NewFlux(mocks, nil, gitTools, cliConfig)
+	g.Expect(f.BootstrapGit(ctx, cluster, clusterSpec)).To(Succeed())
+	g.Expect(cliConfig.GitKnownHostsFile).To(Equal("known_hosts"))
+}
@@ -2,6 +2,7 @@ package flux
 
 import (
 	"context"
+	"errors"
 
 	"github.com/aws/eks-anywhere/pkg/git"
 	gitFactory "github.com/aws/eks-anywhere/pkg/git/factory"
@@ -22,7 +23,7 @@ func newGitClient(gitTools *gitFactory.GitTools) *gitClient {
 	return &gitClient{
 		git:         gitTools.Client,
 		gitProvider: gitTools.Provider,
-		Retrier:     retrier.NewWithMaxRetries(maxRetries, backOffPeriod),
+		Retrier:     newRetrier(nil),
 	}
 }
 
@@ -53,6 +54,67 @@ func (c *gitClient) CreateRepo(ctx context.Context, opts git.CreateRepoOpts) err
 	)
 }
 
+// CreatePullRequest opens a pull request with the git provider. It's not retried, since a failed response
+// doesn't guarantee the pull request wasn't created.
+func (c *gitClient) CreatePullRequest(ctx context.Context, opts git.CreatePullRequestOpts) (*git.PullRequest, error) {
+	if c.gitProvider == nil {
+		return nil, errors.New("pull requests are not supported by the generic git provider")
+	}
+
+	return c.gitProvider.CreatePullRequest(ctx, opts)
+}
+
+// CreateWebhook registers a webhook in the repository with the git provider. It's not retried, since a failed response
+// doesn't guarantee the webhook wasn't created.
+func (c *gitClient) CreateWebhook(ctx context.Context, opts git.CreateWebhookOpts) error {
+	p, ok := c.gitProvider.(git.WebhookProviderClient)
+	if !ok {
+		return errors.New("webhooks are not supported by the git provider")
+	}
+
+	return p.CreateWebhook(ctx, opts)
+}
+
+// WebhookExists checks if a webhook sending to the url is registered in the repository with the git provider.
+func (c *gitClient) WebhookExists(ctx context.Context, url string) (exists bool, err error) {
+	p, ok := c.gitProvider.(git.WebhookProviderClient)
+	if !ok {
+		return false, errors.New("webhooks are not supported by the git provider")
+	}
+
+	err = c.Retry(
+		func() error {
+			exists, err = p.WebhookExists(ctx, url)
+			return err
+		},
+	)
+	return exists, err
+}
+
+// DeleteRepo deletes the repository with the git provider. It's not retried, since a failed response
+// doesn't guarantee the repository wasn't deleted.
+func (c *gitClient) DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts) error {
+	if c.gitProvider == nil {
+		return errors.New("deleting repositories is not supported by the generic git provider")
+	}
+
+	return c.gitProvider.DeleteRepo(ctx, opts)
+}
+
+// ArchiveRepo archives the repository with the git provider.
+func (c *gitClient) ArchiveRepo(ctx context.Context, opts git.ArchiveRepoOpts) error {
+	p, ok := c.gitProvider.(git.ArchiveRepoProviderClient)
+	if !ok {
+		return errors.New("archiving repositories is not supported by the git provider")
+	}
+
+	return c.Retry(
+		func() error {
+			return p.ArchiveRepo(ctx, opts)
+		},
+	)
+}
+
 func (c *gitClient) Clone(ctx context.Context) error {
 	return c.Retry(
 		func() error {
@@ -61,10 +123,96 @@ func (c *gitClient) Clone(ctx context.Context) error {
 	)
 }
 
+// SetSparseCheckoutDirectories restricts the files the local repository checks out to the ones under dirs.
+func (c *gitClient) SetSparseCheckoutDirectories(dirs ...string) {
+	c.git.SetSparseCheckoutDirectories(dirs...)
+}
+
 func (c *gitClient) Push(ctx context.Context) error {
+	var rejectedErr error
+	err := c.Retry(
+		func() error {
+			err := c.git.Push(ctx)
+			// Pushing again won't succeed until the local branch is rebased, or the branch protection rules change
+			var rejected *git.PushRejectedError
+			var protected *git.ProtectedBranchError
+			if errors.As(err, &rejected) || errors.As(err, &protected) {
+				rejectedErr = err
+				return nil
+			}
+			return err
+		},
+	)
+	if rejectedErr != nil {
+		return rejectedErr
+	}
+	return err
+}
+
+// RebaseOnRemote replays the last local commit on top of the remote branch.
+func (c *gitClient) RebaseOnRemote(ctx context.Context) error {
+	var conflictErr error
+	err := c.Retry(
+		func() error {
+			err := c.git.RebaseOnRemote(ctx)
+			var conflict *git.RebaseConflictError
+			if errors.As(err, &conflict) {
+				conflictErr = err
+				return nil
+			}
+			return err
+		},
+	)
+	if conflictErr != nil {
+		return conflictErr
+	}
+	return err
+}
+
+func (c *gitClient) ForcePush(ctx context.Context, lease string) error {
+	var rejectedErr error
+	err := c.Retry(
+		func() error {
+			err := c.git.ForcePush(ctx, lease)
+			// Pushing again won't succeed until the remote branch points to the lease commit again
+			var rejected *git.PushRejectedError
+			if errors.As(err, &rejected) {
+				rejectedErr = err
+				return nil
+			}
+			return err
+		},
+	)
+	if rejectedErr != nil {
+		return rejectedErr
+	}
+	return err
+}
+
+func (c *gitClient) ResetSoft(commit string) error {
+	return c.git.ResetSoft(commit)
+}
+
+func (c *gitClient) DeleteRemoteBranch(ctx context.Context, branch string) error {
+	return c.Retry(
+		func() error {
+			return c.git.DeleteRemoteBranch(ctx, branch)
+		},
+	)
+}
+
+func (c *gitClient) PushToBranch(ctx context.Context, branch string) error {
+	return c.Retry(
+		func() error {
+			return c.git.PushToBranch(ctx, branch)
+		},
+	)
+}
+
+func (c *gitClient) PushTag(ctx context.Context, name string) error {
 	return c.Retry(
 		func() error {
-			return c.git.Push(ctx)
+			return c.git.PushTag(ctx, name)
 		},
 	)
 }
@@ -91,6 +239,78 @@ func (c *gitClient) PathExists(ctx context.Context, owner, repo, branch, path st
 	return exists, err
 }
 
+func (c *gitClient) ValidateProvider(ctx context.Context) error {
+	if c.gitProvider == nil {
+		return nil
+	}
+
+	return c.gitProvider.Validate(ctx)
+}
+
+// ValidateProviderPermissions checks the git provider credentials have the permissions required to install GitOps,
+// if the provider supports checking them.
+func (c *gitClient) ValidateProviderPermissions(ctx context.Context) error {
+	p, ok := c.gitProvider.(git.PermissionsProviderClient)
+	if !ok {
+		return nil
+	}
+
+	return p.ValidatePermissions(ctx)
+}
+
+// ValidatePushAccess checks the git provider credentials can push to the repository, if the provider supports checking it.
+func (c *gitClient) ValidatePushAccess(ctx context.Context) error {
+	p, ok := c.gitProvider.(git.PushAccessProviderClient)
+	if !ok {
+		return nil
+	}
+
+	return c.Retry(
+		func() error {
+			return p.ValidatePushAccess(ctx)
+		},
+	)
+}
+
+// ValidateBranchPush checks the protection rules of the branch accept direct pushes, if the provider supports checking them.
+// It's not retried, since a protected branch won't accept pushes until its rules are changed.
+func (c *gitClient) ValidateBranchPush(ctx context.Context, branch string) error {
+	p, ok := c.gitProvider.(git.BranchProtectionProviderClient)
+	if !ok {
+		return nil
+	}
+
+	return p.ValidateBranchPush(ctx, branch)
+}
+
+func (c *gitClient) ValidateRemoteExists(ctx context.Context) error {
+	return c.Retry(
+		func() error {
+			return c.git.ValidateRemoteExists(ctx)
+		},
+	)
+}
+
+func (c *gitClient) RemoteBranchExists(ctx context.Context, branch string) (exists bool, err error) {
+	err = c.Retry(
+		func() error {
+			exists, err = c.git.RemoteBranchExists(ctx, branch)
+			return err
+		},
+	)
+	return exists, err
+}
+
+func (c *gitClient) RemoteBranches(ctx context.Context) (branches []string, err error) {
+	err = c.Retry(
+		func() error {
+			branches, err = c.git.RemoteBranches(ctx)
+			return err
+		},
+	)
+	return branches, err
+}
+
 func (c *gitClient) Add(filename string) error {
 	return c.git.Add(filename)
 }
@@ -103,6 +323,42 @@ func (c *gitClient) Commit(message string) error {
 	return c.git.Commit(message)
 }
 
+func (c *gitClient) Tag(name, message string) error {
+	return c.git.Tag(name, message)
+}
+
+func (c *gitClient) LastCommit() (*git.Commit, error) {
+	return c.git.LastCommit()
+}
+
+func (c *gitClient) AmendCommit(message string) error {
+	return c.git.AmendCommit(message)
+}
+
+// FetchAndReset fetches the branch and hard resets the local repository to it.
+func (c *gitClient) FetchAndReset(ctx context.Context, branch string) error {
+	return c.Retry(
+		func() error {
+			return c.git.FetchAndReset(ctx, branch)
+		},
+	)
+}
+
+// LocalChanges returns the changes of the local repository branch missing in the remote branch.
+func (c *gitClient) LocalChanges(ctx context.Context, branch string) (changes *git.LocalChanges, err error) {
+	err = c.Retry(
+		func() error {
+			changes, err = c.git.LocalChanges(ctx, branch)
+			return err
+		},
+	)
+	return changes, err
+}
+
+func (c *gitClient) StagedFiles() ([]string, error) {
+	return c.git.StagedFiles()
+}
+
 func (c *gitClient) Branch(name string) error {
 	return c.git.Branch(name)
 }
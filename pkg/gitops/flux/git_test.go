@@ -93,6 +93,119 @@ func TestGitClientCreateRepoError(t *testing.T) {
 	tt.Expect(tt.c.CreateRepo(tt.ctx, opts)).To(MatchError(ContainSubstring("error in create repo")), "gitClient.CreateRepo() should fail after 5 tries")
 }
 
+func TestGitClientCreatePullRequestSuccess(t *testing.T) {
+	tt := newGitClientTest(t)
+	opts := git.CreatePullRequestOpts{Head: "eksa/mgmt", Base: "main"}
+	want := &git.PullRequest{Number: 1}
+	tt.p.EXPECT().CreatePullRequest(tt.ctx, opts).Return(want, nil)
+
+	tt.Expect(tt.c.CreatePullRequest(tt.ctx, opts)).To(Equal(want))
+}
+
+func TestGitClientCreatePullRequestError(t *testing.T) {
+	tt := newGitClientTest(t)
+	opts := git.CreatePullRequestOpts{}
+	tt.p.EXPECT().CreatePullRequest(tt.ctx, opts).Return(nil, errors.New("error in create pull request")).Times(1)
+
+	_, err := tt.c.CreatePullRequest(tt.ctx, opts)
+	tt.Expect(err).To(MatchError(ContainSubstring("error in create pull request")), "gitClient.CreatePullRequest() should not be retried")
+}
+
+func TestGitClientCreatePullRequestNoProvider(t *testing.T) {
+	tt := newGitClientTest(t)
+	c := newGitClient(&gitFactory.GitTools{Provider: nil, Client: tt.g})
+
+	_, err := c.CreatePullRequest(tt.ctx, git.CreatePullRequestOpts{})
+	tt.Expect(err).To(MatchError(ContainSubstring("not supported by the generic git provider")))
+}
+
+type webhookProviderClient struct {
+	*mocks.MockProviderClient
+	*mocks.MockWebhookProviderClient
+}
+
+func TestGitClientCreateWebhookSuccess(t *testing.T) {
+	tt := newGitClientTest(t)
+	w := mocks.NewMockWebhookProviderClient(gomock.NewController(t))
+	c := newGitClient(&gitFactory.GitTools{Provider: &webhookProviderClient{tt.p, w}, Client: tt.g})
+	opts := git.CreateWebhookOpts{Url: "https://flux-webhook.example.com/hook/abc", Secret: "token", Events: []string{"push"}}
+	w.EXPECT().CreateWebhook(tt.ctx, opts).Return(nil)
+
+	tt.Expect(c.CreateWebhook(tt.ctx, opts)).To(Succeed())
+}
+
+func TestGitClientCreateWebhookError(t *testing.T) {
+	tt := newGitClientTest(t)
+	w := mocks.NewMockWebhookProviderClient(gomock.NewController(t))
+	c := newGitClient(&gitFactory.GitTools{Provider: &webhookProviderClient{tt.p, w}, Client: tt.g})
+	w.EXPECT().CreateWebhook(tt.ctx, git.CreateWebhookOpts{}).Return(errors.New("error in create webhook")).Times(1)
+
+	tt.Expect(c.CreateWebhook(tt.ctx, git.CreateWebhookOpts{})).To(MatchError(ContainSubstring("error in create webhook")), "gitClient.CreateWebhook() should not be retried")
+}
+
+func TestGitClientCreateWebhookNotSupported(t *testing.T) {
+	tt := newGitClientTest(t)
+
+	tt.Expect(tt.c.CreateWebhook(tt.ctx, git.CreateWebhookOpts{})).To(MatchError(ContainSubstring("webhooks are not supported by the git provider")))
+}
+
+func TestGitClientWebhookExistsRetrySuccess(t *testing.T) {
+	tt := newGitClientTest(t)
+	w := mocks.NewMockWebhookProviderClient(gomock.NewController(t))
+	c := newGitClient(&gitFactory.GitTools{Provider: &webhookProviderClient{tt.p, w}, Client: tt.g})
+	c.Retrier = retrier.NewWithMaxRetries(maxRetries, 0)
+	url := "https://flux-webhook.example.com/hook/abc"
+	w.EXPECT().WebhookExists(tt.ctx, url).Return(false, errors.New("error in list hooks")).Times(1)
+	w.EXPECT().WebhookExists(tt.ctx, url).Return(true, nil).Times(1)
+
+	tt.Expect(c.WebhookExists(tt.ctx, url)).To(BeTrue())
+}
+
+func TestGitClientWebhookExistsNotSupported(t *testing.T) {
+	tt := newGitClientTest(t)
+
+	_, err := tt.c.WebhookExists(tt.ctx, "https://flux-webhook.example.com/hook/abc")
+	tt.Expect(err).To(MatchError(ContainSubstring("webhooks are not supported by the git provider")))
+}
+
+func TestGitClientDeleteRepoSuccess(t *testing.T) {
+	tt := newGitClientTest(t)
+	opts := git.DeleteRepoOpts{Owner: "aws", Repository: "eksa-gitops"}
+	tt.p.EXPECT().DeleteRepo(tt.ctx, opts).Return(errors.New("error in delete repo")).Times(1)
+
+	tt.Expect(tt.c.DeleteRepo(tt.ctx, opts)).To(MatchError(ContainSubstring("error in delete repo")), "gitClient.DeleteRepo() should not be retried")
+}
+
+func TestGitClientDeleteRepoNoProvider(t *testing.T) {
+	tt := newGitClientTest(t)
+	c := newGitClient(&gitFactory.GitTools{Provider: nil, Client: tt.g})
+
+	tt.Expect(c.DeleteRepo(tt.ctx, git.DeleteRepoOpts{})).To(MatchError(ContainSubstring("not supported by the generic git provider")))
+}
+
+type archiveRepoProviderClient struct {
+	*mocks.MockProviderClient
+	*mocks.MockArchiveRepoProviderClient
+}
+
+func TestGitClientArchiveRepoSuccess(t *testing.T) {
+	tt := newGitClientTest(t)
+	a := mocks.NewMockArchiveRepoProviderClient(gomock.NewController(t))
+	c := newGitClient(&gitFactory.GitTools{Provider: &archiveRepoProviderClient{tt.p, a}, Client: tt.g})
+	c.Retrier = retrier.NewWithMaxRetries(maxRetries, 0)
+	opts := git.ArchiveRepoOpts{Owner: "aws", Repository: "eksa-gitops"}
+	a.EXPECT().ArchiveRepo(tt.ctx, opts).Return(errors.New("error in archive repo")).Times(4)
+	a.EXPECT().ArchiveRepo(tt.ctx, opts).Return(nil).Times(1)
+
+	tt.Expect(c.ArchiveRepo(tt.ctx, opts)).To(Succeed(), "gitClient.ArchiveRepo() should succeed with 5 tries")
+}
+
+func TestGitClientArchiveRepoNotSupported(t *testing.T) {
+	tt := newGitClientTest(t)
+
+	tt.Expect(tt.c.ArchiveRepo(tt.ctx, git.ArchiveRepoOpts{})).To(MatchError(ContainSubstring("archiving repositories is not supported by the git provider")))
+}
+
 func TestGitClientCloneSuccess(t *testing.T) {
 	tt := newGitClientTest(t)
 	tt.g.EXPECT().Clone(tt.ctx).Return(errors.New("error in clone repo")).Times(4)
@@ -125,6 +238,35 @@ func TestGitClientPushError(t *testing.T) {
 	tt.Expect(tt.c.Push(tt.ctx)).To(MatchError(ContainSubstring("error in push repo")), "gitClient.Push() should fail after 5 tries")
 }
 
+func TestGitClientPushRejected(t *testing.T) {
+	tt := newGitClientTest(t)
+	tt.g.EXPECT().Push(tt.ctx).Return(&git.PushRejectedError{Branch: "main", Err: errors.New("non-fast-forward update")}).Times(1)
+
+	tt.Expect(tt.c.Push(tt.ctx)).To(MatchError(ContainSubstring("push to branch main rejected")), "gitClient.Push() should not retry a rejected push")
+}
+
+func TestGitClientPushProtectedBranch(t *testing.T) {
+	tt := newGitClientTest(t)
+	tt.g.EXPECT().Push(tt.ctx).Return(&git.ProtectedBranchError{Branch: "main", Err: errors.New("protected branch hook declined")}).Times(1)
+
+	tt.Expect(tt.c.Push(tt.ctx)).To(MatchError(ContainSubstring("branch main is protected")), "gitClient.Push() should not retry a push rejected by the branch protection")
+}
+
+func TestGitClientRebaseOnRemoteSuccess(t *testing.T) {
+	tt := newGitClientTest(t)
+	tt.g.EXPECT().RebaseOnRemote(tt.ctx).Return(errors.New("error in fetch")).Times(1)
+	tt.g.EXPECT().RebaseOnRemote(tt.ctx).Return(nil).Times(1)
+
+	tt.Expect(tt.c.RebaseOnRemote(tt.ctx)).To(Succeed())
+}
+
+func TestGitClientRebaseOnRemoteConflict(t *testing.T) {
+	tt := newGitClientTest(t)
+	tt.g.EXPECT().RebaseOnRemote(tt.ctx).Return(&git.RebaseConflictError{Paths: []string{"clusters/mgmt/eksa-system/eksa-cluster.yaml"}}).Times(1)
+
+	tt.Expect(tt.c.RebaseOnRemote(tt.ctx)).To(MatchError(ContainSubstring("files changed both locally and in the remote branch")), "gitClient.RebaseOnRemote() should not retry a conflict")
+}
+
 func TestGitClientPullSuccess(t *testing.T) {
 	tt := newGitClientTest(t)
 	tt.g.EXPECT().Pull(tt.ctx, "").Return(errors.New("error in pull repo")).Times(4)
@@ -239,3 +381,176 @@ func TestGitClientInitError(t *testing.T) {
 
 	tt.Expect(tt.c.Init()).To(MatchError(ContainSubstring("error in init")), "gitClient.Init() should fail after 1 try")
 }
+
+func TestGitClientValidateProviderSuccess(t *testing.T) {
+	tt := newGitClientTest(t)
+	tt.p.EXPECT().Validate(tt.ctx).Return(nil)
+
+	tt.Expect(tt.c.ValidateProvider(tt.ctx)).To(Succeed(), "gitClient.ValidateProvider() should succeed with 1 try")
+}
+
+func TestGitClientValidateProviderSkip(t *testing.T) {
+	tt := newGitClientTest(t)
+
+	c := newGitClient(&gitFactory.GitTools{Provider: nil, Client: tt.g})
+	tt.Expect(c.ValidateProvider(tt.ctx)).To(Succeed())
+}
+
+func TestGitClientValidateProviderError(t *testing.T) {
+	tt := newGitClientTest(t)
+	tt.p.EXPECT().Validate(tt.ctx).Return(errors.New("error in validate"))
+
+	tt.Expect(tt.c.ValidateProvider(tt.ctx)).To(MatchError(ContainSubstring("error in validate")), "gitClient.ValidateProvider() should fail after 1 try")
+}
+
+type permissionsProviderClient struct {
+	*mocks.MockProviderClient
+	*mocks.MockPermissionsProviderClient
+}
+
+func TestGitClientValidateProviderPermissionsError(t *testing.T) {
+	tt := newGitClientTest(t)
+	p := mocks.NewMockPermissionsProviderClient(gomock.NewController(t))
+	c := newGitClient(&gitFactory.GitTools{Provider: &permissionsProviderClient{tt.p, p}, Client: tt.g})
+	p.EXPECT().ValidatePermissions(tt.ctx).Return(errors.New("missing the admin permissions")).Times(1)
+
+	tt.Expect(c.ValidateProviderPermissions(tt.ctx)).To(MatchError(ContainSubstring("missing the admin permissions")), "gitClient.ValidateProviderPermissions() should not be retried")
+}
+
+func TestGitClientValidateProviderPermissionsNotSupported(t *testing.T) {
+	tt := newGitClientTest(t)
+
+	tt.Expect(tt.c.ValidateProviderPermissions(tt.ctx)).To(Succeed())
+}
+
+type pushAccessProviderClient struct {
+	*mocks.MockProviderClient
+	*mocks.MockPushAccessProviderClient
+}
+
+func TestGitClientValidatePushAccessSuccess(t *testing.T) {
+	tt := newGitClientTest(t)
+	p := mocks.NewMockPushAccessProviderClient(gomock.NewController(t))
+	c := newGitClient(&gitFactory.GitTools{Provider: &pushAccessProviderClient{tt.p, p}, Client: tt.g})
+	c.Retrier = tt.c.Retrier
+	p.EXPECT().ValidatePushAccess(tt.ctx).Return(errors.New("error in get repository")).Times(4)
+	p.EXPECT().ValidatePushAccess(tt.ctx).Return(nil).Times(1)
+
+	tt.Expect(c.ValidatePushAccess(tt.ctx)).To(Succeed(), "gitClient.ValidatePushAccess() should succeed with 5 tries")
+}
+
+func TestGitClientValidatePushAccessNotSupported(t *testing.T) {
+	tt := newGitClientTest(t)
+
+	tt.Expect(tt.c.ValidatePushAccess(tt.ctx)).To(Succeed())
+}
+
+func TestGitClientRemoteBranchExistsSuccess(t *testing.T) {
+	tt := newGitClientTest(t)
+	tt.g.EXPECT().RemoteBranchExists(tt.ctx, "main").Return(false, errors.New("error in list remote")).Times(4)
+	tt.g.EXPECT().RemoteBranchExists(tt.ctx, "main").Return(true, nil).Times(1)
+
+	exists, err := tt.c.RemoteBranchExists(tt.ctx, "main")
+	tt.Expect(err).To(Succeed(), "gitClient.RemoteBranchExists() should succeed with 5 tries")
+	tt.Expect(exists).To(BeTrue())
+}
+
+func TestGitClientRemoteBranchExistsError(t *testing.T) {
+	tt := newGitClientTest(t)
+	tt.g.EXPECT().RemoteBranchExists(tt.ctx, "main").Return(false, errors.New("error in list remote")).Times(5)
+
+	_, err := tt.c.RemoteBranchExists(tt.ctx, "main")
+	tt.Expect(err).To(MatchError(ContainSubstring("error in list remote")), "gitClient.RemoteBranchExists() should fail after 5 tries")
+}
+
+func TestGitClientRemoteBranchesSuccess(t *testing.T) {
+	tt := newGitClientTest(t)
+	tt.g.EXPECT().RemoteBranches(tt.ctx).Return(nil, errors.New("error in list remote")).Times(4)
+	tt.g.EXPECT().RemoteBranches(tt.ctx).Return([]string{"main"}, nil).Times(1)
+
+	branches, err := tt.c.RemoteBranches(tt.ctx)
+	tt.Expect(err).To(Succeed(), "gitClient.RemoteBranches() should succeed with 5 tries")
+	tt.Expect(branches).To(Equal([]string{"main"}))
+}
+
+type branchProtectionProviderClient struct {
+	*mocks.MockProviderClient
+	*mocks.MockBranchProtectionProviderClient
+}
+
+func TestGitClientValidateBranchPushProtected(t *testing.T) {
+	tt := newGitClientTest(t)
+	p := mocks.NewMockBranchProtectionProviderClient(gomock.NewController(t))
+	c := newGitClient(&gitFactory.GitTools{Provider: &branchProtectionProviderClient{tt.p, p}, Client: tt.g})
+	p.EXPECT().ValidateBranchPush(tt.ctx, "main").Return(&git.ProtectedBranchError{Branch: "main"}).Times(1)
+
+	tt.Expect(c.ValidateBranchPush(tt.ctx, "main")).To(MatchError("branch main is protected and doesn't accept direct pushes"), "gitClient.ValidateBranchPush() should not be retried")
+}
+
+func TestGitClientValidateBranchPushNotSupported(t *testing.T) {
+	tt := newGitClientTest(t)
+
+	tt.Expect(tt.c.ValidateBranchPush(tt.ctx, "main")).To(Succeed())
+}
+
+func TestGitClientValidateRemoteExistsSuccess(t *testing.T) {
+	tt := newGitClientTest(t)
+	tt.g.EXPECT().ValidateRemoteExists(tt.ctx).Return(errors.New("error in validate remote")).Times(4)
+	tt.g.EXPECT().ValidateRemoteExists(tt.ctx).Return(nil).Times(1)
+
+	tt.Expect(tt.c.ValidateRemoteExists(tt.ctx)).To(Succeed(), "gitClient.ValidateRemoteExists() should succeed with 5 tries")
+}
+
+func TestGitClientValidateRemoteExistsError(t *testing.T) {
+	tt := newGitClientTest(t)
+	tt.g.EXPECT().ValidateRemoteExists(tt.ctx).Return(errors.New("error in validate remote")).Times(5)
+	tt.g.EXPECT().ValidateRemoteExists(tt.ctx).Return(nil).AnyTimes()
+
+	tt.Expect(tt.c.ValidateRemoteExists(tt.ctx)).To(MatchError(ContainSubstring("error in validate remote")), "gitClient.ValidateRemoteExists() should fail after 5 tries")
+}
+
+func TestGitClientForcePushSuccess(t *testing.T) {
+	tt := newGitClientTest(t)
+	tt.g.EXPECT().ForcePush(tt.ctx, "a1b2c3").Return(errors.New("error in force push")).Times(4)
+	tt.g.EXPECT().ForcePush(tt.ctx, "a1b2c3").Return(nil).Times(1)
+
+	tt.Expect(tt.c.ForcePush(tt.ctx, "a1b2c3")).To(Succeed(), "gitClient.ForcePush() should succeed with 5 tries")
+}
+
+func TestGitClientForcePushError(t *testing.T) {
+	tt := newGitClientTest(t)
+	tt.g.EXPECT().ForcePush(tt.ctx, "a1b2c3").Return(errors.New("error in force push")).Times(5)
+	tt.g.EXPECT().ForcePush(tt.ctx, "a1b2c3").Return(nil).AnyTimes()
+
+	tt.Expect(tt.c.ForcePush(tt.ctx, "a1b2c3")).To(MatchError(ContainSubstring("error in force push")), "gitClient.ForcePush() should fail after 5 tries")
+}
+
+func TestGitClientForcePushLeaseRejected(t *testing.T) {
+	tt := newGitClientTest(t)
+	rejected := &git.PushRejectedError{Branch: "main", Err: errors.New("required to be a1b2c3")}
+	tt.g.EXPECT().ForcePush(tt.ctx, "a1b2c3").Return(rejected).Times(1)
+
+	tt.Expect(tt.c.ForcePush(tt.ctx, "a1b2c3")).To(MatchError(rejected), "gitClient.ForcePush() shouldn't retry a rejected lease")
+}
+
+func TestGitClientDeleteRemoteBranchSuccess(t *testing.T) {
+	tt := newGitClientTest(t)
+	tt.g.EXPECT().DeleteRemoteBranch(tt.ctx, "main").Return(errors.New("error in delete branch")).Times(4)
+	tt.g.EXPECT().DeleteRemoteBranch(tt.ctx, "main").Return(nil).Times(1)
+
+	tt.Expect(tt.c.DeleteRemoteBranch(tt.ctx, "main")).To(Succeed(), "gitClient.DeleteRemoteBranch() should succeed with 5 tries")
+}
+
+func TestGitClientLastCommitSuccess(t *testing.T) {
+	tt := newGitClientTest(t)
+	tt.g.EXPECT().LastCommit().Return(&git.Commit{Hash: "a1"}, nil)
+
+	tt.Expect(tt.c.LastCommit()).To(Equal(&git.Commit{Hash: "a1"}), "gitClient.LastCommit() should succeed with 1 try")
+}
+
+func TestGitClientAmendCommitError(t *testing.T) {
+	tt := newGitClientTest(t)
+	tt.g.EXPECT().AmendCommit("").Return(errors.New("error in amend"))
+
+	tt.Expect(tt.c.AmendCommit("")).To(MatchError(ContainSubstring("error in amend")), "gitClient.AmendCommit() should fail after 1 try")
+}
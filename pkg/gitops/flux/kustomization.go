@@ -0,0 +1,309 @@
+package flux
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	apiyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+const (
+	repairKustomizationCommitMessage = "Repair commit of kustomization references; generated by EKS-A CLI"
+
+	kustomizationResourcesKey = "resources"
+	kustomizationPatchesKey   = "patchesStrategicMerge"
+	// fluxComponentsFileName is generated by flux bootstrap and only written by EKS-A for the OCI sync,
+	// so it can legitimately be missing before the bootstrap has run.
+	fluxComponentsFileName = "gotk-components.yaml"
+)
+
+// DanglingKustomizationReferencesError is returned when a kustomization file lists
+// resources or patches that do not exist in the repository.
+type DanglingKustomizationReferencesError struct {
+	Kustomization string
+	Missing       []string
+}
+
+func (e *DanglingKustomizationReferencesError) Error() string {
+	return fmt.Sprintf("kustomization %s references files that do not exist: %s", e.Kustomization, strings.Join(e.Missing, ", "))
+}
+
+// ValidateKustomization syncs the git repository and verifies that every file referenced by the
+// eksa-system and flux-system kustomizations exists in the repository. The errors of all the kustomizations
+// are returned together.
+func (f *Flux) ValidateKustomization(ctx context.Context, clusterSpec *cluster.Spec) error {
+	if f.shouldSkipFlux() {
+		logger.Info("GitOps field not specified, validate kustomization skipped")
+		return nil
+	}
+
+	fc, err := newFluxForCluster(f, clusterSpec, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fc.syncGitRepo(ctx); err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, dir := range fc.kustomizationDirs() {
+		if _, err := fc.findDanglingKustomizationReferences(dir); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return utilerrors.Reduce(utilerrors.NewAggregate(errs))
+	}
+
+	logger.V(3).Info("Finished validating kustomization references", "repository", fc.repository())
+	return nil
+}
+
+// RepairKustomization syncs the git repository and rewrites any eksa-system or flux-system kustomization
+// with dangling references so that it only lists the files actually present, then commits the fix.
+func (f *Flux) RepairKustomization(ctx context.Context, clusterSpec *cluster.Spec) error {
+	if f.shouldSkipFlux() {
+		logger.Info("GitOps field not specified, repair kustomization skipped")
+		return nil
+	}
+
+	fc, err := newFluxForCluster(f, clusterSpec, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fc.syncGitRepo(ctx); err != nil {
+		return err
+	}
+
+	var repaired []string
+	for _, dir := range fc.kustomizationDirs() {
+		k, err := fc.findDanglingKustomizationReferences(dir)
+		if err == nil {
+			continue
+		}
+		if k == nil {
+			return err
+		}
+
+		logger.V(3).Info("Repairing kustomization", "kustomization", path.Join(dir, kustomizeFileName), "error", err)
+		if err := fc.rebuildKustomization(dir, k); err != nil {
+			return err
+		}
+
+		p := path.Join(dir, kustomizeFileName)
+		if err := f.gitClient.Add(p); err != nil {
+			return fmt.Errorf("adding %s to git: %v", p, err)
+		}
+		repaired = append(repaired, p)
+	}
+
+	if len(repaired) == 0 {
+		logger.V(3).Info("Kustomization references are valid, nothing to repair")
+		return nil
+	}
+
+	if err := f.pushToRemoteRepo(ctx, strings.Join(repaired, ", "), repairKustomizationCommitMessage); err != nil {
+		return err
+	}
+
+	logger.V(3).Info("Finished pushing repaired kustomization files to git", "repository", fc.repository())
+	return nil
+}
+
+func (fc *fluxForCluster) kustomizationDirs() []string {
+	dirs := []string{fc.eksaSystemDir()}
+	if fc.clusterSpec.Cluster.IsSelfManaged() {
+		dirs = append(dirs, fc.fluxSystemDir())
+	}
+	if fc.usesClusterTenants() {
+		dirs = append(dirs, fc.tenantDir())
+	}
+	if fc.usesHubAndSpoke() {
+		dirs = append(dirs, fc.spokeDir())
+	}
+	return dirs
+}
+
+// findDanglingKustomizationReferences parses the kustomization file in dir and returns it along with a
+// DanglingKustomizationReferencesError if any of its resources or patches don't exist.
+// A missing kustomization file is not considered an error, and remote resources are not checked.
+func (fc *fluxForCluster) findDanglingKustomizationReferences(dir string) (map[string]interface{}, error) {
+	p := path.Join(fc.writer.Dir(), dir, kustomizeFileName)
+	if !validations.FileExists(p) {
+		logger.V(4).Info("Kustomization file does not exist, skipping", "kustomization", p)
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(p)
+	if err != nil {
+		return nil, fmt.Errorf("reading kustomization %s: %v", p, err)
+	}
+
+	k := map[string]interface{}{}
+	if err := yaml.Unmarshal(content, &k); err != nil {
+		return nil, fmt.Errorf("parsing kustomization %s: %v", p, err)
+	}
+
+	var missing []string
+	for _, key := range []string{kustomizationResourcesKey, kustomizationPatchesKey} {
+		for _, file := range kustomizationEntries(k, key) {
+			if file == fluxComponentsFileName || isRemoteKustomizationEntry(file) {
+				continue
+			}
+			if !validations.FileExists(path.Join(fc.writer.Dir(), dir, file)) {
+				missing = append(missing, file)
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		return k, &DanglingKustomizationReferencesError{Kustomization: path.Join(dir, kustomizeFileName), Missing: missing}
+	}
+
+	return k, nil
+}
+
+// rebuildKustomization rewrites the kustomization file in dir so its resources are the listed resources that still
+// resolve, like remote urls, directories and files in subpaths, followed by the unlisted Kubernetes manifests present in
+// dir, and its patches are only the ones that still exist. All the other fields are preserved. The changelog and other
+// yaml files that aren't Kubernetes objects are never added.
+func (fc *fluxForCluster) rebuildKustomization(dir string, k map[string]interface{}) error {
+	dirPath := path.Join(fc.writer.Dir(), dir)
+
+	var patches []string
+	for _, file := range kustomizationEntries(k, kustomizationPatchesKey) {
+		if validations.FileExists(path.Join(dirPath, file)) {
+			patches = append(patches, file)
+		}
+	}
+
+	files, err := filepath.Glob(path.Join(dirPath, "*.yaml"))
+	if err != nil {
+		return fmt.Errorf("listing files in %s: %v", dir, err)
+	}
+
+	resources := []string{}
+	listed := map[string]bool{}
+	for _, entry := range kustomizationEntries(k, kustomizationResourcesKey) {
+		if entry == fluxComponentsFileName || isRemoteKustomizationEntry(entry) || validations.FileExists(path.Join(dirPath, entry)) {
+			resources = append(resources, entry)
+			listed[path.Clean(entry)] = true
+		}
+	}
+	for _, file := range files {
+		name := filepath.Base(file)
+		if name == kustomizeFileName || name == changelogFileName || listed[name] || containsString(patches, name) {
+			continue
+		}
+		if !isKubernetesManifest(file) {
+			logger.V(4).Info("File is not a Kubernetes manifest, not adding it to the kustomization", "file", path.Join(dir, name))
+			continue
+		}
+		resources = append(resources, name)
+	}
+
+	k[kustomizationResourcesKey] = resources
+	if _, ok := k[kustomizationPatchesKey]; ok {
+		k[kustomizationPatchesKey] = patches
+	}
+
+	content, err := yaml.Marshal(k)
+	if err != nil {
+		return fmt.Errorf("marshalling kustomization for %s: %v", dir, err)
+	}
+
+	w, err := fc.writer.WithDir(dir)
+	if err != nil {
+		return fmt.Errorf("initializing writer for %s: %v", dir, err)
+	}
+	w.CleanUpTemp()
+
+	if filePath, err := w.Write(kustomizeFileName, content, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("writing kustomization file into %s: %v", filePath, err)
+	}
+
+	return nil
+}
+
+// isKubernetesManifest returns true if file can be read and all its yaml documents are Kubernetes objects, with an
+// apiVersion and a kind.
+func isKubernetesManifest(file string) bool {
+	f, err := os.Open(file)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	found := false
+	r := apiyaml.NewYAMLReader(bufio.NewReader(f))
+	for {
+		doc, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return found
+		}
+		if err != nil {
+			return false
+		}
+
+		m := map[string]interface{}{}
+		if err := yaml.Unmarshal(doc, &m); err != nil {
+			return false
+		}
+		if len(m) == 0 {
+			continue
+		}
+		if _, ok := m["apiVersion"]; !ok {
+			return false
+		}
+		if _, ok := m["kind"]; !ok {
+			return false
+		}
+		found = true
+	}
+}
+
+// isRemoteKustomizationEntry returns true if a kustomization resource is a remote url, like a git repository, instead of
+// a path in the repository.
+func isRemoteKustomizationEntry(entry string) bool {
+	return strings.Contains(entry, "://") || strings.HasPrefix(entry, "git@") || strings.HasPrefix(entry, "github.com/")
+}
+
+func kustomizationEntries(k map[string]interface{}, key string) []string {
+	raw, ok := k[key].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	entries := make([]string, 0, len(raw))
+	for _, e := range raw {
+		if s, ok := e.(string); ok {
+			entries = append(entries, s)
+		}
+	}
+	return entries
+}
+
+func containsString(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,185 @@
+package flux_test
+
+import (
+	"errors"
+	"os"
+	"path"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+)
+
+const (
+	testEksaSystemDir = "clusters/management-cluster/management-cluster/eksa-system"
+	testFluxSystemDir = "clusters/management-cluster/flux-system"
+)
+
+func setupKustomizationRepo(t *testing.T, g fluxTest, files map[string]string) *cluster.Spec {
+	t.Helper()
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+
+	if err := os.MkdirAll(path.Join(g.writer.Dir(), ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, content := range files {
+		p := path.Join(g.writer.Dir(), name)
+		if err := os.MkdirAll(path.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	return clusterSpec
+}
+
+func validKustomizationRepoFiles() map[string]string {
+	return map[string]string{
+		path.Join(testEksaSystemDir, "kustomization.yaml"): "resources:\n- eksa-cluster.yaml\n",
+		path.Join(testEksaSystemDir, "eksa-cluster.yaml"):  "kind: Cluster\n",
+		path.Join(testFluxSystemDir, "kustomization.yaml"): "namespace: flux-system\nresources:\n- gotk-components.yaml\n- gotk-sync.yaml\npatchesStrategicMerge:\n- gotk-patches.yaml\n",
+		path.Join(testFluxSystemDir, "gotk-sync.yaml"):     "kind: GitRepository\n",
+		path.Join(testFluxSystemDir, "gotk-patches.yaml"):  "kind: Deployment\n",
+	}
+}
+
+func TestValidateKustomizationSuccess(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := setupKustomizationRepo(t, g, validKustomizationRepoFiles())
+
+	g.Expect(g.gitOpsFlux.ValidateKustomization(g.ctx, clusterSpec)).To(Succeed())
+}
+
+func TestValidateKustomizationDanglingReferences(t *testing.T) {
+	g := newFluxTest(t)
+	files := validKustomizationRepoFiles()
+	delete(files, path.Join(testFluxSystemDir, "gotk-patches.yaml"))
+	clusterSpec := setupKustomizationRepo(t, g, files)
+
+	err := g.gitOpsFlux.ValidateKustomization(g.ctx, clusterSpec)
+	var danglingErr *flux.DanglingKustomizationReferencesError
+	g.Expect(errors.As(err, &danglingErr)).To(BeTrue())
+	g.Expect(danglingErr.Missing).To(ConsistOf("gotk-patches.yaml"))
+	g.Expect(err).To(MatchError(ContainSubstring("kustomization clusters/management-cluster/flux-system/kustomization.yaml references files that do not exist: gotk-patches.yaml")))
+}
+
+func TestValidateKustomizationAllDanglingReferences(t *testing.T) {
+	g := newFluxTest(t)
+	files := validKustomizationRepoFiles()
+	delete(files, path.Join(testEksaSystemDir, "eksa-cluster.yaml"))
+	delete(files, path.Join(testFluxSystemDir, "gotk-patches.yaml"))
+	clusterSpec := setupKustomizationRepo(t, g, files)
+
+	err := g.gitOpsFlux.ValidateKustomization(g.ctx, clusterSpec)
+	var aggregate utilerrors.Aggregate
+	g.Expect(errors.As(err, &aggregate)).To(BeTrue())
+	g.Expect(aggregate.Errors()).To(HaveLen(2))
+	g.Expect(err).To(MatchError(ContainSubstring("kustomization clusters/management-cluster/management-cluster/eksa-system/kustomization.yaml references files that do not exist: eksa-cluster.yaml")))
+	g.Expect(err).To(MatchError(ContainSubstring("kustomization clusters/management-cluster/flux-system/kustomization.yaml references files that do not exist: gotk-patches.yaml")))
+}
+
+func TestValidateKustomizationRemoteResources(t *testing.T) {
+	g := newFluxTest(t)
+	files := validKustomizationRepoFiles()
+	files[path.Join(testEksaSystemDir, "kustomization.yaml")] = "resources:\n- eksa-cluster.yaml\n- https://github.com/org/addons//base?ref=v1\n- github.com/org/addons/overlay\n"
+	clusterSpec := setupKustomizationRepo(t, g, files)
+
+	g.Expect(g.gitOpsFlux.ValidateKustomization(g.ctx, clusterSpec)).To(Succeed())
+}
+
+func TestValidateKustomizationSkip(t *testing.T) {
+	g := newFluxTest(t)
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, nil, nil)
+
+	g.Expect(f.ValidateKustomization(g.ctx, g.clusterSpec)).To(Succeed())
+}
+
+func TestRepairKustomizationSuccess(t *testing.T) {
+	g := newFluxTest(t)
+	files := validKustomizationRepoFiles()
+	files[path.Join(testEksaSystemDir, "kustomization.yaml")] = "resources:\n- eksa-cluster.yaml\n- removed.yaml\n"
+	files[path.Join(testEksaSystemDir, "extra.yaml")] = "apiVersion: v1\nkind: ConfigMap\n"
+	clusterSpec := setupKustomizationRepo(t, g, files)
+
+	g.git.EXPECT().Add(path.Join(testEksaSystemDir, "kustomization.yaml")).Return(nil)
+	g.git.EXPECT().Commit("Repair commit of kustomization references; generated by EKS-A CLI").Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+
+	g.Expect(g.gitOpsFlux.RepairKustomization(g.ctx, clusterSpec)).To(Succeed())
+
+	content, err := os.ReadFile(path.Join(g.writer.Dir(), testEksaSystemDir, "kustomization.yaml"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(content)).To(Equal("resources:\n- eksa-cluster.yaml\n- extra.yaml\n"))
+}
+
+func TestRepairKustomizationSkipsFilesNotKubernetesManifests(t *testing.T) {
+	g := newFluxTest(t)
+	files := validKustomizationRepoFiles()
+	files[path.Join(testEksaSystemDir, "kustomization.yaml")] = "resources:\n- eksa-cluster.yaml\n- removed.yaml\n"
+	files[path.Join(testEksaSystemDir, "CHANGELOG.yaml")] = "cluster: management-cluster\nentries:\n- operation: create\n"
+	files[path.Join(testEksaSystemDir, "values.yaml")] = "replicas: 2\n"
+	files[path.Join(testEksaSystemDir, "partial.yaml")] = "apiVersion: v1\nkind: ConfigMap\n---\nreplicas: 2\n"
+	files[path.Join(testEksaSystemDir, "multi.yaml")] = "apiVersion: v1\nkind: ConfigMap\n---\n---\napiVersion: v1\nkind: Secret\n"
+	clusterSpec := setupKustomizationRepo(t, g, files)
+
+	g.git.EXPECT().Add(path.Join(testEksaSystemDir, "kustomization.yaml")).Return(nil)
+	g.git.EXPECT().Commit("Repair commit of kustomization references; generated by EKS-A CLI").Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+
+	g.Expect(g.gitOpsFlux.RepairKustomization(g.ctx, clusterSpec)).To(Succeed())
+
+	content, err := os.ReadFile(path.Join(g.writer.Dir(), testEksaSystemDir, "kustomization.yaml"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(content)).To(Equal("resources:\n- eksa-cluster.yaml\n- multi.yaml\n"))
+}
+
+func TestRepairKustomizationKeepsResolvingEntries(t *testing.T) {
+	g := newFluxTest(t)
+	files := validKustomizationRepoFiles()
+	files[path.Join(testEksaSystemDir, "kustomization.yaml")] = "resources:\n- addons\n- https://github.com/org/addons//base?ref=v1\n- extra/config.yaml\n- eksa-cluster.yaml\n- removed.yaml\n- removed-dir\n"
+	files[path.Join(testEksaSystemDir, "addons", "kustomization.yaml")] = "resources: []\n"
+	files[path.Join(testEksaSystemDir, "extra", "config.yaml")] = "kind: ConfigMap\n"
+	clusterSpec := setupKustomizationRepo(t, g, files)
+
+	g.git.EXPECT().Add(path.Join(testEksaSystemDir, "kustomization.yaml")).Return(nil)
+	g.git.EXPECT().Commit("Repair commit of kustomization references; generated by EKS-A CLI").Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+
+	g.Expect(g.gitOpsFlux.RepairKustomization(g.ctx, clusterSpec)).To(Succeed())
+
+	content, err := os.ReadFile(path.Join(g.writer.Dir(), testEksaSystemDir, "kustomization.yaml"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(content)).To(Equal("resources:\n- addons\n- https://github.com/org/addons//base?ref=v1\n- extra/config.yaml\n- eksa-cluster.yaml\n"))
+}
+
+func TestRepairKustomizationNothingToRepair(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := setupKustomizationRepo(t, g, validKustomizationRepoFiles())
+
+	g.Expect(g.gitOpsFlux.RepairKustomization(g.ctx, clusterSpec)).To(Succeed())
+}
+
+func TestRepairKustomizationPushError(t *testing.T) {
+	g := newFluxTest(t)
+	files := validKustomizationRepoFiles()
+	delete(files, path.Join(testFluxSystemDir, "gotk-sync.yaml"))
+	clusterSpec := setupKustomizationRepo(t, g, files)
+
+	g.git.EXPECT().Add(path.Join(testFluxSystemDir, "kustomization.yaml")).Return(nil)
+	g.git.EXPECT().Commit("Repair commit of kustomization references; generated by EKS-A CLI").Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(errors.New("error in push"))
+
+	g.Expect(g.gitOpsFlux.RepairKustomization(g.ctx, clusterSpec)).To(MatchError(ContainSubstring("error in push")))
+
+	content, err := os.ReadFile(path.Join(g.writer.Dir(), testFluxSystemDir, "kustomization.yaml"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(content)).To(Equal("namespace: flux-system\npatchesStrategicMerge:\n- gotk-patches.yaml\nresources:\n- gotk-components.yaml\n"))
+}
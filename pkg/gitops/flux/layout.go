@@ -0,0 +1,104 @@
+package flux
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+// clusterLayoutValues is the cluster metadata available to the templated eksaSystemDir and fluxSystemDir.
+type clusterLayoutValues struct {
+	clusterConfigPathValues
+	// FluxNamespace is the flux system namespace.
+	FluxNamespace string
+}
+
+// clusterLayout is the directory layout of a cluster in the repository, relative to its cluster config path.
+type clusterLayout struct {
+	eksaSystemDir string
+	fluxSystemDir string
+}
+
+// resolveClusterLayout returns the eksaSystemDir and fluxSystemDir of the FluxConfig, executing them as templates with
+// the cluster metadata. They default to <cluster name>/eksa-system and the flux system namespace. Since flux bootstrap
+// writes its components to <path>/<namespace>, the fluxSystemDir must end with the flux system namespace, and its parent
+// is the path flux syncs the cluster from.
+func resolveClusterLayout(clusterSpec *cluster.Spec) (clusterLayout, error) {
+	spec := clusterSpec.FluxConfig.Spec
+	values := clusterLayoutValues{
+		clusterConfigPathValues: clusterConfigPathValues{
+			Name:      clusterSpec.Cluster.Name,
+			Namespace: clusterSpec.Cluster.Namespace,
+			Labels:    clusterSpec.Cluster.Labels,
+		},
+		FluxNamespace: spec.SystemNamespace,
+	}
+
+	layout := clusterLayout{
+		eksaSystemDir: path.Join(clusterSpec.Cluster.Name, eksaSystemDirName),
+		fluxSystemDir: spec.SystemNamespace,
+	}
+
+	var err error
+	if spec.EksaSystemDir != "" {
+		if layout.eksaSystemDir, err = resolveLayoutDir("eksaSystemDir", spec.EksaSystemDir, values); err != nil {
+			return clusterLayout{}, err
+		}
+	}
+
+	if spec.FluxSystemDir != "" {
+		if layout.fluxSystemDir, err = resolveLayoutDir("fluxSystemDir", spec.FluxSystemDir, values); err != nil {
+			return clusterLayout{}, err
+		}
+		if path.Base(layout.fluxSystemDir) != spec.SystemNamespace {
+			return clusterLayout{}, fmt.Errorf("fluxSystemDir %s must end with the flux system namespace %s", layout.fluxSystemDir, spec.SystemNamespace)
+		}
+	}
+
+	if clusterSpec.Cluster.IsSelfManaged() && !isSubDir(layout.eksaSystemDir, path.Dir(layout.fluxSystemDir)) {
+		return clusterLayout{}, fmt.Errorf("eksaSystemDir %s must be under %s, the directory flux syncs the cluster from", layout.eksaSystemDir, path.Dir(layout.fluxSystemDir))
+	}
+
+	return layout, nil
+}
+
+func resolveLayoutDir(field, dir string, values clusterLayoutValues) (string, error) {
+	resolved := dir
+	if strings.Contains(dir, "{{") {
+		r, err := templater.Execute(dir, values)
+		if err != nil {
+			return "", fmt.Errorf("resolving %s template %s: %v", field, dir, err)
+		}
+		resolved = string(r)
+	}
+
+	if err := validateResolvedClusterConfigPath(resolved); err != nil {
+		return "", fmt.Errorf("resolving %s %s: %v", field, dir, err)
+	}
+	return resolved, nil
+}
+
+// resolveSyncPath returns the path flux syncs the cluster from: the parent of its fluxSystemDir.
+func resolveSyncPath(clusterSpec *cluster.Spec) (string, error) {
+	configPath, err := resolveClusterConfigPath(clusterSpec)
+	if err != nil {
+		return "", err
+	}
+	layout, err := resolveClusterLayout(clusterSpec)
+	if err != nil {
+		return "", err
+	}
+	return path.Dir(path.Join(configPath, layout.fluxSystemDir)), nil
+}
+
+// hasCustomEksaSystemDir returns true if the FluxConfig overrides the default <cluster name>/eksa-system layout.
+func hasCustomEksaSystemDir(clusterSpec *cluster.Spec) bool {
+	return clusterSpec.FluxConfig.Spec.EksaSystemDir != ""
+}
+
+func isSubDir(dir, parent string) bool {
+	return parent == "." || dir == parent || strings.HasPrefix(dir, parent+"/")
+}
@@ -0,0 +1,125 @@
+package flux_test
+
+import (
+	"path"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+func TestUpdateGitEksaSpecTemplatedEksaSystemDir(t *testing.T) {
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := templatedPathClusterSpec(t, "")
+	clusterSpec.FluxConfig.Spec.EksaSystemDir = "{{.Labels.env}}/{{.Name}}"
+
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add("clusters/management-cluster/prod/management-cluster").Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+
+	g.Expect(g.gitOpsFlux.UpdateGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+	g.Expect(path.Join(g.writer.Dir(), "clusters/management-cluster/prod/management-cluster/eksa-cluster.yaml")).To(BeARegularFile())
+}
+
+func TestBootstrapGithubFluxSystemDir(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{}
+	clusterSpec := templatedPathClusterSpec(t, "clusters/{{.Name}}")
+	clusterSpec.FluxConfig.Spec.FluxSystemDir = "infra/{{.FluxNamespace}}"
+	clusterSpec.FluxConfig.Spec.EksaSystemDir = "infra/eksa-system"
+
+	wantConfig := clusterSpec.FluxConfig.DeepCopy()
+	wantConfig.Spec.ClusterConfigPath = "clusters/management-cluster/infra"
+	g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, wantConfig).Return(nil)
+
+	g.Expect(g.gitOpsFlux.BootstrapGithub(g.ctx, cluster, clusterSpec)).To(Succeed())
+}
+
+func TestResolveGitSettingsClusterLayout(t *testing.T) {
+	g := NewWithT(t)
+	clusterSpec := templatedPathClusterSpec(t, "fleet")
+	clusterSpec.FluxConfig.Spec.FluxSystemDir = "{{.Labels.region}}/flux-system"
+	clusterSpec.FluxConfig.Spec.EksaSystemDir = "{{.Labels.region}}/{{.Name}}/{{.Namespace}}"
+
+	settings, err := flux.ResolveGitSettings(clusterSpec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(settings.FluxSystemDir).To(Equal("fleet/us-west-2/flux-system"))
+	g.Expect(settings.EksaSystemDir).To(Equal("fleet/us-west-2/management-cluster/default"))
+}
+
+func TestResolveGitSettingsClusterLayoutErrors(t *testing.T) {
+	tests := []struct {
+		testName      string
+		eksaSystemDir string
+		fluxSystemDir string
+		wantErr       string
+	}{
+		{
+			testName:      "flux system dir without namespace",
+			fluxSystemDir: "infra/flux",
+			wantErr:       "fluxSystemDir infra/flux must end with the flux system namespace flux-system",
+		},
+		{
+			testName:      "eksa system dir not synced",
+			eksaSystemDir: "{{.Name}}/eksa-system",
+			fluxSystemDir: "infra/flux-system",
+			wantErr:       "eksaSystemDir management-cluster/eksa-system must be under infra",
+		},
+		{
+			testName:      "parent directory",
+			eksaSystemDir: "../{{.Name}}",
+			wantErr:       "can't contain empty, '.' or '..' segments",
+		},
+		{
+			testName:      "missing label",
+			eksaSystemDir: "{{.Labels.team}}/{{.Name}}",
+			wantErr:       "references cluster metadata that is not set",
+		},
+		{
+			testName:      "invalid template",
+			eksaSystemDir: "{{.Name",
+			wantErr:       "resolving eksaSystemDir template",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			clusterSpec := templatedPathClusterSpec(t, "fleet")
+			clusterSpec.FluxConfig.Spec.EksaSystemDir = tt.eksaSystemDir
+			clusterSpec.FluxConfig.Spec.FluxSystemDir = tt.fluxSystemDir
+
+			_, err := flux.ResolveGitSettings(clusterSpec)
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
+
+func TestResolveGitSettingsWorkloadClusterEksaSystemDir(t *testing.T) {
+	g := NewWithT(t)
+	clusterConfig := v1alpha1.NewCluster("workload-cluster")
+	clusterConfig.SetManagedBy("management-cluster")
+	clusterSpec := newClusterSpec(t, clusterConfig, "fleet")
+	clusterSpec.FluxConfig.Spec.EksaSystemDir = "workloads/{{.Name}}"
+
+	settings, err := flux.ResolveGitSettings(clusterSpec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(settings.EksaSystemDir).To(Equal("fleet/workloads/workload-cluster"))
+}
+
+func TestFindOrphanedClusterDirsCustomEksaSystemDir(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := setupClusterDirsRepo(t, g, "management-cluster", "workload-1")
+	clusterSpec.FluxConfig.Spec.EksaSystemDir = "{{.Name}}"
+
+	_, err := g.gitOpsFlux.FindOrphanedClusterDirs(g.ctx, clusterSpec, nil)
+	g.Expect(err).To(MatchError(ContainSubstring("not supported with a custom eksaSystemDir {{.Name}}")))
+}
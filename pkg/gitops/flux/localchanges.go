@@ -0,0 +1,95 @@
+package flux
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// LocalChangesPolicy is how the uncommitted changes and unpushed commits found in the local repository, usually left
+// by a failed operation, are handled before updating it.
+type LocalChangesPolicy string
+
+const (
+	// LocalChangesFail fails the operation, leaving the local repository as it is to be inspected.
+	LocalChangesFail LocalChangesPolicy = "fail"
+	// LocalChangesReset discards the uncommitted changes and unpushed commits, resetting to the remote branch.
+	LocalChangesReset LocalChangesPolicy = "reset"
+	// LocalChangesRebase keeps the unpushed commit, replaying it on top of the remote branch if they diverged,
+	// so it's pushed with the cluster config changes. It fails on uncommitted changes and on more than one
+	// diverging commit.
+	LocalChangesRebase LocalChangesPolicy = "rebase"
+)
+
+// WithLocalChangesPolicy makes the operations check the existing local repository for uncommitted changes and for
+// commits diverging from the remote branch before updating it, and handle them with the policy.
+func WithLocalChangesPolicy(policy LocalChangesPolicy) Opt {
+	return func(f *Flux) {
+		f.localChangesPolicy = policy
+	}
+}
+
+// ValidateLocalChangesPolicy returns an error if policy isn't a LocalChangesPolicy.
+func ValidateLocalChangesPolicy(policy string) error {
+	switch LocalChangesPolicy(policy) {
+	case LocalChangesFail, LocalChangesReset, LocalChangesRebase:
+		return nil
+	default:
+		return fmt.Errorf("invalid local changes policy %q, it must be one of %s, %s or %s", policy, LocalChangesFail, LocalChangesReset, LocalChangesRebase)
+	}
+}
+
+// handleLocalChanges checks the existing local repository for changes missing in the remote branch and handles them
+// with the local changes policy, before the branch is checked out and pulled.
+func (fc *fluxForCluster) handleLocalChanges(ctx context.Context) error {
+	policy := fc.Flux.localChangesPolicy
+	if policy == "" {
+		return nil
+	}
+
+	changes, err := fc.gitClient.LocalChanges(ctx, fc.branch())
+	if err != nil {
+		return err
+	}
+	if changes.IsEmpty() {
+		return nil
+	}
+
+	switch policy {
+	case LocalChangesReset:
+		logger.Info("Warning: discarding the changes of the local git repository missing in the remote branch", "directory", fc.writer.Dir(), "branch", fc.branch(), "changes", changes.String())
+		if err := fc.gitClient.FetchAndReset(ctx, fc.branch()); err != nil {
+			return fmt.Errorf("discarding local changes: %v", err)
+		}
+		return nil
+	case LocalChangesRebase:
+		return fc.rebaseLocalChanges(ctx, changes)
+	default:
+		return fmt.Errorf("local git repository %s has %s in branch %s: push or discard them, or remove the directory", fc.writer.Dir(), changes, fc.branch())
+	}
+}
+
+// rebaseLocalChanges keeps the unpushed commits of the local branch, replaying the last one on top of the remote
+// branch if they diverged.
+func (fc *fluxForCluster) rebaseLocalChanges(ctx context.Context, changes *git.LocalChanges) error {
+	if len(changes.UncommittedFiles) > 0 {
+		return fmt.Errorf("local git repository %s has %s in branch %s: uncommitted changes can't be rebased, commit or discard them", fc.writer.Dir(), changes, fc.branch())
+	}
+
+	if !changes.Diverged {
+		logger.V(3).Info("Keeping the unpushed commits of the local git repository", "directory", fc.writer.Dir(), "branch", fc.branch(), "commits", len(changes.UnpushedCommits))
+		return nil
+	}
+
+	if !changes.OnBranch || len(changes.UnpushedCommits) > 1 {
+		return fmt.Errorf("local git repository %s has %s in branch %s: only one commit of the checked out branch can be rebased, push or discard them", fc.writer.Dir(), changes, fc.branch())
+	}
+
+	logger.Info("Rebasing the unpushed commit of the local git repository on the remote branch", "directory", fc.writer.Dir(), "branch", fc.branch(), "commit", changes.UnpushedCommits[0])
+	if err := fc.gitClient.RebaseOnRemote(ctx); err != nil {
+		return fmt.Errorf("rebasing local changes: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package flux_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	"github.com/aws/eks-anywhere/pkg/providers"
+)
+
+const localChangesClusterName = "management-cluster"
+
+func setupLocalChangesRepo(t *testing.T, g fluxTest) *cluster.Spec {
+	t.Helper()
+	if err := os.MkdirAll(path.Join(g.writer.Dir(), ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return newClusterSpec(t, v1alpha1.NewCluster(localChangesClusterName), "")
+}
+
+func updateLocalChangesCluster(g fluxTest, f *flux.Flux, clusterSpec *cluster.Spec) error {
+	return f.UpdateGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(localChangesClusterName), []providers.MachineConfig{machineConfig(localChangesClusterName)})
+}
+
+func (g fluxTest) expectUpdateCommitPushed(clusterSpec *cluster.Spec) {
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add("clusters/management-cluster/management-cluster/eksa-system").Return(nil)
+	g.git.EXPECT().Commit(updateCommitMessage).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+}
+
+func TestUpdateGitEksaSpecNoLocalChanges(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := setupLocalChangesRepo(t, g)
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithLocalChangesPolicy(flux.LocalChangesFail))
+
+	g.git.EXPECT().LocalChanges(g.ctx, "testBranch").Return(&git.LocalChanges{}, nil)
+	g.expectUpdateCommitPushed(clusterSpec)
+
+	g.Expect(updateLocalChangesCluster(g, f, clusterSpec)).To(Succeed())
+}
+
+func TestUpdateGitEksaSpecLocalChangesFail(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := setupLocalChangesRepo(t, g)
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithLocalChangesPolicy(flux.LocalChangesFail))
+
+	g.git.EXPECT().LocalChanges(g.ctx, "testBranch").Return(&git.LocalChanges{
+		UncommittedFiles: []string{"clusters/management-cluster/management-cluster/eksa-system/eksa-cluster.yaml"},
+		UnpushedCommits:  []string{"abc", "def"},
+		Diverged:         true,
+	}, nil)
+
+	g.Expect(updateLocalChangesCluster(g, f, clusterSpec)).To(MatchError(ContainSubstring(
+		"has uncommitted changes to clusters/management-cluster/management-cluster/eksa-system/eksa-cluster.yaml and 2 unpushed commits diverging from the remote branch in branch testBranch: push or discard them",
+	)))
+}
+
+func TestUpdateGitEksaSpecLocalChangesReset(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := setupLocalChangesRepo(t, g)
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithLocalChangesPolicy(flux.LocalChangesReset))
+
+	g.git.EXPECT().LocalChanges(g.ctx, "testBranch").Return(&git.LocalChanges{UncommittedFiles: []string{"README.md"}}, nil)
+	g.git.EXPECT().FetchAndReset(g.ctx, "testBranch").Return(nil)
+	g.expectUpdateCommitPushed(clusterSpec)
+
+	g.Expect(updateLocalChangesCluster(g, f, clusterSpec)).To(Succeed())
+}
+
+func TestUpdateGitEksaSpecLocalChangesRebase(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := setupLocalChangesRepo(t, g)
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithLocalChangesPolicy(flux.LocalChangesRebase))
+
+	g.git.EXPECT().LocalChanges(g.ctx, "testBranch").Return(&git.LocalChanges{UnpushedCommits: []string{"abc"}, Diverged: true, OnBranch: true}, nil)
+	g.git.EXPECT().RebaseOnRemote(g.ctx).Return(nil)
+	g.expectUpdateCommitPushed(clusterSpec)
+
+	g.Expect(updateLocalChangesCluster(g, f, clusterSpec)).To(Succeed())
+}
+
+func TestUpdateGitEksaSpecLocalChangesRebaseAhead(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := setupLocalChangesRepo(t, g)
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithLocalChangesPolicy(flux.LocalChangesRebase))
+
+	g.git.EXPECT().LocalChanges(g.ctx, "testBranch").Return(&git.LocalChanges{UnpushedCommits: []string{"abc", "def"}}, nil)
+	g.expectUpdateCommitPushed(clusterSpec)
+
+	g.Expect(updateLocalChangesCluster(g, f, clusterSpec)).To(Succeed())
+}
+
+func TestUpdateGitEksaSpecLocalChangesRebaseUncommitted(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := setupLocalChangesRepo(t, g)
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithLocalChangesPolicy(flux.LocalChangesRebase))
+
+	g.git.EXPECT().LocalChanges(g.ctx, "testBranch").Return(&git.LocalChanges{UncommittedFiles: []string{"README.md"}}, nil)
+
+	g.Expect(updateLocalChangesCluster(g, f, clusterSpec)).To(MatchError(ContainSubstring("uncommitted changes can't be rebased")))
+}
+
+func TestUpdateGitEksaSpecLocalChangesRebaseSeveralDivergedCommits(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := setupLocalChangesRepo(t, g)
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithLocalChangesPolicy(flux.LocalChangesRebase))
+
+	g.git.EXPECT().LocalChanges(g.ctx, "testBranch").Return(&git.LocalChanges{UnpushedCommits: []string{"abc", "def"}, Diverged: true, OnBranch: true}, nil)
+
+	g.Expect(updateLocalChangesCluster(g, f, clusterSpec)).To(MatchError(ContainSubstring("only one commit of the checked out branch can be rebased")))
+}
+
+func TestValidateLocalChangesPolicy(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(flux.ValidateLocalChangesPolicy("rebase")).To(Succeed())
+	g.Expect(flux.ValidateLocalChangesPolicy("stash")).To(MatchError(`invalid local changes policy "stash", it must be one of fail, reset or rebase`))
+}
@@ -0,0 +1,90 @@
+package flux
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const migrateFluxconfigCommitMessage = "Migrate commit of flux configuration; generated by EKS-A CLI"
+
+// MigrateFluxManifests regenerates the flux-system files for the flux version in the cluster spec bundle
+// and commits them if they differ from the ones in the repository. It's a no-op when the committed files
+// are already current, so it's safe to run repeatedly.
+func (f *Flux) MigrateFluxManifests(ctx context.Context, clusterSpec *cluster.Spec) error {
+	if f.shouldSkipFlux() {
+		logger.Info("GitOps field not specified, migrate flux manifests skipped")
+		return nil
+	}
+
+	if !clusterSpec.Cluster.IsSelfManaged() {
+		logger.V(3).Info("Flux system files are owned by the management cluster, migrate flux manifests skipped")
+		return nil
+	}
+
+	fc, err := newFluxForCluster(f, clusterSpec, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fc.syncGitRepo(ctx); err != nil {
+		return err
+	}
+
+	dir := fc.fluxSystemDir()
+	committed, err := fc.readRepoFiles(dir)
+	if err != nil {
+		return err
+	}
+
+	g := NewFileGenerator()
+	if err := g.Init(fc.writer, fc.eksaSystemDir(), dir); err != nil {
+		return err
+	}
+
+	if err := g.WriteFluxSystemFiles(clusterSpec); err != nil {
+		return fmt.Errorf("writing flux system files: %v", err)
+	}
+
+	generated, err := fc.readRepoFiles(dir)
+	if err != nil {
+		return err
+	}
+
+	if repoFilesEqual(committed, generated) {
+		logger.V(3).Info("Flux system files are up to date, nothing to migrate", "path", dir)
+		return nil
+	}
+
+	if err := f.gitClient.Add(dir); err != nil {
+		return fmt.Errorf("adding %s to git: %v", dir, err)
+	}
+
+	if err := fc.validateCommitSize(); err != nil {
+		return err
+	}
+
+	if err := f.pushToRemoteRepo(ctx, dir, migrateFluxconfigCommitMessage); err != nil {
+		return err
+	}
+
+	logger.V(3).Info("Finished pushing migrated flux system files to git", "repository", fc.repository())
+	return nil
+}
+
+func repoFilesEqual(a, b map[string][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for p, content := range a {
+		other, ok := b[p]
+		if !ok || !bytes.Equal(content, other) {
+			return false
+		}
+	}
+	return true
+}
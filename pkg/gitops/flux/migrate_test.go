@@ -0,0 +1,79 @@
+package flux_test
+
+import (
+	"errors"
+	"os"
+	"path"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+)
+
+func setupMigrateRepo(t *testing.T, g fluxTest, clusterConfig *v1alpha1.Cluster) *cluster.Spec {
+	t.Helper()
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+
+	if err := os.MkdirAll(path.Join(g.writer.Dir(), ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	stale := path.Join(g.writer.Dir(), testFluxSystemDir, "gotk-patches.yaml")
+	if err := os.MkdirAll(path.Dir(stale), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(stale, []byte("kind: Deployment\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return clusterSpec
+}
+
+func TestMigrateFluxManifestsStaleFiles(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := setupMigrateRepo(t, g, v1alpha1.NewCluster("management-cluster"))
+
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil).Times(2)
+	g.git.EXPECT().Add(testFluxSystemDir).Return(nil)
+	g.git.EXPECT().Commit("Migrate commit of flux configuration; generated by EKS-A CLI").Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+
+	g.Expect(g.gitOpsFlux.MigrateFluxManifests(g.ctx, clusterSpec)).To(Succeed())
+	test.AssertFilesEquals(t, path.Join(g.writer.Dir(), testFluxSystemDir, "gotk-patches.yaml"), "./testdata/gotk-patches.yaml")
+	test.AssertFilesEquals(t, path.Join(g.writer.Dir(), testFluxSystemDir, "gotk-sync.yaml"), "./testdata/gotk-sync.yaml")
+
+	// Files are current now, so running it again is a no-op.
+	g.Expect(g.gitOpsFlux.MigrateFluxManifests(g.ctx, clusterSpec)).To(Succeed())
+}
+
+func TestMigrateFluxManifestsPushError(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := setupMigrateRepo(t, g, v1alpha1.NewCluster("management-cluster"))
+
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add(testFluxSystemDir).Return(nil)
+	g.git.EXPECT().Commit("Migrate commit of flux configuration; generated by EKS-A CLI").Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(errors.New("error in push"))
+
+	g.Expect(g.gitOpsFlux.MigrateFluxManifests(g.ctx, clusterSpec)).To(MatchError(ContainSubstring("error in push")))
+}
+
+func TestMigrateFluxManifestsWorkloadCluster(t *testing.T) {
+	g := newFluxTest(t)
+	clusterConfig := v1alpha1.NewCluster("workload-cluster")
+	clusterConfig.SetManagedBy("management-cluster")
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+
+	g.Expect(g.gitOpsFlux.MigrateFluxManifests(g.ctx, clusterSpec)).To(Succeed())
+}
+
+func TestMigrateFluxManifestsSkip(t *testing.T) {
+	g := newFluxTest(t)
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, nil, nil)
+
+	g.Expect(f.MigrateFluxManifests(g.ctx, g.clusterSpec)).To(Succeed())
+}
@@ -0,0 +1,239 @@
+package flux
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const migrateGitOpsConfigCommitMessage = "Migrate GitOpsConfig to FluxConfig; generated by EKS-A CLI"
+
+// MigrateGitOpsConfig converts a cluster configured with the legacy gitOpsConfig to the FluxConfig of clusterSpec.
+// The cluster files are moved from the legacy paths to the ones of the FluxConfig, the GitOpsConfig in the committed
+// cluster config is replaced by the FluxConfig and the move is committed and pushed in a single commit. For a management
+// cluster whose sync path changes, the flux-system Kustomization in the cluster is then updated to reconcile the new path.
+// The FluxConfig must keep the repository, branch, flux namespace and name of the GitOpsConfig. It's a no-op when the
+// cluster files are already at the FluxConfig paths and don't reference a GitOpsConfig.
+func (f *Flux) MigrateGitOpsConfig(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, gitOpsConfig *v1alpha1.GitOpsConfig) error {
+	if f.shouldSkipFlux() {
+		logger.Info("GitOps field not specified, migrate GitOpsConfig skipped")
+		return nil
+	}
+
+	legacySpec := legacyClusterSpec(clusterSpec, gitOpsConfig)
+	if err := validateGitOpsConfigMigration(legacySpec.FluxConfig, clusterSpec.FluxConfig); err != nil {
+		return fmt.Errorf("migrating GitOpsConfig %s: %v", gitOpsConfig.Name, err)
+	}
+
+	legacy, err := newFluxForCluster(f, legacySpec, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	fc, err := newFluxForCluster(f, clusterSpec, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fc.syncGitRepo(ctx); err != nil {
+		return err
+	}
+
+	moves := []repoDirMove{{from: legacy.eksaSystemDir(), to: fc.eksaSystemDir()}}
+	if clusterSpec.Cluster.IsSelfManaged() {
+		moves = append(moves, repoDirMove{from: legacy.fluxSystemDir(), to: fc.fluxSystemDir()})
+	}
+
+	legacySyncPath, err := resolveSyncPath(legacySpec)
+	if err != nil {
+		return err
+	}
+	syncPath, err := resolveSyncPath(clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	migrated, err := fc.migrateRepoFiles(moves, clusterSpec.FluxConfig, legacySyncPath, syncPath)
+	if err != nil {
+		return err
+	}
+	if !migrated {
+		logger.V(3).Info("Cluster config is already migrated to FluxConfig, nothing to migrate", "path", fc.eksaSystemDir())
+		return nil
+	}
+
+	dir := fc.clusterConfigDir()
+	if err := f.gitClient.Add(dir); err != nil {
+		return fmt.Errorf("adding %s to git: %v", dir, err)
+	}
+
+	if err := fc.validateCommitSize(); err != nil {
+		return err
+	}
+
+	if err := f.pushToRemoteRepo(ctx, dir, migrateGitOpsConfigCommitMessage); err != nil {
+		return err
+	}
+	logger.V(3).Info("Finished pushing migrated cluster config to git", "repository", fc.repository(), "path", dir)
+
+	if !clusterSpec.Cluster.IsSelfManaged() || legacySyncPath == syncPath {
+		return nil
+	}
+
+	logger.V(3).Info("Updating flux sync path", "from", legacySyncPath, "to", syncPath)
+	if err := f.fluxClient.SetKustomizationPath(ctx, managementCluster, fc.namespace(), "./"+syncPath); err != nil {
+		return fmt.Errorf("updating flux kustomization path to %s: %v", syncPath, err)
+	}
+
+	return f.fluxClient.ForceReconcile(ctx, managementCluster, fc.namespace())
+}
+
+// legacyClusterSpec returns a copy of the cluster spec with the FluxConfig the GitOpsConfig was converted to,
+// defaulted the way the GitOpsConfig was when its files were committed.
+func legacyClusterSpec(clusterSpec *cluster.Spec, gitOpsConfig *v1alpha1.GitOpsConfig) *cluster.Spec {
+	legacySpec := clusterSpec.DeepCopy()
+	gitOpsConfig = gitOpsConfig.DeepCopy()
+	gitOpsConfig.SetDefaults()
+	legacySpec.GitOpsConfig = gitOpsConfig
+	legacySpec.FluxConfig = gitOpsConfig.ConvertToFluxConfig()
+
+	if legacySpec.FluxConfig.Spec.ClusterConfigPath == "" {
+		name := clusterSpec.Cluster.Name
+		if clusterSpec.Cluster.IsManaged() {
+			name = clusterSpec.Cluster.ManagedBy()
+		}
+		legacySpec.FluxConfig.Spec.ClusterConfigPath = path.Join("clusters", name)
+	}
+	return legacySpec
+}
+
+func validateGitOpsConfigMigration(legacy, fluxConfig *v1alpha1.FluxConfig) error {
+	if fluxConfig == nil {
+		return fmt.Errorf("the cluster config must reference a FluxConfig")
+	}
+	if fluxConfig.Name != legacy.Name {
+		return fmt.Errorf("FluxConfig name %s must be the same as the GitOpsConfig name %s", fluxConfig.Name, legacy.Name)
+	}
+	github := fluxConfig.Spec.Github
+	if github == nil || github.Owner != legacy.Spec.Github.Owner || github.Repository != legacy.Spec.Github.Repository || github.Personal != legacy.Spec.Github.Personal {
+		return fmt.Errorf("FluxConfig must use the github repository %s/%s of the GitOpsConfig", legacy.Spec.Github.Owner, legacy.Spec.Github.Repository)
+	}
+	if fluxConfig.Spec.Branch != legacy.Spec.Branch {
+		return fmt.Errorf("FluxConfig branch %s must be the same as the GitOpsConfig branch %s", fluxConfig.Spec.Branch, legacy.Spec.Branch)
+	}
+	if fluxConfig.Spec.SystemNamespace != legacy.Spec.SystemNamespace {
+		return fmt.Errorf("FluxConfig systemNamespace %s must be the same as the GitOpsConfig fluxSystemNamespace %s", fluxConfig.Spec.SystemNamespace, legacy.Spec.SystemNamespace)
+	}
+	return nil
+}
+
+// repoDirMove is a directory of the repository moved to a new path.
+type repoDirMove struct {
+	from, to string
+}
+
+// migrateRepoFiles moves the files of each dir to its new path, converting the committed cluster config to the
+// FluxConfig and rewriting the sync path of the flux-system manifests. It returns false if there was nothing to migrate.
+func (fc *fluxForCluster) migrateRepoFiles(moves []repoDirMove, fluxConfig *v1alpha1.FluxConfig, legacySyncPath, syncPath string) (bool, error) {
+	files := map[string][]byte{}
+	migrated := false
+	for _, m := range moves {
+		dirFiles, err := fc.readRepoFiles(m.from)
+		if err != nil {
+			return false, err
+		}
+		if len(dirFiles) == 0 {
+			return false, fmt.Errorf("migrating GitOpsConfig: %s does not exist in the repository", m.from)
+		}
+
+		if m.from != m.to {
+			logger.V(3).Info("Moving cluster files", "from", m.from, "to", m.to)
+			if err := fc.gitClient.Remove(m.from); err != nil {
+				return false, fmt.Errorf("removing %s from git: %v", m.from, err)
+			}
+			migrated = true
+		}
+
+		for p, content := range dirFiles {
+			newPath := path.Join(m.to, strings.TrimPrefix(p, m.from+"/"))
+			switch path.Base(p) {
+			case clusterConfigFileName:
+				converted, changed, err := convertClusterConfigToFluxConfig(content, fluxConfig)
+				if err != nil {
+					return false, fmt.Errorf("converting %s: %v", p, err)
+				}
+				content = converted
+				migrated = migrated || changed
+			case fluxSyncFileName:
+				if legacySyncPath != syncPath {
+					content = []byte(strings.ReplaceAll(string(content), "path: ./"+legacySyncPath+"\n", "path: ./"+syncPath+"\n"))
+				}
+			}
+			files[newPath] = content
+		}
+	}
+
+	if !migrated {
+		return false, nil
+	}
+
+	if err := fc.writeRepoFiles(files); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// convertClusterConfigToFluxConfig replaces the GitOpsConfig of a cluster config file by the FluxConfig and makes the
+// Cluster reference it. The other objects are kept as they are. It returns false if there was no GitOpsConfig.
+func convertClusterConfigToFluxConfig(content []byte, fluxConfig *v1alpha1.FluxConfig) ([]byte, bool, error) {
+	docs := strings.Split(string(content), v1alpha1.YamlSeparator)
+	converted := false
+	for i, doc := range docs {
+		obj := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+			return nil, false, err
+		}
+
+		switch obj["kind"] {
+		case v1alpha1.GitOpsConfigKind:
+			b, err := yaml.Marshal(fluxConfig.ConvertConfigToConfigGenerateStruct())
+			if err != nil {
+				return nil, false, err
+			}
+			docs[i] = replaceYamlDoc(doc, b)
+			converted = true
+		case v1alpha1.ClusterKind:
+			spec, ok := obj["spec"].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if ref, ok := spec["gitOpsRef"].(map[string]interface{}); ok && ref["kind"] == v1alpha1.GitOpsConfigKind {
+				ref["kind"] = v1alpha1.FluxConfigKind
+				ref["name"] = fluxConfig.Name
+				b, err := yaml.Marshal(obj)
+				if err != nil {
+					return nil, false, err
+				}
+				docs[i] = replaceYamlDoc(doc, b)
+				converted = true
+			}
+		}
+	}
+
+	return []byte(strings.Join(docs, v1alpha1.YamlSeparator)), converted, nil
+}
+
+// replaceYamlDoc returns the new content of a yaml document, keeping the trailing new lines of the replaced one.
+func replaceYamlDoc(doc string, content []byte) string {
+	trailing := doc[len(strings.TrimRight(doc, "\n")):]
+	return strings.TrimRight(string(content), "\n") + trailing
+}
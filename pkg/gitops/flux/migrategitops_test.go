@@ -0,0 +1,179 @@
+package flux_test
+
+import (
+	"errors"
+	"os"
+	"path"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	legacyEksaSystemDir = "clusters/management-cluster/management-cluster/eksa-system"
+	legacyFluxSystemDir = "clusters/management-cluster/flux-system"
+)
+
+func legacyGitOpsConfig() *v1alpha1.GitOpsConfig {
+	return &v1alpha1.GitOpsConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-gitops", Namespace: "default"},
+		Spec: v1alpha1.GitOpsConfigSpec{
+			Flux: v1alpha1.Flux{
+				Github: v1alpha1.Github{
+					Owner:      "mFolwer",
+					Repository: "testRepo",
+					Personal:   true,
+					Branch:     "testBranch",
+				},
+			},
+		},
+	}
+}
+
+func setupLegacyRepo(t *testing.T, g fluxTest) {
+	t.Helper()
+	files := map[string]string{
+		path.Join(legacyEksaSystemDir, "eksa-cluster.yaml"):  "./testdata/cluster-config-gitopsconfig.yaml",
+		path.Join(legacyEksaSystemDir, "kustomization.yaml"): "./testdata/kustomization.yaml",
+		path.Join(legacyFluxSystemDir, "gotk-sync.yaml"):     "./testdata/gotk-sync-legacy.yaml",
+	}
+	for p, src := range files {
+		content, err := os.ReadFile(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dst := path.Join(g.writer.Dir(), p)
+		if err := os.MkdirAll(path.Dir(dst), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(dst, content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestMigrateGitOpsConfigNewPath(t *testing.T) {
+	g := newFluxTest(t)
+	managementCluster := &types.Cluster{Name: "management-cluster"}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "clusters/fleet/management-cluster")
+	setupLegacyRepo(t, g)
+
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch("testBranch").Return(nil)
+	g.git.EXPECT().Remove(legacyEksaSystemDir).Return(nil)
+	g.git.EXPECT().Remove(legacyFluxSystemDir).Return(nil)
+	g.git.EXPECT().Add("clusters/fleet/management-cluster").Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.flux.EXPECT().SetKustomizationPath(g.ctx, managementCluster, "flux-system", "./clusters/fleet/management-cluster").Return(nil)
+	g.flux.EXPECT().ForceReconcile(g.ctx, managementCluster, "flux-system").Return(nil)
+
+	g.Expect(g.gitOpsFlux.MigrateGitOpsConfig(g.ctx, managementCluster, clusterSpec, legacyGitOpsConfig())).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(g.writer.Dir(), "clusters/fleet/management-cluster/management-cluster/eksa-system/eksa-cluster.yaml"), "./testdata/cluster-config-fluxconfig-new-path.yaml")
+	test.AssertFilesEquals(t, path.Join(g.writer.Dir(), "clusters/fleet/management-cluster/flux-system/gotk-sync.yaml"), "./testdata/gotk-sync-migrated.yaml")
+}
+
+func TestMigrateGitOpsConfigSamePath(t *testing.T) {
+	g := newFluxTest(t)
+	managementCluster := &types.Cluster{Name: "management-cluster"}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "clusters/management-cluster")
+	setupLegacyRepo(t, g)
+
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch("testBranch").Return(nil)
+	g.git.EXPECT().Add("clusters/management-cluster").Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+
+	g.Expect(g.gitOpsFlux.MigrateGitOpsConfig(g.ctx, managementCluster, clusterSpec, legacyGitOpsConfig())).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(g.writer.Dir(), legacyEksaSystemDir, "eksa-cluster.yaml"), "./testdata/cluster-config-fluxconfig.yaml")
+	test.AssertFilesEquals(t, path.Join(g.writer.Dir(), legacyFluxSystemDir, "gotk-sync.yaml"), "./testdata/gotk-sync-legacy.yaml")
+}
+
+func TestMigrateGitOpsConfigAlreadyMigrated(t *testing.T) {
+	g := newFluxTest(t)
+	managementCluster := &types.Cluster{Name: "management-cluster"}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "clusters/management-cluster")
+	setupLegacyRepo(t, g)
+	migrated, err := os.ReadFile("./testdata/cluster-config-fluxconfig.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(os.WriteFile(path.Join(g.writer.Dir(), legacyEksaSystemDir, "eksa-cluster.yaml"), migrated, 0o644)).To(Succeed())
+
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch("testBranch").Return(nil)
+
+	g.Expect(g.gitOpsFlux.MigrateGitOpsConfig(g.ctx, managementCluster, clusterSpec, legacyGitOpsConfig())).To(Succeed())
+}
+
+func TestMigrateGitOpsConfigMissingClusterConfig(t *testing.T) {
+	g := newFluxTest(t)
+	managementCluster := &types.Cluster{Name: "management-cluster"}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "clusters/fleet/management-cluster")
+
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch("testBranch").Return(nil)
+
+	g.Expect(g.gitOpsFlux.MigrateGitOpsConfig(g.ctx, managementCluster, clusterSpec, legacyGitOpsConfig())).To(MatchError(ContainSubstring("does not exist in the repository")))
+}
+
+func TestMigrateGitOpsConfigPushError(t *testing.T) {
+	g := newFluxTest(t)
+	managementCluster := &types.Cluster{Name: "management-cluster"}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "clusters/management-cluster")
+	setupLegacyRepo(t, g)
+
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch("testBranch").Return(nil)
+	g.git.EXPECT().Add("clusters/management-cluster").Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(errors.New("error from client"))
+
+	g.Expect(g.gitOpsFlux.MigrateGitOpsConfig(g.ctx, managementCluster, clusterSpec, legacyGitOpsConfig())).To(MatchError(ContainSubstring("error from client")))
+}
+
+func TestMigrateGitOpsConfigInvalid(t *testing.T) {
+	tests := []struct {
+		testName string
+		modify   func(*cluster.Spec)
+		wantErr  string
+	}{
+		{
+			testName: "no flux config",
+			modify:   func(s *cluster.Spec) { s.FluxConfig = nil },
+			wantErr:  "the cluster config must reference a FluxConfig",
+		},
+		{
+			testName: "different name",
+			modify:   func(s *cluster.Spec) { s.FluxConfig.Name = "fleet" },
+			wantErr:  "FluxConfig name fleet must be the same as the GitOpsConfig name test-gitops",
+		},
+		{
+			testName: "different repository",
+			modify:   func(s *cluster.Spec) { s.FluxConfig.Spec.Github.Repository = "fleet" },
+			wantErr:  "FluxConfig must use the github repository mFolwer/testRepo of the GitOpsConfig",
+		},
+		{
+			testName: "different branch",
+			modify:   func(s *cluster.Spec) { s.FluxConfig.Spec.Branch = "main" },
+			wantErr:  "FluxConfig branch main must be the same as the GitOpsConfig branch testBranch",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := newFluxTest(t)
+			clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "clusters/management-cluster")
+			tt.modify(clusterSpec)
+
+			g.Expect(g.gitOpsFlux.MigrateGitOpsConfig(g.ctx, &types.Cluster{}, clusterSpec, legacyGitOpsConfig())).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
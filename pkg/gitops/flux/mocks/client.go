@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/aws/eks-anywhere/pkg/gitops/flux (interfaces: FluxClient,KubeClient,GitOpsFluxClient,GitClient,Templater)
+// Source: github.com/aws/eks-anywhere/pkg/gitops/flux (interfaces: FluxClient,KubeClient,GitOpsFluxClient,GitClient,BucketClient,Templater,SecretClient,ImageChecker)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -16,6 +16,9 @@ import (
 	git "github.com/aws/eks-anywhere/pkg/git"
 	types "github.com/aws/eks-anywhere/pkg/types"
 	gomock "github.com/golang/mock/gomock"
+	v1 "k8s.io/api/apps/v1"
+	v10 "k8s.io/api/core/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
 // MockFluxClient is a mock of FluxClient interface.
@@ -41,6 +44,48 @@ func (m *MockFluxClient) EXPECT() *MockFluxClientMockRecorder {
 	return m.recorder
 }
 
+// BootstrapAzureDevOps mocks base method.
+func (m *MockFluxClient) BootstrapAzureDevOps(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BootstrapAzureDevOps", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BootstrapAzureDevOps indicates an expected call of BootstrapAzureDevOps.
+func (mr *MockFluxClientMockRecorder) BootstrapAzureDevOps(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapAzureDevOps", reflect.TypeOf((*MockFluxClient)(nil).BootstrapAzureDevOps), arg0, arg1, arg2)
+}
+
+// BootstrapBitbucketServer mocks base method.
+func (m *MockFluxClient) BootstrapBitbucketServer(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BootstrapBitbucketServer", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BootstrapBitbucketServer indicates an expected call of BootstrapBitbucketServer.
+func (mr *MockFluxClientMockRecorder) BootstrapBitbucketServer(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapBitbucketServer", reflect.TypeOf((*MockFluxClient)(nil).BootstrapBitbucketServer), arg0, arg1, arg2)
+}
+
+// BootstrapCodeCommit mocks base method.
+func (m *MockFluxClient) BootstrapCodeCommit(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BootstrapCodeCommit", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BootstrapCodeCommit indicates an expected call of BootstrapCodeCommit.
+func (mr *MockFluxClientMockRecorder) BootstrapCodeCommit(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapCodeCommit", reflect.TypeOf((*MockFluxClient)(nil).BootstrapCodeCommit), arg0, arg1, arg2)
+}
+
 // BootstrapGit mocks base method.
 func (m *MockFluxClient) BootstrapGit(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig, arg3 *config.CliConfig) error {
 	m.ctrl.T.Helper()
@@ -55,6 +100,20 @@ func (mr *MockFluxClientMockRecorder) BootstrapGit(arg0, arg1, arg2, arg3 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapGit", reflect.TypeOf((*MockFluxClient)(nil).BootstrapGit), arg0, arg1, arg2, arg3)
 }
 
+// BootstrapGitea mocks base method.
+func (m *MockFluxClient) BootstrapGitea(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BootstrapGitea", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BootstrapGitea indicates an expected call of BootstrapGitea.
+func (mr *MockFluxClientMockRecorder) BootstrapGitea(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapGitea", reflect.TypeOf((*MockFluxClient)(nil).BootstrapGitea), arg0, arg1, arg2)
+}
+
 // BootstrapGithub mocks base method.
 func (m *MockFluxClient) BootstrapGithub(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig) error {
 	m.ctrl.T.Helper()
@@ -69,6 +128,77 @@ func (mr *MockFluxClientMockRecorder) BootstrapGithub(arg0, arg1, arg2 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapGithub", reflect.TypeOf((*MockFluxClient)(nil).BootstrapGithub), arg0, arg1, arg2)
 }
 
+// BootstrapGitlab mocks base method.
+func (m *MockFluxClient) BootstrapGitlab(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BootstrapGitlab", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BootstrapGitlab indicates an expected call of BootstrapGitlab.
+func (mr *MockFluxClientMockRecorder) BootstrapGitlab(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapGitlab", reflect.TypeOf((*MockFluxClient)(nil).BootstrapGitlab), arg0, arg1, arg2)
+}
+
+// ExportComponents mocks base method.
+func (m *MockFluxClient) ExportComponents(arg0 context.Context, arg1 *v1alpha1.FluxConfig) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportComponents", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportComponents indicates an expected call of ExportComponents.
+func (mr *MockFluxClientMockRecorder) ExportComponents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportComponents", reflect.TypeOf((*MockFluxClient)(nil).ExportComponents), arg0, arg1)
+}
+
+// InstallComponents mocks base method.
+func (m *MockFluxClient) InstallComponents(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallComponents", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InstallComponents indicates an expected call of InstallComponents.
+func (mr *MockFluxClientMockRecorder) InstallComponents(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallComponents", reflect.TypeOf((*MockFluxClient)(nil).InstallComponents), arg0, arg1, arg2)
+}
+
+// PullArtifact mocks base method.
+func (m *MockFluxClient) PullArtifact(arg0 context.Context, arg1 *v1alpha1.FluxConfig, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PullArtifact", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PullArtifact indicates an expected call of PullArtifact.
+func (mr *MockFluxClientMockRecorder) PullArtifact(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PullArtifact", reflect.TypeOf((*MockFluxClient)(nil).PullArtifact), arg0, arg1, arg2)
+}
+
+// PushArtifact mocks base method.
+func (m *MockFluxClient) PushArtifact(arg0 context.Context, arg1 *v1alpha1.FluxConfig, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PushArtifact", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PushArtifact indicates an expected call of PushArtifact.
+func (mr *MockFluxClientMockRecorder) PushArtifact(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushArtifact", reflect.TypeOf((*MockFluxClient)(nil).PushArtifact), arg0, arg1, arg2)
+}
+
 // Reconcile mocks base method.
 func (m *MockFluxClient) Reconcile(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig) error {
 	m.ctrl.T.Helper()
@@ -120,6 +250,34 @@ func (m *MockKubeClient) EXPECT() *MockKubeClientMockRecorder {
 	return m.recorder
 }
 
+// ApplyKubeSpecFromBytesWithNamespace mocks base method.
+func (m *MockKubeClient) ApplyKubeSpecFromBytesWithNamespace(arg0 context.Context, arg1 *types.Cluster, arg2 []byte, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyKubeSpecFromBytesWithNamespace", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyKubeSpecFromBytesWithNamespace indicates an expected call of ApplyKubeSpecFromBytesWithNamespace.
+func (mr *MockKubeClientMockRecorder) ApplyKubeSpecFromBytesWithNamespace(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyKubeSpecFromBytesWithNamespace", reflect.TypeOf((*MockKubeClient)(nil).ApplyKubeSpecFromBytesWithNamespace), arg0, arg1, arg2, arg3)
+}
+
+// ApplyKustomization mocks base method.
+func (m *MockKubeClient) ApplyKustomization(arg0 context.Context, arg1 *types.Cluster, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyKustomization", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyKustomization indicates an expected call of ApplyKustomization.
+func (mr *MockKubeClientMockRecorder) ApplyKustomization(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyKustomization", reflect.TypeOf((*MockKubeClient)(nil).ApplyKustomization), arg0, arg1, arg2)
+}
+
 // DeleteSecret mocks base method.
 func (m *MockKubeClient) DeleteSecret(arg0 context.Context, arg1 *types.Cluster, arg2, arg3 string) error {
 	m.ctrl.T.Helper()
@@ -134,6 +292,35 @@ func (mr *MockKubeClientMockRecorder) DeleteSecret(arg0, arg1, arg2, arg3 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecret", reflect.TypeOf((*MockKubeClient)(nil).DeleteSecret), arg0, arg1, arg2, arg3)
 }
 
+// DryRunApplyKustomization mocks base method.
+func (m *MockKubeClient) DryRunApplyKustomization(arg0 context.Context, arg1 *types.Cluster, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DryRunApplyKustomization", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DryRunApplyKustomization indicates an expected call of DryRunApplyKustomization.
+func (mr *MockKubeClientMockRecorder) DryRunApplyKustomization(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DryRunApplyKustomization", reflect.TypeOf((*MockKubeClient)(nil).DryRunApplyKustomization), arg0, arg1, arg2)
+}
+
+// GetDeployment mocks base method.
+func (m *MockKubeClient) GetDeployment(arg0 context.Context, arg1, arg2, arg3 string) (*v1.Deployment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeployment", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*v1.Deployment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeployment indicates an expected call of GetDeployment.
+func (mr *MockKubeClientMockRecorder) GetDeployment(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeployment", reflect.TypeOf((*MockKubeClient)(nil).GetDeployment), arg0, arg1, arg2, arg3)
+}
+
 // GetEksaCluster mocks base method.
 func (m *MockKubeClient) GetEksaCluster(arg0 context.Context, arg1 *types.Cluster, arg2 string) (*v1alpha1.Cluster, error) {
 	m.ctrl.T.Helper()
@@ -149,6 +336,39 @@ func (mr *MockKubeClientMockRecorder) GetEksaCluster(arg0, arg1, arg2 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEksaCluster", reflect.TypeOf((*MockKubeClient)(nil).GetEksaCluster), arg0, arg1, arg2)
 }
 
+// GetObject mocks base method.
+func (m *MockKubeClient) GetObject(arg0 context.Context, arg1, arg2, arg3, arg4 string, arg5 runtime.Object) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObject", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetObject indicates an expected call of GetObject.
+func (mr *MockKubeClientMockRecorder) GetObject(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObject", reflect.TypeOf((*MockKubeClient)(nil).GetObject), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// MergePatchResource mocks base method.
+func (m *MockKubeClient) MergePatchResource(arg0 context.Context, arg1, arg2, arg3 string, arg4 ...executables.KubectlOpt) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2, arg3}
+	for _, a := range arg4 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MergePatchResource", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergePatchResource indicates an expected call of MergePatchResource.
+func (mr *MockKubeClientMockRecorder) MergePatchResource(arg0, arg1, arg2, arg3 interface{}, arg4 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3}, arg4...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergePatchResource", reflect.TypeOf((*MockKubeClient)(nil).MergePatchResource), varargs...)
+}
+
 // RemoveAnnotation mocks base method.
 func (m *MockKubeClient) RemoveAnnotation(arg0 context.Context, arg1, arg2, arg3 string, arg4 ...executables.KubectlOpt) error {
 	m.ctrl.T.Helper()
@@ -210,6 +430,90 @@ func (m *MockGitOpsFluxClient) EXPECT() *MockGitOpsFluxClientMockRecorder {
 	return m.recorder
 }
 
+// ApplyKustomization mocks base method.
+func (m *MockGitOpsFluxClient) ApplyKustomization(arg0 context.Context, arg1 *types.Cluster, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyKustomization", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyKustomization indicates an expected call of ApplyKustomization.
+func (mr *MockGitOpsFluxClientMockRecorder) ApplyKustomization(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyKustomization", reflect.TypeOf((*MockGitOpsFluxClient)(nil).ApplyKustomization), arg0, arg1, arg2)
+}
+
+// ApplyNamespace mocks base method.
+func (m *MockGitOpsFluxClient) ApplyNamespace(arg0 context.Context, arg1 *types.Cluster, arg2 string, arg3, arg4 map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyNamespace", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyNamespace indicates an expected call of ApplyNamespace.
+func (mr *MockGitOpsFluxClientMockRecorder) ApplyNamespace(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyNamespace", reflect.TypeOf((*MockGitOpsFluxClient)(nil).ApplyNamespace), arg0, arg1, arg2, arg3, arg4)
+}
+
+// ApplySecret mocks base method.
+func (m *MockGitOpsFluxClient) ApplySecret(arg0 context.Context, arg1 *types.Cluster, arg2, arg3 string, arg4 map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplySecret", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplySecret indicates an expected call of ApplySecret.
+func (mr *MockGitOpsFluxClientMockRecorder) ApplySecret(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplySecret", reflect.TypeOf((*MockGitOpsFluxClient)(nil).ApplySecret), arg0, arg1, arg2, arg3, arg4)
+}
+
+// BootstrapAzureDevOps mocks base method.
+func (m *MockGitOpsFluxClient) BootstrapAzureDevOps(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BootstrapAzureDevOps", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BootstrapAzureDevOps indicates an expected call of BootstrapAzureDevOps.
+func (mr *MockGitOpsFluxClientMockRecorder) BootstrapAzureDevOps(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapAzureDevOps", reflect.TypeOf((*MockGitOpsFluxClient)(nil).BootstrapAzureDevOps), arg0, arg1, arg2)
+}
+
+// BootstrapBitbucketServer mocks base method.
+func (m *MockGitOpsFluxClient) BootstrapBitbucketServer(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BootstrapBitbucketServer", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BootstrapBitbucketServer indicates an expected call of BootstrapBitbucketServer.
+func (mr *MockGitOpsFluxClientMockRecorder) BootstrapBitbucketServer(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapBitbucketServer", reflect.TypeOf((*MockGitOpsFluxClient)(nil).BootstrapBitbucketServer), arg0, arg1, arg2)
+}
+
+// BootstrapCodeCommit mocks base method.
+func (m *MockGitOpsFluxClient) BootstrapCodeCommit(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BootstrapCodeCommit", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BootstrapCodeCommit indicates an expected call of BootstrapCodeCommit.
+func (mr *MockGitOpsFluxClientMockRecorder) BootstrapCodeCommit(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapCodeCommit", reflect.TypeOf((*MockGitOpsFluxClient)(nil).BootstrapCodeCommit), arg0, arg1, arg2)
+}
+
 // BootstrapGit mocks base method.
 func (m *MockGitOpsFluxClient) BootstrapGit(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig, arg3 *config.CliConfig) error {
 	m.ctrl.T.Helper()
@@ -224,6 +528,20 @@ func (mr *MockGitOpsFluxClientMockRecorder) BootstrapGit(arg0, arg1, arg2, arg3
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapGit", reflect.TypeOf((*MockGitOpsFluxClient)(nil).BootstrapGit), arg0, arg1, arg2, arg3)
 }
 
+// BootstrapGitea mocks base method.
+func (m *MockGitOpsFluxClient) BootstrapGitea(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BootstrapGitea", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BootstrapGitea indicates an expected call of BootstrapGitea.
+func (mr *MockGitOpsFluxClientMockRecorder) BootstrapGitea(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapGitea", reflect.TypeOf((*MockGitOpsFluxClient)(nil).BootstrapGitea), arg0, arg1, arg2)
+}
+
 // BootstrapGithub mocks base method.
 func (m *MockGitOpsFluxClient) BootstrapGithub(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig) error {
 	m.ctrl.T.Helper()
@@ -238,6 +556,20 @@ func (mr *MockGitOpsFluxClientMockRecorder) BootstrapGithub(arg0, arg1, arg2 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapGithub", reflect.TypeOf((*MockGitOpsFluxClient)(nil).BootstrapGithub), arg0, arg1, arg2)
 }
 
+// BootstrapGitlab mocks base method.
+func (m *MockGitOpsFluxClient) BootstrapGitlab(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BootstrapGitlab", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BootstrapGitlab indicates an expected call of BootstrapGitlab.
+func (mr *MockGitOpsFluxClientMockRecorder) BootstrapGitlab(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapGitlab", reflect.TypeOf((*MockGitOpsFluxClient)(nil).BootstrapGitlab), arg0, arg1, arg2)
+}
+
 // DeleteSystemSecret mocks base method.
 func (m *MockGitOpsFluxClient) DeleteSystemSecret(arg0 context.Context, arg1 *types.Cluster, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -266,6 +598,20 @@ func (mr *MockGitOpsFluxClientMockRecorder) DisableResourceReconcile(arg0, arg1,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableResourceReconcile", reflect.TypeOf((*MockGitOpsFluxClient)(nil).DisableResourceReconcile), arg0, arg1, arg2, arg3, arg4)
 }
 
+// DryRunApplyKustomization mocks base method.
+func (m *MockGitOpsFluxClient) DryRunApplyKustomization(arg0 context.Context, arg1 *types.Cluster, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DryRunApplyKustomization", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DryRunApplyKustomization indicates an expected call of DryRunApplyKustomization.
+func (mr *MockGitOpsFluxClientMockRecorder) DryRunApplyKustomization(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DryRunApplyKustomization", reflect.TypeOf((*MockGitOpsFluxClient)(nil).DryRunApplyKustomization), arg0, arg1, arg2)
+}
+
 // EnableResourceReconcile mocks base method.
 func (m *MockGitOpsFluxClient) EnableResourceReconcile(arg0 context.Context, arg1 *types.Cluster, arg2, arg3, arg4 string) error {
 	m.ctrl.T.Helper()
@@ -280,6 +626,21 @@ func (mr *MockGitOpsFluxClientMockRecorder) EnableResourceReconcile(arg0, arg1,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableResourceReconcile", reflect.TypeOf((*MockGitOpsFluxClient)(nil).EnableResourceReconcile), arg0, arg1, arg2, arg3, arg4)
 }
 
+// ExportComponents mocks base method.
+func (m *MockGitOpsFluxClient) ExportComponents(arg0 context.Context, arg1 *v1alpha1.FluxConfig) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportComponents", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportComponents indicates an expected call of ExportComponents.
+func (mr *MockGitOpsFluxClientMockRecorder) ExportComponents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportComponents", reflect.TypeOf((*MockGitOpsFluxClient)(nil).ExportComponents), arg0, arg1)
+}
+
 // ForceReconcile mocks base method.
 func (m *MockGitOpsFluxClient) ForceReconcile(arg0 context.Context, arg1 *types.Cluster, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -309,75 +670,202 @@ func (mr *MockGitOpsFluxClientMockRecorder) GetCluster(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCluster", reflect.TypeOf((*MockGitOpsFluxClient)(nil).GetCluster), arg0, arg1, arg2)
 }
 
-// Reconcile mocks base method.
-func (m *MockGitOpsFluxClient) Reconcile(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig) error {
+// GetDeployment mocks base method.
+func (m *MockGitOpsFluxClient) GetDeployment(arg0 context.Context, arg1 *types.Cluster, arg2, arg3 string) (*v1.Deployment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Reconcile", arg0, arg1, arg2)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetDeployment", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*v1.Deployment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Reconcile indicates an expected call of Reconcile.
-func (mr *MockGitOpsFluxClientMockRecorder) Reconcile(arg0, arg1, arg2 interface{}) *gomock.Call {
+// GetDeployment indicates an expected call of GetDeployment.
+func (mr *MockGitOpsFluxClientMockRecorder) GetDeployment(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockGitOpsFluxClient)(nil).Reconcile), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeployment", reflect.TypeOf((*MockGitOpsFluxClient)(nil).GetDeployment), arg0, arg1, arg2, arg3)
 }
 
-// Uninstall mocks base method.
-func (m *MockGitOpsFluxClient) Uninstall(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig) error {
+// GetObject mocks base method.
+func (m *MockGitOpsFluxClient) GetObject(arg0 context.Context, arg1 *types.Cluster, arg2, arg3, arg4 string, arg5 runtime.Object) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Uninstall", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "GetObject", arg0, arg1, arg2, arg3, arg4, arg5)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Uninstall indicates an expected call of Uninstall.
-func (mr *MockGitOpsFluxClientMockRecorder) Uninstall(arg0, arg1, arg2 interface{}) *gomock.Call {
+// GetObject indicates an expected call of GetObject.
+func (mr *MockGitOpsFluxClientMockRecorder) GetObject(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Uninstall", reflect.TypeOf((*MockGitOpsFluxClient)(nil).Uninstall), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObject", reflect.TypeOf((*MockGitOpsFluxClient)(nil).GetObject), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
-// MockGitClient is a mock of GitClient interface.
-type MockGitClient struct {
-	ctrl     *gomock.Controller
-	recorder *MockGitClientMockRecorder
+// InstallComponents mocks base method.
+func (m *MockGitOpsFluxClient) InstallComponents(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallComponents", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// MockGitClientMockRecorder is the mock recorder for MockGitClient.
-type MockGitClientMockRecorder struct {
-	mock *MockGitClient
+// InstallComponents indicates an expected call of InstallComponents.
+func (mr *MockGitOpsFluxClientMockRecorder) InstallComponents(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallComponents", reflect.TypeOf((*MockGitOpsFluxClient)(nil).InstallComponents), arg0, arg1, arg2)
 }
 
-// NewMockGitClient creates a new mock instance.
-func NewMockGitClient(ctrl *gomock.Controller) *MockGitClient {
-	mock := &MockGitClient{ctrl: ctrl}
-	mock.recorder = &MockGitClientMockRecorder{mock}
-	return mock
+// PullArtifact mocks base method.
+func (m *MockGitOpsFluxClient) PullArtifact(arg0 context.Context, arg1 *v1alpha1.FluxConfig, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PullArtifact", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockGitClient) EXPECT() *MockGitClientMockRecorder {
-	return m.recorder
+// PullArtifact indicates an expected call of PullArtifact.
+func (mr *MockGitOpsFluxClientMockRecorder) PullArtifact(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PullArtifact", reflect.TypeOf((*MockGitOpsFluxClient)(nil).PullArtifact), arg0, arg1, arg2)
 }
 
-// Add mocks base method.
-func (m *MockGitClient) Add(arg0 string) error {
+// PushArtifact mocks base method.
+func (m *MockGitOpsFluxClient) PushArtifact(arg0 context.Context, arg1 *v1alpha1.FluxConfig, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Add", arg0)
+	ret := m.ctrl.Call(m, "PushArtifact", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Add indicates an expected call of Add.
-func (mr *MockGitClientMockRecorder) Add(arg0 interface{}) *gomock.Call {
+// PushArtifact indicates an expected call of PushArtifact.
+func (mr *MockGitOpsFluxClientMockRecorder) PushArtifact(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockGitClient)(nil).Add), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushArtifact", reflect.TypeOf((*MockGitOpsFluxClient)(nil).PushArtifact), arg0, arg1, arg2)
 }
 
-// Branch mocks base method.
-func (m *MockGitClient) Branch(arg0 string) error {
+// Reconcile mocks base method.
+func (m *MockGitOpsFluxClient) Reconcile(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Branch", arg0)
+	ret := m.ctrl.Call(m, "Reconcile", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockGitOpsFluxClientMockRecorder) Reconcile(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockGitOpsFluxClient)(nil).Reconcile), arg0, arg1, arg2)
+}
+
+// SetGitRepositoryBranch mocks base method.
+func (m *MockGitOpsFluxClient) SetGitRepositoryBranch(arg0 context.Context, arg1 *types.Cluster, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetGitRepositoryBranch", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetGitRepositoryBranch indicates an expected call of SetGitRepositoryBranch.
+func (mr *MockGitOpsFluxClientMockRecorder) SetGitRepositoryBranch(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGitRepositoryBranch", reflect.TypeOf((*MockGitOpsFluxClient)(nil).SetGitRepositoryBranch), arg0, arg1, arg2, arg3)
+}
+
+// SetKustomizationPath mocks base method.
+func (m *MockGitOpsFluxClient) SetKustomizationPath(arg0 context.Context, arg1 *types.Cluster, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetKustomizationPath", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetKustomizationPath indicates an expected call of SetKustomizationPath.
+func (mr *MockGitOpsFluxClientMockRecorder) SetKustomizationPath(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKustomizationPath", reflect.TypeOf((*MockGitOpsFluxClient)(nil).SetKustomizationPath), arg0, arg1, arg2, arg3)
+}
+
+// Uninstall mocks base method.
+func (m *MockGitOpsFluxClient) Uninstall(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Uninstall", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Uninstall indicates an expected call of Uninstall.
+func (mr *MockGitOpsFluxClientMockRecorder) Uninstall(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Uninstall", reflect.TypeOf((*MockGitOpsFluxClient)(nil).Uninstall), arg0, arg1, arg2)
+}
+
+// MockGitClient is a mock of GitClient interface.
+type MockGitClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockGitClientMockRecorder
+}
+
+// MockGitClientMockRecorder is the mock recorder for MockGitClient.
+type MockGitClientMockRecorder struct {
+	mock *MockGitClient
+}
+
+// NewMockGitClient creates a new mock instance.
+func NewMockGitClient(ctrl *gomock.Controller) *MockGitClient {
+	mock := &MockGitClient{ctrl: ctrl}
+	mock.recorder = &MockGitClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGitClient) EXPECT() *MockGitClientMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockGitClient) Add(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockGitClientMockRecorder) Add(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockGitClient)(nil).Add), arg0)
+}
+
+// AmendCommit mocks base method.
+func (m *MockGitClient) AmendCommit(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AmendCommit", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AmendCommit indicates an expected call of AmendCommit.
+func (mr *MockGitClientMockRecorder) AmendCommit(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AmendCommit", reflect.TypeOf((*MockGitClient)(nil).AmendCommit), arg0)
+}
+
+// ArchiveRepo mocks base method.
+func (m *MockGitClient) ArchiveRepo(arg0 context.Context, arg1 git.ArchiveRepoOpts) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveRepo", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ArchiveRepo indicates an expected call of ArchiveRepo.
+func (mr *MockGitClientMockRecorder) ArchiveRepo(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveRepo", reflect.TypeOf((*MockGitClient)(nil).ArchiveRepo), arg0, arg1)
+}
+
+// Branch mocks base method.
+func (m *MockGitClient) Branch(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Branch", arg0)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
@@ -416,6 +904,21 @@ func (mr *MockGitClientMockRecorder) Commit(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockGitClient)(nil).Commit), arg0)
 }
 
+// CreatePullRequest mocks base method.
+func (m *MockGitClient) CreatePullRequest(arg0 context.Context, arg1 git.CreatePullRequestOpts) (*git.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePullRequest", arg0, arg1)
+	ret0, _ := ret[0].(*git.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePullRequest indicates an expected call of CreatePullRequest.
+func (mr *MockGitClientMockRecorder) CreatePullRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePullRequest", reflect.TypeOf((*MockGitClient)(nil).CreatePullRequest), arg0, arg1)
+}
+
 // CreateRepo mocks base method.
 func (m *MockGitClient) CreateRepo(arg0 context.Context, arg1 git.CreateRepoOpts) error {
 	m.ctrl.T.Helper()
@@ -430,6 +933,76 @@ func (mr *MockGitClientMockRecorder) CreateRepo(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRepo", reflect.TypeOf((*MockGitClient)(nil).CreateRepo), arg0, arg1)
 }
 
+// CreateWebhook mocks base method.
+func (m *MockGitClient) CreateWebhook(arg0 context.Context, arg1 git.CreateWebhookOpts) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockGitClientMockRecorder) CreateWebhook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockGitClient)(nil).CreateWebhook), arg0, arg1)
+}
+
+// DeleteRemoteBranch mocks base method.
+func (m *MockGitClient) DeleteRemoteBranch(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRemoteBranch", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRemoteBranch indicates an expected call of DeleteRemoteBranch.
+func (mr *MockGitClientMockRecorder) DeleteRemoteBranch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRemoteBranch", reflect.TypeOf((*MockGitClient)(nil).DeleteRemoteBranch), arg0, arg1)
+}
+
+// DeleteRepo mocks base method.
+func (m *MockGitClient) DeleteRepo(arg0 context.Context, arg1 git.DeleteRepoOpts) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRepo", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRepo indicates an expected call of DeleteRepo.
+func (mr *MockGitClientMockRecorder) DeleteRepo(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRepo", reflect.TypeOf((*MockGitClient)(nil).DeleteRepo), arg0, arg1)
+}
+
+// FetchAndReset mocks base method.
+func (m *MockGitClient) FetchAndReset(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchAndReset", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FetchAndReset indicates an expected call of FetchAndReset.
+func (mr *MockGitClientMockRecorder) FetchAndReset(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAndReset", reflect.TypeOf((*MockGitClient)(nil).FetchAndReset), arg0, arg1)
+}
+
+// ForcePush mocks base method.
+func (m *MockGitClient) ForcePush(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForcePush", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForcePush indicates an expected call of ForcePush.
+func (mr *MockGitClientMockRecorder) ForcePush(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForcePush", reflect.TypeOf((*MockGitClient)(nil).ForcePush), arg0, arg1)
+}
+
 // GetRepo mocks base method.
 func (m *MockGitClient) GetRepo(arg0 context.Context) (*git.Repository, error) {
 	m.ctrl.T.Helper()
@@ -459,6 +1032,36 @@ func (mr *MockGitClientMockRecorder) Init() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Init", reflect.TypeOf((*MockGitClient)(nil).Init))
 }
 
+// LastCommit mocks base method.
+func (m *MockGitClient) LastCommit() (*git.Commit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastCommit")
+	ret0, _ := ret[0].(*git.Commit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LastCommit indicates an expected call of LastCommit.
+func (mr *MockGitClientMockRecorder) LastCommit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastCommit", reflect.TypeOf((*MockGitClient)(nil).LastCommit))
+}
+
+// LocalChanges mocks base method.
+func (m *MockGitClient) LocalChanges(arg0 context.Context, arg1 string) (*git.LocalChanges, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LocalChanges", arg0, arg1)
+	ret0, _ := ret[0].(*git.LocalChanges)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LocalChanges indicates an expected call of LocalChanges.
+func (mr *MockGitClientMockRecorder) LocalChanges(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LocalChanges", reflect.TypeOf((*MockGitClient)(nil).LocalChanges), arg0, arg1)
+}
+
 // PathExists mocks base method.
 func (m *MockGitClient) PathExists(arg0 context.Context, arg1, arg2, arg3, arg4 string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -502,6 +1105,78 @@ func (mr *MockGitClientMockRecorder) Push(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockGitClient)(nil).Push), arg0)
 }
 
+// PushTag mocks base method.
+func (m *MockGitClient) PushTag(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PushTag", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PushTag indicates an expected call of PushTag.
+func (mr *MockGitClientMockRecorder) PushTag(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushTag", reflect.TypeOf((*MockGitClient)(nil).PushTag), arg0, arg1)
+}
+
+// PushToBranch mocks base method.
+func (m *MockGitClient) PushToBranch(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PushToBranch", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PushToBranch indicates an expected call of PushToBranch.
+func (mr *MockGitClientMockRecorder) PushToBranch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushToBranch", reflect.TypeOf((*MockGitClient)(nil).PushToBranch), arg0, arg1)
+}
+
+// RebaseOnRemote mocks base method.
+func (m *MockGitClient) RebaseOnRemote(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebaseOnRemote", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RebaseOnRemote indicates an expected call of RebaseOnRemote.
+func (mr *MockGitClientMockRecorder) RebaseOnRemote(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebaseOnRemote", reflect.TypeOf((*MockGitClient)(nil).RebaseOnRemote), arg0)
+}
+
+// RemoteBranchExists mocks base method.
+func (m *MockGitClient) RemoteBranchExists(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoteBranchExists", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoteBranchExists indicates an expected call of RemoteBranchExists.
+func (mr *MockGitClientMockRecorder) RemoteBranchExists(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoteBranchExists", reflect.TypeOf((*MockGitClient)(nil).RemoteBranchExists), arg0, arg1)
+}
+
+// RemoteBranches mocks base method.
+func (m *MockGitClient) RemoteBranches(arg0 context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoteBranches", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoteBranches indicates an expected call of RemoteBranches.
+func (mr *MockGitClientMockRecorder) RemoteBranches(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoteBranches", reflect.TypeOf((*MockGitClient)(nil).RemoteBranches), arg0)
+}
+
 // Remove mocks base method.
 func (m *MockGitClient) Remove(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -516,6 +1191,201 @@ func (mr *MockGitClientMockRecorder) Remove(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockGitClient)(nil).Remove), arg0)
 }
 
+// ResetSoft mocks base method.
+func (m *MockGitClient) ResetSoft(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetSoft", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetSoft indicates an expected call of ResetSoft.
+func (mr *MockGitClientMockRecorder) ResetSoft(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetSoft", reflect.TypeOf((*MockGitClient)(nil).ResetSoft), arg0)
+}
+
+// SetSparseCheckoutDirectories mocks base method.
+func (m *MockGitClient) SetSparseCheckoutDirectories(arg0 ...string) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "SetSparseCheckoutDirectories", varargs...)
+}
+
+// SetSparseCheckoutDirectories indicates an expected call of SetSparseCheckoutDirectories.
+func (mr *MockGitClientMockRecorder) SetSparseCheckoutDirectories(arg0 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSparseCheckoutDirectories", reflect.TypeOf((*MockGitClient)(nil).SetSparseCheckoutDirectories), arg0...)
+}
+
+// StagedFiles mocks base method.
+func (m *MockGitClient) StagedFiles() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StagedFiles")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StagedFiles indicates an expected call of StagedFiles.
+func (mr *MockGitClientMockRecorder) StagedFiles() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StagedFiles", reflect.TypeOf((*MockGitClient)(nil).StagedFiles))
+}
+
+// Tag mocks base method.
+func (m *MockGitClient) Tag(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tag", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Tag indicates an expected call of Tag.
+func (mr *MockGitClientMockRecorder) Tag(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tag", reflect.TypeOf((*MockGitClient)(nil).Tag), arg0, arg1)
+}
+
+// ValidateBranchPush mocks base method.
+func (m *MockGitClient) ValidateBranchPush(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateBranchPush", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateBranchPush indicates an expected call of ValidateBranchPush.
+func (mr *MockGitClientMockRecorder) ValidateBranchPush(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateBranchPush", reflect.TypeOf((*MockGitClient)(nil).ValidateBranchPush), arg0, arg1)
+}
+
+// ValidateProvider mocks base method.
+func (m *MockGitClient) ValidateProvider(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateProvider", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateProvider indicates an expected call of ValidateProvider.
+func (mr *MockGitClientMockRecorder) ValidateProvider(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateProvider", reflect.TypeOf((*MockGitClient)(nil).ValidateProvider), arg0)
+}
+
+// ValidateProviderPermissions mocks base method.
+func (m *MockGitClient) ValidateProviderPermissions(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateProviderPermissions", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateProviderPermissions indicates an expected call of ValidateProviderPermissions.
+func (mr *MockGitClientMockRecorder) ValidateProviderPermissions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateProviderPermissions", reflect.TypeOf((*MockGitClient)(nil).ValidateProviderPermissions), arg0)
+}
+
+// ValidatePushAccess mocks base method.
+func (m *MockGitClient) ValidatePushAccess(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidatePushAccess", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidatePushAccess indicates an expected call of ValidatePushAccess.
+func (mr *MockGitClientMockRecorder) ValidatePushAccess(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidatePushAccess", reflect.TypeOf((*MockGitClient)(nil).ValidatePushAccess), arg0)
+}
+
+// ValidateRemoteExists mocks base method.
+func (m *MockGitClient) ValidateRemoteExists(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateRemoteExists", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateRemoteExists indicates an expected call of ValidateRemoteExists.
+func (mr *MockGitClientMockRecorder) ValidateRemoteExists(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateRemoteExists", reflect.TypeOf((*MockGitClient)(nil).ValidateRemoteExists), arg0)
+}
+
+// WebhookExists mocks base method.
+func (m *MockGitClient) WebhookExists(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WebhookExists", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WebhookExists indicates an expected call of WebhookExists.
+func (mr *MockGitClientMockRecorder) WebhookExists(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WebhookExists", reflect.TypeOf((*MockGitClient)(nil).WebhookExists), arg0, arg1)
+}
+
+// MockBucketClient is a mock of BucketClient interface.
+type MockBucketClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockBucketClientMockRecorder
+}
+
+// MockBucketClientMockRecorder is the mock recorder for MockBucketClient.
+type MockBucketClientMockRecorder struct {
+	mock *MockBucketClient
+}
+
+// NewMockBucketClient creates a new mock instance.
+func NewMockBucketClient(ctrl *gomock.Controller) *MockBucketClient {
+	mock := &MockBucketClient{ctrl: ctrl}
+	mock.recorder = &MockBucketClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBucketClient) EXPECT() *MockBucketClientMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockBucketClient) Delete(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBucketClientMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBucketClient)(nil).Delete), arg0, arg1)
+}
+
+// Upload mocks base method.
+func (m *MockBucketClient) Upload(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockBucketClientMockRecorder) Upload(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockBucketClient)(nil).Upload), arg0, arg1, arg2)
+}
+
 // MockTemplater is a mock of Templater interface.
 type MockTemplater struct {
 	ctrl     *gomock.Controller
@@ -558,3 +1428,78 @@ func (mr *MockTemplaterMockRecorder) WriteToFile(arg0, arg1, arg2 interface{}, a
 	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteToFile", reflect.TypeOf((*MockTemplater)(nil).WriteToFile), varargs...)
 }
+
+// MockSecretClient is a mock of SecretClient interface.
+type MockSecretClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecretClientMockRecorder
+}
+
+// MockSecretClientMockRecorder is the mock recorder for MockSecretClient.
+type MockSecretClientMockRecorder struct {
+	mock *MockSecretClient
+}
+
+// NewMockSecretClient creates a new mock instance.
+func NewMockSecretClient(ctrl *gomock.Controller) *MockSecretClient {
+	mock := &MockSecretClient{ctrl: ctrl}
+	mock.recorder = &MockSecretClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSecretClient) EXPECT() *MockSecretClientMockRecorder {
+	return m.recorder
+}
+
+// GetSecretFromNamespace mocks base method.
+func (m *MockSecretClient) GetSecretFromNamespace(arg0 context.Context, arg1, arg2, arg3 string) (*v10.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecretFromNamespace", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*v10.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecretFromNamespace indicates an expected call of GetSecretFromNamespace.
+func (mr *MockSecretClientMockRecorder) GetSecretFromNamespace(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretFromNamespace", reflect.TypeOf((*MockSecretClient)(nil).GetSecretFromNamespace), arg0, arg1, arg2, arg3)
+}
+
+// MockImageChecker is a mock of ImageChecker interface.
+type MockImageChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockImageCheckerMockRecorder
+}
+
+// MockImageCheckerMockRecorder is the mock recorder for MockImageChecker.
+type MockImageCheckerMockRecorder struct {
+	mock *MockImageChecker
+}
+
+// NewMockImageChecker creates a new mock instance.
+func NewMockImageChecker(ctrl *gomock.Controller) *MockImageChecker {
+	mock := &MockImageChecker{ctrl: ctrl}
+	mock.recorder = &MockImageCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImageChecker) EXPECT() *MockImageCheckerMockRecorder {
+	return m.recorder
+}
+
+// CheckImage mocks base method.
+func (m *MockImageChecker) CheckImage(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckImage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckImage indicates an expected call of CheckImage.
+func (mr *MockImageCheckerMockRecorder) CheckImage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckImage", reflect.TypeOf((*MockImageChecker)(nil).CheckImage), arg0, arg1)
+}
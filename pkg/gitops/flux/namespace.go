@@ -0,0 +1,27 @@
+package flux
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// createSystemNamespace creates the system namespace with the labels and annotations of the flux config before flux
+// is bootstrapped, so they are in place when the controllers start, i.e. pod security admission labels. The flux
+// bootstrap keeps the existing namespace and the flux-system patch keeps the metadata when it's reconciled.
+func (f *Flux) createSystemNamespace(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	metadata := clusterSpec.FluxConfig.Spec.SystemNamespaceMetadata
+	if cluster.ExistingManagement || metadata == nil || (len(metadata.Labels) == 0 && len(metadata.Annotations) == 0) {
+		return nil
+	}
+
+	namespace := clusterSpec.FluxConfig.Spec.SystemNamespace
+	logger.V(3).Info("Creating flux system namespace", "namespace", namespace, "labels", metadata.Labels, "annotations", metadata.Annotations)
+	if err := f.fluxClient.ApplyNamespace(ctx, cluster, namespace, metadata.Labels, metadata.Annotations); err != nil {
+		return fmt.Errorf("creating flux system namespace %s: %v", namespace, err)
+	}
+	return nil
+}
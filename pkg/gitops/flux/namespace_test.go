@@ -0,0 +1,51 @@
+package flux_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+var systemNamespaceMetadata = &v1alpha1.FluxNamespaceMetadata{
+	Labels:      map[string]string{"pod-security.kubernetes.io/enforce": "restricted"},
+	Annotations: map[string]string{"example.com/cost-center": "platform"},
+}
+
+func TestBootstrapCreatesSystemNamespace(t *testing.T) {
+	g := newFluxTest(t)
+	c := &types.Cluster{}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.SystemNamespaceMetadata = systemNamespaceMetadata
+
+	gomock.InOrder(
+		g.flux.EXPECT().ApplyNamespace(g.ctx, c, "flux-system", systemNamespaceMetadata.Labels, systemNamespaceMetadata.Annotations).Return(nil),
+		g.flux.EXPECT().BootstrapGithub(g.ctx, c, clusterSpec.FluxConfig).Return(nil),
+	)
+
+	g.Expect(g.gitOpsFlux.Bootstrap(g.ctx, c, clusterSpec)).To(Succeed())
+}
+
+func TestBootstrapCreateSystemNamespaceError(t *testing.T) {
+	g := newFluxTest(t)
+	c := &types.Cluster{}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.SystemNamespaceMetadata = systemNamespaceMetadata
+
+	g.flux.EXPECT().ApplyNamespace(g.ctx, c, "flux-system", systemNamespaceMetadata.Labels, systemNamespaceMetadata.Annotations).Return(errors.New("forbidden"))
+
+	g.Expect(g.gitOpsFlux.Bootstrap(g.ctx, c, clusterSpec)).To(MatchError("creating flux system namespace flux-system: forbidden"))
+}
+
+func TestBootstrapSkipsSystemNamespaceWithExistingManagement(t *testing.T) {
+	g := newFluxTest(t)
+	c := &types.Cluster{ExistingManagement: true}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.SystemNamespaceMetadata = systemNamespaceMetadata
+
+	g.Expect(g.gitOpsFlux.Bootstrap(g.ctx, c, clusterSpec)).To(Succeed())
+}
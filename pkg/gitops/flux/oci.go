@@ -0,0 +1,239 @@
+package flux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+// errOCIRepositoryNotSupported is returned by the operations that only make sense for a Git repository source.
+var errOCIRepositoryNotSupported = errors.New("operation not supported for a flux config with an ociRepository source")
+
+// usesOCIRepository returns true if the cluster syncs from an OCI artifact instead of a Git repository.
+func usesOCIRepository(clusterSpec *cluster.Spec) bool {
+	return clusterSpec.FluxConfig != nil && clusterSpec.FluxConfig.Spec.OCIRepository != nil
+}
+
+// installOCIGitOps renders the cluster manifests, publishes them as an OCI artifact and, for self-managed clusters,
+// installs flux and the flux-system sync pointing to the artifact. Managed clusters share the artifact of their
+// management cluster, so it is pulled first to avoid dropping the manifests of the other clusters when pushing.
+func (f *Flux) installOCIGitOps(ctx context.Context, cluster *types.Cluster, fc *fluxForCluster) error {
+	if fc.clusterSpec.Cluster.IsManaged() {
+		if err := fc.pullArtifact(ctx); err != nil {
+			return err
+		}
+	} else if err := fc.resetArtifactDir(); err != nil {
+		return err
+	}
+
+	logger.Info("Adding cluster configuration files to OCI artifact")
+	if err := fc.writeArtifactFiles(ctx); err != nil {
+		return err
+	}
+
+	if f.verifyManifestsBeforeBootstrap && !cluster.ExistingManagement {
+		if err := fc.verifyEksaManifests(ctx, cluster); err != nil {
+			return err
+		}
+	}
+
+	if err := fc.pushArtifact(ctx); err != nil {
+		return err
+	}
+
+	if err := f.Bootstrap(ctx, cluster, fc.clusterSpec); err != nil {
+		return err
+	}
+
+	if fc.clusterSpec.Cluster.IsSelfManaged() {
+		if f.verifyCommittedClusterConfig {
+			if err := fc.verifyCommittedClusterConfig(); err != nil {
+				return err
+			}
+		}
+		if err := fc.setupReceiver(ctx, cluster); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BootstrapOCIRepository installs the flux components and applies the flux-system kustomization, which syncs the
+// cluster from the OCI artifact. The artifact has to be pushed before, since flux can't bootstrap from an OCI registry.
+func (f *Flux) BootstrapOCIRepository(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if cluster.ExistingManagement || clusterSpec.FluxConfig.Spec.OCIRepository == nil {
+		return nil
+	}
+
+	return f.installComponentsAndSync(ctx, cluster, clusterSpec)
+}
+
+// installComponentsAndSync installs the flux components and applies the flux-system files written locally,
+// for the sources that flux can't bootstrap from.
+func (f *Flux) installComponentsAndSync(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	fc, err := newFluxForCluster(f, clusterSpec, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := f.fluxClient.InstallComponents(ctx, cluster, clusterSpec.FluxConfig); err != nil {
+		return err
+	}
+
+	return f.fluxClient.ApplyKustomization(ctx, cluster, path.Join(f.writer.Dir(), fc.fluxSystemDir()))
+}
+
+func (f *Flux) updateOCIEksaSpec(ctx context.Context, fc *fluxForCluster) error {
+	if err := fc.pullArtifact(ctx); err != nil {
+		return err
+	}
+
+	g := NewFileGenerator()
+	if err := g.Init(f.writer, fc.eksaSystemDir(), fc.fluxSystemDir()); err != nil {
+		return err
+	}
+
+	if err := g.WriteEksaFiles(fc.clusterSpec, fc.datacenterConfig, fc.machineConfigs); err != nil {
+		return err
+	}
+
+	if err := fc.pushArtifact(ctx); err != nil {
+		return err
+	}
+	logger.V(3).Info("Finished pushing updated cluster config file to OCI repository", "url", fc.clusterSpec.FluxConfig.Spec.OCIRepository.Url)
+	return nil
+}
+
+func (f *Flux) cleanupOCIArtifact(ctx context.Context, fc *fluxForCluster) error {
+	if fc.clusterSpec.Cluster.IsSelfManaged() {
+		logger.V(3).Info("Self-managed cluster artifact is left in the OCI repository, skip clean up")
+		return nil
+	}
+
+	if err := fc.pullArtifact(ctx); err != nil {
+		return err
+	}
+
+	p := path.Join(f.writer.Dir(), fc.eksaSystemDir())
+	if !validations.FileExists(p) {
+		logger.V(3).Info("cluster dir does not exist in OCI artifact, skip clean up")
+		return nil
+	}
+
+	if err := os.RemoveAll(p); err != nil {
+		return fmt.Errorf("removing %s from OCI artifact: %v", fc.eksaSystemDir(), err)
+	}
+
+	if err := fc.pushArtifact(ctx); err != nil {
+		return err
+	}
+	logger.V(3).Info("Finished cleaning up cluster files in OCI repository", "url", fc.clusterSpec.FluxConfig.Spec.OCIRepository.Url)
+	return nil
+}
+
+func (f *Flux) upgradeOCIFiles(ctx context.Context, fc *fluxForCluster) error {
+	if err := fc.pullArtifact(ctx); err != nil {
+		return err
+	}
+
+	g := NewFileGenerator()
+	if err := g.Init(f.writer, fc.eksaSystemDir(), fc.fluxSystemDir()); err != nil {
+		return err
+	}
+
+	if err := g.WriteFluxPatch(fc.clusterSpec); err != nil {
+		return err
+	}
+
+	if err := fc.writeFluxComponents(ctx, g); err != nil {
+		return err
+	}
+
+	return fc.pushArtifact(ctx)
+}
+
+// writeArtifactFiles writes the eks-a manifests and, for self-managed clusters, the flux system manifests.
+// The toolkit components are included so flux reconciles its own upgrades from the artifact or bucket.
+func (fc *fluxForCluster) writeArtifactFiles(ctx context.Context) error {
+	g := NewFileGenerator()
+	if err := g.Init(fc.writer, fc.eksaSystemDir(), fc.fluxSystemDir()); err != nil {
+		return err
+	}
+
+	if err := g.WriteEksaFiles(fc.clusterSpec, fc.datacenterConfig, fc.machineConfigs); err != nil {
+		return fmt.Errorf("writing eks-a config files: %v", err)
+	}
+
+	if fc.clusterSpec.Cluster.IsSelfManaged() {
+		if err := g.WriteFluxSystemFiles(fc.clusterSpec); err != nil {
+			return fmt.Errorf("writing flux system files: %v", err)
+		}
+		if err := fc.writeFluxComponents(ctx, g); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (fc *fluxForCluster) writeFluxComponents(ctx context.Context, g *FileGenerator) error {
+	components, err := fc.fluxClient.ExportComponents(ctx, fc.clusterSpec.FluxConfig)
+	if err != nil {
+		return fmt.Errorf("exporting flux components: %v", err)
+	}
+	if err := g.WriteFluxComponents(components); err != nil {
+		return fmt.Errorf("writing flux system files: %v", err)
+	}
+	return nil
+}
+
+// artifactDir is the local directory holding the files of the cluster config path, that are pushed as OCI artifact or uploaded to a bucket.
+func (fc *fluxForCluster) artifactDir() string {
+	return path.Join(fc.writer.Dir(), fc.path())
+}
+
+// resetArtifactDir empties the local artifact directory, so files from previous runs are never pushed.
+func (fc *fluxForCluster) resetArtifactDir() error {
+	if err := os.RemoveAll(fc.artifactDir()); err != nil {
+		return fmt.Errorf("cleaning up local artifact directory: %v", err)
+	}
+	if err := os.MkdirAll(fc.artifactDir(), os.ModePerm); err != nil {
+		return fmt.Errorf("creating local artifact directory: %v", err)
+	}
+	return nil
+}
+
+func (fc *fluxForCluster) pullArtifact(ctx context.Context) error {
+	if err := fc.resetArtifactDir(); err != nil {
+		return err
+	}
+
+	logger.V(3).Info("Pulling OCI artifact", "url", fc.clusterSpec.FluxConfig.Spec.OCIRepository.Url)
+	if err := fc.fluxClient.PullArtifact(ctx, fc.clusterSpec.FluxConfig, fc.artifactDir()); err != nil {
+		return fmt.Errorf("pulling OCI artifact: %v", err)
+	}
+	return nil
+}
+
+func (fc *fluxForCluster) pushArtifact(ctx context.Context) error {
+	logger.V(3).Info("Pushing OCI artifact", "url", fc.clusterSpec.FluxConfig.Spec.OCIRepository.Url)
+	if err := fc.fluxClient.PushArtifact(ctx, fc.clusterSpec.FluxConfig, fc.artifactDir()); err != nil {
+		return fmt.Errorf("pushing OCI artifact: %v", err)
+	}
+	return nil
+}
+
+// WithWriter sets the writer for the local files of the flux config, used when there is no git repository
+// to take it from, i.e. with an OCI repository or bucket source.
+func WithWriter(writer filewriter.FileWriter) Opt {
+	return func(f *Flux) {
+		f.writer = writer
+	}
+}
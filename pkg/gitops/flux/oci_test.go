@@ -0,0 +1,156 @@
+package flux_test
+
+import (
+	"errors"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+func newOCIClusterSpec(t *testing.T, clusterConfig *v1alpha1.Cluster) *cluster.Spec {
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+	clusterSpec.FluxConfig.Spec.Github = nil
+	clusterSpec.FluxConfig.Spec.OCIRepository = &v1alpha1.OCIRepositoryConfig{
+		Url:       "oci://registry.local/eksa/fleet",
+		Tag:       "latest",
+		SecretRef: "registry-credentials",
+	}
+	return clusterSpec
+}
+
+func TestInstallGitOpsOCIRepositoryManagementCluster(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newOCIClusterSpec(t, v1alpha1.NewCluster(clusterName))
+	artifactDir := path.Join(g.writer.Dir(), "clusters/management-cluster")
+
+	g.flux.EXPECT().ExportComponents(g.ctx, clusterSpec.FluxConfig).Return([]byte("components"), nil)
+	g.flux.EXPECT().PushArtifact(g.ctx, clusterSpec.FluxConfig, artifactDir).Return(nil)
+	g.flux.EXPECT().InstallComponents(g.ctx, cluster, clusterSpec.FluxConfig).Return(nil)
+	g.flux.EXPECT().ApplyKustomization(g.ctx, cluster, path.Join(artifactDir, "flux-system")).Return(nil)
+
+	g.Expect(g.gitOpsFlux.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(artifactDir, "management-cluster/eksa-system", defaultEksaClusterConfigFileName), "./testdata/cluster-config-oci-management.yaml")
+	test.AssertFilesEquals(t, path.Join(artifactDir, "flux-system", defaultFluxSyncFileName), "./testdata/gotk-sync-oci.yaml")
+	test.AssertContentToFile(t, "components", path.Join(artifactDir, "flux-system", "gotk-components.yaml"))
+}
+
+func TestInstallGitOpsOCIRepositoryWorkloadCluster(t *testing.T) {
+	cluster := &types.Cluster{ExistingManagement: true}
+	clusterName := "workload-cluster"
+	clusterConfig := v1alpha1.NewCluster(clusterName)
+	clusterConfig.SetManagedBy("management-cluster")
+	g := newFluxTest(t)
+	clusterSpec := newOCIClusterSpec(t, clusterConfig)
+	artifactDir := path.Join(g.writer.Dir(), "clusters/management-cluster")
+
+	g.flux.EXPECT().PullArtifact(g.ctx, clusterSpec.FluxConfig, artifactDir).Return(nil)
+	g.flux.EXPECT().PushArtifact(g.ctx, clusterSpec.FluxConfig, artifactDir).Return(nil)
+	g.flux.EXPECT().InstallComponents(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	g.Expect(g.gitOpsFlux.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(artifactDir, "workload-cluster/eksa-system", defaultEksaClusterConfigFileName), "./testdata/cluster-config-oci-workload.yaml")
+	g.Expect(path.Join(artifactDir, "flux-system", defaultFluxSyncFileName)).NotTo(BeAnExistingFile())
+}
+
+func TestInstallGitOpsOCIRepositoryPushError(t *testing.T) {
+	cluster := &types.Cluster{}
+	g := newFluxTest(t)
+	clusterSpec := newOCIClusterSpec(t, v1alpha1.NewCluster("management-cluster"))
+
+	g.flux.EXPECT().ExportComponents(g.ctx, clusterSpec.FluxConfig).Return([]byte("components"), nil)
+	g.flux.EXPECT().PushArtifact(g.ctx, clusterSpec.FluxConfig, gomock.Any()).Return(errors.New("error in push"))
+
+	g.Expect(g.gitOpsFlux.InstallGitOps(g.ctx, cluster, clusterSpec, nil, nil)).To(MatchError(ContainSubstring("error in push")))
+}
+
+func TestInstallGitOpsOCIRepositoryBootstrapError(t *testing.T) {
+	cluster := &types.Cluster{}
+	g := newFluxTest(t)
+	clusterSpec := newOCIClusterSpec(t, v1alpha1.NewCluster("management-cluster"))
+
+	g.flux.EXPECT().ExportComponents(g.ctx, clusterSpec.FluxConfig).Return([]byte("components"), nil)
+	g.flux.EXPECT().PushArtifact(g.ctx, clusterSpec.FluxConfig, gomock.Any()).Return(nil)
+	g.flux.EXPECT().InstallComponents(g.ctx, cluster, clusterSpec.FluxConfig).Return(errors.New("error in install"))
+	g.flux.EXPECT().Uninstall(g.ctx, cluster, clusterSpec.FluxConfig).Return(nil)
+
+	g.Expect(g.gitOpsFlux.InstallGitOps(g.ctx, cluster, clusterSpec, nil, nil)).To(MatchError(ContainSubstring("installing OCI repository gitops: error in install")))
+}
+
+func TestUpdateGitEksaSpecOCIRepository(t *testing.T) {
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newOCIClusterSpec(t, v1alpha1.NewCluster(clusterName))
+	artifactDir := path.Join(g.writer.Dir(), "clusters/management-cluster")
+
+	g.flux.EXPECT().PullArtifact(g.ctx, clusterSpec.FluxConfig, artifactDir).Return(nil)
+	g.flux.EXPECT().PushArtifact(g.ctx, clusterSpec.FluxConfig, artifactDir).Return(nil)
+
+	g.Expect(g.gitOpsFlux.UpdateGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(artifactDir, "management-cluster/eksa-system", defaultEksaClusterConfigFileName), "./testdata/cluster-config-oci-management.yaml")
+}
+
+func TestUpdateGitEksaSpecOCIRepositoryPullError(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := newOCIClusterSpec(t, v1alpha1.NewCluster("management-cluster"))
+
+	g.flux.EXPECT().PullArtifact(g.ctx, clusterSpec.FluxConfig, gomock.Any()).Return(errors.New("error in pull"))
+
+	g.Expect(g.gitOpsFlux.UpdateGitEksaSpec(g.ctx, clusterSpec, nil, nil)).To(MatchError(ContainSubstring("error in pull")))
+}
+
+func TestCleanupGitRepoOCIRepositoryWorkloadCluster(t *testing.T) {
+	clusterConfig := v1alpha1.NewCluster("workload-cluster")
+	clusterConfig.SetManagedBy("management-cluster")
+	g := newFluxTest(t)
+	clusterSpec := newOCIClusterSpec(t, clusterConfig)
+	artifactDir := path.Join(g.writer.Dir(), "clusters/management-cluster")
+	eksaSystemDir := path.Join(artifactDir, "workload-cluster/eksa-system")
+
+	g.flux.EXPECT().PullArtifact(g.ctx, clusterSpec.FluxConfig, artifactDir).DoAndReturn(
+		func(_, _, _ interface{}) error {
+			return os.MkdirAll(eksaSystemDir, os.ModePerm)
+		},
+	)
+	g.flux.EXPECT().PushArtifact(g.ctx, clusterSpec.FluxConfig, artifactDir).Return(nil)
+
+	g.Expect(g.gitOpsFlux.CleanupGitRepo(g.ctx, clusterSpec)).To(Succeed())
+	g.Expect(eksaSystemDir).NotTo(BeADirectory())
+}
+
+func TestValidationsOCIRepository(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := newOCIClusterSpec(t, v1alpha1.NewCluster("management-cluster"))
+
+	g.Expect(g.gitOpsFlux.Validations(g.ctx, clusterSpec)).To(BeEmpty())
+}
+
+func TestForceReconcileGitRepoOCIRepository(t *testing.T) {
+	cluster := &types.Cluster{}
+	g := newFluxTest(t)
+	clusterSpec := newOCIClusterSpec(t, v1alpha1.NewCluster("management-cluster"))
+
+	g.flux.EXPECT().Reconcile(g.ctx, cluster, clusterSpec.FluxConfig).Return(nil)
+
+	g.Expect(g.gitOpsFlux.ForceReconcileGitRepo(g.ctx, cluster, clusterSpec)).To(Succeed())
+}
+
+func TestValidateKustomizationOCIRepositoryNotSupported(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := newOCIClusterSpec(t, v1alpha1.NewCluster("management-cluster"))
+
+	g.Expect(g.gitOpsFlux.ValidateKustomization(g.ctx, clusterSpec)).To(MatchError(ContainSubstring("not supported for a flux config with an ociRepository source")))
+}
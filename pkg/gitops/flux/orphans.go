@@ -0,0 +1,138 @@
+package flux
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+const pruneClusterconfigCommitMessage = "Prune commit of orphaned cluster configuration; generated by EKS-A CLI"
+
+type orphanedClusterDirsOptions struct {
+	prune bool
+}
+
+// OrphanedClusterDirsOpt configures FindOrphanedClusterDirs.
+type OrphanedClusterDirsOpt func(*orphanedClusterDirsOptions)
+
+// WithOrphanedClusterDirsPrune makes FindOrphanedClusterDirs remove the orphaned cluster directories
+// from the repository and push the removal. Without it, the directories are only listed.
+func WithOrphanedClusterDirsPrune() OrphanedClusterDirsOpt {
+	return func(o *orphanedClusterDirsOptions) {
+		o.prune = true
+	}
+}
+
+// ListManagedClusters syncs the git repository and returns the names of the clusters that have
+// an eksa-system directory under the flux cluster config path.
+func (f *Flux) ListManagedClusters(ctx context.Context, clusterSpec *cluster.Spec) ([]string, error) {
+	if f.shouldSkipFlux() {
+		logger.Info("GitOps field not specified, list managed clusters skipped")
+		return nil, nil
+	}
+
+	fc, err := newFluxForCluster(f, clusterSpec, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fc.syncGitRepo(ctx); err != nil {
+		return nil, err
+	}
+
+	return fc.listManagedClusters()
+}
+
+// FindOrphanedClusterDirs syncs the git repository and returns the cluster directories under the flux cluster
+// config path whose cluster is not in knownClusters. The cluster in clusterSpec is never considered orphaned.
+// The directories are only removed from the repository when the WithOrphanedClusterDirsPrune option is provided.
+func (f *Flux) FindOrphanedClusterDirs(ctx context.Context, clusterSpec *cluster.Spec, knownClusters []string, opts ...OrphanedClusterDirsOpt) ([]string, error) {
+	if f.shouldSkipFlux() {
+		logger.Info("GitOps field not specified, find orphaned cluster dirs skipped")
+		return nil, nil
+	}
+
+	o := &orphanedClusterDirsOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	fc, err := newFluxForCluster(f, clusterSpec, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fc.syncGitRepo(ctx); err != nil {
+		return nil, err
+	}
+
+	clusters, err := fc.listManagedClusters()
+	if err != nil {
+		return nil, err
+	}
+
+	var orphaned []string
+	for _, c := range clusters {
+		if c == clusterSpec.Cluster.Name || containsString(knownClusters, c) {
+			continue
+		}
+		orphaned = append(orphaned, path.Join(fc.path(), c))
+	}
+
+	if len(orphaned) == 0 {
+		logger.V(3).Info("No orphaned cluster directories found", "path", fc.path())
+		return nil, nil
+	}
+
+	logger.V(3).Info("Found orphaned cluster directories", "dirs", orphaned)
+	if !o.prune {
+		return orphaned, nil
+	}
+
+	for _, dir := range orphaned {
+		if err := f.gitClient.Remove(dir); err != nil {
+			return nil, fmt.Errorf("removing %s in git: %v", dir, err)
+		}
+	}
+
+	if err := f.pushToRemoteRepo(ctx, strings.Join(orphaned, ", "), pruneClusterconfigCommitMessage); err != nil {
+		return nil, err
+	}
+
+	logger.V(3).Info("Finished pruning orphaned cluster directories in git", "repository", fc.repository())
+	return orphaned, nil
+}
+
+func (fc *fluxForCluster) listManagedClusters() ([]string, error) {
+	if hasCustomEksaSystemDir(fc.clusterSpec) {
+		return nil, fmt.Errorf("listing cluster directories is not supported with a custom eksaSystemDir %s", fc.clusterSpec.FluxConfig.Spec.EksaSystemDir)
+	}
+
+	root := path.Join(fc.writer.Dir(), fc.path())
+	if !validations.FileExists(root) {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, fmt.Errorf("listing cluster directories in %s: %v", fc.path(), err)
+	}
+
+	var clusters []string
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		if validations.FileExists(path.Join(root, e.Name(), eksaSystemDirName)) {
+			clusters = append(clusters, e.Name())
+		}
+	}
+
+	return clusters, nil
+}
@@ -0,0 +1,90 @@
+package flux_test
+
+import (
+	"errors"
+	"os"
+	"path"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+)
+
+func setupClusterDirsRepo(t *testing.T, g fluxTest, clusters ...string) *cluster.Spec {
+	t.Helper()
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+
+	dirs := []string{".git", testFluxSystemDir, "clusters/management-cluster/not-a-cluster"}
+	for _, c := range clusters {
+		dirs = append(dirs, path.Join("clusters/management-cluster", c, "eksa-system"))
+	}
+	for _, d := range dirs {
+		if err := os.MkdirAll(path.Join(g.writer.Dir(), d), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	return clusterSpec
+}
+
+func TestListManagedClusters(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := setupClusterDirsRepo(t, g, "management-cluster", "workload-1", "workload-2")
+
+	g.Expect(g.gitOpsFlux.ListManagedClusters(g.ctx, clusterSpec)).To(ConsistOf("management-cluster", "workload-1", "workload-2"))
+}
+
+func TestFindOrphanedClusterDirs(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := setupClusterDirsRepo(t, g, "management-cluster", "workload-1", "workload-2")
+
+	dirs, err := g.gitOpsFlux.FindOrphanedClusterDirs(g.ctx, clusterSpec, []string{"workload-1"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(dirs).To(ConsistOf("clusters/management-cluster/workload-2"))
+	g.Expect(path.Join(g.writer.Dir(), "clusters/management-cluster/workload-2")).To(BeADirectory())
+}
+
+func TestFindOrphanedClusterDirsNoneFound(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := setupClusterDirsRepo(t, g, "management-cluster", "workload-1")
+
+	dirs, err := g.gitOpsFlux.FindOrphanedClusterDirs(g.ctx, clusterSpec, []string{"workload-1"}, flux.WithOrphanedClusterDirsPrune())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(dirs).To(BeEmpty())
+}
+
+func TestFindOrphanedClusterDirsPrune(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := setupClusterDirsRepo(t, g, "management-cluster", "workload-1", "workload-2")
+
+	g.git.EXPECT().Remove("clusters/management-cluster/workload-1").Return(nil)
+	g.git.EXPECT().Remove("clusters/management-cluster/workload-2").Return(nil)
+	g.git.EXPECT().Commit("Prune commit of orphaned cluster configuration; generated by EKS-A CLI").Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+
+	dirs, err := g.gitOpsFlux.FindOrphanedClusterDirs(g.ctx, clusterSpec, nil, flux.WithOrphanedClusterDirsPrune())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(dirs).To(ConsistOf("clusters/management-cluster/workload-1", "clusters/management-cluster/workload-2"))
+}
+
+func TestFindOrphanedClusterDirsPruneRemoveError(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := setupClusterDirsRepo(t, g, "management-cluster", "workload-1")
+
+	g.git.EXPECT().Remove("clusters/management-cluster/workload-1").Return(errors.New("error in remove"))
+
+	_, err := g.gitOpsFlux.FindOrphanedClusterDirs(g.ctx, clusterSpec, nil, flux.WithOrphanedClusterDirsPrune())
+	g.Expect(err).To(MatchError(ContainSubstring("error in remove")))
+}
+
+func TestFindOrphanedClusterDirsSkip(t *testing.T) {
+	g := newFluxTest(t)
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, nil, nil)
+
+	g.Expect(f.FindOrphanedClusterDirs(g.ctx, g.clusterSpec, nil)).To(BeEmpty())
+	g.Expect(f.ListManagedClusters(g.ctx, g.clusterSpec)).To(BeEmpty())
+}
@@ -0,0 +1,133 @@
+package flux
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+// pathClaimsDir is the repository directory where each management cluster sharing the repository records the
+// cluster config path it reconciles from, one file per management cluster so concurrent operations don't conflict.
+const pathClaimsDir = ".eksa/claims"
+
+// pathClaim is the cluster config path claimed by a management cluster in the repository.
+type pathClaim struct {
+	Cluster string `json:"cluster"`
+	Path    string `json:"path"`
+}
+
+func (fc *fluxForCluster) pathClaimFile() string {
+	return path.Join(pathClaimsDir, fc.clusterSpec.Cluster.Name+".yaml")
+}
+
+// validatePathNotClaimed returns an error if the cluster config path of the management cluster is the same as, or
+// nested with, a path claimed by another management cluster in the local repository. validateRemoteConfigPathDoesNotExist
+// only catches an identical path that was already pushed, not clusters whose paths contain each other's files.
+func (fc *fluxForCluster) validatePathNotClaimed() error {
+	if !fc.clusterSpec.Cluster.IsSelfManaged() {
+		return nil
+	}
+
+	claims, err := fc.readPathClaims()
+	if err != nil {
+		return err
+	}
+
+	name := fc.clusterSpec.Cluster.Name
+	for _, c := range claims {
+		if c.Cluster == name {
+			if c.Path != fc.path() {
+				return fmt.Errorf("management cluster %s already claims flux path %s in %s, remove the claim if the cluster no longer exists", name, c.Path, fc.pathClaimFile())
+			}
+			continue
+		}
+		if pathsOverlap(c.Path, fc.path()) {
+			return fmt.Errorf("flux path %s overlaps path %s claimed by management cluster %s", fc.path(), c.Path, c.Cluster)
+		}
+	}
+	return nil
+}
+
+func (fc *fluxForCluster) readPathClaims() ([]pathClaim, error) {
+	dir := path.Join(fc.writer.Dir(), pathClaimsDir)
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading flux path claims: %v", err)
+	}
+
+	var claims []pathClaim
+	for _, e := range entries {
+		if e.IsDir() || path.Ext(e.Name()) != ".yaml" {
+			continue
+		}
+		content, err := os.ReadFile(path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading flux path claim %s: %v", e.Name(), err)
+		}
+		c := pathClaim{}
+		if err := yaml.Unmarshal(content, &c); err != nil {
+			return nil, fmt.Errorf("parsing flux path claim %s: %v", e.Name(), err)
+		}
+		claims = append(claims, c)
+	}
+	return claims, nil
+}
+
+// writePathClaim writes the path claim of the management cluster and stages it, so it's committed with the
+// cluster config files.
+func (fc *fluxForCluster) writePathClaim() error {
+	if !fc.clusterSpec.Cluster.IsSelfManaged() {
+		return nil
+	}
+
+	content, err := yaml.Marshal(pathClaim{Cluster: fc.clusterSpec.Cluster.Name, Path: fc.path()})
+	if err != nil {
+		return fmt.Errorf("marshalling flux path claim: %v", err)
+	}
+
+	w, err := fc.writer.WithDir(pathClaimsDir)
+	if err != nil {
+		return fmt.Errorf("initializing writer for %s: %v", pathClaimsDir, err)
+	}
+	w.CleanUpTemp()
+
+	if _, err := w.Write(path.Base(fc.pathClaimFile()), content, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("writing flux path claim: %v", err)
+	}
+
+	if err := fc.gitClient.Add(fc.pathClaimFile()); err != nil {
+		return fmt.Errorf("adding %s to git: %v", fc.pathClaimFile(), err)
+	}
+	return nil
+}
+
+// removePathClaim stages the removal of the path claim of the management cluster, if it exists.
+func (fc *fluxForCluster) removePathClaim() error {
+	if !fc.clusterSpec.Cluster.IsSelfManaged() || !validations.FileExists(path.Join(fc.writer.Dir(), fc.pathClaimFile())) {
+		return nil
+	}
+
+	logger.V(3).Info("Removing flux path claim", "file", fc.pathClaimFile())
+	if err := fc.gitClient.Remove(fc.pathClaimFile()); err != nil {
+		return fmt.Errorf("removing %s in git: %v", fc.pathClaimFile(), err)
+	}
+	return nil
+}
+
+// pathsOverlap returns true if the paths are the same or one is a parent directory of the other.
+func pathsOverlap(a, b string) bool {
+	a, b = path.Clean(a), path.Clean(b)
+	return a == b || strings.HasPrefix(b, a+"/") || strings.HasPrefix(a, b+"/")
+}
@@ -0,0 +1,149 @@
+package flux_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	gitFactory "github.com/aws/eks-anywhere/pkg/git/factory"
+	gitMocks "github.com/aws/eks-anywhere/pkg/git/mocks"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+func writePathClaim(t *testing.T, dir, clusterName, claimedPath string) {
+	t.Helper()
+	p := path.Join(dir, pathClaimFile(clusterName))
+	if err := os.MkdirAll(path.Dir(p), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte("cluster: "+clusterName+"\npath: "+claimedPath+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestInstallGitOpsClaimsClusterConfigPath(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	writePathClaim(t, g.writer.Dir(), "other-management-cluster", "clusters/other-management-cluster")
+
+	expectInstallGitOpsWithRepo(g, cluster, clusterSpec)
+
+	g.Expect(g.gitOpsFlux.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+
+	claim, err := os.ReadFile(path.Join(g.writer.Dir(), pathClaimFile(clusterName)))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(claim)).To(Equal("cluster: management-cluster\npath: clusters/management-cluster\n"))
+}
+
+func TestInstallGitOpsClusterConfigPathClaimed(t *testing.T) {
+	tests := []struct {
+		testName    string
+		claimedBy   string
+		claimedPath string
+		wantErr     string
+	}{
+		{
+			testName:    "same path",
+			claimedBy:   "other-management-cluster",
+			claimedPath: "clusters/management-cluster",
+			wantErr:     "flux path clusters/management-cluster overlaps path clusters/management-cluster claimed by management cluster other-management-cluster",
+		},
+		{
+			testName:    "parent path",
+			claimedBy:   "other-management-cluster",
+			claimedPath: "clusters",
+			wantErr:     "flux path clusters/management-cluster overlaps path clusters claimed by management cluster other-management-cluster",
+		},
+		{
+			testName:    "nested path",
+			claimedBy:   "other-management-cluster",
+			claimedPath: "clusters/management-cluster/other",
+			wantErr:     "flux path clusters/management-cluster overlaps path clusters/management-cluster/other claimed by management cluster other-management-cluster",
+		},
+		{
+			testName:    "cluster claims another path",
+			claimedBy:   "management-cluster",
+			claimedPath: "clusters/prod",
+			wantErr:     "management cluster management-cluster already claims flux path clusters/prod in .eksa/claims/management-cluster.yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			cluster := &types.Cluster{}
+			clusterName := "management-cluster"
+			g := newFluxTest(t)
+			clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+			writePathClaim(t, g.writer.Dir(), tt.claimedBy, tt.claimedPath)
+
+			g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: clusterSpec.FluxConfig.Spec.Github.Repository}, nil)
+			g.git.EXPECT().Clone(g.ctx).Return(nil)
+			g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+
+			err := g.gitOpsFlux.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
+
+func TestInstallGitOpsWorkloadClusterDoesNotClaimPath(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "workload-cluster"
+	g := newFluxTest(t)
+	clusterConfig := v1alpha1.NewCluster(clusterName)
+	clusterConfig.SetManagedBy("management-cluster")
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+	writePathClaim(t, g.writer.Dir(), "management-cluster", "clusters/management-cluster")
+
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: clusterSpec.FluxConfig.Spec.Github.Repository}, nil)
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add("clusters").Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.git.EXPECT().Pull(g.ctx, clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, clusterSpec.FluxConfig).Return(nil)
+
+	g.Expect(g.gitOpsFlux.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+	g.Expect(path.Join(g.writer.Dir(), pathClaimFile(clusterName))).NotTo(BeAnExistingFile())
+}
+
+func TestCleanupGitRepoRemovesPathClaim(t *testing.T) {
+	g := newFluxTest(t)
+	mockCtrl := gomock.NewController(t)
+	clusterName := "management-cluster"
+	expectedClusterPath := "clusters/management-cluster"
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+
+	gitClient := gitMocks.NewMockClient(mockCtrl)
+	gitClient.EXPECT().Clone(g.ctx).Return(nil)
+	gitClient.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	gitClient.EXPECT().Remove(expectedClusterPath).Return(nil)
+	gitClient.EXPECT().Remove(pathClaimFile(clusterName)).Return(nil)
+	gitClient.EXPECT().Commit(test.OfType("string")).Return(nil)
+	gitClient.EXPECT().Push(g.ctx).Return(nil)
+
+	_, w := test.NewWriter(t)
+	if _, err := w.WithDir(expectedClusterPath); err != nil {
+		t.Fatal(err)
+	}
+	writePathClaim(t, w.Dir(), clusterName, expectedClusterPath)
+
+	f := flux.NewFlux(nil, nil, &gitFactory.GitTools{
+		Provider: gitMocks.NewMockProviderClient(mockCtrl),
+		Client:   gitClient,
+		Writer:   w,
+	}, nil)
+
+	g.Expect(f.CleanupGitRepo(g.ctx, clusterSpec)).To(Succeed())
+}
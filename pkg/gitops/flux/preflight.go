@@ -0,0 +1,114 @@
+package flux
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+// Preflight runs all the git and git provider checks required before installing GitOps for the cluster:
+// the validity of the flux system namespace, the provider authentication and permissions, the reachability
+// of the git remote and the availability of the cluster config path in the configured branch.
+// All the checks are run and every failure is reported in the returned aggregated error.
+func (f *Flux) Preflight(ctx context.Context, clusterSpec *cluster.Spec) error {
+	if f.shouldSkipFlux() {
+		logger.Info("GitOps field not specified, flux preflight skipped")
+		return nil
+	}
+
+	fc, err := newFluxForCluster(f, clusterSpec, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	preflights := []validations.Validation{
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "Flux system namespace",
+				Remediation: "Please provide a valid DNS-1123 label as the flux system namespace",
+				Err:         fc.validateNamespace(),
+			}
+		},
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "Git provider access",
+				Remediation: "Please make sure the git provider credentials are valid and have write permissions to the repository",
+				Err:         f.gitClient.ValidateProvider(ctx),
+			}
+		},
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "Git remote repository",
+				Remediation: "Please make sure the git repository exists and is reachable with the provided credentials",
+				Err:         fc.validateRemoteReachable(ctx),
+			}
+		},
+	}
+	preflights = append(preflights, f.Validations(ctx, clusterSpec)...)
+
+	preflightErr := &PreflightError{}
+	for _, preflight := range preflights {
+		result := preflight()
+		result.Report()
+		if result.Err != nil {
+			preflightErr.Failures = append(preflightErr.Failures, &PreflightFailure{Name: result.Name, Err: result.Err, Remediation: result.Remediation})
+		}
+	}
+
+	if len(preflightErr.Failures) > 0 {
+		return preflightErr
+	}
+
+	logger.V(3).Info("Finished flux preflight", "repository", fc.repository())
+	return nil
+}
+
+// PreflightError is returned by Preflight and lists every failed check.
+type PreflightError struct {
+	Failures []*PreflightFailure
+}
+
+func (e *PreflightError) Error() string {
+	failures := make([]string, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		failures = append(failures, f.Error())
+	}
+	return fmt.Sprintf("flux preflight failed: %s", strings.Join(failures, "; "))
+}
+
+// PreflightFailure describes a single failed flux preflight check.
+type PreflightFailure struct {
+	Name        string
+	Err         error
+	Remediation string
+}
+
+func (e *PreflightFailure) Error() string {
+	return fmt.Sprintf("%s: %v (%s)", strings.ToLower(e.Name), e.Err, e.Remediation)
+}
+
+func (e *PreflightFailure) Unwrap() error {
+	return e.Err
+}
+
+func (fc *fluxForCluster) validateNamespace() error {
+	if errs := validation.IsDNS1123Label(fc.namespace()); len(errs) > 0 {
+		return fmt.Errorf("invalid namespace %q: %s", fc.namespace(), strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// validateRemoteReachable checks the generic git remote can be reached and listed with the configured credentials.
+// Github repositories are created during the install if they don't exist, so they are covered by the provider check.
+func (fc *fluxForCluster) validateRemoteReachable(ctx context.Context) error {
+	if fc.clusterSpec.FluxConfig.Spec.Git == nil {
+		return nil
+	}
+	return fc.gitClient.ValidateRemoteExists(ctx)
+}
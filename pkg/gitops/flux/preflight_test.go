@@ -0,0 +1,124 @@
+package flux_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+)
+
+func TestPreflightGithubSuccess(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+
+	g.git.EXPECT().ValidateProvider(g.ctx).Return(nil)
+	g.git.EXPECT().ValidateProviderPermissions(g.ctx).Return(nil)
+	g.git.EXPECT().ValidateBranchPush(g.ctx, "testBranch").Return(nil)
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: "testRepo"}, nil)
+	g.git.EXPECT().ValidatePushAccess(g.ctx).Return(nil)
+	g.git.EXPECT().RemoteBranchExists(g.ctx, "testBranch").Return(true, nil)
+	g.git.EXPECT().PathExists(g.ctx, "mFolwer", "testRepo", "testBranch", "clusters/management-cluster").Return(false, nil)
+
+	g.Expect(g.gitOpsFlux.Preflight(g.ctx, clusterSpec)).To(Succeed())
+}
+
+func TestPreflightGitSuccess(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.Github = nil
+	clusterSpec.FluxConfig.Spec.Git = &v1alpha1.GitProviderConfig{RepositoryUrl: "ssh://git@example.com/testRepo.git"}
+
+	g.git.EXPECT().ValidateProvider(g.ctx).Return(nil)
+	g.git.EXPECT().ValidateRemoteExists(g.ctx).Return(nil)
+	g.git.EXPECT().ValidateProviderPermissions(g.ctx).Return(nil)
+	g.git.EXPECT().ValidateBranchPush(g.ctx, "testBranch").Return(nil)
+	g.git.EXPECT().ValidatePushAccess(g.ctx).Return(nil)
+	g.git.EXPECT().RemoteBranchExists(g.ctx, "testBranch").Return(true, nil)
+	g.git.EXPECT().PathExists(g.ctx, "", "testRepo", "testBranch", "clusters/management-cluster").Return(false, nil)
+
+	g.Expect(g.gitOpsFlux.Preflight(g.ctx, clusterSpec)).To(Succeed())
+}
+
+func TestPreflightAggregatesFailures(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.SystemNamespace = "Flux_System"
+	clusterSpec.FluxConfig.Spec.Github = nil
+	clusterSpec.FluxConfig.Spec.Git = &v1alpha1.GitProviderConfig{RepositoryUrl: "ssh://git@example.com/testRepo.git"}
+
+	g.git.EXPECT().ValidateProvider(g.ctx).Return(nil)
+	g.git.EXPECT().ValidateRemoteExists(g.ctx).Return(errors.New("error in list remote"))
+	g.git.EXPECT().ValidateProviderPermissions(g.ctx).Return(nil)
+	g.git.EXPECT().ValidateBranchPush(g.ctx, "testBranch").Return(nil)
+	g.git.EXPECT().ValidatePushAccess(g.ctx).Return(nil)
+	g.git.EXPECT().RemoteBranchExists(g.ctx, "testBranch").Return(true, nil)
+	g.git.EXPECT().PathExists(g.ctx, "", "testRepo", "testBranch", "clusters/management-cluster").Return(true, nil)
+
+	err := g.gitOpsFlux.Preflight(g.ctx, clusterSpec)
+	g.Expect(err).To(MatchError(ContainSubstring("flux system namespace: invalid namespace \"Flux_System\"")))
+	g.Expect(err).To(MatchError(ContainSubstring("git remote repository: error in list remote")))
+	g.Expect(err).To(MatchError(ContainSubstring("flux path: flux path clusters/management-cluster already exists in remote repository")))
+
+	var preflightErr *flux.PreflightError
+	g.Expect(errors.As(err, &preflightErr)).To(BeTrue())
+	g.Expect(preflightErr.Failures).To(HaveLen(3))
+	g.Expect(preflightErr.Failures[0].Name).To(Equal("Flux system namespace"))
+}
+
+func TestPreflightProviderError(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+
+	g.git.EXPECT().ValidateProvider(g.ctx).Return(errors.New("error in validate token"))
+	g.git.EXPECT().ValidateProviderPermissions(g.ctx).Return(nil)
+	g.git.EXPECT().ValidateBranchPush(g.ctx, "testBranch").Return(nil)
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: "testRepo"}, nil)
+	g.git.EXPECT().ValidatePushAccess(g.ctx).Return(nil)
+	g.git.EXPECT().RemoteBranchExists(g.ctx, "testBranch").Return(true, nil)
+	g.git.EXPECT().PathExists(g.ctx, "mFolwer", "testRepo", "testBranch", "clusters/management-cluster").Return(false, nil)
+
+	g.Expect(g.gitOpsFlux.Preflight(g.ctx, clusterSpec)).To(MatchError(ContainSubstring("git provider access: error in validate token")))
+}
+
+func TestPreflightSkip(t *testing.T) {
+	g := newFluxTest(t)
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, nil, nil)
+
+	g.Expect(f.Preflight(g.ctx, g.clusterSpec)).To(Succeed())
+}
+
+func TestPreflightProviderPermissionsError(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+
+	g.git.EXPECT().ValidateProvider(g.ctx).Return(nil)
+	g.git.EXPECT().ValidateProviderPermissions(g.ctx).Return(errors.New("missing the admin permissions"))
+	g.git.EXPECT().ValidateBranchPush(g.ctx, "testBranch").Return(nil)
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: "testRepo"}, nil)
+	g.git.EXPECT().ValidatePushAccess(g.ctx).Return(nil)
+	g.git.EXPECT().RemoteBranchExists(g.ctx, "testBranch").Return(true, nil)
+	g.git.EXPECT().PathExists(g.ctx, "mFolwer", "testRepo", "testBranch", "clusters/management-cluster").Return(false, nil)
+
+	err := g.gitOpsFlux.Preflight(g.ctx, clusterSpec)
+	g.Expect(err).To(MatchError(ContainSubstring("git provider permissions: missing the admin permissions")))
+	g.Expect(err).To(MatchError(ContainSubstring("the repo scope for a Github personal access token (classic)")))
+}
+
+func TestPreflightRepositoryWriteAccessError(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+
+	g.git.EXPECT().ValidateProvider(g.ctx).Return(nil)
+	g.git.EXPECT().ValidateProviderPermissions(g.ctx).Return(nil)
+	g.git.EXPECT().ValidateBranchPush(g.ctx, "testBranch").Return(nil)
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: "testRepo"}, nil)
+	g.git.EXPECT().ValidatePushAccess(g.ctx).Return(errors.New("github credentials can't push to repository mFolwer/testRepo"))
+	g.git.EXPECT().PathExists(g.ctx, "mFolwer", "testRepo", "testBranch", "clusters/management-cluster").Return(false, nil)
+
+	err := g.gitOpsFlux.Preflight(g.ctx, clusterSpec)
+	g.Expect(err).To(MatchError(ContainSubstring("git repository write access: github credentials can't push to repository mFolwer/testRepo")))
+}
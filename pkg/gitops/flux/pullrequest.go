@@ -0,0 +1,120 @@
+package flux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	defaultPullRequestBranchPrefix = "eksa/"
+	pullRequestBranchTimeFormat    = "20060102150405"
+)
+
+// WithPullRequests makes commitFluxAndClusterConfigToGit and UpdateGitEksaSpec push the cluster config changes to a new
+// branch and open a pull request against the sync branch with the git provider API, instead of pushing to the sync
+// branch directly, so the sync branch can be protected. Branches are named <branchPrefix><cluster name>-<timestamp>,
+// with eksa/ as the default prefix. Repositories without commits are still pushed to directly, since a pull request
+// needs an existing base branch.
+func WithPullRequests(branchPrefix string) Opt {
+	return func(f *Flux) {
+		if branchPrefix == "" {
+			branchPrefix = defaultPullRequestBranchPrefix
+		}
+		f.pullRequestBranchPrefix = branchPrefix
+	}
+}
+
+// WithPullRequestFallback makes the cluster config changes be pushed to a new branch and merged with a pull request,
+// like WithPullRequests with the default branch prefix, when the protection rules of the sync branch reject direct
+// pushes. Otherwise, the Validations fail for a protected sync branch.
+func WithPullRequestFallback() Opt {
+	return func(f *Flux) {
+		f.pullRequestFallback = true
+	}
+}
+
+// validateBranchPush checks the protection rules of the sync branch accept direct pushes, unless the changes are
+// pushed through pull requests.
+func (fc *fluxForCluster) validateBranchPush(ctx context.Context) error {
+	if fc.gitClient == nil || fc.pullRequestBranchPrefix != "" {
+		return nil
+	}
+
+	err := fc.gitClient.ValidateBranchPush(ctx, fc.branch())
+	var protected *git.ProtectedBranchError
+	if errors.As(err, &protected) && fc.pullRequestFallback {
+		logger.V(3).Info("Sync branch doesn't accept direct pushes, the changes will be pushed through a pull request", "branch", fc.branch(), "reason", err.Error())
+		return nil
+	}
+	return err
+}
+
+// pullRequestBranchPrefixForPush returns the prefix of the pull request branch the changes are pushed to, or an empty
+// prefix if they're pushed to the sync branch directly.
+func (fc *fluxForCluster) pullRequestBranchPrefixForPush(ctx context.Context) (string, error) {
+	if fc.pullRequestBranchPrefix != "" || !fc.pullRequestFallback {
+		return fc.pullRequestBranchPrefix, nil
+	}
+
+	err := fc.gitClient.ValidateBranchPush(ctx, fc.branch())
+	var protected *git.ProtectedBranchError
+	if errors.As(err, &protected) {
+		logger.Info("Sync branch doesn't accept direct pushes, opening a pull request with the changes instead", "branch", fc.branch(), "reason", err.Error())
+		return defaultPullRequestBranchPrefix, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("checking protection rules of branch %s: %v", fc.branch(), err)
+	}
+	return "", nil
+}
+
+// checkoutPullRequestBranch creates and checks out a new branch from the sync branch for the changes of the cluster,
+// returning its name. It returns an empty name if pull requests are disabled or the repository has no commits.
+// Checking out a branch discards local changes, so it must be done before writing any file.
+func (fc *fluxForCluster) checkoutPullRequestBranch(ctx context.Context) (string, error) {
+	prefix, err := fc.pullRequestBranchPrefixForPush(ctx)
+	if err != nil {
+		return "", err
+	}
+	if prefix == "" {
+		return "", nil
+	}
+
+	if _, err := fc.gitClient.LastCommit(); err != nil {
+		logger.V(3).Info("Repository has no commits, pushing to the sync branch instead of opening a pull request", "branch", fc.branch())
+		return "", nil
+	}
+
+	b := fmt.Sprintf("%s%s-%s", prefix, fc.clusterSpec.Cluster.Name, time.Now().UTC().Format(pullRequestBranchTimeFormat))
+	logger.V(3).Info("Creating pull request branch", "branch", b)
+	if err := fc.gitClient.Branch(b); err != nil {
+		return "", fmt.Errorf("switching to git branch %s: %v", b, err)
+	}
+	return b, nil
+}
+
+// pushAndOpenPullRequest commits and pushes the changes to the pull request branch and opens a pull request to merge
+// them into the sync branch.
+func (fc *fluxForCluster) pushAndOpenPullRequest(ctx context.Context, branch, path, msg string) error {
+	if err := fc.pushToRemoteRepo(ctx, path, msg); err != nil {
+		return err
+	}
+
+	pr, err := fc.gitClient.CreatePullRequest(ctx, git.CreatePullRequestOpts{
+		Title:       commitSubject(msg),
+		Description: fmt.Sprintf("Cluster configuration changes for cluster %s, pushed by EKS Anywhere.", fc.clusterSpec.Cluster.Name),
+		Head:        branch,
+		Base:        fc.branch(),
+	})
+	if err != nil {
+		return fmt.Errorf("opening pull request from %s to %s: %v", branch, fc.branch(), err)
+	}
+
+	logger.Info("Opened pull request with the cluster configuration changes, flux will reconcile them once merged", "url", pr.Url)
+	return nil
+}
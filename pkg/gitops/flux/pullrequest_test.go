@@ -0,0 +1,251 @@
+package flux_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+func TestInstallGitOpsOnWorkloadClusterOpensPullRequest(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "workload-cluster"
+	clusterConfig := v1alpha1.NewCluster(clusterName)
+	clusterConfig.SetManagedBy("management-cluster")
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithPullRequests(""))
+	syncBranch := clusterSpec.FluxConfig.Spec.Branch
+
+	var prBranch string
+	g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, clusterSpec.FluxConfig)
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: clusterSpec.FluxConfig.Spec.Github.Repository}, nil)
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(syncBranch).Return(nil)
+	g.git.EXPECT().LastCommit().Return(&git.Commit{Hash: "abc"}, nil)
+	g.git.EXPECT().Branch(gomock.Not(syncBranch)).DoAndReturn(func(name string) error {
+		prBranch = name
+		return nil
+	})
+	g.git.EXPECT().Add("clusters").Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.git.EXPECT().CreatePullRequest(g.ctx, gomock.Any()).DoAndReturn(func(_ interface{}, opts git.CreatePullRequestOpts) (*git.PullRequest, error) {
+		g.Expect(opts.Head).To(Equal(prBranch))
+		g.Expect(opts.Base).To(Equal(syncBranch))
+		g.Expect(opts.Title).To(Equal("Initial commit of cluster configuration; generated by EKS-A CLI"))
+		return &git.PullRequest{Number: 1, Url: "https://github.com/mFowler/testRepo/pull/1"}, nil
+	})
+	g.git.EXPECT().Pull(g.ctx, syncBranch).Return(nil)
+
+	g.Expect(f.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+	g.Expect(prBranch).To(MatchRegexp(`^eksa/workload-cluster-\d{14}$`))
+}
+
+func TestInstallGitOpsPullRequestsEmptyRepository(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "workload-cluster"
+	clusterConfig := v1alpha1.NewCluster(clusterName)
+	clusterConfig.SetManagedBy("management-cluster")
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithPullRequests(""))
+
+	g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, clusterSpec.FluxConfig)
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: clusterSpec.FluxConfig.Spec.Github.Repository}, nil)
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().LastCommit().Return(nil, errors.New("reference not found"))
+	g.git.EXPECT().Add("clusters").Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.git.EXPECT().Pull(g.ctx, clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+
+	g.Expect(f.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+}
+
+func TestInstallGitOpsPullRequestFallbackProtectedBranch(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "workload-cluster"
+	clusterConfig := v1alpha1.NewCluster(clusterName)
+	clusterConfig.SetManagedBy("management-cluster")
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithPullRequestFallback())
+	syncBranch := clusterSpec.FluxConfig.Spec.Branch
+
+	var prBranch string
+	g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, clusterSpec.FluxConfig)
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: clusterSpec.FluxConfig.Spec.Github.Repository}, nil)
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(syncBranch).Return(nil)
+	g.git.EXPECT().ValidateBranchPush(g.ctx, syncBranch).Return(&git.ProtectedBranchError{Branch: syncBranch, Rules: []string{"pull request reviews"}})
+	g.git.EXPECT().LastCommit().Return(&git.Commit{Hash: "abc"}, nil)
+	g.git.EXPECT().Branch(gomock.Not(syncBranch)).DoAndReturn(func(name string) error {
+		prBranch = name
+		return nil
+	})
+	g.git.EXPECT().Add("clusters").Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.git.EXPECT().CreatePullRequest(g.ctx, gomock.Any()).Return(&git.PullRequest{Number: 1, Url: "https://github.com/mFowler/testRepo/pull/1"}, nil)
+	g.git.EXPECT().Pull(g.ctx, syncBranch).Return(nil)
+
+	g.Expect(f.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+	g.Expect(prBranch).To(MatchRegexp(`^eksa/workload-cluster-\d{14}$`))
+}
+
+func TestInstallGitOpsPullRequestFallbackUnprotectedBranch(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "workload-cluster"
+	clusterConfig := v1alpha1.NewCluster(clusterName)
+	clusterConfig.SetManagedBy("management-cluster")
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithPullRequestFallback())
+	syncBranch := clusterSpec.FluxConfig.Spec.Branch
+
+	g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, clusterSpec.FluxConfig)
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: clusterSpec.FluxConfig.Spec.Github.Repository}, nil)
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(syncBranch).Return(nil)
+	g.git.EXPECT().ValidateBranchPush(g.ctx, syncBranch).Return(nil)
+	g.git.EXPECT().Add("clusters").Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.git.EXPECT().Pull(g.ctx, syncBranch).Return(nil)
+
+	g.Expect(f.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+}
+
+func TestInstallGitOpsPullRequestFallbackError(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "workload-cluster"
+	clusterConfig := v1alpha1.NewCluster(clusterName)
+	clusterConfig.SetManagedBy("management-cluster")
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithPullRequestFallback())
+	syncBranch := clusterSpec.FluxConfig.Spec.Branch
+
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: clusterSpec.FluxConfig.Spec.Github.Repository}, nil)
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(syncBranch).Return(nil)
+	g.git.EXPECT().ValidateBranchPush(g.ctx, syncBranch).Return(errors.New("server error"))
+
+	g.Expect(f.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(MatchError(ContainSubstring("checking protection rules of branch testBranch: server error")))
+}
+
+func TestValidationsProtectedBranch(t *testing.T) {
+	g := newFluxTest(t)
+	_, repo, _ := g.setupFlux()
+	g.git.EXPECT().ValidateProviderPermissions(g.ctx).Return(nil)
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: repo}, nil)
+	g.git.EXPECT().ValidatePushAccess(g.ctx).Return(nil)
+	g.git.EXPECT().RemoteBranchExists(g.ctx, "main").Return(true, nil)
+	g.git.EXPECT().ValidateBranchPush(g.ctx, "main").Return(&git.ProtectedBranchError{Branch: "main", Rules: []string{"pull request reviews"}})
+
+	err := runValidations(g.gitOpsFlux.Validations(g.ctx, g.clusterSpec))
+	g.Expect(err).To(MatchError("branch main is protected and doesn't accept direct pushes, it requires pull request reviews"))
+}
+
+func TestValidationsProtectedBranchPullRequestFallback(t *testing.T) {
+	g := newFluxTest(t)
+	owner, repo, path := g.setupFlux()
+	g.gitOpsFlux = flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithPullRequestFallback())
+	g.git.EXPECT().ValidateProviderPermissions(g.ctx).Return(nil)
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: repo}, nil)
+	g.git.EXPECT().ValidatePushAccess(g.ctx).Return(nil)
+	g.git.EXPECT().RemoteBranchExists(g.ctx, "main").Return(true, nil)
+	g.git.EXPECT().ValidateBranchPush(g.ctx, "main").Return(&git.ProtectedBranchError{Branch: "main"})
+	g.git.EXPECT().PathExists(g.ctx, owner, repo, "main", path).Return(false, nil)
+
+	g.Expect(runValidations(g.gitOpsFlux.Validations(g.ctx, g.clusterSpec))).To(Succeed())
+}
+
+func TestValidationsProtectedBranchWithPullRequests(t *testing.T) {
+	g := newFluxTest(t)
+	owner, repo, path := g.setupFlux()
+	g.gitOpsFlux = flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithPullRequests(""))
+	g.git.EXPECT().ValidateProviderPermissions(g.ctx).Return(nil)
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: repo}, nil)
+	g.git.EXPECT().ValidatePushAccess(g.ctx).Return(nil)
+	g.git.EXPECT().RemoteBranchExists(g.ctx, "main").Return(true, nil)
+	g.git.EXPECT().PathExists(g.ctx, owner, repo, "main", path).Return(false, nil)
+
+	g.Expect(runValidations(g.gitOpsFlux.Validations(g.ctx, g.clusterSpec))).To(Succeed())
+}
+
+func TestUpdateGitEksaSpecOpensPullRequest(t *testing.T) {
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithPullRequests("changes/"), flux.WithUpdateCommitSquash(time.Hour))
+	syncBranch := clusterSpec.FluxConfig.Spec.Branch
+
+	var prBranch string
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(syncBranch).Return(nil)
+	g.git.EXPECT().LastCommit().Return(&git.Commit{Author: git.CommitAuthor, Message: updateCommitMessage, When: time.Now()}, nil)
+	g.git.EXPECT().Branch(gomock.Not(syncBranch)).DoAndReturn(func(name string) error {
+		prBranch = name
+		return nil
+	})
+	g.git.EXPECT().Add("clusters/management-cluster/management-cluster/eksa-system").Return(nil)
+	g.git.EXPECT().Commit(updateCommitMessage).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.git.EXPECT().CreatePullRequest(g.ctx, gomock.Any()).DoAndReturn(func(_ interface{}, opts git.CreatePullRequestOpts) (*git.PullRequest, error) {
+		g.Expect(opts.Head).To(Equal(prBranch))
+		g.Expect(opts.Base).To(Equal(syncBranch))
+		g.Expect(opts.Title).To(Equal(updateCommitMessage))
+		return &git.PullRequest{Number: 2}, nil
+	})
+
+	g.Expect(f.UpdateGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+	g.Expect(prBranch).To(MatchRegexp(`^changes/management-cluster-\d{14}$`))
+}
+
+func TestUpdateGitEksaSpecPullRequestBranchError(t *testing.T) {
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithPullRequests(""))
+	syncBranch := clusterSpec.FluxConfig.Spec.Branch
+
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(syncBranch).Return(nil)
+	g.git.EXPECT().LastCommit().Return(&git.Commit{Hash: "abc"}, nil)
+	g.git.EXPECT().Branch(gomock.Not(syncBranch)).Return(errors.New("error in branch"))
+
+	err := f.UpdateGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})
+	g.Expect(err).To(MatchError(ContainSubstring("error in branch")))
+}
+
+func TestUpdateGitEksaSpecCreatePullRequestError(t *testing.T) {
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithPullRequests(""))
+	syncBranch := clusterSpec.FluxConfig.Spec.Branch
+
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(syncBranch).Return(nil)
+	g.git.EXPECT().LastCommit().Return(&git.Commit{Hash: "abc"}, nil)
+	g.git.EXPECT().Branch(gomock.Not(syncBranch)).Return(nil)
+	g.git.EXPECT().Add("clusters/management-cluster/management-cluster/eksa-system").Return(nil)
+	g.git.EXPECT().Commit(updateCommitMessage).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.git.EXPECT().CreatePullRequest(g.ctx, gomock.Any()).Return(nil, errors.New("pull requests are not supported by the generic git provider"))
+
+	err := f.UpdateGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})
+	g.Expect(err).To(MatchError(ContainSubstring("opening pull request from eksa/management-cluster-")))
+}
@@ -0,0 +1,146 @@
+package flux
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	receiverTokenSecretKey = "token"
+	receiverTokenLength    = 32
+	githubPingEvent        = "ping"
+	githubPushEvent        = "push"
+)
+
+// receiverName returns the name of the generated Receiver, which matches the flux-system GitRepository it triggers.
+func receiverName(fluxConfig *v1alpha1.FluxConfig) string {
+	return fluxConfig.Spec.SystemNamespace
+}
+
+// ReceiverWebhookPath returns the path the notification-controller serves the generated Receiver webhook at.
+// It's computed the same way flux does, from the token stored in the Receiver secret, its name and namespace.
+func ReceiverWebhookPath(fluxConfig *v1alpha1.FluxConfig, token string) string {
+	digest := sha256.Sum256([]byte(token + receiverName(fluxConfig) + fluxConfig.Spec.SystemNamespace))
+	return fmt.Sprintf("/hook/%x", digest)
+}
+
+func logReceiverWebhook(fluxConfig *v1alpha1.FluxConfig) {
+	if fluxConfig.Spec.Receiver == nil {
+		return
+	}
+
+	name := receiverName(fluxConfig)
+	namespace := fluxConfig.Spec.SystemNamespace
+	logger.Info("Flux webhook receiver configured, the webhook path is published in the receiver status once reconciled",
+		"receiver", name,
+		"namespace", namespace,
+		"command", fmt.Sprintf("kubectl get receiver %s -n %s -o jsonpath='{.status.url}'", name, namespace),
+	)
+}
+
+// setupReceiver generates the token of the receiver secret and registers the webhook with the Github provider when the
+// receiver has a webhook url, so reconciliation is triggered by pushes. Otherwise the secret is left to the user and
+// only the command to get the webhook path is logged. The token of an existing receiver secret is reused and the webhook
+// is not registered again if the repository already has one for the receiver url, so resumed runs don't duplicate them.
+func (fc *fluxForCluster) setupReceiver(ctx context.Context, cluster *types.Cluster) error {
+	receiver := fc.clusterSpec.FluxConfig.Spec.Receiver
+	if receiver == nil {
+		return nil
+	}
+	if receiver.WebhookUrl == "" {
+		logReceiverWebhook(fc.clusterSpec.FluxConfig)
+		return nil
+	}
+
+	namespace := fc.clusterSpec.FluxConfig.Spec.SystemNamespace
+	token, err := fc.receiverToken(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	url := strings.TrimSuffix(receiver.WebhookUrl, "/") + ReceiverWebhookPath(fc.clusterSpec.FluxConfig, token)
+	if fc.clusterSpec.FluxConfig.Spec.Github == nil {
+		logger.Info("Flux webhook receiver configured, register the webhook url in the repository to trigger reconciliation on push",
+			"url", url, "secret", receiver.SecretName, "namespace", namespace)
+		return nil
+	}
+
+	exists, err := fc.gitClient.WebhookExists(ctx, url)
+	if err != nil {
+		return fmt.Errorf("checking receiver webhook: %v", err)
+	}
+	if exists {
+		logger.V(3).Info("Flux webhook receiver already registered in the Github repository", "url", url)
+		return nil
+	}
+
+	if err := fc.gitClient.CreateWebhook(ctx, git.CreateWebhookOpts{
+		Url:    url,
+		Secret: token,
+		Events: githubWebhookEvents(receiver.Events),
+	}); err != nil {
+		return fmt.Errorf("registering receiver webhook: %v", err)
+	}
+	logger.Info("Registered flux webhook receiver in the Github repository", "url", url)
+	return nil
+}
+
+// receiverToken returns the token of the receiver secret, generating it and creating the secret if it doesn't exist yet.
+func (fc *fluxForCluster) receiverToken(ctx context.Context, cluster *types.Cluster) (string, error) {
+	receiver := fc.clusterSpec.FluxConfig.Spec.Receiver
+	namespace := fc.clusterSpec.FluxConfig.Spec.SystemNamespace
+
+	secret := &corev1.Secret{}
+	err := fc.fluxClient.GetObject(ctx, cluster, "secret", receiver.SecretName, namespace, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return "", fmt.Errorf("getting receiver secret %s: %v", receiver.SecretName, err)
+	}
+	if token := secret.Data[receiverTokenSecretKey]; err == nil && len(token) > 0 {
+		logger.V(3).Info("Reusing the token of the existing flux webhook receiver secret", "secret", receiver.SecretName)
+		return string(token), nil
+	}
+
+	token, err := generateReceiverToken()
+	if err != nil {
+		return "", err
+	}
+	if err := fc.fluxClient.ApplySecret(ctx, cluster, receiver.SecretName, namespace, map[string]string{receiverTokenSecretKey: token}); err != nil {
+		return "", fmt.Errorf("creating receiver secret %s: %v", receiver.SecretName, err)
+	}
+	return token, nil
+}
+
+func generateReceiverToken() (string, error) {
+	b := make([]byte, receiverTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating receiver token: %v", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// githubWebhookEvents returns the events the Github webhook is sent on, push by default. The ping event,
+// sent once when the webhook is registered, is not a valid webhook event.
+func githubWebhookEvents(receiverEvents []string) []string {
+	events := make([]string, 0, len(receiverEvents))
+	for _, e := range receiverEvents {
+		if e != githubPingEvent {
+			events = append(events, e)
+		}
+	}
+	if len(events) == 0 {
+		return []string{githubPushEvent}
+	}
+	return events
+}
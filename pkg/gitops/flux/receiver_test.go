@@ -0,0 +1,236 @@
+package flux_test
+
+import (
+	"errors"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+func TestWriteFluxSystemFilesWithReceiverContent(t *testing.T) {
+	g := NewWithT(t)
+	_, w := test.NewWriter(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.Receiver = &v1alpha1.FluxReceiverConfig{
+		Type:       "github",
+		SecretName: "webhook-token",
+		Events:     []string{"ping", "push"},
+	}
+
+	gen := flux.NewFileGenerator()
+	g.Expect(gen.Init(w, "eksa-system", "flux-system")).To(Succeed())
+	g.Expect(gen.WriteFluxSystemFiles(clusterSpec)).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "flux-system", "gotk-receiver.yaml"), "./testdata/gotk-receiver.yaml")
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "flux-system", "kustomization.yaml"), "./testdata/flux-kustomization-receiver.yaml")
+}
+
+func TestReceiverWebhookPath(t *testing.T) {
+	g := NewWithT(t)
+	fluxConfig := &v1alpha1.FluxConfig{
+		Spec: v1alpha1.FluxConfigSpec{
+			SystemNamespace: "flux-system",
+		},
+	}
+
+	// sha256 of "tokenflux-systemflux-system"
+	g.Expect(flux.ReceiverWebhookPath(fluxConfig, "token")).To(Equal("/hook/b4ed96657f619deceb967ae4ea5108cc55bc4200b3c288f9482521f19677c392"))
+}
+
+func expectInstallGitOpsWithRepo(g fluxTest, cluster *types.Cluster, clusterSpec *cluster.Spec) {
+	g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, clusterSpec.FluxConfig)
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: clusterSpec.FluxConfig.Spec.Github.Repository}, nil)
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add("clusters").Return(nil)
+	g.git.EXPECT().Add(pathClaimFile(clusterSpec.Cluster.Name)).Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.git.EXPECT().Pull(g.ctx, clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+}
+
+func expectReceiverSecretNotFound(g fluxTest, cluster *types.Cluster) {
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "secret", "webhook-token", "flux-system", gomock.Any()).
+		Return(apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "webhook-token"))
+}
+
+func TestInstallGitOpsRegistersReceiverWebhook(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	clusterSpec.FluxConfig.Spec.Receiver = &v1alpha1.FluxReceiverConfig{
+		Type:       "github",
+		SecretName: "webhook-token",
+		Events:     []string{"ping", "push"},
+		WebhookUrl: "https://flux-webhook.example.com/",
+	}
+
+	var token string
+	expectInstallGitOpsWithRepo(g, cluster, clusterSpec)
+	expectReceiverSecretNotFound(g, cluster)
+	g.flux.EXPECT().ApplySecret(g.ctx, cluster, "webhook-token", "flux-system", gomock.Any()).DoAndReturn(
+		func(_ interface{}, _ *types.Cluster, _, _ string, data map[string]string) error {
+			token = data["token"]
+			return nil
+		},
+	)
+	g.git.EXPECT().WebhookExists(g.ctx, gomock.Any()).Return(false, nil)
+	g.git.EXPECT().CreateWebhook(g.ctx, gomock.Any()).DoAndReturn(func(_ interface{}, opts git.CreateWebhookOpts) error {
+		g.Expect(opts.Secret).To(Equal(token))
+		g.Expect(opts.Url).To(Equal("https://flux-webhook.example.com" + flux.ReceiverWebhookPath(clusterSpec.FluxConfig, token)))
+		g.Expect(opts.Events).To(Equal([]string{"push"}))
+		return nil
+	})
+
+	g.Expect(g.gitOpsFlux.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+	g.Expect(token).To(HaveLen(64))
+}
+
+func TestInstallGitOpsReceiverSecretError(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	clusterSpec.FluxConfig.Spec.Receiver = &v1alpha1.FluxReceiverConfig{
+		Type:       "github",
+		SecretName: "webhook-token",
+		WebhookUrl: "https://flux-webhook.example.com",
+	}
+
+	expectInstallGitOpsWithRepo(g, cluster, clusterSpec)
+	expectReceiverSecretNotFound(g, cluster)
+	g.flux.EXPECT().ApplySecret(g.ctx, cluster, "webhook-token", "flux-system", gomock.Any()).Return(errors.New("error in apply"))
+
+	err := g.gitOpsFlux.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})
+	g.Expect(err).To(MatchError(ContainSubstring("creating receiver secret webhook-token: error in apply")))
+}
+
+func TestInstallGitOpsReceiverWebhookError(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	clusterSpec.FluxConfig.Spec.Receiver = &v1alpha1.FluxReceiverConfig{
+		Type:       "github",
+		SecretName: "webhook-token",
+		WebhookUrl: "https://flux-webhook.example.com",
+	}
+
+	expectInstallGitOpsWithRepo(g, cluster, clusterSpec)
+	expectReceiverSecretNotFound(g, cluster)
+	g.flux.EXPECT().ApplySecret(g.ctx, cluster, "webhook-token", "flux-system", gomock.Any()).Return(nil)
+	g.git.EXPECT().WebhookExists(g.ctx, gomock.Any()).Return(false, nil)
+	g.git.EXPECT().CreateWebhook(g.ctx, gomock.Any()).Return(errors.New("hook already exists"))
+
+	err := g.gitOpsFlux.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})
+	g.Expect(err).To(MatchError(ContainSubstring("registering receiver webhook: hook already exists")))
+}
+
+func TestInstallGitOpsReceiverReusesExistingSecretAndWebhook(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	clusterSpec.FluxConfig.Spec.Receiver = &v1alpha1.FluxReceiverConfig{
+		Type:       "github",
+		SecretName: "webhook-token",
+		WebhookUrl: "https://flux-webhook.example.com",
+	}
+
+	expectInstallGitOpsWithRepo(g, cluster, clusterSpec)
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "secret", "webhook-token", "flux-system", gomock.Any()).DoAndReturn(
+		func(_ interface{}, _ *types.Cluster, _, _, _ string, obj runtime.Object) error {
+			obj.(*corev1.Secret).Data = map[string][]byte{"token": []byte("existing-token")}
+			return nil
+		},
+	)
+	g.git.EXPECT().WebhookExists(g.ctx, "https://flux-webhook.example.com"+flux.ReceiverWebhookPath(clusterSpec.FluxConfig, "existing-token")).Return(true, nil)
+
+	g.Expect(g.gitOpsFlux.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+}
+
+func TestInstallGitOpsReceiverWebhookExistsError(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	clusterSpec.FluxConfig.Spec.Receiver = &v1alpha1.FluxReceiverConfig{
+		Type:       "github",
+		SecretName: "webhook-token",
+		WebhookUrl: "https://flux-webhook.example.com",
+	}
+
+	expectInstallGitOpsWithRepo(g, cluster, clusterSpec)
+	expectReceiverSecretNotFound(g, cluster)
+	g.flux.EXPECT().ApplySecret(g.ctx, cluster, "webhook-token", "flux-system", gomock.Any()).Return(nil)
+	g.git.EXPECT().WebhookExists(g.ctx, gomock.Any()).Return(false, errors.New("forbidden"))
+
+	err := g.gitOpsFlux.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})
+	g.Expect(err).To(MatchError(ContainSubstring("checking receiver webhook: forbidden")))
+}
+
+func TestInstallGitOpsReceiverSecretGetError(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	clusterSpec.FluxConfig.Spec.Receiver = &v1alpha1.FluxReceiverConfig{
+		Type:       "github",
+		SecretName: "webhook-token",
+		WebhookUrl: "https://flux-webhook.example.com",
+	}
+
+	expectInstallGitOpsWithRepo(g, cluster, clusterSpec)
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "secret", "webhook-token", "flux-system", gomock.Any()).Return(errors.New("connection refused"))
+
+	err := g.gitOpsFlux.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})
+	g.Expect(err).To(MatchError(ContainSubstring("getting receiver secret webhook-token: connection refused")))
+}
+
+func TestInstallGitOpsReceiverWebhookNotGithub(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	clusterSpec.FluxConfig.Spec.Git = &v1alpha1.GitProviderConfig{RepositoryUrl: "git.xyz"}
+	clusterSpec.FluxConfig.Spec.Github = nil
+	clusterSpec.FluxConfig.Spec.Receiver = &v1alpha1.FluxReceiverConfig{
+		Type:       "generic",
+		SecretName: "webhook-token",
+		WebhookUrl: "https://flux-webhook.example.com",
+	}
+
+	g.flux.EXPECT().BootstrapGit(g.ctx, cluster, clusterSpec.FluxConfig, nil)
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add("clusters").Return(nil)
+	g.git.EXPECT().Add(pathClaimFile(clusterSpec.Cluster.Name)).Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.git.EXPECT().Pull(g.ctx, clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	expectReceiverSecretNotFound(g, cluster)
+	g.flux.EXPECT().ApplySecret(g.ctx, cluster, "webhook-token", "flux-system", gomock.Any()).DoAndReturn(
+		func(_ interface{}, _ *types.Cluster, _, _ string, data map[string]string) error {
+			g.Expect(strings.TrimSpace(data["token"])).NotTo(BeEmpty())
+			return nil
+		},
+	)
+
+	g.Expect(g.gitOpsFlux.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+}
@@ -0,0 +1,89 @@
+package flux
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const reconcileWaitPollPeriod = 5 * time.Second
+
+// WithReconcileWait makes ForceReconcileGitRepo block until the source and the eksa-system Kustomization report
+// the new revision applied, failing if that takes longer than the timeout.
+func WithReconcileWait(timeout time.Duration) Opt {
+	return func(f *Flux) {
+		f.reconcileWaitTimeout = timeout
+	}
+}
+
+// ForceReconcileGitRepoAndWait requests flux to reconcile its source and waits until the source has handled the
+// request and the Kustomization reconciling the eksa-system path applied the revision fetched by the source.
+// It returns the reconciled revision.
+func (f *Flux) ForceReconcileGitRepoAndWait(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, timeout time.Duration) (string, error) {
+	if f.shouldSkipFlux() {
+		logger.Info("GitOps not configured, force reconcile flux git repo skipped")
+		return "", nil
+	}
+
+	// The reconcile requests are timestamped with a precision of a second
+	requestedAt := time.Now().Truncate(time.Second)
+	if err := f.forceReconcile(ctx, cluster, clusterSpec); err != nil {
+		return "", err
+	}
+
+	r := retrier.New(timeout, retrier.WithRetryPolicy(func(_ int, _ error) (bool, time.Duration) {
+		return true, reconcileWaitPollPeriod
+	}))
+
+	var revision string
+	err := r.Retry(func() error {
+		status, err := f.GitOpsStatus(ctx, cluster, clusterSpec)
+		if err != nil {
+			return err
+		}
+		revision, err = reconciledRevision(status, requestedAt)
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("waiting for flux to reconcile: %v", err)
+	}
+
+	return revision, nil
+}
+
+// reconciledRevision returns the revision of the source if it handled a reconcile request made after requestedAt
+// and the Kustomization applied that revision.
+func reconciledRevision(status *GitOpsStatus, requestedAt time.Time) (string, error) {
+	source, kustomization := status.Source, status.Kustomization
+	if !source.Ready {
+		return "", fmt.Errorf("%s %s is not ready: %s", source.Kind, source.Name, source.Message)
+	}
+	if !reconcileHandledSince(source.LastHandledReconcileAt, requestedAt) {
+		return "", fmt.Errorf("%s %s hasn't handled the reconcile request yet", source.Kind, source.Name)
+	}
+	if kustomization.Revision != source.Revision {
+		return "", fmt.Errorf("%s %s applied revision %q, waiting for %q", kustomization.Kind, kustomization.Name, kustomization.Revision, source.Revision)
+	}
+	if !kustomization.Ready {
+		return "", fmt.Errorf("%s %s is not ready: %s", kustomization.Kind, kustomization.Name, kustomization.Message)
+	}
+	return source.Revision, nil
+}
+
+// reconcileHandledSince returns true if the handled reconcile request was made at or after t. The requests made
+// by annotating the source are unix timestamps, while the ones made by the flux cli are RFC3339 times.
+func reconcileHandledSince(handledAt string, t time.Time) bool {
+	if unix, err := strconv.ParseInt(handledAt, 10, 64); err == nil {
+		return !time.Unix(unix, 0).Before(t)
+	}
+	if handled, err := time.Parse(time.RFC3339Nano, handledAt); err == nil {
+		return !handled.Before(t)
+	}
+	return false
+}
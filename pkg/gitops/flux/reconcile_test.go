@@ -0,0 +1,153 @@
+package flux_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	reconciledGitRepository = `
+apiVersion: source.toolkit.fluxcd.io/v1beta2
+kind: GitRepository
+metadata:
+  name: flux-system
+  namespace: flux-system
+status:
+  artifact:
+    revision: main/abc123
+  lastHandledReconcileAt: "4102444800"
+  conditions:
+  - type: Ready
+    status: "True"
+    reason: Succeeded
+    message: "stored artifact for revision 'main/abc123'"
+    lastTransitionTime: "2022-10-01T00:00:00Z"
+`
+	reconciledOCIRepository = `
+apiVersion: source.toolkit.fluxcd.io/v1beta2
+kind: OCIRepository
+metadata:
+  name: flux-system
+  namespace: flux-system
+status:
+  artifact:
+    revision: latest/abc123
+  lastHandledReconcileAt: "2100-01-01T00:00:00.123456789Z"
+  conditions:
+  - type: Ready
+    status: "True"
+    reason: Succeeded
+    message: "stored artifact for digest 'latest/abc123'"
+    lastTransitionTime: "2022-10-01T00:00:00Z"
+`
+	appliedOCIKustomization = `
+apiVersion: kustomize.toolkit.fluxcd.io/v1beta2
+kind: Kustomization
+metadata:
+  name: flux-system
+  namespace: flux-system
+status:
+  lastAppliedRevision: latest/abc123
+  conditions:
+  - type: Ready
+    status: "True"
+    reason: ReconciliationSucceeded
+    message: "Applied revision: latest/abc123"
+    lastTransitionTime: "2022-10-01T00:00:00Z"
+`
+)
+
+func TestForceReconcileGitRepoAndWaitSuccess(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{Name: "management-cluster"}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+
+	g.flux.EXPECT().ForceReconcile(g.ctx, cluster, "flux-system").Return(nil)
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "gitrepositories.source.toolkit.fluxcd.io", "flux-system", "flux-system", gomock.Any()).DoAndReturn(returnObject(t, reconciledGitRepository))
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "kustomizations.kustomize.toolkit.fluxcd.io", "flux-system", "flux-system", gomock.Any()).DoAndReturn(returnObject(t, healthyKustomization))
+
+	revision, err := g.gitOpsFlux.ForceReconcileGitRepoAndWait(g.ctx, cluster, clusterSpec, time.Minute)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(revision).To(Equal("main/abc123"))
+}
+
+func TestForceReconcileGitRepoAndWaitOCIRepository(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{Name: "management-cluster"}
+	clusterSpec := newOCIClusterSpec(t, v1alpha1.NewCluster("management-cluster"))
+
+	g.flux.EXPECT().Reconcile(g.ctx, cluster, clusterSpec.FluxConfig).Return(nil)
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "ocirepositories.source.toolkit.fluxcd.io", "flux-system", "flux-system", gomock.Any()).DoAndReturn(returnObject(t, reconciledOCIRepository))
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "kustomizations.kustomize.toolkit.fluxcd.io", "flux-system", "flux-system", gomock.Any()).DoAndReturn(returnObject(t, appliedOCIKustomization))
+
+	revision, err := g.gitOpsFlux.ForceReconcileGitRepoAndWait(g.ctx, cluster, clusterSpec, time.Minute)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(revision).To(Equal("latest/abc123"))
+}
+
+func TestForceReconcileGitRepoAndWaitRequestNotHandled(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{Name: "management-cluster"}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+
+	g.flux.EXPECT().ForceReconcile(g.ctx, cluster, "flux-system").Return(nil)
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "gitrepositories.source.toolkit.fluxcd.io", "flux-system", "flux-system", gomock.Any()).DoAndReturn(returnObject(t, readyGitRepository))
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "kustomizations.kustomize.toolkit.fluxcd.io", "flux-system", "flux-system", gomock.Any()).DoAndReturn(returnObject(t, healthyKustomization))
+
+	_, err := g.gitOpsFlux.ForceReconcileGitRepoAndWait(g.ctx, cluster, clusterSpec, time.Millisecond)
+	g.Expect(err).To(MatchError(ContainSubstring("GitRepository flux-system hasn't handled the reconcile request yet")))
+}
+
+func TestForceReconcileGitRepoAndWaitRevisionNotApplied(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{Name: "management-cluster"}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+
+	g.flux.EXPECT().ForceReconcile(g.ctx, cluster, "flux-system").Return(nil)
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "gitrepositories.source.toolkit.fluxcd.io", "flux-system", "flux-system", gomock.Any()).DoAndReturn(returnObject(t, reconciledGitRepository))
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "kustomizations.kustomize.toolkit.fluxcd.io", "flux-system", "flux-system", gomock.Any()).DoAndReturn(returnObject(t, failedKustomization))
+
+	_, err := g.gitOpsFlux.ForceReconcileGitRepoAndWait(g.ctx, cluster, clusterSpec, time.Millisecond)
+	g.Expect(err).To(MatchError(ContainSubstring(`Kustomization flux-system applied revision "main/abc000", waiting for "main/abc123"`)))
+}
+
+func TestForceReconcileGitRepoAndWaitReconcileError(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{Name: "management-cluster"}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+
+	g.flux.EXPECT().ForceReconcile(g.ctx, cluster, "flux-system").Return(errors.New("error from client"))
+
+	_, err := g.gitOpsFlux.ForceReconcileGitRepoAndWait(g.ctx, cluster, clusterSpec, time.Minute)
+	g.Expect(err).To(MatchError("error from client"))
+}
+
+func TestForceReconcileGitRepoWithReconcileWait(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{Name: "management-cluster"}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithReconcileWait(time.Minute))
+
+	g.flux.EXPECT().ForceReconcile(g.ctx, cluster, "flux-system").Return(nil)
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "gitrepositories.source.toolkit.fluxcd.io", "flux-system", "flux-system", gomock.Any()).DoAndReturn(returnObject(t, reconciledGitRepository))
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "kustomizations.kustomize.toolkit.fluxcd.io", "flux-system", "flux-system", gomock.Any()).DoAndReturn(returnObject(t, healthyKustomization))
+
+	g.Expect(f.ForceReconcileGitRepo(g.ctx, cluster, clusterSpec)).To(Succeed())
+}
+
+func TestForceReconcileGitRepoAndWaitSkipFlux(t *testing.T) {
+	g := newFluxTest(t)
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, nil, nil)
+
+	revision, err := f.ForceReconcileGitRepoAndWait(g.ctx, &types.Cluster{}, g.clusterSpec, time.Minute)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(revision).To(BeEmpty())
+}
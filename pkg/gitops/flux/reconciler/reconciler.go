@@ -0,0 +1,70 @@
+package reconciler
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+)
+
+// GitOpsFlux commits the changes made to the cluster config in the cluster back to git.
+type GitOpsFlux interface {
+	ReverseSync(ctx context.Context, clusterSpec *cluster.Spec) (*flux.DriftReport, error)
+}
+
+// GitOpsFluxBuilder builds the GitOpsFlux for the repository of the cluster spec.
+type GitOpsFluxBuilder func(ctx context.Context, clusterSpec *cluster.Spec) (GitOpsFlux, error)
+
+// ReverseSyncer commits the cluster config changes made in the management cluster back to the flux repository of
+// the clusters with reverse sync enabled in their FluxConfig.
+type ReverseSyncer struct {
+	client          client.Client
+	buildGitOpsFlux GitOpsFluxBuilder
+}
+
+// NewReverseSyncer returns a new ReverseSyncer.
+func NewReverseSyncer(client client.Client, buildGitOpsFlux GitOpsFluxBuilder) *ReverseSyncer {
+	return &ReverseSyncer{
+		client:          client,
+		buildGitOpsFlux: buildGitOpsFlux,
+	}
+}
+
+// ReverseSync commits the cluster config of the cluster to git if it changed since it was last committed. It's a
+// no-op for clusters without a FluxConfig or with reverse sync disabled.
+func (r *ReverseSyncer) ReverseSync(ctx context.Context, log logr.Logger, c *anywherev1.Cluster) error {
+	if c.Spec.GitOpsRef == nil || c.Spec.GitOpsRef.Kind != anywherev1.FluxConfigKind {
+		return nil
+	}
+
+	// The objects of the spec are modified to be written to git, so they can't be the ones being reconciled.
+	clusterSpec, err := cluster.BuildSpec(ctx, clientutil.NewKubeClient(r.client), c.DeepCopy())
+	if err != nil {
+		return errors.Wrap(err, "building cluster spec for reverse sync")
+	}
+
+	if clusterSpec.FluxConfig == nil || !clusterSpec.FluxConfig.Spec.ReverseSync {
+		return nil
+	}
+
+	gitOpsFlux, err := r.buildGitOpsFlux(ctx, clusterSpec)
+	if err != nil {
+		return errors.Wrap(err, "building gitops flux for reverse sync")
+	}
+
+	report, err := gitOpsFlux.ReverseSync(ctx, clusterSpec)
+	if err != nil {
+		return errors.Wrap(err, "reverse syncing cluster config to git")
+	}
+
+	if report != nil {
+		log.Info("Committed cluster config changes to git", "file", report.File, "drifted", len(report.Objects))
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package reconciler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	eksdv1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux/reconciler"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+type fakeGitOpsFlux struct {
+	clusterSpec *cluster.Spec
+	report      *flux.DriftReport
+	err         error
+}
+
+func (f *fakeGitOpsFlux) ReverseSync(_ context.Context, clusterSpec *cluster.Spec) (*flux.DriftReport, error) {
+	f.clusterSpec = clusterSpec
+	return f.report, f.err
+}
+
+type reverseSyncerTest struct {
+	*WithT
+	ctx        context.Context
+	cluster    *anywherev1.Cluster
+	fluxConfig *anywherev1.FluxConfig
+	gitOpsFlux *fakeGitOpsFlux
+}
+
+func newReverseSyncerTest(t *testing.T) *reverseSyncerTest {
+	bundle := test.Bundle()
+	return &reverseSyncerTest{
+		WithT: NewWithT(t),
+		ctx:   context.Background(),
+		cluster: &anywherev1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-cluster",
+				Namespace: "default",
+			},
+			Spec: anywherev1.ClusterSpec{
+				KubernetesVersion: "1.20",
+				BundlesRef: &anywherev1.BundlesRef{
+					Name:       bundle.Name,
+					Namespace:  bundle.Namespace,
+					APIVersion: bundle.APIVersion,
+				},
+				GitOpsRef: &anywherev1.Ref{
+					Kind: anywherev1.FluxConfigKind,
+					Name: "my-flux",
+				},
+			},
+		},
+		fluxConfig: &anywherev1.FluxConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-flux",
+				Namespace: "default",
+			},
+			Spec: anywherev1.FluxConfigSpec{
+				Github: &anywherev1.GithubProviderConfig{
+					Owner:      "janedoe",
+					Repository: "flux-fleet",
+				},
+				ReverseSync: true,
+			},
+		},
+		gitOpsFlux: &fakeGitOpsFlux{},
+	}
+}
+
+func (tt *reverseSyncerTest) reverseSyncer(buildErr error) *reconciler.ReverseSyncer {
+	scheme := runtime.NewScheme()
+	_ = anywherev1.AddToScheme(scheme)
+	_ = releasev1.AddToScheme(scheme)
+	_ = eksdv1.AddToScheme(scheme)
+	objs := []client.Object{test.Bundle(), test.EksdRelease(), tt.cluster, tt.fluxConfig}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+
+	return reconciler.NewReverseSyncer(cl, func(context.Context, *cluster.Spec) (reconciler.GitOpsFlux, error) {
+		if buildErr != nil {
+			return nil, buildErr
+		}
+		return tt.gitOpsFlux, nil
+	})
+}
+
+func TestReverseSyncerReverseSync(t *testing.T) {
+	tt := newReverseSyncerTest(t)
+	tt.gitOpsFlux.report = &flux.DriftReport{File: "clusters/my-cluster/my-cluster/eksa-system/eksa-cluster.yaml"}
+
+	tt.Expect(tt.reverseSyncer(nil).ReverseSync(tt.ctx, test.NewNullLogger(), tt.cluster)).To(Succeed())
+	tt.Expect(tt.gitOpsFlux.clusterSpec).NotTo(BeNil())
+	tt.Expect(tt.gitOpsFlux.clusterSpec.Cluster).NotTo(BeIdenticalTo(tt.cluster))
+	tt.Expect(tt.gitOpsFlux.clusterSpec.FluxConfig.Spec.ReverseSync).To(BeTrue())
+}
+
+func TestReverseSyncerReverseSyncDisabled(t *testing.T) {
+	tt := newReverseSyncerTest(t)
+	tt.fluxConfig.Spec.ReverseSync = false
+
+	tt.Expect(tt.reverseSyncer(errors.New("not expected")).ReverseSync(tt.ctx, test.NewNullLogger(), tt.cluster)).To(Succeed())
+	tt.Expect(tt.gitOpsFlux.clusterSpec).To(BeNil())
+}
+
+func TestReverseSyncerReverseSyncNoGitOps(t *testing.T) {
+	tt := newReverseSyncerTest(t)
+	tt.cluster.Spec.GitOpsRef = nil
+
+	tt.Expect(tt.reverseSyncer(errors.New("not expected")).ReverseSync(tt.ctx, test.NewNullLogger(), tt.cluster)).To(Succeed())
+	tt.Expect(tt.gitOpsFlux.clusterSpec).To(BeNil())
+}
+
+func TestReverseSyncerReverseSyncError(t *testing.T) {
+	tt := newReverseSyncerTest(t)
+	tt.gitOpsFlux.err = errors.New("push rejected")
+
+	err := tt.reverseSyncer(nil).ReverseSync(tt.ctx, test.NewNullLogger(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("reverse syncing cluster config to git: push rejected")))
+}
+
+func TestReverseSyncerReverseSyncBuildError(t *testing.T) {
+	tt := newReverseSyncerTest(t)
+
+	err := tt.reverseSyncer(errors.New("no credentials")).ReverseSync(tt.ctx, test.NewNullLogger(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("building gitops flux for reverse sync: no credentials")))
+}
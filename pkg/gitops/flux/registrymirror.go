@@ -0,0 +1,78 @@
+package flux
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/registrymirror"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+// upstreamFluxRegistry is the registry flux bootstrap pulls the toolkit components images from, before the
+// flux-system patch replaces them with the bundle images.
+const upstreamFluxRegistry = "ghcr.io/fluxcd"
+
+// ImageChecker checks images can be pulled from their registries.
+type ImageChecker interface {
+	CheckImage(ctx context.Context, image string) error
+}
+
+// WithImageChecker enables checking the flux controllers images can be pulled from the registry mirror
+// before installing flux.
+func WithImageChecker(checker ImageChecker) Opt {
+	return func(f *Flux) {
+		f.imageChecker = checker
+	}
+}
+
+func (f *Flux) registryMirrorValidation(ctx context.Context, clusterSpec *cluster.Spec) validations.Validation {
+	return func() *validations.ValidationResult {
+		return &validations.ValidationResult{
+			Name:        "Flux registry mirror images",
+			Remediation: "Please add a registry mirror OCI namespace for the registry of the flux images and make sure the images are pushed to the registry mirror",
+			Err:         f.validateRegistryMirrorImages(ctx, clusterSpec),
+		}
+	}
+}
+
+// validateRegistryMirrorImages checks every flux controller image is served by the registry mirror of the cluster and,
+// with an image checker, can be pulled from it.
+func (f *Flux) validateRegistryMirrorImages(ctx context.Context, clusterSpec *cluster.Spec) error {
+	mirror := registrymirror.FromCluster(clusterSpec.Cluster)
+	if mirror == nil {
+		return nil
+	}
+
+	if mirror.ReplaceRegistry(upstreamFluxRegistry) == upstreamFluxRegistry {
+		logger.Info("Warning: the registry mirror doesn't mirror the upstream flux registry, flux bootstrap will pull the toolkit components images from it", "registry", upstreamFluxRegistry)
+	}
+
+	images := fluxControllerImages(clusterSpec)
+	for _, name := range sortedKeys(images) {
+		image := images[name]
+		if !servedByMirror(mirror, image) {
+			return fmt.Errorf("flux %s image %s isn't served by the registry mirror %s", name, image, mirror.BaseRegistry)
+		}
+		if f.imageChecker == nil {
+			continue
+		}
+		logger.V(4).Info("Checking flux image can be pulled from the registry mirror", "image", image)
+		if err := f.imageChecker.CheckImage(ctx, image); err != nil {
+			return fmt.Errorf("flux %s image %s can't be pulled from the registry mirror: %v", name, image, err)
+		}
+	}
+	return nil
+}
+
+// servedByMirror returns true if the already rewritten image is pulled from one of the mirror namespaces.
+func servedByMirror(mirror *registrymirror.RegistryMirror, image string) bool {
+	for _, namespace := range mirror.NamespacedRegistryMap {
+		if image == namespace || strings.HasPrefix(image, namespace+"/") {
+			return true
+		}
+	}
+	return false
+}
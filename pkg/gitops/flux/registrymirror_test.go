@@ -0,0 +1,77 @@
+package flux_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	fluxMocks "github.com/aws/eks-anywhere/pkg/gitops/flux/mocks"
+)
+
+type registryMirrorFluxTest struct {
+	fluxTest
+	checker *fluxMocks.MockImageChecker
+}
+
+func newRegistryMirrorFluxTest(t *testing.T) registryMirrorFluxTest {
+	g := newBucketFluxTest(t)
+	checker := fluxMocks.NewMockImageChecker(gomock.NewController(t))
+	g.gitOpsFlux = flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithBucketClient(g.bucket), flux.WithImageChecker(checker))
+	return registryMirrorFluxTest{fluxTest: g.fluxTest, checker: checker}
+}
+
+func newRegistryMirrorClusterSpec(t *testing.T, mirror *v1alpha1.RegistryMirrorConfiguration) *cluster.Spec {
+	clusterConfig := v1alpha1.NewCluster("management-cluster")
+	clusterConfig.Spec.RegistryMirrorConfiguration = mirror
+	return newBucketClusterSpec(t, clusterConfig)
+}
+
+func TestValidationsRegistryMirrorImages(t *testing.T) {
+	g := newRegistryMirrorFluxTest(t)
+	clusterSpec := newRegistryMirrorClusterSpec(t, &v1alpha1.RegistryMirrorConfiguration{Endpoint: "1.2.3.4", Port: "443"})
+
+	g.checker.EXPECT().CheckImage(g.ctx, "1.2.3.4:443/l0g8r8j6/fluxcd/helm-controller:v0.10.0-d82011942ec8a447ba89a70ff9a84bf7b9579492").Return(nil)
+	g.checker.EXPECT().CheckImage(g.ctx, "1.2.3.4:443/l0g8r8j6/fluxcd/kustomize-controller:v0.11.1-d82011942ec8a447ba89a70ff9a84bf7b9579492").Return(nil)
+	g.checker.EXPECT().CheckImage(g.ctx, "1.2.3.4:443/l0g8r8j6/fluxcd/notification-controller:v0.13.0-d82011942ec8a447ba89a70ff9a84bf7b9579492").Return(nil)
+	g.checker.EXPECT().CheckImage(g.ctx, "1.2.3.4:443/l0g8r8j6/fluxcd/source-controller:v0.12.1-8539f509df046a4f567d2182dde824b957136599").Return(nil)
+
+	g.Expect(runValidations(g.gitOpsFlux.Validations(g.ctx, clusterSpec))).To(Succeed())
+}
+
+func TestValidationsRegistryMirrorImageNotPullable(t *testing.T) {
+	g := newRegistryMirrorFluxTest(t)
+	clusterSpec := newRegistryMirrorClusterSpec(t, &v1alpha1.RegistryMirrorConfiguration{Endpoint: "1.2.3.4", Port: "443"})
+
+	g.checker.EXPECT().CheckImage(g.ctx, gomock.Any()).Return(errors.New("not found"))
+
+	g.Expect(runValidations(g.gitOpsFlux.Validations(g.ctx, clusterSpec))).To(MatchError(
+		"flux helm-controller image 1.2.3.4:443/l0g8r8j6/fluxcd/helm-controller:v0.10.0-d82011942ec8a447ba89a70ff9a84bf7b9579492 can't be pulled from the registry mirror: not found",
+	))
+}
+
+func TestValidationsRegistryMirrorImageNotMirrored(t *testing.T) {
+	g := newRegistryMirrorFluxTest(t)
+	clusterSpec := newRegistryMirrorClusterSpec(t, &v1alpha1.RegistryMirrorConfiguration{
+		Endpoint: "1.2.3.4",
+		Port:     "443",
+		OCINamespaces: []v1alpha1.OCINamespace{
+			{Registry: "ghcr.io", Namespace: "ghcr"},
+		},
+	})
+
+	g.Expect(runValidations(g.gitOpsFlux.Validations(g.ctx, clusterSpec))).To(MatchError(
+		"flux helm-controller image public.ecr.aws/l0g8r8j6/fluxcd/helm-controller:v0.10.0-d82011942ec8a447ba89a70ff9a84bf7b9579492 isn't served by the registry mirror 1.2.3.4:443",
+	))
+}
+
+func TestValidationsRegistryMirrorWithoutImageChecker(t *testing.T) {
+	g := newBucketFluxTest(t)
+	clusterSpec := newRegistryMirrorClusterSpec(t, &v1alpha1.RegistryMirrorConfiguration{Endpoint: "1.2.3.4", Port: "443"})
+
+	g.Expect(runValidations(g.gitOpsFlux.Validations(g.ctx, clusterSpec))).To(Succeed())
+}
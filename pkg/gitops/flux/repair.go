@@ -0,0 +1,180 @@
+package flux
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	namespaceResourceType = "namespaces"
+	secretResourceType    = "secrets"
+)
+
+// repairDiagnosis is what is broken in a flux installation.
+type repairDiagnosis struct {
+	namespaceMissing bool
+	secretMissing    bool
+	sourceMissing    bool
+	// kustomizationMissing is true if the Kustomization reconciling the eksa-system path doesn't exist.
+	kustomizationMissing bool
+	// unhealthy is true if the source or the Kustomization exist but are not ready.
+	unhealthy bool
+}
+
+// needsBootstrap returns true if resources created by bootstrap are missing.
+func (d *repairDiagnosis) needsBootstrap() bool {
+	return d.namespaceMissing || d.secretMissing || d.sourceMissing || d.kustomizationMissing
+}
+
+func (d *repairDiagnosis) healthy() bool {
+	return !d.needsBootstrap() && !d.unhealthy
+}
+
+// Repair detects a broken flux installation in the management cluster and re-runs the parts of the bootstrap needed to
+// fix it, without requiring a cluster upgrade or a reinstall of GitOps. A deleted flux namespace, flux-system secret,
+// source or Kustomization is re-created by bootstrapping flux again, which is idempotent, and a failed source or
+// Kustomization is reconciled again. It's a no-op when the installation is healthy.
+func (f *Flux) Repair(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if f.shouldSkipFlux() {
+		logger.Info("GitOps not configured, repair skipped")
+		return nil
+	}
+
+	if !clusterSpec.Cluster.IsSelfManaged() {
+		return fmt.Errorf("repairing gitops: flux runs in management cluster %s, repair the management cluster instead", clusterSpec.Cluster.ManagedBy())
+	}
+
+	d, err := f.diagnose(ctx, managementCluster, clusterSpec)
+	if err != nil {
+		return fmt.Errorf("repairing gitops: %v", err)
+	}
+
+	if d.healthy() {
+		logger.Info("GitOps is healthy, nothing to repair")
+		return nil
+	}
+
+	if d.needsBootstrap() {
+		logger.V(1).Info("Flux installation is missing resources, bootstrapping flux again",
+			"namespaceMissing", d.namespaceMissing, "secretMissing", d.secretMissing,
+			"sourceMissing", d.sourceMissing, "kustomizationMissing", d.kustomizationMissing)
+		if usesOCIRepository(clusterSpec) || usesBucket(clusterSpec) {
+			if err := f.writeFluxSystemArtifactFiles(ctx, clusterSpec); err != nil {
+				return fmt.Errorf("repairing gitops: %v", err)
+			}
+		}
+		for _, bootstrap := range f.bootstraps() {
+			if err := bootstrap(ctx, managementCluster, clusterSpec); err != nil {
+				return fmt.Errorf("repairing gitops when bootstrapping flux: %v", err)
+			}
+		}
+	}
+
+	logger.V(1).Info("Reconciling flux")
+	if err := f.ForceReconcileGitRepo(ctx, managementCluster, clusterSpec); err != nil {
+		return fmt.Errorf("repairing gitops when reconciling flux: %v", err)
+	}
+
+	return nil
+}
+
+// bootstraps returns the bootstrap of every source type, each a no-op unless the FluxConfig uses its source.
+// Unlike Bootstrap, a failure doesn't uninstall flux.
+func (f *Flux) bootstraps() []func(context.Context, *types.Cluster, *cluster.Spec) error {
+	return []func(context.Context, *types.Cluster, *cluster.Spec) error{
+		f.BootstrapGithub,
+		f.BootstrapGitlab,
+		f.BootstrapBitbucketServer,
+		f.BootstrapAzureDevOps,
+		f.BootstrapGitea,
+		f.BootstrapCodeCommit,
+		f.BootstrapOCIRepository,
+		f.BootstrapBucket,
+		f.BootstrapGit,
+	}
+}
+
+// writeFluxSystemArtifactFiles writes the flux system files locally, which the OCI repository and bucket bootstraps
+// apply since flux can't bootstrap from those sources.
+func (f *Flux) writeFluxSystemArtifactFiles(ctx context.Context, clusterSpec *cluster.Spec) error {
+	fc, err := newFluxForCluster(f, clusterSpec, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fc.resetArtifactDir(); err != nil {
+		return err
+	}
+
+	g := NewFileGenerator()
+	if err := g.Init(fc.writer, fc.eksaSystemDir(), fc.fluxSystemDir()); err != nil {
+		return err
+	}
+	if err := g.WriteFluxSystemFiles(clusterSpec); err != nil {
+		return fmt.Errorf("writing flux system files: %v", err)
+	}
+	return fc.writeFluxComponents(ctx, g)
+}
+
+func (f *Flux) diagnose(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) (*repairDiagnosis, error) {
+	d := &repairDiagnosis{}
+	namespace := clusterSpec.FluxConfig.Spec.SystemNamespace
+
+	missing, err := f.objectMissing(ctx, managementCluster, namespaceResourceType, namespace, "")
+	if err != nil {
+		return nil, err
+	}
+	if missing {
+		logger.V(3).Info("Flux namespace not found", "namespace", namespace)
+		d.namespaceMissing = true
+		return d, nil
+	}
+
+	// The OCI repository and bucket sources authenticate with the credentials of the CLI instead of the flux-system secret
+	if !usesOCIRepository(clusterSpec) && !usesBucket(clusterSpec) {
+		d.secretMissing, err = f.objectMissing(ctx, managementCluster, secretResourceType, namespace, namespace)
+		if err != nil {
+			return nil, err
+		}
+		if d.secretMissing {
+			logger.V(3).Info("Flux system secret not found", "secret", namespace, "namespace", namespace)
+		}
+	}
+
+	status, err := f.GitOpsStatus(ctx, managementCluster, clusterSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range []*ResourceStatus{status.Source, status.Kustomization} {
+		if s.Ready {
+			continue
+		}
+		logger.V(3).Info("Flux resource is not ready", "kind", s.Kind, "name", s.Name, "reason", s.Reason, "message", s.Message)
+		if s.Reason != notFoundReason {
+			d.unhealthy = true
+		}
+	}
+	d.sourceMissing = status.Source.Reason == notFoundReason
+	d.kustomizationMissing = status.Kustomization.Reason == notFoundReason
+
+	return d, nil
+}
+
+func (f *Flux) objectMissing(ctx context.Context, cluster *types.Cluster, resourceType, name, namespace string) (bool, error) {
+	err := f.fluxClient.GetObject(ctx, cluster, resourceType, name, namespace, &unstructured.Unstructured{})
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("getting %s %s: %v", resourceType, name, err)
+	}
+	return false, nil
+}
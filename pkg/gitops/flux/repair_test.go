@@ -0,0 +1,158 @@
+package flux_test
+
+import (
+	"errors"
+	"path"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const healthyKustomization = `
+apiVersion: kustomize.toolkit.fluxcd.io/v1beta2
+kind: Kustomization
+metadata:
+  name: flux-system
+  namespace: flux-system
+status:
+  lastAppliedRevision: main/abc123
+  conditions:
+  - type: Ready
+    status: "True"
+    reason: ReconciliationSucceeded
+    message: "Applied revision: main/abc123"
+    lastTransitionTime: "2022-10-01T00:00:00Z"
+`
+
+func notFoundError(resource, name string) error {
+	return apierrors.NewNotFound(schema.GroupResource{Resource: resource}, name)
+}
+
+func TestRepairHealthy(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{Name: "management-cluster"}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "namespaces", "flux-system", "", gomock.Any()).Return(nil)
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "secrets", "flux-system", "flux-system", gomock.Any()).Return(nil)
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "gitrepositories.source.toolkit.fluxcd.io", "flux-system", "flux-system", gomock.Any()).DoAndReturn(returnObject(t, readyGitRepository))
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "kustomizations.kustomize.toolkit.fluxcd.io", "flux-system", "flux-system", gomock.Any()).DoAndReturn(returnObject(t, healthyKustomization))
+
+	g.Expect(g.gitOpsFlux.Repair(g.ctx, cluster, clusterSpec)).To(Succeed())
+}
+
+func TestRepairNamespaceMissing(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{Name: "management-cluster"}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "namespaces", "flux-system", "", gomock.Any()).Return(notFoundError("namespaces", "flux-system"))
+	g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, clusterSpec.FluxConfig).Return(nil)
+	g.flux.EXPECT().ForceReconcile(g.ctx, cluster, "flux-system").Return(nil)
+
+	g.Expect(g.gitOpsFlux.Repair(g.ctx, cluster, clusterSpec)).To(Succeed())
+}
+
+func TestRepairSecretMissing(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{Name: "management-cluster"}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "namespaces", "flux-system", "", gomock.Any()).Return(nil)
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "secrets", "flux-system", "flux-system", gomock.Any()).Return(notFoundError("secrets", "flux-system"))
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "gitrepositories.source.toolkit.fluxcd.io", "flux-system", "flux-system", gomock.Any()).DoAndReturn(returnObject(t, readyGitRepository))
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "kustomizations.kustomize.toolkit.fluxcd.io", "flux-system", "flux-system", gomock.Any()).DoAndReturn(returnObject(t, healthyKustomization))
+	g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, clusterSpec.FluxConfig).Return(nil)
+	g.flux.EXPECT().ForceReconcile(g.ctx, cluster, "flux-system").Return(nil)
+
+	g.Expect(g.gitOpsFlux.Repair(g.ctx, cluster, clusterSpec)).To(Succeed())
+}
+
+func TestRepairKustomizationFailed(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{Name: "management-cluster"}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "namespaces", "flux-system", "", gomock.Any()).Return(nil)
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "secrets", "flux-system", "flux-system", gomock.Any()).Return(nil)
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "gitrepositories.source.toolkit.fluxcd.io", "flux-system", "flux-system", gomock.Any()).DoAndReturn(returnObject(t, readyGitRepository))
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "kustomizations.kustomize.toolkit.fluxcd.io", "flux-system", "flux-system", gomock.Any()).DoAndReturn(returnObject(t, failedKustomization))
+	g.flux.EXPECT().ForceReconcile(g.ctx, cluster, "flux-system").Return(nil)
+
+	g.Expect(g.gitOpsFlux.Repair(g.ctx, cluster, clusterSpec)).To(Succeed())
+}
+
+func TestRepairKustomizationMissing(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{Name: "management-cluster"}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "namespaces", "flux-system", "", gomock.Any()).Return(nil)
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "secrets", "flux-system", "flux-system", gomock.Any()).Return(nil)
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "gitrepositories.source.toolkit.fluxcd.io", "flux-system", "flux-system", gomock.Any()).DoAndReturn(returnObject(t, readyGitRepository))
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "kustomizations.kustomize.toolkit.fluxcd.io", "flux-system", "flux-system", gomock.Any()).Return(notFoundError("kustomizations", "flux-system"))
+	g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, clusterSpec.FluxConfig).Return(nil)
+	g.flux.EXPECT().ForceReconcile(g.ctx, cluster, "flux-system").Return(nil)
+
+	g.Expect(g.gitOpsFlux.Repair(g.ctx, cluster, clusterSpec)).To(Succeed())
+}
+
+func TestRepairOCIRepositoryMissing(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{Name: "management-cluster"}
+	clusterSpec := newOCIClusterSpec(t, v1alpha1.NewCluster("management-cluster"))
+
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "namespaces", "flux-system", "", gomock.Any()).Return(nil)
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "ocirepositories.source.toolkit.fluxcd.io", "flux-system", "flux-system", gomock.Any()).Return(notFoundError("ocirepositories", "flux-system"))
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "kustomizations.kustomize.toolkit.fluxcd.io", "flux-system", "flux-system", gomock.Any()).DoAndReturn(returnObject(t, healthyKustomization))
+	g.flux.EXPECT().ExportComponents(g.ctx, clusterSpec.FluxConfig).Return([]byte("components"), nil)
+	g.flux.EXPECT().InstallComponents(g.ctx, cluster, clusterSpec.FluxConfig).Return(nil)
+	g.flux.EXPECT().ApplyKustomization(g.ctx, cluster, path.Join(g.writer.Dir(), "clusters/management-cluster/flux-system")).Return(nil)
+	g.flux.EXPECT().Reconcile(g.ctx, cluster, clusterSpec.FluxConfig).Return(nil)
+
+	g.Expect(g.gitOpsFlux.Repair(g.ctx, cluster, clusterSpec)).To(Succeed())
+}
+
+func TestRepairSkipFlux(t *testing.T) {
+	g := newFluxTest(t)
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, nil, nil)
+
+	g.Expect(f.Repair(g.ctx, &types.Cluster{}, g.clusterSpec)).To(Succeed())
+}
+
+func TestRepairNotSelfManaged(t *testing.T) {
+	g := newFluxTest(t)
+	clusterConfig := v1alpha1.NewCluster("workload-cluster")
+	clusterConfig.SetManagedBy("management-cluster")
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+
+	g.Expect(g.gitOpsFlux.Repair(g.ctx, &types.Cluster{}, clusterSpec)).To(MatchError(ContainSubstring("repair the management cluster instead")))
+}
+
+func TestRepairGetNamespaceError(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{Name: "management-cluster"}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "namespaces", "flux-system", "", gomock.Any()).Return(errors.New("error in get"))
+
+	g.Expect(g.gitOpsFlux.Repair(g.ctx, cluster, clusterSpec)).To(MatchError(ContainSubstring("getting namespaces flux-system: error in get")))
+}
+
+func TestRepairBootstrapError(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{Name: "management-cluster"}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "namespaces", "flux-system", "", gomock.Any()).Return(notFoundError("namespaces", "flux-system"))
+	g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, clusterSpec.FluxConfig).Return(errors.New("error from client"))
+
+	g.Expect(g.gitOpsFlux.Repair(g.ctx, cluster, clusterSpec)).To(MatchError(ContainSubstring("repairing gitops when bootstrapping flux: error from client")))
+}
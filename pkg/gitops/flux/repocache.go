@@ -0,0 +1,11 @@
+package flux
+
+// WithRepositoryCache makes the operations reuse the local repository when it was already cloned by a previous
+// operation, fetching the branch and hard resetting to it instead of cloning the repository again. Local commits
+// and changes that weren't pushed are discarded. Use it with a git client whose repository directory is stable
+// across operations on the same repository.
+func WithRepositoryCache() Opt {
+	return func(f *Flux) {
+		f.repositoryCache = true
+	}
+}
@@ -0,0 +1,153 @@
+package flux
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// GitRepoCleanup is how CleanupGitRepo cleans up the repository of a management cluster when it's deleted.
+type GitRepoCleanup string
+
+const (
+	// GitRepoCleanupDeleteBranch deletes the sync branch of the cluster in the remote repository.
+	GitRepoCleanupDeleteBranch GitRepoCleanup = "delete-branch"
+	// GitRepoCleanupArchiveRepo archives the repository with the git provider API, keeping the last committed cluster
+	// config read-only.
+	GitRepoCleanupArchiveRepo GitRepoCleanup = "archive-repo"
+	// GitRepoCleanupDeleteRepo deletes the repository with the git provider API.
+	GitRepoCleanupDeleteRepo GitRepoCleanup = "delete-repo"
+)
+
+// WithGitRepoCleanup makes CleanupGitRepo delete the sync branch, or archive or delete the repository, when a
+// management cluster is deleted, instead of removing the cluster dir and pushing the change, so branches and
+// repositories don't pile up. Workload clusters share the repository of their management cluster, so their dir is
+// still removed. Archiving and deleting the repository require a git provider supporting them. The branch is only
+// deleted when it's templated per cluster and no other cluster uses it, and the repository is only archived or deleted
+// when no other cluster uses it and it has no other branch than the sync branch. Otherwise only the cluster dir is removed.
+func WithGitRepoCleanup(cleanup GitRepoCleanup) Opt {
+	return func(f *Flux) {
+		f.gitRepoCleanup = cleanup
+	}
+}
+
+// ValidateGitRepoCleanup returns an error if the git repo cleanup isn't supported.
+func ValidateGitRepoCleanup(cleanup string) error {
+	switch GitRepoCleanup(cleanup) {
+	case GitRepoCleanupDeleteBranch, GitRepoCleanupArchiveRepo, GitRepoCleanupDeleteRepo:
+		return nil
+	default:
+		return fmt.Errorf("unsupported git repo cleanup %s", cleanup)
+	}
+}
+
+// remoteRepoSharedReason returns why the sync branch or repository of the management cluster might be used by other
+// clusters, or an empty string if it's owned by the cluster. The branch is owned when it's templated per cluster and
+// the synced local repository has no other cluster config or path claim. The local repository only has the sync
+// branch, and other clusters could sync from the other branches of the repository, so the repository is owned when the
+// branch is and it has no other branch. A sparse checkout doesn't have the files of the other clusters, so neither is
+// ever considered owned with it.
+func (fc *fluxForCluster) remoteRepoSharedReason(ctx context.Context) (string, error) {
+	if fc.gitRepoCleanup == GitRepoCleanupDeleteBranch && !strings.Contains(fc.clusterSpec.FluxConfig.Spec.Branch, "{{") {
+		return fmt.Sprintf("branch %s is not templated per cluster", fc.branch()), nil
+	}
+	if fc.sparseCheckout {
+		return "the local repository is a sparse checkout of the cluster config path", nil
+	}
+
+	claims, err := fc.readPathClaims()
+	if err != nil {
+		return "", err
+	}
+	for _, c := range claims {
+		if c.Cluster != fc.clusterSpec.Cluster.Name {
+			return fmt.Sprintf("management cluster %s claims path %s", c.Cluster, c.Path), nil
+		}
+	}
+
+	configs, err := fc.clusterConfigDirs()
+	if err != nil {
+		return "", err
+	}
+	for _, dir := range configs {
+		if dir != fc.eksaSystemDir() {
+			return fmt.Sprintf("cluster config %s exists", path.Join(dir, clusterConfigFileName)), nil
+		}
+	}
+
+	if fc.gitRepoCleanup == GitRepoCleanupDeleteBranch {
+		return "", nil
+	}
+
+	branches, err := fc.gitClient.RemoteBranches(ctx)
+	if err != nil {
+		return "", fmt.Errorf("listing branches of repository %s: %v", fc.repository(), err)
+	}
+	for _, b := range branches {
+		if b != fc.branch() {
+			return fmt.Sprintf("repository %s has branch %s", fc.repository(), b), nil
+		}
+	}
+
+	return "", nil
+}
+
+// clusterConfigDirs returns the directories of the local repository with a cluster config file, relative to its root.
+func (fc *fluxForCluster) clusterConfigDirs() ([]string, error) {
+	root := fc.writer.Dir()
+	var dirs []string
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || d.Name() != clusterConfigFileName {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, filepath.Dir(p))
+		if err != nil {
+			return err
+		}
+		dirs = append(dirs, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing cluster configs in the local repository: %v", err)
+	}
+	return dirs, nil
+}
+
+// cleanupRemoteRepo applies the git repo cleanup to the repository of the management cluster.
+func (fc *fluxForCluster) cleanupRemoteRepo(ctx context.Context) error {
+	switch fc.gitRepoCleanup {
+	case GitRepoCleanupDeleteBranch:
+		logger.V(3).Info("Deleting cluster branch in git", "repository", fc.repository(), "branch", fc.branch())
+		if err := fc.gitClient.DeleteRemoteBranch(ctx, fc.branch()); err != nil {
+			return fmt.Errorf("deleting branch %s in git: %v", fc.branch(), err)
+		}
+	case GitRepoCleanupArchiveRepo:
+		logger.V(3).Info("Archiving cluster repository", "repository", fc.repository(), "owner", fc.owner())
+		if err := fc.gitClient.ArchiveRepo(ctx, git.ArchiveRepoOpts{Owner: fc.owner(), Repository: fc.repository()}); err != nil {
+			return fmt.Errorf("archiving repository %s: %v", fc.repository(), err)
+		}
+	case GitRepoCleanupDeleteRepo:
+		logger.V(3).Info("Deleting cluster repository", "repository", fc.repository(), "owner", fc.owner())
+		if err := fc.gitClient.DeleteRepo(ctx, git.DeleteRepoOpts{Owner: fc.owner(), Repository: fc.repository()}); err != nil {
+			return fmt.Errorf("deleting repository %s: %v", fc.repository(), err)
+		}
+	default:
+		return fmt.Errorf("unsupported git repo cleanup %s", fc.gitRepoCleanup)
+	}
+
+	logger.V(3).Info("Finished cleaning up cluster repository", "repository", fc.repository(), "cleanup", fc.gitRepoCleanup)
+	return nil
+}
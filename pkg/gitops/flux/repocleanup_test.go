@@ -0,0 +1,213 @@
+package flux_test
+
+import (
+	"errors"
+	"path"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+)
+
+const (
+	templatedClusterBranch = "clusters/{{.Name}}"
+	managementClusterDir   = "clusters/management-cluster"
+)
+
+func writeRepoFile(g fluxTest, file, content string) {
+	w, err := g.writer.WithDir(path.Dir(file))
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = w.Write(path.Base(file), []byte(content), filewriter.PersistentFile)
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+// newOwnedRepoClusterSpec returns the spec of a management cluster syncing from its own templated branch, with its
+// cluster config and path claim as the only ones in the local repository.
+func newOwnedRepoClusterSpec(t *testing.T, g fluxTest) *cluster.Spec {
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.Branch = templatedClusterBranch
+	writeRepoFile(g, path.Join(managementClusterDir, "management-cluster/eksa-system/eksa-cluster.yaml"), "kind: Cluster")
+	writeRepoFile(g, ".eksa/claims/management-cluster.yaml", "cluster: management-cluster\npath: "+managementClusterDir)
+	return clusterSpec
+}
+
+func expectSyncClusterBranch(g fluxTest) {
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch("clusters/management-cluster").Return(nil)
+}
+
+func TestCleanupGitRepoDeleteBranch(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := newOwnedRepoClusterSpec(t, g)
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithGitRepoCleanup(flux.GitRepoCleanupDeleteBranch))
+
+	expectSyncClusterBranch(g)
+	g.git.EXPECT().DeleteRemoteBranch(g.ctx, "clusters/management-cluster").Return(nil)
+
+	g.Expect(f.CleanupGitRepo(g.ctx, clusterSpec)).To(Succeed())
+}
+
+func TestCleanupGitRepoDeleteBranchError(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := newOwnedRepoClusterSpec(t, g)
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithGitRepoCleanup(flux.GitRepoCleanupDeleteBranch))
+
+	expectSyncClusterBranch(g)
+	g.git.EXPECT().DeleteRemoteBranch(g.ctx, "clusters/management-cluster").Return(errors.New("protected branch"))
+
+	g.Expect(f.CleanupGitRepo(g.ctx, clusterSpec)).To(MatchError(ContainSubstring("deleting branch clusters/management-cluster in git: protected branch")))
+}
+
+func TestCleanupGitRepoArchiveRepo(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := newOwnedRepoClusterSpec(t, g)
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithGitRepoCleanup(flux.GitRepoCleanupArchiveRepo))
+	github := clusterSpec.FluxConfig.Spec.Github
+
+	expectSyncClusterBranch(g)
+	g.git.EXPECT().RemoteBranches(g.ctx).Return([]string{"clusters/management-cluster"}, nil)
+	g.git.EXPECT().ArchiveRepo(g.ctx, git.ArchiveRepoOpts{Owner: github.Owner, Repository: github.Repository}).Return(nil)
+
+	g.Expect(f.CleanupGitRepo(g.ctx, clusterSpec)).To(Succeed())
+}
+
+func TestCleanupGitRepoArchiveDedicatedRepoFixedBranch(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := newOwnedRepoClusterSpec(t, g)
+	clusterSpec.FluxConfig.Spec.Branch = "main"
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithGitRepoCleanup(flux.GitRepoCleanupArchiveRepo))
+	github := clusterSpec.FluxConfig.Spec.Github
+
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch("main").Return(nil)
+	g.git.EXPECT().RemoteBranches(g.ctx).Return([]string{"main"}, nil)
+	g.git.EXPECT().ArchiveRepo(g.ctx, git.ArchiveRepoOpts{Owner: github.Owner, Repository: github.Repository}).Return(nil)
+
+	g.Expect(f.CleanupGitRepo(g.ctx, clusterSpec)).To(Succeed())
+}
+
+func TestCleanupGitRepoArchiveRepoListBranchesError(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := newOwnedRepoClusterSpec(t, g)
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithGitRepoCleanup(flux.GitRepoCleanupArchiveRepo))
+
+	expectSyncClusterBranch(g)
+	g.git.EXPECT().RemoteBranches(g.ctx).Return(nil, errors.New("authentication required"))
+
+	g.Expect(f.CleanupGitRepo(g.ctx, clusterSpec)).To(MatchError(ContainSubstring("listing branches of repository " + clusterSpec.FluxConfig.Spec.Github.Repository + ": authentication required")))
+}
+
+func TestCleanupGitRepoDeleteRepo(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := newOwnedRepoClusterSpec(t, g)
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithGitRepoCleanup(flux.GitRepoCleanupDeleteRepo))
+	github := clusterSpec.FluxConfig.Spec.Github
+
+	expectSyncClusterBranch(g)
+	g.git.EXPECT().RemoteBranches(g.ctx).Return([]string{"clusters/management-cluster"}, nil)
+	g.git.EXPECT().DeleteRepo(g.ctx, git.DeleteRepoOpts{Owner: github.Owner, Repository: github.Repository}).Return(errors.New("forbidden"))
+
+	g.Expect(f.CleanupGitRepo(g.ctx, clusterSpec)).To(MatchError(ContainSubstring("deleting repository " + github.Repository + ": forbidden")))
+}
+
+func TestCleanupGitRepoUnsupportedCleanup(t *testing.T) {
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithGitRepoCleanup("rename-repo"))
+
+	g.Expect(f.CleanupGitRepo(g.ctx, clusterSpec)).To(MatchError(ContainSubstring("unsupported git repo cleanup rename-repo")))
+}
+
+func TestCleanupGitRepoSharedRemovesDir(t *testing.T) {
+	tests := []struct {
+		testName string
+		cleanup  flux.GitRepoCleanup
+		setup    func(g fluxTest, clusterSpec *cluster.Spec)
+	}{
+		{
+			testName: "delete branch not templated",
+			cleanup:  flux.GitRepoCleanupDeleteBranch,
+			setup: func(g fluxTest, clusterSpec *cluster.Spec) {
+				clusterSpec.FluxConfig.Spec.Branch = "clusters/management-cluster"
+			},
+		},
+		{
+			testName: "delete branch other cluster config",
+			cleanup:  flux.GitRepoCleanupDeleteBranch,
+			setup: func(g fluxTest, _ *cluster.Spec) {
+				writeRepoFile(g, path.Join(managementClusterDir, "workload-cluster/eksa-system/eksa-cluster.yaml"), "kind: Cluster")
+			},
+		},
+		{
+			testName: "delete repo other cluster config",
+			cleanup:  flux.GitRepoCleanupDeleteRepo,
+			setup: func(g fluxTest, _ *cluster.Spec) {
+				writeRepoFile(g, path.Join(managementClusterDir, "workload-cluster/eksa-system/eksa-cluster.yaml"), "kind: Cluster")
+			},
+		},
+		{
+			testName: "delete repo other path claim",
+			cleanup:  flux.GitRepoCleanupDeleteRepo,
+			setup: func(g fluxTest, _ *cluster.Spec) {
+				writeRepoFile(g, ".eksa/claims/other-cluster.yaml", "cluster: other-cluster\npath: clusters/other-cluster")
+			},
+		},
+		{
+			testName: "delete repo other cluster branch",
+			cleanup:  flux.GitRepoCleanupDeleteRepo,
+			setup: func(g fluxTest, _ *cluster.Spec) {
+				g.git.EXPECT().RemoteBranches(g.ctx).Return([]string{"clusters/management-cluster", "clusters/other-cluster"}, nil)
+			},
+		},
+		{
+			testName: "archive repo default branch",
+			cleanup:  flux.GitRepoCleanupArchiveRepo,
+			setup: func(g fluxTest, _ *cluster.Spec) {
+				g.git.EXPECT().RemoteBranches(g.ctx).Return([]string{"main", "clusters/management-cluster"}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := newFluxTest(t)
+			clusterSpec := newOwnedRepoClusterSpec(t, g)
+			tt.setup(g, clusterSpec)
+			f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithGitRepoCleanup(tt.cleanup))
+
+			expectSyncClusterBranch(g)
+			g.git.EXPECT().Remove(managementClusterDir).Return(nil)
+			g.git.EXPECT().Remove(".eksa/claims/management-cluster.yaml").Return(nil)
+			g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+			g.git.EXPECT().Push(g.ctx).Return(nil)
+
+			g.Expect(f.CleanupGitRepo(g.ctx, clusterSpec)).To(Succeed())
+		})
+	}
+}
+
+func TestCleanupGitRepoDeleteRepoWorkloadClusterRemovesDir(t *testing.T) {
+	g := newFluxTest(t)
+	clusterConfig := v1alpha1.NewCluster("workload-cluster")
+	clusterConfig.SetManagedBy("management-cluster")
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithGitRepoCleanup(flux.GitRepoCleanupDeleteRepo))
+	eksaSystemDir := "clusters/management-cluster/workload-cluster/eksa-system"
+	if _, err := g.writer.WithDir(eksaSystemDir); err != nil {
+		t.Fatal(err)
+	}
+
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Remove(eksaSystemDir).Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+
+	g.Expect(f.CleanupGitRepo(g.ctx, clusterSpec)).To(Succeed())
+}
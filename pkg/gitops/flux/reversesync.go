@@ -0,0 +1,137 @@
+package flux
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers"
+)
+
+// ReverseSync commits the cluster config of the live EKS-A objects in clusterSpec back to git when their spec drifted
+// from the cluster config committed in the repository, so changes made in the cluster outside of git, like scaling
+// the worker nodes with kubectl, aren't silently reverted by flux. The files are written the same way as
+// UpdateGitEksaSpec, with the annotations of the committed objects instead of the live ones, so the objects in
+// clusterSpec are modified and shouldn't be the ones reconciled. It returns the drift that was committed, or nil if
+// git already matches the live objects.
+func (f *Flux) ReverseSync(ctx context.Context, clusterSpec *cluster.Spec) (*DriftReport, error) {
+	if f.shouldSkipFlux() {
+		logger.Info("GitOps field not specified, reverse sync skipped")
+		return nil, nil
+	}
+
+	if usesOCIRepository(clusterSpec) || usesBucket(clusterSpec) {
+		return nil, errors.New("reverse sync is only supported with a git repository")
+	}
+
+	datacenterConfig, machineConfigs := providerConfigs(clusterSpec.Config)
+	if datacenterConfig == nil {
+		return nil, errors.New("reverse sync requires the datacenter config of the cluster")
+	}
+	fc, err := newFluxForCluster(f, clusterSpec, datacenterConfig, machineConfigs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fc.syncGitRepo(ctx); err != nil {
+		return nil, err
+	}
+
+	file, committedObjects, err := fc.readCommittedClusterConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	live := map[string]kubernetes.Object{}
+	for _, o := range append([]kubernetes.Object{clusterSpec.Cluster}, clusterSpec.Config.ChildObjects()...) {
+		// Objects read with a typed client don't have their type set, which is needed to write them.
+		if o.GetObjectKind().GroupVersionKind().Kind == "" {
+			o.GetObjectKind().SetGroupVersionKind(v1alpha1.GroupVersion.WithKind(reflect.TypeOf(o).Elem().Name()))
+		}
+		// The live annotations include the ones set by the controllers and kubectl, only the committed ones are kept.
+		o.SetAnnotations(nil)
+		live[objectKey(o)] = o
+	}
+
+	report := &DriftReport{File: file}
+	for _, committed := range committedObjects {
+		drift := &ObjectDrift{Kind: committed.GetObjectKind().GroupVersionKind().Kind, Name: committed.GetName(), Namespace: committed.GetNamespace()}
+		if drift.Namespace == "" {
+			drift.Namespace = constants.DefaultNamespace
+		}
+
+		l, ok := live[objectKey(committed)]
+		if !ok {
+			drift.Missing = true
+			report.Objects = append(report.Objects, *drift)
+			continue
+		}
+
+		l.SetAnnotations(committed.GetAnnotations())
+		drift, err = specDrift(drift, committed, l)
+		if err != nil {
+			return nil, err
+		}
+		if drift != nil {
+			report.Objects = append(report.Objects, *drift)
+		}
+	}
+
+	if !report.HasDrift() {
+		logger.V(4).Info("Committed cluster config matches the live objects, skipping reverse sync", "file", file)
+		return nil, nil
+	}
+
+	logger.Info("Committing cluster config changed in the cluster to git", "file", file, "drifted", len(report.Objects))
+	// Squashing would amend a commit made by the CLI, hiding that the change didn't come from git.
+	if err := fc.commitEksaFiles(ctx, reverseSyncOperation, false); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// providerConfigs returns the datacenter config and machine configs of the cluster config, to be written to git.
+func providerConfigs(config *cluster.Config) (providers.DatacenterConfig, []providers.MachineConfig) {
+	var datacenterConfig providers.DatacenterConfig
+	switch {
+	case config.VSphereDatacenter != nil:
+		datacenterConfig = config.VSphereDatacenter
+	case config.CloudStackDatacenter != nil:
+		datacenterConfig = config.CloudStackDatacenter
+	case config.DockerDatacenter != nil:
+		datacenterConfig = config.DockerDatacenter
+	case config.SnowDatacenter != nil:
+		datacenterConfig = config.SnowDatacenter
+	case config.NutanixDatacenter != nil:
+		datacenterConfig = config.NutanixDatacenter
+	case config.TinkerbellDatacenter != nil:
+		datacenterConfig = config.TinkerbellDatacenter
+	}
+
+	machineConfigs := []providers.MachineConfig{}
+	for _, m := range config.VSphereMachineConfigs {
+		machineConfigs = append(machineConfigs, m)
+	}
+	for _, m := range config.CloudStackMachineConfigs {
+		machineConfigs = append(machineConfigs, m)
+	}
+	for _, m := range config.SnowMachineConfigs {
+		machineConfigs = append(machineConfigs, m)
+	}
+	for _, m := range config.NutanixMachineConfigs {
+		machineConfigs = append(machineConfigs, m)
+	}
+	for _, m := range config.TinkerbellMachineConfigs {
+		machineConfigs = append(machineConfigs, m)
+	}
+	// Sorted so the machine configs are always written in the same order.
+	sort.Slice(machineConfigs, func(i, j int) bool { return machineConfigs[i].GetName() < machineConfigs[j].GetName() })
+	return datacenterConfig, machineConfigs
+}
@@ -0,0 +1,80 @@
+package flux_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+)
+
+const reverseSyncCommitMessage = "Reverse sync commit of cluster configuration changed in the cluster; generated by EKS-A controller"
+
+func (tt *driftTest) liveClusterSpec() *cluster.Spec {
+	live := tt.committed.DeepCopy()
+	// Objects read from the cluster with a typed client don't have their type set
+	live.Cluster.TypeMeta = metav1.TypeMeta{}
+	live.Cluster.Annotations = map[string]string{v1alpha1.ManagedByCLIAnnotation: "true"}
+	live.VSphereDatacenter = datacenterConfig("management-cluster")
+	live.VSphereMachineConfigs = map[string]*v1alpha1.VSphereMachineConfig{"management-cluster": machineConfig("management-cluster")}
+	tt.clusterSpec.Config = live
+	return tt.clusterSpec
+}
+
+func TestReverseSync(t *testing.T) {
+	tt := newDriftTest(t)
+	clusterSpec := tt.liveClusterSpec()
+	clusterSpec.Cluster.Spec.KubernetesVersion = v1alpha1.Kube121
+
+	tt.expectSync()
+	tt.git.EXPECT().Add("clusters/management-cluster/management-cluster/eksa-system").Return(nil)
+	tt.git.EXPECT().Commit(reverseSyncCommitMessage + "\n\nCluster configuration changes:\nCluster/management-cluster:\n  spec.kubernetesVersion: \"1.19\" -> \"1.21\"").Return(nil)
+	tt.git.EXPECT().Push(tt.ctx).Return(nil)
+
+	report, err := tt.gitOpsFlux.ReverseSync(tt.ctx, clusterSpec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(report.Objects).To(Equal([]flux.ObjectDrift{
+		{
+			Kind:      "Cluster",
+			Name:      "management-cluster",
+			Namespace: "default",
+			Fields: []flux.FieldDrift{
+				{Path: "spec.kubernetesVersion", Git: `"1.19"`, Live: `"1.21"`},
+			},
+		},
+	}))
+
+	content, err := os.ReadFile(path.Join(tt.writer.Dir(), report.File))
+	tt.Expect(err).NotTo(HaveOccurred())
+	committed, err := cluster.ParseConfig(content)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(committed.Cluster.GetObjectKind().GroupVersionKind().Kind).To(Equal(v1alpha1.ClusterKind))
+	tt.Expect(committed.Cluster.Spec.KubernetesVersion).To(Equal(v1alpha1.Kube121))
+	tt.Expect(committed.Cluster.Annotations).To(BeEmpty())
+}
+
+func TestReverseSyncNoDrift(t *testing.T) {
+	tt := newDriftTest(t)
+	clusterSpec := tt.liveClusterSpec()
+
+	tt.expectSync()
+
+	report, err := tt.gitOpsFlux.ReverseSync(tt.ctx, clusterSpec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(report).To(BeNil())
+}
+
+func TestReverseSyncOCIRepository(t *testing.T) {
+	tt := newDriftTest(t)
+	clusterSpec := tt.liveClusterSpec()
+	clusterSpec.FluxConfig.Spec.Github = nil
+	clusterSpec.FluxConfig.Spec.OCIRepository = &v1alpha1.OCIRepositoryConfig{Url: "oci://public.ecr.aws/l0g8r8j6/flux-fleet"}
+
+	_, err := tt.gitOpsFlux.ReverseSync(tt.ctx, clusterSpec)
+	tt.Expect(err).To(MatchError("reverse sync is only supported with a git repository"))
+}
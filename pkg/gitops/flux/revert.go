@@ -0,0 +1,73 @@
+package flux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+// WithRevertOnBootstrapFailure makes InstallGitOps remove the cluster config it pushed to the repository when
+// flux fails to bootstrap or the pushed manifests can't be applied, so the repository doesn't reference a cluster
+// that was never created. The removal is committed and pushed after flux is uninstalled.
+func WithRevertOnBootstrapFailure() Opt {
+	return func(f *Flux) {
+		f.revertOnBootstrapFailure = true
+	}
+}
+
+// revertClusterConfig removes the cluster config pushed by commitFluxAndClusterConfigToGit, including the flux
+// components pushed by a partial bootstrap, and its path claim. The installation already failed, so a failure to
+// revert is only logged.
+func (fc *fluxForCluster) revertClusterConfig(ctx context.Context) {
+	if !fc.Flux.revertOnBootstrapFailure || !fc.clusterSpec.Cluster.IsSelfManaged() {
+		return
+	}
+
+	if fc.stagingBranch != "" {
+		// The cluster config was only pushed to the staging branch, which is deleted instead
+		return
+	}
+
+	if fc.pullRequestBranch != "" {
+		logger.Info("Warning: flux bootstrap failed, close the pull request with the cluster config without merging it", "branch", fc.pullRequestBranch)
+		return
+	}
+
+	logger.Info("Reverting the cluster config pushed to the git repository", "path", fc.path())
+	if err := fc.removeClusterConfig(ctx); err != nil {
+		logger.Info("Warning: failed to revert the cluster config pushed to the git repository, remove it manually", "path", fc.path(), "error", err.Error())
+	}
+}
+
+func (fc *fluxForCluster) removeClusterConfig(ctx context.Context) error {
+	// Flux bootstrap pushes its components to the repository before it fails
+	var upToDateErr *git.RepositoryUpToDateError
+	if err := fc.gitClient.Pull(ctx, fc.branch()); err != nil && !errors.As(err, &upToDateErr) {
+		return err
+	}
+
+	if !validations.FileExists(path.Join(fc.writer.Dir(), fc.path())) {
+		logger.V(3).Info("Cluster config does not exist in git, skipping revert", "path", fc.path())
+		return nil
+	}
+
+	if err := fc.gitClient.Remove(fc.path()); err != nil {
+		return fmt.Errorf("removing %s in git: %v", fc.path(), err)
+	}
+
+	if err := fc.removePathClaim(); err != nil {
+		return err
+	}
+
+	msg, err := fc.clusterconfigCommitMessage(revertOperation, "")
+	if err != nil {
+		return err
+	}
+
+	return fc.Flux.pushToRemoteRepo(ctx, fc.path(), msg)
+}
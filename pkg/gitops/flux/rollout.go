@@ -0,0 +1,152 @@
+package flux
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/types"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const (
+	defaultControllersRolloutTimeout = 5 * time.Minute
+	controllersRolloutPollPeriod     = 5 * time.Second
+)
+
+// fluxControllerImageValues maps the flux-system patch values of the controller images to their deployments.
+var fluxControllerImageValues = map[string]string{
+	"SourceControllerImage":          v1alpha1.FluxSourceController,
+	"KustomizeControllerImage":       v1alpha1.FluxKustomizeController,
+	"HelmControllerImage":            v1alpha1.FluxHelmController,
+	"NotificationControllerImage":    v1alpha1.FluxNotificationController,
+	"ImageReflectorControllerImage":  v1alpha1.FluxImageReflectorController,
+	"ImageAutomationControllerImage": v1alpha1.FluxImageAutomationController,
+}
+
+// WithControllersRolloutTimeout sets how long Upgrade waits for the flux controllers to run the images of the new
+// bundle, 5 minutes by default.
+func WithControllersRolloutTimeout(timeout time.Duration) Opt {
+	return func(f *Flux) {
+		f.controllersRolloutTimeout = timeout
+	}
+}
+
+// fluxControllerImages returns the image of each flux controller deployment the flux-system patch sets,
+// keyed by deployment name.
+func fluxControllerImages(clusterSpec *cluster.Spec) map[string]string {
+	if clusterSpec.FluxConfig == nil {
+		return nil
+	}
+
+	images := map[string]string{}
+	for key, value := range fluxPatchValues(clusterSpec) {
+		name, ok := fluxControllerImageValues[key]
+		if !ok {
+			continue
+		}
+		if image, ok := value.(string); ok && image != "" {
+			images[name] = image
+		}
+	}
+	return images
+}
+
+// fluxControllersChangeDiff reports the flux controllers whose image changes between the bundles of the specs.
+func fluxControllersChangeDiff(currentSpec, newSpec *cluster.Spec) *types.ChangeDiff {
+	currentImages := fluxControllerImages(currentSpec)
+	newImages := fluxControllerImages(newSpec)
+
+	var reports []types.ComponentChangeDiff
+	for _, name := range sortedKeys(newImages) {
+		oldImage, ok := currentImages[name]
+		if !ok || oldImage == newImages[name] {
+			continue
+		}
+		reports = append(reports, types.ComponentChangeDiff{
+			ComponentName: fmt.Sprintf("Flux %s", name),
+			OldVersion:    releasev1alpha1.Image{URI: oldImage}.Tag(),
+			NewVersion:    releasev1alpha1.Image{URI: newImages[name]}.Tag(),
+		})
+	}
+	if len(reports) == 0 {
+		return nil
+	}
+
+	logger.V(1).Info("Flux controllers change diff", "controllers", len(reports))
+	return &types.ChangeDiff{ComponentReports: reports}
+}
+
+// waitForControllersRollout waits until every flux controller deployment runs the image of the new bundle
+// and all its replicas are updated and available.
+func (f *Flux) waitForControllersRollout(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	images := fluxControllerImages(clusterSpec)
+	namespace := clusterSpec.FluxConfig.Spec.SystemNamespace
+
+	timeout := f.controllersRolloutTimeout
+	if timeout == 0 {
+		timeout = defaultControllersRolloutTimeout
+	}
+	r := retrier.New(timeout, retrier.WithRetryPolicy(func(_ int, _ error) (bool, time.Duration) {
+		return true, controllersRolloutPollPeriod
+	}))
+
+	for _, name := range sortedKeys(images) {
+		logger.V(3).Info("Waiting for flux controller rollout", "deployment", name, "image", images[name])
+		err := r.Retry(func() error {
+			deployment, err := f.fluxClient.GetDeployment(ctx, cluster, name, namespace)
+			if err != nil {
+				return err
+			}
+			return deploymentRolledOut(deployment, images[name])
+		})
+		if err != nil {
+			return fmt.Errorf("waiting for flux controller %s to roll out: %v", name, err)
+		}
+	}
+	return nil
+}
+
+func deploymentRolledOut(deployment *appsv1.Deployment, image string) error {
+	if !hasContainerImage(deployment, image) {
+		return fmt.Errorf("deployment %s doesn't run image %s", deployment.Name, image)
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	status := deployment.Status
+	if status.ObservedGeneration < deployment.Generation ||
+		status.UpdatedReplicas != replicas ||
+		status.AvailableReplicas != replicas ||
+		status.Replicas != replicas {
+		return fmt.Errorf("deployment %s has %d updated and %d available replicas out of %d", deployment.Name, status.UpdatedReplicas, status.AvailableReplicas, replicas)
+	}
+	return nil
+}
+
+func hasContainerImage(deployment *appsv1.Deployment, image string) bool {
+	for _, c := range deployment.Spec.Template.Spec.Containers {
+		if c.Image == image {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
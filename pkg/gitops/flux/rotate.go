@@ -0,0 +1,51 @@
+package flux
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// RotateCredentials re-creates the flux-system secret with the current git provider credentials and forces a reconcile.
+// Re-bootstrapping with a new personal access token registers a new deploy key with the git provider, replacing the
+// expired or revoked one, while the generic git provider picks up the private key file the CLI is configured with.
+func (f *Flux) RotateCredentials(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if f.shouldSkipFlux() {
+		logger.Info("GitOps not configured, credentials rotation skipped")
+		return nil
+	}
+
+	if !clusterSpec.Cluster.IsSelfManaged() {
+		return fmt.Errorf("rotating gitops credentials: flux runs in management cluster %s, rotate the credentials of the management cluster instead", clusterSpec.Cluster.ManagedBy())
+	}
+
+	if usesOCIRepository(clusterSpec) || usesBucket(clusterSpec) {
+		logger.Info("Flux source is not a git repository, credentials rotation skipped")
+		return nil
+	}
+
+	if clusterSpec.FluxConfig.Spec.ExternalSecret != nil {
+		logger.Info("Flux git credentials are synced by the External Secrets Operator, rotate them in the external secret store", "remoteKey", clusterSpec.FluxConfig.Spec.ExternalSecret.RemoteKey)
+		return nil
+	}
+
+	logger.V(1).Info("Deleting flux-system secret", "namespace", clusterSpec.FluxConfig.Spec.SystemNamespace)
+	if err := f.fluxClient.DeleteSystemSecret(ctx, managementCluster, clusterSpec.FluxConfig.Spec.SystemNamespace); err != nil {
+		return fmt.Errorf("rotating gitops credentials when deleting old flux-system secret: %v", err)
+	}
+
+	for _, bootstrap := range f.bootstraps() {
+		if err := bootstrap(ctx, managementCluster, clusterSpec); err != nil {
+			return fmt.Errorf("rotating gitops credentials when bootstrapping flux: %v", err)
+		}
+	}
+
+	if err := f.ForceReconcileGitRepo(ctx, managementCluster, clusterSpec); err != nil {
+		return fmt.Errorf("rotating gitops credentials when reconciling flux: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package flux_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+func TestRotateCredentialsSuccess(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{Name: "management-cluster", KubeconfigFile: "k.kubeconfig"}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "clusters/management-cluster")
+
+	g.flux.EXPECT().DeleteSystemSecret(g.ctx, cluster, "flux-system").Return(nil)
+	g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, clusterSpec.FluxConfig).Return(nil)
+	g.flux.EXPECT().ForceReconcile(g.ctx, cluster, "flux-system").Return(nil)
+
+	g.Expect(g.gitOpsFlux.RotateCredentials(g.ctx, cluster, clusterSpec)).To(Succeed())
+}
+
+func TestRotateCredentialsSkipFlux(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{Name: "management-cluster"}
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, nil, nil)
+
+	g.Expect(f.RotateCredentials(g.ctx, cluster, g.clusterSpec)).To(Succeed())
+}
+
+func TestRotateCredentialsNotSelfManaged(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{Name: "workload-cluster"}
+	clusterConfig := v1alpha1.NewCluster("workload-cluster")
+	clusterConfig.SetManagedBy("management-cluster")
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+
+	g.Expect(g.gitOpsFlux.RotateCredentials(g.ctx, cluster, clusterSpec)).To(MatchError(ContainSubstring("flux runs in management cluster management-cluster")))
+}
+
+func TestRotateCredentialsOCIRepository(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{Name: "management-cluster"}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.Github = nil
+	clusterSpec.FluxConfig.Spec.OCIRepository = &v1alpha1.OCIRepositoryConfig{Url: "oci://registry.local/eksa/fleet"}
+
+	g.Expect(g.gitOpsFlux.RotateCredentials(g.ctx, cluster, clusterSpec)).To(Succeed())
+}
+
+func TestRotateCredentialsExternalSecret(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{Name: "management-cluster"}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.ExternalSecret = &v1alpha1.FluxExternalSecretConfig{
+		SecretStoreRef: v1alpha1.FluxSecretStoreRef{Name: "aws-secrets-manager"},
+		RemoteKey:      "eksa/flux-fleet",
+	}
+
+	g.Expect(g.gitOpsFlux.RotateCredentials(g.ctx, cluster, clusterSpec)).To(Succeed())
+}
+
+func TestRotateCredentialsDeleteSecretError(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{Name: "management-cluster"}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+
+	g.flux.EXPECT().DeleteSystemSecret(g.ctx, cluster, "flux-system").Return(errors.New("error from client"))
+
+	g.Expect(g.gitOpsFlux.RotateCredentials(g.ctx, cluster, clusterSpec)).To(MatchError(ContainSubstring("deleting old flux-system secret: error from client")))
+}
+
+func TestRotateCredentialsBootstrapError(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{Name: "management-cluster"}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+
+	g.flux.EXPECT().DeleteSystemSecret(g.ctx, cluster, "flux-system").Return(nil)
+	g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, clusterSpec.FluxConfig).Return(errors.New("error from client"))
+
+	g.Expect(g.gitOpsFlux.RotateCredentials(g.ctx, cluster, clusterSpec)).To(MatchError(ContainSubstring("bootstrapping flux: error from client")))
+}
+
+func TestRotateCredentialsReconcileError(t *testing.T) {
+	g := newFluxTest(t)
+	cluster := &types.Cluster{Name: "management-cluster"}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+
+	g.flux.EXPECT().DeleteSystemSecret(g.ctx, cluster, "flux-system").Return(nil)
+	g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, clusterSpec.FluxConfig).Return(nil)
+	g.flux.EXPECT().ForceReconcile(g.ctx, cluster, "flux-system").Return(errors.New("error from client"))
+
+	g.Expect(g.gitOpsFlux.RotateCredentials(g.ctx, cluster, clusterSpec)).To(MatchError(ContainSubstring("reconciling flux: error from client")))
+}
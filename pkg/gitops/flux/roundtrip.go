@@ -0,0 +1,148 @@
+package flux
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// CommittedClusterConfigMismatchError is returned when the cluster config committed to the repository
+// doesn't parse back to the same objects EKS-A generated it from.
+type CommittedClusterConfigMismatchError struct {
+	File    string
+	Objects []string
+}
+
+func (e *CommittedClusterConfigMismatchError) Error() string {
+	return fmt.Sprintf("committed cluster config %s does not match the cluster spec, objects differ: %s", e.File, strings.Join(e.Objects, ", "))
+}
+
+// WithVerifyCommittedClusterConfig makes InstallGitOps re-parse the committed eksa-cluster.yaml of a self-managed cluster
+// and compare it to the spec it was generated from, failing if they diverge. It's disabled by default since it
+// requires reading and parsing the whole config again.
+func WithVerifyCommittedClusterConfig() Opt {
+	return func(f *Flux) {
+		f.verifyCommittedClusterConfig = true
+	}
+}
+
+// verifyCommittedClusterConfig checks the committed cluster config round-trips back to the in-memory spec.
+// Only objects written by the cluster marshaller are compared, and only their spec, since metadata
+// is stripped on purpose when generating the file.
+func (fc *fluxForCluster) verifyCommittedClusterConfig() error {
+	file := path.Join(fc.eksaSystemDir(), clusterConfigFileName)
+	content, err := os.ReadFile(path.Join(fc.writer.Dir(), file))
+	if err != nil {
+		return fmt.Errorf("reading committed cluster config: %v", err)
+	}
+
+	parsed, err := cluster.ParseConfig(content)
+	if err != nil {
+		return fmt.Errorf("parsing committed cluster config %s: %v", file, err)
+	}
+
+	diffs := diffObjectSpecs(fc.committedObjects(), append(parsed.ChildObjects(), parsed.Cluster))
+	if len(diffs) > 0 {
+		return &CommittedClusterConfigMismatchError{File: file, Objects: diffs}
+	}
+
+	logger.V(3).Info("Verified committed cluster config matches the cluster spec", "file", file)
+	return nil
+}
+
+// committedObjects returns the in-memory objects that MarshalClusterSpec writes to the cluster config file.
+func (fc *fluxForCluster) committedObjects() []kubernetes.Object {
+	spec := fc.clusterSpec
+	objs := []kubernetes.Object{spec.Cluster}
+	objs = appendObjects(objs, fc.datacenterConfig)
+	for _, m := range fc.machineConfigs {
+		objs = appendObjects(objs, m)
+	}
+
+	if spec.GitOpsConfig != nil {
+		objs = append(objs, spec.GitOpsConfig)
+	} else if spec.FluxConfig != nil {
+		objs = append(objs, spec.FluxConfig)
+	}
+	if spec.OIDCConfig != nil {
+		objs = append(objs, spec.OIDCConfig)
+	}
+	if spec.AWSIamConfig != nil {
+		objs = append(objs, spec.AWSIamConfig)
+	}
+	for _, t := range spec.TinkerbellTemplateConfigs {
+		objs = append(objs, t)
+	}
+	for _, p := range spec.SnowIPPools {
+		objs = append(objs, p)
+	}
+	return objs
+}
+
+func appendObjects(objs []kubernetes.Object, elems ...interface{}) []kubernetes.Object {
+	for _, e := range elems {
+		if o, ok := e.(kubernetes.Object); ok && !reflect.ValueOf(o).IsNil() {
+			objs = append(objs, o)
+		}
+	}
+	return objs
+}
+
+// diffObjectSpecs matches objects by type and name and returns the sorted keys of the ones
+// that are missing on either side or whose spec is not semantically equal.
+func diffObjectSpecs(want, got []kubernetes.Object) []string {
+	gotByKey := make(map[string]kubernetes.Object, len(got))
+	for _, o := range got {
+		gotByKey[objectKey(o)] = o
+	}
+
+	var diffs []string
+	for _, w := range want {
+		key := objectKey(w)
+		g, ok := gotByKey[key]
+		if !ok {
+			diffs = append(diffs, key+" (missing)")
+			continue
+		}
+		delete(gotByKey, key)
+
+		if !equality.Semantic.DeepEqual(objectSpec(w), objectSpec(g)) {
+			diffs = append(diffs, key)
+		}
+	}
+
+	for key := range gotByKey {
+		diffs = append(diffs, key+" (unexpected)")
+	}
+
+	sort.Strings(diffs)
+	return diffs
+}
+
+// objectKey identifies an object by kind and name. Objects built in memory don't always have their TypeMeta set,
+// so it falls back to the type name, which matches the kind for all EKS-A API types.
+func objectKey(o kubernetes.Object) string {
+	kind := o.GetObjectKind().GroupVersionKind().Kind
+	if kind == "" {
+		kind = reflect.TypeOf(o).Elem().Name()
+	}
+	return fmt.Sprintf("%s/%s", kind, o.GetName())
+}
+
+// objectSpec returns the value of the Spec field of an API object, or nil if it doesn't have one.
+func objectSpec(o kubernetes.Object) interface{} {
+	v := reflect.ValueOf(o).Elem().FieldByName("Spec")
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
@@ -0,0 +1,112 @@
+package flux_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// divergingMachineConfig simulates a marshaller bug by writing a different template than the one in memory.
+type divergingMachineConfig struct {
+	*v1alpha1.VSphereMachineConfig
+}
+
+func (m *divergingMachineConfig) Marshallable() v1alpha1.Marshallable {
+	c := m.VSphereMachineConfig.DeepCopy()
+	c.Spec.Template = "/SDDC-Datacenter/vm/Templates/diverged"
+	return c.Marshallable()
+}
+
+func roundTripDatacenterConfig(clusterName string) *v1alpha1.VSphereDatacenterConfig {
+	d := datacenterConfig(clusterName)
+	d.APIVersion = v1alpha1.SchemeBuilder.GroupVersion.String()
+	return d
+}
+
+func roundTripMachineConfig(clusterName string) *v1alpha1.VSphereMachineConfig {
+	m := machineConfig(clusterName)
+	m.APIVersion = v1alpha1.SchemeBuilder.GroupVersion.String()
+	return m
+}
+
+func roundTripClusterSpec(t *testing.T, clusterName string) *cluster.Spec {
+	clusterConfig := v1alpha1.NewCluster(clusterName)
+	clusterConfig.Spec.DatacenterRef = v1alpha1.Ref{Kind: v1alpha1.VSphereDatacenterKind, Name: clusterName}
+	clusterConfig.Spec.ControlPlaneConfiguration.MachineGroupRef = &v1alpha1.Ref{Kind: v1alpha1.VSphereMachineConfigKind, Name: clusterName}
+	return newClusterSpec(t, clusterConfig, "")
+}
+
+func expectInstallGitOpsWithVerification(g fluxTest, cluster *types.Cluster, clusterSpec *cluster.Spec) {
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: clusterSpec.FluxConfig.Spec.Github.Repository}, nil)
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add("clusters").Return(nil)
+	g.git.EXPECT().Add(pathClaimFile(clusterSpec.Cluster.Name)).Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.git.EXPECT().Pull(g.ctx, clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, clusterSpec.FluxConfig).Return(nil)
+}
+
+func TestInstallGitOpsVerifyCommittedClusterConfig(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := roundTripClusterSpec(t, clusterName)
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithVerifyCommittedClusterConfig())
+	expectInstallGitOpsWithVerification(g, cluster, clusterSpec)
+
+	g.Expect(f.InstallGitOps(g.ctx, cluster, clusterSpec, roundTripDatacenterConfig(clusterName), []providers.MachineConfig{roundTripMachineConfig(clusterName)})).To(Succeed())
+}
+
+func TestInstallGitOpsVerifyCommittedClusterConfigDiverges(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := roundTripClusterSpec(t, clusterName)
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithVerifyCommittedClusterConfig())
+	expectInstallGitOpsWithVerification(g, cluster, clusterSpec)
+	machineConfigs := []providers.MachineConfig{&divergingMachineConfig{roundTripMachineConfig(clusterName)}}
+
+	err := f.InstallGitOps(g.ctx, cluster, clusterSpec, roundTripDatacenterConfig(clusterName), machineConfigs)
+	var mismatchErr *flux.CommittedClusterConfigMismatchError
+	g.Expect(errors.As(err, &mismatchErr)).To(BeTrue())
+	g.Expect(mismatchErr.Objects).To(ConsistOf("VSphereMachineConfig/management-cluster"))
+	g.Expect(err).To(MatchError(ContainSubstring("committed cluster config clusters/management-cluster/management-cluster/eksa-system/eksa-cluster.yaml does not match the cluster spec")))
+}
+
+func TestInstallGitOpsVerifyCommittedClusterConfigMissingObject(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := roundTripClusterSpec(t, clusterName)
+	// the datacenter is written to the file but the cluster references a different one, so it's not parsed back
+	clusterSpec.Cluster.Spec.DatacenterRef.Name = "other-datacenter"
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithVerifyCommittedClusterConfig())
+	expectInstallGitOpsWithVerification(g, cluster, clusterSpec)
+
+	err := f.InstallGitOps(g.ctx, cluster, clusterSpec, roundTripDatacenterConfig(clusterName), []providers.MachineConfig{roundTripMachineConfig(clusterName)})
+	var mismatchErr *flux.CommittedClusterConfigMismatchError
+	g.Expect(errors.As(err, &mismatchErr)).To(BeTrue())
+	g.Expect(mismatchErr.Objects).To(ConsistOf("VSphereDatacenterConfig/management-cluster (missing)"))
+}
+
+func TestInstallGitOpsVerifyCommittedClusterConfigDisabled(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := roundTripClusterSpec(t, clusterName)
+	expectInstallGitOpsWithVerification(g, cluster, clusterSpec)
+	machineConfigs := []providers.MachineConfig{&divergingMachineConfig{roundTripMachineConfig(clusterName)}}
+
+	g.Expect(g.gitOpsFlux.InstallGitOps(g.ctx, cluster, clusterSpec, roundTripDatacenterConfig(clusterName), machineConfigs)).To(Succeed())
+}
@@ -0,0 +1,37 @@
+package flux
+
+import (
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+)
+
+const (
+	sealedSecretsChartName = "sealed-secrets"
+	// sealedSecretsReleaseName is the name of the controller kubeseal looks for by default.
+	sealedSecretsReleaseName = "sealed-secrets-controller"
+)
+
+// WriteFluxSealedSecrets writes a HelmRepository and a HelmRelease installing the sealed-secrets controller with the
+// helm-controller, so SealedSecrets can be committed to the repository as soon as flux is bootstrapped. It does nothing
+// if sealed secrets aren't configured.
+func (g *FileGenerator) WriteFluxSealedSecrets(clusterSpec *cluster.Spec) error {
+	s := clusterSpec.FluxConfig.Spec.SealedSecrets
+	if s == nil {
+		return nil
+	}
+
+	values := map[string]interface{}{
+		"Name":            sealedSecretsChartName,
+		"Namespace":       clusterSpec.FluxConfig.Spec.SystemNamespace,
+		"ReleaseName":     sealedSecretsReleaseName,
+		"RepositoryUrl":   s.RepositoryUrl,
+		"TargetNamespace": s.Namespace,
+		"Version":         s.Version,
+	}
+	if path, err := g.fluxTemplater.WriteToFile(fluxSealedSecretsContent, values, fluxSealedSecretsFileName, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("creating flux-system sealed secrets manifest file into %s: %v", path, err)
+	}
+	return nil
+}
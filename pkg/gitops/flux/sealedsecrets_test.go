@@ -0,0 +1,44 @@
+package flux_test
+
+import (
+	"errors"
+	"path"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+)
+
+func TestFileGeneratorWriteFluxSystemFilesWithSealedSecretsContent(t *testing.T) {
+	g := NewWithT(t)
+	_, w := test.NewWriter(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig.Spec.SealedSecrets = &v1alpha1.FluxSealedSecretsConfig{Version: "2.7.x"}
+	clusterSpec.FluxConfig.SetDefaults()
+
+	gen := flux.NewFileGenerator()
+	g.Expect(gen.Init(w, "eksa-system", "flux-system")).To(Succeed())
+	g.Expect(gen.WriteFluxSystemFiles(clusterSpec)).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "flux-system", "eksa-sealed-secrets.yaml"), "./testdata/eksa-sealed-secrets.yaml")
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "flux-system", "kustomization.yaml"), "./testdata/flux-kustomization-sealed-secrets.yaml")
+}
+
+func TestFileGeneratorWriteFluxSealedSecretsError(t *testing.T) {
+	tt := newFileGeneratorTest(t)
+	tt.clusterSpec.FluxConfig.Spec.SealedSecrets = &v1alpha1.FluxSealedSecretsConfig{}
+
+	tt.t.EXPECT().WriteToFile(gomock.Any(), gomock.Any(), "eksa-sealed-secrets.yaml", gomock.Any()).Return("", errors.New("error in write sealed secrets"))
+
+	tt.Expect(tt.g.WriteFluxSealedSecrets(tt.clusterSpec)).To(MatchError(ContainSubstring("error in write sealed secrets")))
+}
+
+func TestFileGeneratorWriteFluxSealedSecretsSkip(t *testing.T) {
+	tt := newFileGeneratorTest(t)
+
+	tt.Expect(tt.g.WriteFluxSealedSecrets(tt.clusterSpec)).To(Succeed())
+}
@@ -0,0 +1,42 @@
+package flux
+
+import (
+	"errors"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+// GitSettings are the git repository settings EKS-A uses for the FluxConfig of a cluster,
+// with all the defaults and normalization applied.
+type GitSettings struct {
+	Repository    string
+	Owner         string
+	Branch        string
+	Path          string
+	Namespace     string
+	EksaSystemDir string
+	FluxSystemDir string
+}
+
+// ResolveGitSettings returns the git settings EKS-A uses for the FluxConfig in the cluster spec, so they can be
+// consumed by tools outside EKS-A without reimplementing their normalization.
+func ResolveGitSettings(clusterSpec *cluster.Spec) (GitSettings, error) {
+	if clusterSpec.FluxConfig == nil {
+		return GitSettings{}, errors.New("resolving git settings: cluster spec doesn't have a FluxConfig")
+	}
+
+	fc, err := newFluxForCluster(nil, clusterSpec, nil, nil)
+	if err != nil {
+		return GitSettings{}, err
+	}
+
+	return GitSettings{
+		Repository:    fc.repository(),
+		Owner:         fc.owner(),
+		Branch:        fc.branch(),
+		Path:          fc.path(),
+		Namespace:     fc.namespace(),
+		EksaSystemDir: fc.eksaSystemDir(),
+		FluxSystemDir: fc.fluxSystemDir(),
+	}, nil
+}
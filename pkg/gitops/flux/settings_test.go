@@ -0,0 +1,52 @@
+package flux_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+)
+
+func TestResolveGitSettingsGithub(t *testing.T) {
+	g := NewWithT(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+
+	settings, err := flux.ResolveGitSettings(clusterSpec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(settings).To(Equal(flux.GitSettings{
+		Repository:    "testRepo",
+		Owner:         "mFolwer",
+		Branch:        "testBranch",
+		Path:          "clusters/management-cluster",
+		Namespace:     "flux-system",
+		EksaSystemDir: "clusters/management-cluster/management-cluster/eksa-system",
+		FluxSystemDir: "clusters/management-cluster/flux-system",
+	}))
+}
+
+func TestResolveGitSettingsGit(t *testing.T) {
+	g := NewWithT(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "fleet/{{.Name}}")
+	clusterSpec.FluxConfig.Spec.Github = nil
+	clusterSpec.FluxConfig.Spec.Git = &v1alpha1.GitProviderConfig{
+		RepositoryUrl: "ssh://git@example.com/org/fleet-infra.git",
+	}
+
+	settings, err := flux.ResolveGitSettings(clusterSpec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(settings.Repository).To(Equal("fleet-infra"))
+	g.Expect(settings.Owner).To(BeEmpty())
+	g.Expect(settings.Path).To(Equal("fleet/management-cluster"))
+	g.Expect(settings.EksaSystemDir).To(Equal("fleet/management-cluster/management-cluster/eksa-system"))
+}
+
+func TestResolveGitSettingsNoFluxConfig(t *testing.T) {
+	g := NewWithT(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.FluxConfig = nil
+
+	_, err := flux.ResolveGitSettings(clusterSpec)
+	g.Expect(err).To(MatchError(ContainSubstring("cluster spec doesn't have a FluxConfig")))
+}
@@ -0,0 +1,95 @@
+package flux
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/git/providers/azuredevops"
+	"github.com/aws/eks-anywhere/pkg/git/providers/bitbucket"
+	"github.com/aws/eks-anywhere/pkg/git/providers/codecommit"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitea"
+	"github.com/aws/eks-anywhere/pkg/git/providers/github"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab"
+)
+
+// WriteFluxSources writes a GitRepository and a Kustomization for each additional FluxConfig of the cluster, so the
+// flux bootstrapped from the repository of the cluster config also reconciles the additional repositories. They're
+// named after their FluxConfig. It does nothing if the cluster has no additional FluxConfigs.
+func (g *FileGenerator) WriteFluxSources(clusterSpec *cluster.Spec) error {
+	if len(clusterSpec.AdditionalFluxConfigs) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(clusterSpec.AdditionalFluxConfigs))
+	for name := range clusterSpec.AdditionalFluxConfigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sources := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		source, err := additionalSourceValues(clusterSpec.AdditionalFluxConfigs[name])
+		if err != nil {
+			return err
+		}
+		sources = append(sources, source)
+	}
+
+	values := map[string]interface{}{
+		"Namespace": clusterSpec.FluxConfig.Spec.SystemNamespace,
+		"Sources":   sources,
+	}
+	if path, err := g.fluxTemplater.WriteToFile(fluxSourcesContent, values, fluxSourcesFileName, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("creating flux-system additional sources manifest file into %s: %v", path, err)
+	}
+	return nil
+}
+
+func additionalSourceValues(fluxConfig *v1alpha1.FluxConfig) (map[string]interface{}, error) {
+	url, err := additionalSourceUrl(fluxConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]interface{}{
+		"Name":      fluxConfig.Name,
+		"Url":       url,
+		"Branch":    fluxConfig.Spec.Branch,
+		"Path":      fluxConfig.Spec.ClusterConfigPath,
+		"SecretRef": fluxConfig.Spec.CredentialsSecretRef,
+	}
+	if m := fluxConfig.Spec.MultiTenancy; m != nil {
+		values["ServiceAccountName"] = m.ServiceAccountName
+		values["TargetNamespace"] = m.TargetNamespace
+	}
+	addSyncValues(values, fluxConfig.Spec.Sync)
+	return values, nil
+}
+
+// additionalSourceUrl returns the url flux clones the repository of an additional FluxConfig from.
+func additionalSourceUrl(fluxConfig *v1alpha1.FluxConfig) (string, error) {
+	spec := fluxConfig.Spec
+	switch {
+	case spec.Github != nil:
+		return github.RepoUrl(github.Hostname(spec.Github), spec.Github.Owner, spec.Github.Repository), nil
+	case spec.Gitlab != nil:
+		return gitlab.RepoUrl(gitlab.Hostname(spec.Gitlab), spec.Gitlab.Owner, spec.Gitlab.Repository), nil
+	case spec.BitbucketServer != nil:
+		c := spec.BitbucketServer
+		return bitbucket.RepoUrl(c.Hostname, c.Owner, c.Repository, c.Personal), nil
+	case spec.AzureDevOps != nil:
+		c := spec.AzureDevOps
+		return azuredevops.RepoUrl(c.Organization, c.Project, c.Repository), nil
+	case spec.Gitea != nil:
+		return gitea.RepoUrl(spec.Gitea.Hostname, spec.Gitea.Owner, spec.Gitea.Repository), nil
+	case spec.CodeCommit != nil:
+		return codecommit.RepoUrl(spec.CodeCommit.Region, spec.CodeCommit.Repository), nil
+	case spec.Git != nil:
+		return spec.Git.RepositoryUrl, nil
+	default:
+		return "", fmt.Errorf("FluxConfig %s must use a Git repository to be an additional source", fluxConfig.Name)
+	}
+}
@@ -0,0 +1,81 @@
+package flux_test
+
+import (
+	"errors"
+	"path"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+)
+
+func additionalFluxConfig(name string, spec v1alpha1.FluxConfigSpec) *v1alpha1.FluxConfig {
+	c := &v1alpha1.FluxConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       spec,
+	}
+	c.SetDefaults()
+	return c
+}
+
+func TestFileGeneratorWriteFluxSystemFilesWithSourcesContent(t *testing.T) {
+	g := NewWithT(t)
+	_, w := test.NewWriter(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.AdditionalFluxConfigs = map[string]*v1alpha1.FluxConfig{
+		"apps": additionalFluxConfig("apps", v1alpha1.FluxConfigSpec{
+			ClusterConfigPath:    "clusters/management-cluster",
+			Github:               &v1alpha1.GithubProviderConfig{Owner: "janedoe", Repository: "apps"},
+			CredentialsSecretRef: "apps-credentials",
+			MultiTenancy:         &v1alpha1.FluxMultiTenancyConfig{ServiceAccountName: "apps", TargetNamespace: "apps"},
+		}),
+		"addons": additionalFluxConfig("addons", v1alpha1.FluxConfigSpec{
+			ClusterConfigPath: "addons",
+			Branch:            "release",
+			Git:               &v1alpha1.GitProviderConfig{RepositoryUrl: "ssh://git@example.com/platform/addons.git"},
+			Sync:              &v1alpha1.FluxSyncConfig{Interval: "5m0s", Timeout: "2m0s"},
+		}),
+	}
+
+	gen := flux.NewFileGenerator()
+	g.Expect(gen.Init(w, "eksa-system", "flux-system")).To(Succeed())
+	g.Expect(gen.WriteFluxSystemFiles(clusterSpec)).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "flux-system", "eksa-sources.yaml"), "./testdata/eksa-sources.yaml")
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "flux-system", "kustomization.yaml"), "./testdata/flux-kustomization-sources.yaml")
+}
+
+func TestFileGeneratorWriteFluxSourcesError(t *testing.T) {
+	tt := newFileGeneratorTest(t)
+	tt.clusterSpec.AdditionalFluxConfigs = map[string]*v1alpha1.FluxConfig{
+		"apps": additionalFluxConfig("apps", v1alpha1.FluxConfigSpec{
+			Github: &v1alpha1.GithubProviderConfig{Owner: "janedoe", Repository: "apps"},
+		}),
+	}
+
+	tt.t.EXPECT().WriteToFile(gomock.Any(), gomock.Any(), "eksa-sources.yaml", gomock.Any()).Return("", errors.New("error in write sources"))
+
+	tt.Expect(tt.g.WriteFluxSources(tt.clusterSpec)).To(MatchError(ContainSubstring("error in write sources")))
+}
+
+func TestFileGeneratorWriteFluxSourcesNotGit(t *testing.T) {
+	tt := newFileGeneratorTest(t)
+	tt.clusterSpec.AdditionalFluxConfigs = map[string]*v1alpha1.FluxConfig{
+		"apps": additionalFluxConfig("apps", v1alpha1.FluxConfigSpec{
+			OCIRepository: &v1alpha1.OCIRepositoryConfig{Url: "oci://public.ecr.aws/apps"},
+		}),
+	}
+
+	tt.Expect(tt.g.WriteFluxSources(tt.clusterSpec)).To(MatchError("FluxConfig apps must use a Git repository to be an additional source"))
+}
+
+func TestFileGeneratorWriteFluxSourcesSkip(t *testing.T) {
+	tt := newFileGeneratorTest(t)
+
+	tt.Expect(tt.g.WriteFluxSources(tt.clusterSpec)).To(Succeed())
+}
@@ -0,0 +1,22 @@
+package flux
+
+import "github.com/aws/eks-anywhere/pkg/logger"
+
+// WithSparseCheckout makes the local repository only materialize the cluster config path of the cluster the
+// operation is for, instead of every file of the repository. The files outside of it are kept in the commits.
+func WithSparseCheckout() Opt {
+	return func(f *Flux) {
+		f.sparseCheckout = true
+	}
+}
+
+// setupSparseCheckout restricts the worktree of the local repository to the cluster config path when sparse
+// checkout is enabled. It must be called before the repository is cloned or its branch checked out.
+func (fc *fluxForCluster) setupSparseCheckout() {
+	if !fc.sparseCheckout {
+		return
+	}
+
+	logger.V(4).Info("Restricting local repository checkout to cluster config path", "path", fc.path())
+	fc.gitClient.SetSparseCheckoutDirectories(fc.path())
+}
@@ -0,0 +1,142 @@
+package flux
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// readClusterConfigFile returns the content of the cluster config file in the local repository, or nil if it doesn't
+// exist yet.
+func (fc *fluxForCluster) readClusterConfigFile() ([]byte, error) {
+	content, err := os.ReadFile(path.Join(fc.writer.Dir(), fc.eksaSystemDir(), clusterConfigFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading cluster config file: %v", err)
+	}
+	return content, nil
+}
+
+// clusterConfigChanges returns a human readable summary of the spec fields changed between the previous and the
+// updated cluster config files, like the node counts and versions, and of the objects added and removed. It's empty
+// when there's no previous file or the specs are the same. The summary is only informative, files that can't be
+// parsed aren't summarized.
+func clusterConfigChanges(previous, updated []byte) (string, error) {
+	if len(previous) == 0 {
+		return "", nil
+	}
+
+	previousObjects, err := clusterConfigObjects(previous)
+	if err != nil {
+		logger.V(3).Info("Could not parse the previous cluster config, skipping the summary of the changes", "error", err)
+		return "", nil
+	}
+
+	updatedObjects, err := clusterConfigObjects(updated)
+	if err != nil {
+		logger.V(3).Info("Could not parse the updated cluster config, skipping the summary of the changes", "error", err)
+		return "", nil
+	}
+
+	b := &strings.Builder{}
+	for _, key := range sortedObjectKeys(updatedObjects) {
+		p, ok := previousObjects[key]
+		if !ok {
+			fmt.Fprintf(b, "%s: added\n", key)
+			continue
+		}
+
+		fields, err := specChanges(p, updatedObjects[key])
+		if err != nil {
+			return "", err
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(b, "%s:\n", key)
+		for _, f := range fields {
+			fmt.Fprintf(b, "  %s: %s -> %s\n", f.Path, changeValue(f.Git), changeValue(f.Live))
+		}
+	}
+
+	for _, key := range sortedObjectKeys(previousObjects) {
+		if _, ok := updatedObjects[key]; !ok {
+			fmt.Fprintf(b, "%s: removed\n", key)
+		}
+	}
+
+	if b.Len() == 0 {
+		return "", nil
+	}
+	return "Cluster configuration changes:\n" + strings.TrimSuffix(b.String(), "\n"), nil
+}
+
+// specChanges returns the spec fields set in either object whose value is different, in the previous object as
+// FieldDrift.Git and in the updated one as FieldDrift.Live, sorted by path.
+func specChanges(previous, updated kubernetes.Object) ([]FieldDrift, error) {
+	previousSpec, err := unstructuredSpec(previous)
+	if err != nil {
+		return nil, err
+	}
+	updatedSpec, err := unstructuredSpec(updated)
+	if err != nil {
+		return nil, err
+	}
+
+	// diffFields only compares the fields set in its first value, so the fields only set in the updated spec are
+	// found by comparing it with the previous one.
+	changes := map[string]FieldDrift{}
+	for _, f := range diffFields("spec", previousSpec, updatedSpec) {
+		changes[f.Path] = f
+	}
+	for _, f := range diffFields("spec", updatedSpec, previousSpec) {
+		if _, ok := changes[f.Path]; !ok {
+			changes[f.Path] = FieldDrift{Path: f.Path, Git: f.Live, Live: f.Git}
+		}
+	}
+
+	fields := make([]FieldDrift, 0, len(changes))
+	for _, f := range changes {
+		fields = append(fields, f)
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Path < fields[j].Path })
+	return fields, nil
+}
+
+func clusterConfigObjects(content []byte) (map[string]kubernetes.Object, error) {
+	parsed, err := cluster.ParseConfig(content)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := map[string]kubernetes.Object{}
+	for _, o := range append([]kubernetes.Object{parsed.Cluster}, parsed.ChildObjects()...) {
+		objects[objectKey(o)] = o
+	}
+	return objects, nil
+}
+
+func sortedObjectKeys(objects map[string]kubernetes.Object) []string {
+	keys := make([]string, 0, len(objects))
+	for k := range objects {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func changeValue(v string) string {
+	if v == "" {
+		return "unset"
+	}
+	return v
+}
@@ -0,0 +1,45 @@
+package flux_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/providers"
+)
+
+func TestUpdateGitEksaSpecChangesSummary(t *testing.T) {
+	tt := newDriftTest(t)
+	clusterSpec := tt.liveClusterSpec()
+	clusterSpec.Cluster.TypeMeta = tt.committed.Cluster.TypeMeta
+	clusterSpec.Cluster.Spec.KubernetesVersion = v1alpha1.Kube121
+	clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count = 3
+	clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf = &v1alpha1.ResolvConf{Path: "/etc/my-resolv.conf"}
+
+	tt.expectSync()
+	tt.git.EXPECT().Add("clusters/management-cluster/management-cluster/eksa-system").Return(nil)
+	tt.git.EXPECT().Commit(updateCommitMessage + `
+
+Cluster configuration changes:
+Cluster/management-cluster:
+  spec.clusterNetwork.dns.resolvConf: unset -> {"path":"/etc/my-resolv.conf"}
+  spec.controlPlaneConfiguration.count: unset -> 3
+  spec.kubernetesVersion: "1.19" -> "1.21"`).Return(nil)
+	tt.git.EXPECT().Push(tt.ctx).Return(nil)
+
+	tt.Expect(tt.gitOpsFlux.UpdateGitEksaSpec(tt.ctx, clusterSpec, clusterSpec.VSphereDatacenter, []providers.MachineConfig{clusterSpec.VSphereMachineConfigs["management-cluster"]})).To(Succeed())
+}
+
+func TestUpdateGitEksaSpecChangesSummaryNoChanges(t *testing.T) {
+	tt := newDriftTest(t)
+	clusterSpec := tt.liveClusterSpec()
+	clusterSpec.Cluster.TypeMeta = tt.committed.Cluster.TypeMeta
+
+	tt.expectSync()
+	tt.git.EXPECT().Add("clusters/management-cluster/management-cluster/eksa-system").Return(nil)
+	tt.git.EXPECT().Commit(updateCommitMessage).Return(nil)
+	tt.git.EXPECT().Push(tt.ctx).Return(nil)
+
+	tt.Expect(tt.gitOpsFlux.UpdateGitEksaSpec(tt.ctx, clusterSpec, clusterSpec.VSphereDatacenter, []providers.MachineConfig{clusterSpec.VSphereMachineConfigs["management-cluster"]})).To(Succeed())
+}
@@ -0,0 +1,32 @@
+package flux
+
+import (
+	"fmt"
+	"path"
+)
+
+// spokeDir is the directory of the hub-and-spoke files of a workload cluster. Since it has a kustomization listing
+// the eksa-system directory and the spoke Kustomization, the flux-system Kustomization doesn't reconcile the manifests
+// directory below, which is reconciled into the workload cluster by the spoke Kustomization instead.
+func (fc *fluxForCluster) spokeDir() string {
+	return path.Dir(fc.eksaSystemDir())
+}
+
+// usesHubAndSpoke returns true if the cluster is a workload cluster whose manifests are reconciled by the flux of the
+// management cluster.
+func (fc *fluxForCluster) usesHubAndSpoke() bool {
+	return fc.clusterSpec.FluxConfig.Spec.HubAndSpoke && fc.clusterSpec.Cluster.IsManaged()
+}
+
+// writeSpokeFiles writes the hub-and-spoke files of the workload cluster. Like the tenant files, they're only written
+// when the cluster config is first committed, so the manifests committed by the user are never overwritten.
+func (fc *fluxForCluster) writeSpokeFiles(g *FileGenerator) error {
+	if err := g.InitSpoke(fc.writer, fc.spokeDir()); err != nil {
+		return err
+	}
+
+	if err := g.WriteSpokeFiles(fc.clusterSpec, fc.eksaSystemDir()); err != nil {
+		return fmt.Errorf("writing spoke files: %v", err)
+	}
+	return nil
+}
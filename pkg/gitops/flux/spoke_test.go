@@ -0,0 +1,96 @@
+package flux_test
+
+import (
+	"path"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	gitFactory "github.com/aws/eks-anywhere/pkg/git/factory"
+	gitMocks "github.com/aws/eks-anywhere/pkg/git/mocks"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+func TestInstallGitOpsOnWorkloadClusterWithHubAndSpoke(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "workload-cluster"
+	clusterConfig := v1alpha1.NewCluster(clusterName)
+	clusterConfig.SetManagedBy("management-cluster")
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+	clusterSpec.FluxConfig.Spec.HubAndSpoke = true
+
+	g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, clusterSpec.FluxConfig)
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: clusterSpec.FluxConfig.Spec.Github.Repository}, nil)
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add("clusters").Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.git.EXPECT().Pull(g.ctx, clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+
+	g.Expect(g.gitOpsFlux.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+
+	spokeDir := path.Join(g.writer.Dir(), "clusters/management-cluster/workload-cluster")
+	test.AssertFilesEquals(t, path.Join(spokeDir, "eksa-spoke.yaml"), "./testdata/eksa-spoke.yaml")
+	test.AssertFilesEquals(t, path.Join(spokeDir, "kustomization.yaml"), "./testdata/spoke-kustomization.yaml")
+	test.AssertFilesEquals(t, path.Join(spokeDir, "manifests", "kustomization.yaml"), "./testdata/spoke-manifests-kustomization.yaml")
+}
+
+func TestInstallGitOpsOnManagementClusterWithHubAndSpoke(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	clusterSpec.FluxConfig.Spec.HubAndSpoke = true
+
+	g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, clusterSpec.FluxConfig)
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: clusterSpec.FluxConfig.Spec.Github.Repository}, nil)
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add("clusters").Return(nil)
+	g.git.EXPECT().Add(pathClaimFile(clusterName)).Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.git.EXPECT().Pull(g.ctx, clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+
+	g.Expect(g.gitOpsFlux.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+
+	clusterDir := path.Join(g.writer.Dir(), "clusters/management-cluster/management-cluster")
+	g.Expect(path.Join(clusterDir, "eksa-spoke.yaml")).NotTo(BeAnExistingFile())
+	g.Expect(path.Join(clusterDir, "manifests")).NotTo(BeAnExistingFile())
+}
+
+func TestCleanupGitRepoWorkloadClusterWithHubAndSpoke(t *testing.T) {
+	g := newFluxTest(t)
+	mockCtrl := gomock.NewController(t)
+	clusterConfig := v1alpha1.NewCluster("workload-cluster")
+	clusterConfig.SetManagedBy("management-cluster")
+	expectedClusterPath := "clusters/management-cluster/workload-cluster"
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+	clusterSpec.FluxConfig.Spec.HubAndSpoke = true
+
+	gitClient := gitMocks.NewMockClient(mockCtrl)
+	gitClient.EXPECT().Clone(g.ctx).Return(nil)
+	gitClient.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	gitClient.EXPECT().Remove(expectedClusterPath).Return(nil)
+	gitClient.EXPECT().Commit(test.OfType("string")).Return(nil)
+	gitClient.EXPECT().Push(g.ctx).Return(nil)
+
+	_, w := test.NewWriter(t)
+	_, err := w.WithDir(expectedClusterPath)
+	g.Expect(err).NotTo(HaveOccurred())
+	f := flux.NewFlux(nil, nil, &gitFactory.GitTools{
+		Provider: gitMocks.NewMockProviderClient(mockCtrl),
+		Client:   gitClient,
+		Writer:   w,
+	}, nil)
+
+	g.Expect(f.CleanupGitRepo(g.ctx, clusterSpec)).To(Succeed())
+}
@@ -0,0 +1,79 @@
+package flux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// WithUpdateCommitSquash makes UpdateGitEksaSpec amend the last commit of the branch instead of creating a new one,
+// as long as that commit is an EKS-A cluster config update commit created within the given window. The amended commit
+// is then force pushed to the current branch. A window lower or equal to zero disables squashing, which is the default.
+func WithUpdateCommitSquash(window time.Duration) Opt {
+	return func(f *Flux) {
+		f.updateSquashWindow = window
+	}
+}
+
+// squashableUpdateCommit returns the hash of the last commit in the branch if it can be amended with a new update with
+// the message, or false otherwise. Only update commits created by EKS-A with the same subject within the configured
+// window are ever amended, so commits from other authors or older history are never rewritten.
+func (fc *fluxForCluster) squashableUpdateCommit(msg string) (string, bool) {
+	if fc.updateSquashWindow <= 0 {
+		return "", false
+	}
+
+	last, err := fc.gitClient.LastCommit()
+	if err != nil {
+		logger.V(3).Info("Could not get last commit, creating a new commit instead of squashing", "error", err)
+		return "", false
+	}
+
+	if last.Author != fc.commitAuthor() || commitSubject(last.Message) != commitSubject(msg) {
+		logger.V(4).Info("Last commit is not an EKS-A update commit, creating a new commit", "hash", last.Hash)
+		return "", false
+	}
+
+	if time.Since(last.When) > fc.updateSquashWindow {
+		logger.V(4).Info("Last EKS-A update commit is older than the squash window, creating a new commit", "hash", last.Hash, "window", fc.updateSquashWindow)
+		return "", false
+	}
+
+	logger.V(3).Info("Squashing update into last EKS-A update commit", "hash", last.Hash)
+	return last.Hash, true
+}
+
+// commitAuthor returns the author of the commits created by EKS-A, the configured git author or git.CommitAuthor by default.
+func (f *Flux) commitAuthor() string {
+	if f.cliConfig != nil && f.cliConfig.GitAuthorName != "" {
+		return f.cliConfig.GitAuthorName
+	}
+	return git.CommitAuthor
+}
+
+// amendAndForcePushToRemoteRepo amends the last commit with the staged changes and force pushes it, with a lease on
+// the amended commit. When another operation pushed to the branch in the meantime, the amend is undone and the changes
+// are pushed in a new commit on top of the remote branch instead, so the other commits aren't overwritten.
+func (f *Flux) amendAndForcePushToRemoteRepo(ctx context.Context, path, msg, amended string) error {
+	if err := f.gitClient.AmendCommit(msg); err != nil {
+		return fmt.Errorf("amending commit with %s to git: %v", path, err)
+	}
+
+	err := f.gitClient.ForcePush(ctx, amended)
+	var rejected *git.PushRejectedError
+	if errors.As(err, &rejected) {
+		logger.V(3).Info("Squashed commit push rejected, pushing a new commit instead", "branch", rejected.Branch, "hash", amended)
+		if err := f.gitClient.ResetSoft(amended); err != nil {
+			return fmt.Errorf("undoing amended commit with %s: %v", path, err)
+		}
+		return f.pushToRemoteRepo(ctx, path, msg)
+	}
+	if err != nil {
+		return fmt.Errorf("force pushing %s to git: %v", path, err)
+	}
+	return nil
+}
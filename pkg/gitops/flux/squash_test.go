@@ -0,0 +1,155 @@
+package flux_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	"github.com/aws/eks-anywhere/pkg/providers"
+)
+
+const updateCommitMessage = "Update commit of cluster configuration; generated by EKS-A CLI"
+
+func TestUpdateGitEksaSpecCommitSquash(t *testing.T) {
+	clusterName := "management-cluster"
+	eksaSystemDirPath := "clusters/management-cluster/management-cluster/eksa-system"
+
+	tests := []struct {
+		testName   string
+		window     time.Duration
+		lastCommit *git.Commit
+		lastErr    error
+		cliConfig  *config.CliConfig
+		wantAmend  bool
+	}{
+		{
+			testName:   "last update commit within window",
+			window:     time.Hour,
+			lastCommit: &git.Commit{Hash: "a1b2c3", Author: git.CommitAuthor, Message: updateCommitMessage, When: time.Now().Add(-time.Minute)},
+			wantAmend:  true,
+		},
+		{
+			testName:   "last update commit outside window",
+			window:     time.Hour,
+			lastCommit: &git.Commit{Author: git.CommitAuthor, Message: updateCommitMessage, When: time.Now().Add(-2 * time.Hour)},
+		},
+		{
+			testName:   "last commit from another author",
+			window:     time.Hour,
+			lastCommit: &git.Commit{Author: "someone", Message: updateCommitMessage, When: time.Now()},
+		},
+		{
+			testName:   "last update commit from configured author",
+			window:     time.Hour,
+			lastCommit: &git.Commit{Hash: "a1b2c3", Author: "service-account", Message: updateCommitMessage, When: time.Now()},
+			cliConfig:  &config.CliConfig{GitAuthorName: "service-account"},
+			wantAmend:  true,
+		},
+		{
+			testName:   "last update commit from default author with configured author",
+			window:     time.Hour,
+			lastCommit: &git.Commit{Author: git.CommitAuthor, Message: updateCommitMessage, When: time.Now()},
+			cliConfig:  &config.CliConfig{GitAuthorName: "service-account"},
+		},
+		{
+			testName:   "last commit is not an update commit",
+			window:     time.Hour,
+			lastCommit: &git.Commit{Author: git.CommitAuthor, Message: "Initial commit of cluster configuration; generated by EKS-A CLI", When: time.Now()},
+		},
+		{
+			testName: "last commit error",
+			window:   time.Hour,
+			lastErr:  errors.New("error in last commit"),
+		},
+		{
+			testName: "squash disabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := newFluxTest(t)
+			clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+			f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, tt.cliConfig, flux.WithUpdateCommitSquash(tt.window))
+
+			g.git.EXPECT().Clone(g.ctx).Return(nil)
+			g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+			g.git.EXPECT().Add(eksaSystemDirPath).Return(nil)
+			if tt.window > 0 {
+				g.git.EXPECT().LastCommit().Return(tt.lastCommit, tt.lastErr)
+			}
+			if tt.wantAmend {
+				g.git.EXPECT().AmendCommit(updateCommitMessage).Return(nil)
+				g.git.EXPECT().ForcePush(g.ctx, "a1b2c3").Return(nil)
+			} else {
+				g.git.EXPECT().Commit(updateCommitMessage).Return(nil)
+				g.git.EXPECT().Push(g.ctx).Return(nil)
+			}
+
+			g.Expect(f.UpdateGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+		})
+	}
+}
+
+func TestUpdateGitEksaSpecCommitSquashForcePushError(t *testing.T) {
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithUpdateCommitSquash(time.Hour))
+
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add("clusters/management-cluster/management-cluster/eksa-system").Return(nil)
+	g.git.EXPECT().LastCommit().Return(&git.Commit{Hash: "a1b2c3", Author: git.CommitAuthor, Message: updateCommitMessage, When: time.Now()}, nil)
+	g.git.EXPECT().AmendCommit(updateCommitMessage).Return(nil)
+	g.git.EXPECT().ForcePush(g.ctx, "a1b2c3").Return(errors.New("error in force push"))
+
+	err := f.UpdateGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})
+	g.Expect(err).To(MatchError(ContainSubstring("error in force push")))
+}
+
+func TestUpdateGitEksaSpecCommitSquashLeaseRejected(t *testing.T) {
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithUpdateCommitSquash(time.Hour))
+	rejected := &git.PushRejectedError{Branch: "testBranch", Err: errors.New("remote ref refs/heads/testBranch required to be a1b2c3 but is d4e5f6")}
+
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add("clusters/management-cluster/management-cluster/eksa-system").Return(nil)
+	g.git.EXPECT().LastCommit().Return(&git.Commit{Hash: "a1b2c3", Author: git.CommitAuthor, Message: updateCommitMessage, When: time.Now()}, nil)
+	g.git.EXPECT().AmendCommit(updateCommitMessage).Return(nil)
+	g.git.EXPECT().ForcePush(g.ctx, "a1b2c3").Return(rejected)
+	g.git.EXPECT().ResetSoft("a1b2c3").Return(nil)
+	g.git.EXPECT().Commit(updateCommitMessage).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(rejected)
+	g.git.EXPECT().RebaseOnRemote(g.ctx).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+
+	g.Expect(f.UpdateGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+}
+
+func TestUpdateGitEksaSpecCommitSquashLeaseRejectedResetError(t *testing.T) {
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithUpdateCommitSquash(time.Hour))
+
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add("clusters/management-cluster/management-cluster/eksa-system").Return(nil)
+	g.git.EXPECT().LastCommit().Return(&git.Commit{Hash: "a1b2c3", Author: git.CommitAuthor, Message: updateCommitMessage, When: time.Now()}, nil)
+	g.git.EXPECT().AmendCommit(updateCommitMessage).Return(nil)
+	g.git.EXPECT().ForcePush(g.ctx, "a1b2c3").Return(&git.PushRejectedError{Branch: "testBranch", Err: errors.New("required to be a1b2c3")})
+	g.git.EXPECT().ResetSoft("a1b2c3").Return(errors.New("reference not found"))
+
+	err := f.UpdateGitEksaSpec(g.ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})
+	g.Expect(err).To(MatchError(ContainSubstring("undoing amended commit with clusters/management-cluster/management-cluster/eksa-system: reference not found")))
+}
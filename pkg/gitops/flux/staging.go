@@ -0,0 +1,147 @@
+package flux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+const (
+	stagingBranchPrefix               = "eksa/staging/"
+	promoteStagingBranchCommitMessage = "Sync flux from the sync branch after bootstrap; generated by EKS-A CLI"
+)
+
+// WithStagingBranch makes InstallGitOps push the initial cluster config of a management cluster to a staging branch
+// and bootstrap flux from it, and only fast-forward the sync branch to the staging branch once flux is bootstrapped,
+// so a failed bootstrap doesn't leave half-written cluster config in the sync branch. Staging branches are named
+// eksa/staging/<cluster name>. Pull requests take precedence over the staging branch.
+func WithStagingBranch() Opt {
+	return func(f *Flux) {
+		f.stagingBranch = true
+	}
+}
+
+// checkoutStagingBranch creates and checks out the staging branch of the cluster from the sync branch, returning its
+// name. It returns an empty name if the staging branch is disabled, flux isn't bootstrapped by the CLI, or the
+// repository has no commits to fast-forward. Checking out a branch discards local changes, so it must be done before
+// writing any file.
+func (fc *fluxForCluster) checkoutStagingBranch(ctx context.Context) (string, error) {
+	if !fc.Flux.stagingBranch || fc.adoptedFlux || !fc.clusterSpec.Cluster.IsSelfManaged() {
+		return "", nil
+	}
+
+	if _, err := fc.gitClient.LastCommit(); err != nil {
+		logger.V(3).Info("Repository has no commits, pushing to the sync branch instead of a staging branch", "branch", fc.branch())
+		return "", nil
+	}
+
+	b := stagingBranchPrefix + fc.clusterSpec.Cluster.Name
+	// A staging branch left by a previous failed bootstrap would be pulled into the new one
+	if err := fc.gitClient.DeleteRemoteBranch(ctx, b); err != nil {
+		return "", fmt.Errorf("deleting previous staging branch %s: %v", b, err)
+	}
+
+	logger.V(3).Info("Creating staging branch", "branch", b)
+	if err := fc.gitClient.Branch(b); err != nil {
+		return "", fmt.Errorf("switching to git branch %s: %v", b, err)
+	}
+	return b, nil
+}
+
+// clusterSpecForBootstrap returns the cluster spec flux is bootstrapped with, which syncs from the staging branch
+// until it's promoted.
+func (fc *fluxForCluster) clusterSpecForBootstrap() *cluster.Spec {
+	if fc.stagingBranch == "" {
+		return fc.clusterSpec
+	}
+
+	s := fc.clusterSpec.DeepCopy()
+	s.FluxConfig.Spec.Branch = fc.stagingBranch
+	return s
+}
+
+// promoteStagingBranch fast-forwards the sync branch to the staging branch flux was bootstrapped from, with flux
+// syncing from the sync branch, and points the flux GitRepository to the sync branch. The staging branch is then
+// deleted.
+func (fc *fluxForCluster) promoteStagingBranch(ctx context.Context, cluster *types.Cluster) error {
+	if fc.stagingBranch == "" {
+		return nil
+	}
+
+	// Flux bootstrap pushes its components to the staging branch
+	var upToDateErr *git.RepositoryUpToDateError
+	if err := fc.gitClient.Pull(ctx, fc.stagingBranch); err != nil && !errors.As(err, &upToDateErr) {
+		return fmt.Errorf("pulling staging branch %s: %v", fc.stagingBranch, err)
+	}
+
+	if err := fc.syncFromSyncBranch(); err != nil {
+		return err
+	}
+
+	logger.V(3).Info("Fast-forwarding sync branch to staging branch", "branch", fc.branch(), "staging", fc.stagingBranch)
+	if err := fc.gitClient.PushToBranch(ctx, fc.branch()); err != nil {
+		return fmt.Errorf("fast-forwarding branch %s to staging branch %s, merge it manually: %v", fc.branch(), fc.stagingBranch, err)
+	}
+
+	if err := fc.fluxClient.SetGitRepositoryBranch(ctx, cluster, fc.namespace(), fc.branch()); err != nil {
+		return fmt.Errorf("updating flux git repository branch to %s: %v", fc.branch(), err)
+	}
+
+	fc.deleteStagingBranch(ctx)
+	return nil
+}
+
+// syncFromSyncBranch commits the flux sync manifest generated by flux bootstrap with the sync branch instead of the
+// staging branch, so flux doesn't revert the GitRepository to the staging branch once it's promoted.
+func (fc *fluxForCluster) syncFromSyncBranch() error {
+	file := path.Join(fc.fluxSystemDir(), fluxSyncFileName)
+	p := path.Join(fc.writer.Dir(), file)
+	if !validations.FileExists(p) {
+		logger.V(3).Info("Flux sync manifest does not exist, skipping branch update", "file", file)
+		return nil
+	}
+
+	content, err := os.ReadFile(p)
+	if err != nil {
+		return fmt.Errorf("reading flux sync manifest: %v", err)
+	}
+
+	updated := strings.ReplaceAll(string(content), "branch: "+fc.stagingBranch, "branch: "+fc.branch())
+	if updated == string(content) {
+		return nil
+	}
+
+	if err := os.WriteFile(p, []byte(updated), 0o644); err != nil {
+		return fmt.Errorf("writing flux sync manifest: %v", err)
+	}
+
+	if err := fc.gitClient.Add(file); err != nil {
+		return fmt.Errorf("adding %s to git: %v", file, err)
+	}
+
+	if err := fc.gitClient.Commit(promoteStagingBranchCommitMessage); err != nil {
+		return fmt.Errorf("committing %s to git: %v", file, err)
+	}
+	return nil
+}
+
+// deleteStagingBranch deletes the staging branch from the remote. A failure is only logged, the branch is deleted
+// before it's created again.
+func (fc *fluxForCluster) deleteStagingBranch(ctx context.Context) {
+	if fc.stagingBranch == "" {
+		return
+	}
+
+	if err := fc.gitClient.DeleteRemoteBranch(ctx, fc.stagingBranch); err != nil {
+		logger.Info("Warning: failed to delete the staging branch, delete it manually", "branch", fc.stagingBranch, "error", err.Error())
+	}
+}
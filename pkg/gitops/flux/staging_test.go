@@ -0,0 +1,122 @@
+package flux_test
+
+import (
+	"errors"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	stagingBranch        = "eksa/staging/management-cluster"
+	initialCommitMessage = "Initial commit of cluster configuration; generated by EKS-A CLI"
+)
+
+func expectCommitToStagingBranch(g fluxTest, clusterSpec *cluster.Spec) {
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: clusterSpec.FluxConfig.Spec.Github.Repository}, nil)
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().LastCommit().Return(&git.Commit{Hash: "abc"}, nil)
+	g.git.EXPECT().DeleteRemoteBranch(g.ctx, stagingBranch).Return(nil)
+	g.git.EXPECT().Branch(stagingBranch).Return(nil)
+	g.git.EXPECT().Add("clusters").Return(nil)
+	g.git.EXPECT().Add(pathClaimFile(clusterSpec.Cluster.Name)).Return(nil)
+	g.git.EXPECT().Commit(initialCommitMessage).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+}
+
+func TestInstallGitOpsStagingBranch(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithStagingBranch())
+	syncBranch := clusterSpec.FluxConfig.Spec.Branch
+	gotkSync := "clusters/management-cluster/flux-system/gotk-sync.yaml"
+
+	expectCommitToStagingBranch(g, clusterSpec)
+	g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, gomock.Any()).DoAndReturn(
+		func(_ interface{}, _ *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error {
+			g.Expect(fluxConfig.Spec.Branch).To(Equal(stagingBranch))
+			// Flux bootstrap commits the sync manifest with the branch it was bootstrapped from
+			return os.WriteFile(path.Join(g.writer.Dir(), gotkSync), []byte("spec:\n  ref:\n    branch: "+stagingBranch+"\n"), 0o644)
+		},
+	)
+	g.git.EXPECT().Pull(g.ctx, stagingBranch).Return(nil)
+	g.git.EXPECT().Add(gotkSync).Return(nil)
+	g.git.EXPECT().Commit("Sync flux from the sync branch after bootstrap; generated by EKS-A CLI").Return(nil)
+	g.git.EXPECT().PushToBranch(g.ctx, syncBranch).Return(nil)
+	g.flux.EXPECT().SetGitRepositoryBranch(g.ctx, cluster, "flux-system", syncBranch).Return(nil)
+	g.git.EXPECT().DeleteRemoteBranch(g.ctx, stagingBranch).Return(nil)
+	g.git.EXPECT().Pull(g.ctx, syncBranch).Return(nil)
+
+	g.Expect(f.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+	g.Expect(clusterSpec.FluxConfig.Spec.Branch).To(Equal(syncBranch))
+
+	content, err := os.ReadFile(path.Join(g.writer.Dir(), gotkSync))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(content)).To(Equal("spec:\n  ref:\n    branch: " + syncBranch + "\n"))
+}
+
+func TestInstallGitOpsStagingBranchBootstrapError(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithStagingBranch())
+
+	expectCommitToStagingBranch(g, clusterSpec)
+	g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, gomock.Any()).Return(errors.New("error in bootstrap"))
+	g.flux.EXPECT().Uninstall(g.ctx, cluster, gomock.Any()).Return(nil)
+	g.git.EXPECT().DeleteRemoteBranch(g.ctx, stagingBranch).Return(nil)
+
+	g.Expect(f.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(MatchError(ContainSubstring("error in bootstrap")))
+}
+
+func TestInstallGitOpsStagingBranchSyncBranchDiverged(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "management-cluster"
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithStagingBranch())
+	syncBranch := clusterSpec.FluxConfig.Spec.Branch
+
+	expectCommitToStagingBranch(g, clusterSpec)
+	g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, gomock.Any()).Return(nil)
+	g.git.EXPECT().Pull(g.ctx, stagingBranch).Return(&git.RepositoryUpToDateError{})
+	g.git.EXPECT().PushToBranch(g.ctx, syncBranch).Return(&git.PushRejectedError{Branch: syncBranch, Err: errors.New("non-fast-forward update")})
+
+	err := f.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})
+	g.Expect(err).To(MatchError(ContainSubstring("fast-forwarding branch testBranch to staging branch eksa/staging/management-cluster, merge it manually")))
+}
+
+func TestInstallGitOpsStagingBranchWorkloadCluster(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterName := "workload-cluster"
+	clusterConfig := v1alpha1.NewCluster(clusterName)
+	clusterConfig.SetManagedBy("management-cluster")
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithStagingBranch())
+
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: clusterSpec.FluxConfig.Spec.Github.Repository}, nil)
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add("clusters").Return(nil)
+	g.git.EXPECT().Commit(initialCommitMessage).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.flux.EXPECT().BootstrapGithub(g.ctx, cluster, clusterSpec.FluxConfig).Return(nil)
+	g.git.EXPECT().Pull(g.ctx, clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+
+	g.Expect(f.InstallGitOps(g.ctx, cluster, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})).To(Succeed())
+}
@@ -0,0 +1,188 @@
+package flux
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	kustomizationKind         = "Kustomization"
+	kustomizationResourceType = "kustomizations.kustomize.toolkit.fluxcd.io"
+	readyCondition            = "Ready"
+	notFoundReason            = "NotFound"
+)
+
+// sourceResourceTypes are the fully qualified resource types of the flux sources, so they don't clash with other
+// resources with the same name, like buckets.
+var sourceResourceTypes = map[string]string{
+	"GitRepository": "gitrepositories.source.toolkit.fluxcd.io",
+	"OCIRepository": "ocirepositories.source.toolkit.fluxcd.io",
+	"Bucket":        "buckets.source.toolkit.fluxcd.io",
+}
+
+// ResourceStatus is the reconciliation status of a flux source or Kustomization.
+type ResourceStatus struct {
+	Kind      string
+	Name      string
+	Namespace string
+	// Ready is true if the Ready condition of the resource is true.
+	Ready bool
+	// Reason and Message are the ones of the Ready condition, describing the error when the resource is not ready.
+	Reason  string
+	Message string
+	// Revision is the revision of the last artifact fetched by a source or the last revision applied by a Kustomization.
+	Revision string
+	// LastAttemptedRevision is the last revision a Kustomization tried to apply, which differs from Revision when it failed.
+	LastAttemptedRevision string
+	// Suspended is true if the reconciliation of the resource is suspended.
+	Suspended bool
+	// LastHandledReconcileAt is the value of the last reconcile request annotation handled by the controller.
+	LastHandledReconcileAt string
+}
+
+// GitOpsStatus is the status of the flux source and Kustomization that reconcile the eksa-system path of a cluster.
+type GitOpsStatus struct {
+	// Path is the eksa-system path of the cluster in the repository.
+	Path          string
+	Source        *ResourceStatus
+	Kustomization *ResourceStatus
+}
+
+// Healthy returns true if both the source and the Kustomization are ready and not suspended.
+func (s *GitOpsStatus) Healthy() bool {
+	return resourceHealthy(s.Source) && resourceHealthy(s.Kustomization)
+}
+
+func resourceHealthy(s *ResourceStatus) bool {
+	return s != nil && s.Ready && !s.Suspended
+}
+
+// fluxResource holds the spec and status fields shared by the flux sources and Kustomizations.
+type fluxResource struct {
+	Spec struct {
+		Suspend bool `json:"suspend,omitempty"`
+	} `json:"spec,omitempty"`
+	Status struct {
+		Conditions []metav1.Condition `json:"conditions,omitempty"`
+		Artifact   *struct {
+			Revision string `json:"revision,omitempty"`
+		} `json:"artifact,omitempty"`
+		LastAppliedRevision    string `json:"lastAppliedRevision,omitempty"`
+		LastAttemptedRevision  string `json:"lastAttemptedRevision,omitempty"`
+		LastHandledReconcileAt string `json:"lastHandledReconcileAt,omitempty"`
+	} `json:"status,omitempty"`
+}
+
+func (fc *fluxForCluster) getResourceStatus(ctx context.Context, cluster *types.Cluster, resourceType, name, namespace string) (*ResourceStatus, error) {
+	obj := &unstructured.Unstructured{}
+	if err := fc.fluxClient.GetObject(ctx, cluster, resourceType, name, namespace, obj); err != nil {
+		return nil, err
+	}
+
+	r := &fluxResource{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, r); err != nil {
+		return nil, fmt.Errorf("parsing status of %s %s: %v", obj.GetKind(), obj.GetName(), err)
+	}
+
+	s := &ResourceStatus{
+		Kind:                   obj.GetKind(),
+		Name:                   obj.GetName(),
+		Namespace:              obj.GetNamespace(),
+		Revision:               r.Status.LastAppliedRevision,
+		LastAttemptedRevision:  r.Status.LastAttemptedRevision,
+		Suspended:              r.Spec.Suspend,
+		LastHandledReconcileAt: r.Status.LastHandledReconcileAt,
+	}
+	if r.Status.Artifact != nil {
+		s.Revision = r.Status.Artifact.Revision
+	}
+	for _, c := range r.Status.Conditions {
+		if c.Type == readyCondition {
+			s.Ready = c.Status == metav1.ConditionTrue
+			s.Reason = c.Reason
+			s.Message = c.Message
+		}
+	}
+	return s, nil
+}
+
+// GitOpsStatus returns the readiness, revision and error conditions of the flux source and Kustomization that
+// reconcile the eksa-system path of the cluster, so it can be checked whether GitOps is healthy after an install or
+// upgrade. Resources that are not found are reported as not ready.
+func (f *Flux) GitOpsStatus(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) (*GitOpsStatus, error) {
+	if f.shouldSkipFlux() {
+		logger.Info("GitOps field not specified, gitops status skipped")
+		return nil, nil
+	}
+
+	fc, err := newFluxForCluster(f, clusterSpec, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	kind := sourceKind(clusterSpec)
+	source, err := fc.resourceStatus(ctx, cluster, kind, sourceResourceTypes[kind], fc.namespace(), fc.namespace())
+	if err != nil {
+		return nil, err
+	}
+
+	kustomization, err := fc.eksaKustomizationStatus(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GitOpsStatus{
+		Path:          fc.eksaSystemDir(),
+		Source:        source,
+		Kustomization: kustomization,
+	}, nil
+}
+
+// eksaKustomizationStatus returns the status of the Kustomization reconciling the eksa-system path of the cluster:
+// its tenant Kustomization, the Kustomization wiring it into an adopted flux or the flux-system one.
+func (fc *fluxForCluster) eksaKustomizationStatus(ctx context.Context, cluster *types.Cluster) (*ResourceStatus, error) {
+	if fc.usesClusterTenants() {
+		name := fc.clusterSpec.Cluster.Name
+		return fc.resourceStatus(ctx, cluster, kustomizationKind, kustomizationResourceType, name, name)
+	}
+
+	if fc.adoptExistingFlux && fc.clusterSpec.Cluster.IsSelfManaged() {
+		name := adoptedSyncPrefix + fc.clusterSpec.Cluster.Name
+		s, err := fc.getResourceStatus(ctx, cluster, kustomizationResourceType, name, fc.namespace())
+		if err == nil {
+			return s, nil
+		}
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("getting status of %s %s: %v", kustomizationKind, name, err)
+		}
+	}
+
+	return fc.resourceStatus(ctx, cluster, kustomizationKind, kustomizationResourceType, fc.namespace(), fc.namespace())
+}
+
+// resourceStatus returns the status of a flux resource, or a not ready status if it's not found.
+func (fc *fluxForCluster) resourceStatus(ctx context.Context, cluster *types.Cluster, kind, resourceType, name, namespace string) (*ResourceStatus, error) {
+	s, err := fc.getResourceStatus(ctx, cluster, resourceType, name, namespace)
+	if apierrors.IsNotFound(err) {
+		return &ResourceStatus{
+			Kind:      kind,
+			Name:      name,
+			Namespace: namespace,
+			Reason:    notFoundReason,
+			Message:   fmt.Sprintf("%s %s not found in namespace %s", kind, name, namespace),
+		}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting status of %s %s: %v", kind, name, err)
+	}
+	return s, nil
+}
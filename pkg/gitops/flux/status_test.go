@@ -0,0 +1,183 @@
+package flux_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	readyGitRepository = `
+apiVersion: source.toolkit.fluxcd.io/v1beta2
+kind: GitRepository
+metadata:
+  name: flux-system
+  namespace: flux-system
+status:
+  artifact:
+    revision: main/abc123
+  conditions:
+  - type: Ready
+    status: "True"
+    reason: Succeeded
+    message: "stored artifact for revision 'main/abc123'"
+    lastTransitionTime: "2022-10-01T00:00:00Z"
+`
+	failedKustomization = `
+apiVersion: kustomize.toolkit.fluxcd.io/v1beta2
+kind: Kustomization
+metadata:
+  name: flux-system
+  namespace: flux-system
+status:
+  lastAppliedRevision: main/abc000
+  lastAttemptedRevision: main/abc123
+  conditions:
+  - type: Ready
+    status: "False"
+    reason: ReconciliationFailed
+    message: "Cluster/management-cluster dry-run failed"
+    lastTransitionTime: "2022-10-01T00:00:00Z"
+`
+	readyKustomization = `
+apiVersion: kustomize.toolkit.fluxcd.io/v1beta2
+kind: Kustomization
+metadata:
+  name: eksa-management-cluster
+  namespace: flux-system
+status:
+  lastAppliedRevision: main/abc123
+  lastAttemptedRevision: main/abc123
+  conditions:
+  - type: Ready
+    status: "True"
+    reason: ReconciliationSucceeded
+    message: "Applied revision: main/abc123"
+    lastTransitionTime: "2022-10-01T00:00:00Z"
+`
+)
+
+func returnObject(t *testing.T, content string) func(context.Context, *types.Cluster, string, string, string, runtime.Object) error {
+	return func(_ context.Context, _ *types.Cluster, _, _, _ string, obj runtime.Object) error {
+		u := obj.(*unstructured.Unstructured)
+		if err := yaml.Unmarshal([]byte(content), &u.Object); err != nil {
+			t.Fatal(err)
+		}
+		return nil
+	}
+}
+
+func TestGitOpsStatus(t *testing.T) {
+	cluster := &types.Cluster{}
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "gitrepositories.source.toolkit.fluxcd.io", "flux-system", "flux-system", gomock.Any()).DoAndReturn(returnObject(t, readyGitRepository))
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "kustomizations.kustomize.toolkit.fluxcd.io", "flux-system", "flux-system", gomock.Any()).DoAndReturn(returnObject(t, failedKustomization))
+
+	status, err := g.gitOpsFlux.GitOpsStatus(g.ctx, cluster, clusterSpec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(status).To(Equal(&flux.GitOpsStatus{
+		Path: "clusters/management-cluster/management-cluster/eksa-system",
+		Source: &flux.ResourceStatus{
+			Kind:      "GitRepository",
+			Name:      "flux-system",
+			Namespace: "flux-system",
+			Ready:     true,
+			Reason:    "Succeeded",
+			Message:   "stored artifact for revision 'main/abc123'",
+			Revision:  "main/abc123",
+		},
+		Kustomization: &flux.ResourceStatus{
+			Kind:                  "Kustomization",
+			Name:                  "flux-system",
+			Namespace:             "flux-system",
+			Reason:                "ReconciliationFailed",
+			Message:               "Cluster/management-cluster dry-run failed",
+			Revision:              "main/abc000",
+			LastAttemptedRevision: "main/abc123",
+		},
+	}))
+	g.Expect(status.Healthy()).To(BeFalse())
+}
+
+func TestGitOpsStatusNotFound(t *testing.T) {
+	cluster := &types.Cluster{}
+	g := newFluxTest(t)
+	clusterSpec := newOCIClusterSpec(t, v1alpha1.NewCluster("management-cluster"))
+	notFound := apierrors.NewNotFound(schema.GroupResource{Group: "source.toolkit.fluxcd.io", Resource: "ocirepositories"}, "flux-system")
+
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "ocirepositories.source.toolkit.fluxcd.io", "flux-system", "flux-system", gomock.Any()).Return(notFound)
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "kustomizations.kustomize.toolkit.fluxcd.io", "flux-system", "flux-system", gomock.Any()).Return(notFound)
+
+	status, err := g.gitOpsFlux.GitOpsStatus(g.ctx, cluster, clusterSpec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(status.Source.Ready).To(BeFalse())
+	g.Expect(status.Source.Reason).To(Equal("NotFound"))
+	g.Expect(status.Source.Message).To(Equal("OCIRepository flux-system not found in namespace flux-system"))
+	g.Expect(status.Healthy()).To(BeFalse())
+}
+
+func TestGitOpsStatusClusterTenants(t *testing.T) {
+	cluster := &types.Cluster{}
+	clusterConfig := v1alpha1.NewCluster("workload-cluster")
+	clusterConfig.SetManagedBy("management-cluster")
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+	clusterSpec.FluxConfig.Spec.MultiTenancy = &v1alpha1.FluxMultiTenancyConfig{ClusterTenants: true}
+
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "gitrepositories.source.toolkit.fluxcd.io", "flux-system", "flux-system", gomock.Any()).DoAndReturn(returnObject(t, readyGitRepository))
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "kustomizations.kustomize.toolkit.fluxcd.io", "workload-cluster", "workload-cluster", gomock.Any()).DoAndReturn(returnObject(t, readyKustomization))
+
+	status, err := g.gitOpsFlux.GitOpsStatus(g.ctx, cluster, clusterSpec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(status.Healthy()).To(BeTrue())
+}
+
+func TestGitOpsStatusAdoptedFlux(t *testing.T) {
+	cluster := &types.Cluster{}
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithAdoptExistingFlux())
+
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "gitrepositories.source.toolkit.fluxcd.io", "flux-system", "flux-system", gomock.Any()).DoAndReturn(returnObject(t, readyGitRepository))
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "kustomizations.kustomize.toolkit.fluxcd.io", "eksa-management-cluster", "flux-system", gomock.Any()).DoAndReturn(returnObject(t, readyKustomization))
+
+	status, err := f.GitOpsStatus(g.ctx, cluster, clusterSpec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(status.Kustomization.Name).To(Equal("eksa-management-cluster"))
+	g.Expect(status.Kustomization.Revision).To(Equal("main/abc123"))
+	g.Expect(status.Healthy()).To(BeTrue())
+}
+
+func TestGitOpsStatusError(t *testing.T) {
+	cluster := &types.Cluster{}
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+
+	g.flux.EXPECT().GetObject(g.ctx, cluster, "gitrepositories.source.toolkit.fluxcd.io", "flux-system", "flux-system", gomock.Any()).Return(errors.New("error in get"))
+
+	_, err := g.gitOpsFlux.GitOpsStatus(g.ctx, cluster, clusterSpec)
+	g.Expect(err).To(MatchError(ContainSubstring("getting status of GitRepository flux-system: error in get")))
+}
+
+func TestGitOpsStatusSkip(t *testing.T) {
+	g := newFluxTest(t)
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, nil, nil)
+
+	status, err := f.GitOpsStatus(g.ctx, &types.Cluster{}, g.clusterSpec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(status).To(BeNil())
+}
@@ -0,0 +1,54 @@
+package flux
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// upgradeTagTimeFormat is the UTC time of the upgrade in the upgrade tags. It includes the time so the tags of
+// several upgrades on the same day don't conflict.
+const upgradeTagTimeFormat = "2006-01-02-150405"
+
+// WithUpgradeTags makes UpdateGitEksaSpec create and push an annotated tag <cluster name>/v<kubernetes version>-<date>
+// at the commit of the upgraded cluster config, so operators can pin, compare and roll back to the known-good states
+// of the repository. Changes pushed for a pull request aren't tagged.
+func WithUpgradeTags() Opt {
+	return func(f *Flux) {
+		f.upgradeTags = true
+	}
+}
+
+// upgradeTagName returns the name of the tag of the upgrade of the cluster at time t.
+func (fc *fluxForCluster) upgradeTagName(t time.Time) string {
+	return fmt.Sprintf("%s/v%s-%s", fc.clusterSpec.Cluster.Name, fc.clusterSpec.Cluster.Spec.KubernetesVersion, t.UTC().Format(upgradeTagTimeFormat))
+}
+
+// tagUpgrade tags the commit of the upgraded cluster config and pushes the tag. The cluster is already upgraded,
+// so a failure is only logged.
+func (fc *fluxForCluster) tagUpgrade(ctx context.Context) {
+	if !fc.Flux.upgradeTags {
+		return
+	}
+
+	if fc.pullRequestBranch != "" {
+		logger.V(3).Info("Cluster config changes pushed for a pull request, skipping upgrade tag", "branch", fc.pullRequestBranch)
+		return
+	}
+
+	name := fc.upgradeTagName(time.Now())
+	msg := fmt.Sprintf("Upgrade of cluster %s to Kubernetes %s; generated by EKS-A CLI", fc.clusterSpec.Cluster.Name, fc.clusterSpec.Cluster.Spec.KubernetesVersion)
+	if err := fc.gitClient.Tag(name, msg); err != nil {
+		logger.Info("Warning: failed to tag the upgraded cluster config", "tag", name, "error", err.Error())
+		return
+	}
+
+	if err := fc.gitClient.PushTag(ctx, name); err != nil {
+		logger.Info("Warning: failed to push the tag of the upgraded cluster config", "tag", name, "error", err.Error())
+		return
+	}
+
+	logger.V(3).Info("Tagged upgraded cluster config", "tag", name)
+}
@@ -2,6 +2,7 @@ package flux
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/aws/eks-anywhere/pkg/cluster"
@@ -21,21 +22,52 @@ func (f *Flux) Upgrade(ctx context.Context, managementCluster *types.Cluster, cu
 	}
 
 	logger.V(1).Info("Starting Flux upgrades")
-	if err := f.upgradeFilesAndCommit(ctx, newSpec); err != nil {
+	err := f.upgradeFilesAndCommit(ctx, newSpec)
+	if errors.Is(err, errAdoptedFlux) {
+		logger.V(1).Info("Skipping Flux upgrades, flux was adopted and its components are not managed by EKS-A")
+		return nil, nil
+	}
+	if err != nil {
 		return nil, fmt.Errorf("upgrading Flux from bundles %d to bundles %d: %v", currentSpec.Bundles.Spec.Number, newSpec.Bundles.Spec.Number, err)
 	}
-	if err := f.fluxClient.DeleteSystemSecret(ctx, managementCluster, newSpec.FluxConfig.Spec.SystemNamespace); err != nil {
-		return nil, fmt.Errorf("upgrading Flux when deleting old flux-system secret: %v", err)
+	if !usesOCIRepository(newSpec) && !usesBucket(newSpec) {
+		if err := f.fluxClient.DeleteSystemSecret(ctx, managementCluster, newSpec.FluxConfig.Spec.SystemNamespace); err != nil {
+			return nil, fmt.Errorf("upgrading Flux when deleting old flux-system secret: %v", err)
+		}
 	}
 	if err := f.BootstrapGithub(ctx, managementCluster, newSpec); err != nil {
 		return nil, fmt.Errorf("upgrading Flux components with github provider: %v", err)
 	}
+	if err := f.BootstrapGitlab(ctx, managementCluster, newSpec); err != nil {
+		return nil, fmt.Errorf("upgrading Flux components with gitlab provider: %v", err)
+	}
+	if err := f.BootstrapBitbucketServer(ctx, managementCluster, newSpec); err != nil {
+		return nil, fmt.Errorf("upgrading Flux components with bitbucket server provider: %v", err)
+	}
+	if err := f.BootstrapAzureDevOps(ctx, managementCluster, newSpec); err != nil {
+		return nil, fmt.Errorf("upgrading Flux components with azure devops provider: %v", err)
+	}
+	if err := f.BootstrapGitea(ctx, managementCluster, newSpec); err != nil {
+		return nil, fmt.Errorf("upgrading Flux components with gitea provider: %v", err)
+	}
+	if err := f.BootstrapCodeCommit(ctx, managementCluster, newSpec); err != nil {
+		return nil, fmt.Errorf("upgrading Flux components with codecommit provider: %v", err)
+	}
+	if err := f.BootstrapOCIRepository(ctx, managementCluster, newSpec); err != nil {
+		return nil, fmt.Errorf("upgrading Flux components with oci repository: %v", err)
+	}
+	if err := f.BootstrapBucket(ctx, managementCluster, newSpec); err != nil {
+		return nil, fmt.Errorf("upgrading Flux components with bucket: %v", err)
+	}
 	if err := f.BootstrapGit(ctx, managementCluster, newSpec); err != nil {
 		return nil, fmt.Errorf("upgrading Flux components with git provider: %v", err)
 	}
 	if err := f.fluxClient.Reconcile(ctx, managementCluster, newSpec.FluxConfig); err != nil {
 		return nil, fmt.Errorf("reconciling Flux components: %v", err)
 	}
+	if err := f.waitForControllersRollout(ctx, managementCluster, newSpec); err != nil {
+		return nil, fmt.Errorf("upgrading Flux components: %v", err)
+	}
 
 	return changeDiff, nil
 }
@@ -55,19 +87,20 @@ func FluxChangeDiff(currentSpec, newSpec *cluster.Spec) *types.ChangeDiff {
 	}
 	oldVersion := currentSpec.VersionsBundle.Flux.Version
 	newVersion := newSpec.VersionsBundle.Flux.Version
-	if oldVersion != newVersion {
-		logger.V(1).Info("Flux change diff ", "oldVersion ", oldVersion, "newVersion ", newVersion)
-		return &types.ChangeDiff{
-			ComponentReports: []types.ComponentChangeDiff{
-				{
-					ComponentName: "Flux",
-					NewVersion:    newVersion,
-					OldVersion:    oldVersion,
-				},
+	if oldVersion == newVersion {
+		// The controller images can be rebuilt in a new bundle without a new flux version.
+		return fluxControllersChangeDiff(currentSpec, newSpec)
+	}
+	logger.V(1).Info("Flux change diff ", "oldVersion ", oldVersion, "newVersion ", newVersion)
+	return &types.ChangeDiff{
+		ComponentReports: []types.ComponentChangeDiff{
+			{
+				ComponentName: "Flux",
+				NewVersion:    newVersion,
+				OldVersion:    oldVersion,
 			},
-		}
+		},
 	}
-	return nil
 }
 
 func (f *Flux) Install(ctx context.Context, cluster *types.Cluster, oldSpec, newSpec *cluster.Spec) error {
@@ -78,15 +111,27 @@ func (f *Flux) Install(ctx context.Context, cluster *types.Cluster, oldSpec, new
 }
 
 func (f *Flux) upgradeFilesAndCommit(ctx context.Context, newSpec *cluster.Spec) error {
-	fc := &fluxForCluster{
-		Flux:        f,
-		clusterSpec: newSpec,
+	fc, err := newFluxForCluster(f, newSpec, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if usesOCIRepository(newSpec) {
+		return f.upgradeOCIFiles(ctx, fc)
+	}
+
+	if usesBucket(newSpec) {
+		return f.upgradeBucketFiles(ctx, fc)
 	}
 
 	if err := fc.syncGitRepo(ctx); err != nil {
 		return err
 	}
 
+	if fc.isAdoptedFlux() {
+		return errAdoptedFlux
+	}
+
 	if err := fc.commitFluxUpgradeFilesToGit(ctx); err != nil {
 		return err
 	}
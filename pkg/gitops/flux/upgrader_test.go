@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"io/ioutil"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/aws/eks-anywhere/internal/test"
@@ -122,6 +125,113 @@ func TestFluxUpgradeSuccess(t *testing.T) {
 	tt.Expect(g.gitOpsFlux.Upgrade(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec)).To(Equal(wantDiff))
 }
 
+func TestFluxUpgradeControllerImagesChanged(t *testing.T) {
+	tt := newUpgraderTest(t)
+	tt.currentSpec.VersionsBundle.Flux = fluxBundle()
+	tt.currentSpec.FluxConfig = &tt.fluxConfig
+	tt.newSpec.VersionsBundle.Flux = fluxBundle()
+	tt.newSpec.VersionsBundle.Flux.SourceController.URI = "public.ecr.aws/l0g8r8j6/fluxcd/source-controller:v0.12.1-eks-a-2"
+	tt.newSpec.FluxConfig = &tt.fluxConfig
+	bundle := tt.newSpec.VersionsBundle.Flux
+
+	g := newFluxTest(t)
+
+	if err := setupTestFiles(t, g.writer); err != nil {
+		t.Errorf("setting up files: %v", err)
+	}
+
+	wantDiff := &types.ChangeDiff{
+		ComponentReports: []types.ComponentChangeDiff{
+			{
+				ComponentName: "Flux source-controller",
+				NewVersion:    "v0.12.1-eks-a-2",
+				OldVersion:    "v0.12.1-8539f509df046a4f567d2182dde824b957136599",
+			},
+		},
+	}
+
+	g.git.EXPECT().Clone(tt.ctx).Return(nil)
+	g.git.EXPECT().Branch(tt.fluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add(tt.fluxConfig.Spec.ClusterConfigPath).Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(tt.ctx).Return(nil)
+
+	g.flux.EXPECT().DeleteSystemSecret(tt.ctx, tt.cluster, tt.newSpec.FluxConfig.Spec.SystemNamespace)
+	g.flux.EXPECT().BootstrapGithub(tt.ctx, tt.cluster, tt.newSpec.FluxConfig)
+	g.flux.EXPECT().BootstrapGit(tt.ctx, tt.cluster, tt.newSpec.FluxConfig, nil)
+	g.flux.EXPECT().Reconcile(tt.ctx, tt.cluster, tt.newSpec.FluxConfig)
+	for name, image := range map[string]string{
+		"source-controller":       bundle.SourceController.URI,
+		"kustomize-controller":    bundle.KustomizeController.URI,
+		"helm-controller":         bundle.HelmController.URI,
+		"notification-controller": bundle.NotificationController.URI,
+	} {
+		g.flux.EXPECT().GetDeployment(tt.ctx, tt.cluster, name, "flux-system").Return(rolledOutDeployment(name, image), nil)
+	}
+
+	tt.Expect(g.gitOpsFlux.Upgrade(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec)).To(Equal(wantDiff))
+}
+
+func TestFluxUpgradeControllersRolloutTimeout(t *testing.T) {
+	tt := newUpgraderTest(t)
+	tt.newSpec.VersionsBundle.Flux = fluxBundle()
+	tt.newSpec.VersionsBundle.Flux.Version = "v0.2.0"
+	tt.newSpec.FluxConfig = &tt.fluxConfig
+	tt.fluxConfig.Spec.Components = []string{v1alpha1.FluxSourceController, v1alpha1.FluxKustomizeController}
+
+	g := newFluxTest(t)
+	f := flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, nil, flux.WithControllersRolloutTimeout(time.Millisecond))
+
+	if err := setupTestFiles(t, g.writer); err != nil {
+		t.Errorf("setting up files: %v", err)
+	}
+
+	g.git.EXPECT().Clone(tt.ctx).Return(nil)
+	g.git.EXPECT().Branch(tt.fluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add(tt.fluxConfig.Spec.ClusterConfigPath).Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(tt.ctx).Return(nil)
+
+	g.flux.EXPECT().DeleteSystemSecret(tt.ctx, tt.cluster, tt.newSpec.FluxConfig.Spec.SystemNamespace)
+	g.flux.EXPECT().BootstrapGithub(tt.ctx, tt.cluster, tt.newSpec.FluxConfig)
+	g.flux.EXPECT().BootstrapGit(tt.ctx, tt.cluster, tt.newSpec.FluxConfig, nil)
+	g.flux.EXPECT().Reconcile(tt.ctx, tt.cluster, tt.newSpec.FluxConfig)
+	g.flux.EXPECT().GetDeployment(tt.ctx, tt.cluster, "kustomize-controller", "flux-system").
+		Return(rolledOutDeployment("kustomize-controller", "public.ecr.aws/l0g8r8j6/fluxcd/kustomize-controller:old"), nil)
+
+	_, err := f.Upgrade(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("waiting for flux controller kustomize-controller to roll out: deployment kustomize-controller doesn't run image")))
+}
+
+func TestFluxChangeDiffControllerImagesUnchanged(t *testing.T) {
+	tt := newUpgraderTest(t)
+	tt.currentSpec.VersionsBundle.Flux = fluxBundle()
+	tt.currentSpec.FluxConfig = &tt.fluxConfig
+	tt.newSpec.VersionsBundle.Flux = fluxBundle()
+	tt.newSpec.FluxConfig = &tt.fluxConfig
+
+	tt.Expect(flux.FluxChangeDiff(tt.currentSpec, tt.newSpec)).To(BeNil())
+}
+
+func rolledOutDeployment(name, image string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Generation: 2},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "manager", Image: image}},
+				},
+			},
+		},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 2,
+			Replicas:           1,
+			UpdatedReplicas:    1,
+			AvailableReplicas:  1,
+		},
+	}
+}
+
 func TestFluxUpgradeBootstrapGithubError(t *testing.T) {
 	tt := newUpgraderTest(t)
 	tt.newSpec.VersionsBundle.Flux.Version = "v0.2.0"
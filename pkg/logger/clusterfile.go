@@ -0,0 +1,86 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// switchableSink is a zapcore.WriteSyncer whose underlying sink can be replaced while the logger is in use.
+type switchableSink struct {
+	mu   sync.Mutex
+	sink zapcore.WriteSyncer
+}
+
+func newSwitchableSink(sink zapcore.WriteSyncer) *switchableSink {
+	return &switchableSink{sink: sink}
+}
+
+func (s *switchableSink) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sink.Write(p)
+}
+
+func (s *switchableSink) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sink.Sync()
+}
+
+// swap replaces the underlying sink and returns the previous one.
+func (s *switchableSink) swap(sink zapcore.WriteSyncer) zapcore.WriteSyncer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old := s.sink
+	s.sink = sink
+	return old
+}
+
+// WithClusterFile redirects the logger file output to a file for the given cluster, next to the configured
+// output file and named after it, until the returned restore func is called. GetOutputFilePath returns the
+// cluster file while it's active. It's meant to scope the logs of a single cluster operation at a time,
+// so redirections shouldn't overlap.
+func WithClusterFile(clusterName string) (restore func(), err error) {
+	if clusterName == "" || strings.ContainsAny(clusterName, `/\`) {
+		return nil, fmt.Errorf("invalid cluster name for log file: %q", clusterName)
+	}
+
+	outputFileMu.Lock()
+	defer outputFileMu.Unlock()
+
+	if outputFilePath == "" || outputFileSink == nil {
+		return nil, errors.New("logger is not configured to output to a file")
+	}
+
+	previousPath := outputFilePath
+	clusterPath := clusterFilePath(previousPath, clusterName)
+	clusterSink, closeClusterSink, err := zap.Open(clusterPath)
+	if err != nil {
+		return nil, fmt.Errorf("opening cluster log file %s: %v", clusterPath, err)
+	}
+
+	previousSink := outputFileSink.swap(clusterSink)
+	outputFilePath = clusterPath
+
+	return func() {
+		outputFileMu.Lock()
+		defer outputFileMu.Unlock()
+
+		outputFileSink.swap(previousSink)
+		outputFilePath = previousPath
+		_ = clusterSink.Sync()
+		closeClusterSink()
+	}, nil
+}
+
+// clusterFilePath appends the cluster name to the output file name, before its extension.
+func clusterFilePath(outputFile, clusterName string) string {
+	ext := filepath.Ext(outputFile)
+	return strings.TrimSuffix(outputFile, ext) + "-" + clusterName + ext
+}
@@ -0,0 +1,57 @@
+package logger_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+func TestWithClusterFile(t *testing.T) {
+	g := NewWithT(t)
+	logFile := filepath.Join(t.TempDir(), "eksa.log")
+	logger.SetZapForTest(t, logger.ZapOpts{Level: 0, OutputFilePath: logFile})
+
+	logger.Info("before cluster")
+
+	restore, err := logger.WithClusterFile("cluster-a")
+	g.Expect(err).NotTo(HaveOccurred())
+	clusterFile := filepath.Join(filepath.Dir(logFile), "eksa-cluster-a.log")
+	g.Expect(logger.GetOutputFilePath()).To(Equal(clusterFile))
+
+	logger.V(6).Info("during cluster")
+	restore()
+
+	g.Expect(logger.GetOutputFilePath()).To(Equal(logFile))
+	logger.Info("after cluster")
+
+	mainContent, err := os.ReadFile(logFile)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(mainContent)).To(ContainSubstring("before cluster"))
+	g.Expect(string(mainContent)).To(ContainSubstring("after cluster"))
+	g.Expect(string(mainContent)).NotTo(ContainSubstring("during cluster"))
+
+	clusterContent, err := os.ReadFile(clusterFile)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(clusterContent)).To(ContainSubstring("during cluster"))
+	g.Expect(string(clusterContent)).NotTo(ContainSubstring("before cluster"))
+}
+
+func TestWithClusterFileNoOutputFile(t *testing.T) {
+	g := NewWithT(t)
+	logger.SetZapForTest(t, logger.ZapOpts{Level: 0})
+
+	_, err := logger.WithClusterFile("cluster-a")
+	g.Expect(err).To(MatchError("logger is not configured to output to a file"))
+}
+
+func TestWithClusterFileInvalidName(t *testing.T) {
+	g := NewWithT(t)
+	logger.SetZapForTest(t, logger.ZapOpts{Level: 0, OutputFilePath: filepath.Join(t.TempDir(), "eksa.log")})
+
+	_, err := logger.WithClusterFile("../cluster-a")
+	g.Expect(err).To(MatchError(ContainSubstring("invalid cluster name for log file")))
+}
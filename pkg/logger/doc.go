@@ -21,6 +21,12 @@ Level zero, the default, matters most. Increasing levels matter less and less.
   - 8: Truncated external binaries and clients output/responses.
   - 9: Full external binaries and clients output/responses.
 
+Component levels:
+
+Components can have their own log level for the console output, such as git=6,flux=3, see ParseVerbosity.
+V picks the logger of the component the calling package belongs to, based on its import path, and Component
+returns the logger of a named component.
+
 Logging WithValues:
 
 Logging WithValues should be preferred to embedding values into log messages because it allows
@@ -28,6 +34,33 @@ machine readability.
 
 Variables name should start with a capital letter.
 
+Output format:
+
+The console output is human-oriented text by default. With the JSON format, each entry is written to the console
+as a JSON object with the time, the v-level, the message and its values, and the Mark* functions set a status value
+instead of prefixing the message with an emoji.
+
+Redaction:
+
+The values registered with RegisterSecrets, git provider tokens, credentials in urls, kubeconfig keys and tokens,
+and registry auths are masked in the messages and the values before they are written to the console or the log file.
+The values of the keys containing password, passphrase, token, privateKey or secretAccessKey are always masked.
+Structs, maps, slices and zap marshalers are encoded to JSON before being redacted, so their nested fields with
+sensitive names are masked too.
+Never rely on redaction to log secrets on purpose.
+
+Progress:
+
+StartTask and EndTask report the steps of a CLI operation with a step number and their duration. On a terminal,
+a spinner is rendered under the log output while the step runs, otherwise plain lines are logged. In text format,
+the lines are only logged from verbosity 3, so the default output doesn't change. TaskDurations returns the
+recorded durations.
+
+Events:
+
+With InitEvents, the start and the end of the tasks are also written as lifecycle events, one JSON object per line,
+to a file or a file descriptor, for the tools wrapping the CLI. The events don't depend on the log level or format.
+
 Logging WithNames:
 
 Logging WithNames should be used carefully.
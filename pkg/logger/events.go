@@ -0,0 +1,141 @@
+package logger
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// EventPhaseStarted, EventPhaseCompleted and EventPhaseFailed are the types of the events of the phases of
+	// the CLI operation, the tasks started with StartTask.
+	EventPhaseStarted   = "phase.started"
+	EventPhaseCompleted = "phase.completed"
+	EventPhaseFailed    = "phase.failed"
+
+	eventsFdPrefix = "fd:"
+)
+
+// Event is a lifecycle event of the CLI operation, written as one JSON line to the events output.
+type Event struct {
+	Time  time.Time `json:"time"`
+	Type  string    `json:"type"`
+	RunID string    `json:"runId"`
+	// PhaseID identifies the phase within the run, so the started event can be matched with the completed or failed one.
+	PhaseID         string  `json:"phaseId"`
+	Phase           string  `json:"phase"`
+	Step            int     `json:"step"`
+	DurationSeconds float64 `json:"durationSeconds,omitempty"`
+	Error           string  `json:"error,omitempty"`
+}
+
+var (
+	eventsMu  sync.Mutex
+	eventsOut io.WriteCloser
+	runID     string
+)
+
+// InitEvents writes the lifecycle events to output from now on, independently of the log output. output is the
+// path of a file, truncated if it exists, or fd:<number> for a file descriptor inherited from the parent process.
+// It does nothing if output is empty.
+func InitEvents(output string) error {
+	if output == "" {
+		return nil
+	}
+
+	out, err := openEventsOutput(output)
+	if err != nil {
+		return fmt.Errorf("opening events output %s: %v", output, err)
+	}
+
+	id, err := newRunID()
+	if err != nil {
+		out.Close()
+		return fmt.Errorf("generating run id for events: %v", err)
+	}
+
+	eventsMu.Lock()
+	defer eventsMu.Unlock()
+	if eventsOut != nil {
+		eventsOut.Close()
+	}
+	eventsOut = out
+	runID = id
+	return nil
+}
+
+// CloseEvents closes the events output, no more events are written.
+func CloseEvents() {
+	eventsMu.Lock()
+	defer eventsMu.Unlock()
+	if eventsOut == nil {
+		return
+	}
+	if err := eventsOut.Close(); err != nil {
+		l.V(4).Info("Failed closing events output", "error", err)
+	}
+	eventsOut = nil
+}
+
+func openEventsOutput(output string) (io.WriteCloser, error) {
+	if !strings.HasPrefix(output, eventsFdPrefix) {
+		return os.Create(output)
+	}
+
+	fd, err := strconv.Atoi(strings.TrimPrefix(output, eventsFdPrefix))
+	if err != nil || fd < 0 {
+		return nil, fmt.Errorf("invalid file descriptor, it must be %s<number>", eventsFdPrefix)
+	}
+	f := os.NewFile(uintptr(fd), "events")
+	if _, err := f.Stat(); err != nil {
+		return nil, fmt.Errorf("invalid file descriptor %d: %v", fd, err)
+	}
+	return f, nil
+}
+
+func newRunID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// emitTaskEvent writes the event of a task, if the events output is configured. Failing to write an event
+// doesn't fail the task, it's only logged.
+func emitTaskEvent(eventType string, t *Task, elapsed time.Duration, taskErr error) {
+	eventsMu.Lock()
+	defer eventsMu.Unlock()
+	if eventsOut == nil {
+		return
+	}
+
+	e := Event{
+		Time:            time.Now().UTC(),
+		Type:            eventType,
+		RunID:           runID,
+		PhaseID:         fmt.Sprintf("%s-%d", runID, t.step),
+		Phase:           t.name,
+		Step:            t.step,
+		DurationSeconds: elapsed.Seconds(),
+	}
+	if taskErr != nil {
+		e.Error = Redact(taskErr.Error())
+	}
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		l.V(4).Info("Failed marshalling event", "type", eventType, "error", err)
+		return
+	}
+	if _, err := eventsOut.Write(append(line, '\n')); err != nil {
+		l.V(4).Info("Failed writing event", "type", eventType, "error", err)
+	}
+}
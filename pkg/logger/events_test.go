@@ -0,0 +1,127 @@
+package logger_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+func readEvents(t *testing.T, file string) []logger.Event {
+	t.Helper()
+	f, err := os.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var events []logger.Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		e := logger.Event{}
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("parsing event %s: %v", scanner.Text(), err)
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+func TestEventsFile(t *testing.T) {
+	g := NewWithT(t)
+	logger.SetZapForTest(t, logger.ZapOpts{Level: 0, OutputFilePath: filepath.Join(t.TempDir(), "test.log")})
+	eventsFile := filepath.Join(t.TempDir(), "events.ndjson")
+	g.Expect(logger.InitEvents(eventsFile)).To(Succeed())
+	t.Cleanup(logger.CloseEvents)
+
+	validate := logger.StartTask("setup-and-validate")
+	logger.EndTask(validate, nil)
+	install := logger.StartTask("gitops-manager-install")
+	logger.EndTask(install, errors.New("flux bootstrap failed"))
+	logger.CloseEvents()
+
+	events := readEvents(t, eventsFile)
+	g.Expect(events).To(HaveLen(4))
+
+	g.Expect(events[0].Type).To(Equal(logger.EventPhaseStarted))
+	g.Expect(events[0].Phase).To(Equal("setup-and-validate"))
+	g.Expect(events[0].Step).To(Equal(1))
+	g.Expect(events[0].RunID).NotTo(BeEmpty())
+	g.Expect(events[0].Time.IsZero()).To(BeFalse())
+
+	g.Expect(events[1].Type).To(Equal(logger.EventPhaseCompleted))
+	g.Expect(events[1].PhaseID).To(Equal(events[0].PhaseID))
+
+	g.Expect(events[2].Type).To(Equal(logger.EventPhaseStarted))
+	g.Expect(events[2].Step).To(Equal(2))
+	g.Expect(events[2].PhaseID).NotTo(Equal(events[0].PhaseID))
+	g.Expect(events[2].RunID).To(Equal(events[0].RunID))
+
+	g.Expect(events[3].Type).To(Equal(logger.EventPhaseFailed))
+	g.Expect(events[3].Phase).To(Equal("gitops-manager-install"))
+	g.Expect(events[3].Error).To(Equal("flux bootstrap failed"))
+}
+
+func TestEventsFileDescriptor(t *testing.T) {
+	g := NewWithT(t)
+	logger.SetZapForTest(t, logger.ZapOpts{Level: 0, OutputFilePath: filepath.Join(t.TempDir(), "test.log")})
+	eventsFile := filepath.Join(t.TempDir(), "events.ndjson")
+	f, err := os.Create(eventsFile)
+	g.Expect(err).NotTo(HaveOccurred())
+	defer f.Close()
+	// The events output owns the descriptor it's given and closes it.
+	fd, err := syscall.Dup(int(f.Fd()))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(logger.InitEvents(fmt.Sprintf("fd:%d", fd))).To(Succeed())
+	t.Cleanup(logger.CloseEvents)
+
+	logger.EndTask(logger.StartTask("setup-and-validate"), nil)
+	logger.CloseEvents()
+
+	g.Expect(readEvents(t, eventsFile)).To(HaveLen(2))
+}
+
+func TestInitEventsErrors(t *testing.T) {
+	tests := []struct {
+		testName string
+		output   string
+		wantErr  string
+	}{
+		{
+			testName: "invalid file descriptor",
+			output:   "fd:three",
+			wantErr:  "invalid file descriptor, it must be fd:<number>",
+		},
+		{
+			testName: "closed file descriptor",
+			output:   "fd:999",
+			wantErr:  "invalid file descriptor 999",
+		},
+		{
+			testName: "missing directory",
+			output:   "/does-not-exist/events.ndjson",
+			wantErr:  "no such file or directory",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(logger.InitEvents(tt.output)).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
+
+func TestInitEventsEmpty(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(logger.InitEvents("")).To(Succeed())
+}
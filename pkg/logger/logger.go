@@ -3,6 +3,7 @@ package logger
 import (
 	"os"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 )
@@ -13,24 +14,42 @@ const (
 	markSuccess = "🎉 "
 	markFailed  = "❌ "
 	markWarning = "⚠️"
+
+	// statusKey replaces the marks in JSON format.
+	statusKey     = "status"
+	statusPass    = "pass"
+	statusSuccess = "success"
+	statusFailed  = "failed"
+	statusWarning = "warning"
 )
 
 var (
 	l              logr.Logger = logr.Discard()
 	once           sync.Once
 	outputFilePath string
+	outputFileSink *switchableSink
+	outputFileMu   sync.Mutex
+	format         string
+	components     map[string]logr.Logger
+	stdout         *console
 )
 
-func set(logger logr.Logger, out string) {
+func set(loggers zapLoggers, out string, f string) {
 	once.Do(func() {
-		l = logger
+		l = loggers.logger
 		outputFilePath = out
+		outputFileSink = loggers.sink
+		format = f
+		components = loggers.components
+		stdout = loggers.console
 	})
 }
 
 // GetOutputFilePath returns the path to the file where high verbosity logs are written to.
 // If the logger hasn't been configured to output to a file, it returns an empty string.
 func GetOutputFilePath() string {
+	outputFileMu.Lock()
+	defer outputFileMu.Unlock()
 	return outputFilePath
 }
 
@@ -68,8 +87,9 @@ func Info(msg string, keysAndValues ...interface{}) {
 // this Logger. In other words, V values are additive.  V higher verbosity
 // level means a log message is less important.  It's illegal to pass a log
 // level less than zero.
+// When component levels are set, the logger of the component the calling package belongs to is used.
 func V(level int) logr.Logger {
-	return l.V(level)
+	return callerLogger(1).V(level)
 }
 
 func Error(err error, msg string, keysAndValues ...interface{}) {
@@ -77,17 +97,49 @@ func Error(err error, msg string, keysAndValues ...interface{}) {
 }
 
 func MarkPass(msg string, keysAndValues ...interface{}) {
-	l.V(0).Info(markPass+msg, keysAndValues...)
+	logMarked(markPass, statusPass, msg, keysAndValues...)
 }
 
 func MarkSuccess(msg string, keysAndValues ...interface{}) {
-	l.V(0).Info(markSuccess+msg, keysAndValues...)
+	logMarked(markSuccess, statusSuccess, msg, keysAndValues...)
 }
 
 func MarkFail(msg string, keysAndValues ...interface{}) {
-	l.V(0).Info(markFailed+msg, keysAndValues...)
+	logMarked(markFailed, statusFailed, msg, keysAndValues...)
 }
 
 func MarkWarning(msg string, keysAndValues ...interface{}) {
-	l.V(0).Info(markWarning+msg, keysAndValues...)
+	logMarked(markWarning, statusWarning, msg, keysAndValues...)
+}
+
+// logMarked prefixes msg with the mark in text format. In JSON format, the mark is replaced with a status value,
+// so the messages stay free of emoji for the parsers.
+func logMarked(mark, status, msg string, keysAndValues ...interface{}) {
+	logMarkedV(0, mark, status, msg, keysAndValues...)
+}
+
+func logMarkedV(level int, mark, status, msg string, keysAndValues ...interface{}) {
+	if format == JSONFormat {
+		l.V(level).Info(msg, append([]interface{}{statusKey, status}, keysAndValues...)...)
+		return
+	}
+	l.V(level).Info(mark+msg, keysAndValues...)
+}
+
+// MarkSuccessTimed is equivalent to MarkSuccess, appending to msg the time elapsed since start.
+func MarkSuccessTimed(start time.Time, msg string, keysAndValues ...interface{}) {
+	MarkSuccess(msg+" in "+formatElapsed(time.Since(start)), keysAndValues...)
+}
+
+// MarkFailTimed is equivalent to MarkFail, appending to msg the time elapsed since start.
+func MarkFailTimed(start time.Time, msg string, keysAndValues ...interface{}) {
+	MarkFail(msg+" after "+formatElapsed(time.Since(start)), keysAndValues...)
+}
+
+// formatElapsed rounds the duration to seconds, or to milliseconds for durations under a second.
+func formatElapsed(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(time.Second).String()
 }
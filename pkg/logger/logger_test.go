@@ -0,0 +1,41 @@
+package logger_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+func TestFormatElapsed(t *testing.T) {
+	tests := []struct {
+		testName string
+		elapsed  time.Duration
+		want     string
+	}{
+		{
+			testName: "under a second",
+			elapsed:  123456789 * time.Nanosecond,
+			want:     "123ms",
+		},
+		{
+			testName: "seconds",
+			elapsed:  42*time.Second + 400*time.Millisecond,
+			want:     "42s",
+		},
+		{
+			testName: "minutes",
+			elapsed:  3*time.Minute + 2*time.Second + 600*time.Millisecond,
+			want:     "3m3s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(logger.FormatElapsed(tt.elapsed)).To(Equal(tt.want))
+		})
+	}
+}
@@ -1,3 +1,37 @@
 package logger
 
+import (
+	"bytes"
+	"testing"
+)
+
 var NewZap = newZap
+
+var FormatElapsed = formatElapsed
+
+// SetZapForTest replaces the package logger with a new zap logger for the duration of the test.
+func SetZapForTest(t *testing.T, opts ZapOpts) {
+	t.Helper()
+	loggers, err := newZapWithSink(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prevLogger, prevPath, prevSink, prevFormat, prevComponents, prevStdout := l, outputFilePath, outputFileSink, format, components, stdout
+	l, outputFilePath, outputFileSink, format, components, stdout = loggers.logger, opts.OutputFilePath, loggers.sink, opts.Format, loggers.components, loggers.console
+	resetTasks()
+	t.Cleanup(func() {
+		l, outputFilePath, outputFileSink, format, components, stdout = prevLogger, prevPath, prevSink, prevFormat, prevComponents, prevStdout
+		resetTasks()
+	})
+}
+
+// ConsoleOutput returns what the console writes for p while the spinner line is displayed.
+func ConsoleOutput(line string, p []byte) string {
+	var b bytes.Buffer
+	c := newConsole(&b, true)
+	c.setLine(line)
+	b.Reset()
+	_, _ = c.Write(p)
+	return b.String()
+}
@@ -0,0 +1,189 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+)
+
+const (
+	spinnerInterval = 100 * time.Millisecond
+	// clearLine moves the cursor to the beginning of the line and erases it.
+	clearLine = "\r\033[K"
+	// taskLinesLevel is the verbosity of the task lines in text format, so they don't change the default output.
+	taskLinesLevel = 3
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+var (
+	tasksMu       sync.Mutex
+	taskSteps     int
+	taskDurations []TaskDuration
+)
+
+// Task is a step of the CLI operation, started with StartTask and ended with EndTask.
+type Task struct {
+	name  string
+	step  int
+	start time.Time
+	// stop and stopped are only set while a spinner is rendered.
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+// TaskDuration is the time a task took, recorded when it ends.
+type TaskDuration struct {
+	Name     string
+	Step     int
+	Duration time.Duration
+	Failed   bool
+}
+
+// StartTask starts a step of the CLI operation, numbered after the previous ones. On a terminal, a spinner with the
+// step number, the name and the elapsed time is rendered under the log output until the task ends. Otherwise, a plain
+// line is logged, in text format only from verbosity 3.
+func StartTask(name string) *Task {
+	tasksMu.Lock()
+	taskSteps++
+	t := &Task{name: name, step: taskSteps, start: time.Now()}
+	tasksMu.Unlock()
+	emitTaskEvent(EventPhaseStarted, t, 0, nil)
+
+	if stdout != nil && stdout.tty && format != JSONFormat {
+		t.stop, t.stopped = make(chan struct{}), make(chan struct{})
+		go t.spin(stdout)
+		return t
+	}
+
+	if format == JSONFormat {
+		Info("Task started", "task", t.name, "step", t.step)
+	} else {
+		l.V(taskLinesLevel).Info(t.label())
+	}
+	return t
+}
+
+// EndTask stops the spinner of the task, records its duration and logs whether it succeeded or failed with err,
+// in text format only from verbosity 3.
+func EndTask(t *Task, err error) {
+	if t == nil {
+		return
+	}
+
+	elapsed := time.Since(t.start)
+	if t.stop != nil {
+		close(t.stop)
+		<-t.stopped
+	}
+
+	tasksMu.Lock()
+	taskDurations = append(taskDurations, TaskDuration{Name: t.name, Step: t.step, Duration: elapsed, Failed: err != nil})
+	tasksMu.Unlock()
+
+	if err != nil {
+		emitTaskEvent(EventPhaseFailed, t, elapsed, err)
+	} else {
+		emitTaskEvent(EventPhaseCompleted, t, elapsed, nil)
+	}
+
+	level := taskLinesLevel
+	var keysAndValues []interface{}
+	if format == JSONFormat {
+		level = 0
+		keysAndValues = []interface{}{"task", t.name, "step", t.step, "duration", elapsed.String()}
+	}
+	if err != nil {
+		logMarkedV(level, markFailed, statusFailed, t.label()+" failed after "+formatElapsed(elapsed), append(keysAndValues, "error", err.Error())...)
+		return
+	}
+	logMarkedV(level, markPass, statusPass, t.label()+" done in "+formatElapsed(elapsed), keysAndValues...)
+}
+
+// TaskDurations returns the durations of the ended tasks, in the order they ended.
+func TaskDurations() []TaskDuration {
+	tasksMu.Lock()
+	defer tasksMu.Unlock()
+	return append([]TaskDuration(nil), taskDurations...)
+}
+
+func resetTasks() {
+	tasksMu.Lock()
+	defer tasksMu.Unlock()
+	taskSteps = 0
+	taskDurations = nil
+}
+
+func (t *Task) label() string {
+	return fmt.Sprintf("[%d] %s", t.step, t.name)
+}
+
+func (t *Task) spin(c *console) {
+	defer close(t.stopped)
+	ticker := time.NewTicker(spinnerInterval)
+	defer ticker.Stop()
+
+	for i := 0; ; i++ {
+		c.setLine(fmt.Sprintf("%s %s (%s)", spinnerFrames[i%len(spinnerFrames)], t.label(), time.Since(t.start).Round(time.Second)))
+		select {
+		case <-t.stop:
+			c.setLine("")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// console is the console output of the logger. On a terminal, it keeps the spinner line under the log entries
+// by erasing it before writing an entry and rendering it again after.
+type console struct {
+	mu   sync.Mutex
+	out  io.Writer
+	tty  bool
+	line string
+}
+
+func newConsole(out io.Writer, tty bool) *console {
+	return &console{out: out, tty: tty}
+}
+
+func isTerminal(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd()))
+}
+
+func (c *console) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.line == "" {
+		return c.out.Write(p)
+	}
+
+	if _, err := io.WriteString(c.out, clearLine); err != nil {
+		return 0, err
+	}
+	n, err := c.out.Write(p)
+	if err != nil {
+		return n, err
+	}
+	_, err = io.WriteString(c.out, c.line)
+	return n, err
+}
+
+// setLine replaces the spinner line, an empty line erases it.
+func (c *console) setLine(line string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.line != "" {
+		_, _ = io.WriteString(c.out, clearLine)
+	}
+	c.line = line
+	if line != "" {
+		_, _ = io.WriteString(c.out, line)
+	}
+}
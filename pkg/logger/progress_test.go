@@ -0,0 +1,63 @@
+package logger_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+func TestTasksPlainLines(t *testing.T) {
+	g := NewWithT(t)
+	stdout := captureStdout(t)
+	logger.SetZapForTest(t, logger.ZapOpts{Level: 3, OutputFilePath: filepath.Join(t.TempDir(), "test.log")})
+
+	validate := logger.StartTask("setup-and-validate")
+	logger.EndTask(validate, nil)
+	install := logger.StartTask("gitops-manager-install")
+	logger.EndTask(install, errors.New("flux bootstrap failed"))
+
+	g.Expect(string(stdout())).To(MatchRegexp(`^\[1\] setup-and-validate\n` +
+		`✅ \[1\] setup-and-validate done in \S+\n` +
+		`\[2\] gitops-manager-install\n` +
+		`❌ \[2\] gitops-manager-install failed after \S+\t{"error": "flux bootstrap failed"}\n$`))
+
+	durations := logger.TaskDurations()
+	g.Expect(durations).To(HaveLen(2))
+	g.Expect(durations[0].Name).To(Equal("setup-and-validate"))
+	g.Expect(durations[0].Step).To(Equal(1))
+	g.Expect(durations[0].Failed).To(BeFalse())
+	g.Expect(durations[1].Name).To(Equal("gitops-manager-install"))
+	g.Expect(durations[1].Failed).To(BeTrue())
+}
+
+func TestTasksPlainLinesDefaultVerbosity(t *testing.T) {
+	g := NewWithT(t)
+	stdout := captureStdout(t)
+	logger.SetZapForTest(t, logger.ZapOpts{Level: 0, OutputFilePath: filepath.Join(t.TempDir(), "test.log")})
+
+	validate := logger.StartTask("setup-and-validate")
+	logger.EndTask(validate, errors.New("validation failed"))
+
+	g.Expect(string(stdout())).To(BeEmpty())
+	g.Expect(logger.TaskDurations()).To(HaveLen(1))
+}
+
+func TestEndTaskNil(t *testing.T) {
+	g := NewWithT(t)
+	logger.SetZapForTest(t, logger.ZapOpts{Level: 0, OutputFilePath: filepath.Join(t.TempDir(), "test.log")})
+
+	logger.EndTask(nil, nil)
+
+	g.Expect(logger.TaskDurations()).To(BeEmpty())
+}
+
+func TestConsoleRendersSpinnerLineUnderLogEntries(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(logger.ConsoleOutput("⠋ [1] setup-and-validate (3s)", []byte("Validating\n"))).To(Equal("\r\033[KValidating\n⠋ [1] setup-and-validate (3s)"))
+	g.Expect(logger.ConsoleOutput("", []byte("Validating\n"))).To(Equal("Validating\n"))
+}
@@ -0,0 +1,90 @@
+package logger
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+)
+
+// ParseVerbosity parses a verbosity made of a log level and of log levels for components, separated by commas,
+// such as 2,git=6,flux=3. Both are optional, the log level defaults to 0.
+func ParseVerbosity(verbosity string) (level int, componentLevels map[string]int, err error) {
+	for _, v := range strings.Split(verbosity, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+
+		name, value, isComponent := strings.Cut(v, "=")
+		if !isComponent {
+			value = name
+		}
+
+		l, err := strconv.Atoi(value)
+		if err != nil || l < 0 {
+			return 0, nil, fmt.Errorf("invalid verbosity %s: log levels must be non-negative integers", v)
+		}
+
+		if !isComponent {
+			level = l
+			continue
+		}
+
+		if name == "" {
+			return 0, nil, fmt.Errorf("invalid verbosity %s: component name is empty", v)
+		}
+		if componentLevels == nil {
+			componentLevels = map[string]int{}
+		}
+		componentLevels[name] = l
+	}
+	return level, componentLevels, nil
+}
+
+// Component returns the logger of the named component, with the log level set for it, or the package logger if the
+// component doesn't have its own log level.
+func Component(name string) logr.Logger {
+	if c, ok := components[name]; ok {
+		return c
+	}
+	return l
+}
+
+// callerLogger returns the logger of the component of the caller's package, skip frames above the caller of
+// callerLogger. A package belongs to a component when one of the elements of its import path is the component
+// name, i.e. pkg/gitops/flux to flux, with the innermost one winning.
+func callerLogger(skip int) logr.Logger {
+	if len(components) == 0 {
+		return l
+	}
+
+	pc, _, _, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return l
+	}
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return l
+	}
+
+	elems := strings.Split(packagePath(fn.Name()), "/")
+	for i := len(elems) - 1; i >= 0; i-- {
+		if c, ok := components[elems[i]]; ok {
+			return c
+		}
+	}
+	return l
+}
+
+// packagePath returns the import path of the package of a function name,
+// such as github.com/aws/eks-anywhere/pkg/git/gitclient.(*GitClient).Clone.
+func packagePath(funcName string) string {
+	lastSlash := strings.LastIndex(funcName, "/")
+	if dot := strings.Index(funcName[lastSlash+1:], "."); dot >= 0 {
+		return funcName[:lastSlash+1+dot]
+	}
+	return funcName
+}
@@ -0,0 +1,89 @@
+package logger_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+func TestParseVerbosity(t *testing.T) {
+	tests := []struct {
+		testName        string
+		verbosity       string
+		wantLevel       int
+		wantComponents  map[string]int
+		wantErrContains string
+	}{
+		{
+			testName:  "empty",
+			verbosity: "",
+			wantLevel: 0,
+		},
+		{
+			testName:  "level",
+			verbosity: "9",
+			wantLevel: 9,
+		},
+		{
+			testName:       "components",
+			verbosity:      "git=6,flux=3,providers=2",
+			wantLevel:      0,
+			wantComponents: map[string]int{"git": 6, "flux": 3, "providers": 2},
+		},
+		{
+			testName:       "level and components",
+			verbosity:      "2, git=6",
+			wantLevel:      2,
+			wantComponents: map[string]int{"git": 6},
+		},
+		{
+			testName:        "invalid level",
+			verbosity:       "git=high",
+			wantErrContains: "invalid verbosity git=high",
+		},
+		{
+			testName:        "negative level",
+			verbosity:       "-1",
+			wantErrContains: "invalid verbosity -1",
+		},
+		{
+			testName:        "empty component",
+			verbosity:       "=6",
+			wantErrContains: "component name is empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			level, components, err := logger.ParseVerbosity(tt.verbosity)
+			if tt.wantErrContains != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErrContains)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(level).To(Equal(tt.wantLevel))
+			g.Expect(components).To(Equal(tt.wantComponents))
+		})
+	}
+}
+
+func TestVComponentLevels(t *testing.T) {
+	g := NewWithT(t)
+	stdout := captureStdout(t)
+	logger.SetZapForTest(t, logger.ZapOpts{
+		Level:           0,
+		OutputFilePath:  filepath.Join(t.TempDir(), "test.log"),
+		ComponentLevels: map[string]int{"logger_test": 6, "git": 2},
+	})
+
+	logger.V(6).Info("From the logger_test component")
+	logger.V(7).Info("Above the logger_test component level")
+	logger.Component("git").V(2).Info("From the git component")
+	logger.Component("flux").V(2).Info("From the flux component")
+
+	g.Expect(string(stdout())).To(Equal("From the logger_test component\nFrom the git component\n"))
+}
@@ -11,11 +11,22 @@ import (
 	"go.uber.org/zap/zapcore"
 )
 
+const (
+	// TextFormat is the human-oriented console output, the default.
+	TextFormat = "text"
+	// JSONFormat writes one JSON object per log entry to the console, for CI systems and log aggregators.
+	JSONFormat = "json"
+)
+
 // ZapOpts represents a set of arguments for initializing the zap logger.
 type ZapOpts struct {
 	Level          int      // indicates the log level of the logger.
 	OutputFilePath string   // if specified, the logger will output to file at this path.
 	WithNames      []string // specified name elements are added to the logger's name.
+	Format         string   // console output format, TextFormat or JSONFormat. Defaults to TextFormat.
+	// ComponentLevels overrides the log level of the console output for the packages under the named components,
+	// such as git or flux. See V.
+	ComponentLevels map[string]int
 }
 
 // InitZap creates a zap logger with the provided verbosity level
@@ -24,12 +35,12 @@ type ZapOpts struct {
 // The package logger can only be init once, so subsequent calls to this method
 // won't have any effect.
 func InitZap(args ZapOpts) error {
-	logr, err := newZap(args)
+	loggers, err := newZapWithSink(args)
 	if err != nil {
 		return err
 	}
-	set(logr, args.OutputFilePath)
-	l.V(4).Info("Logger init completed", "vlevel", args.Level)
+	set(loggers, args.OutputFilePath, args.Format)
+	l.V(4).Info("Logger init completed", "vlevel", args.Level, "componentLevels", args.ComponentLevels)
 
 	return nil
 }
@@ -43,6 +54,25 @@ func VLevelEncoder(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
 func NullTimeEncoder(t time.Time, enc zapcore.PrimitiveArrayEncoder) {}
 
 func newZap(args ZapOpts) (logr.Logger, error) {
+	loggers, err := newZapWithSink(args)
+	return loggers.logger, err
+}
+
+// zapLoggers is a zap logger together with its file sink, which can be switched to a different file while the
+// logger is in use, and the loggers of the components with their own log level, which share its sinks.
+type zapLoggers struct {
+	logger     logr.Logger
+	sink       *switchableSink
+	console    *console
+	components map[string]logr.Logger
+}
+
+// newZapWithSink creates a zap logger and the loggers of its components.
+func newZapWithSink(args ZapOpts) (zapLoggers, error) {
+	if args.Format != "" && args.Format != TextFormat && args.Format != JSONFormat {
+		return zapLoggers{logger: logr.Discard()}, fmt.Errorf("creating zap logger: unsupported log format %s, must be %s or %s", args.Format, TextFormat, JSONFormat)
+	}
+
 	outputPaths := []string{}
 	if args.OutputFilePath != "" {
 		outputPaths = append(outputPaths, args.OutputFilePath)
@@ -52,6 +82,8 @@ func newZap(args ZapOpts) (logr.Logger, error) {
 		level:         args.Level,
 		encoderConfig: zap.NewDevelopmentEncoderConfig(),
 		outputPaths:   outputPaths,
+		format:        args.Format,
+		console:       newConsole(os.Stdout, isTerminal(os.Stdout)),
 	}
 
 	cfg.encoderConfig.EncodeLevel = nil
@@ -64,18 +96,34 @@ func newZap(args ZapOpts) (logr.Logger, error) {
 		cfg.encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 	}
 
-	zapLog, err := build(cfg)
+	zapLog, sink, err := build(cfg)
 	if err != nil {
-		return logr.Discard(), fmt.Errorf("creating zap logger: %v", err)
+		return zapLoggers{logger: logr.Discard()}, fmt.Errorf("creating zap logger: %v", err)
 	}
 
-	logr := zapr.NewLogger(zapLog)
+	loggers := zapLoggers{
+		logger:  withNames(zapr.NewLogger(zapLog), args.WithNames),
+		sink:    sink,
+		console: cfg.console,
+	}
 
-	for _, name := range args.WithNames {
-		logr = logr.WithName(name)
+	if len(args.ComponentLevels) > 0 {
+		loggers.components = make(map[string]logr.Logger, len(args.ComponentLevels))
+		for name, level := range args.ComponentLevels {
+			componentCfg := cfg
+			componentCfg.level = level
+			loggers.components[name] = withNames(zapr.NewLogger(zap.New(componentCfg.buildCore(sink))), args.WithNames)
+		}
 	}
 
-	return logr, err
+	return loggers, nil
+}
+
+func withNames(logger logr.Logger, names []string) logr.Logger {
+	for _, name := range names {
+		logger = logger.WithName(name)
+	}
+	return logger
 }
 
 // newAtomicLevelAt returns an appropriate zap.AtomicLevel given an integer representing the log level.
@@ -83,15 +131,16 @@ func newAtomicLevelAt(level int) zap.AtomicLevel {
 	return zap.NewAtomicLevelAt(zapcore.Level(-1 * level))
 }
 
-// build constructs a logger and returns a logger.
-func build(cfg config) (*zap.Logger, error) {
-	sink, err := cfg.openSinks()
+// build constructs a logger and returns it together with its file sink.
+func build(cfg config) (*zap.Logger, *switchableSink, error) {
+	ws, err := cfg.openSinks()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
+	sink := newSwitchableSink(ws)
 	logger := zap.New(cfg.buildCore(sink))
-	return logger, nil
+	return logger, sink, nil
 }
 
 func (cfg config) openSinks() (zapcore.WriteSyncer, error) {
@@ -104,14 +153,27 @@ type config struct {
 	outputPaths   []string
 	level         int
 	encoderConfig zapcore.EncoderConfig
+	format        string
+	console       *console
+}
+
+// jsonConsoleEncoderConfig always includes the time and the v-level, so every entry can be parsed on its own.
+func jsonConsoleEncoderConfig() zapcore.EncoderConfig {
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.EncodeLevel = VLevelEncoder
+	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	return encoderConfig
 }
 
 func (cfg config) buildCore(sink zapcore.WriteSyncer) zapcore.Core {
 	fileEncoder := zapcore.NewJSONEncoder(cfg.encoderConfig)
 	consoleEncoder := zapcore.NewConsoleEncoder(cfg.encoderConfig)
+	if cfg.format == JSONFormat {
+		consoleEncoder = zapcore.NewJSONEncoder(jsonConsoleEncoderConfig())
+	}
 
 	return zapcore.NewTee(
-		zapcore.NewCore(consoleEncoder, zapcore.AddSync(os.Stdout), newAtomicLevelAt(cfg.level)),
-		zapcore.NewCore(fileEncoder, zapcore.AddSync(sink), newAtomicLevelAt(9)),
+		newRedactingCore(zapcore.NewCore(consoleEncoder, zapcore.AddSync(cfg.console), newAtomicLevelAt(cfg.level))),
+		newRedactingCore(zapcore.NewCore(fileEncoder, zapcore.AddSync(sink), newAtomicLevelAt(9))),
 	)
 }
@@ -1,8 +1,10 @@
 package logger_test
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
@@ -98,3 +100,71 @@ func TestZapWithInvalidOutputPaths(t *testing.T) {
 		})
 	}
 }
+
+func TestNewZapJSONFormat(t *testing.T) {
+	g := NewWithT(t)
+	stdout := captureStdout(t)
+	logger.SetZapForTest(t, logger.ZapOpts{
+		Level:          0,
+		OutputFilePath: filepath.Join(t.TempDir(), "test.log"),
+		Format:         logger.JSONFormat,
+	})
+
+	logger.MarkSuccess("Cluster created", "cluster", "mgmt")
+	logger.V(4).Info("debug log")
+
+	var entry map[string]interface{}
+	g.Expect(json.Unmarshal(stdout(), &entry)).To(Succeed())
+	g.Expect(entry).To(HaveKeyWithValue("msg", "Cluster created"))
+	g.Expect(entry).To(HaveKeyWithValue("status", "success"))
+	g.Expect(entry).To(HaveKeyWithValue("cluster", "mgmt"))
+	g.Expect(entry).To(HaveKeyWithValue("level", "V0"))
+	g.Expect(entry).To(HaveKey("ts"))
+}
+
+func TestNewZapTextFormatMarks(t *testing.T) {
+	g := NewWithT(t)
+	stdout := captureStdout(t)
+	logger.SetZapForTest(t, logger.ZapOpts{
+		Level:          0,
+		OutputFilePath: filepath.Join(t.TempDir(), "test.log"),
+	})
+
+	logger.MarkSuccess("Cluster created")
+
+	g.Expect(string(stdout())).To(Equal("🎉 Cluster created\n"))
+}
+
+func TestZapWithInvalidFormat(t *testing.T) {
+	g := NewWithT(t)
+	l, err := logger.NewZap(logger.ZapOpts{
+		Level:  0,
+		Format: "yaml",
+	})
+
+	g.Expect(l).To(Equal(logr.Discard()))
+	g.Expect(err).To(MatchError(ContainSubstring("unsupported log format yaml")))
+}
+
+// captureStdout redirects os.Stdout for the duration of the test, the logger must be created afterwards.
+// The returned function closes the redirection and returns what was written.
+func captureStdout(t *testing.T) func() []byte {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	prev := os.Stdout
+	os.Stdout = w
+	t.Cleanup(func() { os.Stdout = prev })
+
+	return func() []byte {
+		w.Close()
+		os.Stdout = prev
+		out, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return out
+	}
+}
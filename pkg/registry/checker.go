@@ -0,0 +1,35 @@
+package registry
+
+import (
+	"context"
+	"crypto/x509"
+)
+
+// ImageChecker checks images can be pulled from their registries.
+type ImageChecker struct {
+	cache           *Cache
+	credentialStore *CredentialStore
+	certificates    *x509.CertPool
+	insecure        bool
+}
+
+// NewImageChecker creates an image checker authenticating with the credential store, and trusting the certificates
+// for the registries.
+func NewImageChecker(cache *Cache, credentialStore *CredentialStore, certificates *x509.CertPool, insecure bool) *ImageChecker {
+	return &ImageChecker{
+		cache:           cache,
+		credentialStore: credentialStore,
+		certificates:    certificates,
+		insecure:        insecure,
+	}
+}
+
+// CheckImage returns an error if the image doesn't exist in its registry or the registry can't be reached.
+func (c *ImageChecker) CheckImage(ctx context.Context, image string) error {
+	artifact := NewArtifactFromURI(image)
+	sc, err := c.cache.Get(NewStorageContext(artifact.Registry, c.credentialStore, c.certificates, c.insecure))
+	if err != nil {
+		return err
+	}
+	return ImageExists(ctx, sc, artifact)
+}
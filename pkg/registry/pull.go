@@ -34,3 +34,20 @@ func PullBytes(ctx context.Context, sc StorageClient, artifact Artifact) (data [
 	}
 	return data, err
 }
+
+// ImageExists checks the image of the artifact can be resolved in the registry, without pulling it.
+func ImageExists(ctx context.Context, sc StorageClient, artifact Artifact) error {
+	srcStorage, err := sc.GetStorage(ctx, artifact)
+	if err != nil {
+		return fmt.Errorf("repository source: %v", err)
+	}
+
+	reference := artifact.Tag
+	if artifact.Digest != "" {
+		reference = artifact.Digest
+	}
+	if _, err = sc.Resolve(ctx, srcStorage, reference); err != nil {
+		return fmt.Errorf("resolving image %s: %v", artifact.VersionedImage(), err)
+	}
+	return nil
+}
@@ -112,3 +112,42 @@ func TestPullGetStorageFail(t *testing.T) {
 	assert.Nil(t, result)
 	assert.EqualError(t, err, "repository source: oops")
 }
+
+func TestImageExists(t *testing.T) {
+	srcClient := mocks.NewMockStorageClient(gomock.NewController(t))
+
+	mockSrcRepo := *mocks.NewMockRepository(gomock.NewController(t))
+	srcClient.EXPECT().GetStorage(ctx, srcArtifact).Return(&mockSrcRepo, nil)
+	srcClient.EXPECT().Resolve(ctx, &mockSrcRepo, srcArtifact.Digest).Return(ocispec.Descriptor{}, nil)
+
+	assert.NoError(t, registry.ImageExists(ctx, srcClient, srcArtifact))
+}
+
+func TestImageExistsTag(t *testing.T) {
+	srcClient := mocks.NewMockStorageClient(gomock.NewController(t))
+	artifact := registry.NewArtifact("1.2.3.4:443", "fluxcd/source-controller", "v0.31.0", "")
+
+	mockSrcRepo := *mocks.NewMockRepository(gomock.NewController(t))
+	srcClient.EXPECT().GetStorage(ctx, artifact).Return(&mockSrcRepo, nil)
+	srcClient.EXPECT().Resolve(ctx, &mockSrcRepo, "v0.31.0").Return(ocispec.Descriptor{}, nil)
+
+	assert.NoError(t, registry.ImageExists(ctx, srcClient, artifact))
+}
+
+func TestImageExistsResolveFail(t *testing.T) {
+	srcClient := mocks.NewMockStorageClient(gomock.NewController(t))
+	artifact := registry.NewArtifact("1.2.3.4:443", "fluxcd/source-controller", "v0.31.0", "")
+
+	mockSrcRepo := *mocks.NewMockRepository(gomock.NewController(t))
+	srcClient.EXPECT().GetStorage(ctx, artifact).Return(&mockSrcRepo, nil)
+	srcClient.EXPECT().Resolve(ctx, &mockSrcRepo, "v0.31.0").Return(ocispec.Descriptor{}, fmt.Errorf("not found"))
+
+	assert.EqualError(t, registry.ImageExists(ctx, srcClient, artifact), "resolving image 1.2.3.4:443/fluxcd/source-controller:v0.31.0: not found")
+}
+
+func TestImageExistsGetStorageFail(t *testing.T) {
+	srcClient := mocks.NewMockStorageClient(gomock.NewController(t))
+	srcClient.EXPECT().GetStorage(ctx, srcArtifact).Return(nil, fmt.Errorf("oops"))
+
+	assert.EqualError(t, registry.ImageExists(ctx, srcClient, srcArtifact), "repository source: oops")
+}
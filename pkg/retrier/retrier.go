@@ -2,6 +2,7 @@ package retrier
 
 import (
 	"math"
+	"math/rand"
 	"time"
 
 	"github.com/aws/eks-anywhere/pkg/logger"
@@ -48,6 +49,15 @@ func WithMaxRetries(maxRetries int, backOffPeriod time.Duration) RetrierOpt {
 	}
 }
 
+// WithExponentialBackoff sets a retry policy that always retries, doubling the wait time after each retry from
+// initial up to max. A random jitter of up to half the wait time is subtracted, so concurrent callers failing
+// at the same time don't retry in lockstep. The retrier timeout bounds the total time spent retrying.
+func WithExponentialBackoff(initial, max time.Duration) RetrierOpt {
+	return func(r *Retrier) {
+		r.retryPolicy = exponentialBackoffPolicy(initial, max)
+	}
+}
+
 func WithBackoffFactor(factor float32) RetrierOpt {
 	return func(r *Retrier) {
 		r.backoffFactor = &factor
@@ -125,3 +135,20 @@ func maxRetriesPolicy(maxRetries int, backOffPeriod time.Duration) RetryPolicy {
 		return totalRetries < maxRetries, backOffPeriod
 	}
 }
+
+func exponentialBackoffPolicy(initial, max time.Duration) RetryPolicy {
+	return func(totalRetries int, _ error) (retry bool, wait time.Duration) {
+		wait = max
+		// Past 2^30 times the initial wait, the max is always reached and shifting further could overflow
+		if totalRetries <= 30 {
+			if backoff := initial << (totalRetries - 1); backoff > 0 && backoff < max {
+				wait = backoff
+			}
+		}
+
+		if half := int64(wait / 2); half > 0 {
+			wait -= time.Duration(rand.Int63n(half + 1))
+		}
+		return true, wait
+	}
+}
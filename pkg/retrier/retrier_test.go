@@ -185,3 +185,54 @@ func TestRetrierWithNilReceiver(t *testing.T) {
 		t.Errorf("Retrier didn't correctly handle nil receiver")
 	}
 }
+
+func TestWithExponentialBackoffWaits(t *testing.T) {
+	initial := 10 * time.Millisecond
+	max := 40 * time.Millisecond
+	var waits []time.Duration
+	r := retrier.New(time.Minute, retrier.WithExponentialBackoff(initial, max))
+
+	retries := 0
+	last := time.Now()
+	err := r.Retry(func() error {
+		now := time.Now()
+		if retries > 0 {
+			waits = append(waits, now.Sub(last))
+		}
+		last = now
+		retries++
+		if retries == 5 {
+			return nil
+		}
+		return errors.New("")
+	})
+	if err != nil {
+		t.Fatalf("Retrier.Retry() error = %v, want nil", err)
+	}
+
+	wantMaxWaits := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond, 40 * time.Millisecond}
+	for i, wait := range waits {
+		if wait < wantMaxWaits[i]/2 {
+			t.Errorf("Wait %d = %v, want at least %v", i, wait, wantMaxWaits[i]/2)
+		}
+	}
+}
+
+func TestWithExponentialBackoffTimeout(t *testing.T) {
+	r := retrier.New(50*time.Millisecond, retrier.WithExponentialBackoff(time.Millisecond, 10*time.Millisecond))
+	start := time.Now()
+	retries := 0
+	err := r.Retry(func() error {
+		retries++
+		return errors.New("error")
+	})
+	if err == nil {
+		t.Fatal("Retrier.Retry() error = nil, want not nil")
+	}
+	if retries < 2 {
+		t.Errorf("Retries = %d, want at least 2", retries)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Retry took %v, want close to the 50ms timeout", elapsed)
+	}
+}
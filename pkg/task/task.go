@@ -150,9 +150,13 @@ func (tr *taskRunner) RunTask(ctx context.Context, commandContext *CommandContex
 			task = nextTask
 			continue
 		}
-		logger.V(4).Info("Task start", "task_name", task.Name())
+		name := task.Name()
+		logger.V(4).Info("Task start", "task_name", name)
 		commandContext.Profiler.SetStartTask(task.Name())
+		progress := logger.StartTask(name)
+		previousError := commandContext.OriginalError
 		nextTask := task.Run(ctx, commandContext)
+		logger.EndTask(progress, taskError(previousError, commandContext.OriginalError))
 		commandContext.Profiler.MarkDoneTask(task.Name())
 		commandContext.Profiler.logProfileSummary(task.Name())
 		if commandContext.OriginalError == nil {
@@ -168,6 +172,14 @@ func (tr *taskRunner) RunTask(ctx context.Context, commandContext *CommandContex
 	return commandContext.OriginalError
 }
 
+// taskError returns the error the task failed with, the tasks run after a failure don't fail themselves.
+func taskError(previousError, err error) error {
+	if previousError != nil {
+		return nil
+	}
+	return err
+}
+
 func taskRunnerFinalBlock(startTime time.Time) {
 	logger.V(4).Info("Tasks completed", "duration", time.Since(startTime))
 }
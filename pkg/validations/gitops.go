@@ -25,6 +25,11 @@ func ValidateAuthenticationForGitProvider(clusterSpec *cluster.Spec, cliConfig *
 		return fmt.Errorf("private key file does not exist at %s or is empty", cliConfig.GitPrivateKeyFile)
 	}
 
+	// The known hosts pinned in the FluxConfig are used instead of the known hosts file
+	if clusterSpec.FluxConfig.Spec.Git.KnownHosts != "" {
+		return nil
+	}
+
 	if cliConfig.GitKnownHostsFile == "" {
 		return errors.New("provide a path to an SSH Known Hosts file which contains a valid entry associate with the given private key via the EKSA_GIT_SSH_KNOWN_HOSTS environment variable")
 	}
@@ -56,6 +56,19 @@ func TestValidateGitOpsGitProviderNoAuthForWorkloadCluster(t *testing.T) {
 				GitKnownHostsFile:   emptyVar,
 			},
 		},
+		{
+			name:    "Empty known host with pinned known hosts",
+			wantErr: nil,
+			git: &v1alpha1.GitProviderConfig{
+				RepositoryUrl: "testRepo",
+				KnownHosts:    "github.com ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl",
+			},
+			cliConfig: &config.CliConfig{
+				GitPrivateKeyFile:   "testdata/git_nonempty_private_key",
+				GitSshKeyPassphrase: testEnvVar,
+				GitKnownHostsFile:   emptyVar,
+			},
+		},
 		{
 			name:      "Empty git config",
 			wantErr:   nil,
@@ -166,6 +166,99 @@ func ValidateGitOpsImmutableFields(ctx context.Context, k validations.KubectlCli
 			if prevGitOps.Spec.Github.Personal != clusterSpec.FluxConfig.Spec.Github.Personal {
 				return errors.New("fluxConfig spec.github.personal is immutable")
 			}
+
+			if prevGitOps.Spec.Github.Hostname != clusterSpec.FluxConfig.Spec.Github.Hostname {
+				return errors.New("fluxConfig spec.github.hostname is immutable")
+			}
+		}
+
+		if prevGitOps.Spec.Gitlab != nil {
+			newGitlab := clusterSpec.FluxConfig.Spec.Gitlab
+			if newGitlab == nil {
+				return errors.New("fluxConfig spec.gitlab is immutable")
+			}
+
+			if prevGitOps.Spec.Gitlab.Repository != newGitlab.Repository {
+				return errors.New("fluxConfig spec.gitlab.repository is immutable")
+			}
+
+			if prevGitOps.Spec.Gitlab.Owner != newGitlab.Owner {
+				return errors.New("fluxConfig spec.gitlab.owner is immutable")
+			}
+
+			if prevGitOps.Spec.Gitlab.Hostname != newGitlab.Hostname {
+				return errors.New("fluxConfig spec.gitlab.hostname is immutable")
+			}
+
+			if prevGitOps.Spec.Gitlab.Personal != newGitlab.Personal {
+				return errors.New("fluxConfig spec.gitlab.personal is immutable")
+			}
+		}
+
+		if prevGitOps.Spec.BitbucketServer != nil {
+			newBitbucket := clusterSpec.FluxConfig.Spec.BitbucketServer
+			if newBitbucket == nil {
+				return errors.New("fluxConfig spec.bitbucketServer is immutable")
+			}
+
+			if prevGitOps.Spec.BitbucketServer.Repository != newBitbucket.Repository {
+				return errors.New("fluxConfig spec.bitbucketServer.repository is immutable")
+			}
+
+			if prevGitOps.Spec.BitbucketServer.Owner != newBitbucket.Owner {
+				return errors.New("fluxConfig spec.bitbucketServer.owner is immutable")
+			}
+
+			if prevGitOps.Spec.BitbucketServer.Hostname != newBitbucket.Hostname {
+				return errors.New("fluxConfig spec.bitbucketServer.hostname is immutable")
+			}
+
+			if prevGitOps.Spec.BitbucketServer.Personal != newBitbucket.Personal {
+				return errors.New("fluxConfig spec.bitbucketServer.personal is immutable")
+			}
+		}
+
+		if prevGitOps.Spec.Gitea != nil {
+			newGitea := clusterSpec.FluxConfig.Spec.Gitea
+			if newGitea == nil {
+				return errors.New("fluxConfig spec.gitea is immutable")
+			}
+
+			if prevGitOps.Spec.Gitea.Repository != newGitea.Repository {
+				return errors.New("fluxConfig spec.gitea.repository is immutable")
+			}
+
+			if prevGitOps.Spec.Gitea.Owner != newGitea.Owner {
+				return errors.New("fluxConfig spec.gitea.owner is immutable")
+			}
+
+			if prevGitOps.Spec.Gitea.Hostname != newGitea.Hostname {
+				return errors.New("fluxConfig spec.gitea.hostname is immutable")
+			}
+
+			if prevGitOps.Spec.Gitea.Personal != newGitea.Personal {
+				return errors.New("fluxConfig spec.gitea.personal is immutable")
+			}
+		}
+
+		if prevGitOps.Spec.AzureDevOps != nil {
+			if !prevGitOps.Spec.AzureDevOps.Equal(clusterSpec.FluxConfig.Spec.AzureDevOps) {
+				return errors.New("fluxConfig spec.azureDevOps is immutable")
+			}
+		}
+
+		if prevGitOps.Spec.CodeCommit != nil {
+			if !prevGitOps.Spec.CodeCommit.Equal(clusterSpec.FluxConfig.Spec.CodeCommit) {
+				return errors.New("fluxConfig spec.codeCommit is immutable")
+			}
+		}
+
+		if !prevGitOps.Spec.OCIRepository.Equal(clusterSpec.FluxConfig.Spec.OCIRepository) {
+			return errors.New("fluxConfig spec.ociRepository is immutable")
+		}
+
+		if !prevGitOps.Spec.Bucket.Equal(clusterSpec.FluxConfig.Spec.Bucket) {
+			return errors.New("fluxConfig spec.bucket is immutable")
 		}
 
 		if prevGitOps.Spec.Branch != clusterSpec.FluxConfig.Spec.Branch {
@@ -176,6 +269,14 @@ func ValidateGitOpsImmutableFields(ctx context.Context, k validations.KubectlCli
 			return errors.New("fluxConfig spec.clusterConfigPath is immutable")
 		}
 
+		if prevGitOps.Spec.EksaSystemDir != clusterSpec.FluxConfig.Spec.EksaSystemDir {
+			return errors.New("fluxConfig spec.eksaSystemDir is immutable")
+		}
+
+		if prevGitOps.Spec.FluxSystemDir != clusterSpec.FluxConfig.Spec.FluxSystemDir {
+			return errors.New("fluxConfig spec.fluxSystemDir is immutable")
+		}
+
 		if prevGitOps.Spec.SystemNamespace != clusterSpec.FluxConfig.Spec.SystemNamespace {
 			return errors.New("fluxConfig spec.systemNamespace is immutable")
 		}
@@ -180,6 +180,224 @@ func TestValidateGitOpsImmutableFieldsFluxConfig(t *testing.T) {
 			},
 			wantErr: "fluxConfig spec.github.personal is immutable",
 		},
+		{
+			name: "github hostname diff",
+			new: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					Github: &v1alpha1.GithubProviderConfig{
+						Hostname: "github.example.com",
+					},
+				},
+			},
+			old: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					Github: &v1alpha1.GithubProviderConfig{},
+				},
+			},
+			wantErr: "fluxConfig spec.github.hostname is immutable",
+		},
+		{
+			name: "gitlab hostname diff",
+			new: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					Gitlab: &v1alpha1.GitlabProviderConfig{
+						Hostname: "a",
+					},
+				},
+			},
+			old: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					Gitlab: &v1alpha1.GitlabProviderConfig{
+						Hostname: "b",
+					},
+				},
+			},
+			wantErr: "fluxConfig spec.gitlab.hostname is immutable",
+		},
+		{
+			name: "gitlab provider removed",
+			new: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					Git: &v1alpha1.GitProviderConfig{},
+				},
+			},
+			old: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					Gitlab: &v1alpha1.GitlabProviderConfig{},
+				},
+			},
+			wantErr: "fluxConfig spec.gitlab is immutable",
+		},
+		{
+			name: "bitbucket server owner diff",
+			new: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					BitbucketServer: &v1alpha1.BitbucketServerProviderConfig{
+						Owner: "a",
+					},
+				},
+			},
+			old: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					BitbucketServer: &v1alpha1.BitbucketServerProviderConfig{
+						Owner: "b",
+					},
+				},
+			},
+			wantErr: "fluxConfig spec.bitbucketServer.owner is immutable",
+		},
+		{
+			name: "azure devops project diff",
+			new: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					AzureDevOps: &v1alpha1.AzureDevOpsProviderConfig{
+						Project: "a",
+					},
+				},
+			},
+			old: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					AzureDevOps: &v1alpha1.AzureDevOpsProviderConfig{
+						Project: "b",
+					},
+				},
+			},
+			wantErr: "fluxConfig spec.azureDevOps is immutable",
+		},
+		{
+			name: "gitea hostname diff",
+			new: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					Gitea: &v1alpha1.GiteaProviderConfig{
+						Hostname: "a",
+					},
+				},
+			},
+			old: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					Gitea: &v1alpha1.GiteaProviderConfig{
+						Hostname: "b",
+					},
+				},
+			},
+			wantErr: "fluxConfig spec.gitea.hostname is immutable",
+		},
+		{
+			name: "codecommit region diff",
+			new: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					CodeCommit: &v1alpha1.CodeCommitProviderConfig{
+						Region: "us-west-2",
+					},
+				},
+			},
+			old: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					CodeCommit: &v1alpha1.CodeCommitProviderConfig{
+						Region: "us-east-1",
+					},
+				},
+			},
+			wantErr: "fluxConfig spec.codeCommit is immutable",
+		},
+		{
+			name: "ociRepository diff",
+			new: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					OCIRepository: &v1alpha1.OCIRepositoryConfig{
+						Url: "oci://registry.local/eksa/fleet",
+						Tag: "a",
+					},
+				},
+			},
+			old: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					OCIRepository: &v1alpha1.OCIRepositoryConfig{
+						Url: "oci://registry.local/eksa/fleet",
+						Tag: "b",
+					},
+				},
+			},
+			wantErr: "fluxConfig spec.ociRepository is immutable",
+		},
+		{
+			name: "ociRepository added",
+			new: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					OCIRepository: &v1alpha1.OCIRepositoryConfig{
+						Url: "oci://registry.local/eksa/fleet",
+					},
+				},
+			},
+			old: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{},
+			},
+			wantErr: "fluxConfig spec.ociRepository is immutable",
+		},
+		{
+			name: "ociRepository removed",
+			new: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{},
+			},
+			old: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					OCIRepository: &v1alpha1.OCIRepositoryConfig{
+						Url: "oci://registry.local/eksa/fleet",
+					},
+				},
+			},
+			wantErr: "fluxConfig spec.ociRepository is immutable",
+		},
+		{
+			name: "bucket diff",
+			new: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					Bucket: &v1alpha1.BucketConfig{
+						BucketName: "a",
+						Endpoint:   "minio.local:9000",
+					},
+				},
+			},
+			old: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					Bucket: &v1alpha1.BucketConfig{
+						BucketName: "b",
+						Endpoint:   "minio.local:9000",
+					},
+				},
+			},
+			wantErr: "fluxConfig spec.bucket is immutable",
+		},
+		{
+			name: "bucket added",
+			new: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					Bucket: &v1alpha1.BucketConfig{
+						BucketName: "a",
+						Endpoint:   "minio.local:9000",
+					},
+				},
+			},
+			old: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{},
+			},
+			wantErr: "fluxConfig spec.bucket is immutable",
+		},
+		{
+			name: "bucket removed",
+			new: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{},
+			},
+			old: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					Bucket: &v1alpha1.BucketConfig{
+						BucketName: "a",
+						Endpoint:   "minio.local:9000",
+					},
+				},
+			},
+			wantErr: "fluxConfig spec.bucket is immutable",
+		},
 		{
 			name: "branch diff",
 			new: &v1alpha1.FluxConfig{
@@ -208,6 +426,34 @@ func TestValidateGitOpsImmutableFieldsFluxConfig(t *testing.T) {
 			},
 			wantErr: "fluxConfig spec.clusterConfigPath is immutable",
 		},
+		{
+			name: "eksaSystemDir diff",
+			new: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					EksaSystemDir: "a",
+				},
+			},
+			old: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					EksaSystemDir: "b",
+				},
+			},
+			wantErr: "fluxConfig spec.eksaSystemDir is immutable",
+		},
+		{
+			name: "fluxSystemDir diff",
+			new: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					FluxSystemDir: "a",
+				},
+			},
+			old: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					FluxSystemDir: "b",
+				},
+			},
+			wantErr: "fluxConfig spec.fluxSystemDir is immutable",
+		},
 		{
 			name: "systemNamespace diff",
 			new: &v1alpha1.FluxConfig{
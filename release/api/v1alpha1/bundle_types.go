@@ -231,6 +231,10 @@ type FluxBundle struct {
 	KustomizeController    Image  `json:"kustomizeController"`
 	HelmController         Image  `json:"helmController"`
 	NotificationController Image  `json:"notificationController"`
+
+	// Image automation controllers, installed only when image automation is enabled in the FluxConfig
+	ImageReflectorController  Image `json:"imageReflectorController,omitempty"`
+	ImageAutomationController Image `json:"imageAutomationController,omitempty"`
 }
 
 type PackageBundle struct {
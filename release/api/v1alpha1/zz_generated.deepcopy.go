@@ -415,6 +415,8 @@ func (in *FluxBundle) DeepCopyInto(out *FluxBundle) {
 	in.KustomizeController.DeepCopyInto(&out.KustomizeController)
 	in.HelmController.DeepCopyInto(&out.HelmController)
 	in.NotificationController.DeepCopyInto(&out.NotificationController)
+	in.ImageReflectorController.DeepCopyInto(&out.ImageReflectorController)
+	in.ImageAutomationController.DeepCopyInto(&out.ImageAutomationController)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxBundle.